@@ -8,6 +8,7 @@ import (
 
 	"github.com/onflow/flow-go/engine/access/subscription"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/events"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -37,6 +38,16 @@ type API interface {
 	GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.TransactionBody, error)
 	GetSystemTransactionResult(ctx context.Context, blockID flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) (*TransactionResult, error)
 
+	// GetTransactionsByAccount returns a page of transactions the account participated in as proposer, payer
+	// or authorizer, within the height range [startHeight, endHeight]. Results are ordered by height and
+	// transaction index. If cursor is not nil, results start at the cursor position. Requires the account
+	// transactions index to be enabled.
+	GetTransactionsByAccount(ctx context.Context, address flow.Address, startHeight uint64, endHeight uint64, limit uint32, cursor *flow.AccountTransactionCursor) (*flow.AccountTransactionsPage, error)
+
+	// GetTransactionTimeline returns the lifecycle timeline of a transaction submitted to this node, from its
+	// receipt through forwarding, collection, block inclusion and execution, to sealing.
+	GetTransactionTimeline(ctx context.Context, id flow.Identifier) (*flow.TransactionTimeline, error)
+
 	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtLatestBlock(ctx context.Context, address flow.Address) (*flow.Account, error)
 	GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error)
@@ -49,13 +60,37 @@ type API interface {
 	GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Address) ([]flow.AccountPublicKey, error)
 	GetAccountKeysAtBlockHeight(ctx context.Context, address flow.Address, height uint64) ([]flow.AccountPublicKey, error)
 
+	// GetAccountStorageAtLatestBlock returns a page of the values stored in the account at the latest sealed block,
+	// inspected using the locally indexed registers. If limit is 0, the maximum page size is used. If cursor is not
+	// nil, results start at the cursor position.
+	GetAccountStorageAtLatestBlock(ctx context.Context, address flow.Address, limit uint32, cursor *flow.AccountStorageCursor) (*flow.AccountStorage, error)
+	// GetAccountStorageAtBlockHeight returns a page of the values stored in the account at the given block height,
+	// inspected using the locally indexed registers. If limit is 0, the maximum page size is used. If cursor is not
+	// nil, results start at the cursor position.
+	GetAccountStorageAtBlockHeight(ctx context.Context, address flow.Address, height uint64, limit uint32, cursor *flow.AccountStorageCursor) (*flow.AccountStorage, error)
+
 	ExecuteScriptAtLatestBlock(ctx context.Context, script []byte, arguments [][]byte) ([]byte, error)
 	ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error)
 	ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments [][]byte) ([]byte, error)
 
+	// ExecuteScriptAtBlockHeightWithOverrides executes the script at the given block height, with the state
+	// overrides applied on top of the execution state of the block. The overrides are never persisted, and the
+	// result reports which overrides were applied. Requires the local execution state index.
+	ExecuteScriptAtBlockHeightWithOverrides(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte, overrides *flow.StateOverrides) (*flow.ScriptResultWithOverrides, error)
+
+	// EstimateTransaction executes the transaction against the latest sealed state without verifying its
+	// signatures and sequence number, and returns the computation, memory, events and fees it would use.
+	// State changes are discarded. Requires the local execution state index.
+	EstimateTransaction(ctx context.Context, tx *flow.TransactionBody) (*flow.TransactionEstimate, error)
+
 	GetEventsForHeightRange(ctx context.Context, eventType string, startHeight, endHeight uint64, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error)
 	GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error)
 
+	// GetEventsByQuery returns the events of all sealed blocks within the height range [startHeight, endHeight]
+	// that match the query, which may combine several event types, contracts and predicates on event fields.
+	// Requires the local event index to be enabled.
+	GetEventsByQuery(ctx context.Context, query *events.Query, startHeight, endHeight uint64, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error)
+
 	GetLatestProtocolStateSnapshot(ctx context.Context) ([]byte, error)
 	GetProtocolStateSnapshotByBlockID(ctx context.Context, blockID flow.Identifier) ([]byte, error)
 	GetProtocolStateSnapshotByHeight(ctx context.Context, blockHeight uint64) ([]byte, error)
@@ -63,6 +98,21 @@ type API interface {
 	GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error)
 	GetExecutionResultByID(ctx context.Context, id flow.Identifier) (*flow.ExecutionResult, error)
 
+	// GetRegistersWithProofAtBlockID returns the values of the registers at the end of the given sealed block,
+	// together with a proof of the values against the final state commitment of the sealed execution result.
+	// The values can be verified with the registerproof package.
+	GetRegistersWithProofAtBlockID(ctx context.Context, blockID flow.Identifier, registerIDs flow.RegisterIDs) (*flow.RegistersProof, error)
+
+	// GetRegisterHistory returns a page of the values written to the register within the height range
+	// [startHeight, endHeight], from the most recent to the oldest value, using the locally indexed registers.
+	// If limit is 0, the maximum page size is used. If cursor is not nil, the page starts at the cursor position.
+	GetRegisterHistory(ctx context.Context, registerID flow.RegisterID, startHeight uint64, endHeight uint64, limit uint32, cursor *flow.RegisterHistoryCursor) (*flow.RegisterHistoryPage, error)
+	// GetAccountRegisterHistory returns a page of the values written to all registers of the account within the
+	// height range [startHeight, endHeight], grouped by register and ordered from the most recent to the oldest
+	// value, using the locally indexed registers.
+	// If limit is 0, the maximum page size is used. If cursor is not nil, the page starts at the cursor position.
+	GetAccountRegisterHistory(ctx context.Context, address flow.Address, startHeight uint64, endHeight uint64, limit uint32, cursor *flow.RegisterHistoryCursor) (*flow.RegisterHistoryPage, error)
+
 	// SubscribeBlocks
 
 	// SubscribeBlocksFromStartBlockID subscribes to the finalized or sealed blocks starting at the requested
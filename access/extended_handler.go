@@ -0,0 +1,277 @@
+package access
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/extended"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ExtendedHandler serves the ExtendedAccessAPI, which exposes flow-go specific access node
+// features that are not part of the Flow Access API.
+type ExtendedHandler struct {
+	extended.UnimplementedExtendedAccessAPIServer
+	api   API
+	chain flow.Chain
+}
+
+var _ extended.ExtendedAccessAPIServer = (*ExtendedHandler)(nil)
+
+// NewExtendedHandler returns a new ExtendedHandler backed by the given API.
+func NewExtendedHandler(api API, chain flow.Chain) *ExtendedHandler {
+	return &ExtendedHandler{
+		api:   api,
+		chain: chain,
+	}
+}
+
+// GetTransactionsByAccount returns a page of transactions the account participated in as proposer,
+// payer or authorizer within the requested height range.
+func (h *ExtendedHandler) GetTransactionsByAccount(
+	ctx context.Context,
+	req *extended.GetTransactionsByAccountRequest,
+) (*extended.GetTransactionsByAccountResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address: %v", err)
+	}
+
+	var cursor *flow.AccountTransactionCursor
+	if req.GetCursor() != "" {
+		c, err := flow.DecodeAccountTransactionCursor(req.GetCursor())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor: %v", err)
+		}
+		cursor = &c
+	}
+
+	page, err := h.api.GetTransactionsByAccount(ctx, address, req.GetStartHeight(), req.GetEndHeight(), req.GetLimit(), cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return AccountTransactionsPageToMessage(page), nil
+}
+
+// AccountTransactionsPageToMessage converts a page of the account-to-transaction index to a protobuf message.
+func AccountTransactionsPageToMessage(page *flow.AccountTransactionsPage) *extended.GetTransactionsByAccountResponse {
+	transactions := make([]*extended.AccountTransaction, len(page.Transactions))
+	for i, tx := range page.Transactions {
+		transactions[i] = &extended.AccountTransaction{
+			BlockHeight:      tx.BlockHeight,
+			TransactionIndex: tx.TransactionIndex,
+			TransactionId:    tx.TransactionID[:],
+			Roles:            tx.Roles.Strings(),
+		}
+	}
+
+	var nextCursor string
+	if page.NextCursor != nil {
+		nextCursor = page.NextCursor.Encode()
+	}
+
+	return &extended.GetTransactionsByAccountResponse{
+		Transactions: transactions,
+		NextCursor:   nextCursor,
+	}
+}
+
+// GetTransactionTimeline returns the lifecycle timeline of a transaction submitted to this node.
+func (h *ExtendedHandler) GetTransactionTimeline(
+	ctx context.Context,
+	req *extended.GetTransactionTimelineRequest,
+) (*extended.GetTransactionTimelineResponse, error) {
+	txID, err := convert.TransactionID(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction id: %v", err)
+	}
+
+	timeline, err := h.api.GetTransactionTimeline(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransactionTimelineToMessage(timeline), nil
+}
+
+// TransactionTimelineToMessage converts a transaction timeline to a protobuf message.
+func TransactionTimelineToMessage(timeline *flow.TransactionTimeline) *extended.GetTransactionTimelineResponse {
+	events := make([]*extended.TransactionTimelineEvent, len(timeline.Events))
+	for i, event := range timeline.Events {
+		events[i] = &extended.TransactionTimelineEvent{
+			Stage:      string(event.Stage),
+			Timestamp:  event.Timestamp.UnixNano(),
+			Attributes: event.Attributes,
+		}
+	}
+
+	return &extended.GetTransactionTimelineResponse{
+		TransactionId: timeline.TransactionID[:],
+		Events:        events,
+	}
+}
+
+// GetAccountStorage returns a page of the values stored in an account, inspected using the locally
+// indexed registers. The latest sealed block is used if the block height is 0.
+func (h *ExtendedHandler) GetAccountStorage(
+	ctx context.Context,
+	req *extended.GetAccountStorageRequest,
+) (*extended.GetAccountStorageResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address: %v", err)
+	}
+
+	var cursor *flow.AccountStorageCursor
+	if req.GetCursor() != "" {
+		c, err := flow.DecodeAccountStorageCursor(req.GetCursor())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor: %v", err)
+		}
+		cursor = &c
+	}
+
+	var accountStorage *flow.AccountStorage
+	if req.GetBlockHeight() == 0 {
+		accountStorage, err = h.api.GetAccountStorageAtLatestBlock(ctx, address, req.GetLimit(), cursor)
+	} else {
+		accountStorage, err = h.api.GetAccountStorageAtBlockHeight(ctx, address, req.GetBlockHeight(), req.GetLimit(), cursor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return AccountStorageToMessage(accountStorage), nil
+}
+
+// AccountStorageToMessage converts a page of account storage to a protobuf message.
+func AccountStorageToMessage(accountStorage *flow.AccountStorage) *extended.GetAccountStorageResponse {
+	domains := make([]*extended.AccountStorageDomain, len(accountStorage.Domains))
+	for i, domain := range accountStorage.Domains {
+		items := make([]*extended.AccountStorageItem, len(domain.Items))
+		for j, item := range domain.Items {
+			items[j] = &extended.AccountStorageItem{
+				Key:           item.Key,
+				Type:          item.Type,
+				Size:          item.Size,
+				SizeTruncated: item.SizeTruncated,
+			}
+		}
+		domains[i] = &extended.AccountStorageDomain{
+			Name:  domain.Name,
+			Items: items,
+		}
+	}
+
+	var nextCursor string
+	if accountStorage.NextCursor != nil {
+		nextCursor = accountStorage.NextCursor.Encode()
+	}
+
+	return &extended.GetAccountStorageResponse{
+		Address:         accountStorage.Address.Bytes(),
+		StorageUsed:     accountStorage.StorageUsed,
+		StorageCapacity: accountStorage.StorageCapacity,
+		Domains:         domains,
+		NextCursor:      nextCursor,
+	}
+}
+
+// GetRegistersWithProof returns the values of registers at the end of a sealed block, together with a
+// proof of the values against the state commitment of the sealed execution result.
+func (h *ExtendedHandler) GetRegistersWithProof(
+	ctx context.Context,
+	req *extended.GetRegistersWithProofRequest,
+) (*extended.GetRegistersWithProofResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block id: %v", err)
+	}
+
+	registerIDs, err := MessagesToRegisterIDs(req.GetRegisterIds())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid register ids: %v", err)
+	}
+
+	registersProof, err := h.api.GetRegistersWithProofAtBlockID(ctx, blockID, registerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return RegistersProofToMessage(registersProof), nil
+}
+
+// MessagesToRegisterIDs converts register ID messages to register IDs.
+// Expected errors during normal operations:
+//   - an error if the owner of a register is neither empty nor an address
+func MessagesToRegisterIDs(messages []*extended.RegisterID) (flow.RegisterIDs, error) {
+	registerIDs := make(flow.RegisterIDs, len(messages))
+	for i, m := range messages {
+		owner := m.GetOwner()
+		if len(owner) != 0 && len(owner) != flow.AddressLength {
+			return nil, fmt.Errorf("invalid owner length %d of register id %d", len(owner), i)
+		}
+		registerIDs[i] = flow.RegisterID{
+			Owner: string(owner),
+			Key:   m.GetKey(),
+		}
+	}
+	return registerIDs, nil
+}
+
+// RegistersProofToMessage converts proven register values to a protobuf message.
+func RegistersProofToMessage(registersProof *flow.RegistersProof) *extended.GetRegistersWithProofResponse {
+	registers := make([]*extended.Register, len(registersProof.Registers))
+	for i, register := range registersProof.Registers {
+		registers[i] = &extended.Register{
+			Id: &extended.RegisterID{
+				Owner: []byte(register.Key.Owner),
+				Key:   register.Key.Key,
+			},
+			Value: register.Value,
+		}
+	}
+
+	return &extended.GetRegistersWithProofResponse{
+		BlockId:         registersProof.BlockID[:],
+		StateCommitment: registersProof.StateCommitment[:],
+		Registers:       registers,
+		Proof:           registersProof.Proof,
+	}
+}
+
+// EstimateTransaction executes a transaction against the latest sealed state without committing it
+// and returns its computation, memory and fee estimates. Signatures are not required.
+func (h *ExtendedHandler) EstimateTransaction(
+	ctx context.Context,
+	req *extended.EstimateTransactionRequest,
+) (*extended.EstimateTransactionResponse, error) {
+	tx, err := convert.MessageToTransaction(req.GetTransaction(), h.chain)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %v", err)
+	}
+
+	estimate, err := h.api.EstimateTransaction(ctx, &tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return TransactionEstimateToMessage(estimate), nil
+}
+
+// TransactionEstimateToMessage converts a transaction estimate to a protobuf message.
+func TransactionEstimateToMessage(estimate *flow.TransactionEstimate) *extended.EstimateTransactionResponse {
+	return &extended.EstimateTransactionResponse{
+		ErrorMessage:           estimate.ErrorMessage,
+		ComputationUsed:        estimate.ComputationUsed,
+		ComputationIntensities: estimate.ComputationIntensities,
+		MemoryEstimate:         estimate.MemoryEstimate,
+		Events:                 convert.EventsToMessages(estimate.Events),
+		Fees:                   estimate.Fees,
+	}
+}
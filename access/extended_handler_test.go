@@ -0,0 +1,320 @@
+package access_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/extended"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestExtendedHandler_GetTransactionsByAccount(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	address := unittest.RandomAddressFixtureForChain(chain.ChainID())
+
+	t.Run("returns page", func(t *testing.T) {
+		api := accessmock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain)
+
+		cursor := &flow.AccountTransactionCursor{BlockHeight: 12, TransactionIndex: 3}
+		next := &flow.AccountTransactionCursor{BlockHeight: 15, TransactionIndex: 1}
+		txID := unittest.IdentifierFixture()
+
+		api.On("GetTransactionsByAccount", context.Background(), address, uint64(10), uint64(20), uint32(5), cursor).
+			Return(&flow.AccountTransactionsPage{
+				Transactions: []flow.AccountTransaction{{
+					Address:          address,
+					BlockHeight:      12,
+					TransactionIndex: 3,
+					TransactionID:    txID,
+					Roles:            flow.TransactionRolePayer | flow.TransactionRoleAuthorizer,
+				}},
+				NextCursor: next,
+			}, nil).
+			Once()
+
+		resp, err := handler.GetTransactionsByAccount(context.Background(), &extended.GetTransactionsByAccountRequest{
+			Address:     address.Bytes(),
+			StartHeight: 10,
+			EndHeight:   20,
+			Limit:       5,
+			Cursor:      cursor.Encode(),
+		})
+		require.NoError(t, err)
+		require.Len(t, resp.Transactions, 1)
+		require.Equal(t, uint64(12), resp.Transactions[0].BlockHeight)
+		require.Equal(t, uint32(3), resp.Transactions[0].TransactionIndex)
+		require.Equal(t, txID[:], resp.Transactions[0].TransactionId)
+		require.Equal(t, []string{"payer", "authorizer"}, resp.Transactions[0].Roles)
+		require.Equal(t, next.Encode(), resp.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		handler := access.NewExtendedHandler(accessmock.NewAPI(t), chain)
+
+		_, err := handler.GetTransactionsByAccount(context.Background(), &extended.GetTransactionsByAccountRequest{
+			Address: address.Bytes(),
+			Cursor:  "not a cursor",
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		handler := access.NewExtendedHandler(accessmock.NewAPI(t), chain)
+
+		_, err := handler.GetTransactionsByAccount(context.Background(), &extended.GetTransactionsByAccountRequest{
+			Address: flow.EmptyAddress.Bytes(),
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestExtendedHandler_GetTransactionTimeline(t *testing.T) {
+	chain := flow.Testnet.Chain()
+
+	t.Run("returns timeline", func(t *testing.T) {
+		api := accessmock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain)
+
+		txID := unittest.IdentifierFixture()
+		received := time.Now().UTC()
+		collectionID := unittest.IdentifierFixture()
+
+		api.On("GetTransactionTimeline", context.Background(), txID).
+			Return(&flow.TransactionTimeline{
+				TransactionID: txID,
+				Events: []flow.TransactionTimelineEvent{
+					{Stage: flow.TransactionStageReceived, Timestamp: received},
+					{
+						Stage:      flow.TransactionStageCollected,
+						Timestamp:  received.Add(time.Second),
+						Attributes: map[string]string{flow.TimelineAttributeCollectionID: collectionID.String()},
+					},
+				},
+			}, nil).
+			Once()
+
+		resp, err := handler.GetTransactionTimeline(context.Background(), &extended.GetTransactionTimelineRequest{
+			Id: txID[:],
+		})
+		require.NoError(t, err)
+		require.Equal(t, txID[:], resp.TransactionId)
+		require.Len(t, resp.Events, 2)
+		require.Equal(t, "received", resp.Events[0].Stage)
+		require.Equal(t, received.UnixNano(), resp.Events[0].Timestamp)
+		require.Empty(t, resp.Events[0].Attributes)
+		require.Equal(t, "collected", resp.Events[1].Stage)
+		require.Equal(t, collectionID.String(), resp.Events[1].Attributes[flow.TimelineAttributeCollectionID])
+	})
+
+	t.Run("tracking disabled", func(t *testing.T) {
+		api := accessmock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain)
+
+		txID := unittest.IdentifierFixture()
+		api.On("GetTransactionTimeline", context.Background(), txID).
+			Return(nil, status.Error(codes.Unimplemented, "transaction timeline tracking is disabled")).
+			Once()
+
+		_, err := handler.GetTransactionTimeline(context.Background(), &extended.GetTransactionTimelineRequest{
+			Id: txID[:],
+		})
+		require.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("missing id", func(t *testing.T) {
+		handler := access.NewExtendedHandler(accessmock.NewAPI(t), chain)
+
+		_, err := handler.GetTransactionTimeline(context.Background(), &extended.GetTransactionTimelineRequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestExtendedHandler_GetAccountStorage(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	address := unittest.RandomAddressFixtureForChain(chain.ChainID())
+
+	accountStorage := &flow.AccountStorage{
+		Address:         address,
+		StorageUsed:     1000,
+		StorageCapacity: 100_000,
+		Domains: []flow.AccountStorageDomain{{
+			Name: "storage",
+			Items: []flow.AccountStorageItem{{
+				Key:           "/storage/flowTokenVault",
+				Type:          "A.7e60df042a9c0868.FlowToken.Vault",
+				Size:          120,
+				SizeTruncated: true,
+			}},
+		}},
+		NextCursor: &flow.AccountStorageCursor{Domain: "storage", Index: 1},
+	}
+
+	t.Run("at latest block", func(t *testing.T) {
+		api := accessmock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain)
+
+		api.On("GetAccountStorageAtLatestBlock", context.Background(), address, uint32(1), (*flow.AccountStorageCursor)(nil)).
+			Return(accountStorage, nil).
+			Once()
+
+		resp, err := handler.GetAccountStorage(context.Background(), &extended.GetAccountStorageRequest{
+			Address: address.Bytes(),
+			Limit:   1,
+		})
+		require.NoError(t, err)
+		require.Equal(t, address.Bytes(), resp.Address)
+		require.Equal(t, uint64(1000), resp.StorageUsed)
+		require.Equal(t, uint64(100_000), resp.StorageCapacity)
+		require.Len(t, resp.Domains, 1)
+		require.Equal(t, "storage", resp.Domains[0].Name)
+		require.Len(t, resp.Domains[0].Items, 1)
+		require.Equal(t, "/storage/flowTokenVault", resp.Domains[0].Items[0].Key)
+		require.Equal(t, uint64(120), resp.Domains[0].Items[0].Size)
+		require.True(t, resp.Domains[0].Items[0].SizeTruncated)
+		require.Equal(t, accountStorage.NextCursor.Encode(), resp.NextCursor)
+	})
+
+	t.Run("at block height with cursor", func(t *testing.T) {
+		api := accessmock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain)
+
+		cursor := flow.AccountStorageCursor{Domain: "storage", Index: 1}
+		api.On("GetAccountStorageAtBlockHeight", context.Background(), address, uint64(10), uint32(0), &cursor).
+			Return(&flow.AccountStorage{Address: address}, nil).
+			Once()
+
+		resp, err := handler.GetAccountStorage(context.Background(), &extended.GetAccountStorageRequest{
+			Address:     address.Bytes(),
+			BlockHeight: 10,
+			Cursor:      cursor.Encode(),
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Domains)
+		require.Empty(t, resp.NextCursor)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		handler := access.NewExtendedHandler(accessmock.NewAPI(t), chain)
+
+		_, err := handler.GetAccountStorage(context.Background(), &extended.GetAccountStorageRequest{
+			Address: address.Bytes(),
+			Cursor:  "not a cursor",
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestExtendedHandler_GetRegistersWithProof(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	blockID := unittest.IdentifierFixture()
+	address := unittest.RandomAddressFixtureForChain(chain.ChainID())
+	registerIDs := flow.RegisterIDs{
+		flow.NewRegisterID(address, "public_key_0"),
+		flow.UUIDRegisterID(0),
+	}
+
+	t.Run("returns proof", func(t *testing.T) {
+		api := accessmock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain)
+
+		commit := unittest.StateCommitmentFixture()
+		api.On("GetRegistersWithProofAtBlockID", context.Background(), blockID, registerIDs).
+			Return(&flow.RegistersProof{
+				BlockID:         blockID,
+				StateCommitment: commit,
+				Registers: []flow.RegisterEntry{
+					{Key: registerIDs[0], Value: []byte{1}},
+					{Key: registerIDs[1]},
+				},
+				Proof: []byte{2, 3},
+			}, nil).
+			Once()
+
+		resp, err := handler.GetRegistersWithProof(context.Background(), &extended.GetRegistersWithProofRequest{
+			BlockId: blockID[:],
+			RegisterIds: []*extended.RegisterID{
+				{Owner: address.Bytes(), Key: "public_key_0"},
+				{Key: registerIDs[1].Key},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, blockID[:], resp.BlockId)
+		require.Equal(t, commit[:], resp.StateCommitment)
+		require.Len(t, resp.Registers, 2)
+		require.Equal(t, address.Bytes(), resp.Registers[0].Id.Owner)
+		require.Equal(t, "public_key_0", resp.Registers[0].Id.Key)
+		require.Equal(t, []byte{1}, resp.Registers[0].Value)
+		require.Empty(t, resp.Registers[1].Id.Owner)
+		require.Empty(t, resp.Registers[1].Value)
+		require.Equal(t, []byte{2, 3}, resp.Proof)
+	})
+
+	t.Run("invalid owner", func(t *testing.T) {
+		handler := access.NewExtendedHandler(accessmock.NewAPI(t), chain)
+
+		_, err := handler.GetRegistersWithProof(context.Background(), &extended.GetRegistersWithProofRequest{
+			BlockId:     blockID[:],
+			RegisterIds: []*extended.RegisterID{{Owner: []byte{1, 2, 3}, Key: "key"}},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("invalid block id", func(t *testing.T) {
+		handler := access.NewExtendedHandler(accessmock.NewAPI(t), chain)
+
+		_, err := handler.GetRegistersWithProof(context.Background(), &extended.GetRegistersWithProofRequest{
+			BlockId: []byte{1, 2, 3},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestExtendedHandler_EstimateTransaction(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	tx := unittest.TransactionBodyFixture()
+
+	t.Run("returns estimate", func(t *testing.T) {
+		api := accessmock.NewAPI(t)
+		handler := access.NewExtendedHandler(api, chain)
+
+		events := unittest.EventsFixture(2)
+		api.On("EstimateTransaction", context.Background(), &tx).
+			Return(&flow.TransactionEstimate{
+				ErrorMessage:           "out of gas",
+				ComputationUsed:        10,
+				ComputationIntensities: map[uint64]uint64{1: 2, 3: 4},
+				MemoryEstimate:         100,
+				Events:                 events,
+				Fees:                   1000,
+			}, nil).
+			Once()
+
+		resp, err := handler.EstimateTransaction(context.Background(), &extended.EstimateTransactionRequest{
+			Transaction: convert.TransactionToMessage(tx),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "out of gas", resp.ErrorMessage)
+		require.Equal(t, uint64(10), resp.ComputationUsed)
+		require.Equal(t, map[uint64]uint64{1: 2, 3: 4}, resp.ComputationIntensities)
+		require.Equal(t, uint64(100), resp.MemoryEstimate)
+		require.Equal(t, convert.EventsToMessages(events), resp.Events)
+		require.Equal(t, uint64(1000), resp.Fees)
+	})
+
+	t.Run("missing transaction", func(t *testing.T) {
+		handler := access.NewExtendedHandler(accessmock.NewAPI(t), chain)
+
+		_, err := handler.EstimateTransaction(context.Background(), &extended.EstimateTransactionRequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
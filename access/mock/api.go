@@ -9,6 +9,8 @@ import (
 
 	entities "github.com/onflow/flow/protobuf/go/flow/entities"
 
+	events "github.com/onflow/flow-go/model/events"
+
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,6 +23,36 @@ type API struct {
 	mock.Mock
 }
 
+// EstimateTransaction provides a mock function with given fields: ctx, tx
+func (_m *API) EstimateTransaction(ctx context.Context, tx *flow.TransactionBody) (*flow.TransactionEstimate, error) {
+	ret := _m.Called(ctx, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateTransaction")
+	}
+
+	var r0 *flow.TransactionEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody) (*flow.TransactionEstimate, error)); ok {
+		return rf(ctx, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody) *flow.TransactionEstimate); ok {
+		r0 = rf(ctx, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionEstimate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody) error); ok {
+		r1 = rf(ctx, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScriptAtBlockHeight provides a mock function with given fields: ctx, blockHeight, script, arguments
 func (_m *API) ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte) ([]byte, error) {
 	ret := _m.Called(ctx, blockHeight, script, arguments)
@@ -51,6 +83,36 @@ func (_m *API) ExecuteScriptAtBlockHeight(ctx context.Context, blockHeight uint6
 	return r0, r1
 }
 
+// ExecuteScriptAtBlockHeightWithOverrides provides a mock function with given fields: ctx, blockHeight, script, arguments, overrides
+func (_m *API) ExecuteScriptAtBlockHeightWithOverrides(ctx context.Context, blockHeight uint64, script []byte, arguments [][]byte, overrides *flow.StateOverrides) (*flow.ScriptResultWithOverrides, error) {
+	ret := _m.Called(ctx, blockHeight, script, arguments, overrides)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScriptAtBlockHeightWithOverrides")
+	}
+
+	var r0 *flow.ScriptResultWithOverrides
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []byte, [][]byte, *flow.StateOverrides) (*flow.ScriptResultWithOverrides, error)); ok {
+		return rf(ctx, blockHeight, script, arguments, overrides)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, []byte, [][]byte, *flow.StateOverrides) *flow.ScriptResultWithOverrides); ok {
+		r0 = rf(ctx, blockHeight, script, arguments, overrides)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ScriptResultWithOverrides)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, []byte, [][]byte, *flow.StateOverrides) error); ok {
+		r1 = rf(ctx, blockHeight, script, arguments, overrides)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScriptAtBlockID provides a mock function with given fields: ctx, blockID, script, arguments
 func (_m *API) ExecuteScriptAtBlockID(ctx context.Context, blockID flow.Identifier, script []byte, arguments [][]byte) ([]byte, error) {
 	ret := _m.Called(ctx, blockID, script, arguments)
@@ -377,6 +439,96 @@ func (_m *API) GetAccountKeysAtLatestBlock(ctx context.Context, address flow.Add
 	return r0, r1
 }
 
+// GetAccountRegisterHistory provides a mock function with given fields: ctx, address, startHeight, endHeight, limit, cursor
+func (_m *API) GetAccountRegisterHistory(ctx context.Context, address flow.Address, startHeight uint64, endHeight uint64, limit uint32, cursor *flow.RegisterHistoryCursor) (*flow.RegisterHistoryPage, error) {
+	ret := _m.Called(ctx, address, startHeight, endHeight, limit, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountRegisterHistory")
+	}
+
+	var r0 *flow.RegisterHistoryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, uint32, *flow.RegisterHistoryCursor) (*flow.RegisterHistoryPage, error)); ok {
+		return rf(ctx, address, startHeight, endHeight, limit, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, uint32, *flow.RegisterHistoryCursor) *flow.RegisterHistoryPage); ok {
+		r0 = rf(ctx, address, startHeight, endHeight, limit, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.RegisterHistoryPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint64, uint32, *flow.RegisterHistoryCursor) error); ok {
+		r1 = rf(ctx, address, startHeight, endHeight, limit, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountStorageAtBlockHeight provides a mock function with given fields: ctx, address, height, limit, cursor
+func (_m *API) GetAccountStorageAtBlockHeight(ctx context.Context, address flow.Address, height uint64, limit uint32, cursor *flow.AccountStorageCursor) (*flow.AccountStorage, error) {
+	ret := _m.Called(ctx, address, height, limit, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountStorageAtBlockHeight")
+	}
+
+	var r0 *flow.AccountStorage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint32, *flow.AccountStorageCursor) (*flow.AccountStorage, error)); ok {
+		return rf(ctx, address, height, limit, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint32, *flow.AccountStorageCursor) *flow.AccountStorage); ok {
+		r0 = rf(ctx, address, height, limit, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountStorage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint32, *flow.AccountStorageCursor) error); ok {
+		r1 = rf(ctx, address, height, limit, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountStorageAtLatestBlock provides a mock function with given fields: ctx, address, limit, cursor
+func (_m *API) GetAccountStorageAtLatestBlock(ctx context.Context, address flow.Address, limit uint32, cursor *flow.AccountStorageCursor) (*flow.AccountStorage, error) {
+	ret := _m.Called(ctx, address, limit, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountStorageAtLatestBlock")
+	}
+
+	var r0 *flow.AccountStorage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint32, *flow.AccountStorageCursor) (*flow.AccountStorage, error)); ok {
+		return rf(ctx, address, limit, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint32, *flow.AccountStorageCursor) *flow.AccountStorage); ok {
+		r0 = rf(ctx, address, limit, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountStorage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint32, *flow.AccountStorageCursor) error); ok {
+		r1 = rf(ctx, address, limit, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockByHeight provides a mock function with given fields: ctx, height
 func (_m *API) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, flow.BlockStatus, error) {
 	ret := _m.Called(ctx, height)
@@ -555,6 +707,36 @@ func (_m *API) GetCollectionByID(ctx context.Context, id flow.Identifier) (*flow
 	return r0, r1
 }
 
+// GetEventsByQuery provides a mock function with given fields: ctx, query, startHeight, endHeight, requiredEventEncodingVersion
+func (_m *API) GetEventsByQuery(ctx context.Context, query *events.Query, startHeight uint64, endHeight uint64, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error) {
+	ret := _m.Called(ctx, query, startHeight, endHeight, requiredEventEncodingVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEventsByQuery")
+	}
+
+	var r0 []flow.BlockEvents
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *events.Query, uint64, uint64, entities.EventEncodingVersion) ([]flow.BlockEvents, error)); ok {
+		return rf(ctx, query, startHeight, endHeight, requiredEventEncodingVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *events.Query, uint64, uint64, entities.EventEncodingVersion) []flow.BlockEvents); ok {
+		r0 = rf(ctx, query, startHeight, endHeight, requiredEventEncodingVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]flow.BlockEvents)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *events.Query, uint64, uint64, entities.EventEncodingVersion) error); ok {
+		r1 = rf(ctx, query, startHeight, endHeight, requiredEventEncodingVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetEventsForBlockIDs provides a mock function with given fields: ctx, eventType, blockIDs, requiredEventEncodingVersion
 func (_m *API) GetEventsForBlockIDs(ctx context.Context, eventType string, blockIDs []flow.Identifier, requiredEventEncodingVersion entities.EventEncodingVersion) ([]flow.BlockEvents, error) {
 	ret := _m.Called(ctx, eventType, blockIDs, requiredEventEncodingVersion)
@@ -917,6 +1099,66 @@ func (_m *API) GetProtocolStateSnapshotByHeight(ctx context.Context, blockHeight
 	return r0, r1
 }
 
+// GetRegisterHistory provides a mock function with given fields: ctx, registerID, startHeight, endHeight, limit, cursor
+func (_m *API) GetRegisterHistory(ctx context.Context, registerID flow.RegisterID, startHeight uint64, endHeight uint64, limit uint32, cursor *flow.RegisterHistoryCursor) (*flow.RegisterHistoryPage, error) {
+	ret := _m.Called(ctx, registerID, startHeight, endHeight, limit, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegisterHistory")
+	}
+
+	var r0 *flow.RegisterHistoryPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.RegisterID, uint64, uint64, uint32, *flow.RegisterHistoryCursor) (*flow.RegisterHistoryPage, error)); ok {
+		return rf(ctx, registerID, startHeight, endHeight, limit, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.RegisterID, uint64, uint64, uint32, *flow.RegisterHistoryCursor) *flow.RegisterHistoryPage); ok {
+		r0 = rf(ctx, registerID, startHeight, endHeight, limit, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.RegisterHistoryPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.RegisterID, uint64, uint64, uint32, *flow.RegisterHistoryCursor) error); ok {
+		r1 = rf(ctx, registerID, startHeight, endHeight, limit, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRegistersWithProofAtBlockID provides a mock function with given fields: ctx, blockID, registerIDs
+func (_m *API) GetRegistersWithProofAtBlockID(ctx context.Context, blockID flow.Identifier, registerIDs flow.RegisterIDs) (*flow.RegistersProof, error) {
+	ret := _m.Called(ctx, blockID, registerIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRegistersWithProofAtBlockID")
+	}
+
+	var r0 *flow.RegistersProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, flow.RegisterIDs) (*flow.RegistersProof, error)); ok {
+		return rf(ctx, blockID, registerIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, flow.RegisterIDs) *flow.RegistersProof); ok {
+		r0 = rf(ctx, blockID, registerIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.RegistersProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, flow.RegisterIDs) error); ok {
+		r1 = rf(ctx, blockID, registerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSystemTransaction provides a mock function with given fields: ctx, blockID
 func (_m *API) GetSystemTransaction(ctx context.Context, blockID flow.Identifier) (*flow.TransactionBody, error) {
 	ret := _m.Called(ctx, blockID)
@@ -1097,6 +1339,66 @@ func (_m *API) GetTransactionResultsByBlockID(ctx context.Context, blockID flow.
 	return r0, r1
 }
 
+// GetTransactionTimeline provides a mock function with given fields: ctx, id
+func (_m *API) GetTransactionTimeline(ctx context.Context, id flow.Identifier) (*flow.TransactionTimeline, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionTimeline")
+	}
+
+	var r0 *flow.TransactionTimeline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) (*flow.TransactionTimeline, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier) *flow.TransactionTimeline); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionTimeline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionsByAccount provides a mock function with given fields: ctx, address, startHeight, endHeight, limit, cursor
+func (_m *API) GetTransactionsByAccount(ctx context.Context, address flow.Address, startHeight uint64, endHeight uint64, limit uint32, cursor *flow.AccountTransactionCursor) (*flow.AccountTransactionsPage, error) {
+	ret := _m.Called(ctx, address, startHeight, endHeight, limit, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByAccount")
+	}
+
+	var r0 *flow.AccountTransactionsPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, uint32, *flow.AccountTransactionCursor) (*flow.AccountTransactionsPage, error)); ok {
+		return rf(ctx, address, startHeight, endHeight, limit, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint64, uint32, *flow.AccountTransactionCursor) *flow.AccountTransactionsPage); ok {
+		r0 = rf(ctx, address, startHeight, endHeight, limit, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountTransactionsPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint64, uint32, *flow.AccountTransactionCursor) error); ok {
+		r1 = rf(ctx, address, startHeight, endHeight, limit, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionsByBlockID provides a mock function with given fields: ctx, blockID
 func (_m *API) GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionBody, error) {
 	ret := _m.Called(ctx, blockID)
@@ -0,0 +1,110 @@
+// Package registerproof verifies register values returned by access nodes, so that clients do not
+// have to trust the node which served them.
+//
+// A proof is checked against an execution result the client already trusts, typically one whose seal
+// was included in a finalized block the client verified.
+package registerproof
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/partial"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ErrInvalidProof is returned when the register values cannot be verified against the execution result.
+var ErrInvalidProof = errors.New("invalid register proof")
+
+// Verify checks that the register values of the proof are part of the final state of the given sealed
+// execution result.
+//
+// Expected errors:
+// - ErrInvalidProof if the proof does not match the execution result, or the values do not match the proof
+func Verify(proof *flow.RegistersProof, result *flow.ExecutionResult) error {
+	if proof.BlockID != result.BlockID {
+		return fmt.Errorf("%w: proof is for block %v, but execution result is for block %v",
+			ErrInvalidProof, proof.BlockID, result.BlockID)
+	}
+
+	commit, err := result.FinalStateCommitment()
+	if err != nil {
+		return fmt.Errorf("%w: execution result has no final state commitment: %v", ErrInvalidProof, err)
+	}
+
+	if proof.StateCommitment != commit {
+		return fmt.Errorf("%w: proof state commitment %v does not match execution result state commitment %v",
+			ErrInvalidProof, proof.StateCommitment, commit)
+	}
+
+	return VerifyRegisters(proof.Registers, proof.Proof, commit)
+}
+
+// VerifyWithSeal checks that the execution result is the one sealed by the seal, and that the register
+// values of the proof are part of its final state.
+//
+// Expected errors:
+// - ErrInvalidProof if the seal does not seal the result, the proof does not match the execution result,
+// or the values do not match the proof
+func VerifyWithSeal(proof *flow.RegistersProof, result *flow.ExecutionResult, seal *flow.Seal) error {
+	if seal.ResultID != result.ID() {
+		return fmt.Errorf("%w: seal is for execution result %v, not %v", ErrInvalidProof, seal.ResultID, result.ID())
+	}
+	if seal.BlockID != result.BlockID {
+		return fmt.Errorf("%w: seal is for block %v, but execution result is for block %v",
+			ErrInvalidProof, seal.BlockID, result.BlockID)
+	}
+	if seal.FinalState != proof.StateCommitment {
+		return fmt.Errorf("%w: proof state commitment %v does not match sealed state commitment %v",
+			ErrInvalidProof, proof.StateCommitment, seal.FinalState)
+	}
+
+	return Verify(proof, result)
+}
+
+// VerifyRegisters checks that the register values are proven by the encoded ledger.TrieBatchProof against
+// the state commitment. Registers with an empty value are proven to be unset.
+//
+// Expected errors:
+// - ErrInvalidProof if the proof is invalid for the state commitment, or the values do not match the proof
+func VerifyRegisters(registers []flow.RegisterEntry, encodedProof []byte, commit flow.StateCommitment) error {
+	if len(registers) == 0 {
+		return fmt.Errorf("%w: no registers to verify", ErrInvalidProof)
+	}
+
+	// the partial ledger verifies each proof of the batch against the root hash when it is constructed
+	state := ledger.State(commit)
+	partialLedger, err := partial.NewLedger(encodedProof, state, partial.DefaultPathFinderVersion)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	keys := make([]ledger.Key, len(registers))
+	for i, register := range registers {
+		keys[i] = convert.RegisterIDToLedgerKey(register.Key)
+	}
+
+	query, err := ledger.NewQuery(state, keys)
+	if err != nil {
+		return fmt.Errorf("could not create ledger query: %w", err)
+	}
+
+	// the paths of the registers are derived from their keys, so a value proven for another register
+	// is reported as missing
+	values, err := partialLedger.Get(query)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+
+	for i, register := range registers {
+		if !bytes.Equal(register.Value, values[i]) {
+			return fmt.Errorf("%w: value of register %v does not match the proven value",
+				ErrInvalidProof, register.Key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package registerproof_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access/registerproof"
+	executionState "github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// proveRegisters stores the registers in a new ledger, and returns a proof of the given registers
+// against the resulting state, together with a sealed execution result with that final state.
+func proveRegisters(
+	t *testing.T,
+	stored flow.RegisterEntries,
+	proven flow.RegisterIDs,
+) (*flow.RegistersProof, *flow.ExecutionResult) {
+	l, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	compactor := fixtures.NewNoopCompactor(l)
+	<-compactor.Ready()
+	t.Cleanup(func() {
+		<-l.Done()
+		<-compactor.Done()
+	})
+
+	keys, values := executionState.RegisterEntriesToKeysValues(stored)
+	update, err := ledger.NewUpdate(l.InitialState(), keys, values)
+	require.NoError(t, err)
+
+	state, _, err := l.Set(update)
+	require.NoError(t, err)
+	commit := flow.StateCommitment(state)
+
+	result := unittest.ExecutionResultFixture(unittest.WithFinalState(commit))
+
+	registers, proof, err := execution.NewLedgerRegisterProver(l).ProveRegisters(context.Background(), result.BlockID, commit, proven)
+	require.NoError(t, err)
+
+	return &flow.RegistersProof{
+		BlockID:         result.BlockID,
+		StateCommitment: commit,
+		Registers:       registers,
+		Proof:           proof,
+	}, result
+}
+
+func TestVerify(t *testing.T) {
+	owner := unittest.RandomAddressFixture()
+	balance := flow.NewRegisterID(owner, "balance")
+	storage := flow.NewRegisterID(owner, "storage")
+	unset := flow.NewRegisterID(owner, "unset")
+
+	stored := flow.RegisterEntries{
+		{Key: balance, Value: []byte{1, 2, 3}},
+		{Key: storage, Value: []byte{4, 5, 6}},
+	}
+
+	t.Run("valid proof", func(t *testing.T) {
+		proof, result := proveRegisters(t, stored, flow.RegisterIDs{balance, storage})
+		require.Equal(t, flow.RegisterValue{1, 2, 3}, proof.Registers[0].Value)
+		require.Equal(t, flow.RegisterValue{4, 5, 6}, proof.Registers[1].Value)
+
+		require.NoError(t, registerproof.Verify(proof, result))
+	})
+
+	t.Run("valid proof of unset register", func(t *testing.T) {
+		proof, result := proveRegisters(t, stored, flow.RegisterIDs{unset})
+		require.Empty(t, proof.Registers[0].Value)
+
+		require.NoError(t, registerproof.Verify(proof, result))
+	})
+
+	t.Run("tampered value", func(t *testing.T) {
+		proof, result := proveRegisters(t, stored, flow.RegisterIDs{balance})
+		proof.Registers[0].Value = []byte{9, 9, 9}
+
+		require.ErrorIs(t, registerproof.Verify(proof, result), registerproof.ErrInvalidProof)
+	})
+
+	t.Run("value claimed for another register", func(t *testing.T) {
+		proof, result := proveRegisters(t, stored, flow.RegisterIDs{balance})
+		proof.Registers[0].Key = storage
+
+		require.ErrorIs(t, registerproof.Verify(proof, result), registerproof.ErrInvalidProof)
+	})
+
+	t.Run("state commitment not matching the result", func(t *testing.T) {
+		proof, _ := proveRegisters(t, stored, flow.RegisterIDs{balance})
+		result := unittest.ExecutionResultFixture(
+			unittest.WithExecutionResultBlockID(proof.BlockID),
+			unittest.WithFinalState(unittest.StateCommitmentFixture()))
+
+		require.ErrorIs(t, registerproof.Verify(proof, result), registerproof.ErrInvalidProof)
+	})
+
+	t.Run("proof against another state", func(t *testing.T) {
+		proof, _ := proveRegisters(t, stored, flow.RegisterIDs{balance})
+		other := unittest.StateCommitmentFixture()
+		proof.StateCommitment = other
+		result := unittest.ExecutionResultFixture(
+			unittest.WithExecutionResultBlockID(proof.BlockID),
+			unittest.WithFinalState(other))
+
+		require.ErrorIs(t, registerproof.Verify(proof, result), registerproof.ErrInvalidProof)
+	})
+
+	t.Run("result for another block", func(t *testing.T) {
+		proof, result := proveRegisters(t, stored, flow.RegisterIDs{balance})
+		proof.BlockID = unittest.IdentifierFixture()
+
+		require.ErrorIs(t, registerproof.Verify(proof, result), registerproof.ErrInvalidProof)
+	})
+}
+
+func TestVerifyWithSeal(t *testing.T) {
+	owner := unittest.RandomAddressFixture()
+	balance := flow.NewRegisterID(owner, "balance")
+	stored := flow.RegisterEntries{{Key: balance, Value: []byte{1, 2, 3}}}
+
+	proof, result := proveRegisters(t, stored, flow.RegisterIDs{balance})
+
+	t.Run("valid seal", func(t *testing.T) {
+		seal := &flow.Seal{
+			BlockID:    result.BlockID,
+			ResultID:   result.ID(),
+			FinalState: proof.StateCommitment,
+		}
+		require.NoError(t, registerproof.VerifyWithSeal(proof, result, seal))
+	})
+
+	t.Run("seal of another result", func(t *testing.T) {
+		seal := &flow.Seal{
+			BlockID:    result.BlockID,
+			ResultID:   unittest.IdentifierFixture(),
+			FinalState: proof.StateCommitment,
+		}
+		require.ErrorIs(t, registerproof.VerifyWithSeal(proof, result, seal), registerproof.ErrInvalidProof)
+	})
+}
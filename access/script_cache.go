@@ -0,0 +1,57 @@
+package access
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// CacheControlHeader is the HTTP header, or gRPC metadata key, used by clients to control
+	// caching of script results.
+	CacheControlHeader = "cache-control"
+
+	// CacheControlNoCache is the value of the CacheControlHeader instructing the node to execute
+	// the script instead of returning a cached result.
+	CacheControlNoCache = "no-cache"
+)
+
+type scriptCacheBypassKey struct{}
+
+// WithScriptCacheBypass returns a copy of the context instructing the API to execute scripts
+// instead of returning cached results.
+func WithScriptCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scriptCacheBypassKey{}, true)
+}
+
+// IsScriptCacheBypassed returns true if the context instructs the API to bypass the script result cache,
+// either because it was created with WithScriptCacheBypass or because the incoming gRPC request
+// contains the CacheControlNoCache directive.
+func IsScriptCacheBypassed(ctx context.Context) bool {
+	if bypass, ok := ctx.Value(scriptCacheBypassKey{}).(bool); ok && bypass {
+		return true
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, value := range md.Get(CacheControlHeader) {
+		if HasNoCacheDirective(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasNoCacheDirective returns true if the comma separated list of cache directives contains
+// the CacheControlNoCache directive.
+func HasNoCacheDirective(value string) bool {
+	for _, directive := range strings.Split(value, ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), CacheControlNoCache) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,79 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+)
+
+var _ commands.AdminCommand = (*ProfileTransactionsCommand)(nil)
+
+// maxProfiledTransactions is the maximum number of transactions which can be profiled with one
+// command, since each profiled transaction writes a profile to disk.
+const maxProfiledTransactions = 1000
+
+// ProfileTransactionsCommand profiles the computation used by the next transactions executed by the
+// node. The profiles are written to the profiler directory of the node, see computer.TransactionProfiler.
+type ProfileTransactionsCommand struct {
+	profiler *computer.TransactionProfiler
+}
+
+// NewProfileTransactionsCommand creates a new ProfileTransactionsCommand object
+func NewProfileTransactionsCommand(profiler *computer.TransactionProfiler) *ProfileTransactionsCommand {
+	return &ProfileTransactionsCommand{
+		profiler: profiler,
+	}
+}
+
+// Handler requests profiling of the next transactions, replacing any pending request.
+// Returns the number of transactions which were still pending to be profiled.
+func (s *ProfileTransactionsCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	count := req.ValidatorData.(uint64)
+
+	pending := s.profiler.Remaining()
+	s.profiler.ProfileNext(count)
+
+	log.Info().
+		Uint64("count", count).
+		Uint64("pending", pending).
+		Msgf("admintool: transaction profiling requested")
+
+	return map[string]interface{}{
+		"count":   count,
+		"pending": pending,
+	}, nil
+}
+
+// Validator checks the inputs for ProfileTransactions command.
+// It expects the following fields in the Data field of the req object:
+//   - count in a numeric format, the number of transactions to profile. 0 cancels pending profiling.
+//
+// The following sentinel errors are expected during normal operations:
+// * `admin.InvalidAdminReqError` if any required field is missing or in a wrong format
+func (s *ProfileTransactionsCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+	result, ok := input["count"]
+	if !ok {
+		return admin.NewInvalidAdminReqErrorf("missing required field: 'count'")
+	}
+	count, ok := result.(float64)
+	if !ok || count < 0 || count != float64(uint64(count)) {
+		return admin.NewInvalidAdminReqParameterError("count", "must be a non-negative integer", result)
+	}
+	if count > maxProfiledTransactions {
+		return admin.NewInvalidAdminReqParameterError("count",
+			fmt.Sprintf("must not exceed %d", maxProfiledTransactions), result)
+	}
+
+	req.ValidatorData = uint64(count)
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+)
+
+func TestProfileTransactionsCommandParsing(t *testing.T) {
+	cmd := ProfileTransactionsCommand{}
+
+	t.Run("happy path", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"count": float64(10), // raw json parses to float64
+			},
+		}
+
+		err := cmd.Validator(req)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), req.ValidatorData)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{},
+		}
+
+		err := cmd.Validator(req)
+		require.True(t, admin.IsInvalidAdminParameterError(err))
+	})
+
+	t.Run("invalid count", func(t *testing.T) {
+		for _, count := range []interface{}{"abc", float64(-1), float64(1.5), float64(maxProfiledTransactions + 1)} {
+			req := &admin.CommandRequest{
+				Data: map[string]interface{}{
+					"count": count,
+				},
+			}
+
+			err := cmd.Validator(req)
+			require.True(t, admin.IsInvalidAdminParameterError(err), "count: %v", count)
+		}
+	})
+}
+
+func TestProfileTransactionsCommand(t *testing.T) {
+	profiler, err := computer.NewTransactionProfiler(zerolog.Nop(), t.TempDir())
+	require.NoError(t, err)
+
+	cmd := NewProfileTransactionsCommand(profiler)
+
+	req := &admin.CommandRequest{
+		Data: map[string]interface{}{
+			"count": float64(5),
+		},
+	}
+	require.NoError(t, cmd.Validator(req))
+
+	result, err := cmd.Handler(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"count": uint64(5), "pending": uint64(0)}, result)
+	require.Equal(t, uint64(5), profiler.Remaining())
+
+	// a new request replaces the pending one
+	req = &admin.CommandRequest{
+		Data: map[string]interface{}{
+			"count": float64(0),
+		},
+	}
+	require.NoError(t, cmd.Validator(req))
+
+	result, err = cmd.Handler(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"count": uint64(0), "pending": uint64(5)}, result)
+	require.Equal(t, uint64(0), profiler.Remaining())
+}
@@ -0,0 +1,78 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/execution/computation/shadow"
+)
+
+var _ commands.AdminCommand = (*ShadowExecutionDivergencesCommand)(nil)
+
+const (
+	defaultDivergenceReports = 10
+	maxDivergenceReports     = 100
+)
+
+// ShadowExecutionDivergencesCommand returns the reports of the latest blocks whose shadow
+// execution diverged from the primary execution, see shadow.Executor.
+type ShadowExecutionDivergencesCommand struct {
+	divergences *shadow.DivergenceStore
+}
+
+// NewShadowExecutionDivergencesCommand creates a new ShadowExecutionDivergencesCommand object.
+// The store is nil if shadow execution is disabled.
+func NewShadowExecutionDivergencesCommand(divergences *shadow.DivergenceStore) *ShadowExecutionDivergencesCommand {
+	return &ShadowExecutionDivergencesCommand{
+		divergences: divergences,
+	}
+}
+
+// Handler returns the reports of the latest diverged blocks, ordered by descending height.
+func (s *ShadowExecutionDivergencesCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	limit := req.ValidatorData.(int)
+
+	reports, err := s.divergences.Reports(limit)
+	if err != nil {
+		return nil, fmt.Errorf("could not read shadow execution reports: %w", err)
+	}
+
+	return reports, nil
+}
+
+// Validator checks the inputs for ShadowExecutionDivergences command.
+// It accepts the following optional fields in the Data field of the req object:
+//   - limit in a numeric format, the maximum number of reports to return. Defaults to 10.
+//
+// The following sentinel errors are expected during normal operations:
+// * `admin.InvalidAdminReqError` if shadow execution is disabled, or if a field is in a wrong format
+func (s *ShadowExecutionDivergencesCommand) Validator(req *admin.CommandRequest) error {
+	if s.divergences == nil {
+		return admin.NewInvalidAdminReqErrorf("shadow execution is not enabled")
+	}
+
+	limit := defaultDivergenceReports
+	if req.Data != nil {
+		input, ok := req.Data.(map[string]interface{})
+		if !ok {
+			return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+		}
+		if result, ok := input["limit"]; ok {
+			value, ok := result.(float64)
+			if !ok || value < 1 || value != float64(int(value)) {
+				return admin.NewInvalidAdminReqParameterError("limit", "must be a positive integer", result)
+			}
+			if value > maxDivergenceReports {
+				return admin.NewInvalidAdminReqParameterError("limit",
+					fmt.Sprintf("must not exceed %d", maxDivergenceReports), result)
+			}
+			limit = int(value)
+		}
+	}
+
+	req.ValidatorData = limit
+
+	return nil
+}
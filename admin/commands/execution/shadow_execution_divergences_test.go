@@ -0,0 +1,88 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/execution/computation/shadow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestShadowExecutionDivergencesCommandParsing(t *testing.T) {
+	store, err := shadow.NewDivergenceStore(t.TempDir())
+	require.NoError(t, err)
+	cmd := NewShadowExecutionDivergencesCommand(store)
+
+	t.Run("default limit", func(t *testing.T) {
+		req := &admin.CommandRequest{}
+
+		err := cmd.Validator(req)
+		require.NoError(t, err)
+		require.Equal(t, defaultDivergenceReports, req.ValidatorData)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"limit": float64(3), // raw json parses to float64
+			},
+		}
+
+		err := cmd.Validator(req)
+		require.NoError(t, err)
+		require.Equal(t, 3, req.ValidatorData)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		for _, limit := range []interface{}{"abc", float64(0), float64(1.5), float64(maxDivergenceReports + 1)} {
+			req := &admin.CommandRequest{
+				Data: map[string]interface{}{
+					"limit": limit,
+				},
+			}
+
+			err := cmd.Validator(req)
+			require.True(t, admin.IsInvalidAdminParameterError(err), "limit: %v", limit)
+		}
+	})
+
+	t.Run("shadow execution disabled", func(t *testing.T) {
+		cmd := NewShadowExecutionDivergencesCommand(nil)
+
+		err := cmd.Validator(&admin.CommandRequest{})
+		require.True(t, admin.IsInvalidAdminParameterError(err))
+	})
+}
+
+func TestShadowExecutionDivergencesCommand(t *testing.T) {
+	store, err := shadow.NewDivergenceStore(t.TempDir())
+	require.NoError(t, err)
+
+	for height := uint64(1); height <= 3; height++ {
+		err := store.Store(&shadow.BlockReport{
+			BlockID:     unittest.IdentifierFixture(),
+			BlockHeight: height,
+		})
+		require.NoError(t, err)
+	}
+
+	cmd := NewShadowExecutionDivergencesCommand(store)
+
+	req := &admin.CommandRequest{
+		Data: map[string]interface{}{
+			"limit": float64(2),
+		},
+	}
+	require.NoError(t, cmd.Validator(req))
+
+	result, err := cmd.Handler(context.Background(), req)
+	require.NoError(t, err)
+
+	reports := result.([]*shadow.BlockReport)
+	require.Len(t, reports, 2)
+	require.Equal(t, uint64(3), reports[0].BlockHeight)
+	require.Equal(t, uint64(2), reports[1].BlockHeight)
+}
@@ -0,0 +1,149 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/cmd/util/common"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*VerifyCheckpointCommand)(nil)
+
+// defaultSealedBlocks is the default number of the latest sealed blocks searched for
+// state commitments matching the root hashes of the checkpoint.
+const defaultSealedBlocks = 1000
+
+type verifyCheckpointReq struct {
+	fileName     string
+	sealedBlocks uint64
+	full         bool
+}
+
+// VerifyCheckpointCommand verifies a checkpoint of the execution node (the latest checkpoint by default),
+// and cross-checks the root hashes of its tries against sealed state commitments.
+// By default, only the checksums of the files are verified. The files are streamed one at a time without
+// rebuilding the tries, so the command doesn't use more memory for large states.
+// In full mode, the tries are rebuilt and the hashes of all the trie nodes are recomputed bottom-up, which
+// requires loading the whole checkpoint into memory next to the execution state of the running node.
+// The command only reports the corrupt subtries, and whether the checkpoint can be rebuilt from the
+// previous checkpoint and the WAL. Rebuilding it requires stopping the node and running the
+// verify-checkpoint util command with --repair.
+type VerifyCheckpointCommand struct {
+	logger        zerolog.Logger
+	state         protocol.State
+	headers       storage.Headers
+	seals         storage.Seals
+	checkpointDir string
+}
+
+func NewVerifyCheckpointCommand(
+	logger zerolog.Logger,
+	state protocol.State,
+	headers storage.Headers,
+	seals storage.Seals,
+	checkpointDir string,
+) *VerifyCheckpointCommand {
+	return &VerifyCheckpointCommand{
+		logger:        logger.With().Str("admin_command", "verify-checkpoint").Logger(),
+		state:         state,
+		headers:       headers,
+		seals:         seals,
+		checkpointDir: checkpointDir,
+	}
+}
+
+func (c *VerifyCheckpointCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(verifyCheckpointReq)
+
+	fileName := data.fileName
+	if fileName == "" {
+		_, last, err := wal.ListCheckpoints(c.checkpointDir)
+		if err != nil {
+			return nil, fmt.Errorf("could not list checkpoints: %w", err)
+		}
+		if last < 0 {
+			return nil, fmt.Errorf("no checkpoint found in %v", c.checkpointDir)
+		}
+		fileName = wal.NumberToFilename(last)
+	}
+
+	verify := common.VerifyCheckpointChecksums
+	if data.full {
+		verify = common.VerifyCheckpoint
+	}
+
+	c.logger.Info().Bool("full", data.full).Msgf("admintool: verifying checkpoint %v", fileName)
+
+	report, err := verify(
+		c.logger,
+		filepath.Join(c.checkpointDir, fileName),
+		c.state,
+		c.headers,
+		c.seals,
+		data.sealedBlocks,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Info().
+		Bool("corrupted", report.IsCorrupted()).
+		Msgf("admintool: finished verifying checkpoint %v", fileName)
+
+	return commands.ConvertToMap(report)
+}
+
+// Validator validates the request.
+// It accepts the following optional fields in the Data field of the req object:
+//   - checkpoint, the file name of the checkpoint to verify (default: the latest checkpoint)
+//   - sealed-blocks, the number of the latest sealed blocks to cross-check the root hashes with
+//   - full, whether to rebuild the tries and verify the hashes of all the trie nodes (default: false)
+//
+// Returns admin.InvalidAdminReqError for invalid inputs.
+func (c *VerifyCheckpointCommand) Validator(req *admin.CommandRequest) error {
+	data := verifyCheckpointReq{
+		sealedBlocks: defaultSealedBlocks,
+	}
+
+	if req.Data != nil {
+		input, ok := req.Data.(map[string]interface{})
+		if !ok {
+			return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+		}
+
+		if value, ok := input["checkpoint"]; ok {
+			fileName, ok := value.(string)
+			if !ok || fileName == "" || filepath.Base(fileName) != fileName {
+				return admin.NewInvalidAdminReqParameterError("checkpoint", "must be the file name of a checkpoint", value)
+			}
+			data.fileName = fileName
+		}
+
+		if value, ok := input["sealed-blocks"]; ok {
+			n, ok := value.(float64)
+			if !ok || n < 0 {
+				return admin.NewInvalidAdminReqParameterError("sealed-blocks", "must be a number >= 0", value)
+			}
+			data.sealedBlocks = uint64(n)
+		}
+
+		if value, ok := input["full"]; ok {
+			full, ok := value.(bool)
+			if !ok {
+				return admin.NewInvalidAdminReqParameterError("full", "must be a boolean", value)
+			}
+			data.full = full
+		}
+	}
+
+	req.ValidatorData = data
+	return nil
+}
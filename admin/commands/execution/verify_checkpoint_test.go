@@ -0,0 +1,207 @@
+package execution
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestVerifyCheckpointCommandParsing(t *testing.T) {
+	cmd := VerifyCheckpointCommand{}
+
+	t.Run("defaults", func(t *testing.T) {
+		req := &admin.CommandRequest{}
+		require.NoError(t, cmd.Validator(req))
+		require.Equal(t, verifyCheckpointReq{sealedBlocks: defaultSealedBlocks}, req.ValidatorData)
+	})
+
+	t.Run("happy path", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"checkpoint":    "checkpoint.00000010",
+				"sealed-blocks": float64(20),
+				"full":          true,
+			},
+		}
+		require.NoError(t, cmd.Validator(req))
+		require.Equal(t, verifyCheckpointReq{fileName: "checkpoint.00000010", sealedBlocks: 20, full: true}, req.ValidatorData)
+	})
+
+	t.Run("invalid checkpoint", func(t *testing.T) {
+		for _, value := range []interface{}{"", "../checkpoint.00000010", float64(10)} {
+			req := &admin.CommandRequest{
+				Data: map[string]interface{}{"checkpoint": value},
+			}
+			err := cmd.Validator(req)
+			require.True(t, admin.IsInvalidAdminParameterError(err), "value %v", value)
+		}
+	})
+
+	t.Run("invalid sealed blocks", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{"sealed-blocks": "10"},
+		}
+		err := cmd.Validator(req)
+		require.True(t, admin.IsInvalidAdminParameterError(err))
+	})
+
+	t.Run("invalid full", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{"full": "true"},
+		}
+		err := cmd.Validator(req)
+		require.True(t, admin.IsInvalidAdminParameterError(err))
+	})
+}
+
+func TestVerifyCheckpointCommand(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		// store a checkpoint with two tries, the first one is sealed at height 11
+		tries := make([]*trie.MTrie, 0, 2)
+		activeTrie := trie.NewEmptyMTrie()
+		for i := 0; i < 2; i++ {
+			paths := testutils.RandomPaths(100)
+			payloads := testutils.RandomPayloads(len(paths), 10, 100)
+			values := make([]ledger.Payload, len(payloads))
+			for j, p := range payloads {
+				values[j] = *p
+			}
+			var err error
+			activeTrie, _, err = trie.NewTrieWithUpdatedRegisters(activeTrie, paths, values, true)
+			require.NoError(t, err)
+			tries = append(tries, activeTrie)
+		}
+		require.NoError(t, wal.StoreCheckpointV6Concurrently(tries, dir, wal.NumberToFilename(3), unittest.Logger()))
+
+		sealedRoot := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(10))
+		sealedHead := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(12))
+
+		params := protocolmock.NewParams(t)
+		params.On("SealedRoot").Return(sealedRoot)
+		snapshot := protocolmock.NewSnapshot(t)
+		snapshot.On("Head").Return(sealedHead, nil)
+		state := protocolmock.NewState(t)
+		state.On("Sealed").Return(snapshot)
+		state.On("Params").Return(params)
+
+		headers := storagemock.NewHeaders(t)
+		seals := storagemock.NewSeals(t)
+		for height := uint64(10); height <= 12; height++ {
+			blockID := unittest.IdentifierFixture()
+			headers.On("BlockIDByHeight", height).Return(blockID, nil).Maybe()
+
+			switch height {
+			case 10:
+				seals.On("FinalizedSealForBlock", blockID).Return(nil, storage.ErrNotFound).Maybe()
+			case 11:
+				seal := unittest.Seal.Fixture(unittest.Seal.WithBlockID(blockID))
+				seal.FinalState = flow.StateCommitment(tries[0].RootHash())
+				seals.On("FinalizedSealForBlock", blockID).Return(seal, nil).Maybe()
+			default:
+				seals.On("FinalizedSealForBlock", blockID).Return(unittest.Seal.Fixture(), nil).Maybe()
+			}
+		}
+		headers.On("BlockIDByHeight", mock.Anything).Return(flow.ZeroID, storage.ErrNotFound).Maybe()
+
+		cmd := NewVerifyCheckpointCommand(unittest.Logger(), state, headers, seals, dir)
+
+		req := &admin.CommandRequest{}
+		require.NoError(t, cmd.Validator(req))
+		result, err := cmd.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		report := result.(map[string]interface{})
+		require.Equal(t, filepath.Join(dir, wal.NumberToFilename(3)), report["checkpoint"])
+		require.NotContains(t, report, "corrupted")
+		require.Equal(t, true, report["sealed_trie_found"])
+		// the trie nodes are not loaded on a running node
+		require.Equal(t, true, report["checksums_only"])
+		require.Equal(t, float64(0), report["node_count"])
+
+		reportTries := report["tries"].([]interface{})
+		require.Len(t, reportTries, 2)
+		require.Equal(t, tries[0].RootHash().String(), reportTries[0].(map[string]interface{})["root_hash"])
+		require.Equal(t, float64(11), reportTries[0].(map[string]interface{})["sealed_height"])
+		require.NotContains(t, reportTries[1], "sealed_height")
+
+		// corrupt a part of the checkpoint, there are no WAL segments to rebuild it from
+		partPath := filepath.Join(dir, wal.CheckpointFileNames(wal.NumberToFilename(3))[1])
+		data, err := os.ReadFile(partPath)
+		require.NoError(t, err)
+		data[len(data)/2]++
+		require.NoError(t, os.WriteFile(partPath, data, 0644))
+
+		result, err = cmd.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		report = result.(map[string]interface{})
+		require.Len(t, report["corrupted"], 1)
+		corrupted := report["corrupted"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, partPath, corrupted["file"])
+		require.Equal(t, "0000", corrupted["path_prefix"])
+
+		rebuild := report["rebuild"].(map[string]interface{})
+		require.Equal(t, false, rebuild["possible"])
+		require.Equal(t, float64(-1), rebuild["from_checkpoint"])
+	})
+}
+
+// TestVerifyCheckpointCommand_Full tests that the full mode detects a trie node whose stored hash doesn't
+// match its content, which the checksums of the files don't detect.
+func TestVerifyCheckpointCommand_Full(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		// a leaf with a wrong hash, the checksums and the root hash are consistent with the stored hash
+		path := testutils.PathByUint8(1)
+		payload := testutils.LightPayload8(1, 1)
+		n := node.NewNode(250, nil, nil, path, payload, hash.Hash{1, 2, 3})
+		for height := 251; height <= ledger.NodeMaxHeight; height++ {
+			n = node.NewInterimNode(height, n, nil)
+		}
+		tr, err := trie.NewMTrie(n, 1, uint64(payload.Size()))
+		require.NoError(t, err)
+		require.NoError(t, wal.StoreCheckpointV6Concurrently([]*trie.MTrie{tr}, dir, wal.NumberToFilename(1), unittest.Logger()))
+
+		cmd := NewVerifyCheckpointCommand(unittest.Logger(), nil, nil, nil, dir)
+
+		// the checksums are valid
+		req := &admin.CommandRequest{}
+		require.NoError(t, cmd.Validator(req))
+		result, err := cmd.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		report := result.(map[string]interface{})
+		require.Equal(t, true, report["checksums_only"])
+		require.NotContains(t, report, "corrupted")
+
+		// the trie nodes are re-hashed in full mode
+		req = &admin.CommandRequest{Data: map[string]interface{}{"full": true}}
+		require.NoError(t, cmd.Validator(req))
+		result, err = cmd.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		report = result.(map[string]interface{})
+		require.NotContains(t, report, "checksums_only")
+		require.Greater(t, report["node_count"], float64(0))
+		require.Len(t, report["corrupted"], 1)
+		corrupted := report["corrupted"].([]interface{})[0].(map[string]interface{})
+		require.Equal(t, float64(250), corrupted["height"])
+		require.Equal(t, "000000", corrupted["path_prefix"])
+	})
+}
@@ -0,0 +1,160 @@
+package state_synchronization
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+)
+
+var _ commands.AdminCommand = (*ReadRegisterHistoryCommand)(nil)
+
+// maxRegisterHistoryChanges is the maximum number of register changes returned by a single invocation of the command.
+// Further changes are returned by passing the returned "next-cursor" back to the command.
+const maxRegisterHistoryChanges = 1000
+
+type registerHistoryData struct {
+	owner       flow.Address
+	key         *string // nil if the history of all registers of the owner is requested
+	startHeight uint64
+	endHeight   uint64
+	cursor      *flow.RegisterHistoryCursor // nil to start from the most recent change
+}
+
+// ReadRegisterHistoryCommand returns the values written to a register, or to all registers of an owner,
+// within a range of block heights.
+type ReadRegisterHistoryCommand struct {
+	registers *execution.RegistersAsyncStore
+}
+
+func (r *ReadRegisterHistoryCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*registerHistoryData)
+
+	var page *flow.RegisterHistoryPage
+	var err error
+	if data.key != nil {
+		page, err = r.registers.RegisterHistory(flow.NewRegisterID(data.owner, *data.key), data.startHeight, data.endHeight, maxRegisterHistoryChanges, data.cursor)
+	} else {
+		page, err = r.registers.OwnerRegisterHistory(flow.AddressToRegisterOwner(data.owner), data.startHeight, data.endHeight, maxRegisterHistoryChanges, data.cursor)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read register history: %w", err)
+	}
+
+	changes := make([]interface{}, 0, len(page.Changes))
+	for _, change := range page.Changes {
+		changes = append(changes, map[string]interface{}{
+			"owner":  hex.EncodeToString([]byte(change.Key.Owner)),
+			"key":    hex.EncodeToString([]byte(change.Key.Key)),
+			"height": change.Height,
+			"value":  hex.EncodeToString(change.Value),
+		})
+	}
+
+	result := map[string]interface{}{
+		"changes": changes,
+	}
+	if page.NextCursor != nil {
+		result["next-cursor"] = page.NextCursor.Encode()
+	}
+
+	return result, nil
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (r *ReadRegisterHistoryCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	data := &registerHistoryData{}
+
+	ownerRaw, ok := input["owner"]
+	if !ok {
+		return admin.NewInvalidAdminReqErrorf("missing required field 'owner'")
+	}
+	ownerStr, ok := ownerRaw.(string)
+	if !ok {
+		return admin.NewInvalidAdminReqParameterError("owner", "must be a hex-encoded address", ownerRaw)
+	}
+	owner, err := flow.StringToAddress(ownerStr)
+	if err != nil {
+		return admin.NewInvalidAdminReqParameterError("owner", "must be a hex-encoded address", ownerRaw)
+	}
+	data.owner = owner
+
+	if keyRaw, ok := input["key"]; ok {
+		keyStr, ok := keyRaw.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("key", "must be a hex-encoded register key", keyRaw)
+		}
+		key, err := hex.DecodeString(keyStr)
+		if err != nil {
+			return admin.NewInvalidAdminReqParameterError("key", "must be a hex-encoded register key", keyRaw)
+		}
+		keyString := string(key)
+		data.key = &keyString
+	}
+
+	data.startHeight, err = parseHeightField(input, "start-height")
+	if err != nil {
+		return err
+	}
+	data.endHeight, err = parseHeightField(input, "end-height")
+	if err != nil {
+		return err
+	}
+	if data.startHeight > data.endHeight {
+		return admin.NewInvalidAdminReqErrorf("start-height %d must not be above end-height %d", data.startHeight, data.endHeight)
+	}
+
+	if cursorRaw, ok := input["cursor"]; ok {
+		cursorStr, ok := cursorRaw.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("cursor", "must be a cursor returned by a previous request", cursorRaw)
+		}
+		cursor, err := flow.DecodeRegisterHistoryCursor(cursorStr)
+		if err != nil {
+			return admin.NewInvalidAdminReqParameterError("cursor", "must be a cursor returned by a previous request", cursorRaw)
+		}
+		if cursor.Height < data.startHeight || cursor.Height > data.endHeight {
+			return admin.NewInvalidAdminReqParameterError("cursor", "must be within the requested height range", cursorRaw)
+		}
+		if data.key != nil && cursor.Key != *data.key {
+			return admin.NewInvalidAdminReqParameterError("cursor", "must belong to the requested register", cursorRaw)
+		}
+		data.cursor = &cursor
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
+
+// parseHeightField parses the required height field with the given name.
+// Returns admin.InvalidAdminReqError if the field is missing or not a valid height.
+func parseHeightField(input map[string]interface{}, field string) (uint64, error) {
+	raw, ok := input[field]
+	if !ok {
+		return 0, admin.NewInvalidAdminReqErrorf("missing required field '%s'", field)
+	}
+
+	height, ok := raw.(float64)
+	if !ok || height < 0 || height != float64(uint64(height)) {
+		return 0, admin.NewInvalidAdminReqParameterError(field, "must be a non-negative integer", raw)
+	}
+
+	return uint64(height), nil
+}
+
+func NewReadRegisterHistoryCommand(registers *execution.RegistersAsyncStore) commands.AdminCommand {
+	return &ReadRegisterHistoryCommand{
+		registers: registers,
+	}
+}
@@ -0,0 +1,131 @@
+package state_synchronization
+
+import (
+	"context"
+	"encoding/hex"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadRegisterHistory(t *testing.T) {
+	address := unittest.AddressFixture()
+	registerID := flow.NewRegisterID(address, "key")
+	change := flow.RegisterChange{Key: registerID, Height: 5, Value: []byte("value")}
+
+	history := iter.Seq2[flow.RegisterChange, error](func(yield func(flow.RegisterChange, error) bool) {
+		yield(change, nil)
+	})
+
+	registers := storagemock.NewRegisterIndex(t)
+	registers.On("History", registerID, uint64(1), uint64(10)).Return(history).Once()
+	registers.On("OwnerHistory", registerID.Owner, uint64(1), uint64(10), (*flow.RegisterHistoryCursor)(nil)).Return(history).Once()
+
+	registersAsync := execution.NewRegistersAsyncStore()
+	require.NoError(t, registersAsync.Initialize(registers))
+
+	c := NewReadRegisterHistoryCommand(registersAsync)
+
+	expected := map[string]interface{}{
+		"changes": []interface{}{
+			map[string]interface{}{
+				"owner":  address.Hex(),
+				"key":    hex.EncodeToString([]byte("key")),
+				"height": uint64(5),
+				"value":  hex.EncodeToString([]byte("value")),
+			},
+		},
+	}
+
+	t.Run("register history", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"owner":        address.Hex(),
+				"key":          hex.EncodeToString([]byte("key")),
+				"start-height": float64(1),
+				"end-height":   float64(10),
+			},
+		}
+		require.NoError(t, c.Validator(req))
+
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	})
+
+	t.Run("owner history", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"owner":        address.Hex(),
+				"start-height": float64(1),
+				"end-height":   float64(10),
+			},
+		}
+		require.NoError(t, c.Validator(req))
+
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	})
+
+	t.Run("continues from cursor", func(t *testing.T) {
+		cursor := flow.RegisterHistoryCursor{Key: registerID.Key, Height: 7}
+		// the history of a single register is continued below the cursor height
+		registers.On("History", registerID, uint64(1), uint64(7)).Return(history).Once()
+
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"owner":        address.Hex(),
+				"key":          hex.EncodeToString([]byte("key")),
+				"start-height": float64(1),
+				"end-height":   float64(10),
+				"cursor":       cursor.Encode(),
+			},
+		}
+		require.NoError(t, c.Validator(req))
+
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+		require.Equal(t, expected, result)
+	})
+}
+
+func TestReadRegisterHistoryInvalid(t *testing.T) {
+	c := NewReadRegisterHistoryCommand(execution.NewRegistersAsyncStore())
+	address := unittest.AddressFixture().Hex()
+
+	tests := map[string]map[string]interface{}{
+		"missing owner":        {"start-height": float64(1), "end-height": float64(10)},
+		"invalid owner":        {"owner": "zz", "start-height": float64(1), "end-height": float64(10)},
+		"invalid key":          {"owner": address, "key": "zz", "start-height": float64(1), "end-height": float64(10)},
+		"missing start height": {"owner": address, "end-height": float64(10)},
+		"missing end height":   {"owner": address, "start-height": float64(1)},
+		"negative height":      {"owner": address, "start-height": float64(-1), "end-height": float64(10)},
+		"fractional height":    {"owner": address, "start-height": float64(1.5), "end-height": float64(10)},
+		"inverted range":       {"owner": address, "start-height": float64(10), "end-height": float64(1)},
+		"invalid cursor":       {"owner": address, "start-height": float64(1), "end-height": float64(10), "cursor": "zz"},
+		"cursor out of range":  {"owner": address, "start-height": float64(1), "end-height": float64(10), "cursor": flow.RegisterHistoryCursor{Key: "key", Height: 11}.Encode()},
+		"cursor of another register": {
+			"owner":        address,
+			"key":          hex.EncodeToString([]byte("key")),
+			"start-height": float64(1),
+			"end-height":   float64(10),
+			"cursor":       flow.RegisterHistoryCursor{Key: "other", Height: 5}.Encode(),
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := c.Validator(&admin.CommandRequest{Data: data})
+			require.Error(t, err)
+			require.True(t, admin.IsInvalidAdminParameterError(err))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package state_synchronization
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/access/ethrpc"
+)
+
+var _ commands.AdminCommand = (*TraceEVMTransactionCommand)(nil)
+
+// EVMTransactionTracer traces indexed EVM transactions.
+// It is implemented by ethrpc.Tracer.
+type EVMTransactionTracer interface {
+	TraceTransaction(ctx context.Context, hash gethCommon.Hash, config *ethrpc.TraceConfig) (json.RawMessage, error)
+}
+
+type traceEVMTransactionData struct {
+	hash   gethCommon.Hash
+	config *ethrpc.TraceConfig
+}
+
+// TraceEVMTransactionCommand replays an indexed EVM transaction and returns its trace.
+// The request accepts the same options as debug_traceTransaction, e.g.
+//
+//	{"hash": "0x...", "tracer": "callTracer", "tracerConfig": {"onlyTopCall": true}, "timeout": "2s"}
+type TraceEVMTransactionCommand struct {
+	tracer EVMTransactionTracer
+}
+
+func (t *TraceEVMTransactionCommand) Handler(ctx context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*traceEVMTransactionData)
+
+	trace, err := t.tracer.TraceTransaction(ctx, data.hash, data.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to trace EVM transaction %s: %w", data.hash, err)
+	}
+
+	var result interface{}
+	err = json.Unmarshal(trace, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode trace: %w", err)
+	}
+
+	return result, nil
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (t *TraceEVMTransactionCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	hashRaw, ok := input["hash"]
+	if !ok {
+		return admin.NewInvalidAdminReqErrorf("missing required field 'hash'")
+	}
+	hashStr, ok := hashRaw.(string)
+	if !ok {
+		return admin.NewInvalidAdminReqParameterError("hash", "must be a hex-encoded transaction hash", hashRaw)
+	}
+	hash := gethCommon.Hash{}
+	err := hash.UnmarshalText([]byte(hashStr))
+	if err != nil {
+		return admin.NewInvalidAdminReqParameterError("hash", "must be a hex-encoded transaction hash", hashRaw)
+	}
+
+	// the remaining fields are the trace options
+	options := make(map[string]interface{}, len(input))
+	for field, value := range input {
+		if field != "hash" {
+			options[field] = value
+		}
+	}
+	encoded, err := json.Marshal(options)
+	if err != nil {
+		return admin.NewInvalidAdminReqErrorf("invalid trace options: %v", err)
+	}
+	config := &ethrpc.TraceConfig{}
+	err = json.Unmarshal(encoded, config)
+	if err != nil {
+		return admin.NewInvalidAdminReqErrorf("invalid trace options: %v", err)
+	}
+
+	req.ValidatorData = &traceEVMTransactionData{
+		hash:   hash,
+		config: config,
+	}
+
+	return nil
+}
+
+func NewTraceEVMTransactionCommand(tracer EVMTransactionTracer) commands.AdminCommand {
+	return &TraceEVMTransactionCommand{
+		tracer: tracer,
+	}
+}
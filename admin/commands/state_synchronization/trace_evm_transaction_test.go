@@ -0,0 +1,85 @@
+package state_synchronization
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/access/ethrpc"
+)
+
+type testEVMTransactionTracer struct {
+	hash   gethCommon.Hash
+	config *ethrpc.TraceConfig
+}
+
+func (t *testEVMTransactionTracer) TraceTransaction(_ context.Context, hash gethCommon.Hash, config *ethrpc.TraceConfig) (json.RawMessage, error) {
+	t.hash = hash
+	t.config = config
+	return json.RawMessage(`{"type":"CALL","gas":"0x5208"}`), nil
+}
+
+func TestTraceEVMTransaction(t *testing.T) {
+	tracer := &testEVMTransactionTracer{}
+	c := NewTraceEVMTransactionCommand(tracer)
+	hash := gethCommon.HexToHash("0x0102")
+
+	t.Run("trace", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"hash":         hash.Hex(),
+				"tracer":       "callTracer",
+				"timeout":      "2s",
+				"tracerConfig": map[string]interface{}{"onlyTopCall": true},
+			},
+		}
+		require.NoError(t, c.Validator(req))
+
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"type": "CALL", "gas": "0x5208"}, result)
+
+		assert.Equal(t, hash, tracer.hash)
+		require.NotNil(t, tracer.config.Tracer)
+		assert.Equal(t, "callTracer", *tracer.config.Tracer)
+		require.NotNil(t, tracer.config.Timeout)
+		assert.Equal(t, "2s", *tracer.config.Timeout)
+		assert.JSONEq(t, `{"onlyTopCall":true}`, string(tracer.config.TracerConfig))
+	})
+
+	t.Run("struct logger options", func(t *testing.T) {
+		req := &admin.CommandRequest{
+			Data: map[string]interface{}{
+				"hash":         hash.Hex(),
+				"enableMemory": true,
+				"limit":        float64(10),
+			},
+		}
+		require.NoError(t, c.Validator(req))
+
+		data := req.ValidatorData.(*traceEVMTransactionData)
+		assert.Nil(t, data.config.Tracer)
+		require.NotNil(t, data.config.Config)
+		assert.True(t, data.config.EnableMemory)
+		assert.Equal(t, 10, data.config.Limit)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		invalid := []interface{}{
+			"not a map",
+			map[string]interface{}{},
+			map[string]interface{}{"hash": 1},
+			map[string]interface{}{"hash": "0x01"},
+			map[string]interface{}{"hash": hash.Hex(), "tracer": 1},
+		}
+		for _, data := range invalid {
+			err := c.Validator(&admin.CommandRequest{Data: data})
+			require.True(t, admin.IsInvalidAdminParameterError(err), "data: %v, err: %v", data, err)
+		}
+	})
+}
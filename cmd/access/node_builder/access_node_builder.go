@@ -43,6 +43,8 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff/verification"
 	recovery "github.com/onflow/flow-go/consensus/recovery/protocol"
 	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/access/ethrpc"
+	"github.com/onflow/flow-go/engine/access/graphql"
 	"github.com/onflow/flow-go/engine/access/index"
 	"github.com/onflow/flow-go/engine/access/ingestion"
 	"github.com/onflow/flow-go/engine/access/ingestion/tx_error_messages"
@@ -65,6 +67,7 @@ import (
 	"github.com/onflow/flow-go/engine/common/version"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/query"
+	"github.com/onflow/flow-go/fvm/environment"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/wal"
@@ -84,10 +87,13 @@ import (
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
 	"github.com/onflow/flow-go/module/grpcserver"
 	"github.com/onflow/flow-go/module/id"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/herocache"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/metrics/unstaked"
+	"github.com/onflow/flow-go/module/profiler"
+	"github.com/onflow/flow-go/module/pruner/pruners"
 	"github.com/onflow/flow-go/module/state_synchronization"
 	"github.com/onflow/flow-go/module/state_synchronization/indexer"
 	edrequester "github.com/onflow/flow-go/module/state_synchronization/requester"
@@ -172,20 +178,31 @@ type AccessNodeConfig struct {
 	executionDataConfig                  edrequester.ExecutionDataConfig
 	PublicNetworkConfig                  PublicNetworkConfig
 	TxResultCacheSize                    uint
+	scriptResultCacheSize                uint
+	scriptResultCacheMaxResultSize       uint
+	transactionTimelinesLimit            uint
 	executionDataIndexingEnabled         bool
+	accountTransactionsIndexEnabled      bool
 	registersDBPath                      string
 	checkpointFile                       string
 	scriptExecutorConfig                 query.QueryConfig
 	scriptExecMinBlock                   uint64
 	scriptExecMaxBlock                   uint64
+	scriptProfilingEnabled               bool
+	scriptProfileDir                     string
+	scriptProfileInterval                time.Duration
 	registerCacheType                    string
 	registerCacheSize                    uint
+	registerCacheHeights                 uint64
 	programCacheSize                     uint
 	checkPayerBalanceMode                string
 	versionControlEnabled                bool
 	storeTxResultErrorMessages           bool
 	stopControlEnabled                   bool
 	registerDBPruneThreshold             uint64
+	registerDBPruningEnabled             bool
+	registerDBPrunerConfig               pruners.RegisterPrunerConfig
+	evmRPCConf                           ethrpc.Config
 }
 
 type PublicNetworkConfig struct {
@@ -213,6 +230,7 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 				ExecutionClientTimeout:    3 * time.Second,
 				ConnectionPoolSize:        backend.DefaultConnectionPoolSize,
 				MaxHeightRange:            backend.DefaultMaxHeightRange,
+				MaxEventQueryHeightRange:  backend.DefaultMaxEventQueryHeightRange,
 				PreferredExecutionNodeIDs: nil,
 				FixedExecutionNodeIDs:     nil,
 				CircuitBreakerConfig: rpcConnection.CircuitBreakerConfig{
@@ -231,11 +249,13 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 				ReadTimeout:    rest.DefaultReadTimeout,
 				IdleTimeout:    rest.DefaultIdleTimeout,
 				MaxRequestSize: commonrest.DefaultMaxRequestSize,
+				Batch:          router.DefaultBatchConfig(),
 			},
 			MaxMsgSize:                grpcutils.DefaultMaxMsgSize,
 			CompressorName:            grpcutils.NoCompressor,
 			WebSocketConfig:           websockets.NewDefaultWebsocketConfig(),
 			EnableWebSocketsStreamAPI: false,
+			GraphQLConfig:             graphql.DefaultConfig(),
 		},
 		stateStreamConf: statestreambackend.Config{
 			MaxExecutionDataMsgSize: grpcutils.DefaultMaxMsgSize,
@@ -278,6 +298,8 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 			MaxRetryDelay:      edrequester.DefaultMaxRetryDelay,
 		},
 		executionDataIndexingEnabled:         false,
+		accountTransactionsIndexEnabled:      false,
+		evmRPCConf:                           ethrpc.DefaultConfig(),
 		executionDataDBMode:                  execution_data.ExecutionDataDBModeBadger.String(),
 		executionDataPrunerHeightRangeTarget: 0,
 		executionDataPrunerThreshold:         pruner.DefaultThreshold,
@@ -287,14 +309,23 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		scriptExecutorConfig:                 query.NewDefaultConfig(),
 		scriptExecMinBlock:                   0,
 		scriptExecMaxBlock:                   math.MaxUint64,
+		scriptProfilingEnabled:               false,
+		scriptProfileDir:                     "profiler",
+		scriptProfileInterval:                15 * time.Minute,
 		registerCacheType:                    pstorage.CacheTypeTwoQueue.String(),
 		registerCacheSize:                    0,
+		registerCacheHeights:                 0,
 		programCacheSize:                     0,
+		scriptResultCacheSize:                0,
+		scriptResultCacheMaxResultSize:       backend.DefaultScriptResultCacheMaxResultSize,
+		transactionTimelinesLimit:            0,
 		checkPayerBalanceMode:                accessNode.Disabled.String(),
 		versionControlEnabled:                true,
 		storeTxResultErrorMessages:           false,
 		stopControlEnabled:                   false,
 		registerDBPruneThreshold:             0,
+		registerDBPruningEnabled:             false,
+		registerDBPrunerConfig:               pruners.DefaultRegisterPrunerConfig,
 	}
 }
 
@@ -309,6 +340,7 @@ type FlowAccessNodeBuilder struct {
 	FollowerState                protocol.FollowerState
 	SyncCore                     *chainsync.Core
 	RpcEng                       *rpc.Engine
+	EVMRPCEng                    *ethrpc.Engine
 	FollowerDistributor          *consensuspubsub.FollowerDistributor
 	CollectionRPC                access.AccessAPIClient
 	TransactionTimings           *stdmap.TransactionTimings
@@ -316,6 +348,7 @@ type FlowAccessNodeBuilder struct {
 	CollectionsToMarkExecuted    *stdmap.Times
 	BlocksToMarkExecuted         *stdmap.Times
 	BlockTransactions            *stdmap.IdentifierMap
+	TransactionTimelines         mempool.TransactionTimings
 	TransactionMetrics           *metrics.TransactionCollector
 	TransactionValidationMetrics *metrics.TransactionValidationCollector
 	RestMetrics                  *metrics.RestCollector
@@ -339,6 +372,7 @@ type FlowAccessNodeBuilder struct {
 	Reporter                     *index.Reporter
 	EventsIndex                  *index.EventsIndex
 	TxResultsIndex               *index.TransactionResultsIndex
+	AccountTransactionsIndex     *index.AccountTransactionsIndex
 	IndexerDependencies          *cmd.DependencyList
 	collectionExecutedMetric     module.CollectionExecutedMetric
 	ExecutionDataPruner          *pruner.Pruner
@@ -363,6 +397,7 @@ type FlowAccessNodeBuilder struct {
 	secureGrpcServer      *grpcserver.GrpcServer
 	unsecureGrpcServer    *grpcserver.GrpcServer
 	stateStreamGrpcServer *grpcserver.GrpcServer
+	apiRateLimiter        *commonrpc.RateLimiterInterceptor // limiter of the unsecure gRPC server, shared with the GraphQL server. nil if no rate limits are configured
 
 	stateStreamBackend *statestreambackend.StateStreamBackend
 	nodeBackend        *backend.Backend
@@ -861,12 +896,18 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 	}
 
 	if builder.executionDataIndexingEnabled {
+		var registers *pstorage.Registers
+		indexerDependable := module.NewProxiedReadyDoneAware()
+
 		var indexedBlockHeight storage.ConsumerProgressInitializer
 
 		builder.
 			AdminCommand("execute-script", func(config *cmd.NodeConfig) commands.AdminCommand {
 				return stateSyncCommands.NewExecuteScriptCommand(builder.ScriptExecutor)
 			}).
+			AdminCommand("read-register-history", func(config *cmd.NodeConfig) commands.AdminCommand {
+				return stateSyncCommands.NewReadRegisterHistoryCommand(builder.RegistersAsyncStore)
+			}).
 			Module("indexed block height consumer progress", func(node *cmd.NodeConfig) error {
 				// Note: progress is stored in the MAIN db since that is where indexed execution data is stored.
 				indexedBlockHeight = store.NewConsumerProgress(badgerimpl.ToDB(builder.DB), module.ConsumeProgressExecutionDataIndexerBlockHeight)
@@ -876,6 +917,36 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 				builder.Storage.LightTransactionResults = bstorage.NewLightTransactionResults(node.Metrics.Cache, node.DB, bstorage.DefaultCacheSize)
 				return nil
 			}).
+			Module("account transactions storage", func(node *cmd.NodeConfig) error {
+				if builder.accountTransactionsIndexEnabled {
+					builder.Storage.AccountTransactions = bstorage.NewAccountTransactions(node.DB)
+				}
+				return nil
+			}).
+			Module("evm blocks storage", func(node *cmd.NodeConfig) error {
+				if builder.evmRPCConf.ListenAddress != "" {
+					builder.Storage.EVMBlocks = bstorage.NewEVMBlocks(node.DB)
+				}
+				return nil
+			}).
+			Module("script execution profiler", func(node *cmd.NodeConfig) error {
+				if builder.scriptProfilingEnabled {
+					builder.scriptExecutorConfig.ComputationProfiler = environment.NewComputationProfiler()
+				}
+				return nil
+			}).
+			Component("script execution profile writer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+				if builder.scriptExecutorConfig.ComputationProfiler == nil {
+					return &module.NoopReadyDoneAware{}, nil
+				}
+				return profiler.NewProfileWriter(
+					node.Logger,
+					builder.scriptExecutorConfig.ComputationProfiler,
+					builder.scriptProfileDir,
+					"cadence-scripts",
+					builder.scriptProfileInterval,
+				)
+			}).
 			DependableComponent("execution data indexer", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 				// Note: using a DependableComponent here to ensure that the indexer does not block
 				// other components from starting while bootstrapping the register db since it may
@@ -934,7 +1005,7 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					}
 				}
 
-				registers, err := pstorage.NewRegisters(pdb, builder.registerDBPruneThreshold)
+				registers, err = pstorage.NewRegisters(pdb, builder.registerDBPruneThreshold)
 				if err != nil {
 					return nil, fmt.Errorf("could not create registers storage: %w", err)
 				}
@@ -945,11 +1016,19 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 						return nil, fmt.Errorf("could not parse register cache type: %w", err)
 					}
 					cacheMetrics := metrics.NewCacheCollector(builder.RootChainID)
-					registersCache, err := pstorage.NewRegistersCache(registers, cacheType, builder.registerCacheSize, cacheMetrics)
-					if err != nil {
-						return nil, fmt.Errorf("could not create registers cache: %w", err)
+					if builder.registerCacheHeights > 0 {
+						registersCache, err := pstorage.NewMultiVersionRegistersCache(registers, cacheType, builder.registerCacheSize, builder.registerCacheHeights, cacheMetrics)
+						if err != nil {
+							return nil, fmt.Errorf("could not create multi-version registers cache: %w", err)
+						}
+						builder.Storage.RegisterIndex = registersCache
+					} else {
+						registersCache, err := pstorage.NewRegistersCache(registers, cacheType, builder.registerCacheSize, cacheMetrics)
+						if err != nil {
+							return nil, fmt.Errorf("could not create registers cache: %w", err)
+						}
+						builder.Storage.RegisterIndex = registersCache
 					}
-					builder.Storage.RegisterIndex = registersCache
 				} else {
 					builder.Storage.RegisterIndex = registers
 				}
@@ -969,6 +1048,7 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.Storage.Collections,
 					builder.Storage.Transactions,
 					builder.Storage.LightTransactionResults,
+					builder.Storage.AccountTransactions,
 					builder.RootChainID.Chain(),
 					indexerDerivedChainData,
 					builder.collectionExecutedMetric,
@@ -1032,8 +1112,24 @@ func (builder *FlowAccessNodeBuilder) BuildExecutionSyncComponents() *FlowAccess
 					builder.StopControl.RegisterHeightRecorder(builder.ExecutionIndexer)
 				}
 
+				indexerDependable.Init(builder.ExecutionIndexer)
+
 				return builder.ExecutionIndexer, nil
 			}, builder.IndexerDependencies)
+
+		if builder.registerDBPruningEnabled {
+			builder.DependableComponent("register db pruner", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+				config := builder.registerDBPrunerConfig
+				config.Threshold = builder.registerDBPruneThreshold
+
+				return pruners.NewRegisterPruner(
+					node.Logger,
+					metrics.NewRegisterPrunerCollector(),
+					registers,
+					config,
+				)
+			}, cmd.NewDependencyList(indexerDependable))
+		}
 	}
 
 	if builder.stateStreamConf.ListenAddr != "" {
@@ -1212,6 +1308,30 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"rest-max-request-size",
 			defaultConfig.rpcConf.RestConfig.MaxRequestSize,
 			"the maximum request size in bytes for payload sent over REST server")
+		flags.IntVar(&builder.rpcConf.RestConfig.Batch.MaxRequests,
+			"rest-batch-max-requests",
+			defaultConfig.rpcConf.RestConfig.Batch.MaxRequests,
+			"the maximum number of requests in a single REST batch request (0 disables the batch endpoint)")
+		flags.IntVar(&builder.rpcConf.RestConfig.Batch.MaxConcurrency,
+			"rest-batch-max-concurrency",
+			defaultConfig.rpcConf.RestConfig.Batch.MaxConcurrency,
+			"the maximum number of requests of a REST batch request executed concurrently")
+		flags.Int64Var(&builder.rpcConf.RestConfig.Batch.MaxResponseSize,
+			"rest-batch-max-response-size",
+			defaultConfig.rpcConf.RestConfig.Batch.MaxResponseSize,
+			"the maximum combined size in bytes of the responses of a REST batch request")
+		flags.StringVar(&builder.rpcConf.GraphQLConfig.ListenAddress,
+			"graphql-addr",
+			defaultConfig.rpcConf.GraphQLConfig.ListenAddress,
+			"the address the GraphQL server listens on (if empty the GraphQL server will not be started)")
+		flags.Uint64Var(&builder.rpcConf.GraphQLConfig.MaxQueryCost,
+			"graphql-max-query-cost",
+			defaultConfig.rpcConf.GraphQLConfig.MaxQueryCost,
+			"the maximum number of Access API calls a single GraphQL query may make. calls are also subject to the api-rate-limits of each method")
+		flags.IntVar(&builder.rpcConf.GraphQLConfig.MaxDepth,
+			"graphql-max-depth",
+			defaultConfig.rpcConf.GraphQLConfig.MaxDepth,
+			"the maximum nesting depth of a GraphQL query")
 		flags.StringVarP(&builder.rpcConf.CollectionAddr,
 			"static-collection-ingress-addr",
 			"",
@@ -1247,6 +1367,10 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"rpc-max-height-range",
 			defaultConfig.rpcConf.BackendConfig.MaxHeightRange,
 			"maximum size for height range requests")
+		flags.UintVar(&builder.rpcConf.BackendConfig.MaxEventQueryHeightRange,
+			"rpc-max-event-query-height-range",
+			defaultConfig.rpcConf.BackendConfig.MaxEventQueryHeightRange,
+			"maximum size for height range requests of event queries, which are served from the local event index")
 		flags.StringSliceVar(&builder.rpcConf.BackendConfig.PreferredExecutionNodeIDs,
 			"preferred-execution-node-ids",
 			defaultConfig.rpcConf.BackendConfig.PreferredExecutionNodeIDs,
@@ -1276,6 +1400,9 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.BoolVar(&builder.retryEnabled, "retry-enabled", defaultConfig.retryEnabled, "whether to enable the retry mechanism at the access node level")
 		flags.BoolVar(&builder.rpcMetricsEnabled, "rpc-metrics-enabled", defaultConfig.rpcMetricsEnabled, "whether to enable the rpc metrics")
 		flags.UintVar(&builder.TxResultCacheSize, "transaction-result-cache-size", defaultConfig.TxResultCacheSize, "transaction result cache size.(Disabled by default i.e 0)")
+		flags.UintVar(&builder.scriptResultCacheSize, "script-result-cache-size", defaultConfig.scriptResultCacheSize, "number of script results at sealed blocks to cache, clients can bypass the cache with the 'Cache-Control: no-cache' header.(Disabled by default i.e 0)")
+		flags.UintVar(&builder.scriptResultCacheMaxResultSize, "script-result-cache-max-result-size", defaultConfig.scriptResultCacheMaxResultSize, "maximum size in bytes of a script result stored in the script result cache")
+		flags.UintVar(&builder.transactionTimelinesLimit, "transaction-timelines-limit", defaultConfig.transactionTimelinesLimit, "maximum number of lifecycle timelines of submitted transactions kept in memory.(Disabled by default i.e 0)")
 		flags.StringVarP(&builder.nodeInfoFile,
 			"node-info-file",
 			"",
@@ -1397,6 +1524,72 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"execution-data-indexing-enabled",
 			defaultConfig.executionDataIndexingEnabled,
 			"whether to enable the execution data indexing")
+		flags.BoolVar(&builder.accountTransactionsIndexEnabled,
+			"account-transactions-index-enabled",
+			defaultConfig.accountTransactionsIndexEnabled,
+			"whether to index transactions by the accounts that proposed, paid for or authorized them. only blocks indexed after it is enabled can be queried. requires execution data indexing")
+
+		// EVM JSON-RPC
+		flags.StringVar(&builder.evmRPCConf.ListenAddress,
+			"evm-rpc-addr",
+			defaultConfig.evmRPCConf.ListenAddress,
+			"the address the Ethereum JSON-RPC server for Flow EVM listens on (if empty the server will not be started). requires execution data indexing")
+		flags.Uint64Var(&builder.evmRPCConf.MaxCallGasLimit,
+			"evm-rpc-max-call-gas-limit",
+			defaultConfig.evmRPCConf.MaxCallGasLimit,
+			"the maximum gas limit of eth_call and eth_estimateGas requests")
+		flags.Uint64Var(&builder.evmRPCConf.MaxLogsBlockRange,
+			"evm-rpc-max-logs-block-range",
+			defaultConfig.evmRPCConf.MaxLogsBlockRange,
+			"the maximum number of EVM blocks an eth_getLogs request may span")
+		flags.Uint64Var(&builder.evmRPCConf.GasPrice,
+			"evm-rpc-gas-price",
+			defaultConfig.evmRPCConf.GasPrice,
+			"the gas price returned by eth_gasPrice, in attoFLOW. transactions with a lower gas price are rejected by eth_sendRawTransaction")
+		flags.DurationVar(&builder.evmRPCConf.Tracing.Timeout,
+			"evm-rpc-trace-timeout",
+			defaultConfig.evmRPCConf.Tracing.Timeout,
+			"the maximum duration of a debug_traceTransaction request")
+		flags.Uint64Var(&builder.evmRPCConf.Tracing.MaxTraceSize,
+			"evm-rpc-max-trace-size",
+			defaultConfig.evmRPCConf.Tracing.MaxTraceSize,
+			"the maximum size of a transaction trace, in bytes")
+		flags.Uint64Var(&builder.evmRPCConf.Tracing.MaxStructLogs,
+			"evm-rpc-max-struct-logs",
+			defaultConfig.evmRPCConf.Tracing.MaxStructLogs,
+			"the maximum number of opcodes recorded by a struct logs transaction trace")
+		flags.StringVar(&builder.evmRPCConf.Sender.FlowAddress,
+			"evm-rpc-flow-address",
+			defaultConfig.evmRPCConf.Sender.FlowAddress,
+			"the Flow account paying for the Cadence transactions submitted by eth_sendRawTransaction (if empty eth_sendRawTransaction is disabled)")
+		flags.Uint32Var(&builder.evmRPCConf.Sender.KeyIndex,
+			"evm-rpc-flow-key-index",
+			defaultConfig.evmRPCConf.Sender.KeyIndex,
+			"the index of the first account key signing the Cadence transactions submitted by eth_sendRawTransaction")
+		flags.Uint32Var(&builder.evmRPCConf.Sender.KeyCount,
+			"evm-rpc-flow-key-count",
+			defaultConfig.evmRPCConf.Sender.KeyCount,
+			"the number of account keys, starting at --evm-rpc-flow-key-index, signing the Cadence transactions submitted by eth_sendRawTransaction. all the keys must use the private key of --evm-rpc-flow-key-file")
+		flags.StringVar(&builder.evmRPCConf.Sender.KeyFile,
+			"evm-rpc-flow-key-file",
+			defaultConfig.evmRPCConf.Sender.KeyFile,
+			"the file containing the hex encoded private key signing the Cadence transactions submitted by eth_sendRawTransaction")
+		flags.StringVar(&builder.evmRPCConf.Sender.Coinbase,
+			"evm-rpc-coinbase",
+			defaultConfig.evmRPCConf.Sender.Coinbase,
+			"the EVM address receiving the fees of the EVM transactions submitted by eth_sendRawTransaction")
+		flags.Uint64Var(&builder.evmRPCConf.Sender.ComputeLimit,
+			"evm-rpc-compute-limit",
+			defaultConfig.evmRPCConf.Sender.ComputeLimit,
+			"the compute limit of the Cadence transactions submitted by eth_sendRawTransaction")
+		flags.Float64Var(&builder.evmRPCConf.Sender.RateLimit,
+			"evm-rpc-send-rate-limit",
+			defaultConfig.evmRPCConf.Sender.RateLimit,
+			"the number of eth_sendRawTransaction requests allowed per second per client IP address (0 disables the rate limit)")
+		flags.IntVar(&builder.evmRPCConf.Sender.RateBurst,
+			"evm-rpc-send-rate-burst",
+			defaultConfig.evmRPCConf.Sender.RateBurst,
+			"the number of eth_sendRawTransaction requests a client IP address may send at once")
 		flags.StringVar(&builder.registersDBPath, "execution-state-dir", defaultConfig.registersDBPath, "directory to use for execution-state database")
 		flags.StringVar(&builder.checkpointFile, "execution-state-checkpoint", defaultConfig.checkpointFile, "execution-state checkpoint file")
 
@@ -1442,6 +1635,18 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"script-execution-max-height",
 			defaultConfig.scriptExecMaxBlock,
 			"highest block height to allow for script execution. default: no limit")
+		flags.BoolVar(&builder.scriptProfilingEnabled,
+			"script-execution-profiling-enabled",
+			defaultConfig.scriptProfilingEnabled,
+			"whether to profile the computation used by locally executed scripts. profiles attribute computation to Cadence call stacks")
+		flags.StringVar(&builder.scriptProfileDir,
+			"script-execution-profile-dir",
+			defaultConfig.scriptProfileDir,
+			"directory to write the profiles of locally executed scripts to")
+		flags.DurationVar(&builder.scriptProfileInterval,
+			"script-execution-profile-interval",
+			defaultConfig.scriptProfileInterval,
+			"interval at which the profile of locally executed scripts is written. default: 15m")
 		flags.StringVar(&builder.registerCacheType,
 			"register-cache-type",
 			defaultConfig.registerCacheType,
@@ -1450,6 +1655,10 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"register-cache-size",
 			defaultConfig.registerCacheSize,
 			"number of registers to cache for script execution. default: 0 (no cache)")
+		flags.Uint64Var(&builder.registerCacheHeights,
+			"register-cache-heights",
+			defaultConfig.registerCacheHeights,
+			"number of recent heights to serve from the register cache. when set, the cache holds the values of the cached registers for all the recent heights, and is updated as new heights are indexed. default: 0 (cache values per height)")
 		flags.UintVar(&builder.programCacheSize,
 			"program-cache-size",
 			defaultConfig.programCacheSize,
@@ -1466,6 +1675,22 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			"registerdb-pruning-threshold",
 			defaultConfig.registerDBPruneThreshold,
 			fmt.Sprintf("specifies the number of blocks below the latest stored block height to keep in register db. default: %d", defaultConfig.registerDBPruneThreshold))
+		flags.BoolVar(&builder.registerDBPruningEnabled,
+			"registerdb-pruning-enabled",
+			defaultConfig.registerDBPruningEnabled,
+			"whether to enable removing register values below the registerdb-pruning-threshold from the register db")
+		flags.DurationVar(&builder.registerDBPrunerConfig.PruningInterval,
+			"registerdb-pruning-interval",
+			defaultConfig.registerDBPrunerConfig.PruningInterval,
+			"duration between register db pruning runs")
+		flags.UintVar(&builder.registerDBPrunerConfig.BatchSize,
+			"registerdb-pruning-batch-size",
+			defaultConfig.registerDBPrunerConfig.BatchSize,
+			"number of register values to remove from the register db in one batch")
+		flags.DurationVar(&builder.registerDBPrunerConfig.SleepAfterEachBatchCommit,
+			"registerdb-pruning-throttle-delay",
+			defaultConfig.registerDBPrunerConfig.SleepAfterEachBatchCommit,
+			"duration to wait between register db pruning batches")
 
 		flags.DurationVar(&builder.rpcConf.WebSocketConfig.InactivityTimeout,
 			"websocket-inactivity-timeout",
@@ -1542,9 +1767,71 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 			return errors.New("execution-data-indexing-enabled must be set if check-payer-balance is enabled")
 		}
 
+		if builder.scriptProfilingEnabled {
+			if !builder.executionDataIndexingEnabled {
+				return errors.New("execution-data-indexing-enabled must be set if script-execution-profiling-enabled is set")
+			}
+			if builder.scriptProfileInterval <= 0 {
+				return errors.New("script-execution-profile-interval must be greater than 0")
+			}
+		}
+
+		if builder.accountTransactionsIndexEnabled && !builder.executionDataIndexingEnabled {
+			return errors.New("execution-data-indexing-enabled must be set if account-transactions-index is enabled")
+		}
+
+		if builder.evmRPCConf.ListenAddress != "" {
+			if !builder.executionDataIndexingEnabled {
+				return errors.New("execution-data-indexing-enabled must be set if evm-rpc-addr is set")
+			}
+			if builder.evmRPCConf.MaxLogsBlockRange == 0 {
+				return errors.New("evm-rpc-max-logs-block-range must be greater than 0")
+			}
+			if builder.evmRPCConf.Tracing.Timeout <= 0 {
+				return errors.New("evm-rpc-trace-timeout must be greater than 0")
+			}
+			if builder.evmRPCConf.Sender.Enabled() {
+				if builder.evmRPCConf.Sender.KeyFile == "" {
+					return errors.New("evm-rpc-flow-key-file must be set if evm-rpc-flow-address is set")
+				}
+				if builder.evmRPCConf.Sender.Coinbase == "" {
+					return errors.New("evm-rpc-coinbase must be set if evm-rpc-flow-address is set")
+				}
+			}
+		}
+
+		if builder.registerDBPruningEnabled {
+			if !builder.executionDataIndexingEnabled {
+				return errors.New("execution-data-indexing-enabled must be set if registerdb-pruning is enabled")
+			}
+			if builder.registerDBPruneThreshold == 0 {
+				return errors.New("registerdb-pruning-threshold must be greater than 0 if registerdb-pruning is enabled")
+			}
+			if builder.registerDBPrunerConfig.PruningInterval <= 0 {
+				return errors.New("registerdb-pruning-interval must be greater than 0")
+			}
+			if builder.registerDBPrunerConfig.BatchSize == 0 {
+				return errors.New("registerdb-pruning-batch-size must be greater than 0")
+			}
+		}
+
 		if builder.rpcConf.RestConfig.MaxRequestSize <= 0 {
 			return errors.New("rest-max-request-size must be greater than 0")
 		}
+		if builder.rpcConf.RestConfig.Batch.MaxRequests < 0 {
+			return errors.New("rest-batch-max-requests must be greater than or equal to 0")
+		}
+		if builder.rpcConf.RestConfig.Batch.MaxRequests > 0 {
+			if builder.rpcConf.RestConfig.Batch.MaxConcurrency <= 0 {
+				return errors.New("rest-batch-max-concurrency must be greater than 0")
+			}
+			if builder.rpcConf.RestConfig.Batch.MaxResponseSize <= 0 {
+				return errors.New("rest-batch-max-response-size must be greater than 0")
+			}
+		}
+		if builder.rpcConf.GraphQLConfig.MaxDepth <= 0 {
+			return errors.New("graphql-max-depth must be greater than 0")
+		}
 
 		return nil
 	})
@@ -1734,6 +2021,14 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			}
 
 			builder.BlocksToMarkExecuted, err = stdmap.NewTimes(1 * 300) // assume 1 block per second * 300 seconds
+			if err != nil {
+				return err
+			}
+
+			// timelines use a separate pool, since timings are removed as soon as the transaction is sealed
+			if builder.transactionTimelinesLimit > 0 {
+				builder.TransactionTimelines, err = stdmap.NewTransactionTimings(builder.transactionTimelinesLimit)
+			}
 
 			return err
 		}).
@@ -1780,6 +2075,7 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				builder.Storage.Collections,
 				builder.Storage.Blocks,
 				builder.BlockTransactions,
+				builder.TransactionTimelines,
 			)
 			if err != nil {
 				return err
@@ -1802,6 +2098,12 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			return nil
 		}).
 		Module("creating grpc servers", func(node *cmd.NodeConfig) error {
+			if len(builder.apiRatelimits) > 0 {
+				// each gRPC server has its own limiter. This one is used by the server of the unsecure API, and
+				// shared with the GraphQL server so that GraphQL queries count towards the same limits
+				builder.apiRateLimiter = commonrpc.NewRateLimiterInterceptor(node.Logger, builder.apiRatelimits, builder.apiBurstlimits)
+			}
+
 			builder.secureGrpcServer = grpcserver.NewGrpcServerBuilder(
 				node.Logger,
 				builder.rpcConf.SecureGRPCListenAddr,
@@ -1811,6 +2113,12 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				builder.apiBurstlimits,
 				grpcserver.WithTransportCredentials(builder.rpcConf.TransportCredentials)).Build()
 
+			stateStreamOptions := []grpcserver.Option{grpcserver.WithStreamInterceptor()}
+			if builder.rpcConf.UnsecureGRPCListenAddr == builder.stateStreamConf.ListenAddr {
+				// the state stream server also serves the unsecure API
+				stateStreamOptions = append(stateStreamOptions, grpcserver.WithRateLimiter(builder.apiRateLimiter))
+			}
+
 			builder.stateStreamGrpcServer = grpcserver.NewGrpcServerBuilder(
 				node.Logger,
 				builder.stateStreamConf.ListenAddr,
@@ -1818,7 +2126,7 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				builder.rpcMetricsEnabled,
 				builder.apiRatelimits,
 				builder.apiBurstlimits,
-				grpcserver.WithStreamInterceptor()).Build()
+				stateStreamOptions...).Build()
 
 			if builder.rpcConf.UnsecureGRPCListenAddr != builder.stateStreamConf.ListenAddr {
 				builder.unsecureGrpcServer = grpcserver.NewGrpcServerBuilder(node.Logger,
@@ -1826,7 +2134,8 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 					builder.rpcConf.MaxMsgSize,
 					builder.rpcMetricsEnabled,
 					builder.apiRatelimits,
-					builder.apiBurstlimits).Build()
+					builder.apiBurstlimits,
+					grpcserver.WithRateLimiter(builder.apiRateLimiter)).Build()
 			} else {
 				builder.unsecureGrpcServer = builder.stateStreamGrpcServer
 			}
@@ -1857,6 +2166,12 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			builder.TxResultsIndex = index.NewTransactionResultsIndex(builder.Reporter, builder.Storage.LightTransactionResults)
 			return nil
 		}).
+		Module("account transactions index", func(node *cmd.NodeConfig) error {
+			if builder.Storage.AccountTransactions != nil {
+				builder.AccountTransactionsIndex = index.NewAccountTransactionsIndex(builder.Reporter, builder.Storage.AccountTransactions)
+			}
+			return nil
+		}).
 		Module("processed finalized block height consumer progress", func(node *cmd.NodeConfig) error {
 			processedFinalizedBlockHeight = store.NewConsumerProgress(badgerimpl.ToDB(builder.DB), module.ConsumeProgressIngestionEngineBlockHeight)
 			return nil
@@ -2028,31 +2343,40 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 				fixedENIdentifiers,
 			)
 
+			// register history is served from the local register index
+			var registers *execution.RegistersAsyncStore
+			if builder.executionDataIndexingEnabled {
+				registers = builder.RegistersAsyncStore
+			}
+
+			nodeCommunicator := backend.NewNodeCommunicator(backendConfig.CircuitBreakerConfig.Enabled)
+
 			builder.nodeBackend, err = backend.New(backend.Params{
-				State:                 node.State,
-				CollectionRPC:         builder.CollectionRPC,
-				HistoricalAccessNodes: builder.HistoricalAccessRPCs,
-				Blocks:                node.Storage.Blocks,
-				Headers:               node.Storage.Headers,
-				Collections:           node.Storage.Collections,
-				Transactions:          node.Storage.Transactions,
-				ExecutionReceipts:     node.Storage.Receipts,
-				ExecutionResults:      node.Storage.Results,
-				TxResultErrorMessages: node.Storage.TransactionResultErrorMessages,
-				ChainID:               node.RootChainID,
-				AccessMetrics:         builder.AccessMetrics,
-				ConnFactory:           connFactory,
-				RetryEnabled:          builder.retryEnabled,
-				MaxHeightRange:        backendConfig.MaxHeightRange,
-				Log:                   node.Logger,
-				SnapshotHistoryLimit:  backend.DefaultSnapshotHistoryLimit,
-				Communicator:          backend.NewNodeCommunicator(backendConfig.CircuitBreakerConfig.Enabled),
-				TxResultCacheSize:     builder.TxResultCacheSize,
-				ScriptExecutor:        builder.ScriptExecutor,
-				ScriptExecutionMode:   scriptExecMode,
-				CheckPayerBalanceMode: checkPayerBalanceMode,
-				EventQueryMode:        eventQueryMode,
-				BlockTracker:          blockTracker,
+				State:                    node.State,
+				CollectionRPC:            builder.CollectionRPC,
+				HistoricalAccessNodes:    builder.HistoricalAccessRPCs,
+				Blocks:                   node.Storage.Blocks,
+				Headers:                  node.Storage.Headers,
+				Collections:              node.Storage.Collections,
+				Transactions:             node.Storage.Transactions,
+				ExecutionReceipts:        node.Storage.Receipts,
+				ExecutionResults:         node.Storage.Results,
+				TxResultErrorMessages:    node.Storage.TransactionResultErrorMessages,
+				ChainID:                  node.RootChainID,
+				AccessMetrics:            builder.AccessMetrics,
+				ConnFactory:              connFactory,
+				RetryEnabled:             builder.retryEnabled,
+				MaxHeightRange:           backendConfig.MaxHeightRange,
+				MaxEventQueryHeightRange: backendConfig.MaxEventQueryHeightRange,
+				Log:                      node.Logger,
+				SnapshotHistoryLimit:     backend.DefaultSnapshotHistoryLimit,
+				Communicator:             nodeCommunicator,
+				TxResultCacheSize:        builder.TxResultCacheSize,
+				ScriptExecutor:           builder.ScriptExecutor,
+				ScriptExecutionMode:      scriptExecMode,
+				CheckPayerBalanceMode:    checkPayerBalanceMode,
+				EventQueryMode:           eventQueryMode,
+				BlockTracker:             blockTracker,
 				SubscriptionHandler: subscription.NewSubscriptionHandler(
 					builder.Logger,
 					broadcaster,
@@ -2060,18 +2384,34 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 					builder.stateStreamConf.ResponseLimit,
 					builder.stateStreamConf.ClientSendBufferSize,
 				),
-				EventsIndex:                builder.EventsIndex,
-				TxResultQueryMode:          txResultQueryMode,
-				TxResultsIndex:             builder.TxResultsIndex,
-				LastFullBlockHeight:        lastFullBlockHeight,
-				IndexReporter:              indexReporter,
-				VersionControl:             builder.VersionControl,
-				ExecNodeIdentitiesProvider: builder.ExecNodeIdentitiesProvider,
+				EventsIndex:                    builder.EventsIndex,
+				TxResultQueryMode:              txResultQueryMode,
+				TxResultsIndex:                 builder.TxResultsIndex,
+				AccountTransactionsIndex:       builder.AccountTransactionsIndex,
+				LastFullBlockHeight:            lastFullBlockHeight,
+				IndexReporter:                  indexReporter,
+				VersionControl:                 builder.VersionControl,
+				ExecNodeIdentitiesProvider:     builder.ExecNodeIdentitiesProvider,
+				ScriptResultCacheSize:          builder.scriptResultCacheSize,
+				ScriptResultCacheMaxResultSize: builder.scriptResultCacheMaxResultSize,
+				TransactionTimelines:           builder.TransactionTimelines,
+				Registers:                      registers,
+				RegisterProver: backend.NewExecutionNodeRegisterProver(
+					node.Logger,
+					connFactory,
+					nodeCommunicator,
+					builder.ExecNodeIdentitiesProvider,
+				),
 			})
 			if err != nil {
 				return nil, fmt.Errorf("could not initialize backend: %w", err)
 			}
 
+			// GraphQL queries share the rate limiter of the unsecure gRPC server
+			if builder.apiRateLimiter != nil {
+				builder.rpcConf.GraphQLConfig.RateLimiter = builder.apiRateLimiter
+			}
+
 			engineBuilder, err := rpc.NewBuilder(
 				node.Logger,
 				node.State,
@@ -2193,6 +2533,31 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 		})
 	}
 
+	if builder.evmRPCConf.ListenAddress != "" {
+		builder.Component("evm json-rpc engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			var err error
+			builder.EVMRPCEng, err = ethrpc.NewEngine(
+				node.Logger,
+				builder.evmRPCConf,
+				node.RootChainID,
+				builder.Reporter,
+				node.Storage.Headers,
+				builder.Storage.Events,
+				builder.RegistersAsyncStore,
+				builder.Storage.EVMBlocks,
+				node.DB,
+				builder.nodeBackend,
+			)
+			if err != nil {
+				return nil, err
+			}
+			return builder.EVMRPCEng, nil
+		})
+		builder.AdminCommand("trace-evm-transaction", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return stateSyncCommands.NewTraceEVMTransactionCommand(builder.EVMRPCEng.Tracer())
+		})
+	}
+
 	if builder.supportsObserver {
 		builder.Component("public sync request handler", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			syncRequestHandler, err := synceng.NewRequestHandlerEngine(
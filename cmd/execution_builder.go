@@ -48,9 +48,12 @@ import (
 	"github.com/onflow/flow-go/engine/common/requester"
 	"github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/execution/checker"
+	"github.com/onflow/flow-go/engine/execution/checkpoints"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	txmetrics "github.com/onflow/flow-go/engine/execution/computation/metrics"
+	"github.com/onflow/flow-go/engine/execution/computation/shadow"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	"github.com/onflow/flow-go/engine/execution/ingestion/fetcher"
 	"github.com/onflow/flow-go/engine/execution/ingestion/stop"
@@ -76,6 +79,7 @@ import (
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/blobs"
 	"github.com/onflow/flow-go/module/chainsync"
+	"github.com/onflow/flow-go/module/execution"
 	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 	exedataprovider "github.com/onflow/flow-go/module/executiondatasync/provider"
 	"github.com/onflow/flow-go/module/executiondatasync/pruner"
@@ -157,8 +161,12 @@ type ExecutionNode struct {
 	diskWAL                *wal.DiskWAL
 	blockDataUploader      *uploader.Manager
 	executionDataStore     execution_data.ExecutionDataStore
-	toTriggerCheckpoint    *atomic.Bool      // create the checkpoint trigger to be controlled by admin tool, and listened by the compactor
-	stopControl            *stop.StopControl // stop the node at given block height
+	toTriggerCheckpoint    *atomic.Bool                  // create the checkpoint trigger to be controlled by admin tool, and listened by the compactor
+	checkpointLeases       *checkpoints.TransferLeases   // checkpoints being downloaded from the checkpoint server, kept by the compactor
+	stopControl            *stop.StopControl             // stop the node at given block height
+	transactionProfiler    *computer.TransactionProfiler // profile transactions on request of the admin tool
+	shadowExecutor         *shadow.Executor              // re-execute blocks with a candidate configuration
+	shadowDivergences      *shadow.DivergenceStore       // reports of diverged shadow executions, read by the admin tool
 	executionDataDatastore *badgerds.Datastore
 	executionDataPruner    *pruner.Pruner
 	executionDataBlobstore blobs.Blobstore
@@ -174,6 +182,7 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		builder:             builder.FlowNodeBuilder,
 		exeConf:             builder.exeConf,
 		toTriggerCheckpoint: atomic.NewBool(false),
+		checkpointLeases:    checkpoints.NewTransferLeases(checkpoints.DefaultTransferLeaseDuration),
 		ingestionUnit:       engine.NewUnit(),
 	}
 
@@ -184,9 +193,24 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		AdminCommand("trigger-checkpoint", func(config *NodeConfig) commands.AdminCommand {
 			return executionCommands.NewTriggerCheckpointCommand(exeNode.toTriggerCheckpoint)
 		}).
+		AdminCommand("verify-checkpoint", func(conf *NodeConfig) commands.AdminCommand {
+			return executionCommands.NewVerifyCheckpointCommand(
+				conf.Logger,
+				conf.State,
+				conf.Storage.Headers,
+				conf.Storage.Seals,
+				exeNode.exeConf.triedir,
+			)
+		}).
 		AdminCommand("stop-at-height", func(config *NodeConfig) commands.AdminCommand {
 			return executionCommands.NewStopAtHeightCommand(exeNode.stopControl)
 		}).
+		AdminCommand("profile-transactions", func(config *NodeConfig) commands.AdminCommand {
+			return executionCommands.NewProfileTransactionsCommand(exeNode.transactionProfiler)
+		}).
+		AdminCommand("shadow-execution-divergences", func(config *NodeConfig) commands.AdminCommand {
+			return executionCommands.NewShadowExecutionDivergencesCommand(exeNode.shadowDivergences)
+		}).
 		AdminCommand("set-uploader-enabled", func(config *NodeConfig) commands.AdminCommand {
 			return uploaderCommands.NewToggleUploaderCommand(exeNode.blockDataUploader)
 		}).
@@ -205,6 +229,7 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		Module("mutable follower state", exeNode.LoadMutableFollowerState).
 		Module("system specs", exeNode.LoadSystemSpecs).
 		Module("execution metrics", exeNode.LoadExecutionMetrics).
+		Module("transaction profiler", exeNode.LoadTransactionProfiler).
 		Module("sync core", exeNode.LoadSyncCore).
 		Module("execution receipts storage", exeNode.LoadExecutionReceiptsStorage).
 		Module("follower distributor", exeNode.LoadFollowerDistributor).
@@ -225,6 +250,7 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		Component("execution state", exeNode.LoadExecutionState).
 		Component("stop control", exeNode.LoadStopControl).
 		Component("execution state ledger WAL compactor", exeNode.LoadExecutionStateLedgerWALCompactor).
+		Component("checkpoint server", exeNode.LoadCheckpointServer).
 		// disable execution data pruner for now, since storehouse is going to need the execution data
 		// for recovery,
 		// TODO: will re-visit this once storehouse has implemented new WAL for checkpoint file of
@@ -237,6 +263,7 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		Component("S3 block data uploader", exeNode.LoadS3BlockDataUploader).
 		Component("transaction execution metrics", exeNode.LoadTransactionExecutionMetrics).
 		Component("provider engine", exeNode.LoadProviderEngine).
+		Component("shadow executor", exeNode.LoadShadowExecutor).
 		Component("checker engine", exeNode.LoadCheckerEngine).
 		Component("ingestion engine", exeNode.LoadIngestionEngine).
 		Component("scripts engine", exeNode.LoadScriptsEngine).
@@ -299,6 +326,16 @@ func (exeNode *ExecutionNode) LoadExecutionMetrics(node *NodeConfig) error {
 	return nil
 }
 
+func (exeNode *ExecutionNode) LoadTransactionProfiler(node *NodeConfig) error {
+	transactionProfiler, err := computer.NewTransactionProfiler(node.Logger, node.BaseConfig.profilerConfig.Dir)
+	if err != nil {
+		return fmt.Errorf("could not create transaction profiler: %w", err)
+	}
+	exeNode.transactionProfiler = transactionProfiler
+	exeNode.exeConf.computationConfig.TransactionProfiler = transactionProfiler
+	return nil
+}
+
 func (exeNode *ExecutionNode) LoadSyncCore(node *NodeConfig) error {
 	var err error
 	exeNode.syncCore, err = chainsync.New(node.Logger, node.SyncCoreConfig, metrics.NewChainSyncCollector(node.RootChainID), node.RootChainID)
@@ -539,6 +576,15 @@ func (exeNode *ExecutionNode) LoadProviderEngine(
 		exeNode.exeConf.computationConfig.ExtensiveTracing)...)
 	vmCtx := fvm.NewContext(opts...)
 
+	if exeNode.exeConf.shadowExecutionConfig.Enabled {
+		shadowExecutor, err := exeNode.createShadowExecutor(node, vmCtx)
+		if err != nil {
+			return nil, fmt.Errorf("could not create shadow executor: %w", err)
+		}
+		exeNode.shadowExecutor = shadowExecutor
+		exeNode.exeConf.computationConfig.ShadowExecutor = shadowExecutor
+	}
+
 	var collector module.ExecutionMetrics
 	collector = exeNode.collector
 	if exeNode.exeConf.transactionExecutionMetricsEnabled {
@@ -941,9 +987,34 @@ func (exeNode *ExecutionNode) LoadExecutionStateLedgerWALCompactor(
 		exeNode.exeConf.checkpointsToKeep,
 		exeNode.toTriggerCheckpoint, // compactor will listen to the signal from admin tool for force triggering checkpointing
 		exeNode.collector,
+		ledger.WithDeltaCheckpoints(exeNode.exeConf.deltaCheckpoints),
+		// keep checkpoints while other execution nodes download them from the checkpoint server
+		ledger.WithCheckpointInUse(exeNode.checkpointLeases.InUse),
 	)
 }
 
+func (exeNode *ExecutionNode) LoadCheckpointServer(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	if exeNode.exeConf.checkpointServerAddress == "" {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+
+	return checkpoints.NewServer(
+		node.Logger,
+		exeNode.exeConf.triedir,
+		checkpoints.ServerConfig{
+			Address:                exeNode.exeConf.checkpointServerAddress,
+			Token:                  exeNode.exeConf.checkpointServerToken,
+			MaxConcurrentTransfers: exeNode.exeConf.checkpointServerMaxTransfers,
+		},
+		exeNode.checkpointLeases,
+	), nil
+}
+
 func (exeNode *ExecutionNode) LoadExecutionDataPruner(
 	node *NodeConfig,
 ) (
@@ -1013,6 +1084,67 @@ func (exeNode *ExecutionNode) LoadExecutionDBPruner(node *NodeConfig) (module.Re
 	), nil
 }
 
+// createShadowExecutor creates the shadow executor, which re-executes blocks with the candidate
+// configuration applied on top of the context of the primary execution.
+func (exeNode *ExecutionNode) createShadowExecutor(
+	node *NodeConfig,
+	vmCtx fvm.Context,
+) (*shadow.Executor, error) {
+	config := exeNode.exeConf.shadowExecutionConfig
+
+	divergences, err := shadow.NewDivergenceStore(config.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	candidateOptions, err := config.Candidate.Options(vmCtx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid shadow execution configuration: %w", err)
+	}
+	candidateCtx := fvm.NewContextFromParent(vmCtx, candidateOptions...)
+	blockComputer, err := shadow.NewCandidateBlockComputer(
+		node.Logger,
+		node.Me,
+		computation.NewProtocolStateWrapper(node.State),
+		candidateCtx,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create candidate block computer: %w", err)
+	}
+
+	var shadowMetrics module.ShadowExecutionMetrics = metrics.NewNoopCollector()
+	if node.MetricsEnabled {
+		shadowMetrics = metrics.NewShadowExecutionCollector()
+	}
+
+	shadowExecutor, err := shadow.NewExecutor(
+		node.Logger,
+		shadowMetrics,
+		blockComputer,
+		divergences,
+		config.QueueSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	exeNode.shadowDivergences = divergences
+	return shadowExecutor, nil
+}
+
+func (exeNode *ExecutionNode) LoadShadowExecutor(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	// the shadow executor is created with the computation manager by the provider engine
+	if exeNode.shadowExecutor == nil {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+	return exeNode.shadowExecutor, nil
+}
+
 func (exeNode *ExecutionNode) LoadCheckerEngine(
 	node *NodeConfig,
 ) (
@@ -1348,6 +1480,7 @@ func (exeNode *ExecutionNode) LoadGrpcServer(
 		signature.NewBlockSignerDecoder(exeNode.committee),
 		exeNode.exeConf.apiRatelimits,
 		exeNode.exeConf.apiBurstlimits,
+		execution.NewLedgerRegisterProver(exeNode.ledgerStorage),
 	), nil
 }
 
@@ -1364,7 +1497,12 @@ func (exeNode *ExecutionNode) LoadBootstrapper(node *NodeConfig) error {
 	// if the execution database does not exist, then we need to bootstrap the execution database.
 	if !bootstrapped {
 
-		err := wal.CheckpointHasRootHash(
+		err := exeNode.downloadRootCheckpoint(node)
+		if err != nil {
+			return fmt.Errorf("could not download root checkpoint: %w", err)
+		}
+
+		err = wal.CheckpointHasRootHash(
 			node.Logger,
 			path.Join(node.BootstrapDir, bootstrapFilenames.DirnameExecutionState),
 			bootstrapFilenames.FilenameWALRootCheckpoint,
@@ -1398,6 +1536,48 @@ func (exeNode *ExecutionNode) LoadBootstrapper(node *NodeConfig) error {
 	return nil
 }
 
+// downloadRootCheckpoint downloads the latest checkpoint from the checkpoint source into the bootstrap
+// folder as root checkpoint, if the checkpoint source is configured and the root checkpoint is missing.
+// The downloaded checkpoint must contain the trie of the root seal's final state.
+// Interrupted downloads are resumed on the next startup. The root checkpoint header is moved into place
+// last, so the root checkpoint is only considered available once all of its files have been downloaded
+// and the hashes of all its trie nodes have been verified.
+func (exeNode *ExecutionNode) downloadRootCheckpoint(node *NodeConfig) error {
+	if exeNode.exeConf.checkpointSource == "" {
+		return nil
+	}
+
+	dir := path.Join(node.BootstrapDir, bootstrapFilenames.DirnameExecutionState)
+	_, err := os.Stat(path.Join(dir, bootstrapFilenames.FilenameWALRootCheckpoint))
+	if err == nil {
+		// root checkpoint is already available
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return fmt.Errorf("could not check root checkpoint file: %w", err)
+	}
+
+	downloader := checkpoints.NewDownloader(
+		node.Logger,
+		checkpoints.NewHTTPClient(),
+		exeNode.exeConf.checkpointSource,
+		exeNode.exeConf.checkpointSourceToken,
+		exeNode.exeConf.checkpointDownloadWorkers,
+	)
+
+	err = downloader.DownloadLatest(
+		context.Background(),
+		dir,
+		bootstrapFilenames.FilenameWALRootCheckpoint,
+		ledgerpkg.RootHash(node.RootSeal.FinalState),
+	)
+	if err != nil {
+		return fmt.Errorf("could not download checkpoint from %v: %w", exeNode.exeConf.checkpointSource, err)
+	}
+
+	return nil
+}
+
 // getContractEpochCounter Gets the epoch counters from the FlowEpoch smart
 // contract from the snapshot provided.
 func getContractEpochCounter(
@@ -9,7 +9,9 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/onflow/flow-go/engine/common/provider"
+	"github.com/onflow/flow-go/engine/execution/checkpoints"
 	"github.com/onflow/flow-go/engine/execution/computation/query"
+	"github.com/onflow/flow-go/engine/execution/computation/shadow"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
 	exepruner "github.com/onflow/flow-go/engine/execution/pruner"
 	"github.com/onflow/flow-go/fvm"
@@ -34,6 +36,7 @@ type ExecutionConfig struct {
 	transactionResultsCacheSize           uint
 	checkpointDistance                    uint
 	checkpointsToKeep                     uint
+	deltaCheckpoints                      uint
 	chunkDataPackDir                      string
 	chunkDataPackCacheSize                uint
 	chunkDataPackRequestsCacheSize        uint32
@@ -55,10 +58,17 @@ type ExecutionConfig struct {
 	chunkDataPackRequestWorkers           uint
 	maxGracefulStopDuration               time.Duration
 	importCheckpointWorkerCount           int
+	checkpointServerAddress               string
+	checkpointServerToken                 string
+	checkpointServerMaxTransfers          uint
+	checkpointSource                      string
+	checkpointSourceToken                 string
+	checkpointDownloadWorkers             int
 	transactionExecutionMetricsEnabled    bool
 	transactionExecutionMetricsBufferSize uint
 
 	computationConfig        computation.ComputationConfig
+	shadowExecutionConfig    shadow.Config
 	receiptRequestWorkers    uint   // common provider engine workers
 	receiptRequestsCacheSize uint32 // common provider engine cache size
 
@@ -89,6 +99,7 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.Uint32Var(&exeConf.mTrieCacheSize, "mtrie-cache-size", 500, "cache size for MTrie")
 	flags.UintVar(&exeConf.checkpointDistance, "checkpoint-distance", 20, "number of WAL segments between checkpoints")
 	flags.UintVar(&exeConf.checkpointsToKeep, "checkpoints-to-keep", 5, "number of recent checkpoints to keep (0 to keep all)")
+	flags.UintVar(&exeConf.deltaCheckpoints, "delta-checkpoints", 0, "number of delta checkpoints to create between two full checkpoints (0 to only create full checkpoints)")
 	flags.UintVar(&exeConf.computationConfig.DerivedDataCacheSize, "cadence-execution-cache", derived.DefaultDerivedDataCacheSize,
 		"cache size for Cadence execution")
 	flags.BoolVar(&exeConf.computationConfig.ExtensiveTracing, "extensive-tracing", false, "adds high-overhead tracing to execution")
@@ -125,9 +136,28 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.IntVar(&exeConf.blobstoreBurstLimit, "blobstore-burst-limit", 0, "outgoing burst limit for Execution Data blobstore")
 	flags.DurationVar(&exeConf.maxGracefulStopDuration, "max-graceful-stop-duration", stop.DefaultMaxGracefulStopDuration, "the maximum amount of time stop control will wait for ingestion engine to gracefully shutdown before crashing")
 	flags.IntVar(&exeConf.importCheckpointWorkerCount, "import-checkpoint-worker-count", 10, "number of workers to import checkpoint file during bootstrap")
+	flags.StringVar(&exeConf.checkpointServerAddress, "checkpoint-server-addr", "", "address to serve the latest checkpoint to other execution nodes on, e.g. :9100 (empty to disable)")
+	flags.StringVar(&exeConf.checkpointServerToken, "checkpoint-server-token", "", "bearer token required to download checkpoints from the checkpoint server (empty to serve checkpoints without authentication)")
+	flags.UintVar(&exeConf.checkpointServerMaxTransfers, "checkpoint-server-max-transfers", checkpoints.DefaultMaxConcurrentTransfers, "maximum number of checkpoint files served at the same time by the checkpoint server")
+	flags.StringVar(&exeConf.checkpointSource, "checkpoint-source", "", "URL of an execution node serving checkpoints, used to download the root checkpoint if it is missing from the bootstrap directory")
+	flags.StringVar(&exeConf.checkpointSourceToken, "checkpoint-source-token", "", "bearer token sent to the checkpoint source")
+	flags.IntVar(&exeConf.checkpointDownloadWorkers, "checkpoint-download-workers", checkpoints.DefaultDownloadWorkers, "number of checkpoint files downloaded in parallel from the checkpoint source")
 	flags.BoolVar(&exeConf.transactionExecutionMetricsEnabled, "tx-execution-metrics", true, "enable collection of transaction execution metrics")
 	flags.UintVar(&exeConf.transactionExecutionMetricsBufferSize, "tx-execution-metrics-buffer-size", 200, "buffer size for transaction execution metrics. The buffer size is the number of blocks that are kept in memory by the metrics provider engine")
 
+	flags.BoolVar(&exeConf.shadowExecutionConfig.Enabled, "shadow-execution-enabled", false, "re-execute every block in the background with the candidate configuration set by the shadow-execution-* flags, and report divergences from the primary execution")
+	flags.UintVar(&exeConf.shadowExecutionConfig.QueueSize, "shadow-execution-queue-size", shadow.DefaultQueueSize, "number of executed blocks queued for shadow execution. blocks are skipped when the queue is full")
+	flags.StringVar(&exeConf.shadowExecutionConfig.Dir, "shadow-execution-dir", filepath.Join(datadir, "shadow_execution"), "directory to store the reports of blocks whose shadow execution diverged")
+	flags.BoolVar(&exeConf.shadowExecutionConfig.Candidate.EVMEnabled, "shadow-execution-evm-enabled", true, "whether EVM is enabled for shadow execution")
+	flags.BoolVar(&exeConf.shadowExecutionConfig.Candidate.AtreeValidationEnabled, "shadow-execution-atree-validation-enabled", false, "whether atree validation is enabled for shadow execution")
+	flags.BoolVar(&exeConf.shadowExecutionConfig.Candidate.LegacyContractUpgradeEnabled, "shadow-execution-legacy-contract-upgrade-enabled", false, "whether legacy contract upgrades are enabled for shadow execution")
+	flags.Uint64Var(&exeConf.shadowExecutionConfig.Candidate.MaxStateInteractionSize, "shadow-execution-max-state-interaction-size", 0, "maximum state interaction size of transactions for shadow execution (0 to use the default)")
+	flags.Uint64Var(&exeConf.shadowExecutionConfig.Candidate.EventCollectionSizeLimit, "shadow-execution-event-collection-size-limit", 0, "event collection size limit of transactions for shadow execution (0 to use the default)")
+	flags.Uint64Var(&exeConf.shadowExecutionConfig.Candidate.ComputationLimit, "shadow-execution-computation-limit", 0, "computation limit of transactions for shadow execution, replacing the limit of each transaction (0 to use the limits of the transactions)")
+	flags.Uint64Var(&exeConf.shadowExecutionConfig.Candidate.MemoryLimit, "shadow-execution-memory-limit", 0, "memory limit of transactions for shadow execution, replacing the limit stored in the state (0 to use the stored limit)")
+	flags.StringToStringVar(&exeConf.shadowExecutionConfig.Candidate.ComputationWeights, "shadow-execution-computation-weights", nil, "computation weights for shadow execution, replacing the weights stored in the state for the given kinds, e.g. 1001=1048576,1002=65536")
+	flags.StringToStringVar(&exeConf.shadowExecutionConfig.Candidate.MemoryWeights, "shadow-execution-memory-weights", nil, "memory weights for shadow execution, replacing the weights stored in the state for the given kinds, e.g. 2=100")
+
 	flags.BoolVar(&exeConf.onflowOnlyLNs, "temp-onflow-only-lns", false, "do not use unless required. forces node to only request collections from onflow collection nodes")
 	flags.BoolVar(&exeConf.enableStorehouse, "enable-storehouse", false, "enable storehouse to store registers on disk, default is false")
 	flags.BoolVar(&exeConf.enableChecker, "enable-checker", true, "enable checker to check the correctness of the execution result, default is true")
@@ -0,0 +1,577 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/compress"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/util"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/bootstrap"
+)
+
+var (
+	flagCheckpointDir   string
+	flagCheckpointFile  string
+	flagStateCommitment string
+	flagOutputDir       string
+	flagFormat          string
+	flagWorkers         int
+	flagBatchSize       int
+)
+
+var Cmd = &cobra.Command{
+	Use:   "export-columnar-execution-state",
+	Short: "exports the payloads of a V6 checkpoint into columnar files (Parquet or Arrow IPC)",
+	Long: `export-columnar-execution-state streams the payloads of a V6 checkpoint with a single trie into
+columnar files with the columns owner, key, value_size and register_type.
+The files are partitioned by the first byte of the owner, using the directory layout
+<output-dir>/owner_prefix=<hex>/payloads.<format>, which is understood by most analytics tools.
+Registers without owner are written into the owner_prefix=global partition.
+The export runs in two phases, so that at most --workers columnar files are written at the same time:
+first the checkpoint part files are read in parallel, and the rows are appended to one temporary
+file per partition in <output-dir>/.spill, then the partitions are converted into columnar files.
+Delta checkpoints are not supported, export the full checkpoint they are based on instead.`,
+	Run: run,
+}
+
+const (
+	formatParquet = "parquet"
+	formatArrow   = "arrow"
+
+	globalPartition = "global"
+)
+
+func init() {
+	Cmd.Flags().StringVar(&flagCheckpointDir, "checkpoint-dir", "",
+		"directory of the checkpoint")
+	_ = Cmd.MarkFlagRequired("checkpoint-dir")
+
+	Cmd.Flags().StringVar(&flagCheckpointFile, "checkpoint-file", bootstrap.FilenameWALRootCheckpoint,
+		"file name of the checkpoint, which must contain a single trie")
+
+	Cmd.Flags().StringVar(&flagStateCommitment, "state-commitment", "",
+		"state commitment of the trie in the checkpoint (hex-encoded, 64 characters)")
+	_ = Cmd.MarkFlagRequired("state-commitment")
+
+	Cmd.Flags().StringVar(&flagOutputDir, "output-dir", "",
+		"directory to write the columnar files to")
+	_ = Cmd.MarkFlagRequired("output-dir")
+
+	Cmd.Flags().StringVar(&flagFormat, "format", formatParquet,
+		"output format, either 'parquet' or 'arrow' (Arrow IPC file format)")
+
+	Cmd.Flags().IntVar(&flagWorkers, "workers", 4,
+		"number of checkpoint part files read concurrently, and of partitions written concurrently")
+
+	Cmd.Flags().IntVar(&flagBatchSize, "batch-size", 8192,
+		"number of rows buffered per partition before writing them as a record batch")
+}
+
+func run(*cobra.Command, []string) {
+	if flagFormat != formatParquet && flagFormat != formatArrow {
+		log.Fatal().Msgf("--format must be either %q or %q", formatParquet, formatArrow)
+	}
+
+	stateCommitment := util.ParseStateCommitment(flagStateCommitment)
+
+	log.Info().Msgf("exporting payloads of checkpoint %s to %s", filepath.Join(flagCheckpointDir, flagCheckpointFile), flagOutputDir)
+
+	rows, err := ExportPayloads(
+		log.Logger,
+		flagCheckpointDir,
+		flagCheckpointFile,
+		ledger.RootHash(stateCommitment),
+		flagOutputDir,
+		flagFormat,
+		flagWorkers,
+		flagBatchSize,
+	)
+	if err != nil {
+		log.Fatal().Err(err).Msg("cannot export payloads")
+	}
+
+	log.Info().Msgf("exported %d payloads", rows)
+}
+
+// payloadSchema is the schema of the exported files.
+var payloadSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "owner", Type: arrow.BinaryTypes.String},
+	{Name: "key", Type: arrow.BinaryTypes.Binary},
+	{Name: "value_size", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "register_type", Type: arrow.BinaryTypes.String},
+}, nil)
+
+// spillDirName is the directory in the output directory the rows of the partitions are collected in,
+// before they are written into columnar files.
+const spillDirName = ".spill"
+
+// ExportPayloads exports the payloads of the given checkpoint into columnar files in outputDir,
+// and returns the number of exported payloads.
+//
+// The rows are first appended to one spill file per partition while the checkpoint parts are read,
+// and the partitions are then written into columnar files by at most workers goroutines. This bounds
+// the number of open columnar writers, which buffer a record batch each, to workers, instead of one
+// per partition and checkpoint part.
+func ExportPayloads(
+	log zerolog.Logger,
+	checkpointDir string,
+	checkpointFile string,
+	rootHash ledger.RootHash,
+	outputDir string,
+	format string,
+	workers int,
+	batchSize int,
+) (uint64, error) {
+	spillDir := filepath.Join(outputDir, spillDirName)
+	err := os.MkdirAll(spillDir, 0755)
+	if err != nil {
+		return 0, fmt.Errorf("could not create spill directory: %w", err)
+	}
+	defer func() {
+		err := os.RemoveAll(spillDir)
+		if err != nil {
+			log.Warn().Err(err).Msgf("could not remove spill directory %s", spillDir)
+		}
+	}()
+
+	spills := newSpillFiles(spillDir)
+	rowsByPart := make([]uint64, 16)
+
+	err = wal.OpenAndReadLeafNodesFromCheckpointV6Concurrently(
+		checkpointDir,
+		checkpointFile,
+		rootHash,
+		workers,
+		log,
+		func(part int, leafNodes <-chan *wal.LeafNode) error {
+			rows, err := spillPart(leafNodes, spills)
+			if err != nil {
+				return err
+			}
+			rowsByPart[part] = rows
+
+			log.Info().Msgf("read %d payloads from checkpoint part %d", rows, part)
+			return nil
+		},
+	)
+	closeErr := spills.Close()
+	if err != nil {
+		return 0, err
+	}
+	if closeErr != nil {
+		return 0, closeErr
+	}
+
+	var total uint64
+	for _, rows := range rowsByPart {
+		total += rows
+	}
+
+	g := errgroup.Group{}
+	g.SetLimit(workers)
+	for _, partition := range spills.Partitions() {
+		g.Go(func() error {
+			rows, err := exportPartition(
+				filepath.Join(spillDir, partition),
+				filepath.Join(outputDir, "owner_prefix="+partition),
+				format,
+				batchSize,
+			)
+			if err != nil {
+				return fmt.Errorf("could not export partition %s: %w", partition, err)
+			}
+
+			log.Info().Msgf("exported %d payloads of partition %s", rows, partition)
+			return nil
+		})
+	}
+	err = g.Wait()
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// spillPart appends the payloads of one checkpoint part to the spill files of their partitions.
+func spillPart(leafNodes <-chan *wal.LeafNode, spills *spillFiles) (uint64, error) {
+	var rows uint64
+	var buf []byte
+	for leafNode := range leafNodes {
+		// the leaf of an empty trie has no payload
+		if leafNode.Payload == nil {
+			continue
+		}
+
+		key, err := leafNode.Payload.Key()
+		if err != nil {
+			return 0, fmt.Errorf("could not decode payload key: %w", err)
+		}
+		registerID, err := convert.LedgerKeyToRegisterID(key)
+		if err != nil {
+			return 0, fmt.Errorf("could not convert payload key to register ID: %w", err)
+		}
+
+		partition := globalPartition
+		if len(registerID.Owner) > 0 {
+			partition = hex.EncodeToString([]byte(registerID.Owner[:1]))
+		}
+
+		buf = encodeRow(buf[:0], spilledRow{
+			owner:        []byte(registerID.Owner),
+			key:          []byte(registerID.Key),
+			valueSize:    uint64(leafNode.Payload.Value().Size()),
+			registerType: util.RegisterType(registerID.Key),
+		})
+		err = spills.Write(partition, buf)
+		if err != nil {
+			return 0, err
+		}
+		rows++
+	}
+
+	return rows, nil
+}
+
+// exportPartition writes the rows of the given spill file into a columnar file in dir.
+func exportPartition(spillPath string, dir string, format string, batchSize int) (rows uint64, errToReturn error) {
+	file, err := os.Open(spillPath)
+	if err != nil {
+		return 0, fmt.Errorf("could not open spill file: %w", err)
+	}
+	defer file.Close()
+
+	w, err := newPartitionWriter(dir, "payloads."+format, format, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err := w.Close()
+		if err != nil && errToReturn == nil {
+			errToReturn = err
+		}
+	}()
+
+	reader := bufio.NewReader(file)
+	for {
+		row, err := decodeRow(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("could not read spill file %s: %w", spillPath, err)
+		}
+
+		err = w.Append(
+			hex.EncodeToString(row.owner),
+			row.key,
+			int64(row.valueSize),
+			row.registerType,
+		)
+		if err != nil {
+			return 0, err
+		}
+		rows++
+	}
+
+	// the spill file is no longer needed, free the disk space before the next partitions are written
+	err = os.Remove(spillPath)
+	if err != nil {
+		return 0, fmt.Errorf("could not remove spill file: %w", err)
+	}
+
+	return rows, nil
+}
+
+// spilledRow is a row of a spill file.
+type spilledRow struct {
+	owner        []byte
+	key          []byte
+	valueSize    uint64
+	registerType string
+}
+
+// encodeRow appends the encoding of the given row to buf: the owner, key and register type are
+// prefixed with their lengths, and all integers are encoded as uvarints.
+func encodeRow(buf []byte, row spilledRow) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(row.owner)))
+	buf = append(buf, row.owner...)
+	buf = binary.AppendUvarint(buf, uint64(len(row.key)))
+	buf = append(buf, row.key...)
+	buf = binary.AppendUvarint(buf, row.valueSize)
+	buf = binary.AppendUvarint(buf, uint64(len(row.registerType)))
+	buf = append(buf, row.registerType...)
+	return buf
+}
+
+// decodeRow reads the next row encoded by encodeRow.
+// Returns io.EOF if there are no more rows.
+func decodeRow(r *bufio.Reader) (spilledRow, error) {
+	var row spilledRow
+
+	owner, err := readBytes(r)
+	if err != nil {
+		// a row can only end at the beginning of the next one
+		return row, err
+	}
+	key, err := readBytes(r)
+	if err != nil {
+		return row, unexpectedEOF(err)
+	}
+	valueSize, err := binary.ReadUvarint(r)
+	if err != nil {
+		return row, unexpectedEOF(err)
+	}
+	registerType, err := readBytes(r)
+	if err != nil {
+		return row, unexpectedEOF(err)
+	}
+
+	row.owner = owner
+	row.key = key
+	row.valueSize = valueSize
+	row.registerType = string(registerType)
+	return row, nil
+}
+
+func readBytes(r *bufio.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, length)
+	_, err = io.ReadFull(r, b)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return b, nil
+}
+
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// spillFiles holds one buffered spill file per partition, which are created on first use.
+// There are at most 257 partitions (one per owner prefix byte and the global partition), so the
+// number of open files is bounded regardless of the number of checkpoint parts.
+//
+// spillFiles is safe for concurrent use.
+type spillFiles struct {
+	dir   string
+	mu    sync.Mutex
+	files map[string]*spillFile
+}
+
+type spillFile struct {
+	mu     sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+func newSpillFiles(dir string) *spillFiles {
+	return &spillFiles{
+		dir:   dir,
+		files: make(map[string]*spillFile),
+	}
+}
+
+// Write appends the given encoded row to the spill file of the given partition.
+func (s *spillFiles) Write(partition string, row []byte) error {
+	f, err := s.file(partition)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, err = f.writer.Write(row)
+	if err != nil {
+		return fmt.Errorf("could not write to spill file %s: %w", f.file.Name(), err)
+	}
+	return nil
+}
+
+func (s *spillFiles) file(partition string) (*spillFile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, ok := s.files[partition]
+	if ok {
+		return f, nil
+	}
+
+	filePath := filepath.Join(s.dir, partition)
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not create spill file %s: %w", filePath, err)
+	}
+	f = &spillFile{
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}
+	s.files[partition] = f
+	return f, nil
+}
+
+// Partitions returns the partitions which have a spill file.
+func (s *spillFiles) Partitions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	partitions := make([]string, 0, len(s.files))
+	for partition := range s.files {
+		partitions = append(partitions, partition)
+	}
+	return partitions
+}
+
+// Close flushes and closes all spill files.
+func (s *spillFiles) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for _, f := range s.files {
+		err := f.writer.Flush()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not flush spill file %s: %w", f.file.Name(), err))
+		}
+		err = f.file.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not close spill file %s: %w", f.file.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// recordWriter writes arrow records to a file.
+type recordWriter interface {
+	Write(rec arrow.Record) error
+	Close() error
+}
+
+// partitionWriter buffers rows of a single partition, and writes them as record batches.
+type partitionWriter struct {
+	file      *os.File
+	writer    recordWriter
+	builder   *array.RecordBuilder
+	batchSize int
+	rows      int
+}
+
+func newPartitionWriter(dir string, fileName string, format string, batchSize int) (*partitionWriter, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("could not create partition directory: %w", err)
+	}
+
+	filePath := filepath.Join(dir, fileName)
+	file, err := os.Create(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not create file %s: %w", filePath, err)
+	}
+
+	var writer recordWriter
+	switch format {
+	case formatParquet:
+		writer, err = pqarrow.NewFileWriter(
+			payloadSchema,
+			file,
+			parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy)),
+			pqarrow.DefaultWriterProps(),
+		)
+	case formatArrow:
+		writer, err = ipc.NewFileWriter(file, ipc.WithSchema(payloadSchema))
+	default:
+		err = fmt.Errorf("unknown format %s", format)
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("could not create writer for %s: %w", filePath, err)
+	}
+
+	return &partitionWriter{
+		file:      file,
+		writer:    writer,
+		builder:   array.NewRecordBuilder(memory.DefaultAllocator, payloadSchema),
+		batchSize: batchSize,
+	}, nil
+}
+
+func (w *partitionWriter) Append(owner string, key []byte, valueSize int64, registerType string) error {
+	w.builder.Field(0).(*array.StringBuilder).Append(owner)
+	w.builder.Field(1).(*array.BinaryBuilder).Append(key)
+	w.builder.Field(2).(*array.Int64Builder).Append(valueSize)
+	w.builder.Field(3).(*array.StringBuilder).Append(registerType)
+	w.rows++
+
+	if w.rows < w.batchSize {
+		return nil
+	}
+	return w.flush()
+}
+
+func (w *partitionWriter) flush() error {
+	if w.rows == 0 {
+		return nil
+	}
+
+	rec := w.builder.NewRecord()
+	defer rec.Release()
+	w.rows = 0
+
+	err := w.writer.Write(rec)
+	if err != nil {
+		return fmt.Errorf("could not write record batch to %s: %w", w.file.Name(), err)
+	}
+	return nil
+}
+
+// Close writes the buffered rows and closes the file.
+func (w *partitionWriter) Close() error {
+	defer w.builder.Release()
+
+	err := w.flush()
+	if err != nil {
+		_ = w.file.Close()
+		return err
+	}
+
+	err = w.writer.Close()
+	if err != nil {
+		_ = w.file.Close()
+		return fmt.Errorf("could not close writer of %s: %w", w.file.Name(), err)
+	}
+
+	// the parquet writer closes the file itself
+	err = w.file.Close()
+	if err != nil && !errors.Is(err, os.ErrClosed) {
+		return fmt.Errorf("could not close %s: %w", w.file.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet/file"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type row struct {
+	owner        string
+	key          string
+	valueSize    int64
+	registerType string
+}
+
+// createCheckpoint stores a checkpoint with a single trie holding the given registers.
+func createCheckpoint(t *testing.T, dir string, fileName string, registers map[flow.RegisterID][]byte) ledger.RootHash {
+	paths := make([]ledger.Path, 0, len(registers))
+	payloads := make([]ledger.Payload, 0, len(registers))
+	for registerID, value := range registers {
+		key := convert.RegisterIDToLedgerKey(registerID)
+		path, err := pathfinder.KeyToPath(key, complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+		paths = append(paths, path)
+		payloads = append(payloads, *ledger.NewPayload(key, value))
+	}
+
+	tr, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+
+	require.NoError(t, wal.StoreCheckpointV6Concurrently([]*trie.MTrie{tr}, dir, fileName, unittest.Logger()))
+	return tr.RootHash()
+}
+
+func readRecords(t *testing.T, records []arrow.Record) []row {
+	var rows []row
+	for _, rec := range records {
+		owners := rec.Column(0).(*array.String)
+		keys := rec.Column(1).(*array.Binary)
+		sizes := rec.Column(2).(*array.Int64)
+		types := rec.Column(3).(*array.String)
+		for i := 0; i < int(rec.NumRows()); i++ {
+			rows = append(rows, row{
+				owner:        owners.Value(i),
+				key:          string(keys.Value(i)),
+				valueSize:    sizes.Value(i),
+				registerType: types.Value(i),
+			})
+		}
+	}
+	return rows
+}
+
+func readParquetFile(t *testing.T, path string) []row {
+	reader, err := file.OpenParquetFile(path, false)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	fileReader, err := pqarrow.NewFileReader(reader, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+
+	table, err := fileReader.ReadTable(context.Background())
+	require.NoError(t, err)
+	defer table.Release()
+
+	tableReader := array.NewTableReader(table, -1)
+	defer tableReader.Release()
+
+	var records []arrow.Record
+	for tableReader.Next() {
+		rec := tableReader.Record()
+		rec.Retain()
+		records = append(records, rec)
+	}
+	return readRecords(t, records)
+}
+
+func readArrowFile(t *testing.T, path string) []row {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	reader, err := ipc.NewFileReader(f)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	var records []arrow.Record
+	for i := 0; i < reader.NumRecords(); i++ {
+		rec, err := reader.Record(i)
+		require.NoError(t, err)
+		rec.Retain()
+		records = append(records, rec)
+	}
+	return readRecords(t, records)
+}
+
+func TestExportPayloads(t *testing.T) {
+	owner1 := flow.HexToAddress("0x0a00000000000001")
+	owner2 := flow.HexToAddress("0x0b00000000000002")
+
+	registers := map[flow.RegisterID][]byte{
+		flow.NewRegisterID(flow.EmptyAddress, "uuid"):                   {1},
+		flow.NewRegisterID(owner1, flow.AccountStatusKey):               {1, 2},
+		flow.NewRegisterID(owner1, "storage"):                           {1, 2, 3},
+		flow.NewRegisterID(owner1, "$\x00\x00\x00\x00\x00\x00\x00\x01"): {1, 2, 3, 4},
+		flow.NewRegisterID(owner2, "code.Test"):                         {1, 2, 3, 4, 5},
+	}
+	for i := 0; i < 100; i++ {
+		registers[flow.NewRegisterID(owner2, "public_key_"+string(rune('a'+i%26))+string(rune('a'+i/26)))] = make([]byte, i+1)
+	}
+
+	for _, format := range []string{formatParquet, formatArrow} {
+		t.Run(format, func(t *testing.T) {
+			unittest.RunWithTempDir(t, func(dir string) {
+				checkpointDir := filepath.Join(dir, "checkpoint")
+				outputDir := filepath.Join(dir, "output")
+				require.NoError(t, os.MkdirAll(checkpointDir, 0755))
+
+				rootHash := createCheckpoint(t, checkpointDir, "root.checkpoint", registers)
+
+				// a small batch size, so that multiple record batches are written
+				rows, err := ExportPayloads(unittest.Logger(), checkpointDir, "root.checkpoint", rootHash, outputDir, format, 4, 10)
+				require.NoError(t, err)
+				require.Equal(t, uint64(len(registers)), rows)
+
+				partitions, err := os.ReadDir(outputDir)
+				require.NoError(t, err)
+				partitionNames := make([]string, 0, len(partitions))
+				for _, p := range partitions {
+					partitionNames = append(partitionNames, p.Name())
+				}
+				require.ElementsMatch(t, []string{"owner_prefix=global", "owner_prefix=0a", "owner_prefix=0b"}, partitionNames)
+
+				exported := make(map[string]row)
+				err = filepath.Walk(outputDir, func(path string, info os.FileInfo, err error) error {
+					if err != nil || info.IsDir() {
+						return err
+					}
+					require.True(t, strings.HasSuffix(path, "."+format))
+
+					var fileRows []row
+					if format == formatParquet {
+						fileRows = readParquetFile(t, path)
+					} else {
+						fileRows = readArrowFile(t, path)
+					}
+
+					partition := filepath.Base(filepath.Dir(path))
+					for _, r := range fileRows {
+						if r.owner == "" {
+							require.Equal(t, "owner_prefix=global", partition)
+						} else {
+							require.Equal(t, "owner_prefix="+r.owner[:2], partition)
+						}
+						exported[r.owner+"/"+r.key] = r
+					}
+					return nil
+				})
+				require.NoError(t, err)
+				require.Len(t, exported, len(registers))
+
+				for registerID, value := range registers {
+					owner := hex.EncodeToString([]byte(registerID.Owner))
+					r, ok := exported[owner+"/"+registerID.Key]
+					require.True(t, ok)
+					require.Equal(t, int64(len(value)), r.valueSize)
+				}
+
+				require.Equal(t, "account status", exported[hex.EncodeToString(owner1[:])+"/"+flow.AccountStatusKey].registerType)
+				require.Equal(t, "domain storage", exported[hex.EncodeToString(owner1[:])+"/storage"].registerType)
+				require.Equal(t, "atree slab", exported[hex.EncodeToString(owner1[:])+"/$\x00\x00\x00\x00\x00\x00\x00\x01"].registerType)
+				require.Equal(t, "contract content", exported[hex.EncodeToString(owner2[:])+"/code.Test"].registerType)
+				require.Equal(t, "uuid generator state", exported["/uuid"].registerType)
+			})
+		})
+	}
+}
+
+func TestSpilledRowEncoding(t *testing.T) {
+	rows := []spilledRow{
+		{owner: []byte{}, key: []byte("uuid"), valueSize: 8, registerType: "uuid generator state"},
+		{owner: []byte{0x0a, 0, 0, 0, 0, 0, 0, 1}, key: []byte("$\x00\x00\x00\x00\x00\x00\x00\x01"), valueSize: 1 << 40, registerType: "atree slab"},
+		{owner: []byte{0x0b, 0, 0, 0, 0, 0, 0, 2}, key: []byte{}, valueSize: 0, registerType: ""},
+	}
+
+	var encoded []byte
+	for _, row := range rows {
+		encoded = encodeRow(encoded, row)
+	}
+
+	reader := bufio.NewReader(bytes.NewReader(encoded))
+	for _, expected := range rows {
+		row, err := decodeRow(reader)
+		require.NoError(t, err)
+		require.Equal(t, expected, row)
+	}
+	_, err := decodeRow(reader)
+	require.ErrorIs(t, err, io.EOF)
+
+	// a truncated row is not mistaken for the end of the file
+	_, err = decodeRow(bufio.NewReader(bytes.NewReader(encodeRow(nil, rows[1])[:12])))
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// export-columnar-execution-state is a separate module, so that the Arrow and Parquet
+// dependencies are not added to the flow-go module.
+func main() {
+	log.Logger = log.Output(zerolog.ConsoleWriter{
+		Out:        os.Stderr,
+		TimeFormat: time.TimeOnly,
+	})
+
+	if err := Cmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
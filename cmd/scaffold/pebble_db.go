@@ -7,6 +7,7 @@ import (
 
 	"github.com/cockroachdb/pebble"
 
+	"github.com/onflow/flow-go/storage/migration"
 	pebblestorage "github.com/onflow/flow-go/storage/pebble"
 )
 
@@ -25,6 +26,13 @@ func InitPebbleDB(dir string) (*pebble.DB, io.Closer, error) {
 		return nil, nil, fmt.Errorf("could not create pebble db (path: %s): %w", dir, err)
 	}
 
+	// refuse to start on a database that is only partially migrated from badger,
+	// the migration must be resumed with the migrate-badger-to-pebble util command
+	err = migration.CheckMigrationStatus(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	db, err := pebblestorage.OpenDefaultPebbleDB(dir)
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not open newly created pebble db (path: %s): %w", dir, err)
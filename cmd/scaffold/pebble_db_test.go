@@ -1,12 +1,15 @@
 package scaffold_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/cmd/scaffold"
+	"github.com/onflow/flow-go/storage/migration"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -23,3 +26,19 @@ func TestInitPebbleDBDirNotSet(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "missing required flag")
 }
+
+func TestInitPebbleDBHalfMigrated(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, migration.MarkerStarted), nil, 0600))
+
+		_, _, err := scaffold.InitPebbleDB(dir)
+		require.ErrorIs(t, err, migration.ErrHalfMigrated)
+
+		// once the migration has completed, the database can be opened
+		require.NoError(t, os.WriteFile(filepath.Join(dir, migration.MarkerCompleted), nil, 0600))
+
+		_, closer, err := scaffold.InitPebbleDB(dir)
+		require.NoError(t, err)
+		require.NoError(t, closer.Close())
+	})
+}
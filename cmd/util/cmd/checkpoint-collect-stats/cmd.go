@@ -9,18 +9,15 @@ import (
 	"sync"
 
 	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/runtime"
 	"github.com/pkg/profile"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"go.uber.org/atomic"
 
-	"github.com/onflow/atree"
-
 	"github.com/onflow/flow-go/cmd/util/ledger/reporters"
 	"github.com/onflow/flow-go/cmd/util/ledger/util"
-	"github.com/onflow/flow-go/fvm/evm/emulator/state"
-	"github.com/onflow/flow-go/fvm/evm/handler"
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	"github.com/onflow/flow-go/ledger/complete"
@@ -47,23 +44,10 @@ const (
 )
 
 const (
-	// NOTE: this constant is defined in github.com/onflow/cadence/runtime/storage.go
-	// Use this contant directly from cadence runtime package after dependency is updated.
-	AccountStorageKey = "stored"
-)
-
-const (
-	domainTypePrefix         = "domain "
 	payloadChannelBufferSize = 100_000
 	initialAccountMapSize    = 5_000_000
 )
 
-const (
-	// EVM register keys from fvm/evm/handler/blockHashList.go
-	blockHashListMetaKey         = "BlockHashListMeta"
-	blockHashListBucketKeyPrefix = "BlockHashListBucket"
-)
-
 // percentiles are Tukey's seven-number summary (without
 // the 0 and 100 because min and max are always included).
 var percentiles = []float64{12.5, 25.0, 50.0, 75.0, 87.5}
@@ -419,7 +403,7 @@ func writeStats(reportName string, stats interface{}) {
 }
 
 func isDomainType(typ string) bool {
-	return strings.HasPrefix(typ, domainTypePrefix)
+	return strings.HasPrefix(typ, util.DomainRegisterTypePrefix)
 }
 
 func isDomainRegister(key ledger.Key) bool {
@@ -436,61 +420,15 @@ func isDomainRegister(key ledger.Key) bool {
 func isAccountRegister(key ledger.Key) bool {
 	k := key.KeyParts[1].Value
 	kstr := string(k)
-	return kstr == AccountStorageKey
+	return kstr == runtime.AccountStorageKey
 }
 
 func getRegisterType(key ledger.Key) string {
-	k := key.KeyParts[1].Value
-	kstr := string(k)
-
-	if atree.LedgerKeyIsSlabKey(kstr) {
-		return "atree slab"
-	}
+	kstr := string(key.KeyParts[1].Value)
 
-	_, isDomain := common.AllStorageDomainsByIdentifier[kstr]
-	if isDomain {
-		return domainTypePrefix + kstr
+	typ := util.RegisterType(kstr)
+	if typ == util.UnknownRegisterType {
+		log.Warn().Msgf("unknown payload key: %s", kstr)
 	}
-
-	switch kstr {
-	case AccountStorageKey:
-		return "account"
-	case flow.ContractNamesKey:
-		return "contract names"
-	case flow.AccountStatusKey:
-		return "account status"
-	case flow.AddressStateKey:
-		return "address generator state"
-	case state.AccountsStorageIDKey:
-		return "account storage ID"
-	case state.CodesStorageIDKey:
-		return "code storage ID"
-	case handler.BlockStoreLatestBlockKey:
-		return "latest block"
-	case handler.BlockStoreLatestBlockProposalKey:
-		return "latest block proposal"
-	}
-
-	// other fvm registers
-	if kstr == "uuid" || strings.HasPrefix(kstr, "uuid_") {
-		return "uuid generator state"
-	}
-	if strings.HasPrefix(kstr, "public_key_") {
-		return "public key"
-	}
-	if strings.HasPrefix(kstr, flow.CodeKeyPrefix) {
-		return "contract content"
-	}
-
-	// other evm registers
-	if strings.HasPrefix(kstr, blockHashListBucketKeyPrefix) {
-		return "block hash list bucket"
-	}
-	if strings.HasPrefix(kstr, blockHashListMetaKey) {
-		return "block hash list meta"
-	}
-
-	log.Warn().Msgf("unknown payload key: %s", kstr)
-
-	return "others"
+	return typ
 }
@@ -2,6 +2,7 @@ package checkpoint_list_tries
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -28,6 +29,19 @@ func init() {
 
 func run(*cobra.Command, []string) {
 
+	dir, fileName := filepath.Split(flagCheckpoint)
+	chain, err := wal.CheckpointChain(dir, fileName, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error while reading checkpoint chain")
+	}
+	if len(chain) > 0 {
+		// delta checkpoints are loaded together with all their base checkpoints
+		log.Info().Ints("base_checkpoints", chain).Msgf("checkpoint is a delta checkpoint with %d base checkpoints", len(chain))
+		for _, base := range chain {
+			fmt.Printf("base checkpoint: %s\n", filepath.Join(dir, wal.NumberToFilename(base)))
+		}
+	}
+
 	log.Info().Msgf("loading checkpoint %v", flagCheckpoint)
 	tries, err := wal.LoadCheckpoint(flagCheckpoint, log.Logger)
 	if err != nil {
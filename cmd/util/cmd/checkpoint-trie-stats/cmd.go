@@ -3,6 +3,7 @@ package checkpoint_trie_stats
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -45,6 +46,7 @@ func run(*cobra.Command, []string) {
 		Int("InterimNodeCount", res.interimNodeCount).
 		Int("LeafNodeCount", res.leafNodeCount).
 		Int("TotalPayloadSize", res.totalPayloadSize).
+		Ints("BaseCheckpoints", res.baseCheckpoints).
 		Msgf("successfully scanned checkpoint %v", flagCheckpoint)
 }
 
@@ -53,6 +55,7 @@ type result struct {
 	interimNodeCount int
 	leafNodeCount    int
 	totalPayloadSize int
+	baseCheckpoints  []int // chain of base checkpoints if the checkpoint is a delta checkpoint
 }
 
 func readTrie(tries []*trie.MTrie, index int) (*trie.MTrie, error) {
@@ -72,7 +75,27 @@ func readTrie(tries []*trie.MTrie, index int) (*trie.MTrie, error) {
 }
 
 func scanCheckpoint(checkpoint string, trieIndex int, log zerolog.Logger) (result, error) {
-	tries, err := wal.LoadCheckpoint(flagCheckpoint, log)
+	dir, fileName := filepath.Split(checkpoint)
+	info, err := wal.ReadDeltaCheckpointInfo(dir, fileName, log)
+	if err != nil {
+		return result{}, fmt.Errorf("error while reading checkpoint version: %w", err)
+	}
+
+	var chain []int
+	if info != nil {
+		chain, err = wal.CheckpointChain(dir, fileName, log)
+		if err != nil {
+			return result{}, fmt.Errorf("error while reading checkpoint chain: %w", err)
+		}
+
+		log.Info().
+			Ints("base_checkpoints", chain).
+			Uint64("delta_node_count", info.NodeCount-info.BaseNodeCount).
+			Uint64("base_node_count", info.BaseNodeCount).
+			Msg("checkpoint is a delta checkpoint, loading it with its base checkpoints")
+	}
+
+	tries, err := wal.LoadCheckpoint(checkpoint, log)
 	if err != nil {
 		return result{}, fmt.Errorf("error while loading checkpoint: %w", err)
 	}
@@ -93,6 +116,7 @@ func scanCheckpoint(checkpoint string, trieIndex int, log zerolog.Logger) (resul
 		interimNodeCount: 0,
 		leafNodeCount:    0,
 		totalPayloadSize: 0,
+		baseCheckpoints:  chain,
 	}
 	processNode := func(n *node.Node) error {
 		if n.IsLeaf() {
@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"context"
 	"encoding/hex"
+	"os"
 
 	client "github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow/protobuf/go/flow/execution"
@@ -16,6 +17,8 @@ import (
 
 	sdk "github.com/onflow/flow-go-sdk"
 
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/environment"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/grpcclient"
@@ -34,6 +37,7 @@ var (
 	flagProposalKeySeq      uint64
 	flagUseExecutionDataAPI bool
 	flagDumpRegisters       bool
+	flagProfileOutput       string
 )
 
 var Cmd = &cobra.Command{
@@ -68,6 +72,9 @@ func init() {
 	Cmd.Flags().BoolVar(&flagUseExecutionDataAPI, "use-execution-data-api", false, "use the execution data API")
 
 	Cmd.Flags().BoolVar(&flagDumpRegisters, "dump-registers", false, "dump registers")
+
+	Cmd.Flags().StringVar(&flagProfileOutput, "profile-output", "",
+		"write a pprof profile of the computation used by the transaction to the given file")
 }
 
 func run(*cobra.Command, []string) {
@@ -180,6 +187,11 @@ func run(*cobra.Command, []string) {
 
 		dumpRegisters := flagDumpRegisters && isDebuggedTx
 
+		var profiler *environment.ComputationProfiler
+		if flagProfileOutput != "" && isDebuggedTx {
+			profiler = environment.NewComputationProfiler()
+		}
+
 		runTransaction(
 			debugger,
 			blockTxID,
@@ -187,8 +199,13 @@ func run(*cobra.Command, []string) {
 			blockSnapshot,
 			header,
 			dumpRegisters,
+			profiler,
 		)
 
+		if profiler != nil {
+			writeProfile(profiler, flagProfileOutput)
+		}
+
 		if isDebuggedTx {
 			break
 		}
@@ -202,6 +219,7 @@ func runTransaction(
 	blockSnapshot *blockSnapshot,
 	header *flow.Header,
 	dumpRegisters bool,
+	profiler *environment.ComputationProfiler,
 ) {
 
 	log.Info().Msgf("Fetching transaction %s ...", txID)
@@ -243,6 +261,7 @@ func runTransaction(
 		txBody,
 		blockSnapshot,
 		header,
+		fvm.WithComputationProfiler(profiler),
 	)
 	if processErr != nil {
 		log.Fatal().Err(processErr).Msg("Failed to process transaction")
@@ -280,6 +299,21 @@ func runTransaction(
 	}
 }
 
+func writeProfile(profiler *environment.ComputationProfiler, path string) {
+	file, err := os.Create(path)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create profile file")
+	}
+	defer file.Close()
+
+	err = profiler.WriteProfile(file)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to write profile")
+	}
+
+	log.Info().Msgf("Wrote computation profile to %s, inspect it with `go tool pprof %s`", path, path)
+}
+
 func sortRegisters(registerIDs []flow.RegisterID) {
 	slices.SortFunc(registerIDs, func(a, b flow.RegisterID) int {
 		return cmp.Or(
@@ -0,0 +1,484 @@
+package diff_tries
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/runtime"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/reporters"
+	"github.com/onflow/flow-go/cmd/util/ledger/util"
+	"github.com/onflow/flow-go/cmd/util/ledger/util/registers"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	moduleUtil "github.com/onflow/flow-go/module/util"
+)
+
+var (
+	flagCheckpoint1      string
+	flagCheckpoint2      string
+	flagStateCommitment1 string
+	flagStateCommitment2 string
+	flagOutputDirectory  string
+	flagNWorker          int
+)
+
+var Cmd = &cobra.Command{
+	Use:   "diff-tries",
+	Short: "Compares two tries loaded from checkpoints, skipping shared subtries",
+	Long: `diff-tries walks two tries at the same time and skips subtries with the same hash,
+so that only the changed registers are visited. The changed registers are reported grouped by account,
+and registers of Cadence storage are attributed to their storage domain where possible.
+The tries can be loaded from the same checkpoint, or from two different checkpoints.`,
+	Run: run,
+}
+
+const ReporterName = "trie-diff"
+
+func init() {
+	Cmd.Flags().StringVar(&flagCheckpoint1, "checkpoint-1", "",
+		"checkpoint file containing the first trie")
+	_ = Cmd.MarkFlagRequired("checkpoint-1")
+
+	Cmd.Flags().StringVar(&flagStateCommitment1, "state-commitment-1", "",
+		"state commitment of the first trie")
+	_ = Cmd.MarkFlagRequired("state-commitment-1")
+
+	Cmd.Flags().StringVar(&flagCheckpoint2, "checkpoint-2", "",
+		"checkpoint file containing the second trie, defaults to --checkpoint-1")
+
+	Cmd.Flags().StringVar(&flagStateCommitment2, "state-commitment-2", "",
+		"state commitment of the second trie")
+	_ = Cmd.MarkFlagRequired("state-commitment-2")
+
+	Cmd.Flags().StringVar(&flagOutputDirectory, "output-directory", "",
+		"Output directory")
+	_ = Cmd.MarkFlagRequired("output-directory")
+
+	Cmd.Flags().IntVar(&flagNWorker, "n-worker", 10,
+		"number of workers used to decode the changes of accounts")
+}
+
+func run(*cobra.Command, []string) {
+	if flagCheckpoint2 == "" {
+		flagCheckpoint2 = flagCheckpoint1
+	}
+
+	stateCommitment1 := util.ParseStateCommitment(flagStateCommitment1)
+	stateCommitment2 := util.ParseStateCommitment(flagStateCommitment2)
+
+	trie1, trie2, err := loadTries(stateCommitment1, stateCommitment2)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load tries")
+	}
+
+	log.Info().Msg("diffing tries")
+
+	changes, err := diffTries(trie1, trie2, flagNWorker)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to diff tries")
+	}
+
+	rw := reporters.NewReportFileWriterFactoryWithFormat(flagOutputDirectory, log.Logger, reporters.ReportFormatJSONL).
+		ReportWriter(ReporterName)
+	defer rw.Close()
+
+	registerCount := 0
+	for _, accountChanges := range changes {
+		rw.Write(accountChanges)
+		registerCount += len(accountChanges.Registers)
+	}
+
+	log.Info().Msgf("found %d changed registers in %d accounts", registerCount, len(changes))
+}
+
+// loadTries loads the tries with the given state commitments. Each checkpoint is only loaded once.
+func loadTries(stateCommitment1, stateCommitment2 flow.StateCommitment) (*trie.MTrie, *trie.MTrie, error) {
+	tries1, err := loadCheckpoint(flagCheckpoint1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tries2 := tries1
+	if filepath.Clean(flagCheckpoint2) != filepath.Clean(flagCheckpoint1) {
+		tries2, err = loadCheckpoint(flagCheckpoint2)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	trie1, err := findTrie(tries1, stateCommitment1)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not find first trie in %s: %w", flagCheckpoint1, err)
+	}
+
+	trie2, err := findTrie(tries2, stateCommitment2)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not find second trie in %s: %w", flagCheckpoint2, err)
+	}
+
+	return trie1, trie2, nil
+}
+
+func loadCheckpoint(checkpoint string) ([]*trie.MTrie, error) {
+	log.Info().Msgf("loading checkpoint %s", checkpoint)
+
+	tries, err := wal.LoadCheckpoint(checkpoint, log.Logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not load checkpoint %s: %w", checkpoint, err)
+	}
+
+	log.Info().Msgf("checkpoint %s loaded, total tries: %d", checkpoint, len(tries))
+	return tries, nil
+}
+
+func findTrie(tries []*trie.MTrie, stateCommitment flow.StateCommitment) (*trie.MTrie, error) {
+	for _, t := range tries {
+		if t.RootHash() == ledger.RootHash(stateCommitment) {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("no trie with state commitment %x", stateCommitment)
+}
+
+const (
+	registerTypeAtreeSlab      = "atree slab"
+	registerTypeDomain         = "domain storage map"
+	registerTypeAccountStorage = "account storage map"
+	registerTypeOther          = "other"
+)
+
+type registerChange struct {
+	Key    string
+	Type   string
+	Domain string
+	Value1 []byte
+	Value2 []byte
+	// Exists1 and Exists2 distinguish an unallocated register from an empty value.
+	Exists1 bool
+	Exists2 bool
+}
+
+var _ json.Marshaler = registerChange{}
+
+func (c registerChange) MarshalJSON() ([]byte, error) {
+	var change string
+	switch {
+	case !c.Exists1:
+		change = "added"
+	case !c.Exists2:
+		change = "removed"
+	default:
+		change = "updated"
+	}
+
+	return json.Marshal(struct {
+		Key    string `json:"key"`
+		Type   string `json:"type"`
+		Domain string `json:"domain,omitempty"`
+		Change string `json:"change"`
+		Value1 string `json:"value1"`
+		Value2 string `json:"value2"`
+	}{
+		Key:    hex.EncodeToString([]byte(c.Key)),
+		Type:   c.Type,
+		Domain: c.Domain,
+		Change: change,
+		Value1: hex.EncodeToString(c.Value1),
+		Value2: hex.EncodeToString(c.Value2),
+	})
+}
+
+type accountChanges struct {
+	Owner       string
+	Registers   []*registerChange
+	DecodeError string
+}
+
+var _ json.Marshaler = accountChanges{}
+
+func (e accountChanges) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Kind        string            `json:"kind"`
+		Owner       string            `json:"owner"`
+		Registers   []*registerChange `json:"registers"`
+		DecodeError string            `json:"decode_error,omitempty"`
+	}{
+		Kind:        "account-diff",
+		Owner:       hex.EncodeToString([]byte(e.Owner)),
+		Registers:   e.Registers,
+		DecodeError: e.DecodeError,
+	})
+}
+
+// diffTries returns the registers which differ between the two tries, grouped by account and
+// sorted by owner and key. Registers of Cadence storage are attributed to their storage domain
+// where possible.
+func diffTries(trie1, trie2 *trie.MTrie, nWorkers int) ([]*accountChanges, error) {
+	changesByOwner := make(map[string]*accountChanges)
+
+	err := trie.DiffTries(trie1, trie2, func(_ ledger.Path, payload1, payload2 *ledger.Payload) error {
+		payload := payload1
+		if payload == nil {
+			payload = payload2
+		}
+
+		key, err := payload.Key()
+		if err != nil {
+			return fmt.Errorf("could not decode payload key: %w", err)
+		}
+		registerID, err := convert.LedgerKeyToRegisterID(key)
+		if err != nil {
+			return fmt.Errorf("could not convert payload key to register ID: %w", err)
+		}
+
+		account, ok := changesByOwner[registerID.Owner]
+		if !ok {
+			account = &accountChanges{Owner: registerID.Owner}
+			changesByOwner[registerID.Owner] = account
+		}
+
+		change := &registerChange{
+			Key:  registerID.Key,
+			Type: registerType(registerID.Key),
+		}
+		if payload1 != nil {
+			change.Value1 = payload1.Value()
+			change.Exists1 = true
+		}
+		if payload2 != nil {
+			change.Value2 = payload2.Value()
+			change.Exists2 = true
+		}
+		if change.Type == registerTypeDomain {
+			change.Domain = registerID.Key
+		}
+		account.Registers = append(account.Registers, change)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*accountChanges, 0, len(changesByOwner))
+	for _, account := range changesByOwner {
+		slices.SortFunc(account.Registers, func(a, b *registerChange) int {
+			return bytes.Compare([]byte(a.Key), []byte(b.Key))
+		})
+		changes = append(changes, account)
+	}
+	slices.SortFunc(changes, func(a, b *accountChanges) int {
+		return bytes.Compare([]byte(a.Owner), []byte(b.Owner))
+	})
+
+	log.Info().Msgf("decoding storage domains of %d changed accounts", len(changes))
+
+	logAccount := moduleUtil.LogProgress(
+		log.Logger,
+		moduleUtil.DefaultLogProgressConfig("decoding accounts", len(changes)),
+	)
+
+	g := errgroup.Group{}
+	g.SetLimit(max(nWorkers, 1))
+	for _, account := range changes {
+		g.Go(func() error {
+			defer logAccount(1)
+
+			err := decodeDomains(trie1, trie2, account)
+			if err != nil {
+				account.DecodeError = err.Error()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return changes, nil
+}
+
+func registerType(key string) string {
+	if flow.IsSlabIndexKey(key) {
+		return registerTypeAtreeSlab
+	}
+	if _, ok := common.AllStorageDomainsByIdentifier[key]; ok {
+		return registerTypeDomain
+	}
+	if key == runtime.AccountStorageKey {
+		return registerTypeAccountStorage
+	}
+	return registerTypeOther
+}
+
+// decodeDomains attributes the changed atree slabs of the account to the storage domain they belong to.
+// Slabs which exist in the second trie are looked up there, removed slabs are looked up in the first trie.
+// Slabs which are not reachable from a domain storage map stored in its own slab, such as slabs of
+// domain storage maps inlined into the account storage map, or of non-Cadence atree storage,
+// are left without a domain.
+func decodeDomains(trie1, trie2 *trie.MTrie, account *accountChanges) error {
+	if account.Owner == "" {
+		return nil
+	}
+
+	slabs1 := make(map[string]*registerChange)
+	slabs2 := make(map[string]*registerChange)
+	for _, change := range account.Registers {
+		if change.Type != registerTypeAtreeSlab {
+			continue
+		}
+		if change.Exists2 {
+			slabs2[change.Key] = change
+		} else {
+			slabs1[change.Key] = change
+		}
+	}
+
+	err := attributeSlabs(trie2, account.Owner, slabs2)
+	if err != nil {
+		return err
+	}
+	return attributeSlabs(trie1, account.Owner, slabs1)
+}
+
+var errAllSlabsFound = errors.New("all slabs found")
+
+// attributeSlabs walks the slabs of all domain storage maps of the account, and sets the domain
+// of the given slab registers. The walk stops as soon as all slabs have been found.
+func attributeSlabs(t *trie.MTrie, owner string, slabs map[string]*registerChange) (err error) {
+	if len(slabs) == 0 {
+		return nil
+	}
+
+	defer func() {
+		// decoding corrupted storage panics
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while decoding storage: %v", r)
+		}
+	}()
+
+	storage := runtime.NewStorage(
+		registers.ReadOnlyLedger{Registers: trieRegisters{trie: t}},
+		nil,
+		runtime.StorageConfig{StorageFormatV2Enabled: true},
+	)
+
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{Storage: storage})
+	if err != nil {
+		return fmt.Errorf("could not create interpreter: %w", err)
+	}
+
+	address := common.Address(flow.BytesToAddress([]byte(owner)))
+
+	for _, domain := range common.AllStorageDomains {
+		storageMap := storage.GetDomainStorageMap(inter, address, domain, false)
+		if storageMap == nil || storageMap.Inlined() {
+			continue
+		}
+
+		err = walkSlabs(storage, storageMap.SlabID(), func(id atree.SlabID) error {
+			index := id.Index()
+			change, ok := slabs[string(atree.SlabIndexToLedgerKey(index))]
+			if !ok {
+				return nil
+			}
+			change.Domain = domain.Identifier()
+			delete(slabs, string(atree.SlabIndexToLedgerKey(index)))
+			if len(slabs) == 0 {
+				return errAllSlabsFound
+			}
+			return nil
+		})
+		if errors.Is(err, errAllSlabsFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("could not walk slabs of domain %s: %w", domain.Identifier(), err)
+		}
+	}
+
+	return nil
+}
+
+// walkSlabs calls visit for the slab with the given ID and all slabs referenced by it, recursively.
+func walkSlabs(storage atree.SlabStorage, id atree.SlabID, visit func(atree.SlabID) error) error {
+	slab, found, err := storage.Retrieve(id)
+	if err != nil {
+		return fmt.Errorf("could not retrieve slab %s: %w", id, err)
+	}
+	if !found {
+		return fmt.Errorf("slab %s not found", id)
+	}
+
+	err = visit(id)
+	if err != nil {
+		return err
+	}
+
+	return walkStorables(storage, slab.ChildStorables(), visit)
+}
+
+func walkStorables(storage atree.SlabStorage, storables []atree.Storable, visit func(atree.SlabID) error) error {
+	for _, storable := range storables {
+		var err error
+		if id, ok := storable.(atree.SlabIDStorable); ok {
+			err = walkSlabs(storage, atree.SlabID(id), visit)
+		} else {
+			// inlined values contain the references of their own children
+			err = walkStorables(storage, storable.ChildStorables(), visit)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trieRegisters reads the registers of a single trie on demand.
+// Only Get is supported, registers are never iterated.
+type trieRegisters struct {
+	trie *trie.MTrie
+}
+
+var _ registers.Registers = trieRegisters{}
+
+func (r trieRegisters) Get(owner string, key string) ([]byte, error) {
+	ledgerKey := convert.RegisterIDToLedgerKey(flow.RegisterID{Owner: owner, Key: key})
+	path, err := pathfinder.KeyToPath(ledgerKey, complete.DefaultPathFinderVersion)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute path: %w", err)
+	}
+
+	payload := r.trie.ReadSinglePayload(path)
+	if payload == nil {
+		return nil, nil
+	}
+	return payload.Value(), nil
+}
+
+func (r trieRegisters) Set(string, string, []byte) error {
+	return errors.New("trie registers are read-only")
+}
+
+func (r trieRegisters) ForEach(registers.ForEachCallback) error {
+	return errors.New("trie registers cannot be iterated")
+}
+
+func (r trieRegisters) Count() int {
+	return 0
+}
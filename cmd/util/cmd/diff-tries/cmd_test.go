@@ -0,0 +1,130 @@
+package diff_tries
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/runtime"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/cmd/util/ledger/util"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func newTrie(t *testing.T, parent *trie.MTrie, payloads map[flow.RegisterID]*ledger.Payload) *trie.MTrie {
+	paths := make([]ledger.Path, 0, len(payloads))
+	values := make([]ledger.Payload, 0, len(payloads))
+	for registerID, payload := range payloads {
+		path, err := pathfinder.KeyToPath(convert.RegisterIDToLedgerKey(registerID), complete.DefaultPathFinderVersion)
+		require.NoError(t, err)
+		paths = append(paths, path)
+		values = append(values, *payload)
+	}
+
+	result, _, err := trie.NewTrieWithUpdatedRegisters(parent, paths, values, true)
+	require.NoError(t, err)
+	return result
+}
+
+func TestDiffTries(t *testing.T) {
+	chain := flow.Emulator.Chain()
+	testFlowAddress, err := chain.AddressAtIndex(1_000_000)
+	require.NoError(t, err)
+	testAddress := common.Address(testFlowAddress)
+	otherAddress := flow.HexToAddress("0x02")
+
+	payloads := map[flow.RegisterID]*ledger.Payload{}
+	payloadsLedger := util.NewPayloadsLedger(payloads)
+
+	var slabIndex uint64
+	payloadsLedger.AllocateSlabIndexFunc = func([]byte) (atree.SlabIndex, error) {
+		var index atree.SlabIndex
+		slabIndex++
+		binary.BigEndian.PutUint64(index[:], slabIndex)
+		return index, nil
+	}
+
+	storage := runtime.NewStorage(payloadsLedger, nil, runtime.StorageConfig{})
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{Storage: storage})
+	require.NoError(t, err)
+
+	// store an array large enough to be stored in its own slab in the storage domain
+	arrayStaticType := interpreter.NewVariableSizedStaticType(nil, interpreter.PrimitiveStaticTypeInt)
+	arrayValues := make([]interpreter.Value, 100)
+	for i := range arrayValues {
+		arrayValues[i] = interpreter.NewUnmeteredIntValueFromInt64(int64(i))
+	}
+	array := interpreter.NewArrayValue(
+		inter,
+		interpreter.EmptyLocationRange,
+		arrayStaticType,
+		testAddress,
+		arrayValues...,
+	)
+
+	storageMap := storage.GetDomainStorageMap(inter, testAddress, common.StorageDomainPathStorage, true)
+	storageMap.SetValue(inter, interpreter.StringStorageMapKey("array"), array)
+
+	require.NoError(t, storage.NondeterministicCommit(inter, false))
+
+	payloads[flow.NewRegisterID(otherAddress, "a")] = ledger.NewPayload(
+		convert.RegisterIDToLedgerKey(flow.NewRegisterID(otherAddress, "a")),
+		[]byte{1},
+	)
+	trie1 := newTrie(t, trie.NewEmptyMTrie(), payloads)
+
+	// update the array in place, which only changes the slab of the array
+	arraySlabKey := string(atree.SlabIndexToLedgerKey(array.SlabID().Index()))
+	oldArraySlab := payloads[flow.NewRegisterID(testFlowAddress, arraySlabKey)].Value()
+	array.Set(inter, interpreter.EmptyLocationRange, 0, interpreter.NewUnmeteredIntValueFromInt64(1000))
+	require.NoError(t, storage.NondeterministicCommit(inter, false))
+
+	changed := map[flow.RegisterID]*ledger.Payload{
+		flow.NewRegisterID(testFlowAddress, arraySlabKey): payloads[flow.NewRegisterID(testFlowAddress, arraySlabKey)],
+		flow.NewRegisterID(otherAddress, "a"):             ledger.EmptyPayload(),
+		flow.NewRegisterID(otherAddress, "b"): ledger.NewPayload(
+			convert.RegisterIDToLedgerKey(flow.NewRegisterID(otherAddress, "b")),
+			[]byte{2},
+		),
+	}
+	trie2 := newTrie(t, trie1, changed)
+
+	changes, err := diffTries(trie1, trie2, 2)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+
+	// accounts are sorted by owner
+	other, test := changes[0], changes[1]
+	require.Equal(t, string(otherAddress[:]), other.Owner)
+	require.Equal(t, string(testAddress[:]), test.Owner)
+
+	require.Len(t, other.Registers, 2)
+	require.Equal(t, "a", other.Registers[0].Key)
+	require.Equal(t, registerTypeOther, other.Registers[0].Type)
+	require.True(t, other.Registers[0].Exists1)
+	require.False(t, other.Registers[0].Exists2)
+	require.Equal(t, "b", other.Registers[1].Key)
+	require.False(t, other.Registers[1].Exists1)
+	require.Equal(t, []byte{2}, other.Registers[1].Value2)
+
+	require.Empty(t, test.DecodeError)
+	require.Len(t, test.Registers, 1)
+	arrayChange := test.Registers[0]
+	require.Equal(t, arraySlabKey, arrayChange.Key)
+	require.Equal(t, registerTypeAtreeSlab, arrayChange.Type)
+	require.Equal(t, common.StorageDomainPathStorage.Identifier(), arrayChange.Domain)
+	require.Equal(t, []byte(oldArraySlab), arrayChange.Value1)
+
+	// equal tries have no changes
+	changes, err = diffTries(trie2, trie2, 2)
+	require.NoError(t, err)
+	require.Empty(t, changes)
+}
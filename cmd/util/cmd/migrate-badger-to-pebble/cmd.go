@@ -0,0 +1,94 @@
+package migrate_badger_to_pebble
+
+import (
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/storage/migration"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/operation/pebbleimpl"
+	storagepebble "github.com/onflow/flow-go/storage/pebble"
+)
+
+var (
+	flagDatadir       string
+	flagPebbleDir     string
+	flagWorkers       int
+	flagBatchByteSize int
+	flagVerifyOnly    bool
+)
+
+var Cmd = &cobra.Command{
+	Use:   "migrate-badger-to-pebble",
+	Short: "Copies the protocol database from badger to pebble",
+	Long: `Copies all prefixes of the protocol database from badger to pebble, then verifies the key counts
+and checksums of every prefix. The node must be stopped while the migration runs.
+The migration fails if the badger database contains keys under prefixes which are not migrated.
+An interrupted migration is resumed by running the command again with the same flags.
+Nodes refuse to start with a pebble directory holding a migration that has not completed.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory that stores the badger protocol database")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().StringVar(&flagPebbleDir, "pebble-dir", "",
+		"directory that stores the pebble protocol database")
+	_ = Cmd.MarkFlagRequired("pebble-dir")
+
+	Cmd.Flags().IntVar(&flagWorkers, "workers", migration.DefaultWorkers,
+		"number of prefixes copied and verified concurrently")
+
+	Cmd.Flags().IntVar(&flagBatchByteSize, "batch-byte-size", migration.DefaultBatchByteSize,
+		"size of the data written to pebble in a single batch")
+
+	Cmd.Flags().BoolVar(&flagVerifyOnly, "verify-only", false,
+		"only verify that both databases hold the same data, without copying")
+}
+
+func run(*cobra.Command, []string) {
+	log.Info().
+		Str("datadir", flagDatadir).
+		Str("pebble_dir", flagPebbleDir).
+		Int("workers", flagWorkers).
+		Int("batch_byte_size", flagBatchByteSize).
+		Bool("verify_only", flagVerifyOnly).
+		Msg("flags")
+
+	badgerDB := common.InitStorage(flagDatadir)
+	defer badgerDB.Close()
+
+	err := os.MkdirAll(flagPebbleDir, 0700)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not create pebble directory")
+	}
+
+	pebbleDB, err := storagepebble.OpenDefaultPebbleDB(flagPebbleDir)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open pebble database")
+	}
+	defer pebbleDB.Close()
+
+	if flagVerifyOnly {
+		err = migration.Verify(log.Logger, badgerimpl.ToDB(badgerDB), pebbleimpl.ToDB(pebbleDB), flagWorkers)
+		if err != nil {
+			log.Fatal().Err(err).Msg("verification failed")
+		}
+		log.Info().Msg("verification succeeded")
+		return
+	}
+
+	err = migration.RunMigration(log.Logger, badgerimpl.ToDB(badgerDB), pebbleimpl.ToDB(pebbleDB), migration.Config{
+		PebbleDir:     flagPebbleDir,
+		Workers:       flagWorkers,
+		BatchByteSize: flagBatchByteSize,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("migration failed")
+	}
+}
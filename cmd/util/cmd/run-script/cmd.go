@@ -23,6 +23,7 @@ import (
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
 	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/model/events"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/metrics"
 )
@@ -338,6 +339,21 @@ func (*api) GetSystemTransactionResult(
 	return nil, errors.New("unimplemented")
 }
 
+func (*api) GetTransactionsByAccount(
+	_ context.Context,
+	_ flow.Address,
+	_ uint64,
+	_ uint64,
+	_ uint32,
+	_ *flow.AccountTransactionCursor,
+) (*flow.AccountTransactionsPage, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetTransactionTimeline(_ context.Context, _ flow.Identifier) (*flow.TransactionTimeline, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (*api) GetAccount(_ context.Context, _ flow.Address) (*flow.Account, error) {
 	return nil, errors.New("unimplemented")
 }
@@ -394,6 +410,42 @@ func (*api) GetAccountKeysAtBlockHeight(
 	return nil, errors.New("unimplemented")
 }
 
+func (*api) GetAccountStorageAtLatestBlock(
+	_ context.Context,
+	_ flow.Address,
+	_ uint32,
+	_ *flow.AccountStorageCursor,
+) (*flow.AccountStorage, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetAccountStorageAtBlockHeight(
+	_ context.Context,
+	_ flow.Address,
+	_ uint64,
+	_ uint32,
+	_ *flow.AccountStorageCursor,
+) (*flow.AccountStorage, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) EstimateTransaction(
+	_ context.Context,
+	_ *flow.TransactionBody,
+) (*flow.TransactionEstimate, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) ExecuteScriptAtBlockHeightWithOverrides(
+	_ context.Context,
+	_ uint64,
+	_ []byte,
+	_ [][]byte,
+	_ *flow.StateOverrides,
+) (*flow.ScriptResultWithOverrides, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (a *api) ExecuteScriptAtLatestBlock(
 	_ context.Context,
 	script []byte,
@@ -444,6 +496,16 @@ func (a *api) GetEventsForBlockIDs(
 	return nil, errors.New("unimplemented")
 }
 
+func (a *api) GetEventsByQuery(
+	_ context.Context,
+	_ *events.Query,
+	_ uint64,
+	_ uint64,
+	_ entities.EventEncodingVersion,
+) ([]flow.BlockEvents, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (*api) GetLatestProtocolStateSnapshot(_ context.Context) ([]byte, error) {
 	return nil, errors.New("unimplemented")
 }
@@ -464,6 +526,36 @@ func (*api) GetExecutionResultByID(_ context.Context, _ flow.Identifier) (*flow.
 	return nil, errors.New("unimplemented")
 }
 
+func (*api) GetRegistersWithProofAtBlockID(
+	_ context.Context,
+	_ flow.Identifier,
+	_ flow.RegisterIDs,
+) (*flow.RegistersProof, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetRegisterHistory(
+	_ context.Context,
+	_ flow.RegisterID,
+	_ uint64,
+	_ uint64,
+	_ uint32,
+	_ *flow.RegisterHistoryCursor,
+) (*flow.RegisterHistoryPage, error) {
+	return nil, errors.New("unimplemented")
+}
+
+func (*api) GetAccountRegisterHistory(
+	_ context.Context,
+	_ flow.Address,
+	_ uint64,
+	_ uint64,
+	_ uint32,
+	_ *flow.RegisterHistoryCursor,
+) (*flow.RegisterHistoryPage, error) {
+	return nil, errors.New("unimplemented")
+}
+
 func (*api) SubscribeBlocksFromStartBlockID(
 	_ context.Context,
 	_ flow.Identifier,
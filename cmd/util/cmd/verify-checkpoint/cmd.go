@@ -0,0 +1,159 @@
+package verify_checkpoint
+
+import (
+	"path/filepath"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	utilcommon "github.com/onflow/flow-go/cmd/util/common"
+	"github.com/onflow/flow-go/ledger/common/pathfinder"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+var (
+	flagCheckpointDir  string
+	flagCheckpointFile string
+	flagDatadir        string
+	flagSealedBlocks   uint64
+	flagRepair         bool
+	flagBackupDir      string
+	flagForestCapacity int
+)
+
+var Cmd = &cobra.Command{
+	Use:   "verify-checkpoint",
+	Short: "verifies the integrity of a checkpoint, and optionally rebuilds a corrupt checkpoint",
+	Long: `verify-checkpoint validates the checksum of every part of a checkpoint, re-hashes all the trie nodes
+bottom-up against the stored root hashes, and reports the corrupt subtries.
+If --datadir is given, the root hashes are cross-checked against the state commitments of the latest sealed blocks.
+A corrupt checkpoint can be rebuilt with --repair from the previous checkpoint and the WAL segments following it,
+if they are available in the checkpoint directory. The execution node must be stopped while repairing a checkpoint.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVar(&flagCheckpointDir, "checkpoint-dir", "",
+		"directory of the checkpoints and WAL segments")
+	_ = Cmd.MarkFlagRequired("checkpoint-dir")
+
+	Cmd.Flags().StringVar(&flagCheckpointFile, "checkpoint-file", "",
+		"file name of the checkpoint to verify (default: the latest checkpoint)")
+
+	Cmd.Flags().StringVar(&flagDatadir, "datadir", "",
+		"directory of the protocol database, used to cross-check the root hashes with sealed state commitments (optional)")
+
+	Cmd.Flags().Uint64Var(&flagSealedBlocks, "sealed-blocks", 1000,
+		"number of the latest sealed blocks to search for state commitments matching the root hashes")
+
+	Cmd.Flags().BoolVar(&flagRepair, "repair", false,
+		"rebuild the checkpoint from the previous checkpoint and the WAL if it is corrupt")
+
+	Cmd.Flags().StringVar(&flagBackupDir, "backup-dir", "",
+		"directory to move the files of the corrupt checkpoint to, required with --repair")
+
+	Cmd.Flags().IntVar(&flagForestCapacity, "forest-capacity", complete.DefaultCacheSize,
+		"number of tries stored in the rebuilt checkpoint, must match the --mtrie-cache-size of the execution node")
+}
+
+func run(*cobra.Command, []string) {
+	if flagRepair && flagBackupDir == "" {
+		log.Fatal().Msg("--backup-dir is required with --repair")
+	}
+
+	fileName := flagCheckpointFile
+	if fileName == "" {
+		_, last, err := wal.ListCheckpoints(flagCheckpointDir)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not list checkpoints")
+		}
+		if last < 0 {
+			log.Fatal().Msgf("no checkpoint found in %v", flagCheckpointDir)
+		}
+		fileName = wal.NumberToFilename(last)
+	}
+	checkpointFilePath := filepath.Join(flagCheckpointDir, fileName)
+
+	var (
+		state   protocol.State
+		headers storage.Headers
+		seals   storage.Seals
+	)
+	if flagDatadir != "" {
+		db := common.InitStorage(flagDatadir)
+		defer db.Close()
+
+		storages := common.InitStorages(db)
+		var err error
+		state, err = common.InitProtocolState(db, storages)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not init protocol state")
+		}
+		headers = storages.Headers
+		seals = storages.Seals
+	}
+
+	log.Info().Msgf("verifying checkpoint %v", checkpointFilePath)
+
+	report, err := utilcommon.VerifyCheckpoint(log.Logger, checkpointFilePath, state, headers, seals, flagSealedBlocks)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not verify checkpoint")
+	}
+
+	common.PrettyPrint(report)
+
+	if report.SealedTrieFound != nil && !*report.SealedTrieFound {
+		log.Warn().Msgf("none of the tries of the checkpoint matches a state commitment of the latest %d sealed blocks",
+			flagSealedBlocks)
+	}
+
+	if !report.IsCorrupted() {
+		log.Info().Msgf("checkpoint %v is valid", checkpointFilePath)
+		return
+	}
+
+	if !report.Rebuild.Possible {
+		log.Fatal().Msgf("checkpoint %v is corrupted, and cannot be rebuilt: %s", checkpointFilePath, report.Rebuild.Reason)
+	}
+
+	if !flagRepair {
+		log.Fatal().Msgf("checkpoint %v is corrupted, it can be rebuilt from checkpoint %d and WAL segments %d to %d. "+
+			"rerun with --repair and --backup-dir to rebuild it",
+			checkpointFilePath, report.Rebuild.FromCheckpoint, report.Rebuild.FirstSegment, report.Rebuild.LastSegment)
+	}
+
+	checkpoint, _ := wal.FilenameToNumber(fileName)
+	repair(checkpoint, checkpointFilePath)
+}
+
+func repair(checkpoint int, checkpointFilePath string) {
+	diskWal, err := wal.NewDiskWAL(log.Logger, nil, metrics.NewNoopCollector(), flagCheckpointDir, flagForestCapacity, pathfinder.PathByteSize, wal.SegmentSize)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open WAL")
+	}
+	defer func() {
+		<-diskWal.Done()
+	}()
+
+	err = wal.RepairCheckpoint(diskWal, checkpoint, flagForestCapacity, flagBackupDir)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("could not repair checkpoint %v", checkpointFilePath)
+	}
+
+	report, err := utilcommon.VerifyCheckpoint(log.Logger, checkpointFilePath, nil, nil, nil, 0)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not verify repaired checkpoint")
+	}
+	if report.IsCorrupted() {
+		common.PrettyPrint(report)
+		log.Fatal().Msgf("repaired checkpoint %v is still corrupted", checkpointFilePath)
+	}
+
+	log.Info().Msgf("checkpoint %v was rebuilt successfully, the corrupt files were moved to %v",
+		checkpointFilePath, flagBackupDir)
+}
@@ -0,0 +1,252 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// CheckpointVerificationReport is the result of verifying a checkpoint with VerifyCheckpoint.
+type CheckpointVerificationReport struct {
+	Checkpoint string `json:"checkpoint"`
+	// ChecksumsOnly is true if only the checksums of the checkpoint files were verified,
+	// see VerifyCheckpointChecksums. The trie nodes are not verified, and NodeCount is 0.
+	ChecksumsOnly bool             `json:"checksums_only,omitempty"`
+	NodeCount     uint64           `json:"node_count"`
+	Corrupted     []CorruptSubtrie `json:"corrupted,omitempty"`
+	Tries         []CheckpointTrie `json:"tries"`
+	// SealedTrieFound is true if the root hash of at least one trie matches a sealed state commitment,
+	// it is only set if the root hashes were cross-checked against the protocol state.
+	SealedTrieFound *bool `json:"sealed_trie_found,omitempty"`
+	// Rebuild describes how a corrupt checkpoint can be rebuilt, it is only set if the checkpoint is corrupt.
+	Rebuild *CheckpointRebuild `json:"rebuild,omitempty"`
+}
+
+// CorruptSubtrie is a subtrie of a checkpoint which failed verification.
+type CorruptSubtrie struct {
+	File       string `json:"file"`
+	Height     int    `json:"height"`
+	PathPrefix string `json:"path_prefix"`
+	Error      string `json:"error"`
+}
+
+// CheckpointTrie is a trie of a checkpoint.
+type CheckpointTrie struct {
+	RootHash string `json:"root_hash"`
+	// SealedHeight is the height of the sealed block with the root hash as state commitment, if any.
+	SealedHeight *uint64 `json:"sealed_height,omitempty"`
+}
+
+// CheckpointRebuild describes whether a corrupt checkpoint can be rebuilt from a previous checkpoint
+// and the WAL segments following it.
+type CheckpointRebuild struct {
+	Possible       bool   `json:"possible"`
+	FromCheckpoint int    `json:"from_checkpoint"` // -1 for the root checkpoint
+	FirstSegment   int    `json:"first_segment"`
+	LastSegment    int    `json:"last_segment"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// IsCorrupted returns true if the checkpoint failed verification.
+func (r *CheckpointVerificationReport) IsCorrupted() bool {
+	return len(r.Corrupted) > 0
+}
+
+// VerifyCheckpoint verifies the integrity of the given checkpoint file, see wal.VerifyCheckpoint.
+//
+// If state is not nil, the root hashes of the tries are cross-checked against the state commitments
+// sealed by the latest sealedBlocks sealed blocks. A checkpoint usually also contains tries of blocks
+// which are not sealed yet, or of forks, so only some of the tries are expected to match.
+//
+// If the checkpoint is corrupt, the report describes whether it can be rebuilt from the previous
+// checkpoint and the WAL segments in the checkpoint directory, see wal.RepairCheckpoint.
+func VerifyCheckpoint(
+	logger zerolog.Logger,
+	checkpointFilePath string,
+	state protocol.State,
+	headers storage.Headers,
+	seals storage.Seals,
+	sealedBlocks uint64,
+) (*CheckpointVerificationReport, error) {
+	dir, fileName := filepath.Split(checkpointFilePath)
+
+	result, err := wal.VerifyCheckpoint(dir, fileName, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify checkpoint %v: %w", checkpointFilePath, err)
+	}
+
+	report := &CheckpointVerificationReport{
+		Checkpoint: checkpointFilePath,
+		NodeCount:  result.NodeCount,
+	}
+	return completeReport(logger, report, result, state, headers, seals, sealedBlocks)
+}
+
+// VerifyCheckpointChecksums verifies the checksums of the files of the given checkpoint, see
+// wal.VerifyCheckpointChecksums. Unlike VerifyCheckpoint, the checkpoint is streamed one file at a
+// time without rebuilding the tries, so it can be used on a running node.
+// The root hashes are cross-checked and the rebuild is described as for VerifyCheckpoint.
+func VerifyCheckpointChecksums(
+	logger zerolog.Logger,
+	checkpointFilePath string,
+	state protocol.State,
+	headers storage.Headers,
+	seals storage.Seals,
+	sealedBlocks uint64,
+) (*CheckpointVerificationReport, error) {
+	dir, fileName := filepath.Split(checkpointFilePath)
+
+	result, err := wal.VerifyCheckpointChecksums(dir, fileName, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not verify checksums of checkpoint %v: %w", checkpointFilePath, err)
+	}
+
+	report := &CheckpointVerificationReport{
+		Checkpoint:    checkpointFilePath,
+		ChecksumsOnly: true,
+	}
+	return completeReport(logger, report, result, state, headers, seals, sealedBlocks)
+}
+
+// completeReport adds the corrupt subtries and the tries of the verification result to the report,
+// cross-checks the root hashes and describes the rebuild of a corrupt checkpoint.
+func completeReport(
+	logger zerolog.Logger,
+	report *CheckpointVerificationReport,
+	result *wal.CheckpointVerificationResult,
+	state protocol.State,
+	headers storage.Headers,
+	seals storage.Seals,
+	sealedBlocks uint64,
+) (*CheckpointVerificationReport, error) {
+	dir, fileName := filepath.Split(report.Checkpoint)
+	report.Tries = make([]CheckpointTrie, 0, len(result.RootHashes))
+
+	for _, corrupted := range result.Corrupted {
+		logger.Warn().Msgf("checkpoint is corrupted: %v", corrupted)
+		report.Corrupted = append(report.Corrupted, CorruptSubtrie{
+			File:       corrupted.File,
+			Height:     corrupted.Height,
+			PathPrefix: corrupted.PathPrefix(),
+			Error:      corrupted.Err.Error(),
+		})
+	}
+
+	for _, rootHash := range result.RootHashes {
+		report.Tries = append(report.Tries, CheckpointTrie{RootHash: rootHash.String()})
+	}
+
+	if state != nil && len(result.RootHashes) > 0 {
+		sealedHeights, err := findSealedHeights(state, headers, seals, result.RootHashes, sealedBlocks)
+		if err != nil {
+			return nil, fmt.Errorf("could not cross-check root hashes with sealed state commitments: %w", err)
+		}
+
+		found := false
+		for i, rootHash := range result.RootHashes {
+			height, ok := sealedHeights[flow.StateCommitment(rootHash)]
+			if ok {
+				report.Tries[i].SealedHeight = &height
+				found = true
+			}
+		}
+		report.SealedTrieFound = &found
+
+		logger.Info().Msgf("%d of %d tries in checkpoint match sealed state commitments", len(sealedHeights), len(result.RootHashes))
+	}
+
+	if report.IsCorrupted() {
+		var err error
+		report.Rebuild, err = checkpointRebuild(dir, fileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// findSealedHeights returns the heights of the latest sealed blocks with the given state commitments,
+// searching at most sealedBlocks sealed heights backwards from the latest sealed block.
+func findSealedHeights(
+	state protocol.State,
+	headers storage.Headers,
+	seals storage.Seals,
+	rootHashes []ledger.RootHash,
+	sealedBlocks uint64,
+) (map[flow.StateCommitment]uint64, error) {
+	remaining := make(map[flow.StateCommitment]struct{}, len(rootHashes))
+	for _, commit := range hashesToCommits(rootHashes) {
+		remaining[commit] = struct{}{}
+	}
+
+	sealed, err := state.Sealed().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest sealed block: %w", err)
+	}
+	rootHeight := state.Params().SealedRoot().Height
+
+	found := make(map[flow.StateCommitment]uint64)
+	for height := sealed.Height; height >= rootHeight && sealed.Height-height < sealedBlocks; height-- {
+		blockID, err := headers.BlockIDByHeight(height)
+		if err != nil {
+			return nil, fmt.Errorf("could not find block by height %v: %w", height, err)
+		}
+
+		seal, err := seals.FinalizedSealForBlock(blockID)
+		if errors.Is(err, storage.ErrNotFound) {
+			// the root block might not have a seal indexed
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not find seal for block %v at height %v: %w", blockID, height, err)
+		}
+
+		if _, ok := remaining[seal.FinalState]; ok {
+			found[seal.FinalState] = height
+			delete(remaining, seal.FinalState)
+			if len(remaining) == 0 {
+				break
+			}
+		}
+
+		if height == 0 {
+			break
+		}
+	}
+
+	return found, nil
+}
+
+func checkpointRebuild(dir string, fileName string) (*CheckpointRebuild, error) {
+	checkpoint, ok := wal.FilenameToNumber(fileName)
+	if !ok {
+		return &CheckpointRebuild{
+			Reason: fmt.Sprintf("%v is not a numbered checkpoint", fileName),
+		}, nil
+	}
+
+	previous, ok, err := wal.CheckpointRebuildSource(dir, checkpoint)
+	if err != nil {
+		return nil, fmt.Errorf("could not find checkpoint to rebuild checkpoint %d from: %w", checkpoint, err)
+	}
+
+	rebuild := &CheckpointRebuild{
+		Possible:       ok,
+		FromCheckpoint: previous,
+		FirstSegment:   previous + 1,
+		LastSegment:    checkpoint,
+	}
+	if !ok {
+		rebuild.Reason = fmt.Sprintf("WAL segments %d to %d are not available", previous+1, checkpoint)
+	}
+	return rebuild, nil
+}
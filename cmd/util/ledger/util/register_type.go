@@ -0,0 +1,81 @@
+package util
+
+import (
+	"strings"
+
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/runtime"
+
+	"github.com/onflow/flow-go/fvm/evm/emulator/state"
+	"github.com/onflow/flow-go/fvm/evm/handler"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// DomainRegisterTypePrefix is the prefix of the register type of domain storage map registers.
+	DomainRegisterTypePrefix = "domain "
+
+	// UnknownRegisterType is the register type of registers whose key is not recognized.
+	UnknownRegisterType = "others"
+)
+
+const (
+	// EVM register keys from fvm/evm/handler/blockHashList.go
+	blockHashListMetaKey         = "BlockHashListMeta"
+	blockHashListBucketKeyPrefix = "BlockHashListBucket"
+)
+
+// RegisterType returns a human-readable type of the register with the given key,
+// such as "atree slab", "domain storage" or "contract content".
+// It returns UnknownRegisterType if the key is not recognized.
+func RegisterType(key string) string {
+	if atree.LedgerKeyIsSlabKey(key) {
+		return "atree slab"
+	}
+
+	_, isDomain := common.AllStorageDomainsByIdentifier[key]
+	if isDomain {
+		return DomainRegisterTypePrefix + key
+	}
+
+	switch key {
+	case runtime.AccountStorageKey:
+		return "account"
+	case flow.ContractNamesKey:
+		return "contract names"
+	case flow.AccountStatusKey:
+		return "account status"
+	case flow.AddressStateKey:
+		return "address generator state"
+	case state.AccountsStorageIDKey:
+		return "account storage ID"
+	case state.CodesStorageIDKey:
+		return "code storage ID"
+	case handler.BlockStoreLatestBlockKey:
+		return "latest block"
+	case handler.BlockStoreLatestBlockProposalKey:
+		return "latest block proposal"
+	}
+
+	// other fvm registers
+	if key == "uuid" || strings.HasPrefix(key, "uuid_") {
+		return "uuid generator state"
+	}
+	if strings.HasPrefix(key, "public_key_") {
+		return "public key"
+	}
+	if strings.HasPrefix(key, flow.CodeKeyPrefix) {
+		return "contract content"
+	}
+
+	// other evm registers
+	if strings.HasPrefix(key, blockHashListBucketKeyPrefix) {
+		return "block hash list bucket"
+	}
+	if strings.HasPrefix(key, blockHashListMetaKey) {
+		return "block hash list meta"
+	}
+
+	return UnknownRegisterType
+}
@@ -686,6 +686,7 @@ func (suite *Suite) TestGetSealedTransaction() {
 			collections,
 			all.Blocks,
 			blockTransactions,
+			nil,
 		)
 		require.NoError(suite.T(), err)
 
@@ -875,6 +876,7 @@ func (suite *Suite) TestGetTransactionResult() {
 			collections,
 			all.Blocks,
 			blockTransactions,
+			nil,
 		)
 		require.NoError(suite.T(), err)
 
@@ -1128,6 +1130,7 @@ func (suite *Suite) TestExecuteScript() {
 			collections,
 			all.Blocks,
 			blockTransactions,
+			nil,
 		)
 		require.NoError(suite.T(), err)
 
@@ -0,0 +1,684 @@
+package ethrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/eth/filters"
+	"github.com/onflow/go-ethereum/rpc"
+
+	"github.com/onflow/flow-go/cmd/build"
+	"github.com/onflow/flow-go/fvm/evm/offchain/query"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/storage"
+)
+
+// errBlockNotFound is returned by state queries for EVM blocks which are not indexed.
+var errBlockNotFound = errors.New("header not found")
+
+// revertError is returned by eth_call and eth_estimateGas if the execution is reverted.
+// It carries the returned data, which holds the encoded revert reason.
+type revertError struct {
+	message string
+	data    string
+}
+
+var _ rpc.DataError = (*revertError)(nil)
+
+func newRevertError(res *types.Result) *revertError {
+	return &revertError{
+		message: res.ErrorMessageWithRevertReason(),
+		data:    hexutil.Encode(res.ReturnedData),
+	}
+}
+
+func (e *revertError) Error() string {
+	return e.message
+}
+
+// ErrorCode returns the JSON-RPC error code of reverted executions, as defined by go-ethereum.
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the hex encoded data returned by the reverted execution.
+func (e *revertError) ErrorData() interface{} {
+	return e.data
+}
+
+// EthAPI implements the methods of the "eth" namespace of the Ethereum JSON-RPC API.
+type EthAPI struct {
+	evmChainID *big.Int
+	config     Config
+	evmBlocks  storage.EVMBlocks
+	events     *eventsReader
+	views      *query.ViewProvider
+	sender     *Sender // nil if submitting transactions is disabled
+}
+
+// ChainId returns the EVM chain ID.
+func (a *EthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(a.evmChainID)
+}
+
+// BlockNumber returns the height of the latest indexed EVM block.
+func (a *EthAPI) BlockNumber() (hexutil.Uint64, error) {
+	height, err := a.latestHeight()
+	if err != nil {
+		return 0, err
+	}
+	return hexutil.Uint64(height), nil
+}
+
+// Syncing always returns false, since only blocks sealed on Flow are served.
+func (a *EthAPI) Syncing() (interface{}, error) {
+	return false, nil
+}
+
+// GasPrice returns the configured gas price.
+func (a *EthAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).SetUint64(a.config.GasPrice))
+}
+
+// MaxPriorityFeePerGas returns the configured gas price, since the base fee of Flow EVM blocks is 0.
+func (a *EthAPI) MaxPriorityFeePerGas() *hexutil.Big {
+	return a.GasPrice()
+}
+
+// Accounts returns an empty list, since the server does not manage EVM accounts.
+func (a *EthAPI) Accounts() []gethCommon.Address {
+	return []gethCommon.Address{}
+}
+
+// GetBalance returns the balance of the given address at the end of the given block.
+func (a *EthAPI) GetBalance(
+	_ context.Context,
+	address gethCommon.Address,
+	blockNrOrHash rpc.BlockNumberOrHash,
+) (*hexutil.Big, error) {
+	view, err := a.view(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := view.GetBalance(address)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(balance), nil
+}
+
+// GetTransactionCount returns the nonce of the given address at the end of the given block.
+func (a *EthAPI) GetTransactionCount(
+	_ context.Context,
+	address gethCommon.Address,
+	blockNrOrHash rpc.BlockNumberOrHash,
+) (*hexutil.Uint64, error) {
+	view, err := a.view(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := view.GetNonce(address)
+	if err != nil {
+		return nil, err
+	}
+	return (*hexutil.Uint64)(&nonce), nil
+}
+
+// GetCode returns the code of the given address at the end of the given block.
+func (a *EthAPI) GetCode(
+	_ context.Context,
+	address gethCommon.Address,
+	blockNrOrHash rpc.BlockNumberOrHash,
+) (hexutil.Bytes, error) {
+	view, err := a.view(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return view.GetCode(address)
+}
+
+// GetStorageAt returns the value of the given storage slot of the given address at the end of the given block.
+func (a *EthAPI) GetStorageAt(
+	_ context.Context,
+	address gethCommon.Address,
+	key string,
+	blockNrOrHash rpc.BlockNumberOrHash,
+) (hexutil.Bytes, error) {
+	slot, err := hexutil.DecodeBig(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage key %q: %w", key, err)
+	}
+	view, err := a.view(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	value, err := view.GetSlab(address, gethCommon.BigToHash(slot))
+	if err != nil {
+		return nil, err
+	}
+	return value.Bytes(), nil
+}
+
+// Call executes the given call on top of the state at the end of the given block, and returns the
+// returned data. Reverted executions return a revertError.
+func (a *EthAPI) Call(
+	_ context.Context,
+	args CallArgs,
+	blockNrOrHash *rpc.BlockNumberOrHash,
+) (hexutil.Bytes, error) {
+	res, err := a.dryCall(args, blockNrOrHash, a.callGasLimit(args))
+	if err != nil {
+		return nil, err
+	}
+	err = callError(res)
+	if err != nil {
+		return nil, err
+	}
+	return res.ReturnedData, nil
+}
+
+// EstimateGas returns the lowest gas limit with which the given call succeeds on top of the state at the
+// end of the given block.
+func (a *EthAPI) EstimateGas(
+	_ context.Context,
+	args CallArgs,
+	blockNrOrHash *rpc.BlockNumberOrHash,
+) (hexutil.Uint64, error) {
+	hi := a.callGasLimit(args)
+	res, err := a.dryCall(args, blockNrOrHash, hi)
+	if err != nil {
+		return 0, err
+	}
+	err = callError(res)
+	if err != nil {
+		return 0, err
+	}
+
+	// the gas used by the successful call is a lower bound
+	lo := res.GasConsumed - 1
+
+	// most calls succeed with the gas they used, plus the gas withheld from inner calls
+	optimistic := res.GasConsumed * 64 / 63
+	if optimistic > lo && optimistic < hi {
+		res, err := a.dryCall(args, blockNrOrHash, optimistic)
+		if err != nil {
+			return 0, err
+		}
+		if res.Successful() {
+			hi = optimistic
+		} else {
+			lo = optimistic
+		}
+	}
+
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		res, err := a.dryCall(args, blockNrOrHash, mid)
+		if err != nil {
+			return 0, err
+		}
+		if res.Successful() {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return hexutil.Uint64(hi), nil
+}
+
+// GetBlockByNumber returns the EVM block at the given height, or nil if it is not indexed.
+// If fullTx is true the block includes its full transactions, otherwise only their hashes.
+func (a *EthAPI) GetBlockByNumber(_ context.Context, number rpc.BlockNumber, fullTx bool) (*Block, error) {
+	height, err := a.resolveNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return a.block(height, fullTx)
+}
+
+// GetBlockByHash returns the EVM block with the given hash, or nil if it is not indexed.
+// If fullTx is true the block includes its full transactions, otherwise only their hashes.
+func (a *EthAPI) GetBlockByHash(_ context.Context, hash gethCommon.Hash, fullTx bool) (*Block, error) {
+	height, err := a.evmBlocks.HeightByHash(hash)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return a.block(height, fullTx)
+}
+
+// GetBlockTransactionCountByNumber returns the number of transactions of the EVM block at the given height,
+// or nil if it is not indexed.
+func (a *EthAPI) GetBlockTransactionCountByNumber(_ context.Context, number rpc.BlockNumber) (*hexutil.Uint, error) {
+	height, err := a.resolveNumber(number)
+	if err != nil {
+		return nil, err
+	}
+	return a.transactionCount(height)
+}
+
+// GetBlockTransactionCountByHash returns the number of transactions of the EVM block with the given hash,
+// or nil if it is not indexed.
+func (a *EthAPI) GetBlockTransactionCountByHash(_ context.Context, hash gethCommon.Hash) (*hexutil.Uint, error) {
+	height, err := a.evmBlocks.HeightByHash(hash)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return a.transactionCount(height)
+}
+
+// GetTransactionByHash returns the EVM transaction with the given hash, or nil if it is not indexed.
+func (a *EthAPI) GetTransactionByHash(_ context.Context, hash gethCommon.Hash) (*Transaction, error) {
+	blockHash, transactions, index, err := a.transaction(hash)
+	if err != nil || transactions == nil {
+		return nil, err
+	}
+	return transactions[index].transaction(a.evmChainID, blockHash), nil
+}
+
+// GetTransactionReceipt returns the receipt of the EVM transaction with the given hash, or nil if it is
+// not indexed.
+func (a *EthAPI) GetTransactionReceipt(_ context.Context, hash gethCommon.Hash) (*Receipt, error) {
+	blockHash, transactions, index, err := a.transaction(hash)
+	if err != nil || transactions == nil {
+		return nil, err
+	}
+
+	cumulativeGasUsed := uint64(0)
+	for _, tx := range transactions[:index+1] {
+		cumulativeGasUsed += tx.event.GasConsumed
+	}
+
+	return transactions[index].receipt(blockHash, cumulativeGasUsed), nil
+}
+
+// GetLogs returns the logs matching the given filter criteria. The block range of the criteria may not
+// span more than the configured maximum number of blocks.
+func (a *EthAPI) GetLogs(_ context.Context, criteria filters.FilterCriteria) ([]*gethTypes.Log, error) {
+	var from, to uint64
+	if criteria.BlockHash != nil {
+		height, err := a.evmBlocks.HeightByHash(*criteria.BlockHash)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return nil, errBlockNotFound
+			}
+			return nil, err
+		}
+		from, to = height, height
+	} else {
+		var err error
+		from, err = a.resolveBigNumber(criteria.FromBlock)
+		if err != nil {
+			return nil, err
+		}
+		to, err = a.resolveBigNumber(criteria.ToBlock)
+		if err != nil {
+			return nil, err
+		}
+		if from > to {
+			return nil, fmt.Errorf("invalid block range: from block %d is above to block %d", from, to)
+		}
+		if to-from >= a.config.MaxLogsBlockRange {
+			return nil, fmt.Errorf("block range %d-%d exceeds the maximum of %d blocks", from, to, a.config.MaxLogsBlockRange)
+		}
+		latest, err := a.latestHeight()
+		if err != nil {
+			return nil, err
+		}
+		to = min(to, latest)
+	}
+
+	result := []*gethTypes.Log{}
+	for height := from; height <= to; height++ {
+		entry, err := a.evmBlocks.ByHeight(height)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				break
+			}
+			return nil, err
+		}
+		if !bloomMatches(entry.LogsBloom, criteria.Addresses, criteria.Topics) {
+			continue
+		}
+
+		blockEvent, transactionEvents, err := a.events.Block(entry)
+		if err != nil {
+			return nil, err
+		}
+		logIndex := uint(0)
+		for _, event := range transactionEvents {
+			tx, err := decodeTransaction(a.evmChainID, blockEvent.Hash, event, logIndex)
+			if err != nil {
+				return nil, err
+			}
+			logIndex += uint(len(tx.logs))
+			for _, log := range tx.logs {
+				if logMatches(log, criteria.Addresses, criteria.Topics) {
+					result = append(result, log)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// SendRawTransaction submits the given signed EVM transaction in a Cadence transaction, and returns
+// its hash.
+func (a *EthAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (gethCommon.Hash, error) {
+	if a.sender == nil {
+		return gethCommon.Hash{}, errors.New("submitting transactions is not enabled on this node")
+	}
+	return a.sender.Send(ctx, input)
+}
+
+// latestHeight returns the height of the latest indexed EVM block.
+func (a *EthAPI) latestHeight() (uint64, error) {
+	height, err := a.evmBlocks.LatestHeight()
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return 0, errBlockNotFound
+		}
+		return 0, err
+	}
+	return height, nil
+}
+
+// resolveNumber returns the EVM height of the given block number.
+func (a *EthAPI) resolveNumber(number rpc.BlockNumber) (uint64, error) {
+	switch number {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber, rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+		// only blocks sealed on Flow are indexed
+		return a.latestHeight()
+	case rpc.EarliestBlockNumber:
+		return 0, nil
+	}
+	if number < 0 {
+		return 0, fmt.Errorf("invalid block number %d", number)
+	}
+	return uint64(number), nil
+}
+
+// resolveBigNumber returns the EVM height of the given block number of a filter criteria, a nil
+// number refers to the latest block.
+func (a *EthAPI) resolveBigNumber(number *big.Int) (uint64, error) {
+	if number == nil {
+		return a.latestHeight()
+	}
+	if !number.IsInt64() {
+		return 0, fmt.Errorf("invalid block number %s", number)
+	}
+	return a.resolveNumber(rpc.BlockNumber(number.Int64()))
+}
+
+// resolveHeight returns the EVM height of the given block number or hash.
+func (a *EthAPI) resolveHeight(blockNrOrHash rpc.BlockNumberOrHash) (uint64, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		height, err := a.evmBlocks.HeightByHash(hash)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				return 0, errBlockNotFound
+			}
+			return 0, err
+		}
+		return height, nil
+	}
+	if number, ok := blockNrOrHash.Number(); ok {
+		return a.resolveNumber(number)
+	}
+	return a.latestHeight()
+}
+
+// view returns a view of the EVM state at the end of the given block.
+func (a *EthAPI) view(blockNrOrHash rpc.BlockNumberOrHash) (*query.View, error) {
+	height, err := a.resolveHeight(blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	view, err := a.views.GetBlockView(height)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, errBlockNotFound
+		}
+		return nil, err
+	}
+	return view, nil
+}
+
+// callGasLimit returns the gas limit of the given call, capped at the configured maximum.
+func (a *EthAPI) callGasLimit(args CallArgs) uint64 {
+	if args.Gas == nil {
+		return a.config.MaxCallGasLimit
+	}
+	return min(uint64(*args.Gas), a.config.MaxCallGasLimit)
+}
+
+// dryCall executes the given call with the given gas limit on top of the state at the end of the given
+// block, which defaults to the latest block.
+func (a *EthAPI) dryCall(args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash, gasLimit uint64) (*types.Result, error) {
+	blockRef := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		blockRef = *blockNrOrHash
+	}
+
+	// every call is executed in a fresh view, since calls modify the state of their view
+	view, err := a.view(blockRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to gethCommon.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	if args.To != nil {
+		to = *args.To
+	}
+	value := big.NewInt(0)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+
+	return view.DryCall(from, to, args.data(), value, gasLimit)
+}
+
+// callError returns the error of an unsuccessful call result.
+func callError(res *types.Result) error {
+	if res.Invalid() {
+		return res.ValidationError
+	}
+	if res.Failed() {
+		if res.ResultSummary().ErrorCode == types.ExecutionErrCodeExecutionReverted {
+			return newRevertError(res)
+		}
+		return res.VMError
+	}
+	return nil
+}
+
+// block returns the EVM block at the given height, or nil if it is not indexed.
+func (a *EthAPI) block(height uint64, fullTx bool) (*Block, error) {
+	entry, blockEvent, transactionEvents, err := a.events.BlockByHeight(height)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var transactions []*decodedTransaction
+	if fullTx {
+		transactions, err = decodeTransactions(a.evmChainID, blockEvent.Hash, transactionEvents)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return block(a.evmChainID, entry, blockEvent, transactions, fullTx), nil
+}
+
+// transactionCount returns the number of transactions of the EVM block at the given height, or nil if it
+// is not indexed.
+func (a *EthAPI) transactionCount(height uint64) (*hexutil.Uint, error) {
+	entry, err := a.evmBlocks.ByHeight(height)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	count := hexutil.Uint(len(entry.TransactionHashes))
+	return &count, nil
+}
+
+// transaction returns the hash and the decoded transactions of the EVM block containing the transaction
+// with the given hash, and the index of the transaction. The transactions are nil if the transaction is
+// not indexed.
+func (a *EthAPI) transaction(hash gethCommon.Hash) (gethCommon.Hash, []*decodedTransaction, int, error) {
+	height, err := a.evmBlocks.HeightByTransactionHash(hash)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return gethCommon.Hash{}, nil, 0, nil
+		}
+		return gethCommon.Hash{}, nil, 0, err
+	}
+
+	_, blockEvent, transactionEvents, err := a.events.BlockByHeight(height)
+	if err != nil {
+		return gethCommon.Hash{}, nil, 0, err
+	}
+	transactions, err := decodeTransactions(a.evmChainID, blockEvent.Hash, transactionEvents)
+	if err != nil {
+		return gethCommon.Hash{}, nil, 0, err
+	}
+
+	for i, tx := range transactions {
+		if tx.event.Hash == hash {
+			return blockEvent.Hash, transactions, i, nil
+		}
+	}
+	return gethCommon.Hash{}, nil, 0, fmt.Errorf("transaction %s not found in EVM block %d", hash, height)
+}
+
+// bloomMatches returns true if the given logs bloom may contain logs matching the given addresses and
+// topics. A nil bloom refers to a block without logs.
+func bloomMatches(logsBloom []byte, addresses []gethCommon.Address, topics [][]gethCommon.Hash) bool {
+	if len(logsBloom) == 0 {
+		return false
+	}
+	bloom := gethTypes.BytesToBloom(logsBloom)
+
+	if len(addresses) > 0 {
+		found := false
+		for _, address := range addresses {
+			if gethTypes.BloomLookup(bloom, address) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	for _, alternatives := range topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range alternatives {
+			if gethTypes.BloomLookup(bloom, topic) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// logMatches returns true if the given log matches the given addresses and topics. The log must match
+// one of the addresses, and for every position one of the topics. Empty lists match everything.
+func logMatches(log *gethTypes.Log, addresses []gethCommon.Address, topics [][]gethCommon.Hash) bool {
+	if len(addresses) > 0 {
+		found := false
+		for _, address := range addresses {
+			if log.Address == address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(topics) > len(log.Topics) {
+		return false
+	}
+	for i, alternatives := range topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range alternatives {
+			if log.Topics[i] == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// NetAPI implements the methods of the "net" namespace of the Ethereum JSON-RPC API.
+type NetAPI struct {
+	evmChainID *big.Int
+}
+
+// Version returns the EVM chain ID as the network ID.
+func (a *NetAPI) Version() string {
+	return a.evmChainID.String()
+}
+
+// Listening returns true, since the node accepts requests.
+func (a *NetAPI) Listening() bool {
+	return true
+}
+
+// Web3API implements the methods of the "web3" namespace of the Ethereum JSON-RPC API.
+type Web3API struct{}
+
+// ClientVersion returns the version of the node software.
+func (a *Web3API) ClientVersion() string {
+	return fmt.Sprintf("flow-go/%s", build.Version())
+}
+
+// DebugAPI implements the tracing methods of the "debug" namespace of the Ethereum JSON-RPC API.
+type DebugAPI struct {
+	tracer *Tracer
+}
+
+// TraceTransaction returns the trace of the EVM transaction with the given hash.
+func (a *DebugAPI) TraceTransaction(ctx context.Context, hash gethCommon.Hash, config *TraceConfig) (json.RawMessage, error) {
+	return a.tracer.TraceTransaction(ctx, hash, config)
+}
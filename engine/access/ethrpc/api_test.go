@@ -0,0 +1,444 @@
+package ethrpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	gethCrypto "github.com/onflow/go-ethereum/crypto"
+	"github.com/onflow/go-ethereum/eth/filters"
+	"github.com/onflow/go-ethereum/rpc"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/offchain/blocks"
+	"github.com/onflow/flow-go/fvm/evm/offchain/query"
+	"github.com/onflow/flow-go/fvm/evm/testutils"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testSignedTransactionEvent returns the event of a signed legacy EVM transaction, executed in the EVM
+// block at the given height with the given index, and the hash of the transaction.
+func testSignedTransactionEvent(
+	t *testing.T,
+	key *ecdsa.PrivateKey,
+	nonce uint64,
+	blockHeight uint64,
+	index uint16,
+	gasConsumed uint64,
+	logs ...*gethTypes.Log,
+) (*events.Event, gethCommon.Hash) {
+	to := gethCommon.HexToAddress("0xff")
+	tx, err := gethTypes.SignTx(
+		gethTypes.NewTransaction(nonce, to, big.NewInt(1), 100_000, big.NewInt(1), nil),
+		gethTypes.LatestSignerForChainID(types.EVMChainIDFromFlowChainID(testChainID)),
+		key,
+	)
+	require.NoError(t, err)
+	payload, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	result := &types.Result{
+		TxType:      gethTypes.LegacyTxType,
+		GasConsumed: gasConsumed,
+		TxHash:      tx.Hash(),
+		Index:       index,
+		Logs:        logs,
+	}
+	return events.NewTransactionEvent(result, payload, blockHeight), tx.Hash()
+}
+
+// logKey identifies a log returned by the API.
+type logKey struct {
+	TxHash gethCommon.Hash
+	Index  uint
+}
+
+func logKeys(logs []*gethTypes.Log) []logKey {
+	keys := make([]logKey, len(logs))
+	for i, log := range logs {
+		keys[i] = logKey{TxHash: log.TxHash, Index: log.Index}
+	}
+	return keys
+}
+
+func TestEthAPI(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		evmBlocks := bstorage.NewEVMBlocks(db)
+		testEvents := newTestEVMEvents(t)
+		reader := newEventsReader(testChainID, testEvents.headers, testEvents.events, evmBlocks)
+
+		key, err := gethCrypto.GenerateKey()
+		require.NoError(t, err)
+		sender := gethCrypto.PubkeyToAddress(key.PublicKey)
+
+		address1 := gethCommon.HexToAddress("0x01")
+		address2 := gethCommon.HexToAddress("0x02")
+		topic1 := gethCommon.HexToHash("0x11")
+		topic2 := gethCommon.HexToHash("0x12")
+
+		// Flow block 10 executes EVM block 0, with two transactions emitting logs
+		tx0, tx0Hash := testSignedTransactionEvent(t, key, 0, 0, 0, 21_000,
+			&gethTypes.Log{Address: address1, Topics: []gethCommon.Hash{topic1}})
+		tx1, tx1Hash := testSignedTransactionEvent(t, key, 1, 0, 1, 30_000,
+			&gethTypes.Log{Address: address2, Topics: []gethCommon.Hash{topic2}},
+			&gethTypes.Log{Address: address1, Topics: []gethCommon.Hash{topic1, topic2}})
+		block0, block0Hash := testBlockEvent(t, 0)
+		testEvents.setEvents(10, tx0, tx1, block0)
+
+		// Flow block 11 executes EVM block 1, with a transaction without logs
+		tx2, _ := testSignedTransactionEvent(t, key, 2, 1, 0, 25_000)
+		block1, _ := testBlockEvent(t, 1)
+		testEvents.setEvents(11, tx2, block1)
+
+		// Flow block 12 executes EVM block 2, with a transaction emitting a log
+		tx3, tx3Hash := testSignedTransactionEvent(t, key, 3, 2, 0, 40_000,
+			&gethTypes.Log{Address: address2, Topics: []gethCommon.Hash{topic1}})
+		block2, _ := testBlockEvent(t, 2)
+		testEvents.setEvents(12, tx3, block2)
+
+		reporter := syncmock.NewIndexReporter(t)
+		reporter.On("LowestIndexedHeight").Return(uint64(10), nil)
+		reporter.On("HighestIndexedHeight").Return(uint64(12), nil)
+		require.NoError(t, newBlockIndexer(zerolog.Nop(), reporter, reader, evmBlocks, db).IndexNewHeights())
+
+		config := DefaultConfig()
+		config.MaxLogsBlockRange = 5
+		api := &EthAPI{
+			evmChainID: types.EVMChainIDFromFlowChainID(testChainID),
+			config:     config,
+			evmBlocks:  evmBlocks,
+			events:     reader,
+		}
+
+		t.Run("GetLogs of a block range", func(t *testing.T) {
+			logs, err := api.GetLogs(context.Background(), filters.FilterCriteria{
+				FromBlock: big.NewInt(0),
+				ToBlock:   big.NewInt(2),
+			})
+			require.NoError(t, err)
+			// log indexes are counted per block
+			assert.Equal(t, []logKey{{tx0Hash, 0}, {tx1Hash, 1}, {tx1Hash, 2}, {tx3Hash, 0}}, logKeys(logs))
+
+			assert.Equal(t, uint64(0), logs[2].BlockNumber)
+			assert.Equal(t, block0Hash, logs[2].BlockHash)
+			assert.Equal(t, uint(1), logs[2].TxIndex)
+			assert.Equal(t, address1, logs[2].Address)
+		})
+
+		t.Run("GetLogs filtered by address and topics", func(t *testing.T) {
+			criteria := filters.FilterCriteria{FromBlock: big.NewInt(0), ToBlock: big.NewInt(2)}
+
+			criteria.Addresses = []gethCommon.Address{address1}
+			logs, err := api.GetLogs(context.Background(), criteria)
+			require.NoError(t, err)
+			assert.Equal(t, []logKey{{tx0Hash, 0}, {tx1Hash, 2}}, logKeys(logs))
+
+			// any topic at the first position, topic2 at the second position
+			criteria.Addresses = nil
+			criteria.Topics = [][]gethCommon.Hash{{}, {topic2}}
+			logs, err = api.GetLogs(context.Background(), criteria)
+			require.NoError(t, err)
+			assert.Equal(t, []logKey{{tx1Hash, 2}}, logKeys(logs))
+
+			// alternatives of an address and of a topic
+			criteria.Addresses = []gethCommon.Address{address2, gethCommon.HexToAddress("0x03")}
+			criteria.Topics = [][]gethCommon.Hash{{topic1, gethCommon.HexToHash("0x13")}}
+			logs, err = api.GetLogs(context.Background(), criteria)
+			require.NoError(t, err)
+			assert.Equal(t, []logKey{{tx3Hash, 0}}, logKeys(logs))
+		})
+
+		t.Run("GetLogs skips blocks whose bloom does not match", func(t *testing.T) {
+			calls := len(testEvents.events.Calls)
+
+			logs, err := api.GetLogs(context.Background(), filters.FilterCriteria{
+				FromBlock: big.NewInt(0),
+				ToBlock:   big.NewInt(2),
+				Addresses: []gethCommon.Address{gethCommon.HexToAddress("0x03")},
+			})
+			require.NoError(t, err)
+			assert.Empty(t, logs)
+
+			// the events of the blocks are not read
+			assert.Len(t, testEvents.events.Calls, calls)
+		})
+
+		t.Run("GetLogs of a block hash", func(t *testing.T) {
+			logs, err := api.GetLogs(context.Background(), filters.FilterCriteria{BlockHash: &block0Hash})
+			require.NoError(t, err)
+			assert.Equal(t, []logKey{{tx0Hash, 0}, {tx1Hash, 1}, {tx1Hash, 2}}, logKeys(logs))
+
+			unknown := gethCommon.HexToHash("0x01")
+			_, err = api.GetLogs(context.Background(), filters.FilterCriteria{BlockHash: &unknown})
+			require.ErrorIs(t, err, errBlockNotFound)
+		})
+
+		t.Run("GetLogs defaults to the latest block", func(t *testing.T) {
+			logs, err := api.GetLogs(context.Background(), filters.FilterCriteria{})
+			require.NoError(t, err)
+			assert.Equal(t, []logKey{{tx3Hash, 0}}, logKeys(logs))
+
+			// the end of the range is capped at the latest block
+			logs, err = api.GetLogs(context.Background(), filters.FilterCriteria{
+				FromBlock: big.NewInt(2),
+				ToBlock:   big.NewInt(6),
+			})
+			require.NoError(t, err)
+			assert.Equal(t, []logKey{{tx3Hash, 0}}, logKeys(logs))
+		})
+
+		t.Run("GetLogs with an invalid range", func(t *testing.T) {
+			_, err := api.GetLogs(context.Background(), filters.FilterCriteria{
+				FromBlock: big.NewInt(2),
+				ToBlock:   big.NewInt(1),
+			})
+			require.ErrorContains(t, err, "invalid block range")
+
+			_, err = api.GetLogs(context.Background(), filters.FilterCriteria{
+				FromBlock: big.NewInt(0),
+				ToBlock:   big.NewInt(5),
+			})
+			require.ErrorContains(t, err, "exceeds the maximum")
+		})
+
+		t.Run("GetTransactionReceipt", func(t *testing.T) {
+			receipt, err := api.GetTransactionReceipt(context.Background(), tx1Hash)
+			require.NoError(t, err)
+			require.NotNil(t, receipt)
+			assert.Equal(t, tx1Hash, receipt.TransactionHash)
+			assert.Equal(t, hexutil.Uint64(1), receipt.TransactionIndex)
+			assert.Equal(t, block0Hash, receipt.BlockHash)
+			assert.Equal(t, sender, receipt.From)
+			assert.Equal(t, hexutil.Uint64(30_000), receipt.GasUsed)
+			// the gas of the previous transactions of the block is included
+			assert.Equal(t, hexutil.Uint64(51_000), receipt.CumulativeGasUsed)
+			assert.Equal(t, hexutil.Uint64(gethTypes.ReceiptStatusSuccessful), receipt.Status)
+			require.Len(t, receipt.Logs, 2)
+			assert.Equal(t, uint(1), receipt.Logs[0].Index)
+			assert.Equal(t, uint(2), receipt.Logs[1].Index)
+
+			// the first transaction of a block
+			receipt, err = api.GetTransactionReceipt(context.Background(), tx3Hash)
+			require.NoError(t, err)
+			require.NotNil(t, receipt)
+			assert.Equal(t, hexutil.Uint64(40_000), receipt.CumulativeGasUsed)
+			require.Len(t, receipt.Logs, 1)
+			assert.Equal(t, uint(0), receipt.Logs[0].Index)
+
+			// transactions which are not indexed have no receipt
+			receipt, err = api.GetTransactionReceipt(context.Background(), gethCommon.HexToHash("0x01"))
+			require.NoError(t, err)
+			assert.Nil(t, receipt)
+		})
+
+		t.Run("resolveNumber", func(t *testing.T) {
+			for _, number := range []rpc.BlockNumber{
+				rpc.LatestBlockNumber,
+				rpc.PendingBlockNumber,
+				rpc.SafeBlockNumber,
+				rpc.FinalizedBlockNumber,
+			} {
+				height, err := api.resolveNumber(number)
+				require.NoError(t, err)
+				assert.Equal(t, uint64(2), height, "block number %v", number)
+			}
+
+			height, err := api.resolveNumber(rpc.EarliestBlockNumber)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(0), height)
+
+			height, err = api.resolveNumber(rpc.BlockNumber(1))
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), height)
+
+			_, err = api.resolveNumber(rpc.BlockNumber(-10))
+			require.Error(t, err)
+
+			height, err = api.resolveBigNumber(nil)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(2), height)
+
+			_, err = api.resolveBigNumber(new(big.Int).Lsh(big.NewInt(1), 64))
+			require.Error(t, err)
+		})
+	})
+}
+
+func TestEthAPI_ResolveNumberWithoutBlocks(t *testing.T) {
+	evmBlocks := storagemock.NewEVMBlocks(t)
+	evmBlocks.On("LatestHeight").Return(uint64(0), storage.ErrNotFound)
+
+	api := &EthAPI{evmBlocks: evmBlocks}
+	_, err := api.resolveNumber(rpc.LatestBlockNumber)
+	require.ErrorIs(t, err, errBlockNotFound)
+}
+
+func TestBloomMatches(t *testing.T) {
+	address := gethCommon.HexToAddress("0x01")
+	topic1 := gethCommon.HexToHash("0x11")
+	topic2 := gethCommon.HexToHash("0x12")
+	other := gethCommon.HexToHash("0x13")
+
+	bloom := gethTypes.CreateBloom(gethTypes.Receipts{{Logs: []*gethTypes.Log{
+		{Address: address, Topics: []gethCommon.Hash{topic1, topic2}},
+	}}}).Bytes()
+
+	// blocks without logs never match
+	assert.False(t, bloomMatches(nil, nil, nil))
+
+	assert.True(t, bloomMatches(bloom, nil, nil))
+	assert.True(t, bloomMatches(bloom, []gethCommon.Address{gethCommon.HexToAddress("0x02"), address}, nil))
+	assert.False(t, bloomMatches(bloom, []gethCommon.Address{gethCommon.HexToAddress("0x02")}, nil))
+
+	assert.True(t, bloomMatches(bloom, nil, [][]gethCommon.Hash{{}, {other, topic2}}))
+	assert.False(t, bloomMatches(bloom, nil, [][]gethCommon.Hash{{topic1}, {other}}))
+	assert.False(t, bloomMatches(bloom, []gethCommon.Address{address}, [][]gethCommon.Hash{{other}}))
+}
+
+func TestLogMatches(t *testing.T) {
+	address := gethCommon.HexToAddress("0x01")
+	topic1 := gethCommon.HexToHash("0x11")
+	topic2 := gethCommon.HexToHash("0x12")
+	log := &gethTypes.Log{Address: address, Topics: []gethCommon.Hash{topic1, topic2}}
+
+	assert.True(t, logMatches(log, nil, nil))
+	assert.True(t, logMatches(log, []gethCommon.Address{gethCommon.HexToAddress("0x02"), address}, nil))
+	assert.False(t, logMatches(log, []gethCommon.Address{gethCommon.HexToAddress("0x02")}, nil))
+
+	// topics are matched by position, an empty position matches any topic
+	assert.True(t, logMatches(log, nil, [][]gethCommon.Hash{{}, {topic2}}))
+	assert.True(t, logMatches(log, nil, [][]gethCommon.Hash{{topic2, topic1}}))
+	assert.False(t, logMatches(log, nil, [][]gethCommon.Hash{{topic2}}))
+
+	// the log has fewer topics than the filter
+	assert.False(t, logMatches(log, nil, [][]gethCommon.Hash{{}, {}, {}}))
+}
+
+// testStorageProvider provides the same EVM state at every height.
+type testStorageProvider struct {
+	snapshot types.BackendStorageSnapshot
+}
+
+func (p *testStorageProvider) GetSnapshotAt(uint64) (types.BackendStorageSnapshot, error) {
+	return p.snapshot, nil
+}
+
+// testBlockSnapshotProvider provides the same block snapshot at every height.
+type testBlockSnapshotProvider struct {
+	snapshot types.BlockSnapshot
+}
+
+func (p *testBlockSnapshotProvider) GetSnapshotAt(uint64) (types.BlockSnapshot, error) {
+	return p.snapshot, nil
+}
+
+func TestEthAPI_Call(t *testing.T) {
+	testutils.RunWithTestBackend(t, func(backend *testutils.TestBackend) {
+		testutils.RunWithTestFlowEVMRootAddress(t, backend, func(rootAddr flow.Address) {
+			testContract := testutils.GetStorageTestContract(t)
+			testutils.RunWithDeployedContract(t, testContract, backend, rootAddr, func(testContract *testutils.TestContract) {
+				blks, err := blocks.NewBlocks(testChainID, rootAddr, backend)
+				require.NoError(t, err)
+
+				evmBlocks := storagemock.NewEVMBlocks(t)
+				evmBlocks.On("LatestHeight").Return(uint64(1), nil).Maybe()
+
+				config := DefaultConfig()
+				api := &EthAPI{
+					evmChainID: types.EVMChainIDFromFlowChainID(testChainID),
+					config:     config,
+					evmBlocks:  evmBlocks,
+					views: query.NewViewProvider(
+						testChainID,
+						rootAddr,
+						&testStorageProvider{snapshot: backend},
+						&testBlockSnapshotProvider{snapshot: blks},
+						config.MaxCallGasLimit,
+					),
+				}
+
+				to := testContract.DeployedAt.ToCommon()
+				callArgs := func(method string, args ...interface{}) CallArgs {
+					data := hexutil.Bytes(testContract.MakeCallData(t, method, args...))
+					return CallArgs{To: &to, Data: &data}
+				}
+
+				t.Run("successful call", func(t *testing.T) {
+					result, err := api.Call(context.Background(), callArgs("retrieve"), nil)
+					require.NoError(t, err)
+					assert.Len(t, result, 32)
+
+					gas, err := api.EstimateGas(context.Background(), callArgs("store", big.NewInt(2)), nil)
+					require.NoError(t, err)
+					assert.Greater(t, uint64(gas), uint64(21_000))
+
+					// the estimated gas is enough, and less is not
+					limit := gas
+					args := callArgs("store", big.NewInt(2))
+					args.Gas = &limit
+					_, err = api.Call(context.Background(), args, nil)
+					require.NoError(t, err)
+					limit = gas - 1
+					_, err = api.Call(context.Background(), args, nil)
+					require.Error(t, err)
+				})
+
+				t.Run("reverted call", func(t *testing.T) {
+					requireRevertError := func(t *testing.T, err error) {
+						var revertErr *revertError
+						require.True(t, errors.As(err, &revertErr), "unexpected error: %v", err)
+						assert.Equal(t, 3, revertErr.ErrorCode())
+						assert.Contains(t, revertErr.Error(), "Assert Error Message")
+
+						// the data is the ABI encoded Error(string) revert reason
+						data, err := hexutil.Decode(revertErr.ErrorData().(string))
+						require.NoError(t, err)
+						assert.Equal(t, gethCrypto.Keccak256([]byte("Error(string)"))[:4], data[:4])
+					}
+
+					_, err := api.Call(context.Background(), callArgs("assertError"), nil)
+					requireRevertError(t, err)
+
+					_, err = api.EstimateGas(context.Background(), callArgs("assertError"), nil)
+					requireRevertError(t, err)
+				})
+
+				t.Run("invalid call", func(t *testing.T) {
+					// the gas limit is below the intrinsic gas, so the call is not executed
+					limit := hexutil.Uint64(1)
+					args := callArgs("retrieve")
+					args.Gas = &limit
+					_, err := api.Call(context.Background(), args, nil)
+					require.Error(t, err)
+
+					var revertErr *revertError
+					assert.False(t, errors.As(err, &revertErr))
+				})
+
+				t.Run("unknown block", func(t *testing.T) {
+					blockHash := gethCommon.HexToHash("0x01")
+					evmBlocks.On("HeightByHash", blockHash).Return(uint64(0), storage.ErrNotFound).Once()
+
+					blockRef := rpc.BlockNumberOrHashWithHash(blockHash, false)
+					_, err := api.Call(context.Background(), callArgs("retrieve"), &blockRef)
+					require.ErrorIs(t, err, errBlockNotFound)
+				})
+			})
+		})
+	})
+}
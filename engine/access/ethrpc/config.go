@@ -0,0 +1,122 @@
+package ethrpc
+
+import (
+	"time"
+)
+
+const (
+	// DefaultReadTimeout is the default read timeout for the HTTP server
+	DefaultReadTimeout = time.Second * 15
+
+	// DefaultWriteTimeout is the default write timeout for the HTTP server
+	DefaultWriteTimeout = time.Second * 30
+
+	// DefaultIdleTimeout is the default idle timeout for the HTTP server
+	DefaultIdleTimeout = time.Second * 60
+
+	// DefaultMaxCallGasLimit is the default maximum gas limit of eth_call and eth_estimateGas requests.
+	DefaultMaxCallGasLimit = 50_000_000
+
+	// DefaultMaxLogsBlockRange is the default maximum number of EVM blocks an eth_getLogs request may span.
+	DefaultMaxLogsBlockRange = 1_000
+
+	// DefaultGasPrice is the default gas price returned by eth_gasPrice, in attoFLOW.
+	DefaultGasPrice = 1
+
+	// DefaultComputeLimit is the default compute limit of the Cadence transactions submitted by eth_sendRawTransaction.
+	DefaultComputeLimit = 9_999
+
+	// DefaultKeyCount is the default number of account keys signing the Cadence transactions submitted by
+	// eth_sendRawTransaction.
+	DefaultKeyCount = 1
+
+	// DefaultSendRateLimit is the default number of eth_sendRawTransaction requests allowed per second per client.
+	DefaultSendRateLimit = 10
+
+	// DefaultSendRateBurst is the default number of eth_sendRawTransaction requests a client may send at once.
+	DefaultSendRateBurst = 20
+
+	// DefaultIndexingInterval is the default interval at which new Flow heights are checked for EVM events.
+	DefaultIndexingInterval = time.Millisecond * 500
+
+	// DefaultTraceTimeout is the default maximum duration of a transaction trace.
+	DefaultTraceTimeout = time.Second * 5
+
+	// DefaultMaxTraceSize is the default maximum size of a transaction trace, in bytes.
+	DefaultMaxTraceSize = 50 * 1024 * 1024 // 50 MB
+
+	// DefaultMaxStructLogs is the default maximum number of opcodes recorded by a struct logs trace.
+	DefaultMaxStructLogs = 100_000
+)
+
+// Config defines the configurable options of the Ethereum JSON-RPC server.
+type Config struct {
+	ListenAddress     string
+	WriteTimeout      time.Duration
+	ReadTimeout       time.Duration
+	IdleTimeout       time.Duration
+	MaxCallGasLimit   uint64        // maximum gas limit of eth_call and eth_estimateGas requests
+	MaxLogsBlockRange uint64        // maximum number of EVM blocks an eth_getLogs request may span
+	GasPrice          uint64        // gas price returned by eth_gasPrice, and minimum gas price of submitted transactions, in attoFLOW
+	IndexingInterval  time.Duration // interval at which new Flow heights are checked for EVM events
+
+	// Tracing configures the limits of debug_traceTransaction requests.
+	Tracing TracingConfig
+
+	// Sender configures the submission of EVM transactions received by eth_sendRawTransaction.
+	Sender SenderConfig
+}
+
+// SenderConfig defines the Flow account used to submit EVM transactions with eth_sendRawTransaction.
+// Submitting transactions is disabled if no Flow address is configured.
+// The Cadence transactions are signed by KeyCount account keys with consecutive indices starting at
+// KeyIndex, which must all have the private key stored in KeyFile. Each key has its own sequence number,
+// so using more keys allows to submit more transactions concurrently.
+type SenderConfig struct {
+	FlowAddress  string  // hex encoded address of the Flow account paying for the Cadence transactions
+	KeyIndex     uint32  // index of the first account key used to sign the Cadence transactions
+	KeyCount     uint32  // number of account keys used to sign the Cadence transactions
+	KeyFile      string  // path to the file containing the hex encoded private key of the account keys
+	Coinbase     string  // hex encoded EVM address receiving the EVM transaction fees
+	ComputeLimit uint64  // compute limit of the Cadence transactions
+	RateLimit    float64 // requests allowed per second per client IP, 0 disables the rate limit
+	RateBurst    int     // requests a client IP may send at once
+}
+
+// TracingConfig defines the limits of transaction traces.
+type TracingConfig struct {
+	Timeout       time.Duration // maximum duration of a trace, requests may use a shorter timeout
+	MaxTraceSize  uint64        // maximum size of a trace, in bytes
+	MaxStructLogs uint64        // maximum number of opcodes recorded by a struct logs trace
+}
+
+// Enabled returns true if EVM transactions may be submitted.
+func (c SenderConfig) Enabled() bool {
+	return c.FlowAddress != ""
+}
+
+// DefaultConfig returns the default configuration of the Ethereum JSON-RPC server.
+// The server is disabled by default.
+func DefaultConfig() Config {
+	return Config{
+		ListenAddress:     "",
+		WriteTimeout:      DefaultWriteTimeout,
+		ReadTimeout:       DefaultReadTimeout,
+		IdleTimeout:       DefaultIdleTimeout,
+		MaxCallGasLimit:   DefaultMaxCallGasLimit,
+		MaxLogsBlockRange: DefaultMaxLogsBlockRange,
+		GasPrice:          DefaultGasPrice,
+		IndexingInterval:  DefaultIndexingInterval,
+		Tracing: TracingConfig{
+			Timeout:       DefaultTraceTimeout,
+			MaxTraceSize:  DefaultMaxTraceSize,
+			MaxStructLogs: DefaultMaxStructLogs,
+		},
+		Sender: SenderConfig{
+			KeyCount:     DefaultKeyCount,
+			ComputeLimit: DefaultComputeLimit,
+			RateLimit:    DefaultSendRateLimit,
+			RateBurst:    DefaultSendRateBurst,
+		},
+	}
+}
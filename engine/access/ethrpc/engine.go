@@ -0,0 +1,203 @@
+package ethrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/onflow/go-ethereum/rpc"
+	"github.com/rs/cors"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/fvm/evm"
+	"github.com/onflow/flow-go/fvm/evm/offchain/query"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+// Engine serves the Ethereum JSON-RPC API for Flow EVM, and builds the EVM block index backing it
+// from the events and registers indexed by the execution state indexer.
+type Engine struct {
+	component.Component
+
+	log       zerolog.Logger
+	config    Config
+	indexer   *blockIndexer
+	tracer    *Tracer
+	rpcServer *rpc.Server
+	server    *http.Server
+}
+
+// NewEngine returns a new Ethereum JSON-RPC engine.
+// Submitting EVM transactions with eth_sendRawTransaction uses the given Access API, and is only enabled
+// if a Flow account is configured.
+//
+// No errors are expected during normal operation.
+func NewEngine(
+	log zerolog.Logger,
+	config Config,
+	chainID flow.ChainID,
+	reporter state_synchronization.IndexReporter,
+	headers storage.Headers,
+	events storage.Events,
+	registers RegisterReader,
+	evmBlocks storage.EVMBlocks,
+	batcher bstorage.BatchBuilder,
+	api access.API,
+) (*Engine, error) {
+	log = log.With().Str("engine", "evm_json_rpc").Logger()
+
+	reader := newEventsReader(chainID, headers, events, evmBlocks)
+
+	var sender *Sender
+	if config.Sender.Enabled() {
+		var err error
+		sender, err = NewSender(api, chainID, config)
+		if err != nil {
+			return nil, fmt.Errorf("could not create EVM transaction sender: %w", err)
+		}
+	}
+
+	states := &storageProvider{
+		registers: registers,
+		evmBlocks: evmBlocks,
+	}
+	blocks := &blockSnapshotProvider{
+		chainID:   chainID,
+		events:    reader,
+		evmBlocks: evmBlocks,
+	}
+	tracer := newTracer(log, chainID, config.Tracing, reader, evmBlocks, states, blocks)
+
+	evmChainID := types.EVMChainIDFromFlowChainID(chainID)
+	eth := &EthAPI{
+		evmChainID: evmChainID,
+		config:     config,
+		evmBlocks:  evmBlocks,
+		events:     reader,
+		views: query.NewViewProvider(
+			chainID,
+			evm.StorageAccountAddress(chainID),
+			states,
+			blocks,
+			config.MaxCallGasLimit,
+		),
+		sender: sender,
+	}
+
+	rpcServer, err := NewRPCServer(eth, &NetAPI{evmChainID: evmChainID}, &Web3API{}, &DebugAPI{tracer: tracer})
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Engine{
+		log:       log,
+		config:    config,
+		indexer:   newBlockIndexer(log, reporter, reader, evmBlocks, batcher),
+		tracer:    tracer,
+		rpcServer: rpcServer,
+		server:    NewServer(rpcServer, config),
+	}
+
+	e.Component = component.NewComponentManagerBuilder().
+		AddWorker(e.indexer.worker(config.IndexingInterval)).
+		AddWorker(e.serve).
+		AddWorker(e.shutdownWorker).
+		Build()
+
+	return e, nil
+}
+
+// NewRPCServer returns a JSON-RPC server serving the given "eth", "net", "web3" and "debug" APIs.
+//
+// No errors are expected during normal operation.
+func NewRPCServer(eth *EthAPI, net *NetAPI, web3 *Web3API, debug *DebugAPI) (*rpc.Server, error) {
+	server := rpc.NewServer()
+	apis := map[string]interface{}{
+		"eth":   eth,
+		"net":   net,
+		"web3":  web3,
+		"debug": debug,
+	}
+	for namespace, api := range apis {
+		err := server.RegisterName(namespace, api)
+		if err != nil {
+			return nil, fmt.Errorf("could not register %s API: %w", namespace, err)
+		}
+	}
+	return server, nil
+}
+
+// Tracer returns the tracer of indexed EVM transactions.
+func (e *Engine) Tracer() *Tracer {
+	return e.tracer
+}
+
+// NewServer returns an HTTP server initialized with the given JSON-RPC server.
+func NewServer(rpcServer *rpc.Server, config Config) *http.Server {
+	c := cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedHeaders: []string{"*"},
+		AllowedMethods: []string{
+			http.MethodPost,
+			http.MethodOptions,
+		},
+	})
+
+	return &http.Server{
+		Handler:      c.Handler(rpcServer),
+		Addr:         config.ListenAddress,
+		WriteTimeout: config.WriteTimeout,
+		ReadTimeout:  config.ReadTimeout,
+		IdleTimeout:  config.IdleTimeout,
+	}
+}
+
+// serve is a worker routine which starts the HTTP JSON-RPC server.
+// The ready callback is called after the server address is bound.
+// Note: The irrecoverable.SignalerContext is used as base context for error handling.
+func (e *Engine) serve(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	e.log.Info().Str("evm_json_rpc_address", e.config.ListenAddress).Msg("starting EVM JSON-RPC server on address")
+
+	e.server.BaseContext = func(_ net.Listener) context.Context {
+		return irrecoverable.WithSignalerContext(ctx, ctx)
+	}
+
+	l, err := net.Listen("tcp", e.config.ListenAddress)
+	if err != nil {
+		e.log.Err(err).Msg("failed to start the EVM JSON-RPC server")
+		ctx.Throw(err)
+		return
+	}
+	ready()
+
+	err = e.server.Serve(l) // blocking call
+	if err != nil {
+		if errors.Is(err, http.ErrServerClosed) {
+			return
+		}
+		e.log.Err(err).Msg("fatal error in EVM JSON-RPC server")
+		ctx.Throw(err)
+	}
+}
+
+// shutdownWorker is a worker routine which shuts down the server when the context is cancelled.
+func (e *Engine) shutdownWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+	<-ctx.Done()
+
+	// use unbounded context, rely on shutdown logic to have timeout
+	err := e.server.Shutdown(context.Background())
+	if err != nil {
+		e.log.Error().Err(err).Msg("error stopping EVM JSON-RPC server")
+	}
+	e.rpcServer.Stop()
+}
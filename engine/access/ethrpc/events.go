@@ -0,0 +1,169 @@
+package ethrpc
+
+import (
+	"fmt"
+	"sort"
+
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/rlp"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/stdlib"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// eventsReader reads the EVM events emitted by Flow blocks from the events indexed by the
+// execution state indexer.
+type eventsReader struct {
+	headers                 storage.Headers
+	events                  storage.Events
+	evmBlocks               storage.EVMBlocks
+	blockExecutedType       flow.EventType
+	transactionExecutedType flow.EventType
+}
+
+func newEventsReader(
+	chainID flow.ChainID,
+	headers storage.Headers,
+	events storage.Events,
+	evmBlocks storage.EVMBlocks,
+) *eventsReader {
+	cadenceTypes := stdlib.CadenceTypesForChain(chainID)
+	return &eventsReader{
+		headers:                 headers,
+		events:                  events,
+		evmBlocks:               evmBlocks,
+		blockExecutedType:       flow.EventType(cadenceTypes.BlockExecuted.ID()),
+		transactionExecutedType: flow.EventType(cadenceTypes.TransactionExecuted.ID()),
+	}
+}
+
+// evmEvents is the set of EVM events emitted by a Flow block, in execution order.
+type evmEvents struct {
+	blocks       []*events.BlockEventPayload
+	transactions []*events.TransactionEventPayload
+}
+
+// ByHeight returns the decoded EVM events emitted by the Flow block at the given height.
+//
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if the Flow block or its events are not indexed.
+func (r *eventsReader) ByHeight(height uint64) (*evmEvents, error) {
+	blockID, err := r.headers.BlockIDByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get block ID at height %d: %w", height, err)
+	}
+
+	flowEvents, err := r.events.ByBlockID(blockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get events of block %v: %w", blockID, err)
+	}
+
+	// events are keyed/sorted by [blockID, txID, txIndex, eventIndex]
+	// we need to resort them by tx index then event index so the output is in execution order
+	sort.Slice(flowEvents, func(i, j int) bool {
+		if flowEvents[i].TransactionIndex == flowEvents[j].TransactionIndex {
+			return flowEvents[i].EventIndex < flowEvents[j].EventIndex
+		}
+		return flowEvents[i].TransactionIndex < flowEvents[j].TransactionIndex
+	})
+
+	result := &evmEvents{}
+	for _, event := range flowEvents {
+		switch event.Type {
+		case r.blockExecutedType:
+			cadenceEvent, err := events.FlowEventToCadenceEvent(event)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode EVM block event at height %d: %w", height, err)
+			}
+			payload, err := events.DecodeBlockEventPayload(cadenceEvent)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode EVM block event payload at height %d: %w", height, err)
+			}
+			result.blocks = append(result.blocks, payload)
+
+		case r.transactionExecutedType:
+			cadenceEvent, err := events.FlowEventToCadenceEvent(event)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode EVM transaction event at height %d: %w", height, err)
+			}
+			payload, err := events.DecodeTransactionEventPayload(cadenceEvent)
+			if err != nil {
+				return nil, fmt.Errorf("could not decode EVM transaction event payload at height %d: %w", height, err)
+			}
+			result.transactions = append(result.transactions, payload)
+		}
+	}
+
+	return result, nil
+}
+
+// Block returns the EVM block event and the EVM transaction events of the given indexed EVM block.
+// The transaction events are ordered by transaction index.
+//
+// No errors are expected during normal operation.
+func (r *eventsReader) Block(entry *flow.EVMBlock) (*events.BlockEventPayload, []*events.TransactionEventPayload, error) {
+	var block *events.BlockEventPayload
+	transactions := make([]*events.TransactionEventPayload, 0, len(entry.TransactionHashes))
+
+	for height := entry.FirstFlowHeight; height <= entry.FlowHeight; height++ {
+		evmEvents, err := r.ByHeight(height)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, tx := range evmEvents.transactions {
+			if tx.BlockHeight == entry.Height {
+				transactions = append(transactions, tx)
+			}
+		}
+		for _, b := range evmEvents.blocks {
+			if b.Height == entry.Height {
+				block = b
+			}
+		}
+	}
+
+	if block == nil {
+		return nil, nil, fmt.Errorf("EVM block event of block %d not found at Flow height %d", entry.Height, entry.FlowHeight)
+	}
+	if len(transactions) != len(entry.TransactionHashes) {
+		return nil, nil, fmt.Errorf("found %d EVM transaction events of block %d, expected %d",
+			len(transactions), entry.Height, len(entry.TransactionHashes))
+	}
+
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].Index < transactions[j].Index
+	})
+
+	return block, transactions, nil
+}
+
+// BlockByHeight returns the EVM block event and the EVM transaction events of the EVM block at the given height.
+//
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if the EVM block is not indexed.
+func (r *eventsReader) BlockByHeight(height uint64) (*flow.EVMBlock, *events.BlockEventPayload, []*events.TransactionEventPayload, error) {
+	entry, err := r.evmBlocks.ByHeight(height)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	block, transactions, err := r.Block(entry)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return entry, block, transactions, nil
+}
+
+// decodeLogs decodes the RLP encoded logs of an EVM transaction event.
+func decodeLogs(tx *events.TransactionEventPayload) ([]*gethTypes.Log, error) {
+	if len(tx.Logs) == 0 {
+		return nil, nil
+	}
+	var logs []*gethTypes.Log
+	err := rlp.DecodeBytes(tx.Logs, &logs)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode logs of EVM transaction %s: %w", tx.Hash, err)
+	}
+	return logs, nil
+}
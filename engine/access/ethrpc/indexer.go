@@ -0,0 +1,253 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/state_synchronization"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+)
+
+// pendingTransaction is an EVM transaction whose EVM block has not been executed yet.
+type pendingTransaction struct {
+	hash        gethCommon.Hash
+	index       uint16
+	blockHeight uint64
+	flowHeight  uint64
+	logs        []*gethTypes.Log
+}
+
+// blockIndexer builds the EVM block index from the EVM events indexed by the execution state indexer.
+//
+// EVM transaction events are usually emitted by the same Flow block as the EVM block event of their
+// EVM block. Transactions whose EVM block is not executed yet are kept in memory, and the latest
+// indexed Flow height is only advanced once all of them are part of an indexed EVM block. This way,
+// indexing is resumed from a consistent height after a restart.
+type blockIndexer struct {
+	log       zerolog.Logger
+	reporter  state_synchronization.IndexReporter
+	events    *eventsReader
+	evmBlocks storage.EVMBlocks
+	batcher   bstorage.BatchBuilder
+
+	// nextHeight is the next Flow height whose EVM events are processed, it may be above the latest
+	// indexed Flow height + 1 while some transactions are pending.
+	nextHeight  uint64
+	pending     []pendingTransaction
+	blocks      []flow.EVMBlock
+	initialized bool
+}
+
+func newBlockIndexer(
+	log zerolog.Logger,
+	reporter state_synchronization.IndexReporter,
+	events *eventsReader,
+	evmBlocks storage.EVMBlocks,
+	batcher bstorage.BatchBuilder,
+) *blockIndexer {
+	return &blockIndexer{
+		log:       log.With().Str("component", "evm_block_indexer").Logger(),
+		reporter:  reporter,
+		events:    events,
+		evmBlocks: evmBlocks,
+		batcher:   batcher,
+	}
+}
+
+// worker periodically indexes the EVM blocks emitted by newly indexed Flow blocks.
+func (i *blockIndexer) worker(interval time.Duration) component.ComponentWorker {
+	return func(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+		ready()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := i.IndexNewHeights()
+				if err != nil {
+					ctx.Throw(fmt.Errorf("could not index EVM blocks: %w", err))
+					return
+				}
+			}
+		}
+	}
+}
+
+// IndexNewHeights indexes the EVM events of all Flow heights indexed by the execution state indexer
+// since the last call. It returns without error if the execution state indexer is not initialized yet.
+// This method shouldn't be used concurrently.
+//
+// No errors are expected during normal operation.
+func (i *blockIndexer) IndexNewHeights() error {
+	highest, err := i.reporter.HighestIndexedHeight()
+	if err != nil {
+		if errors.Is(err, indexer.ErrIndexNotInitialized) {
+			return nil
+		}
+		return fmt.Errorf("could not get highest indexed height: %w", err)
+	}
+
+	if !i.initialized {
+		err = i.initialize()
+		if err != nil {
+			if errors.Is(err, indexer.ErrIndexNotInitialized) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	for ; i.nextHeight <= highest; i.nextHeight++ {
+		err := i.indexHeight(i.nextHeight)
+		if err != nil {
+			return fmt.Errorf("could not index EVM events at height %d: %w", i.nextHeight, err)
+		}
+	}
+
+	return nil
+}
+
+// initialize resumes indexing after the latest indexed Flow height, or starts indexing at the lowest
+// height indexed by the execution state indexer.
+func (i *blockIndexer) initialize() error {
+	latest, err := i.evmBlocks.LatestFlowHeight()
+	if err == nil {
+		i.nextHeight = latest + 1
+		i.initialized = true
+		return nil
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not get latest indexed Flow height: %w", err)
+	}
+
+	lowest, err := i.reporter.LowestIndexedHeight()
+	if err != nil {
+		return fmt.Errorf("could not get lowest indexed height: %w", err)
+	}
+
+	i.log.Info().Uint64("start_height", lowest).Msg("starting EVM block index")
+
+	i.nextHeight = lowest
+	i.initialized = true
+	return nil
+}
+
+// indexHeight processes the EVM events emitted by the Flow block at the given height, and stores the EVM
+// blocks executed so far if no transactions are pending.
+func (i *blockIndexer) indexHeight(height uint64) error {
+	evmEvents, err := i.events.ByHeight(height)
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range evmEvents.transactions {
+		logs, err := decodeLogs(tx)
+		if err != nil {
+			return err
+		}
+		i.pending = append(i.pending, pendingTransaction{
+			hash:        tx.Hash,
+			index:       tx.Index,
+			blockHeight: tx.BlockHeight,
+			flowHeight:  height,
+			logs:        logs,
+		})
+	}
+
+	for _, block := range evmEvents.blocks {
+		i.blocks = append(i.blocks, i.executedBlock(height, block.Height, block.Hash))
+	}
+
+	if len(i.pending) > 0 {
+		return nil
+	}
+
+	batch := bstorage.NewBatch(i.batcher)
+	err = i.evmBlocks.BatchStore(height, i.blocks, batch)
+	if err != nil {
+		return err
+	}
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("batch flush error: %w", err)
+	}
+
+	if len(i.blocks) > 0 {
+		i.log.Debug().
+			Uint64("flow_height", height).
+			Uint64("evm_height", i.blocks[len(i.blocks)-1].Height).
+			Msg("indexed EVM blocks")
+	}
+	i.blocks = nil
+
+	return nil
+}
+
+// executedBlock builds the index entry of the EVM block executed at the given Flow height from its
+// pending transactions, and removes them from the pending transactions.
+func (i *blockIndexer) executedBlock(flowHeight uint64, evmHeight uint64, hash gethCommon.Hash) flow.EVMBlock {
+	block := flow.EVMBlock{
+		Height:          evmHeight,
+		Hash:            hash,
+		FirstFlowHeight: flowHeight,
+		FlowHeight:      flowHeight,
+	}
+
+	remaining := i.pending[:0]
+	var transactions []pendingTransaction
+	for _, tx := range i.pending {
+		switch {
+		case tx.blockHeight == evmHeight:
+			transactions = append(transactions, tx)
+		case tx.blockHeight < evmHeight:
+			// this only happens if the transaction events of a block are not followed by its block event
+			i.log.Warn().
+				Str("tx_hash", tx.hash.Hex()).
+				Uint64("evm_height", tx.blockHeight).
+				Msg("dropping EVM transaction of a block which was not executed")
+		default:
+			remaining = append(remaining, tx)
+		}
+	}
+	i.pending = remaining
+
+	// transactions are emitted in execution order, except if they span several Flow blocks
+	sort.SliceStable(transactions, func(j, k int) bool {
+		return transactions[j].index < transactions[k].index
+	})
+
+	var bloom gethTypes.Bloom
+	hasLogs := false
+	for _, tx := range transactions {
+		block.TransactionHashes = append(block.TransactionHashes, tx.hash)
+		block.FirstFlowHeight = min(block.FirstFlowHeight, tx.flowHeight)
+		for _, log := range tx.logs {
+			hasLogs = true
+			bloom.Add(log.Address.Bytes())
+			for _, topic := range log.Topics {
+				bloom.Add(topic.Bytes())
+			}
+		}
+	}
+	// the bloom of blocks without logs is empty, it is not stored to save space
+	if hasLogs {
+		block.LogsBloom = bloom.Bytes()
+	}
+
+	return block
+}
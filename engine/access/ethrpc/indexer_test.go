@@ -0,0 +1,196 @@
+package ethrpc
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/onflow/cadence/encoding/ccf"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const testChainID = flow.Emulator
+
+// testEVMEvents mocks the headers and events storage with the EVM events emitted by Flow blocks.
+type testEVMEvents struct {
+	t       *testing.T
+	headers *storagemock.Headers
+	events  *storagemock.Events
+}
+
+func newTestEVMEvents(t *testing.T) *testEVMEvents {
+	return &testEVMEvents{
+		t:       t,
+		headers: storagemock.NewHeaders(t),
+		events:  storagemock.NewEvents(t),
+	}
+}
+
+// setEvents sets the events emitted by the Flow block at the given height.
+func (e *testEVMEvents) setEvents(height uint64, evmEvents ...*events.Event) {
+	blockID := unittest.IdentifierFixture()
+	e.headers.On("BlockIDByHeight", height).Return(blockID, nil).Maybe()
+
+	flowEvents := make([]flow.Event, len(evmEvents))
+	for i, evmEvent := range evmEvents {
+		cadenceEvent, err := evmEvent.Payload.ToCadence(testChainID)
+		require.NoError(e.t, err)
+		payload, err := ccf.Encode(cadenceEvent)
+		require.NoError(e.t, err)
+
+		flowEvents[i] = flow.Event{
+			Type:             flow.EventType(cadenceEvent.EventType.ID()),
+			TransactionIndex: uint32(i),
+			EventIndex:       0,
+			Payload:          payload,
+		}
+	}
+	e.events.On("ByBlockID", blockID).Return(flowEvents, nil).Maybe()
+}
+
+func testBlockEvent(t *testing.T, height uint64) (*events.Event, gethCommon.Hash) {
+	block := types.NewBlock(gethCommon.Hash{}, height, 1_000+height, big.NewInt(0), gethCommon.Hash{})
+	hash, err := block.Hash()
+	require.NoError(t, err)
+	return events.NewBlockEvent(block), hash
+}
+
+func testTransactionEvent(blockHeight uint64, index uint16, logs ...*gethTypes.Log) (*events.Event, gethCommon.Hash) {
+	hash := gethCommon.BytesToHash(unittest.RandomBytes(32))
+	result := &types.Result{
+		TxType:      gethTypes.LegacyTxType,
+		GasConsumed: 21_000,
+		TxHash:      hash,
+		Index:       index,
+		Logs:        logs,
+	}
+	return events.NewTransactionEvent(result, unittest.RandomBytes(100), blockHeight), hash
+}
+
+func TestBlockIndexer(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		evmBlocks := bstorage.NewEVMBlocks(db)
+		testEvents := newTestEVMEvents(t)
+
+		reporter := syncmock.NewIndexReporter(t)
+		reporter.On("LowestIndexedHeight").Return(uint64(10), nil)
+
+		reader := newEventsReader(testChainID, testEvents.headers, testEvents.events, evmBlocks)
+		blockIndexer := newBlockIndexer(zerolog.Nop(), reporter, reader, evmBlocks, db)
+
+		log := &gethTypes.Log{
+			Address: gethCommon.HexToAddress("0x01"),
+			Topics:  []gethCommon.Hash{gethCommon.HexToHash("0x02")},
+		}
+
+		// Flow block 10 executes EVM block 0, with a transaction emitting a log
+		tx0, tx0Hash := testTransactionEvent(0, 0, log)
+		block0, block0Hash := testBlockEvent(t, 0)
+		testEvents.setEvents(10, tx0, block0)
+
+		// Flow block 11 emits no EVM events
+		testEvents.setEvents(11)
+
+		// Flow block 12 emits the transactions of EVM block 1, which is executed by Flow block 13
+		tx1, tx1Hash := testTransactionEvent(1, 1)
+		tx2, tx2Hash := testTransactionEvent(1, 0)
+		testEvents.setEvents(12, tx1, tx2)
+		block1, block1Hash := testBlockEvent(t, 1)
+		testEvents.setEvents(13, block1)
+
+		t.Run("not initialized", func(t *testing.T) {
+			reporter.On("HighestIndexedHeight").Return(uint64(0), indexer.ErrIndexNotInitialized).Once()
+			require.NoError(t, blockIndexer.IndexNewHeights())
+
+			_, err := evmBlocks.LatestFlowHeight()
+			require.ErrorIs(t, err, storage.ErrNotFound)
+		})
+
+		t.Run("index blocks with logs", func(t *testing.T) {
+			reporter.On("HighestIndexedHeight").Return(uint64(11), nil).Once()
+			require.NoError(t, blockIndexer.IndexNewHeights())
+
+			latest, err := evmBlocks.LatestFlowHeight()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(11), latest)
+
+			block, err := evmBlocks.ByHeight(0)
+			require.NoError(t, err)
+			assert.Equal(t, block0Hash, block.Hash)
+			assert.Equal(t, []gethCommon.Hash{tx0Hash}, block.TransactionHashes)
+			assert.Equal(t, uint64(10), block.FirstFlowHeight)
+			assert.Equal(t, uint64(10), block.FlowHeight)
+
+			bloom := gethTypes.BytesToBloom(block.LogsBloom)
+			assert.True(t, bloom.Test(log.Address.Bytes()))
+			assert.True(t, bloom.Test(log.Topics[0].Bytes()))
+
+			height, err := evmBlocks.HeightByTransactionHash(tx0Hash)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(0), height)
+		})
+
+		t.Run("pending transactions are not committed", func(t *testing.T) {
+			reporter.On("HighestIndexedHeight").Return(uint64(12), nil).Once()
+			require.NoError(t, blockIndexer.IndexNewHeights())
+
+			latest, err := evmBlocks.LatestFlowHeight()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(11), latest)
+
+			_, err = evmBlocks.ByHeight(1)
+			require.ErrorIs(t, err, storage.ErrNotFound)
+		})
+
+		t.Run("index block spanning several Flow blocks", func(t *testing.T) {
+			reporter.On("HighestIndexedHeight").Return(uint64(13), nil).Once()
+			require.NoError(t, blockIndexer.IndexNewHeights())
+
+			latest, err := evmBlocks.LatestFlowHeight()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(13), latest)
+
+			latestEVM, err := evmBlocks.LatestHeight()
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), latestEVM)
+
+			block, err := evmBlocks.ByHeight(1)
+			require.NoError(t, err)
+			assert.Equal(t, block1Hash, block.Hash)
+			// transactions are ordered by index
+			assert.Equal(t, []gethCommon.Hash{tx2Hash, tx1Hash}, block.TransactionHashes)
+			assert.Equal(t, uint64(12), block.FirstFlowHeight)
+			assert.Equal(t, uint64(13), block.FlowHeight)
+			assert.Empty(t, block.LogsBloom)
+
+			_, blockEvent, transactions, err := reader.BlockByHeight(1)
+			require.NoError(t, err)
+			assert.Equal(t, uint64(1), blockEvent.Height)
+			require.Len(t, transactions, 2)
+			assert.Equal(t, tx2Hash, transactions[0].Hash)
+			assert.Equal(t, tx1Hash, transactions[1].Hash)
+		})
+
+		t.Run("resume after restart", func(t *testing.T) {
+			restarted := newBlockIndexer(zerolog.Nop(), reporter, reader, evmBlocks, db)
+
+			reporter.On("HighestIndexedHeight").Return(uint64(13), nil).Once()
+			require.NoError(t, restarted.IndexNewHeights())
+			assert.Equal(t, uint64(14), restarted.nextHeight)
+		})
+	})
+}
@@ -0,0 +1,322 @@
+package ethrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/crypto"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/rpc"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/access"
+	fvmCrypto "github.com/onflow/flow-go/fvm/crypto"
+	"github.com/onflow/flow-go/fvm/evm/stdlib"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// runTransactionTemplate is the Cadence transaction executing an EVM transaction. The transaction fees
+// are paid to the coinbase address. Transactions which are not valid in the EVM abort the Cadence
+// transaction, so that invalid transactions do not increment the nonce of the sender.
+const runTransactionTemplate = `
+import EVM from %s
+
+transaction(encodedTx: [UInt8], coinbase: [UInt8; 20]) {
+	prepare(signer: &Account) {}
+
+	execute {
+		let result = EVM.run(tx: encodedTx, coinbase: EVM.EVMAddress(bytes: coinbase))
+		assert(
+			result.status == EVM.Status.successful || result.status == EVM.Status.failed,
+			message: "invalid EVM transaction: ".concat(result.errorMessage)
+		)
+	}
+}
+`
+
+// ErrRateLimited is returned if a client sends more transactions than allowed by the rate limit.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// maxRateLimitedClients is the maximum number of clients whose rate limit is tracked. The limiters of
+// the least recently seen clients are evicted first.
+const maxRateLimitedClients = 10_000
+
+// Sender submits EVM transactions in Cadence transactions signed by a Flow account.
+//
+// The Cadence transactions are signed by a set of account keys, which are used in turn. Submissions
+// using the same key are serialized, so that its proposal sequence numbers are assigned and submitted
+// in order. Submissions using different keys run concurrently.
+// The sequence number of a key is tracked locally, since the transactions in flight are not reflected
+// by the on-chain key. It is re-synchronized with the on-chain key if the key was used by someone else,
+// or if no submitted transaction was executed within the transaction expiry.
+type Sender struct {
+	api          access.API
+	evmChainID   *big.Int
+	gasPrice     *big.Int // minimum gas price of submitted EVM transactions
+	script       []byte
+	address      flow.Address
+	keyBytes     []byte
+	coinbase     cadence.Array
+	computeLimit uint64
+	limiter      *clientRateLimiter // nil if the rate limit is disabled
+
+	keys    []*senderKey
+	nextKey atomic.Uint64
+}
+
+// senderKey is an account key signing Cadence transactions, with the state of its sequence number.
+type senderKey struct {
+	index uint32
+
+	mu             sync.Mutex
+	privateKey     crypto.PrivateKey // decoded on first use, since the signature algorithm is read from the chain
+	nextSeqNumber  uint64
+	onChainSeq     uint64
+	progressHeight uint64 // latest finalized height at which submitted transactions were known to progress
+}
+
+// NewSender returns a new Sender using the account keys of the sender configuration, and rejecting EVM
+// transactions with a gas price below the configured gas price.
+// The private key is decoded using the signature algorithm of each on-chain account key when the first
+// transaction is signed with it.
+//
+// No errors are expected during normal operation.
+func NewSender(api access.API, chainID flow.ChainID, config Config) (*Sender, error) {
+	senderConfig := config.Sender
+
+	address := flow.HexToAddress(senderConfig.FlowAddress)
+	if !chainID.Chain().IsValid(address) {
+		return nil, fmt.Errorf("invalid Flow address %s for chain %s", senderConfig.FlowAddress, chainID)
+	}
+
+	if senderConfig.KeyCount == 0 {
+		return nil, fmt.Errorf("at least one account key is required")
+	}
+
+	if !gethCommon.IsHexAddress(senderConfig.Coinbase) {
+		return nil, fmt.Errorf("invalid coinbase address %q", senderConfig.Coinbase)
+	}
+	coinbase := gethCommon.HexToAddress(senderConfig.Coinbase)
+	coinbaseValues := make([]cadence.Value, len(coinbase))
+	for i, b := range coinbase {
+		coinbaseValues[i] = cadence.UInt8(b)
+	}
+
+	keyData, err := os.ReadFile(senderConfig.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not read Flow account key (path=%s): %w", senderConfig.KeyFile, err)
+	}
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(keyData)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("could not hex decode Flow account key (path=%s): %w", senderConfig.KeyFile, err)
+	}
+
+	keys := make([]*senderKey, senderConfig.KeyCount)
+	for i := range keys {
+		keys[i] = &senderKey{index: senderConfig.KeyIndex + uint32(i)}
+	}
+
+	var limiter *clientRateLimiter
+	if senderConfig.RateLimit > 0 {
+		limiter, err = newClientRateLimiter(rate.Limit(senderConfig.RateLimit), senderConfig.RateBurst)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	evmAddress := systemcontracts.SystemContractsForChain(chainID).EVMContract.Address
+
+	return &Sender{
+		api:          api,
+		evmChainID:   types.EVMChainIDFromFlowChainID(chainID),
+		gasPrice:     new(big.Int).SetUint64(config.GasPrice),
+		script:       []byte(fmt.Sprintf(runTransactionTemplate, evmAddress.HexWithPrefix())),
+		address:      address,
+		keyBytes:     keyBytes,
+		coinbase:     cadence.NewArray(coinbaseValues).WithType(stdlib.EVMAddressBytesCadenceType),
+		computeLimit: senderConfig.ComputeLimit,
+		limiter:      limiter,
+		keys:         keys,
+	}, nil
+}
+
+// Send validates the given signed EVM transaction, submits it in a Cadence transaction and returns its hash.
+//
+// Expected errors during normal operation:
+//   - ErrRateLimited if the client sent more transactions than allowed by the rate limit
+//
+// Invalid transactions are rejected with an error.
+func (s *Sender) Send(ctx context.Context, encoded []byte) (gethCommon.Hash, error) {
+	if s.limiter != nil && !s.limiter.Allow(clientFromContext(ctx)) {
+		return gethCommon.Hash{}, ErrRateLimited
+	}
+
+	tx := &gethTypes.Transaction{}
+	err := tx.UnmarshalBinary(encoded)
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("invalid transaction: %w", err)
+	}
+	if tx.Protected() && tx.ChainId().Cmp(s.evmChainID) != 0 {
+		return gethCommon.Hash{}, fmt.Errorf("invalid chain ID %s, expected %s", tx.ChainId(), s.evmChainID)
+	}
+	if tx.GasPrice().Cmp(s.gasPrice) < 0 {
+		return gethCommon.Hash{}, fmt.Errorf("gas price %s is below the minimum gas price %s", tx.GasPrice(), s.gasPrice)
+	}
+	_, err = gethTypes.Sender(gethTypes.LatestSignerForChainID(s.evmChainID), tx)
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("invalid transaction signature: %w", err)
+	}
+
+	txValues := make([]cadence.Value, len(encoded))
+	for i, b := range encoded {
+		txValues[i] = cadence.UInt8(b)
+	}
+	txArgument, err := jsoncdc.Encode(cadence.NewArray(txValues).WithType(stdlib.EVMTransactionBytesCadenceType))
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("could not encode transaction argument: %w", err)
+	}
+	coinbaseArgument, err := jsoncdc.Encode(s.coinbase)
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("could not encode coinbase argument: %w", err)
+	}
+
+	key := s.keys[(s.nextKey.Add(1)-1)%uint64(len(s.keys))]
+
+	// the reference block and the on-chain key are read before the key is locked, so that concurrent
+	// submissions with the same key don't wait for them
+	header, _, err := s.api.GetLatestBlockHeader(ctx, false)
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("could not get latest block header: %w", err)
+	}
+	accountKey, err := s.api.GetAccountKeyAtLatestBlock(ctx, s.address, key.index)
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("could not get key %d of account %s: %w", key.index, s.address, err)
+	}
+
+	// the transaction is sent while holding the lock, so that the transactions of a key are received by
+	// the collection nodes in the order of their sequence numbers
+	key.mu.Lock()
+	defer key.mu.Unlock()
+
+	if key.privateKey == nil {
+		privateKey, err := crypto.DecodePrivateKey(accountKey.SignAlgo, s.keyBytes)
+		if err != nil {
+			return gethCommon.Hash{}, fmt.Errorf("could not decode Flow account key: %w", err)
+		}
+		if !privateKey.PublicKey().Equals(accountKey.PublicKey) {
+			return gethCommon.Hash{}, fmt.Errorf("private key does not match key %d of account %s", key.index, s.address)
+		}
+		key.privateKey = privateKey
+	}
+	seqNumber := key.sequenceNumber(accountKey.SeqNumber, header.Height)
+
+	txBody := flow.NewTransactionBody().
+		SetScript(s.script).
+		AddArgument(txArgument).
+		AddArgument(coinbaseArgument).
+		SetReferenceBlockID(header.ID()).
+		SetComputeLimit(s.computeLimit).
+		SetProposalKey(s.address, key.index, seqNumber).
+		SetPayer(s.address).
+		AddAuthorizer(s.address)
+
+	hasher, err := fvmCrypto.NewPrefixedHashing(accountKey.HashAlgo, "")
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("could not create hasher: %w", err)
+	}
+	err = txBody.SignEnvelope(s.address, key.index, key.privateKey, hasher)
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("could not sign transaction: %w", err)
+	}
+
+	err = s.api.SendTransaction(ctx, txBody)
+	if err != nil {
+		return gethCommon.Hash{}, fmt.Errorf("could not send transaction: %w", err)
+	}
+	key.nextSeqNumber = seqNumber + 1
+
+	return tx.Hash(), nil
+}
+
+// sequenceNumber returns the sequence number of the next transaction, given the on-chain sequence number
+// and the latest finalized height. Must be called while holding the lock.
+// The on-chain sequence number and the height are read before the lock is acquired, so they may be older
+// than the ones seen by a concurrent submission.
+func (k *senderKey) sequenceNumber(onChainSeq uint64, height uint64) uint64 {
+	onChainSeq = max(onChainSeq, k.onChainSeq)
+	height = max(height, k.progressHeight)
+
+	expired := height > k.progressHeight+flow.DefaultTransactionExpiry
+	if k.nextSeqNumber <= onChainSeq || expired {
+		// no submitted transaction is in flight, or the transactions in flight expired
+		k.nextSeqNumber = onChainSeq
+		k.progressHeight = height
+	} else if onChainSeq != k.onChainSeq {
+		// some submitted transactions were executed
+		k.progressHeight = height
+	}
+	k.onChainSeq = onChainSeq
+
+	return k.nextSeqNumber
+}
+
+// clientRateLimiter limits the rate of requests per client IP address.
+// The limiters of at most maxRateLimitedClients clients are kept.
+//
+// clientRateLimiter is safe for concurrent use.
+type clientRateLimiter struct {
+	limit    rate.Limit
+	burst    int
+	limiters *lru.Cache[string, *rate.Limiter]
+}
+
+func newClientRateLimiter(limit rate.Limit, burst int) (*clientRateLimiter, error) {
+	limiters, err := lru.New[string, *rate.Limiter](maxRateLimitedClients)
+	if err != nil {
+		return nil, fmt.Errorf("could not create rate limiter cache: %w", err)
+	}
+	return &clientRateLimiter{
+		limit:    limit,
+		burst:    burst,
+		limiters: limiters,
+	}, nil
+}
+
+// Allow returns whether a request of the given client is allowed by the rate limit.
+func (l *clientRateLimiter) Allow(client string) bool {
+	limiter, ok := l.limiters.Get(client)
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		// a concurrent request of the same client may have added a limiter
+		previous, found, _ := l.limiters.PeekOrAdd(client, limiter)
+		if found {
+			limiter = previous
+		}
+	}
+	return limiter.Allow()
+}
+
+// clientFromContext returns the IP address of the client of the JSON-RPC request.
+// Requests forwarded by a proxy are attributed to the proxy.
+func clientFromContext(ctx context.Context) string {
+	remoteAddr := rpc.PeerInfoFromContext(ctx).RemoteAddr
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
@@ -0,0 +1,156 @@
+package ethrpc
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	gethCrypto "github.com/onflow/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestSender_SequenceNumber(t *testing.T) {
+	s := &senderKey{}
+
+	// nothing in flight, the on-chain sequence number is used
+	assert.Equal(t, uint64(5), s.sequenceNumber(5, 100))
+	s.nextSeqNumber = 6
+
+	// transactions in flight are not reflected by the on-chain sequence number
+	assert.Equal(t, uint64(6), s.sequenceNumber(5, 101))
+	s.nextSeqNumber = 7
+
+	// some transactions were executed, the local sequence number is kept
+	assert.Equal(t, uint64(7), s.sequenceNumber(6, 200))
+	s.nextSeqNumber = 8
+
+	// the on-chain sequence number read by a concurrent submission is older than the latest one seen
+	assert.Equal(t, uint64(8), s.sequenceNumber(5, 150))
+	assert.Equal(t, uint64(6), s.onChainSeq)
+	assert.Equal(t, uint64(200), s.progressHeight)
+
+	// all transactions were executed
+	assert.Equal(t, uint64(8), s.sequenceNumber(8, 201))
+	s.nextSeqNumber = 9
+
+	// the key was used by someone else
+	assert.Equal(t, uint64(12), s.sequenceNumber(12, 202))
+	s.nextSeqNumber = 13
+
+	// the transactions in flight did not progress within the expiry, they are considered expired
+	assert.Equal(t, uint64(13), s.sequenceNumber(12, 202+flow.DefaultTransactionExpiry))
+	assert.Equal(t, uint64(12), s.sequenceNumber(12, 203+flow.DefaultTransactionExpiry))
+}
+
+func TestSender_Send(t *testing.T) {
+	chainID := flow.Emulator
+	evmChainID := types.EVMChainIDFromFlowChainID(chainID)
+	address := chainID.Chain().ServiceAddress()
+
+	accountKey, err := unittest.AccountKeyDefaultFixture()
+	require.NoError(t, err)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(accountKey.PrivateKey.Encode())), 0600))
+
+	evmKey, err := gethCrypto.GenerateKey()
+	require.NoError(t, err)
+	signedTx := func(t *testing.T, nonce uint64, gasPrice int64) []byte {
+		tx, err := gethTypes.SignTx(
+			gethTypes.NewTransaction(nonce, gethCommon.Address{1}, big.NewInt(1), 21_000, big.NewInt(gasPrice), nil),
+			gethTypes.LatestSignerForChainID(evmChainID),
+			evmKey,
+		)
+		require.NoError(t, err)
+		encoded, err := tx.MarshalBinary()
+		require.NoError(t, err)
+		return encoded
+	}
+
+	newSender := func(t *testing.T, api *accessmock.API, keyCount uint32, rateLimit float64) *Sender {
+		config := DefaultConfig()
+		config.GasPrice = 10
+		config.Sender.FlowAddress = address.Hex()
+		config.Sender.KeyIndex = 1
+		config.Sender.KeyCount = keyCount
+		config.Sender.KeyFile = keyFile
+		config.Sender.Coinbase = gethCommon.Address{2}.Hex()
+		config.Sender.RateLimit = rateLimit
+		config.Sender.RateBurst = 2
+		sender, err := NewSender(api, chainID, config)
+		require.NoError(t, err)
+		return sender
+	}
+
+	t.Run("gas price below the configured gas price", func(t *testing.T) {
+		sender := newSender(t, accessmock.NewAPI(t), 1, 0)
+		_, err := sender.Send(context.Background(), signedTx(t, 0, 9))
+		require.ErrorContains(t, err, "below the minimum gas price")
+	})
+
+	t.Run("keys are used in turn", func(t *testing.T) {
+		api := accessmock.NewAPI(t)
+		header := unittest.BlockHeaderFixture()
+		api.On("GetLatestBlockHeader", mock.Anything, false).Return(header, flow.BlockStatusFinalized, nil)
+		for _, index := range []uint32{1, 2} {
+			publicKey := accountKey.PublicKey(1000)
+			publicKey.Index = index
+			publicKey.SeqNumber = uint64(10 * index)
+			api.On("GetAccountKeyAtLatestBlock", mock.Anything, address, index).Return(&publicKey, nil)
+		}
+
+		var proposalKeys []flow.ProposalKey
+		api.On("SendTransaction", mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				proposalKeys = append(proposalKeys, args.Get(1).(*flow.TransactionBody).ProposalKey)
+			}).
+			Return(nil)
+
+		sender := newSender(t, api, 2, 0)
+		for nonce := uint64(0); nonce < 4; nonce++ {
+			_, err := sender.Send(context.Background(), signedTx(t, nonce, 10))
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, []flow.ProposalKey{
+			{Address: address, KeyIndex: 1, SequenceNumber: 10},
+			{Address: address, KeyIndex: 2, SequenceNumber: 20},
+			{Address: address, KeyIndex: 1, SequenceNumber: 11},
+			{Address: address, KeyIndex: 2, SequenceNumber: 21},
+		}, proposalKeys)
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		// the rate limit is checked before the transaction is decoded
+		sender := newSender(t, accessmock.NewAPI(t), 1, 0.001)
+		for i := 0; i < 2; i++ {
+			_, err := sender.Send(context.Background(), []byte{})
+			require.ErrorContains(t, err, "invalid transaction")
+		}
+		_, err := sender.Send(context.Background(), []byte{})
+		require.ErrorIs(t, err, ErrRateLimited)
+	})
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	limiter, err := newClientRateLimiter(0.001, 2)
+	require.NoError(t, err)
+
+	assert.True(t, limiter.Allow("1.2.3.4"))
+	assert.True(t, limiter.Allow("1.2.3.4"))
+	assert.False(t, limiter.Allow("1.2.3.4"))
+
+	// clients are limited independently
+	assert.True(t, limiter.Allow("5.6.7.8"))
+}
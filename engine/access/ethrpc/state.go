@@ -0,0 +1,122 @@
+package ethrpc
+
+import (
+	"errors"
+	"fmt"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/offchain/blocks"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// RegisterReader provides the values of registers at a Flow height.
+// It is implemented by execution.RegistersAsyncStore.
+type RegisterReader interface {
+	// RegisterValues returns the values of the given registers at the given Flow height.
+	//
+	// Expected errors during normal operation:
+	//   - indexer.ErrIndexNotInitialized if the registers are still bootstrapping
+	//   - storage.ErrHeightNotIndexed if the height is not indexed
+	//   - storage.ErrNotFound if a register does not exist at the height
+	RegisterValues(ids flow.RegisterIDs, height uint64) ([]flow.RegisterValue, error)
+}
+
+// registerSnapshot is a read-only view of the registers at the end of a Flow block.
+type registerSnapshot struct {
+	registers RegisterReader
+	height    uint64
+}
+
+var _ types.BackendStorageSnapshot = (*registerSnapshot)(nil)
+
+// GetValue returns the value of the given register, or nil if the register does not exist.
+func (s *registerSnapshot) GetValue(owner []byte, key []byte) ([]byte, error) {
+	values, err := s.registers.RegisterValues(flow.RegisterIDs{flow.CadenceRegisterID(owner, key)}, s.height)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return values[0], nil
+}
+
+// storageProvider provides the EVM state at the start of EVM blocks, from the registers at the end of
+// the Flow block which executed the previous EVM block.
+type storageProvider struct {
+	registers RegisterReader
+	evmBlocks storage.EVMBlocks
+}
+
+var _ types.StorageProvider = (*storageProvider)(nil)
+
+// GetSnapshotAt returns the EVM state at the start of the EVM block at the given height.
+func (p *storageProvider) GetSnapshotAt(evmBlockHeight uint64) (types.BackendStorageSnapshot, error) {
+	if evmBlockHeight == 0 {
+		return nil, fmt.Errorf("EVM state before the genesis block is not available")
+	}
+	previous, err := p.evmBlocks.ByHeight(evmBlockHeight - 1)
+	if err != nil {
+		return nil, err
+	}
+	return &registerSnapshot{
+		registers: p.registers,
+		height:    previous.FlowHeight,
+	}, nil
+}
+
+// blockSnapshotProvider provides the block context of EVM blocks from their block events.
+type blockSnapshotProvider struct {
+	chainID   flow.ChainID
+	events    *eventsReader
+	evmBlocks storage.EVMBlocks
+}
+
+var _ types.BlockSnapshotProvider = (*blockSnapshotProvider)(nil)
+
+// GetSnapshotAt returns the block snapshot of the EVM block at the given height.
+func (p *blockSnapshotProvider) GetSnapshotAt(evmBlockHeight uint64) (types.BlockSnapshot, error) {
+	entry, err := p.evmBlocks.ByHeight(evmBlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	block, _, err := p.events.Block(entry)
+	if err != nil {
+		return nil, err
+	}
+	return &blockSnapshot{
+		chainID:   p.chainID,
+		block:     block,
+		evmBlocks: p.evmBlocks,
+	}, nil
+}
+
+type blockSnapshot struct {
+	chainID   flow.ChainID
+	block     *events.BlockEventPayload
+	evmBlocks storage.EVMBlocks
+}
+
+var _ types.BlockSnapshot = (*blockSnapshot)(nil)
+
+// BlockContext returns the context used to execute calls on top of the block.
+func (s *blockSnapshot) BlockContext() (types.BlockContext, error) {
+	return blocks.NewBlockContext(
+		s.chainID,
+		s.block.Height,
+		s.block.Timestamp,
+		func(height uint64) gethCommon.Hash {
+			entry, err := s.evmBlocks.ByHeight(height)
+			if err != nil {
+				return gethCommon.Hash{}
+			}
+			return entry.Hash
+		},
+		s.block.PrevRandao,
+		nil,
+	)
+}
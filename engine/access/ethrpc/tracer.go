@@ -0,0 +1,372 @@
+package ethrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/core/tracing"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+	"github.com/onflow/go-ethereum/core/vm"
+	gethTracers "github.com/onflow/go-ethereum/eth/tracers"
+	"github.com/onflow/go-ethereum/eth/tracers/logger"
+	_ "github.com/onflow/go-ethereum/eth/tracers/native" // imported so the native tracers are registered in init
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/fvm/evm"
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/offchain/sync"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	callTracerName     = "callTracer"
+	prestateTracerName = "prestateTracer"
+)
+
+var (
+	// ErrTraceTimeout is returned if a trace does not complete within its timeout.
+	ErrTraceTimeout = errors.New("execution timeout")
+
+	// ErrTraceTooLarge is returned if a trace exceeds the maximum trace size.
+	ErrTraceTooLarge = errors.New("trace too large")
+)
+
+// TraceConfig holds the options of a transaction trace, following the debug_traceTransaction API of geth.
+// The struct logger is used if no tracer is given.
+type TraceConfig struct {
+	*logger.Config
+	Tracer       *string         // name of the tracer, either callTracer or prestateTracer
+	Timeout      *string         // timeout of the trace, bounded by the configured maximum
+	TracerConfig json.RawMessage // configuration of the tracer
+}
+
+// Tracer traces indexed EVM transactions, by replaying the transactions of their EVM block on top of
+// the indexed registers.
+type Tracer struct {
+	config    TracingConfig
+	events    *eventsReader
+	evmBlocks storage.EVMBlocks
+	replayer  *sync.Replayer
+}
+
+func newTracer(
+	log zerolog.Logger,
+	chainID flow.ChainID,
+	config TracingConfig,
+	reader *eventsReader,
+	evmBlocks storage.EVMBlocks,
+	storageProvider *storageProvider,
+	blockProvider *blockSnapshotProvider,
+) *Tracer {
+	return &Tracer{
+		config:    config,
+		events:    reader,
+		evmBlocks: evmBlocks,
+		replayer: sync.NewReplayer(
+			chainID,
+			evm.StorageAccountAddress(chainID),
+			storageProvider,
+			blockProvider,
+			log,
+			nil,
+			true,
+		),
+	}
+}
+
+// TraceTransaction replays the EVM transaction with the given hash, and returns its trace as produced by
+// the tracer of the given configuration.
+//
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if the transaction is not indexed.
+//   - ErrTraceTimeout if the trace did not complete within its timeout.
+//   - ErrTraceTooLarge if the trace exceeds the maximum trace size.
+//   - all other errors are the result of invalid requests, or of failures to replay the transaction
+func (t *Tracer) TraceTransaction(ctx context.Context, hash gethCommon.Hash, config *TraceConfig) (json.RawMessage, error) {
+	if config == nil {
+		config = &TraceConfig{}
+	}
+
+	timeout := t.config.Timeout
+	if config.Timeout != nil {
+		requested, err := time.ParseDuration(*config.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout %q: %w", *config.Timeout, err)
+		}
+		timeout = min(timeout, requested)
+	}
+
+	height, err := t.evmBlocks.HeightByTransactionHash(hash)
+	if err != nil {
+		return nil, fmt.Errorf("could not find transaction %s: %w", hash, err)
+	}
+	entry, blockEvent, transactions, err := t.events.BlockByHeight(height)
+	if err != nil {
+		return nil, fmt.Errorf("could not get EVM block %d: %w", height, err)
+	}
+
+	txIndex := -1
+	payloads := make([]events.TransactionEventPayload, len(transactions))
+	for i, tx := range transactions {
+		payloads[i] = *tx
+		if tx.Hash == hash {
+			txIndex = i
+		}
+	}
+	if txIndex < 0 {
+		return nil, fmt.Errorf("transaction %s not found in EVM block %d: %w", hash, height, storage.ErrNotFound)
+	}
+
+	tracer, err := t.newTracer(config, &gethTracers.Context{
+		BlockHash:   entry.Hash,
+		BlockNumber: new(big.Int).SetUint64(entry.Height),
+		TxIndex:     txIndex,
+		TxHash:      hash,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sizeLimiter := newTraceSizeLimiter(t.config.MaxTraceSize, t.config.MaxStructLogs, config)
+	tracer = sizeLimiter.wrap(tracer)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	replayed := make(chan struct{})
+	go func() {
+		// the replay checks the context between transactions, the traced transaction is interrupted by
+		// stopping the tracer
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				tracer.Stop(ErrTraceTimeout)
+			} else {
+				tracer.Stop(ctx.Err())
+			}
+		case <-replayed:
+		}
+	}()
+
+	_, err = t.replayer.ReplayTransaction(ctx, payloads, blockEvent, txIndex, tracer)
+	close(replayed)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, ErrTraceTimeout
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if sizeLimiter.exceeded {
+		return nil, fmt.Errorf("%w: estimated size exceeds the limit of %d bytes", ErrTraceTooLarge, t.config.MaxTraceSize)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not replay transaction %s: %w", hash, err)
+	}
+
+	result, err := tracer.GetResult()
+	if err != nil {
+		return nil, err
+	}
+	// the size is only estimated while tracing, check the actual size of the result
+	if uint64(len(result)) > t.config.MaxTraceSize {
+		return nil, fmt.Errorf("%w: %d bytes exceed the limit of %d bytes", ErrTraceTooLarge, len(result), t.config.MaxTraceSize)
+	}
+
+	return result, nil
+}
+
+// newTracer returns the tracer of the given configuration.
+func (t *Tracer) newTracer(config *TraceConfig, tracerCtx *gethTracers.Context) (*gethTracers.Tracer, error) {
+	if config.Tracer == nil || *config.Tracer == "" {
+		logConfig := logger.Config{}
+		if config.Config != nil {
+			logConfig = *config.Config
+		}
+		// bound the number of recorded opcodes, since struct logs are accumulated in memory
+		if logConfig.Limit <= 0 || uint64(logConfig.Limit) > t.config.MaxStructLogs {
+			logConfig.Limit = int(t.config.MaxStructLogs)
+		}
+		structLogger := logger.NewStructLogger(&logConfig)
+		return &gethTracers.Tracer{
+			Hooks:     structLogger.Hooks(),
+			GetResult: structLogger.GetResult,
+			Stop:      structLogger.Stop,
+		}, nil
+	}
+
+	switch *config.Tracer {
+	case callTracerName, prestateTracerName:
+		tracer, err := gethTracers.DefaultDirectory.New(*config.Tracer, tracerCtx, config.TracerConfig)
+		if err != nil {
+			return nil, fmt.Errorf("could not create %s: %w", *config.Tracer, err)
+		}
+		return tracer, nil
+	default:
+		return nil, fmt.Errorf("tracer %q is not supported, supported tracers are %s and %s",
+			*config.Tracer, callTracerName, prestateTracerName)
+	}
+}
+
+// Estimated sizes of the JSON encoded parts of traces, in bytes.
+const (
+	structLogSize   = 150 // struct log of an opcode, without its stack, memory and storage
+	stackItemSize   = 70  // hex encoded 32 byte word
+	storageSlotSize = 140 // hex encoded 32 byte key and value
+	callFrameSize   = 300 // call frame or account, without its input and output
+	logSize         = 150 // event log, without its data and topics
+)
+
+// traceSizeLimiter estimates the size of the output of a tracer while a transaction is traced, and stops
+// the tracer with ErrTraceTooLarge once the estimated size exceeds the maximum trace size. Tracers
+// accumulate their output in memory until the transaction completes, so the size of a trace must be
+// bounded before the result is produced.
+//
+// The estimate is based on the events of the traced transaction: the opcodes for struct logs, and the
+// call frames, event logs and accessed storage slots for the native tracers.
+type traceSizeLimiter struct {
+	maxSize    uint64
+	size       uint64
+	exceeded   bool
+	structLogs bool
+	logConfig  logger.Config
+	// opcodes is the number of traced opcodes, the struct logger records at most logConfig.Limit of them
+	opcodes int
+	// storage holds the slots accessed by each contract, as the struct logger includes all of them in the
+	// struct log of each SLOAD and SSTORE opcode
+	storage map[gethCommon.Address]map[gethCommon.Hash]struct{}
+}
+
+func newTraceSizeLimiter(maxSize uint64, maxStructLogs uint64, config *TraceConfig) *traceSizeLimiter {
+	l := &traceSizeLimiter{
+		maxSize:    maxSize,
+		structLogs: config.Tracer == nil || *config.Tracer == "",
+		storage:    make(map[gethCommon.Address]map[gethCommon.Hash]struct{}),
+	}
+	if config.Config != nil {
+		l.logConfig = *config.Config
+	}
+	// same limit as the struct logger, see newTracer
+	if l.logConfig.Limit <= 0 || uint64(l.logConfig.Limit) > maxStructLogs {
+		l.logConfig.Limit = int(maxStructLogs)
+	}
+	return l
+}
+
+// wrap returns the given tracer with hooks which estimate the size of its output.
+// The hooks are called by the goroutine executing the traced transaction.
+func (l *traceSizeLimiter) wrap(tracer *gethTracers.Tracer) *gethTracers.Tracer {
+	hooks := *tracer.Hooks
+
+	onEnter := tracer.OnEnter
+	hooks.OnEnter = func(depth int, typ byte, from gethCommon.Address, to gethCommon.Address, input []byte, gas uint64, value *big.Int) {
+		if !l.add(tracer, l.frameSize(callFrameSize+2*uint64(len(input)))) || onEnter == nil {
+			return
+		}
+		onEnter(depth, typ, from, to, input, gas, value)
+	}
+
+	onExit := tracer.OnExit
+	hooks.OnExit = func(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+		if !l.add(tracer, 2*uint64(len(output))) || onExit == nil {
+			return
+		}
+		onExit(depth, output, gasUsed, err, reverted)
+	}
+
+	onLog := tracer.OnLog
+	hooks.OnLog = func(log *gethTypes.Log) {
+		if !l.add(tracer, l.frameSize(logSize+2*uint64(len(log.Data))+stackItemSize*uint64(len(log.Topics)))) || onLog == nil {
+			return
+		}
+		onLog(log)
+	}
+
+	onOpcode := tracer.OnOpcode
+	hooks.OnOpcode = func(pc uint64, op byte, gas, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+		if !l.add(tracer, l.opcodeSize(vm.OpCode(op), scope, rData)) || onOpcode == nil {
+			return
+		}
+		onOpcode(pc, op, gas, cost, scope, rData, depth, err)
+	}
+
+	return &gethTracers.Tracer{
+		Hooks:     &hooks,
+		GetResult: tracer.GetResult,
+		Stop:      tracer.Stop,
+	}
+}
+
+// add adds the given size to the estimated size of the trace, and returns false if the trace is too large.
+func (l *traceSizeLimiter) add(tracer *gethTracers.Tracer, size uint64) bool {
+	if l.exceeded {
+		return false
+	}
+	l.size += size
+	if l.size > l.maxSize {
+		l.exceeded = true
+		tracer.Stop(ErrTraceTooLarge)
+		return false
+	}
+	return true
+}
+
+// frameSize returns the given size of a call frame or event log, which are only included in the output
+// of the native tracers.
+func (l *traceSizeLimiter) frameSize(size uint64) uint64 {
+	if l.structLogs {
+		return 0
+	}
+	return size
+}
+
+// opcodeSize returns the estimated size of the output of the tracer for the execution of the given opcode.
+func (l *traceSizeLimiter) opcodeSize(op vm.OpCode, scope tracing.OpContext, rData []byte) uint64 {
+	var slotCount uint64
+	if op == vm.SLOAD || op == vm.SSTORE {
+		stack := scope.StackData()
+		if len(stack) > 0 {
+			contract := scope.Address()
+			slots, ok := l.storage[contract]
+			if !ok {
+				slots = make(map[gethCommon.Hash]struct{})
+				l.storage[contract] = slots
+			}
+			slot := gethCommon.Hash(stack[len(stack)-1].Bytes32())
+			if _, ok := slots[slot]; !ok {
+				slots[slot] = struct{}{}
+				// the native tracers only record each accessed slot once
+				slotCount = 1
+			}
+			if l.structLogs {
+				slotCount = uint64(len(slots))
+			}
+		}
+	}
+
+	if !l.structLogs {
+		return storageSlotSize * slotCount
+	}
+	if l.opcodes >= l.logConfig.Limit {
+		return 0
+	}
+	l.opcodes++
+
+	size := uint64(structLogSize)
+	if !l.logConfig.DisableStack {
+		size += stackItemSize * uint64(len(scope.StackData()))
+	}
+	if l.logConfig.EnableMemory {
+		size += 2 * uint64(len(scope.MemoryData()))
+	}
+	if !l.logConfig.DisableStorage {
+		size += storageSlotSize * slotCount
+	}
+	if l.logConfig.EnableReturnData {
+		size += 2 * uint64(len(rData))
+	}
+	return size
+}
@@ -0,0 +1,158 @@
+package ethrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/core/tracing"
+	"github.com/onflow/go-ethereum/core/vm"
+	gethTracers "github.com/onflow/go-ethereum/eth/tracers"
+	"github.com/onflow/go-ethereum/eth/tracers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+)
+
+func TestTracer_NewTracer(t *testing.T) {
+	tracer := &Tracer{config: DefaultConfig().Tracing}
+	tracerCtx := &gethTracers.Context{}
+
+	t.Run("struct logger by default", func(t *testing.T) {
+		structLogger, err := tracer.newTracer(&TraceConfig{}, tracerCtx)
+		require.NoError(t, err)
+		assert.NotNil(t, structLogger.OnOpcode)
+	})
+
+	t.Run("native tracers", func(t *testing.T) {
+		for _, name := range []string{callTracerName, prestateTracerName} {
+			nativeTracer, err := tracer.newTracer(&TraceConfig{Tracer: &name}, tracerCtx)
+			require.NoError(t, err)
+			assert.NotNil(t, nativeTracer.GetResult)
+		}
+	})
+
+	t.Run("unsupported tracer", func(t *testing.T) {
+		name := "4byteTracer"
+		_, err := tracer.newTracer(&TraceConfig{Tracer: &name}, tracerCtx)
+		require.Error(t, err)
+
+		// tracers are never evaluated as JS code
+		code := "{}"
+		_, err = tracer.newTracer(&TraceConfig{Tracer: &code}, tracerCtx)
+		require.Error(t, err)
+	})
+
+	t.Run("struct logs limit", func(t *testing.T) {
+		config := &TraceConfig{Config: &logger.Config{Limit: 1_000_000}}
+		_, err := tracer.newTracer(config, tracerCtx)
+		require.NoError(t, err)
+		// the configuration of the request is not modified
+		assert.Equal(t, 1_000_000, config.Limit)
+	})
+}
+
+func TestTracer_TraceTransaction(t *testing.T) {
+	hash := gethCommon.HexToHash("0x01")
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		tracer := &Tracer{config: DefaultConfig().Tracing}
+		timeout := "soon"
+		_, err := tracer.TraceTransaction(context.Background(), hash, &TraceConfig{Timeout: &timeout})
+		require.Error(t, err)
+	})
+
+	t.Run("transaction not indexed", func(t *testing.T) {
+		evmBlocks := storagemock.NewEVMBlocks(t)
+		evmBlocks.On("HeightByTransactionHash", hash).Return(uint64(0), storage.ErrNotFound)
+
+		tracer := &Tracer{config: DefaultConfig().Tracing, evmBlocks: evmBlocks}
+		_, err := tracer.TraceTransaction(context.Background(), hash, nil)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+	})
+}
+
+func TestTraceSizeLimiter(t *testing.T) {
+	contract := gethCommon.HexToAddress("0x01")
+
+	// newStoppableTracer returns a tracer without hooks, recording the reason it was stopped with
+	newStoppableTracer := func() (*gethTracers.Tracer, *error) {
+		var reason error
+		return &gethTracers.Tracer{
+			Hooks:     &tracing.Hooks{},
+			GetResult: func() (json.RawMessage, error) { return nil, reason },
+			Stop:      func(err error) { reason = err },
+		}, &reason
+	}
+
+	t.Run("stops the struct logger", func(t *testing.T) {
+		limiter := newTraceSizeLimiter(1000, 100, &TraceConfig{})
+		tracer, reason := newStoppableTracer()
+		tracer = limiter.wrap(tracer)
+
+		scope := &opContext{address: contract, stack: []uint256.Int{*uint256.NewInt(1)}}
+		tracer.OnOpcode(0, byte(vm.PUSH1), 100, 3, scope, nil, 1, nil)
+		require.False(t, limiter.exceeded)
+
+		// the struct log of each SLOAD includes all accessed storage slots of the contract
+		for i := uint64(0); !limiter.exceeded; i++ {
+			require.Less(t, i, uint64(5))
+			scope.stack = []uint256.Int{*uint256.NewInt(i)}
+			tracer.OnOpcode(i, byte(vm.SLOAD), 100, 100, scope, nil, 1, nil)
+		}
+
+		_, err := tracer.GetResult()
+		require.ErrorIs(t, err, ErrTraceTooLarge)
+		require.ErrorIs(t, *reason, ErrTraceTooLarge)
+	})
+
+	t.Run("only counts the recorded struct logs", func(t *testing.T) {
+		limit := 5
+		limiter := newTraceSizeLimiter(structLogSize*uint64(limit), 100, &TraceConfig{Config: &logger.Config{Limit: limit}})
+		tracer, _ := newStoppableTracer()
+		tracer = limiter.wrap(tracer)
+
+		for i := 0; i < 2*limit; i++ {
+			tracer.OnOpcode(uint64(i), byte(vm.JUMPDEST), 100, 1, &opContext{address: contract}, nil, 1, nil)
+		}
+		assert.False(t, limiter.exceeded)
+	})
+
+	t.Run("stops the call tracer", func(t *testing.T) {
+		name := callTracerName
+		callTracer, err := (&Tracer{config: DefaultConfig().Tracing}).newTracer(&TraceConfig{Tracer: &name}, &gethTracers.Context{})
+		require.NoError(t, err)
+		limiter := newTraceSizeLimiter(1000, 100, &TraceConfig{Tracer: &name})
+		callTracer = limiter.wrap(callTracer)
+
+		callTracer.OnEnter(0, byte(vm.CALL), contract, contract, nil, 100, big.NewInt(0))
+		require.False(t, limiter.exceeded)
+		callTracer.OnEnter(1, byte(vm.CALL), contract, contract, make([]byte, 1000), 100, big.NewInt(0))
+		require.True(t, limiter.exceeded)
+
+		// the hooks are no longer forwarded to the tracer
+		callTracer.OnExit(1, nil, 10, nil, false)
+		callTracer.OnExit(0, nil, 10, nil, false)
+
+		_, err = callTracer.GetResult()
+		require.ErrorIs(t, err, ErrTraceTooLarge)
+	})
+}
+
+// opContext is the scope of an opcode passed to the tracing hooks.
+type opContext struct {
+	address gethCommon.Address
+	stack   []uint256.Int
+}
+
+func (c *opContext) MemoryData() []byte          { return nil }
+func (c *opContext) StackData() []uint256.Int    { return c.stack }
+func (c *opContext) Caller() gethCommon.Address  { return c.address }
+func (c *opContext) Address() gethCommon.Address { return c.address }
+func (c *opContext) CallValue() *uint256.Int     { return uint256.NewInt(0) }
+func (c *opContext) CallInput() []byte           { return nil }
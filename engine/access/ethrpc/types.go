@@ -0,0 +1,317 @@
+package ethrpc
+
+import (
+	"fmt"
+	"math/big"
+
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/onflow/go-ethereum/common/hexutil"
+	gethTypes "github.com/onflow/go-ethereum/core/types"
+
+	"github.com/onflow/flow-go/fvm/evm/events"
+	"github.com/onflow/flow-go/fvm/evm/types"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// blockGasLimit is the gas limit reported for EVM blocks. Flow EVM blocks have no gas limit, the value
+// is only reported for compatibility with Ethereum tooling.
+const blockGasLimit = 120_000_000
+
+// Block is the JSON-RPC representation of an EVM block.
+type Block struct {
+	Number           hexutil.Uint64       `json:"number"`
+	Hash             gethCommon.Hash      `json:"hash"`
+	ParentHash       gethCommon.Hash      `json:"parentHash"`
+	Nonce            gethTypes.BlockNonce `json:"nonce"`
+	Sha3Uncles       gethCommon.Hash      `json:"sha3Uncles"`
+	LogsBloom        hexutil.Bytes        `json:"logsBloom"`
+	TransactionsRoot gethCommon.Hash      `json:"transactionsRoot"`
+	StateRoot        gethCommon.Hash      `json:"stateRoot"`
+	ReceiptsRoot     gethCommon.Hash      `json:"receiptsRoot"`
+	Miner            gethCommon.Address   `json:"miner"`
+	Difficulty       hexutil.Uint64       `json:"difficulty"`
+	TotalDifficulty  hexutil.Uint64       `json:"totalDifficulty"`
+	ExtraData        hexutil.Bytes        `json:"extraData"`
+	GasLimit         hexutil.Uint64       `json:"gasLimit"`
+	GasUsed          hexutil.Uint64       `json:"gasUsed"`
+	Timestamp        hexutil.Uint64       `json:"timestamp"`
+	// Transactions holds either the transaction hashes or the full transactions of the block.
+	Transactions  interface{}       `json:"transactions"`
+	Uncles        []gethCommon.Hash `json:"uncles"`
+	MixHash       gethCommon.Hash   `json:"mixHash"`
+	BaseFeePerGas *hexutil.Big      `json:"baseFeePerGas"`
+}
+
+// Transaction is the JSON-RPC representation of an EVM transaction included in a block.
+type Transaction struct {
+	BlockHash        gethCommon.Hash       `json:"blockHash"`
+	BlockNumber      *hexutil.Big          `json:"blockNumber"`
+	From             gethCommon.Address    `json:"from"`
+	Gas              hexutil.Uint64        `json:"gas"`
+	GasPrice         *hexutil.Big          `json:"gasPrice"`
+	GasFeeCap        *hexutil.Big          `json:"maxFeePerGas,omitempty"`
+	GasTipCap        *hexutil.Big          `json:"maxPriorityFeePerGas,omitempty"`
+	Hash             gethCommon.Hash       `json:"hash"`
+	Input            hexutil.Bytes         `json:"input"`
+	Nonce            hexutil.Uint64        `json:"nonce"`
+	To               *gethCommon.Address   `json:"to"`
+	TransactionIndex hexutil.Uint64        `json:"transactionIndex"`
+	Value            *hexutil.Big          `json:"value"`
+	Type             hexutil.Uint64        `json:"type"`
+	Accesses         *gethTypes.AccessList `json:"accessList,omitempty"`
+	ChainID          *hexutil.Big          `json:"chainId,omitempty"`
+	V                *hexutil.Big          `json:"v"`
+	R                *hexutil.Big          `json:"r"`
+	S                *hexutil.Big          `json:"s"`
+}
+
+// Receipt is the JSON-RPC representation of the receipt of an EVM transaction.
+type Receipt struct {
+	TransactionHash   gethCommon.Hash     `json:"transactionHash"`
+	TransactionIndex  hexutil.Uint64      `json:"transactionIndex"`
+	BlockHash         gethCommon.Hash     `json:"blockHash"`
+	BlockNumber       *hexutil.Big        `json:"blockNumber"`
+	From              gethCommon.Address  `json:"from"`
+	To                *gethCommon.Address `json:"to"`
+	CumulativeGasUsed hexutil.Uint64      `json:"cumulativeGasUsed"`
+	GasUsed           hexutil.Uint64      `json:"gasUsed"`
+	EffectiveGasPrice *hexutil.Big        `json:"effectiveGasPrice"`
+	ContractAddress   *gethCommon.Address `json:"contractAddress"`
+	Logs              []*gethTypes.Log    `json:"logs"`
+	LogsBloom         gethTypes.Bloom     `json:"logsBloom"`
+	Status            hexutil.Uint64      `json:"status"`
+	Type              hexutil.Uint64      `json:"type"`
+	RevertReason      hexutil.Bytes       `json:"revertReason,omitempty"`
+}
+
+// CallArgs are the arguments of eth_call and eth_estimateGas requests.
+type CallArgs struct {
+	From                 *gethCommon.Address `json:"from"`
+	To                   *gethCommon.Address `json:"to"`
+	Gas                  *hexutil.Uint64     `json:"gas"`
+	GasPrice             *hexutil.Big        `json:"gasPrice"`
+	MaxFeePerGas         *hexutil.Big        `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big        `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big        `json:"value"`
+	Data                 *hexutil.Bytes      `json:"data"`
+	Input                *hexutil.Bytes      `json:"input"`
+}
+
+// data returns the call data, the input field takes precedence over the legacy data field.
+func (args *CallArgs) data() []byte {
+	if args.Input != nil {
+		return *args.Input
+	}
+	if args.Data != nil {
+		return *args.Data
+	}
+	return nil
+}
+
+// decodedTransaction is an EVM transaction decoded from its transaction event.
+type decodedTransaction struct {
+	event *events.TransactionEventPayload
+	tx    *gethTypes.Transaction
+	from  gethCommon.Address
+	logs  []*gethTypes.Log
+}
+
+// decodeTransaction decodes the EVM transaction of the given transaction event. The logs are
+// annotated with the block and transaction they were emitted by, and are indexed starting at logIndex.
+func decodeTransaction(
+	evmChainID *big.Int,
+	blockHash gethCommon.Hash,
+	event *events.TransactionEventPayload,
+	logIndex uint,
+) (*decodedTransaction, error) {
+	var tx *gethTypes.Transaction
+	var from gethCommon.Address
+
+	if len(event.Payload) > 0 && event.TransactionType == types.DirectCallTxType {
+		call, err := types.DirectCallFromEncoded(event.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode direct call %s: %w", event.Hash, err)
+		}
+		tx = call.Transaction()
+		from = call.From.ToCommon()
+	} else {
+		tx = &gethTypes.Transaction{}
+		err := tx.UnmarshalBinary(event.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode transaction %s: %w", event.Hash, err)
+		}
+		from, err = gethTypes.Sender(gethTypes.LatestSignerForChainID(evmChainID), tx)
+		if err != nil {
+			return nil, fmt.Errorf("could not recover sender of transaction %s: %w", event.Hash, err)
+		}
+	}
+
+	logs, err := decodeLogs(event)
+	if err != nil {
+		return nil, err
+	}
+	for _, log := range logs {
+		log.BlockNumber = event.BlockHeight
+		log.BlockHash = blockHash
+		log.TxHash = event.Hash
+		log.TxIndex = uint(event.Index)
+		log.Index = logIndex
+		logIndex++
+	}
+
+	return &decodedTransaction{
+		event: event,
+		tx:    tx,
+		from:  from,
+		logs:  logs,
+	}, nil
+}
+
+// decodeTransactions decodes the EVM transactions of a block from their transaction events.
+func decodeTransactions(
+	evmChainID *big.Int,
+	blockHash gethCommon.Hash,
+	transactionEvents []*events.TransactionEventPayload,
+) ([]*decodedTransaction, error) {
+	transactions := make([]*decodedTransaction, 0, len(transactionEvents))
+	logIndex := uint(0)
+	for _, event := range transactionEvents {
+		tx, err := decodeTransaction(evmChainID, blockHash, event, logIndex)
+		if err != nil {
+			return nil, err
+		}
+		logIndex += uint(len(tx.logs))
+		transactions = append(transactions, tx)
+	}
+	return transactions, nil
+}
+
+// transaction returns the JSON-RPC representation of the transaction.
+func (t *decodedTransaction) transaction(evmChainID *big.Int, blockHash gethCommon.Hash) *Transaction {
+	v, r, s := t.tx.RawSignatureValues()
+	result := &Transaction{
+		BlockHash:        blockHash,
+		BlockNumber:      (*hexutil.Big)(new(big.Int).SetUint64(t.event.BlockHeight)),
+		From:             t.from,
+		Gas:              hexutil.Uint64(t.tx.Gas()),
+		GasPrice:         (*hexutil.Big)(t.tx.GasPrice()),
+		Hash:             t.event.Hash,
+		Input:            t.tx.Data(),
+		Nonce:            hexutil.Uint64(t.tx.Nonce()),
+		To:               t.tx.To(),
+		TransactionIndex: hexutil.Uint64(t.event.Index),
+		Value:            (*hexutil.Big)(t.tx.Value()),
+		Type:             hexutil.Uint64(t.tx.Type()),
+		V:                (*hexutil.Big)(v),
+		R:                (*hexutil.Big)(r),
+		S:                (*hexutil.Big)(s),
+	}
+
+	switch t.tx.Type() {
+	case gethTypes.LegacyTxType:
+		if t.tx.Protected() {
+			result.ChainID = (*hexutil.Big)(evmChainID)
+		}
+	case gethTypes.AccessListTxType:
+		accesses := t.tx.AccessList()
+		result.Accesses = &accesses
+		result.ChainID = (*hexutil.Big)(t.tx.ChainId())
+	case gethTypes.DynamicFeeTxType, gethTypes.BlobTxType:
+		accesses := t.tx.AccessList()
+		result.Accesses = &accesses
+		result.ChainID = (*hexutil.Big)(t.tx.ChainId())
+		result.GasFeeCap = (*hexutil.Big)(t.tx.GasFeeCap())
+		result.GasTipCap = (*hexutil.Big)(t.tx.GasTipCap())
+	}
+
+	return result
+}
+
+// receipt returns the JSON-RPC representation of the receipt of the transaction.
+func (t *decodedTransaction) receipt(blockHash gethCommon.Hash, cumulativeGasUsed uint64) *Receipt {
+	logs := t.logs
+	if logs == nil {
+		logs = []*gethTypes.Log{}
+	}
+
+	// the base fee of Flow EVM blocks is 0
+	effectiveGasPrice, err := t.tx.EffectiveGasTip(big.NewInt(0))
+	if err != nil {
+		effectiveGasPrice = t.tx.GasPrice()
+	}
+
+	result := &Receipt{
+		TransactionHash:   t.event.Hash,
+		TransactionIndex:  hexutil.Uint64(t.event.Index),
+		BlockHash:         blockHash,
+		BlockNumber:       (*hexutil.Big)(new(big.Int).SetUint64(t.event.BlockHeight)),
+		From:              t.from,
+		To:                t.tx.To(),
+		CumulativeGasUsed: hexutil.Uint64(cumulativeGasUsed),
+		GasUsed:           hexutil.Uint64(t.event.GasConsumed),
+		EffectiveGasPrice: (*hexutil.Big)(effectiveGasPrice),
+		Logs:              logs,
+		LogsBloom:         gethTypes.CreateBloom(gethTypes.Receipts{{Logs: t.logs}}),
+		Status:            hexutil.Uint64(gethTypes.ReceiptStatusSuccessful),
+	}
+
+	if t.event.TransactionType != types.DirectCallTxType {
+		result.Type = hexutil.Uint64(t.event.TransactionType)
+	}
+	if t.event.ContractAddress != "" {
+		address := gethCommon.HexToAddress(t.event.ContractAddress)
+		result.ContractAddress = &address
+	}
+	if types.ErrorCode(t.event.ErrorCode) != types.ErrCodeNoError {
+		result.Status = hexutil.Uint64(gethTypes.ReceiptStatusFailed)
+		if types.ErrorCode(t.event.ErrorCode) == types.ExecutionErrCodeExecutionReverted {
+			result.RevertReason = t.event.ReturnedData
+		}
+	}
+
+	return result
+}
+
+// block returns the JSON-RPC representation of the given EVM block.
+func block(
+	evmChainID *big.Int,
+	entry *flow.EVMBlock,
+	blockEvent *events.BlockEventPayload,
+	transactions []*decodedTransaction,
+	fullTx bool,
+) *Block {
+	result := &Block{
+		Number:           hexutil.Uint64(blockEvent.Height),
+		Hash:             blockEvent.Hash,
+		ParentHash:       blockEvent.ParentBlockHash,
+		Sha3Uncles:       gethTypes.EmptyUncleHash,
+		LogsBloom:        gethTypes.BytesToBloom(entry.LogsBloom).Bytes(),
+		TransactionsRoot: blockEvent.TransactionHashRoot,
+		StateRoot:        gethTypes.EmptyRootHash,
+		ReceiptsRoot:     blockEvent.ReceiptRoot,
+		Miner:            types.CoinbaseAddress.ToCommon(),
+		ExtraData:        hexutil.Bytes{},
+		GasLimit:         hexutil.Uint64(blockGasLimit),
+		GasUsed:          hexutil.Uint64(blockEvent.TotalGasUsed),
+		Timestamp:        hexutil.Uint64(blockEvent.Timestamp),
+		Uncles:           []gethCommon.Hash{},
+		MixHash:          blockEvent.PrevRandao,
+		BaseFeePerGas:    (*hexutil.Big)(big.NewInt(0)),
+	}
+
+	if fullTx {
+		txs := make([]*Transaction, 0, len(transactions))
+		for _, tx := range transactions {
+			txs = append(txs, tx.transaction(evmChainID, blockEvent.Hash))
+		}
+		result.Transactions = txs
+	} else {
+		hashes := entry.TransactionHashes
+		if hashes == nil {
+			hashes = []gethCommon.Hash{}
+		}
+		result.Transactions = hashes
+	}
+
+	return result
+}
@@ -0,0 +1,49 @@
+package graphql
+
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimiter limits the rate of calls per Access API method.
+// It is implemented by the rate limiter used for the gRPC API, so GraphQL queries are subject to the
+// same per-method limits configured with --api-rate-limits and --api-burst-limits.
+type RateLimiter interface {
+	// Allow returns true if a call to the API method with the given name is within the rate limits.
+	Allow(methodName string) bool
+}
+
+type queryCostKey struct{}
+
+// queryCost tracks the cost of a single GraphQL query, which is the number of Access API calls
+// made to resolve it. Resolvers may be executed concurrently, so the counter is updated atomically.
+type queryCost struct {
+	used atomic.Uint64
+	max  uint64
+}
+
+// withQueryCost returns a context tracking the cost of the query executed with it.
+func withQueryCost(ctx context.Context, maxCost uint64) context.Context {
+	return context.WithValue(ctx, queryCostKey{}, &queryCost{max: maxCost})
+}
+
+// charge accounts for one call to the given Access API method.
+//
+// Expected errors during normal operations:
+//   - codes.ResourceExhausted if the query exceeds its maximum cost, or the method exceeds its rate limit
+func (r *Resolver) charge(ctx context.Context, methodName string) error {
+	if cost, ok := ctx.Value(queryCostKey{}).(*queryCost); ok && cost.max > 0 {
+		if cost.used.Add(1) > cost.max {
+			return status.Errorf(codes.ResourceExhausted, "query exceeds the maximum cost of %d API calls", cost.max)
+		}
+	}
+
+	if r.limiter != nil && !r.limiter.Allow(methodName) {
+		return status.Errorf(codes.ResourceExhausted, "%s rate limit reached, please retry later.", methodName)
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// eventEncoding is the encoding of event payloads returned by the GraphQL API.
+const eventEncoding = entities.EventEncodingVersion_JSON_CDC_V0
+
+// Resolver is the root resolver of the GraphQL schema. All data is loaded through the Access API,
+// and every call to the Access API counts towards the cost of the query.
+type Resolver struct {
+	api     access.API
+	chain   flow.Chain
+	limiter RateLimiter
+}
+
+// NewResolver returns a new root resolver backed by the given Access API.
+// The limiter is optional, if it is nil, calls are only limited by the maximum query cost.
+func NewResolver(api access.API, chain flow.Chain, limiter RateLimiter) *Resolver {
+	return &Resolver{
+		api:     api,
+		chain:   chain,
+		limiter: limiter,
+	}
+}
+
+// LatestBlock resolves the latest finalized or sealed block.
+func (r *Resolver) LatestBlock(ctx context.Context, args struct{ Sealed bool }) (*blockResolver, error) {
+	if err := r.charge(ctx, "GetLatestBlock"); err != nil {
+		return nil, err
+	}
+	block, blockStatus, err := r.api.GetLatestBlock(ctx, args.Sealed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blockResolver{r: r, block: block, status: blockStatus}, nil
+}
+
+// Block resolves the block with the given ID or height.
+func (r *Resolver) Block(ctx context.Context, args struct {
+	ID     *string
+	Height *string
+}) (*blockResolver, error) {
+	if (args.ID == nil) == (args.Height == nil) {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of id and height must be provided")
+	}
+
+	var block *flow.Block
+	var blockStatus flow.BlockStatus
+	if args.ID != nil {
+		id, err := parseID(*args.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.charge(ctx, "GetBlockByID"); err != nil {
+			return nil, err
+		}
+		block, blockStatus, err = r.api.GetBlockByID(ctx, id)
+		if err != nil {
+			return nil, nilIfNotFound(err)
+		}
+	} else {
+		height, err := parseUint64(*args.Height)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.charge(ctx, "GetBlockByHeight"); err != nil {
+			return nil, err
+		}
+		block, blockStatus, err = r.api.GetBlockByHeight(ctx, height)
+		if err != nil {
+			return nil, nilIfNotFound(err)
+		}
+	}
+
+	return &blockResolver{r: r, block: block, status: blockStatus}, nil
+}
+
+// Collection resolves the collection with the given ID.
+func (r *Resolver) Collection(ctx context.Context, args struct{ ID string }) (*collectionResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection := &collectionResolver{r: r, id: id}
+	if _, err := collection.load(ctx); err != nil {
+		return nil, nilIfNotFound(err)
+	}
+
+	return collection, nil
+}
+
+// Transaction resolves the transaction with the given ID.
+func (r *Resolver) Transaction(ctx context.Context, args struct{ ID string }) (*transactionResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.transaction(ctx, id)
+}
+
+// TransactionResult resolves the result of the transaction with the given ID.
+func (r *Resolver) TransactionResult(ctx context.Context, args struct{ ID string }) (*transactionResultResolver, error) {
+	id, err := parseID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.transactionResult(ctx, id)
+}
+
+// Account resolves the account with the given address, at the given height or the latest sealed block.
+func (r *Resolver) Account(ctx context.Context, args struct {
+	Address string
+	Height  *string
+}) (*accountResolver, error) {
+	address := flow.HexToAddress(args.Address)
+	if !r.chain.IsValid(address) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid address for chain: %s", args.Address)
+	}
+
+	var account *flow.Account
+	if args.Height != nil {
+		height, err := parseUint64(*args.Height)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.charge(ctx, "GetAccountAtBlockHeight"); err != nil {
+			return nil, err
+		}
+		account, err = r.api.GetAccountAtBlockHeight(ctx, address, height)
+		if err != nil {
+			return nil, nilIfNotFound(err)
+		}
+	} else {
+		if err := r.charge(ctx, "GetAccountAtLatestBlock"); err != nil {
+			return nil, err
+		}
+		var err error
+		account, err = r.api.GetAccountAtLatestBlock(ctx, address)
+		if err != nil {
+			return nil, nilIfNotFound(err)
+		}
+	}
+
+	return &accountResolver{account: account}, nil
+}
+
+// ExecutionResult resolves the execution result with the given ID, or for the given block.
+func (r *Resolver) ExecutionResult(ctx context.Context, args struct {
+	ID      *string
+	BlockID *string
+}) (*executionResultResolver, error) {
+	if (args.ID == nil) == (args.BlockID == nil) {
+		return nil, status.Error(codes.InvalidArgument, "exactly one of id and blockId must be provided")
+	}
+
+	if args.BlockID != nil {
+		blockID, err := parseID(*args.BlockID)
+		if err != nil {
+			return nil, err
+		}
+		return r.executionResultForBlockID(ctx, blockID)
+	}
+
+	id, err := parseID(*args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.charge(ctx, "GetExecutionResultByID"); err != nil {
+		return nil, err
+	}
+	result, err := r.api.GetExecutionResultByID(ctx, id)
+	if err != nil {
+		return nil, nilIfNotFound(err)
+	}
+
+	return &executionResultResolver{result: result}, nil
+}
+
+// Events resolves the events of the given type within a height range.
+func (r *Resolver) Events(ctx context.Context, args struct {
+	Type        string
+	StartHeight string
+	EndHeight   string
+}) ([]*blockEventsResolver, error) {
+	startHeight, err := parseUint64(args.StartHeight)
+	if err != nil {
+		return nil, err
+	}
+	endHeight, err := parseUint64(args.EndHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.charge(ctx, "GetEventsForHeightRange"); err != nil {
+		return nil, err
+	}
+	blockEvents, err := r.api.GetEventsForHeightRange(ctx, args.Type, startHeight, endHeight, eventEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*blockEventsResolver, len(blockEvents))
+	for i := range blockEvents {
+		resolvers[i] = &blockEventsResolver{blockEvents: blockEvents[i]}
+	}
+	return resolvers, nil
+}
+
+// transaction loads the transaction with the given ID.
+func (r *Resolver) transaction(ctx context.Context, id flow.Identifier) (*transactionResolver, error) {
+	if err := r.charge(ctx, "GetTransaction"); err != nil {
+		return nil, err
+	}
+	tx, err := r.api.GetTransaction(ctx, id)
+	if err != nil {
+		return nil, nilIfNotFound(err)
+	}
+
+	return &transactionResolver{r: r, tx: tx}, nil
+}
+
+// transactionResult loads the result of the transaction with the given ID.
+func (r *Resolver) transactionResult(ctx context.Context, id flow.Identifier) (*transactionResultResolver, error) {
+	if err := r.charge(ctx, "GetTransactionResult"); err != nil {
+		return nil, err
+	}
+	result, err := r.api.GetTransactionResult(ctx, id, flow.ZeroID, flow.ZeroID, eventEncoding)
+	if err != nil {
+		return nil, nilIfNotFound(err)
+	}
+
+	return &transactionResultResolver{result: result}, nil
+}
+
+// executionResultForBlockID loads the execution result for the block with the given ID.
+func (r *Resolver) executionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*executionResultResolver, error) {
+	if err := r.charge(ctx, "GetExecutionResultForBlockID"); err != nil {
+		return nil, err
+	}
+	result, err := r.api.GetExecutionResultForBlockID(ctx, blockID)
+	if err != nil {
+		return nil, nilIfNotFound(err)
+	}
+
+	return &executionResultResolver{result: result}, nil
+}
+
+// nilIfNotFound returns nil if the error is a NotFound error, so that the nullable field resolves to null
+// instead of an error. All other errors are returned unchanged.
+func nilIfNotFound(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
+
+// parseID parses a hex encoded identifier.
+func parseID(raw string) (flow.Identifier, error) {
+	id, err := flow.HexStringToIdentifier(raw)
+	if err != nil {
+		return flow.ZeroID, status.Errorf(codes.InvalidArgument, "invalid ID %q: %v", raw, err)
+	}
+	return id, nil
+}
+
+// parseUint64 parses a decimal encoded 64-bit unsigned integer.
+func parseUint64(raw string) (uint64, error) {
+	value, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid value %q: %v", raw, err)
+	}
+	return value, nil
+}
+
+// formatUint64 formats a 64-bit unsigned integer as decimal string.
+func formatUint64(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	_ "embed"
+	"fmt"
+	"net/http"
+	"time"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/rs/cors"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+//go:embed schema.graphql
+var schema string
+
+const (
+	// DefaultMaxQueryCost is the default maximum number of Access API calls a single query may make.
+	DefaultMaxQueryCost = 500
+
+	// DefaultMaxDepth is the default maximum nesting depth of a query.
+	DefaultMaxDepth = 10
+
+	// DefaultMaxParallelism is the default maximum number of resolvers executed concurrently per query.
+	DefaultMaxParallelism = 10
+
+	// DefaultReadTimeout is the default read timeout for the HTTP server
+	DefaultReadTimeout = time.Second * 15
+
+	// DefaultWriteTimeout is the default write timeout for the HTTP server
+	DefaultWriteTimeout = time.Second * 30
+
+	// DefaultIdleTimeout is the default idle timeout for the HTTP server
+	DefaultIdleTimeout = time.Second * 60
+)
+
+// Config defines the configurable options of the GraphQL server.
+type Config struct {
+	ListenAddress  string
+	WriteTimeout   time.Duration
+	ReadTimeout    time.Duration
+	IdleTimeout    time.Duration
+	MaxQueryCost   uint64 // maximum number of Access API calls per query, 0 means unlimited
+	MaxDepth       int    // maximum nesting depth of a query
+	MaxParallelism int    // maximum number of resolvers executed concurrently per query
+
+	// RateLimiter is the per-method rate limiter of the unsecure gRPC server, which is shared so that calls made
+	// by GraphQL queries count towards the same limits. If nil, calls are not rate limited.
+	RateLimiter RateLimiter
+}
+
+// DefaultConfig returns the default configuration of the GraphQL server. The server is disabled by default.
+func DefaultConfig() Config {
+	return Config{
+		ListenAddress:  "",
+		WriteTimeout:   DefaultWriteTimeout,
+		ReadTimeout:    DefaultReadTimeout,
+		IdleTimeout:    DefaultIdleTimeout,
+		MaxQueryCost:   DefaultMaxQueryCost,
+		MaxDepth:       DefaultMaxDepth,
+		MaxParallelism: DefaultMaxParallelism,
+	}
+}
+
+// NewHandler returns an HTTP handler serving GraphQL queries backed by the given Access API.
+// Every Access API call made to resolve a query counts towards the query cost, and is subject to the
+// per-method rate limits of the limiter.
+//
+// No errors are expected during normal operation.
+func NewHandler(api access.API, config Config, chain flow.Chain, limiter RateLimiter) (http.Handler, error) {
+	parsed, err := graphqlgo.ParseSchema(
+		schema,
+		NewResolver(api, chain, limiter),
+		graphqlgo.MaxDepth(config.MaxDepth),
+		graphqlgo.MaxParallelism(config.MaxParallelism),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse graphql schema: %w", err)
+	}
+
+	handler := &relay.Handler{Schema: parsed}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+			return
+		}
+		ctx := withQueryCost(r.Context(), config.MaxQueryCost)
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	}), nil
+}
+
+// NewServer returns an HTTP server initialized with the GraphQL handler.
+// Access API calls made by the resolvers are rate limited by the configured rate limiter.
+//
+// No errors are expected during normal operation.
+func NewServer(
+	api access.API,
+	config Config,
+	logger zerolog.Logger,
+	chain flow.Chain,
+) (*http.Server, error) {
+	handler, err := NewHandler(api, config, chain, config.RateLimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	c := cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedHeaders: []string{"*"},
+		AllowedMethods: []string{
+			http.MethodPost,
+			http.MethodOptions,
+		},
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", handler)
+
+	logger.Debug().Str("graphql_address", config.ListenAddress).Msg("graphql server initialized")
+
+	return &http.Server{
+		Handler:      c.Handler(mux),
+		Addr:         config.ListenAddress,
+		WriteTimeout: config.WriteTimeout,
+		ReadTimeout:  config.ReadTimeout,
+		IdleTimeout:  config.IdleTimeout,
+	}, nil
+}
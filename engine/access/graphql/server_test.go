@@ -0,0 +1,196 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	accessmock "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type response struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// limiterFunc implements RateLimiter with a function.
+type limiterFunc func(methodName string) bool
+
+func (f limiterFunc) Allow(methodName string) bool {
+	return f(methodName)
+}
+
+func execute(t *testing.T, handler http.Handler, query string, variables map[string]interface{}) response {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(body))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var res response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+	return res
+}
+
+const blockQuery = `
+query($height: String!) {
+  block(height: $height) {
+    id
+    height
+    status
+    collections {
+      id
+      transactions {
+        id
+        payer
+        result { status events { type } }
+      }
+    }
+  }
+}`
+
+func TestGraphQL_Block(t *testing.T) {
+	chain := flow.Testnet.Chain()
+
+	block := unittest.BlockFixture()
+	collection := unittest.CollectionFixture(2)
+	block.Payload.Guarantees = []*flow.CollectionGuarantee{{CollectionID: collection.ID()}}
+	light := collection.Light()
+
+	api := accessmock.NewAPI(t)
+	api.On("GetBlockByHeight", mock.Anything, block.Header.Height).
+		Return(&block, flow.BlockStatusSealed, nil)
+	api.On("GetCollectionByID", mock.Anything, collection.ID()).
+		Return(&light, nil)
+	for _, tx := range collection.Transactions {
+		api.On("GetTransaction", mock.Anything, tx.ID()).Return(tx, nil)
+		api.On("GetTransactionResult", mock.Anything, tx.ID(), flow.ZeroID, flow.ZeroID, eventEncoding).
+			Return(&access.TransactionResult{
+				TransactionID: tx.ID(),
+				Status:        flow.TransactionStatusSealed,
+				Events:        []flow.Event{unittest.EventFixture("flow.AccountCreated", 0, 0, tx.ID(), 0)},
+			}, nil)
+	}
+
+	t.Run("resolves nested block data", func(t *testing.T) {
+		handler, err := NewHandler(api, DefaultConfig(), chain, nil)
+		require.NoError(t, err)
+
+		res := execute(t, handler, blockQuery, map[string]interface{}{
+			"height": formatUint64(block.Header.Height),
+		})
+		require.Empty(t, res.Errors)
+
+		var data struct {
+			Block struct {
+				ID          string
+				Height      string
+				Status      string
+				Collections []struct {
+					ID           string
+					Transactions []struct {
+						ID     string
+						Payer  string
+						Result struct {
+							Status string
+							Events []struct{ Type string }
+						}
+					}
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(res.Data, &data))
+
+		assert.Equal(t, block.ID().String(), data.Block.ID)
+		assert.Equal(t, formatUint64(block.Header.Height), data.Block.Height)
+		assert.Equal(t, flow.BlockStatusSealed.String(), data.Block.Status)
+		require.Len(t, data.Block.Collections, 1)
+		require.Len(t, data.Block.Collections[0].Transactions, 2)
+		for i, tx := range data.Block.Collections[0].Transactions {
+			assert.Equal(t, collection.Transactions[i].ID().String(), tx.ID)
+			assert.Equal(t, collection.Transactions[i].Payer.HexWithPrefix(), tx.Payer)
+			assert.Equal(t, flow.TransactionStatusSealed.String(), tx.Result.Status)
+			require.Len(t, tx.Result.Events, 1)
+			assert.Equal(t, "flow.AccountCreated", tx.Result.Events[0].Type)
+		}
+	})
+
+	t.Run("rejects queries exceeding max cost", func(t *testing.T) {
+		config := DefaultConfig()
+		// block + collection + 2 transactions + 2 results
+		config.MaxQueryCost = 5
+
+		handler, err := NewHandler(api, config, chain, nil)
+		require.NoError(t, err)
+
+		res := execute(t, handler, blockQuery, map[string]interface{}{
+			"height": formatUint64(block.Header.Height),
+		})
+		require.NotEmpty(t, res.Errors)
+		assert.Contains(t, res.Errors[0].Message, "query exceeds the maximum cost of 5 API calls")
+	})
+
+	t.Run("applies method rate limits", func(t *testing.T) {
+		limiter := limiterFunc(func(methodName string) bool {
+			return methodName != "GetTransactionResult"
+		})
+
+		handler, err := NewHandler(api, DefaultConfig(), chain, limiter)
+		require.NoError(t, err)
+
+		res := execute(t, handler, blockQuery, map[string]interface{}{
+			"height": formatUint64(block.Header.Height),
+		})
+		require.NotEmpty(t, res.Errors)
+		assert.Contains(t, res.Errors[0].Message, "GetTransactionResult rate limit reached")
+	})
+}
+
+func TestGraphQL_NotFound(t *testing.T) {
+	api := accessmock.NewAPI(t)
+	id := unittest.IdentifierFixture()
+	api.On("GetTransaction", mock.Anything, id).
+		Return(nil, status.Error(codes.NotFound, "not found"))
+
+	handler, err := NewHandler(api, DefaultConfig(), flow.Testnet.Chain(), nil)
+	require.NoError(t, err)
+
+	res := execute(t, handler, `query($id: String!) { transaction(id: $id) { id } }`, map[string]interface{}{
+		"id": id.String(),
+	})
+	require.Empty(t, res.Errors)
+	assert.JSONEq(t, `{"transaction": null}`, string(res.Data))
+}
+
+func TestGraphQL_InvalidArguments(t *testing.T) {
+	api := accessmock.NewAPI(t)
+
+	handler, err := NewHandler(api, DefaultConfig(), flow.Testnet.Chain(), nil)
+	require.NoError(t, err)
+
+	res := execute(t, handler, `{ block(id: "abc", height: "1") { id } }`, nil)
+	require.NotEmpty(t, res.Errors)
+	assert.Contains(t, res.Errors[0].Message, "exactly one of id and height must be provided")
+
+	res = execute(t, handler, `{ account(address: "0x01") { address } }`, nil)
+	require.NotEmpty(t, res.Errors)
+	assert.Contains(t, res.Errors[0].Message, "invalid address for chain")
+}
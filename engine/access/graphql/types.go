@@ -0,0 +1,455 @@
+package graphql
+
+import (
+	"context"
+	"encoding/hex"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type blockResolver struct {
+	r      *Resolver
+	block  *flow.Block
+	status flow.BlockStatus
+}
+
+func (b *blockResolver) ID() string {
+	return b.block.ID().String()
+}
+
+func (b *blockResolver) ParentID() string {
+	return b.block.Header.ParentID.String()
+}
+
+func (b *blockResolver) Height() string {
+	return formatUint64(b.block.Header.Height)
+}
+
+func (b *blockResolver) Timestamp() string {
+	return b.block.Header.Timestamp.Format(time.RFC3339Nano)
+}
+
+func (b *blockResolver) Status() string {
+	return b.status.String()
+}
+
+func (b *blockResolver) Collections() []*collectionResolver {
+	collections := make([]*collectionResolver, len(b.block.Payload.Guarantees))
+	for i, guarantee := range b.block.Payload.Guarantees {
+		collections[i] = &collectionResolver{r: b.r, id: guarantee.CollectionID}
+	}
+	return collections
+}
+
+func (b *blockResolver) TransactionResults(ctx context.Context) ([]*transactionResultResolver, error) {
+	if err := b.r.charge(ctx, "GetTransactionResultsByBlockID"); err != nil {
+		return nil, err
+	}
+	results, err := b.r.api.GetTransactionResultsByBlockID(ctx, b.block.ID(), eventEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*transactionResultResolver, len(results))
+	for i, result := range results {
+		resolvers[i] = &transactionResultResolver{result: result}
+	}
+	return resolvers, nil
+}
+
+func (b *blockResolver) Events(ctx context.Context, args struct{ Type *string }) ([]*eventResolver, error) {
+	// without a type, collect the events of all transactions of the block
+	if args.Type == nil {
+		results, err := b.TransactionResults(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		events := make([]*eventResolver, 0)
+		for _, result := range results {
+			events = append(events, result.Events()...)
+		}
+		return events, nil
+	}
+
+	if err := b.r.charge(ctx, "GetEventsForBlockIDs"); err != nil {
+		return nil, err
+	}
+	blockEvents, err := b.r.api.GetEventsForBlockIDs(ctx, *args.Type, []flow.Identifier{b.block.ID()}, eventEncoding)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*eventResolver, 0)
+	for _, be := range blockEvents {
+		events = append(events, newEventResolvers(be.Events)...)
+	}
+	return events, nil
+}
+
+func (b *blockResolver) ExecutionResult(ctx context.Context) (*executionResultResolver, error) {
+	return b.r.executionResultForBlockID(ctx, b.block.ID())
+}
+
+// collectionResolver lazily loads the collection, since blocks only reference their collections by ID.
+type collectionResolver struct {
+	r  *Resolver
+	id flow.Identifier
+
+	once       sync.Once
+	collection *flow.LightCollection
+	err        error
+}
+
+func (c *collectionResolver) load(ctx context.Context) (*flow.LightCollection, error) {
+	c.once.Do(func() {
+		if c.err = c.r.charge(ctx, "GetCollectionByID"); c.err != nil {
+			return
+		}
+		c.collection, c.err = c.r.api.GetCollectionByID(ctx, c.id)
+	})
+	return c.collection, c.err
+}
+
+func (c *collectionResolver) ID() string {
+	return c.id.String()
+}
+
+func (c *collectionResolver) TransactionIDs(ctx context.Context) ([]string, error) {
+	collection, err := c.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(collection.Transactions))
+	for i, id := range collection.Transactions {
+		ids[i] = id.String()
+	}
+	return ids, nil
+}
+
+func (c *collectionResolver) Transactions(ctx context.Context) ([]*transactionResolver, error) {
+	collection, err := c.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*transactionResolver, len(collection.Transactions))
+	for i, id := range collection.Transactions {
+		transactions[i], err = c.r.transaction(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return transactions, nil
+}
+
+type transactionResolver struct {
+	r  *Resolver
+	tx *flow.TransactionBody
+}
+
+func (t *transactionResolver) ID() string {
+	return t.tx.ID().String()
+}
+
+func (t *transactionResolver) Script() string {
+	return string(t.tx.Script)
+}
+
+func (t *transactionResolver) Arguments() []string {
+	arguments := make([]string, len(t.tx.Arguments))
+	for i, argument := range t.tx.Arguments {
+		arguments[i] = string(argument)
+	}
+	return arguments
+}
+
+func (t *transactionResolver) ReferenceBlockID() string {
+	return t.tx.ReferenceBlockID.String()
+}
+
+func (t *transactionResolver) GasLimit() string {
+	return formatUint64(t.tx.GasLimit)
+}
+
+func (t *transactionResolver) Payer() string {
+	return t.tx.Payer.HexWithPrefix()
+}
+
+func (t *transactionResolver) ProposalKey() *proposalKeyResolver {
+	return &proposalKeyResolver{key: t.tx.ProposalKey}
+}
+
+func (t *transactionResolver) Authorizers() []string {
+	authorizers := make([]string, len(t.tx.Authorizers))
+	for i, authorizer := range t.tx.Authorizers {
+		authorizers[i] = authorizer.HexWithPrefix()
+	}
+	return authorizers
+}
+
+func (t *transactionResolver) Result(ctx context.Context) (*transactionResultResolver, error) {
+	return t.r.transactionResult(ctx, t.tx.ID())
+}
+
+type proposalKeyResolver struct {
+	key flow.ProposalKey
+}
+
+func (p *proposalKeyResolver) Address() string {
+	return p.key.Address.HexWithPrefix()
+}
+
+func (p *proposalKeyResolver) KeyIndex() int32 {
+	return int32(p.key.KeyIndex)
+}
+
+func (p *proposalKeyResolver) SequenceNumber() string {
+	return formatUint64(p.key.SequenceNumber)
+}
+
+type transactionResultResolver struct {
+	result *access.TransactionResult
+}
+
+func (t *transactionResultResolver) TransactionID() string {
+	return t.result.TransactionID.String()
+}
+
+func (t *transactionResultResolver) BlockID() string {
+	return t.result.BlockID.String()
+}
+
+func (t *transactionResultResolver) BlockHeight() string {
+	return formatUint64(t.result.BlockHeight)
+}
+
+func (t *transactionResultResolver) CollectionID() string {
+	return t.result.CollectionID.String()
+}
+
+func (t *transactionResultResolver) Status() string {
+	return t.result.Status.String()
+}
+
+func (t *transactionResultResolver) StatusCode() int32 {
+	return int32(t.result.StatusCode)
+}
+
+func (t *transactionResultResolver) ErrorMessage() string {
+	return t.result.ErrorMessage
+}
+
+func (t *transactionResultResolver) Events() []*eventResolver {
+	return newEventResolvers(t.result.Events)
+}
+
+type eventResolver struct {
+	event flow.Event
+}
+
+func newEventResolvers(events []flow.Event) []*eventResolver {
+	resolvers := make([]*eventResolver, len(events))
+	for i := range events {
+		resolvers[i] = &eventResolver{event: events[i]}
+	}
+	return resolvers
+}
+
+func (e *eventResolver) Type() string {
+	return string(e.event.Type)
+}
+
+func (e *eventResolver) TransactionID() string {
+	return e.event.TransactionID.String()
+}
+
+func (e *eventResolver) TransactionIndex() int32 {
+	return int32(e.event.TransactionIndex)
+}
+
+func (e *eventResolver) EventIndex() int32 {
+	return int32(e.event.EventIndex)
+}
+
+func (e *eventResolver) Payload() string {
+	return string(e.event.Payload)
+}
+
+type blockEventsResolver struct {
+	blockEvents flow.BlockEvents
+}
+
+func (b *blockEventsResolver) BlockID() string {
+	return b.blockEvents.BlockID.String()
+}
+
+func (b *blockEventsResolver) BlockHeight() string {
+	return formatUint64(b.blockEvents.BlockHeight)
+}
+
+func (b *blockEventsResolver) BlockTimestamp() string {
+	return b.blockEvents.BlockTimestamp.Format(time.RFC3339Nano)
+}
+
+func (b *blockEventsResolver) Events() []*eventResolver {
+	return newEventResolvers(b.blockEvents.Events)
+}
+
+type accountResolver struct {
+	account *flow.Account
+}
+
+func (a *accountResolver) Address() string {
+	return a.account.Address.HexWithPrefix()
+}
+
+func (a *accountResolver) Balance() string {
+	return formatUint64(a.account.Balance)
+}
+
+func (a *accountResolver) Keys() []*accountKeyResolver {
+	keys := make([]*accountKeyResolver, len(a.account.Keys))
+	for i := range a.account.Keys {
+		keys[i] = &accountKeyResolver{key: a.account.Keys[i]}
+	}
+	return keys
+}
+
+func (a *accountResolver) Contracts() []*contractResolver {
+	contracts := make([]*contractResolver, 0, len(a.account.Contracts))
+	for name, code := range a.account.Contracts {
+		contracts = append(contracts, &contractResolver{name: name, code: code})
+	}
+	// sort by name for deterministic output
+	sort.Slice(contracts, func(i, j int) bool {
+		return contracts[i].name < contracts[j].name
+	})
+	return contracts
+}
+
+type accountKeyResolver struct {
+	key flow.AccountPublicKey
+}
+
+func (a *accountKeyResolver) Index() int32 {
+	return int32(a.key.Index)
+}
+
+func (a *accountKeyResolver) PublicKey() string {
+	return a.key.PublicKey.String()
+}
+
+func (a *accountKeyResolver) SigningAlgorithm() string {
+	return a.key.SignAlgo.String()
+}
+
+func (a *accountKeyResolver) HashingAlgorithm() string {
+	return a.key.HashAlgo.String()
+}
+
+func (a *accountKeyResolver) SequenceNumber() string {
+	return formatUint64(a.key.SeqNumber)
+}
+
+func (a *accountKeyResolver) Weight() int32 {
+	return int32(a.key.Weight)
+}
+
+func (a *accountKeyResolver) Revoked() bool {
+	return a.key.Revoked
+}
+
+type contractResolver struct {
+	name string
+	code []byte
+}
+
+func (c *contractResolver) Name() string {
+	return c.name
+}
+
+func (c *contractResolver) Code() string {
+	return string(c.code)
+}
+
+type executionResultResolver struct {
+	result *flow.ExecutionResult
+}
+
+func (e *executionResultResolver) ID() string {
+	return e.result.ID().String()
+}
+
+func (e *executionResultResolver) BlockID() string {
+	return e.result.BlockID.String()
+}
+
+func (e *executionResultResolver) PreviousResultID() string {
+	return e.result.PreviousResultID.String()
+}
+
+func (e *executionResultResolver) ExecutionDataID() string {
+	return e.result.ExecutionDataID.String()
+}
+
+func (e *executionResultResolver) Chunks() []*chunkResolver {
+	chunks := make([]*chunkResolver, len(e.result.Chunks))
+	for i, chunk := range e.result.Chunks {
+		chunks[i] = &chunkResolver{chunk: chunk}
+	}
+	return chunks
+}
+
+func (e *executionResultResolver) ServiceEvents() []*serviceEventResolver {
+	events := make([]*serviceEventResolver, len(e.result.ServiceEvents))
+	for i := range e.result.ServiceEvents {
+		events[i] = &serviceEventResolver{event: e.result.ServiceEvents[i]}
+	}
+	return events
+}
+
+type chunkResolver struct {
+	chunk *flow.Chunk
+}
+
+func (c *chunkResolver) Index() int32 {
+	return int32(c.chunk.Index)
+}
+
+func (c *chunkResolver) CollectionIndex() int32 {
+	return int32(c.chunk.CollectionIndex)
+}
+
+func (c *chunkResolver) StartState() string {
+	return hex.EncodeToString(c.chunk.StartState[:])
+}
+
+func (c *chunkResolver) EndState() string {
+	return hex.EncodeToString(c.chunk.EndState[:])
+}
+
+func (c *chunkResolver) EventCollection() string {
+	return c.chunk.EventCollection.String()
+}
+
+func (c *chunkResolver) NumberOfTransactions() string {
+	return formatUint64(c.chunk.NumberOfTransactions)
+}
+
+func (c *chunkResolver) TotalComputationUsed() string {
+	return formatUint64(c.chunk.TotalComputationUsed)
+}
+
+type serviceEventResolver struct {
+	event flow.ServiceEvent
+}
+
+func (s *serviceEventResolver) Type() string {
+	return s.event.Type.String()
+}
@@ -0,0 +1,58 @@
+package index
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+)
+
+// AccountTransactionsIndex implements a wrapper around `storage.AccountTransactions` ensuring that needed data has been synced and is available to the client.
+// Note: read detail how `Reporter` is working
+type AccountTransactionsIndex struct {
+	*Reporter
+	accountTransactions storage.AccountTransactions
+}
+
+func NewAccountTransactionsIndex(reporter *Reporter, accountTransactions storage.AccountTransactions) *AccountTransactionsIndex {
+	return &AccountTransactionsIndex{
+		Reporter:            reporter,
+		accountTransactions: accountTransactions,
+	}
+}
+
+// ByAddress checks data availability and returns a page of transactions the account participated in
+// within the height range [startHeight, endHeight], starting at the cursor if provided.
+// The index may have been enabled after the node indexed earlier blocks, so heights below the
+// first height indexed by the account-to-transaction index are unavailable as well.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the `AccountTransactionsIndex` has not been initialized
+//   - storage.ErrHeightNotIndexed when data is unavailable for any height of the range
+func (a *AccountTransactionsIndex) ByAddress(
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountTransactionCursor,
+	limit uint32,
+) (*flow.AccountTransactionsPage, error) {
+	if err := a.checkDataAvailability(startHeight); err != nil {
+		return nil, err
+	}
+	if err := a.checkDataAvailability(endHeight); err != nil {
+		return nil, err
+	}
+
+	firstHeight, err := a.accountTransactions.FirstIndexedHeight()
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no block indexed by the account transactions index yet", storage.ErrHeightNotIndexed)
+		}
+		return nil, fmt.Errorf("could not get first height of the account transactions index: %w", err)
+	}
+	if startHeight < firstHeight {
+		return nil, fmt.Errorf("%w: block is before first height %d of the account transactions index", storage.ErrHeightNotIndexed, firstHeight)
+	}
+
+	return a.accountTransactions.ByAddress(address, startHeight, endHeight, cursor, limit)
+}
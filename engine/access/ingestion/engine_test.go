@@ -181,6 +181,7 @@ func (s *Suite) SetupTest() {
 		s.collections,
 		s.blocks,
 		blockTransactions,
+		nil,
 	)
 	require.NoError(s.T(), err)
 }
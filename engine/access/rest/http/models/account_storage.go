@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (a *AccountStorage) Build(storage *flow.AccountStorage) {
+	a.Address = storage.Address.String()
+	a.Used = util.FromUint(storage.StorageUsed)
+	a.Capacity = util.FromUint(storage.StorageCapacity)
+
+	domains := make([]AccountStorageDomain, len(storage.Domains))
+	for i, domain := range storage.Domains {
+		domains[i].Build(domain)
+	}
+	a.Domains = domains
+
+	if storage.NextCursor != nil {
+		a.NextCursor = storage.NextCursor.Encode()
+	}
+}
+
+func (d *AccountStorageDomain) Build(domain flow.AccountStorageDomain) {
+	d.Name = domain.Name
+
+	items := make([]AccountStorageItem, len(domain.Items))
+	for i, item := range domain.Items {
+		items[i] = AccountStorageItem{
+			Key:           item.Key,
+			Type:          item.Type,
+			Size:          util.FromUint(item.Size),
+			SizeTruncated: item.SizeTruncated,
+		}
+	}
+	d.Items = items
+}
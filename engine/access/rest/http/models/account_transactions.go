@@ -0,0 +1,25 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (a *AccountTransaction) Build(entry flow.AccountTransaction) {
+	a.TransactionId = entry.TransactionID.String()
+	a.BlockHeight = util.FromUint(entry.BlockHeight)
+	a.TransactionIndex = util.FromUint(entry.TransactionIndex)
+	a.Roles = entry.Roles.Strings()
+}
+
+func (a *AccountTransactions) Build(page *flow.AccountTransactionsPage) {
+	transactions := make([]AccountTransaction, len(page.Transactions))
+	for i, entry := range page.Transactions {
+		transactions[i].Build(entry)
+	}
+	a.Transactions = transactions
+
+	if page.NextCursor != nil {
+		a.NextCursor = page.NextCursor.Encode()
+	}
+}
@@ -0,0 +1,36 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type AccountStorageItem struct {
+	// Path of the value for path domains, key of the value in its domain otherwise.
+	Key string `json:"key"`
+	// Cadence type ID of the stored value.
+	Type string `json:"type"`
+	// Number of bytes used to store the value, including nested values which are not inlined.
+	Size string `json:"size"`
+	// Whether the size excludes nested values below the inspection depth limit.
+	SizeTruncated bool `json:"size_truncated,omitempty"`
+}
+
+type AccountStorageDomain struct {
+	Name  string               `json:"name"`
+	Items []AccountStorageItem `json:"items"`
+}
+
+type AccountStorage struct {
+	Address string `json:"address"`
+	// Number of bytes used by the account.
+	Used string `json:"used"`
+	// Number of bytes the account is allowed to use.
+	Capacity string                 `json:"capacity"`
+	Domains  []AccountStorageDomain `json:"domains"`
+	// Cursor of the next page, empty if there are no more values.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
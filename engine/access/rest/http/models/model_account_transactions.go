@@ -0,0 +1,22 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type AccountTransaction struct {
+	TransactionId    string   `json:"transaction_id"`
+	BlockHeight      string   `json:"block_height"`
+	TransactionIndex string   `json:"transaction_index"`
+	Roles            []string `json:"roles"`
+}
+
+type AccountTransactions struct {
+	Transactions []AccountTransaction `json:"transactions"`
+	// Opaque cursor to pass to the next request to fetch the next page, empty if there are no more results.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,25 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type RegisterChange struct {
+	// Hex encoded register owner.
+	Owner string `json:"owner"`
+	// Hex encoded register key.
+	Key    string `json:"key"`
+	Height string `json:"height"`
+	// Base64 encoded register value.
+	Value string `json:"value"`
+}
+
+type RegisterChanges struct {
+	Changes []RegisterChange `json:"changes"`
+	// Opaque cursor to pass to the next request to fetch the next page, empty if there are no more results.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,18 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type RegisterWithKey struct {
+	// Hex encoded register owner.
+	Owner string `json:"owner"`
+	// Hex encoded register key.
+	Key string `json:"key"`
+	// Base64 encoded register value, empty if the register is not set.
+	Value string `json:"value"`
+}
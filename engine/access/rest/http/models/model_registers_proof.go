@@ -0,0 +1,18 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type RegistersProof struct {
+	BlockId string `json:"block_id"`
+	// Hex encoded final state commitment of the sealed execution result of the block.
+	StateCommitment string            `json:"state_commitment"`
+	Registers       []RegisterWithKey `json:"registers"`
+	// Base64 encoded batch proof of the register values against the state commitment.
+	Proof string `json:"proof"`
+}
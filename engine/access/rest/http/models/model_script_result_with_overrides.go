@@ -0,0 +1,20 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type ScriptResultWithOverrides struct {
+	// Base64 encoded value returned by the script.
+	Value string `json:"value"`
+	// Always true, the value does not reflect the state of the chain.
+	OverridesApplied bool `json:"overrides_applied"`
+	// Number of registers changed by the overrides.
+	OverriddenRegisters string `json:"overridden_registers"`
+	OverriddenContracts string `json:"overridden_contracts"`
+	OverriddenBalances  string `json:"overridden_balances"`
+}
@@ -0,0 +1,23 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+import "github.com/onflow/flow-go/engine/access/rest/common/models"
+
+type TransactionEstimate struct {
+	// Error message of the transaction execution, empty if the transaction succeeded.
+	ErrorMessage    string `json:"error_message"`
+	ComputationUsed string `json:"computation_used"`
+	// Metered intensity of each computation kind, keyed by computation kind.
+	ComputationIntensities map[string]string `json:"computation_intensities"`
+	MemoryEstimate         string            `json:"memory_estimate"`
+	Events                 []models.Event    `json:"events"`
+	// Fees charged for the transaction, in the smallest FLOW unit.
+	Fees string `json:"fees"`
+}
@@ -0,0 +1,25 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+import (
+	"time"
+)
+
+type TransactionTimelineEvent struct {
+	// Lifecycle stage of the transaction, one of received, forwarded, collected, included, executed or sealed.
+	Stage      string            `json:"stage"`
+	Timestamp  time.Time         `json:"timestamp"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+type TransactionTimeline struct {
+	TransactionId string                     `json:"transaction_id"`
+	Events        []TransactionTimelineEvent `json:"events"`
+}
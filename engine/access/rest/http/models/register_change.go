@@ -0,0 +1,27 @@
+package models
+
+import (
+	"encoding/hex"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (c *RegisterChange) Build(change flow.RegisterChange) {
+	c.Owner = hex.EncodeToString([]byte(change.Key.Owner))
+	c.Key = hex.EncodeToString([]byte(change.Key.Key))
+	c.Height = util.FromUint(change.Height)
+	c.Value = util.ToBase64(change.Value)
+}
+
+func (c *RegisterChanges) Build(page *flow.RegisterHistoryPage) {
+	changes := make([]RegisterChange, len(page.Changes))
+	for i, change := range page.Changes {
+		changes[i].Build(change)
+	}
+	c.Changes = changes
+
+	if page.NextCursor != nil {
+		c.NextCursor = page.NextCursor.Encode()
+	}
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"encoding/hex"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (p *RegistersProof) Build(proof *flow.RegistersProof) {
+	p.BlockId = proof.BlockID.String()
+	p.StateCommitment = hex.EncodeToString(proof.StateCommitment[:])
+	p.Registers = make([]RegisterWithKey, len(proof.Registers))
+	for i, register := range proof.Registers {
+		p.Registers[i] = RegisterWithKey{
+			Owner: hex.EncodeToString([]byte(register.Key.Owner)),
+			Key:   hex.EncodeToString([]byte(register.Key.Key)),
+			Value: util.ToBase64(register.Value),
+		}
+	}
+	p.Proof = util.ToBase64(proof.Proof)
+}
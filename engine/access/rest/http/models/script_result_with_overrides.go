@@ -0,0 +1,14 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (s *ScriptResultWithOverrides) Build(result *flow.ScriptResultWithOverrides) {
+	s.Value = util.ToBase64(result.Value)
+	s.OverridesApplied = true
+	s.OverriddenRegisters = util.FromUint(uint64(result.OverriddenRegisters))
+	s.OverriddenContracts = util.FromUint(uint64(result.OverriddenContracts))
+	s.OverriddenBalances = util.FromUint(uint64(result.OverriddenBalances))
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (t *TransactionEstimate) Build(estimate *flow.TransactionEstimate) {
+	t.ErrorMessage = estimate.ErrorMessage
+	t.ComputationUsed = util.FromUint(estimate.ComputationUsed)
+	t.MemoryEstimate = util.FromUint(estimate.MemoryEstimate)
+	t.Fees = util.FromUint(estimate.Fees)
+
+	t.ComputationIntensities = make(map[string]string, len(estimate.ComputationIntensities))
+	for kind, intensity := range estimate.ComputationIntensities {
+		t.ComputationIntensities[util.FromUint(kind)] = util.FromUint(intensity)
+	}
+
+	var events commonmodels.Events
+	events.Build(estimate.Events)
+	t.Events = events
+}
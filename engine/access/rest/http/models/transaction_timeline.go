@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func (t *TransactionTimelineEvent) Build(event flow.TransactionTimelineEvent) {
+	t.Stage = string(event.Stage)
+	t.Timestamp = event.Timestamp
+	t.Attributes = event.Attributes
+}
+
+func (t *TransactionTimeline) Build(timeline *flow.TransactionTimeline) {
+	t.TransactionId = timeline.TransactionID.String()
+
+	events := make([]TransactionTimelineEvent, len(timeline.Events))
+	for i, event := range timeline.Events {
+		events[i].Build(event)
+	}
+	t.Events = events
+}
@@ -0,0 +1,35 @@
+package request
+
+import (
+	"io"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EstimateTransaction is the request to dry-run a transaction. Signatures are optional, since they
+// are not verified when the transaction is estimated.
+type EstimateTransaction struct {
+	Transaction flow.TransactionBody
+}
+
+func EstimateTransactionRequest(r *common.Request) (EstimateTransaction, error) {
+	var req EstimateTransaction
+	err := req.Build(r)
+	return req, err
+}
+
+func (e *EstimateTransaction) Build(r *common.Request) error {
+	return e.Parse(r.Body, r.Chain)
+}
+
+func (e *EstimateTransaction) Parse(rawTransaction io.Reader, chain flow.Chain) error {
+	var tx Transaction
+	err := tx.ParseUnsigned(rawTransaction, chain)
+	if err != nil {
+		return err
+	}
+
+	e.Transaction = tx.Flow()
+	return nil
+}
@@ -0,0 +1,146 @@
+package request
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type registerOverrideBody struct {
+	Owner string `json:"owner"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type contractOverrideBody struct {
+	Address string `json:"address"`
+	Name    string `json:"name"`
+	Code    string `json:"code"`
+}
+
+type balanceOverrideBody struct {
+	Address string `json:"address"`
+	Balance string `json:"balance"`
+}
+
+type stateOverridesBody struct {
+	Registers []registerOverrideBody `json:"registers,omitempty"`
+	Contracts []contractOverrideBody `json:"contracts,omitempty"`
+	Balances  []balanceOverrideBody  `json:"balances,omitempty"`
+}
+
+type scriptWithOverridesBody struct {
+	scriptBody
+	Overrides stateOverridesBody `json:"overrides"`
+}
+
+// ExecuteScriptWithOverrides is the request to execute a script with state overrides applied on top of
+// the execution state of a block.
+type ExecuteScriptWithOverrides struct {
+	BlockHeight uint64
+	Script      Script
+	Overrides   flow.StateOverrides
+}
+
+// ExecuteScriptWithOverridesRequest extracts necessary variables from the provided request,
+// builds a ExecuteScriptWithOverrides instance, and validates it.
+//
+// No errors are expected during normal operation.
+func ExecuteScriptWithOverridesRequest(r *common.Request) (ExecuteScriptWithOverrides, error) {
+	var req ExecuteScriptWithOverrides
+	err := req.Build(r)
+	return req, err
+}
+
+func (e *ExecuteScriptWithOverrides) Build(r *common.Request) error {
+	return e.Parse(
+		r.GetQueryParam(blockHeightQuery),
+		r.Body,
+		r.Chain,
+	)
+}
+
+func (e *ExecuteScriptWithOverrides) Parse(rawHeight string, rawBody io.Reader, chain flow.Chain) error {
+	var height Height
+	err := height.Parse(rawHeight)
+	if err != nil {
+		return err
+	}
+	e.BlockHeight = height.Flow()
+
+	// default to last sealed block
+	if e.BlockHeight == EmptyHeight {
+		e.BlockHeight = SealedHeight
+	}
+
+	var body scriptWithOverridesBody
+	err = parseBody(rawBody, &body)
+	if err != nil {
+		return err
+	}
+
+	err = e.Script.build(body.scriptBody)
+	if err != nil {
+		return err
+	}
+
+	e.Overrides = flow.StateOverrides{}
+	for i, register := range body.Overrides.Registers {
+		owner, err := hex.DecodeString(register.Owner)
+		if err != nil {
+			return fmt.Errorf("invalid register override %d: owner must be hex encoded", i)
+		}
+		key, err := hex.DecodeString(register.Key)
+		if err != nil || len(key) == 0 {
+			return fmt.Errorf("invalid register override %d: key must be hex encoded and not empty", i)
+		}
+		value, err := util.FromBase64(register.Value)
+		if err != nil {
+			return fmt.Errorf("invalid register override %d: value must be base64 encoded", i)
+		}
+		e.Overrides.Registers = append(e.Overrides.Registers, flow.RegisterEntry{
+			Key:   flow.RegisterID{Owner: string(owner), Key: string(key)},
+			Value: value,
+		})
+	}
+
+	for i, contract := range body.Overrides.Contracts {
+		address, err := ParseAddress(contract.Address, chain)
+		if err != nil {
+			return fmt.Errorf("invalid contract override %d: %w", i, err)
+		}
+		if contract.Name == "" {
+			return fmt.Errorf("invalid contract override %d: name must not be empty", i)
+		}
+		code, err := util.FromBase64(contract.Code)
+		if err != nil || len(code) == 0 {
+			return fmt.Errorf("invalid contract override %d: code must be base64 encoded and not empty", i)
+		}
+		e.Overrides.Contracts = append(e.Overrides.Contracts, flow.ContractOverride{
+			Address: address,
+			Name:    contract.Name,
+			Code:    code,
+		})
+	}
+
+	for i, balance := range body.Overrides.Balances {
+		address, err := ParseAddress(balance.Address, chain)
+		if err != nil {
+			return fmt.Errorf("invalid balance override %d: %w", i, err)
+		}
+		value, err := util.ToUint64(balance.Balance)
+		if err != nil {
+			return fmt.Errorf("invalid balance override %d: %w", i, err)
+		}
+		e.Overrides.Balances = append(e.Overrides.Balances, flow.BalanceOverride{
+			Address: address,
+			Balance: value,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package request
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const registerKeyQuery = "key"
+
+type GetAccountRegisterHistory struct {
+	Address flow.Address
+	// Key is the key of the requested register, or nil if the history of all registers of the account is requested.
+	Key         *string
+	StartHeight uint64
+	EndHeight   uint64
+	Limit       uint32
+	Cursor      *flow.RegisterHistoryCursor
+}
+
+// GetAccountRegisterHistoryRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetAccountRegisterHistory instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetAccountRegisterHistoryRequest(r *common.Request) (GetAccountRegisterHistory, error) {
+	var req GetAccountRegisterHistory
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetAccountRegisterHistory) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParam(registerKeyQuery),
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(endHeightQuery),
+		r.GetQueryParam(limitQuery),
+		r.GetQueryParam(cursorQuery),
+		r.Chain,
+	)
+}
+
+func (g *GetAccountRegisterHistory) Parse(
+	rawAddress string,
+	rawKey string,
+	rawStart string,
+	rawEnd string,
+	rawLimit string,
+	rawCursor string,
+	chain flow.Chain,
+) error {
+	address, err := ParseAddress(rawAddress, chain)
+	if err != nil {
+		return err
+	}
+	g.Address = address
+
+	g.Key = nil
+	if rawKey != "" {
+		key, err := hex.DecodeString(rawKey)
+		if err != nil {
+			return fmt.Errorf("invalid register key: must be hex encoded")
+		}
+		keyString := string(key)
+		g.Key = &keyString
+	}
+
+	var height Height
+	err = height.Parse(rawStart)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	g.StartHeight = height.Flow()
+
+	err = height.Parse(rawEnd)
+	if err != nil {
+		return fmt.Errorf("invalid end height: %w", err)
+	}
+	g.EndHeight = height.Flow()
+
+	if g.StartHeight == EmptyHeight || g.EndHeight == EmptyHeight {
+		return fmt.Errorf("must provide start and end height range")
+	}
+
+	if g.StartHeight == FinalHeight || g.StartHeight == SealedHeight {
+		return fmt.Errorf("start height must be a block height")
+	}
+
+	// the special end height values are resolved by the handler
+	if g.EndHeight != FinalHeight && g.EndHeight != SealedHeight && g.StartHeight > g.EndHeight {
+		return fmt.Errorf("start height must be less than or equal to end height")
+	}
+
+	if rawLimit != "" {
+		limit, err := util.ToUint32(rawLimit)
+		if err != nil {
+			return fmt.Errorf("invalid limit: %w", err)
+		}
+		g.Limit = limit
+	}
+
+	g.Cursor = nil
+	if rawCursor != "" {
+		cursor, err := flow.DecodeRegisterHistoryCursor(rawCursor)
+		if err != nil {
+			return err
+		}
+		g.Cursor = &cursor
+	}
+
+	return nil
+}
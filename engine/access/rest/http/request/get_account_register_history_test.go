@@ -0,0 +1,68 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func Test_GetAccountRegisterHistory_InvalidParse(t *testing.T) {
+	var getHistory GetAccountRegisterHistory
+
+	tests := []struct {
+		address string
+		key     string
+		start   string
+		end     string
+		limit   string
+		cursor  string
+		err     string
+	}{
+		{"", "", "1", "10", "", "", "invalid address"},
+		{"f8d6e0586b0a20c7", "zz", "1", "10", "", "", "invalid register key: must be hex encoded"},
+		{"f8d6e0586b0a20c7", "", "-1", "10", "", "", "invalid start height: invalid height format"},
+		{"f8d6e0586b0a20c7", "", "1", "-1", "", "", "invalid end height: invalid height format"},
+		{"f8d6e0586b0a20c7", "", "", "10", "", "", "must provide start and end height range"},
+		{"f8d6e0586b0a20c7", "", "1", "", "", "", "must provide start and end height range"},
+		{"f8d6e0586b0a20c7", "", sealed, "10", "", "", "start height must be a block height"},
+		{"f8d6e0586b0a20c7", "", "10", "1", "", "", "start height must be less than or equal to end height"},
+		{"f8d6e0586b0a20c7", "", "1", "10", "-1", "", "invalid limit: value must be an unsigned 32 bit integer"},
+		{"f8d6e0586b0a20c7", "", "1", "10", "", "zz", "invalid cursor encoding: encoding/hex: invalid byte: U+007A 'z'"},
+		{"f8d6e0586b0a20c7", "", "1", "10", "", "00", "invalid cursor length 1, expected more than 8"},
+	}
+
+	chain := flow.Localnet.Chain()
+	for i, test := range tests {
+		err := getHistory.Parse(test.address, test.key, test.start, test.end, test.limit, test.cursor, chain)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func Test_GetAccountRegisterHistory_ValidParse(t *testing.T) {
+	var getHistory GetAccountRegisterHistory
+
+	addr := "f8d6e0586b0a20c7"
+	chain := flow.Localnet.Chain()
+
+	err := getHistory.Parse(addr, "", "1", "10", "", "", chain)
+	require.NoError(t, err)
+	assert.Equal(t, addr, getHistory.Address.String())
+	assert.Nil(t, getHistory.Key)
+	assert.Equal(t, uint64(1), getHistory.StartHeight)
+	assert.Equal(t, uint64(10), getHistory.EndHeight)
+	assert.Equal(t, uint32(0), getHistory.Limit)
+	assert.Nil(t, getHistory.Cursor)
+
+	cursor := flow.RegisterHistoryCursor{Key: "balance", Height: 5}
+	err = getHistory.Parse(addr, "62616c616e6365", "1", sealed, "50", cursor.Encode(), chain)
+	require.NoError(t, err)
+	require.NotNil(t, getHistory.Key)
+	assert.Equal(t, "balance", *getHistory.Key)
+	assert.Equal(t, SealedHeight, getHistory.EndHeight)
+	assert.Equal(t, uint32(50), getHistory.Limit)
+	assert.Equal(t, &cursor, getHistory.Cursor)
+}
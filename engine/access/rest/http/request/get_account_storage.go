@@ -0,0 +1,81 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type GetAccountStorage struct {
+	Address flow.Address
+	Height  uint64
+	Limit   uint32
+	Cursor  *flow.AccountStorageCursor
+}
+
+// GetAccountStorageRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetAccountStorage instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetAccountStorageRequest(r *common.Request) (GetAccountStorage, error) {
+	var req GetAccountStorage
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetAccountStorage) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParam(blockHeightQuery),
+		r.GetQueryParam(limitQuery),
+		r.GetQueryParam(cursorQuery),
+		r.Chain,
+	)
+}
+
+func (g *GetAccountStorage) Parse(
+	rawAddress string,
+	rawHeight string,
+	rawLimit string,
+	rawCursor string,
+	chain flow.Chain,
+) error {
+	address, err := ParseAddress(rawAddress, chain)
+	if err != nil {
+		return err
+	}
+
+	var height Height
+	err = height.Parse(rawHeight)
+	if err != nil {
+		return err
+	}
+
+	g.Address = address
+	g.Height = height.Flow()
+
+	// default to last block
+	if g.Height == EmptyHeight {
+		g.Height = SealedHeight
+	}
+
+	if rawLimit != "" {
+		limit, err := util.ToUint32(rawLimit)
+		if err != nil {
+			return fmt.Errorf("invalid limit: %w", err)
+		}
+		g.Limit = limit
+	}
+
+	if rawCursor != "" {
+		cursor, err := flow.DecodeAccountStorageCursor(rawCursor)
+		if err != nil {
+			return err
+		}
+		g.Cursor = &cursor
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func Test_GetAccountStorage_InvalidParse(t *testing.T) {
+	var getAccountStorage GetAccountStorage
+
+	tests := []struct {
+		address string
+		height  string
+		limit   string
+		cursor  string
+		err     string
+	}{
+		{"", "", "", "", "invalid address"},
+		{"f8d6e0586b0a20c7", "-1", "", "", "invalid height format"},
+		{"f8d6e0586b0a20c7", "", "-1", "", "invalid limit: value must be an unsigned 32 bit integer"},
+		{"f8d6e0586b0a20c7", "", "", "00", "invalid cursor length 1, expected more than 8"},
+	}
+
+	chain := flow.Localnet.Chain()
+	for i, test := range tests {
+		err := getAccountStorage.Parse(test.address, test.height, test.limit, test.cursor, chain)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func Test_GetAccountStorage_ValidParse(t *testing.T) {
+
+	var getAccountStorage GetAccountStorage
+
+	addr := "f8d6e0586b0a20c7"
+	chain := flow.Localnet.Chain()
+	err := getAccountStorage.Parse(addr, "", "", "", chain)
+	assert.NoError(t, err)
+	assert.Equal(t, getAccountStorage.Address.String(), addr)
+	assert.Equal(t, getAccountStorage.Height, SealedHeight)
+
+	err = getAccountStorage.Parse(addr, "100", "", "", chain)
+	assert.NoError(t, err)
+	assert.Equal(t, getAccountStorage.Height, uint64(100))
+
+	err = getAccountStorage.Parse(addr, sealed, "", "", chain)
+	assert.NoError(t, err)
+	assert.Equal(t, getAccountStorage.Height, SealedHeight)
+
+	err = getAccountStorage.Parse(addr, final, "", "", chain)
+	assert.NoError(t, err)
+	assert.Equal(t, getAccountStorage.Height, FinalHeight)
+
+	cursor := flow.AccountStorageCursor{Domain: "storage", Index: 42}
+	err = getAccountStorage.Parse(addr, "100", "10", cursor.Encode(), chain)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), getAccountStorage.Limit)
+	assert.Equal(t, &cursor, getAccountStorage.Cursor)
+}
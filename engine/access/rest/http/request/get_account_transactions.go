@@ -0,0 +1,100 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const limitQuery = "limit"
+const cursorQuery = "cursor"
+
+type GetAccountTransactions struct {
+	Address     flow.Address
+	StartHeight uint64
+	EndHeight   uint64
+	Limit       uint32
+	Cursor      *flow.AccountTransactionCursor
+}
+
+// GetAccountTransactionsRequest extracts necessary variables and query parameters from the provided request,
+// builds a GetAccountTransactions instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetAccountTransactionsRequest(r *common.Request) (GetAccountTransactions, error) {
+	var req GetAccountTransactions
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetAccountTransactions) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(addressVar),
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(endHeightQuery),
+		r.GetQueryParam(limitQuery),
+		r.GetQueryParam(cursorQuery),
+		r.Chain,
+	)
+}
+
+func (g *GetAccountTransactions) Parse(
+	rawAddress string,
+	rawStart string,
+	rawEnd string,
+	rawLimit string,
+	rawCursor string,
+	chain flow.Chain,
+) error {
+	address, err := ParseAddress(rawAddress, chain)
+	if err != nil {
+		return err
+	}
+	g.Address = address
+
+	var height Height
+	err = height.Parse(rawStart)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	g.StartHeight = height.Flow()
+	err = height.Parse(rawEnd)
+	if err != nil {
+		return fmt.Errorf("invalid end height: %w", err)
+	}
+	g.EndHeight = height.Flow()
+
+	// default to the full indexed range up to the last sealed block
+	if g.StartHeight == EmptyHeight {
+		g.StartHeight = 0
+	}
+	if g.EndHeight == EmptyHeight {
+		g.EndHeight = SealedHeight
+	}
+	if g.StartHeight == FinalHeight || g.StartHeight == SealedHeight {
+		return fmt.Errorf("start height must be a block height")
+	}
+	if g.EndHeight != FinalHeight && g.EndHeight != SealedHeight && g.StartHeight > g.EndHeight {
+		return fmt.Errorf("start height must be less than or equal to end height")
+	}
+
+	if rawLimit != "" {
+		limit, err := util.ToUint32(rawLimit)
+		if err != nil {
+			return fmt.Errorf("invalid limit: %w", err)
+		}
+		g.Limit = limit
+	}
+
+	if rawCursor != "" {
+		cursor, err := flow.DecodeAccountTransactionCursor(rawCursor)
+		if err != nil {
+			return err
+		}
+		g.Cursor = &cursor
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func Test_GetAccountTransactions_InvalidParse(t *testing.T) {
+	var getAccountTransactions GetAccountTransactions
+
+	tests := []struct {
+		address string
+		start   string
+		end     string
+		limit   string
+		cursor  string
+		err     string
+	}{
+		{"", "", "", "", "", "invalid address"},
+		{"f8d6e0586b0a20c7", "-1", "", "", "", "invalid start height: invalid height format"},
+		{"f8d6e0586b0a20c7", "", "foo", "", "", "invalid end height: invalid height format"},
+		{"f8d6e0586b0a20c7", "sealed", "", "", "", "start height must be a block height"},
+		{"f8d6e0586b0a20c7", "10", "5", "", "", "start height must be less than or equal to end height"},
+		{"f8d6e0586b0a20c7", "", "", "-1", "", "invalid limit: value must be an unsigned 32 bit integer"},
+		{"f8d6e0586b0a20c7", "", "", "", "00", "invalid cursor length 1, expected 12"},
+	}
+
+	chain := flow.Localnet.Chain()
+	for i, test := range tests {
+		err := getAccountTransactions.Parse(test.address, test.start, test.end, test.limit, test.cursor, chain)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func Test_GetAccountTransactions_ValidParse(t *testing.T) {
+	var getAccountTransactions GetAccountTransactions
+
+	addr := "f8d6e0586b0a20c7"
+	chain := flow.Localnet.Chain()
+
+	err := getAccountTransactions.Parse(addr, "", "", "", "", chain)
+	require.NoError(t, err)
+	assert.Equal(t, addr, getAccountTransactions.Address.String())
+	assert.Equal(t, uint64(0), getAccountTransactions.StartHeight)
+	assert.Equal(t, SealedHeight, getAccountTransactions.EndHeight)
+	assert.Equal(t, uint32(0), getAccountTransactions.Limit)
+	assert.Nil(t, getAccountTransactions.Cursor)
+
+	cursor := flow.AccountTransactionCursor{BlockHeight: 15, TransactionIndex: 3}
+	err = getAccountTransactions.Parse(addr, "10", "20", "50", cursor.Encode(), chain)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), getAccountTransactions.StartHeight)
+	assert.Equal(t, uint64(20), getAccountTransactions.EndHeight)
+	assert.Equal(t, uint32(50), getAccountTransactions.Limit)
+	assert.Equal(t, &cursor, getAccountTransactions.Cursor)
+}
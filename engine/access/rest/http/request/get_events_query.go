@@ -0,0 +1,141 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/common/parser"
+	"github.com/onflow/flow-go/model/events"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const addressesQuery = "addresses"
+const contractsQuery = "contracts"
+const fieldsQuery = "fields"
+
+// fieldPredicateSeparator separates the field name, operator and value of a field predicate,
+// e.g. `to:eq:0x1654653399040a61`.
+const fieldPredicateSeparator = ":"
+
+// fieldValuesSeparator separates the values of a membership predicate, e.g. `to:in:0x01|0x02`.
+const fieldValuesSeparator = "|"
+
+type GetEventsQuery struct {
+	StartHeight uint64
+	EndHeight   uint64
+	Query       *events.Query
+}
+
+// IsEventsQuery returns true if the events request uses any of the query parameters only supported by
+// event queries, i.e. more than one event type, addresses, contracts or field predicates.
+func IsEventsQuery(r *common.Request) bool {
+	return len(r.GetQueryParams(eventTypeQuery)) > 1 ||
+		r.GetQueryParam(addressesQuery) != "" ||
+		r.GetQueryParam(contractsQuery) != "" ||
+		r.GetQueryParam(fieldsQuery) != ""
+}
+
+// GetEventsQueryRequest extracts necessary variables from the provided request,
+// builds a GetEventsQuery instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetEventsQueryRequest(r *common.Request) (GetEventsQuery, error) {
+	var req GetEventsQuery
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetEventsQuery) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetQueryParams(eventTypeQuery),
+		r.GetQueryParams(addressesQuery),
+		r.GetQueryParams(contractsQuery),
+		r.GetQueryParams(fieldsQuery),
+		r.GetQueryParam(startHeightQuery),
+		r.GetQueryParam(endHeightQuery),
+		r.GetQueryParams(blockQuery),
+		r.Chain,
+	)
+}
+
+func (g *GetEventsQuery) Parse(
+	rawTypes []string,
+	rawAddresses []string,
+	rawContracts []string,
+	rawFields []string,
+	rawStart string,
+	rawEnd string,
+	rawBlockIDs []string,
+	chain flow.Chain,
+) error {
+	if len(rawBlockIDs) > 0 {
+		return fmt.Errorf("event queries only support start and end height range")
+	}
+
+	var height Height
+	err := height.Parse(rawStart)
+	if err != nil {
+		return fmt.Errorf("invalid start height: %w", err)
+	}
+	g.StartHeight = height.Flow()
+	err = height.Parse(rawEnd)
+	if err != nil {
+		return fmt.Errorf("invalid end height: %w", err)
+	}
+	g.EndHeight = height.Flow()
+
+	if g.StartHeight == EmptyHeight || g.EndHeight == EmptyHeight {
+		return fmt.Errorf("must provide start and end height range")
+	}
+	if g.StartHeight == FinalHeight || g.StartHeight == SealedHeight {
+		return fmt.Errorf("start height must be a block height")
+	}
+	if g.EndHeight != FinalHeight && g.EndHeight != SealedHeight && g.StartHeight > g.EndHeight {
+		return fmt.Errorf("start height must be less than or equal to end height")
+	}
+
+	var eventTypes parser.EventTypes
+	err = eventTypes.Parse(rawTypes)
+	if err != nil {
+		return fmt.Errorf("invalid event type: %w", err)
+	}
+
+	predicates := make([]events.FieldPredicate, len(rawFields))
+	for i, raw := range rawFields {
+		predicate, err := parseFieldPredicate(raw)
+		if err != nil {
+			return err
+		}
+		predicates[i] = predicate
+	}
+
+	query, err := events.NewQuery(chain, eventTypes.Flow(), rawAddresses, rawContracts, predicates)
+	if err != nil {
+		return err
+	}
+	g.Query = query
+
+	return nil
+}
+
+// parseFieldPredicate parses a field predicate in the format `<field>:<operator>:<value>`, where values
+// of membership predicates are separated by `|`.
+func parseFieldPredicate(raw string) (events.FieldPredicate, error) {
+	parts := strings.SplitN(raw, fieldPredicateSeparator, 3)
+	if len(parts) != 3 {
+		return events.FieldPredicate{}, fmt.Errorf("invalid field predicate %q, expected format <field>:<operator>:<value>", raw)
+	}
+
+	operator := events.FieldOperator(parts[1])
+	values := []string{parts[2]}
+	if operator == events.FieldOperatorIn {
+		values = strings.Split(parts[2], fieldValuesSeparator)
+	}
+
+	return events.FieldPredicate{
+		Field:    parts[0],
+		Operator: operator,
+		Values:   values,
+	}, nil
+}
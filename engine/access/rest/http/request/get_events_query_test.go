@@ -0,0 +1,71 @@
+package request
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/events"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestGetEventsQuery_InvalidParse(t *testing.T) {
+	var getEvents GetEventsQuery
+	chain := flow.Testnet.Chain()
+
+	tests := []struct {
+		types  []string
+		fields []string
+		start  string
+		end    string
+		ids    []string
+		err    string
+	}{
+		{nil, nil, "5", "10", []string{"7bc42fe85d32ca513769a74f97f7e1a7bad6c9407f0d934c2aa645ef9cf613c7"}, "event queries only support start and end height range"},
+		{nil, nil, "", "10", nil, "must provide start and end height range"},
+		{nil, nil, "sealed", "10", nil, "start height must be a block height"},
+		{nil, nil, "20", "10", nil, "start height must be less than or equal to end height"},
+		{[]string{"foo"}, nil, "5", "10", nil, "invalid event type: error at index 0: invalid event type format"},
+		{nil, []string{"amount"}, "5", "10", nil, `invalid field predicate "amount", expected format <field>:<operator>:<value>`},
+		{nil, []string{"amount:like:1"}, "5", "10", nil, `field predicate 0: unsupported operator "like"`},
+		{nil, []string{"amount:gte:abc"}, "5", "10", nil, `field predicate 0: value "abc" is not a number`},
+	}
+
+	for i, test := range tests {
+		err := getEvents.Parse(test.types, nil, nil, test.fields, test.start, test.end, test.ids, chain)
+		assert.EqualError(t, err, test.err, fmt.Sprintf("test #%d failed", i))
+	}
+}
+
+func TestGetEventsQuery_ValidParse(t *testing.T) {
+	var getEvents GetEventsQuery
+	chain := flow.Testnet.Chain()
+	service := chain.ServiceAddress()
+
+	deposited := fmt.Sprintf("A.%s.FlowToken.TokensDeposited", service.Hex())
+	withdrawn := fmt.Sprintf("A.%s.FlowToken.TokensWithdrawn", service.Hex())
+
+	err := getEvents.Parse(
+		[]string{deposited, withdrawn},
+		[]string{service.Hex()},
+		[]string{fmt.Sprintf("A.%s.FlowToken", service.Hex())},
+		[]string{"to:in:0x01|0x02", "amount:gte:10.5"},
+		"5",
+		"sealed",
+		nil,
+		chain,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(5), getEvents.StartHeight)
+	assert.Equal(t, SealedHeight, getEvents.EndHeight)
+	assert.Len(t, getEvents.Query.EventTypes, 2)
+	assert.Len(t, getEvents.Query.Addresses, 1)
+	assert.Len(t, getEvents.Query.Contracts, 1)
+	assert.Equal(t, []events.FieldPredicate{
+		{Field: "to", Operator: events.FieldOperatorIn, Values: []string{"0x01", "0x02"}},
+		{Field: "amount", Operator: events.FieldOperatorGreaterOrEqual, Values: []string{"10.5"}},
+	}, getEvents.Query.FieldPredicates)
+}
@@ -0,0 +1,82 @@
+package request
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/common/parser"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+type registerIDBody struct {
+	Owner string `json:"owner"`
+	Key   string `json:"key"`
+}
+
+type registersWithProofBody struct {
+	Registers []registerIDBody `json:"registers"`
+}
+
+// GetRegistersWithProof is the request for the values of registers at the end of a sealed block,
+// together with a proof of the values.
+type GetRegistersWithProof struct {
+	BlockID     flow.Identifier
+	RegisterIDs flow.RegisterIDs
+}
+
+// GetRegistersWithProofRequest extracts necessary variables from the provided request,
+// builds a GetRegistersWithProof instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetRegistersWithProofRequest(r *common.Request) (GetRegistersWithProof, error) {
+	var req GetRegistersWithProof
+	err := req.Build(r)
+	return req, err
+}
+
+func (g *GetRegistersWithProof) Build(r *common.Request) error {
+	return g.Parse(
+		r.GetVar(idQuery),
+		r.Body,
+	)
+}
+
+func (g *GetRegistersWithProof) Parse(rawID string, rawBody io.Reader) error {
+	var id parser.ID
+	err := id.Parse(rawID)
+	if err != nil {
+		return err
+	}
+	g.BlockID = id.Flow()
+
+	if g.BlockID == flow.ZeroID {
+		return fmt.Errorf("block ID must be provided")
+	}
+
+	var body registersWithProofBody
+	err = parseBody(rawBody, &body)
+	if err != nil {
+		return err
+	}
+
+	if len(body.Registers) == 0 {
+		return fmt.Errorf("no registers provided")
+	}
+
+	g.RegisterIDs = make(flow.RegisterIDs, len(body.Registers))
+	for i, register := range body.Registers {
+		owner, err := hex.DecodeString(register.Owner)
+		if err != nil || (len(owner) != 0 && len(owner) != flow.AddressLength) {
+			return fmt.Errorf("invalid register %d: owner must be empty or a hex encoded address", i)
+		}
+		key, err := hex.DecodeString(register.Key)
+		if err != nil || len(key) == 0 {
+			return fmt.Errorf("invalid register %d: key must be hex encoded and not empty", i)
+		}
+		g.RegisterIDs[i] = flow.RegisterID{Owner: string(owner), Key: string(key)}
+	}
+
+	return nil
+}
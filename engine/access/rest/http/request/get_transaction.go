@@ -86,3 +86,17 @@ func (g *GetTransactionResult) Build(r *common.Request) error {
 
 	return err
 }
+
+type GetTransactionTimeline struct {
+	GetByIDRequest
+}
+
+// GetTransactionTimelineRequest extracts necessary variables from the provided request,
+// builds a GetTransactionTimeline instance, and validates it.
+//
+// No errors are expected during normal operation.
+func GetTransactionTimelineRequest(r *common.Request) (GetTransactionTimeline, error) {
+	var req GetTransactionTimeline
+	err := req.Build(r)
+	return req, err
+}
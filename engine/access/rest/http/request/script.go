@@ -24,6 +24,10 @@ func (s *Script) Parse(raw io.Reader) error {
 		return err
 	}
 
+	return s.build(body)
+}
+
+func (s *Script) build(body scriptBody) error {
 	source, err := util.FromBase64(body.Script)
 	if err != nil {
 		return fmt.Errorf("invalid script source encoding")
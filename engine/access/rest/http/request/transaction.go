@@ -17,6 +17,16 @@ const maxAllowedScriptArguments = 100
 type Transaction flow.TransactionBody
 
 func (t *Transaction) Parse(raw io.Reader, chain flow.Chain) error {
+	return t.parse(raw, chain, true)
+}
+
+// ParseUnsigned parses a transaction which is not required to carry envelope signatures, such as a
+// transaction submitted for estimation.
+func (t *Transaction) ParseUnsigned(raw io.Reader, chain flow.Chain) error {
+	return t.parse(raw, chain, false)
+}
+
+func (t *Transaction) parse(raw io.Reader, chain flow.Chain, requireSignatures bool) error {
 	var tx models.TransactionsBody
 	err := parseBody(raw, &tx)
 	if err != nil {
@@ -41,7 +51,7 @@ func (t *Transaction) Parse(raw io.Reader, chain flow.Chain) error {
 	if tx.ReferenceBlockId == "" {
 		return fmt.Errorf("reference block not provided")
 	}
-	if len(tx.EnvelopeSignatures) == 0 {
+	if requireSignatures && len(tx.EnvelopeSignatures) == 0 {
 		return fmt.Errorf("envelope signatures not provided")
 	}
 
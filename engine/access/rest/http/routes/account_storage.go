@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// GetAccountStorage handler retrieves a breakdown of the values stored in an account by address and block height
+// and returns the response
+func GetAccountStorage(r *common.Request, backend access.API, _ commonmodels.LinkGenerator) (interface{}, error) {
+	req, err := request.GetAccountStorageRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	// In case we receive special height values 'final' and 'sealed',
+	// fetch that height and overwrite request with it.
+	isSealed := req.Height == request.SealedHeight
+	isFinal := req.Height == request.FinalHeight
+	if isFinal || isSealed {
+		header, _, err := backend.GetLatestBlockHeader(r.Context(), isSealed)
+		if err != nil {
+			err := fmt.Errorf("block with height: %d does not exist", req.Height)
+			return nil, common.NewNotFoundError(err.Error(), err)
+		}
+		req.Height = header.Height
+	}
+
+	accountStorage, err := backend.GetAccountStorageAtBlockHeight(r.Context(), req.Address, req.Height, req.Limit, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountStorage
+	response.Build(accountStorage)
+	return response, nil
+}
@@ -0,0 +1,143 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountStorage tests local getAccountStorage request.
+//
+// Runs the following tests:
+// 1. Get account storage by address at latest sealed block.
+// 2. Get account storage by address at height.
+// 3. Get invalid account storage.
+func TestGetAccountStorage(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	t.Run("get storage by address at latest sealed block", func(t *testing.T) {
+		accountStorage := accountStorageFixture()
+		var height uint64 = 100
+		block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+
+		req := getAccountStorageRequest(t, accountStorage.Address.String(), router.SealedHeightQueryParam)
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(block, flow.BlockStatusSealed, nil).
+			Once()
+
+		backend.Mock.
+			On("GetAccountStorageAtBlockHeight", mocktestify.Anything, accountStorage.Address, height, uint32(0), (*flow.AccountStorageCursor)(nil)).
+			Return(accountStorage, nil).
+			Once()
+
+		router.AssertOKResponse(t, req, expectedAccountStorageResponse(accountStorage), backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get storage by address at height", func(t *testing.T) {
+		accountStorage := accountStorageFixture()
+		var height uint64 = 1337
+
+		req := getAccountStorageRequest(t, accountStorage.Address.String(), fmt.Sprintf("%d", height))
+
+		backend.Mock.
+			On("GetAccountStorageAtBlockHeight", mocktestify.Anything, accountStorage.Address, height, uint32(0), (*flow.AccountStorageCursor)(nil)).
+			Return(accountStorage, nil).
+			Once()
+
+		router.AssertOKResponse(t, req, expectedAccountStorageResponse(accountStorage), backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get invalid", func(t *testing.T) {
+		tests := []struct {
+			url string
+			out string
+		}{
+			{accountStorageURL(t, "123", ""), `{"code":400, "message":"invalid address"}`},
+			{accountStorageURL(t, unittest.AddressFixture().String(), "foo"), `{"code":400, "message":"invalid height format"}`},
+		}
+
+		for i, test := range tests {
+			req, _ := http.NewRequest("GET", test.url, nil)
+			rr := router.ExecuteRequest(req, backend)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.JSONEq(t, test.out, rr.Body.String(), fmt.Sprintf("test #%d failed: %v", i, test))
+		}
+	})
+}
+
+func accountStorageURL(t *testing.T, address string, height string) string {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/storage", address))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if height != "" {
+		q.Add("block_height", height)
+	}
+
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func getAccountStorageRequest(t *testing.T, address string, height string) *http.Request {
+	req, err := http.NewRequest("GET", accountStorageURL(t, address, height), nil)
+	require.NoError(t, err)
+	return req
+}
+
+func accountStorageFixture() *flow.AccountStorage {
+	return &flow.AccountStorage{
+		Address:         unittest.AddressFixture(),
+		StorageUsed:     1200,
+		StorageCapacity: 100_000,
+		Domains: []flow.AccountStorageDomain{
+			{
+				Name: "storage",
+				Items: []flow.AccountStorageItem{
+					{Key: "/storage/flowTokenVault", Type: "A.0ae53cb6e3f42a79.FlowToken.Vault", Size: 120},
+				},
+			},
+			{
+				Name: "contract",
+				Items: []flow.AccountStorageItem{
+					{Key: "Foo", Type: "A.f8d6e0586b0a20c7.Foo", Size: 80},
+				},
+			},
+		},
+	}
+}
+
+func expectedAccountStorageResponse(accountStorage *flow.AccountStorage) string {
+	return fmt.Sprintf(`
+      {
+        "address": "%s",
+        "used": "1200",
+        "capacity": "100000",
+        "domains": [
+          {
+            "name": "storage",
+            "items": [{"key": "/storage/flowTokenVault", "type": "A.0ae53cb6e3f42a79.FlowToken.Vault", "size": "120"}]
+          },
+          {
+            "name": "contract",
+            "items": [{"key": "Foo", "type": "A.f8d6e0586b0a20c7.Foo", "size": "80"}]
+          }
+        ]
+      }`,
+		accountStorage.Address,
+	)
+}
@@ -0,0 +1,43 @@
+package routes
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// GetAccountTransactions handler retrieves a page of transactions the account participated in as proposer,
+// payer or authorizer within the requested height range and returns the response
+func GetAccountTransactions(r *common.Request, backend access.API, _ commonmodels.LinkGenerator) (interface{}, error) {
+	req, err := request.GetAccountTransactionsRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	// if end height is provided with special values then load the height
+	if req.EndHeight == request.FinalHeight || req.EndHeight == request.SealedHeight {
+		latest, _, err := backend.GetLatestBlockHeader(r.Context(), req.EndHeight == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		req.EndHeight = latest.Height
+		// special check after we resolve special height value
+		if req.StartHeight > req.EndHeight {
+			return nil, common.NewBadRequestError(fmt.Errorf("current retrieved end height value is lower than start height"))
+		}
+	}
+
+	page, err := backend.GetTransactionsByAccount(r.Context(), req.Address, req.StartHeight, req.EndHeight, req.Limit, req.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.AccountTransactions
+	response.Build(page)
+	return response, nil
+}
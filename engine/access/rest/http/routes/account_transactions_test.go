@@ -0,0 +1,173 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountTransactions tests local getAccountTransactions request.
+//
+// Runs the following tests:
+// 1. Get account transactions up to the latest sealed block.
+// 2. Get account transactions for a height range with cursor and limit.
+// 3. Get invalid account transactions.
+func TestGetAccountTransactions(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	t.Run("get transactions up to latest sealed block", func(t *testing.T) {
+		address := unittest.AddressFixture()
+		var height uint64 = 100
+		block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(height))
+		page := accountTransactionsPageFixture(address, 90, nil)
+
+		req := getAccountTransactionsRequest(t, address.String(), "", "", "", "")
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(block, flow.BlockStatusSealed, nil).
+			Once()
+
+		backend.Mock.
+			On("GetTransactionsByAccount", mocktestify.Anything, address, uint64(0), height, uint32(0), (*flow.AccountTransactionCursor)(nil)).
+			Return(page, nil).
+			Once()
+
+		router.AssertOKResponse(t, req, expectedAccountTransactionsResponse(page), backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get transactions for height range with cursor", func(t *testing.T) {
+		address := unittest.AddressFixture()
+		cursor := flow.AccountTransactionCursor{BlockHeight: 15, TransactionIndex: 2}
+		next := flow.AccountTransactionCursor{BlockHeight: 18, TransactionIndex: 0}
+		page := accountTransactionsPageFixture(address, 15, &next)
+
+		req := getAccountTransactionsRequest(t, address.String(), "10", "20", "2", cursor.Encode())
+
+		backend.Mock.
+			On("GetTransactionsByAccount", mocktestify.Anything, address, uint64(10), uint64(20), uint32(2), &cursor).
+			Return(page, nil).
+			Once()
+
+		router.AssertOKResponse(t, req, expectedAccountTransactionsResponse(page), backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get invalid", func(t *testing.T) {
+		address := unittest.AddressFixture().String()
+		tests := []struct {
+			url string
+			out string
+		}{
+			{accountTransactionsURL(t, "123", "", "", "", ""), `{"code":400, "message":"invalid address"}`},
+			{accountTransactionsURL(t, address, "foo", "", "", ""), `{"code":400, "message":"invalid start height: invalid height format"}`},
+			{accountTransactionsURL(t, address, "20", "10", "", ""), `{"code":400, "message":"start height must be less than or equal to end height"}`},
+			{accountTransactionsURL(t, address, "", "", "", "xyz"), `{"code":400, "message":"invalid cursor encoding: encoding/hex: invalid byte: U+0078 'x'"}`},
+		}
+
+		for i, test := range tests {
+			req, _ := http.NewRequest("GET", test.url, nil)
+			rr := router.ExecuteRequest(req, backend)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.JSONEq(t, test.out, rr.Body.String(), fmt.Sprintf("test #%d failed: %v", i, test))
+		}
+	})
+}
+
+func accountTransactionsURL(t *testing.T, address string, start string, end string, limit string, cursor string) string {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/transactions", address))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if start != "" {
+		q.Add("start_height", start)
+	}
+	if end != "" {
+		q.Add("end_height", end)
+	}
+	if limit != "" {
+		q.Add("limit", limit)
+	}
+	if cursor != "" {
+		q.Add("cursor", cursor)
+	}
+
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func getAccountTransactionsRequest(t *testing.T, address string, start string, end string, limit string, cursor string) *http.Request {
+	req, err := http.NewRequest(
+		"GET",
+		accountTransactionsURL(t, address, start, end, limit, cursor),
+		nil,
+	)
+
+	require.NoError(t, err)
+	return req
+}
+
+func accountTransactionsPageFixture(address flow.Address, height uint64, next *flow.AccountTransactionCursor) *flow.AccountTransactionsPage {
+	return &flow.AccountTransactionsPage{
+		Transactions: []flow.AccountTransaction{
+			{
+				Address:          address,
+				BlockHeight:      height,
+				TransactionIndex: 2,
+				TransactionID:    unittest.IdentifierFixture(),
+				Roles:            flow.TransactionRolePayer | flow.TransactionRoleAuthorizer,
+			},
+			{
+				Address:          address,
+				BlockHeight:      height + 1,
+				TransactionIndex: 0,
+				TransactionID:    unittest.IdentifierFixture(),
+				Roles:            flow.TransactionRoleProposer,
+			},
+		},
+		NextCursor: next,
+	}
+}
+
+func expectedAccountTransactionsResponse(page *flow.AccountTransactionsPage) string {
+	nextCursor := ""
+	if page.NextCursor != nil {
+		nextCursor = fmt.Sprintf(`, "next_cursor": "%s"`, page.NextCursor.Encode())
+	}
+
+	return fmt.Sprintf(`
+      {
+        "transactions": [
+          {
+            "transaction_id": "%s",
+            "block_height": "%d",
+            "transaction_index": "2",
+            "roles": ["payer", "authorizer"]
+          },
+          {
+            "transaction_id": "%s",
+            "block_height": "%d",
+            "transaction_index": "0",
+            "roles": ["proposer"]
+          }
+        ]%s
+      }`,
+		page.Transactions[0].TransactionID,
+		page.Transactions[0].BlockHeight,
+		page.Transactions[1].TransactionID,
+		page.Transactions[1].BlockHeight,
+		nextCursor,
+	)
+}
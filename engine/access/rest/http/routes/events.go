@@ -15,7 +15,11 @@ const BlockQueryParam = "block_ids"
 const EventTypeQuery = "type"
 
 // GetEvents for the provided block range or list of block IDs filtered by type.
-func GetEvents(r *common.Request, backend access.API, _ commonmodels.LinkGenerator) (interface{}, error) {
+func GetEvents(r *common.Request, backend access.API, link commonmodels.LinkGenerator) (interface{}, error) {
+	if request.IsEventsQuery(r) {
+		return getEventsByQuery(r, backend, link)
+	}
+
 	req, err := request.GetEventsRequest(r)
 	if err != nil {
 		return nil, common.NewBadRequestError(err)
@@ -67,3 +71,41 @@ func GetEvents(r *common.Request, backend access.API, _ commonmodels.LinkGenerat
 	blocksEvents.Build(events)
 	return blocksEvents, nil
 }
+
+// getEventsByQuery returns events for the provided block range filtered by event types, addresses,
+// contracts and event field predicates.
+func getEventsByQuery(r *common.Request, backend access.API, _ commonmodels.LinkGenerator) (interface{}, error) {
+	req, err := request.GetEventsQueryRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	// if end height is provided with special values then load the height
+	if req.EndHeight == request.FinalHeight || req.EndHeight == request.SealedHeight {
+		latest, _, err := backend.GetLatestBlockHeader(r.Context(), req.EndHeight == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		req.EndHeight = latest.Height
+		// special check after we resolve special height value
+		if req.StartHeight > req.EndHeight {
+			return nil, common.NewBadRequestError(fmt.Errorf("current retrieved end height value is lower than start height"))
+		}
+	}
+
+	events, err := backend.GetEventsByQuery(
+		r.Context(),
+		req.Query,
+		req.StartHeight,
+		req.EndHeight,
+		entitiesproto.EventEncodingVersion_JSON_CDC_V0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocksEvents commonmodels.BlocksEvents
+	blocksEvents.Build(events)
+	return blocksEvents, nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/onflow/flow-go/engine/access/rest/http/routes"
 	"github.com/onflow/flow-go/engine/access/rest/router"
 	"github.com/onflow/flow-go/engine/access/rest/util"
+	eventsmodel "github.com/onflow/flow-go/model/events"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/utils/unittest"
 
@@ -134,6 +135,95 @@ func TestGetEvents(t *testing.T) {
 
 }
 
+func TestGetEventsByQuery(t *testing.T) {
+	backend := &mock.API{}
+
+	service := flow.Testnet.Chain().ServiceAddress()
+	depositedType := fmt.Sprintf("A.%s.FlowToken.TokensDeposited", service.Hex())
+	withdrawnType := fmt.Sprintf("A.%s.FlowToken.TokensWithdrawn", service.Hex())
+
+	header := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(10))
+	events := []flow.BlockEvents{unittest.BlockEventsFixture(header, 2)}
+
+	backend.Mock.
+		On("GetEventsByQuery", mocks.Anything, mocks.MatchedBy(func(query *eventsmodel.Query) bool {
+			_, deposited := query.EventTypes[flow.EventType(depositedType)]
+			_, withdrawn := query.EventTypes[flow.EventType(withdrawnType)]
+			return deposited && withdrawn && len(query.FieldPredicates) == 2
+		}), uint64(10), uint64(20), entities.EventEncodingVersion_JSON_CDC_V0).
+		Return(events, nil)
+
+	backend.Mock.
+		On("GetEventsByQuery", mocks.Anything, mocks.MatchedBy(func(query *eventsmodel.Query) bool {
+			_, ok := query.Contracts[fmt.Sprintf("A.%s.FlowToken", service.Hex())]
+			return ok
+		}), uint64(10), uint64(20), entities.EventEncodingVersion_JSON_CDC_V0).
+		Return(events, nil)
+
+	testVectors := []testVector{
+		{
+			description: "Get events by multiple types and field predicates",
+			request: getEventQueryReq(t, map[string]string{
+				routes.EventTypeQuery:        depositedType + "," + withdrawnType,
+				"fields":                     "to:eq:" + service.HexWithPrefix() + ",amount:gte:10.0",
+				router.StartHeightQueryParam: "10",
+				router.EndHeightQueryParam:   "20",
+			}),
+			expectedStatus:   http.StatusOK,
+			expectedResponse: testBlockEventResponse(t, events),
+		},
+		{
+			description: "Get events by contract",
+			request: getEventQueryReq(t, map[string]string{
+				"contracts":                  fmt.Sprintf("A.%s.FlowToken", service.Hex()),
+				router.StartHeightQueryParam: "10",
+				router.EndHeightQueryParam:   "20",
+			}),
+			expectedStatus:   http.StatusOK,
+			expectedResponse: testBlockEventResponse(t, events),
+		},
+		{
+			description: "Get invalid - block IDs",
+			request: getEventQueryReq(t, map[string]string{
+				"contracts":            fmt.Sprintf("A.%s.FlowToken", service.Hex()),
+				routes.BlockQueryParam: header.ID().String(),
+			}),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"code":400,"message":"event queries only support start and end height range"}`,
+		},
+		{
+			description: "Get invalid - malformed field predicate",
+			request: getEventQueryReq(t, map[string]string{
+				"fields":                     "to=0x01",
+				router.StartHeightQueryParam: "10",
+				router.EndHeightQueryParam:   "20",
+			}),
+			expectedStatus:   http.StatusBadRequest,
+			expectedResponse: `{"code":400,"message":"invalid field predicate \"to=0x01\", expected format \u003cfield\u003e:\u003coperator\u003e:\u003cvalue\u003e"}`,
+		},
+	}
+
+	for _, test := range testVectors {
+		t.Run(test.description, func(t *testing.T) {
+			router.AssertResponse(t, test.request, test.expectedStatus, test.expectedResponse, backend)
+		})
+	}
+}
+
+func getEventQueryReq(t *testing.T, params map[string]string) *http.Request {
+	u, _ := url.Parse("/v1/events")
+	q := u.Query()
+	for key, value := range params {
+		q.Add(key, value)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	require.NoError(t, err)
+
+	return req
+}
+
 func getEventReq(t *testing.T, eventType string, start string, end string, blockIDs []string) *http.Request {
 	u, _ := url.Parse("/v1/events")
 	q := u.Query()
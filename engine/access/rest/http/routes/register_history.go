@@ -0,0 +1,50 @@
+package routes
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// GetAccountRegisterHistory handler retrieves the values written to a register of an account, or to all registers
+// of the account, within a block height range and returns the response
+func GetAccountRegisterHistory(r *common.Request, backend access.API, _ commonmodels.LinkGenerator) (interface{}, error) {
+	req, err := request.GetAccountRegisterHistoryRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	// if end height is provided with special values then load the height
+	if req.EndHeight == request.FinalHeight || req.EndHeight == request.SealedHeight {
+		latest, _, err := backend.GetLatestBlockHeader(r.Context(), req.EndHeight == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+
+		req.EndHeight = latest.Height
+		// special check after we resolve special height value
+		if req.StartHeight > req.EndHeight {
+			return nil, common.NewBadRequestError(fmt.Errorf("current retrieved end height value is lower than start height"))
+		}
+	}
+
+	var page *flow.RegisterHistoryPage
+	if req.Key != nil {
+		registerID := flow.NewRegisterID(req.Address, *req.Key)
+		page, err = backend.GetRegisterHistory(r.Context(), registerID, req.StartHeight, req.EndHeight, req.Limit, req.Cursor)
+	} else {
+		page, err = backend.GetAccountRegisterHistory(r.Context(), req.Address, req.StartHeight, req.EndHeight, req.Limit, req.Cursor)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.RegisterChanges
+	response.Build(page)
+	return response, nil
+}
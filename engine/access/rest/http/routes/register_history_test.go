@@ -0,0 +1,157 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestGetAccountRegisterHistory tests local getAccountRegisterHistory request.
+//
+// Runs the following tests:
+// 1. Get the history of a register of an account.
+// 2. Get the history of all registers of an account up to the latest sealed block.
+// 3. Get the next page of the history of all registers of an account.
+// 4. Get the history of a height range which is not indexed.
+// 5. Get invalid register history.
+func TestGetAccountRegisterHistory(t *testing.T) {
+	backend := mock.NewAPI(t)
+	address := unittest.AddressFixture()
+	registerID := flow.NewRegisterID(address, "balance")
+
+	changes := []flow.RegisterChange{
+		{Key: registerID, Height: 8, Value: []byte{2}},
+		{Key: registerID, Height: 3, Value: []byte{1}},
+	}
+	page := &flow.RegisterHistoryPage{Changes: changes}
+	expected := fmt.Sprintf(`{"changes": [
+		{"owner": "%[1]s", "key": "62616c616e6365", "height": "8", "value": "Ag=="},
+		{"owner": "%[1]s", "key": "62616c616e6365", "height": "3", "value": "AQ=="}
+	]}`, address.Hex())
+
+	t.Run("get register history", func(t *testing.T) {
+		req := getAccountRegisterHistoryRequest(t, address.String(), "62616c616e6365", "1", "10", "", "")
+
+		backend.Mock.
+			On("GetRegisterHistory", mocktestify.Anything, registerID, uint64(1), uint64(10), uint32(0), (*flow.RegisterHistoryCursor)(nil)).
+			Return(page, nil).
+			Once()
+
+		router.AssertOKResponse(t, req, expected, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get account register history up to latest sealed block", func(t *testing.T) {
+		block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(100))
+		req := getAccountRegisterHistoryRequest(t, address.String(), "", "1", router.SealedHeightQueryParam, "", "")
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(block, flow.BlockStatusSealed, nil).
+			Once()
+
+		backend.Mock.
+			On("GetAccountRegisterHistory", mocktestify.Anything, address, uint64(1), uint64(100), uint32(0), (*flow.RegisterHistoryCursor)(nil)).
+			Return(page, nil).
+			Once()
+
+		router.AssertOKResponse(t, req, expected, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get next page of account register history", func(t *testing.T) {
+		cursor := flow.RegisterHistoryCursor{Key: "balance", Height: 8}
+		nextCursor := changes[1].Cursor()
+		req := getAccountRegisterHistoryRequest(t, address.String(), "", "1", "10", "1", cursor.Encode())
+
+		backend.Mock.
+			On("GetAccountRegisterHistory", mocktestify.Anything, address, uint64(1), uint64(10), uint32(1), &cursor).
+			Return(&flow.RegisterHistoryPage{Changes: changes[:1], NextCursor: &nextCursor}, nil).
+			Once()
+
+		expected := fmt.Sprintf(`{
+			"changes": [{"owner": "%s", "key": "62616c616e6365", "height": "8", "value": "Ag=="}],
+			"next_cursor": "%s"
+		}`, address.Hex(), nextCursor.Encode())
+
+		router.AssertOKResponse(t, req, expected, backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get history of heights which are not indexed", func(t *testing.T) {
+		req := getAccountRegisterHistoryRequest(t, address.String(), "", "1", "10", "", "")
+
+		backend.Mock.
+			On("GetAccountRegisterHistory", mocktestify.Anything, address, uint64(1), uint64(10), uint32(0), (*flow.RegisterHistoryCursor)(nil)).
+			Return(nil, status.Error(codes.NotFound, "not indexed")).
+			Once()
+
+		rr := router.ExecuteRequest(req, backend)
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get invalid", func(t *testing.T) {
+		tests := []struct {
+			url string
+			out string
+		}{
+			{accountRegisterHistoryURL(t, "123", "", "1", "10", "", ""), `{"code":400, "message":"invalid address"}`},
+			{accountRegisterHistoryURL(t, address.String(), "zz", "1", "10", "", ""), `{"code":400, "message":"invalid register key: must be hex encoded"}`},
+			{accountRegisterHistoryURL(t, address.String(), "", "", "10", "", ""), `{"code":400, "message":"must provide start and end height range"}`},
+			{accountRegisterHistoryURL(t, address.String(), "", "10", "1", "", ""), `{"code":400, "message":"start height must be less than or equal to end height"}`},
+			{accountRegisterHistoryURL(t, address.String(), "", "1", "10", "", "00"), `{"code":400, "message":"invalid cursor length 1, expected more than 8"}`},
+		}
+
+		for i, test := range tests {
+			req, _ := http.NewRequest("GET", test.url, nil)
+			rr := router.ExecuteRequest(req, backend)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.JSONEq(t, test.out, rr.Body.String(), fmt.Sprintf("test #%d failed: %v", i, test))
+		}
+	})
+}
+
+func accountRegisterHistoryURL(t *testing.T, address string, key string, startHeight string, endHeight string, limit string, cursor string) string {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/register_history", address))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if key != "" {
+		q.Add("key", key)
+	}
+	if startHeight != "" {
+		q.Add("start_height", startHeight)
+	}
+	if endHeight != "" {
+		q.Add("end_height", endHeight)
+	}
+	if limit != "" {
+		q.Add("limit", limit)
+	}
+	if cursor != "" {
+		q.Add("cursor", cursor)
+	}
+
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func getAccountRegisterHistoryRequest(t *testing.T, address string, key string, startHeight string, endHeight string, limit string, cursor string) *http.Request {
+	req, err := http.NewRequest("GET", accountRegisterHistoryURL(t, address, key, startHeight, endHeight, limit, cursor), nil)
+	require.NoError(t, err)
+	return req
+}
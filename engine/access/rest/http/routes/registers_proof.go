@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// GetRegistersWithProof returns the values of the requested registers at the end of a sealed block, together
+// with a proof of the values against the state commitment of the sealed execution result.
+func GetRegistersWithProof(r *common.Request, backend access.API, _ commonmodels.LinkGenerator) (interface{}, error) {
+	req, err := request.GetRegistersWithProofRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	registersProof, err := backend.GetRegistersWithProofAtBlockID(r.Context(), req.BlockID, req.RegisterIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.RegistersProof
+	response.Build(registersProof)
+	return response, nil
+}
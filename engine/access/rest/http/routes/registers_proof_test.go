@@ -0,0 +1,123 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func getRegistersWithProofReq(t *testing.T, blockID string, body interface{}) *http.Request {
+	jsonBody, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", fmt.Sprintf("/v1/blocks/%s/register_proofs", blockID), bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	return req
+}
+
+// TestGetRegistersWithProof tests local getRegistersWithProof request.
+//
+// Runs the following tests:
+// 1. Get registers with proof at a sealed block.
+// 2. Get registers with proof at a block whose state is not available.
+// 3. Get registers with proof with an invalid owner.
+// 4. Get registers with proof without registers.
+func TestGetRegistersWithProof(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	blockID := unittest.IdentifierFixture()
+	commit := unittest.StateCommitmentFixture()
+	address := unittest.AddressFixture()
+	registerIDs := flow.RegisterIDs{
+		flow.NewRegisterID(address, "storage"),
+		flow.UUIDRegisterID(0),
+	}
+
+	body := map[string]interface{}{
+		"registers": []map[string]string{{
+			"owner": hex.EncodeToString([]byte(registerIDs[0].Owner)),
+			"key":   hex.EncodeToString([]byte(registerIDs[0].Key)),
+		}, {
+			"owner": "",
+			"key":   hex.EncodeToString([]byte(registerIDs[1].Key)),
+		}},
+	}
+
+	t.Run("get at sealed block", func(t *testing.T) {
+		backend.Mock.
+			On("GetRegistersWithProofAtBlockID", mocktestify.Anything, blockID, registerIDs).
+			Return(&flow.RegistersProof{
+				BlockID:         blockID,
+				StateCommitment: commit,
+				Registers: []flow.RegisterEntry{
+					{Key: registerIDs[0], Value: []byte{1, 2}},
+					{Key: registerIDs[1]},
+				},
+				Proof: []byte{3, 4},
+			}, nil).
+			Once()
+
+		expected := fmt.Sprintf(`{
+			"block_id": "%s",
+			"state_commitment": "%s",
+			"registers": [
+				{"owner": "%s", "key": "%s", "value": "%s"},
+				{"owner": "", "key": "%s", "value": ""}
+			],
+			"proof": "%s"
+		}`,
+			blockID.String(),
+			hex.EncodeToString(commit[:]),
+			hex.EncodeToString([]byte(registerIDs[0].Owner)),
+			hex.EncodeToString([]byte(registerIDs[0].Key)),
+			util.ToBase64([]byte{1, 2}),
+			hex.EncodeToString([]byte(registerIDs[1].Key)),
+			util.ToBase64([]byte{3, 4}),
+		)
+		router.AssertOKResponse(t, getRegistersWithProofReq(t, blockID.String(), body), expected, backend)
+	})
+
+	t.Run("get at block with pruned state", func(t *testing.T) {
+		backend.Mock.
+			On("GetRegistersWithProofAtBlockID", mocktestify.Anything, blockID, registerIDs).
+			Return(nil, status.Errorf(codes.NotFound, "state of block %v is not available", blockID)).
+			Once()
+
+		expected := fmt.Sprintf(`{"code":404, "message":"Flow resource not found: state of block %v is not available"}`, blockID)
+		router.AssertResponse(t, getRegistersWithProofReq(t, blockID.String(), body), http.StatusNotFound, expected, backend)
+	})
+
+	t.Run("get with invalid owner", func(t *testing.T) {
+		invalid := map[string]interface{}{
+			"registers": []map[string]string{{
+				"owner": "0102",
+				"key":   hex.EncodeToString([]byte("storage")),
+			}},
+		}
+
+		expected := `{"code":400, "message":"invalid register 0: owner must be empty or a hex encoded address"}`
+		router.AssertResponse(t, getRegistersWithProofReq(t, blockID.String(), invalid), http.StatusBadRequest, expected, backend)
+	})
+
+	t.Run("get without registers", func(t *testing.T) {
+		invalid := map[string]interface{}{
+			"registers": []map[string]string{},
+		}
+
+		expected := `{"code":400, "message":"no registers provided"}`
+		router.AssertResponse(t, getRegistersWithProofReq(t, blockID.String(), invalid), http.StatusBadRequest, expected, backend)
+	})
+}
@@ -15,22 +15,27 @@ func ExecuteScript(r *common.Request, backend access.API, _ models.LinkGenerator
 		return nil, common.NewBadRequestError(err)
 	}
 
+	ctx := r.Context()
+	if access.HasNoCacheDirective(r.Header.Get(access.CacheControlHeader)) {
+		ctx = access.WithScriptCacheBypass(ctx)
+	}
+
 	if req.BlockID != flow.ZeroID {
-		return backend.ExecuteScriptAtBlockID(r.Context(), req.BlockID, req.Script.Source, req.Script.Args)
+		return backend.ExecuteScriptAtBlockID(ctx, req.BlockID, req.Script.Source, req.Script.Args)
 	}
 
 	// default to sealed height
 	if req.BlockHeight == request.SealedHeight || req.BlockHeight == request.EmptyHeight {
-		return backend.ExecuteScriptAtLatestBlock(r.Context(), req.Script.Source, req.Script.Args)
+		return backend.ExecuteScriptAtLatestBlock(ctx, req.Script.Source, req.Script.Args)
 	}
 
 	if req.BlockHeight == request.FinalHeight {
-		finalBlock, _, err := backend.GetLatestBlockHeader(r.Context(), false)
+		finalBlock, _, err := backend.GetLatestBlockHeader(ctx, false)
 		if err != nil {
 			return nil, err
 		}
 		req.BlockHeight = finalBlock.Height
 	}
 
-	return backend.ExecuteScriptAtBlockHeight(r.Context(), req.BlockHeight, req.Script.Source, req.Script.Args)
+	return backend.ExecuteScriptAtBlockHeight(ctx, req.BlockHeight, req.Script.Source, req.Script.Args)
 }
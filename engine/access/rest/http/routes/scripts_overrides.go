@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// ExecuteScriptWithOverrides executes the script from the request with the state overrides applied on top of
+// the execution state of the block. The response marks that overrides were applied, since the returned value
+// does not reflect the state of the chain.
+func ExecuteScriptWithOverrides(r *common.Request, backend access.API, _ commonmodels.LinkGenerator) (interface{}, error) {
+	req, err := request.ExecuteScriptWithOverridesRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	if req.BlockHeight == request.SealedHeight || req.BlockHeight == request.FinalHeight {
+		latest, _, err := backend.GetLatestBlockHeader(r.Context(), req.BlockHeight == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+		req.BlockHeight = latest.Height
+	}
+
+	result, err := backend.ExecuteScriptAtBlockHeightWithOverrides(
+		r.Context(),
+		req.BlockHeight,
+		req.Script.Source,
+		req.Script.Args,
+		&req.Overrides,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.ScriptResultWithOverrides
+	response.Build(result)
+	return response, nil
+}
@@ -0,0 +1,145 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func executeScriptWithOverridesReq(t *testing.T, height string, body interface{}) *http.Request {
+	u, _ := url.ParseRequestURI("/v1/scripts/overrides")
+	q := u.Query()
+	if height != "" {
+		q.Add("block_height", height)
+	}
+	u.RawQuery = q.Encode()
+
+	jsonBody, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	return req
+}
+
+// TestExecuteScriptWithOverrides tests local executeScriptWithOverrides request.
+//
+// Runs the following tests:
+// 1. Execute a script with overrides at a block height.
+// 2. Execute a script with overrides at the latest sealed block.
+// 3. Execute a script with overrides which can not be applied.
+// 4. Execute a script with invalid overrides.
+func TestExecuteScriptWithOverrides(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	address := unittest.AddressFixture()
+	registerID := flow.NewRegisterID(address, "storage")
+	script := []byte("access(all) fun main(): Int { return 1 }")
+	value := []byte(`{"type":"Int","value":"1"}`)
+
+	body := map[string]interface{}{
+		"script": util.ToBase64(script),
+		"overrides": map[string]interface{}{
+			"registers": []map[string]string{{
+				"owner": hex.EncodeToString([]byte(registerID.Owner)),
+				"key":   hex.EncodeToString([]byte(registerID.Key)),
+				"value": util.ToBase64([]byte{1, 2, 3}),
+			}},
+			"contracts": []map[string]string{{
+				"address": address.Hex(),
+				"name":    "Test",
+				"code":    util.ToBase64([]byte("access(all) contract Test {}")),
+			}},
+			"balances": []map[string]string{{
+				"address": address.Hex(),
+				"balance": "100000000",
+			}},
+		},
+	}
+
+	expectedOverrides := &flow.StateOverrides{
+		Registers: []flow.RegisterEntry{{Key: registerID, Value: []byte{1, 2, 3}}},
+		Contracts: []flow.ContractOverride{{
+			Address: address,
+			Name:    "Test",
+			Code:    []byte("access(all) contract Test {}"),
+		}},
+		Balances: []flow.BalanceOverride{{Address: address, Balance: 100_000_000}},
+	}
+
+	result := &flow.ScriptResultWithOverrides{
+		Value:               value,
+		OverriddenRegisters: 4,
+		OverriddenContracts: 1,
+		OverriddenBalances:  1,
+	}
+	expected := fmt.Sprintf(`{
+		"value": "%s",
+		"overrides_applied": true,
+		"overridden_registers": "4",
+		"overridden_contracts": "1",
+		"overridden_balances": "1"
+	}`, util.ToBase64(value))
+
+	t.Run("execute at height", func(t *testing.T) {
+		backend.Mock.
+			On("ExecuteScriptAtBlockHeightWithOverrides", mocktestify.Anything, uint64(10), script, [][]byte{}, expectedOverrides).
+			Return(result, nil).
+			Once()
+
+		router.AssertOKResponse(t, executeScriptWithOverridesReq(t, "10", body), expected, backend)
+	})
+
+	t.Run("execute at sealed block", func(t *testing.T) {
+		sealed := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(20))
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(sealed, flow.BlockStatusSealed, nil).
+			Once()
+		backend.Mock.
+			On("ExecuteScriptAtBlockHeightWithOverrides", mocktestify.Anything, uint64(20), script, [][]byte{}, expectedOverrides).
+			Return(result, nil).
+			Once()
+
+		router.AssertOKResponse(t, executeScriptWithOverridesReq(t, "", body), expected, backend)
+	})
+
+	t.Run("execute with overrides which can not be applied", func(t *testing.T) {
+		backend.Mock.
+			On("ExecuteScriptAtBlockHeightWithOverrides", mocktestify.Anything, uint64(10), script, [][]byte{}, expectedOverrides).
+			Return(nil, status.Error(codes.InvalidArgument, "failed to apply state overrides: contract is not deployed")).
+			Once()
+
+		expected := `{"code":400, "message":"Invalid Flow argument: failed to apply state overrides: contract is not deployed"}`
+		router.AssertResponse(t, executeScriptWithOverridesReq(t, "10", body), http.StatusBadRequest, expected, backend)
+	})
+
+	t.Run("execute with invalid overrides", func(t *testing.T) {
+		invalid := map[string]interface{}{
+			"script": util.ToBase64(script),
+			"overrides": map[string]interface{}{
+				"balances": []map[string]string{{
+					"address": address.Hex(),
+					"balance": "-1",
+				}},
+			},
+		}
+
+		expected := `{"code":400, "message":"invalid balance override 0: value must be an unsigned 64 bit integer"}`
+		router.AssertResponse(t, executeScriptWithOverridesReq(t, "10", invalid), http.StatusBadRequest, expected, backend)
+	})
+}
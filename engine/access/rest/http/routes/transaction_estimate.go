@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// EstimateTransaction executes the provided transaction without committing its state changes, and returns
+// the computation, memory, events and fees it would use. Signatures are not required.
+func EstimateTransaction(r *common.Request, backend access.API, _ commonmodels.LinkGenerator) (interface{}, error) {
+	req, err := request.EstimateTransactionRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	estimate, err := backend.EstimateTransaction(r.Context(), &req.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.TransactionEstimate
+	response.Build(estimate)
+	return response, nil
+}
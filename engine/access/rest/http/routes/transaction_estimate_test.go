@@ -0,0 +1,107 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func estimateTransactionReq(t *testing.T, body interface{}) *http.Request {
+	jsonBody, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", "/v1/transactions/estimate", bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	return req
+}
+
+// unsignedTransactionPayload returns the HTTP payload of the transaction without its signatures.
+func unsignedTransactionPayload(tx flow.TransactionBody) map[string]interface{} {
+	tx.PayloadSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+	payload := unittest.CreateSendTxHttpPayload(tx)
+	delete(payload, "payload_signatures")
+	delete(payload, "envelope_signatures")
+	return payload
+}
+
+// TestEstimateTransaction tests local estimateTransaction request.
+//
+// Runs the following tests:
+// 1. Estimate an unsigned transaction.
+// 2. Estimate when the sealed state is not indexed.
+// 3. Estimate with an invalid transaction body.
+func TestEstimateTransaction(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	t.Run("estimate", func(t *testing.T) {
+		tx := unittest.TransactionBodyFixture()
+		event := unittest.EventFixture(flow.EventAccountCreated, 0, 0, tx.ID(), 0)
+
+		estimate := &flow.TransactionEstimate{
+			ErrorMessage:           "",
+			ComputationUsed:        17,
+			ComputationIntensities: map[uint64]uint64{1001: 3},
+			MemoryEstimate:         2048,
+			Events:                 []flow.Event{event},
+			Fees:                   1000,
+		}
+
+		backend.Mock.
+			On("EstimateTransaction", mocktestify.Anything, mocktestify.MatchedBy(func(body *flow.TransactionBody) bool {
+				return bytes.Equal(body.Script, tx.Script) &&
+					body.Payer == tx.Payer &&
+					len(body.EnvelopeSignatures) == 0
+			})).
+			Return(estimate, nil).
+			Once()
+
+		expected := fmt.Sprintf(`{
+			"error_message": "",
+			"computation_used": "17",
+			"computation_intensities": {"1001": "3"},
+			"memory_estimate": "2048",
+			"events": [{
+				"type": "%s",
+				"transaction_id": "%s",
+				"transaction_index": "0",
+				"event_index": "0",
+				"payload": "%s"
+			}],
+			"fees": "1000"
+		}`, event.Type, event.TransactionID, util.ToBase64(event.Payload))
+
+		router.AssertOKResponse(t, estimateTransactionReq(t, unsignedTransactionPayload(tx)), expected, backend)
+	})
+
+	t.Run("estimate with sealed state not indexed", func(t *testing.T) {
+		tx := unittest.TransactionBodyFixture()
+
+		backend.Mock.
+			On("EstimateTransaction", mocktestify.Anything, mocktestify.Anything).
+			Return(nil, status.Error(codes.NotFound, "data for block height 10 is not available")).
+			Once()
+
+		expected := `{"code":404, "message":"Flow resource not found: data for block height 10 is not available"}`
+		router.AssertResponse(t, estimateTransactionReq(t, unsignedTransactionPayload(tx)), http.StatusNotFound, expected, backend)
+	})
+
+	t.Run("estimate with invalid body", func(t *testing.T) {
+		payload := unsignedTransactionPayload(unittest.TransactionBodyFixture())
+		delete(payload, "script")
+
+		expected := `{"code":400, "message":"script not provided"}`
+		router.AssertResponse(t, estimateTransactionReq(t, payload), http.StatusBadRequest, expected, backend)
+	})
+}
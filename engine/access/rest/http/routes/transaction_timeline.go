@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+)
+
+// GetTransactionTimeline gets the lifecycle timeline of a transaction submitted to this node by requested ID.
+func GetTransactionTimeline(r *common.Request, backend access.API, _ commonmodels.LinkGenerator) (interface{}, error) {
+	req, err := request.GetTransactionTimelineRequest(r)
+	if err != nil {
+		return nil, common.NewBadRequestError(err)
+	}
+
+	timeline, err := backend.GetTransactionTimeline(r.Context(), req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.TransactionTimeline
+	response.Build(timeline)
+	return response, nil
+}
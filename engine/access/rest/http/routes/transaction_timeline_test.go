@@ -0,0 +1,84 @@
+package routes_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/router"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func getTransactionTimelineReq(t *testing.T, id string) *http.Request {
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/transactions/%s/timeline", id), nil)
+	require.NoError(t, err)
+	return req
+}
+
+// TestGetTransactionTimeline tests local getTransactionTimeline request.
+//
+// Runs the following tests:
+// 1. Get timeline of a tracked transaction.
+// 2. Get timeline of an untracked transaction.
+// 3. Get timeline with an invalid transaction ID.
+func TestGetTransactionTimeline(t *testing.T) {
+	backend := mock.NewAPI(t)
+
+	t.Run("get timeline", func(t *testing.T) {
+		txID := unittest.IdentifierFixture()
+		collectionID := unittest.IdentifierFixture()
+		received := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		timeline := &flow.TransactionTimeline{
+			TransactionID: txID,
+			Events: []flow.TransactionTimelineEvent{
+				{Stage: flow.TransactionStageReceived, Timestamp: received},
+				{
+					Stage:      flow.TransactionStageCollected,
+					Timestamp:  received.Add(1500 * time.Millisecond),
+					Attributes: map[string]string{flow.TimelineAttributeCollectionID: collectionID.String()},
+				},
+			},
+		}
+
+		backend.Mock.
+			On("GetTransactionTimeline", mocktestify.Anything, txID).
+			Return(timeline, nil).
+			Once()
+
+		expected := fmt.Sprintf(`{
+			"transaction_id": "%s",
+			"events": [
+				{"stage": "received", "timestamp": "2024-05-01T10:00:00Z"},
+				{"stage": "collected", "timestamp": "2024-05-01T10:00:01.5Z", "attributes": {"flow.collection.id": "%s"}}
+			]
+		}`, txID, collectionID)
+
+		router.AssertOKResponse(t, getTransactionTimelineReq(t, txID.String()), expected, backend)
+	})
+
+	t.Run("get untracked timeline", func(t *testing.T) {
+		txID := unittest.IdentifierFixture()
+
+		backend.Mock.
+			On("GetTransactionTimeline", mocktestify.Anything, txID).
+			Return(nil, status.Errorf(codes.NotFound, "no timeline tracked for transaction %v", txID)).
+			Once()
+
+		expected := fmt.Sprintf(`{"code":404, "message":"Flow resource not found: no timeline tracked for transaction %s"}`, txID)
+		router.AssertResponse(t, getTransactionTimelineReq(t, txID.String()), http.StatusNotFound, expected, backend)
+	})
+
+	t.Run("get timeline with invalid ID", func(t *testing.T) {
+		expected := `{"code":400, "message":"invalid ID format"}`
+		router.AssertResponse(t, getTransactionTimelineReq(t, "invalid"), http.StatusBadRequest, expected, backend)
+	})
+}
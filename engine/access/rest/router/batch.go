@@ -0,0 +1,317 @@
+package router
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// DefaultBatchMaxRequests is the default maximum number of sub-requests in a single batch.
+	DefaultBatchMaxRequests = 50
+
+	// DefaultBatchMaxConcurrency is the default maximum number of sub-requests of a batch executed concurrently.
+	DefaultBatchMaxConcurrency = 10
+
+	// DefaultBatchMaxResponseSize is the default maximum combined size of the sub-responses of a batch.
+	DefaultBatchMaxResponseSize = 10 << 20 // 10MB
+)
+
+// BatchConfig contains the limits applied to requests to the batch endpoint.
+type BatchConfig struct {
+	// MaxRequests is the maximum number of sub-requests in a single batch.
+	// The batch endpoint is disabled if set to 0.
+	MaxRequests int
+	// MaxConcurrency is the maximum number of sub-requests of a batch executed concurrently.
+	MaxConcurrency int
+	// MaxResponseSize is the maximum combined size in bytes of the sub-responses of a batch.
+	// It is enforced while the sub-responses are written, so it also bounds the memory used to
+	// buffer the responses of sub-requests which are still in progress.
+	MaxResponseSize int64
+}
+
+// DefaultBatchConfig returns the default batch endpoint configuration.
+func DefaultBatchConfig() BatchConfig {
+	return BatchConfig{
+		MaxRequests:     DefaultBatchMaxRequests,
+		MaxConcurrency:  DefaultBatchMaxConcurrency,
+		MaxResponseSize: DefaultBatchMaxResponseSize,
+	}
+}
+
+// BatchRequestItem is a single sub-request of a batch.
+type BatchRequestItem struct {
+	// ID is an opaque client provided identifier, which is returned with the sub-response.
+	ID string `json:"id"`
+	// Method is the HTTP method of the sub-request. Only GET and POST are supported.
+	Method string `json:"method"`
+	// Path is the path of the sub-request relative to /v1, including the query, e.g. "/blocks?height=final".
+	Path string `json:"path"`
+	// Body is the JSON body of the sub-request. Only used for POST requests.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// BatchResponseItem is the response of a single sub-request of a batch.
+type BatchResponseItem struct {
+	ID     string          `json:"id"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// BatchHandler executes a batch of requests to the REST routes and returns the responses of
+// all sub-requests in a single response.
+//
+// Sub-requests are executed concurrently up to the configured concurrency limit. The combined size
+// of the sub-responses is limited, once the limit is reached the sub-requests in progress are
+// cancelled, and they and all remaining sub-requests fail with status 413.
+type BatchHandler struct {
+	*common.HttpHandler
+	router http.Handler
+	config BatchConfig
+}
+
+var _ http.Handler = (*BatchHandler)(nil)
+
+// NewBatchHandler creates a new batch handler, which dispatches sub-requests to the given router.
+// The router must serve the REST routes under the /v1 prefix.
+func NewBatchHandler(
+	logger zerolog.Logger,
+	router http.Handler,
+	chain flow.Chain,
+	maxRequestSize int64,
+	config BatchConfig,
+) *BatchHandler {
+	return &BatchHandler{
+		HttpHandler: common.NewHttpHandler(logger, chain, maxRequestSize),
+		router:      router,
+		config:      config,
+	}
+}
+
+// ServeHTTP decodes the batch, executes all sub-requests and writes their responses in the
+// order of the sub-requests.
+func (h *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	errLog := h.Logger.With().Str("request_url", r.URL.String()).Logger()
+
+	err := h.VerifyRequest(w, r)
+	if err != nil {
+		return
+	}
+
+	var items []BatchRequestItem
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&items); err != nil {
+		h.ErrorHandler(w, common.NewBadRequestError(fmt.Errorf("invalid batch request: %w", err)), errLog)
+		return
+	}
+	if len(items) == 0 {
+		h.ErrorHandler(w, common.NewBadRequestError(fmt.Errorf("batch must contain at least one request")), errLog)
+		return
+	}
+	if len(items) > h.config.MaxRequests {
+		err := fmt.Errorf("batch contains %d requests, at most %d are allowed", len(items), h.config.MaxRequests)
+		h.ErrorHandler(w, common.NewBadRequestError(err), errLog)
+		return
+	}
+
+	h.JsonResponse(w, http.StatusOK, h.execute(r, items), errLog)
+}
+
+// execute runs the sub-requests of the batch under the configured concurrency and response size limits.
+func (h *BatchHandler) execute(r *http.Request, items []BatchRequestItem) []BatchResponseItem {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	responses := make([]BatchResponseItem, len(items))
+	sem := make(chan struct{}, h.config.MaxConcurrency)
+	budget := newBatchBudget(h.config.MaxResponseSize, cancel)
+
+	var wg sync.WaitGroup
+	for i, item := range items {
+		if budget.exhausted() || ctx.Err() != nil {
+			responses[i] = h.sizeExceededResponse(item.ID)
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, item BatchRequestItem) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			response := h.executeItem(ctx, r, item, budget)
+			if budget.exhausted() {
+				// sub-requests which were cancelled when the budget was exhausted fail with an unrelated error
+				response = h.sizeExceededResponse(item.ID)
+			}
+			responses[i] = response
+		}(i, item)
+	}
+	wg.Wait()
+
+	return responses
+}
+
+// executeItem dispatches a single sub-request to the router and captures its response. The response
+// is accounted against the budget while it is written.
+func (h *BatchHandler) executeItem(ctx context.Context, r *http.Request, item BatchRequestItem, budget *batchBudget) BatchResponseItem {
+	method := strings.ToUpper(item.Method)
+	if method != http.MethodGet && method != http.MethodPost {
+		return errorResponseItem(item.ID, http.StatusBadRequest, fmt.Sprintf("unsupported method: %q", item.Method))
+	}
+	if !strings.HasPrefix(item.Path, "/") {
+		return errorResponseItem(item.ID, http.StatusBadRequest, fmt.Sprintf("path must start with '/': %q", item.Path))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, "/v1"+item.Path, bytes.NewReader(item.Body))
+	if err != nil {
+		return errorResponseItem(item.ID, http.StatusBadRequest, fmt.Sprintf("invalid request: %s", err.Error()))
+	}
+	req.RemoteAddr = r.RemoteAddr
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	rw := newBatchResponseWriter(budget)
+	h.router.ServeHTTP(rw, req)
+	if rw.exceeded {
+		return h.sizeExceededResponse(item.ID)
+	}
+
+	body := bytes.TrimSpace(rw.body.Bytes())
+	if !json.Valid(body) {
+		// responses produced by the router itself, e.g. for unknown routes, are plain text
+		return errorResponseItem(item.ID, rw.status, string(body))
+	}
+
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, body); err != nil {
+		return errorResponseItem(item.ID, http.StatusInternalServerError, "failed to encode response")
+	}
+
+	// only the compacted body is kept, so the space of the removed whitespace is returned to the budget
+	budget.release(int64(rw.body.Len() - compacted.Len()))
+
+	return BatchResponseItem{
+		ID:     item.ID,
+		Status: rw.status,
+		Body:   compacted.Bytes(),
+	}
+}
+
+// sizeExceededResponse returns the response of a sub-request which was not returned because
+// the response size limit of the batch was exceeded.
+func (h *BatchHandler) sizeExceededResponse(id string) BatchResponseItem {
+	msg := fmt.Sprintf("batch response size limit of %d bytes exceeded", h.config.MaxResponseSize)
+	return errorResponseItem(id, http.StatusRequestEntityTooLarge, msg)
+}
+
+// errorResponseItem returns a sub-response with the given status and a model error body.
+func errorResponseItem(id string, status int, msg string) BatchResponseItem {
+	body, _ := json.Marshal(models.ModelError{
+		Code:    int32(status),
+		Message: msg,
+	})
+
+	return BatchResponseItem{
+		ID:     id,
+		Status: status,
+		Body:   body,
+	}
+}
+
+// errBatchResponseTooLarge is returned by batchResponseWriter once the response size budget of the batch is exhausted.
+var errBatchResponseTooLarge = errors.New("batch response size limit exceeded")
+
+// batchBudget tracks the remaining response size budget of a batch, which is shared by all sub-requests.
+// Once the budget is exceeded it stays exhausted, even if bytes are released afterwards.
+type batchBudget struct {
+	remaining atomic.Int64
+	// exceeded is set when a reservation exceeds the budget. Bytes released by sub-requests which
+	// completed in the meantime don't reset it, since the cancelled sub-requests were already failed.
+	exceeded atomic.Bool
+	// cancel cancels all sub-requests of the batch, it is called when the budget is exhausted.
+	cancel context.CancelFunc
+}
+
+func newBatchBudget(size int64, cancel context.CancelFunc) *batchBudget {
+	b := &batchBudget{cancel: cancel}
+	b.remaining.Store(size)
+	return b
+}
+
+// reserve consumes n bytes of the budget, and returns false if the budget is exhausted.
+func (b *batchBudget) reserve(n int64) bool {
+	if b.exceeded.Load() {
+		return false
+	}
+	if b.remaining.Add(-n) < 0 {
+		b.exceeded.Store(true)
+		b.cancel()
+		return false
+	}
+	return true
+}
+
+// release returns n previously reserved bytes to the budget.
+func (b *batchBudget) release(n int64) {
+	b.remaining.Add(n)
+}
+
+// exhausted returns true if the budget was exceeded.
+func (b *batchBudget) exhausted() bool {
+	return b.exceeded.Load()
+}
+
+// batchResponseWriter is an http.ResponseWriter which buffers the response of a sub-request.
+// Writes are accounted against the budget of the batch, and fail once it is exhausted.
+type batchResponseWriter struct {
+	header   http.Header
+	body     bytes.Buffer
+	status   int
+	budget   *batchBudget
+	exceeded bool
+}
+
+var _ http.ResponseWriter = (*batchResponseWriter)(nil)
+
+func newBatchResponseWriter(budget *batchBudget) *batchResponseWriter {
+	return &batchResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+		budget: budget,
+	}
+}
+
+func (w *batchResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *batchResponseWriter) Write(b []byte) (int, error) {
+	if w.exceeded || !w.budget.reserve(int64(len(b))) {
+		// drop the partial response, it is replaced with an error
+		w.exceeded = true
+		w.body.Reset()
+		return 0, errBatchResponseTooLarge
+	}
+	return w.body.Write(b)
+}
+
+func (w *batchResponseWriter) WriteHeader(status int) {
+	w.status = status
+}
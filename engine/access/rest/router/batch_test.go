@@ -0,0 +1,225 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/common"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// executeBatchRequest sends the batch body to a router serving the batch route with the given config.
+func executeBatchRequest(t *testing.T, backend access.API, config BatchConfig, body string) *httptest.ResponseRecorder {
+	router := NewRouterBuilder(
+		unittest.Logger(),
+		metrics.NewNoopCollector(),
+	).AddBatchRoute(
+		backend,
+		flow.Testnet.Chain(),
+		common.DefaultMaxRequestSize,
+		config,
+	).Build()
+
+	req, err := http.NewRequest(http.MethodPost, "/v1/batch", strings.NewReader(body))
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func decodeBatchResponse(t *testing.T, rr *httptest.ResponseRecorder) []BatchResponseItem {
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var items []BatchResponseItem
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &items))
+	return items
+}
+
+func TestBatch(t *testing.T) {
+	t.Run("sub-requests are dispatched to the rest routes", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.
+			On("GetNetworkParameters", mocktestify.Anything).
+			Return(access.NetworkParameters{ChainID: flow.Testnet})
+
+		rr := executeBatchRequest(t, backend, DefaultBatchConfig(), `[
+			{"id": "params", "method": "GET", "path": "/network/parameters"},
+			{"id": "unknown", "method": "GET", "path": "/unknown"},
+			{"id": "invalid-method", "method": "DELETE", "path": "/network/parameters"},
+			{"id": "invalid-path", "method": "GET", "path": "network/parameters"},
+			{"id": "invalid-id", "method": "GET", "path": "/blocks/invalid"}
+		]`)
+		items := decodeBatchResponse(t, rr)
+		require.Len(t, items, 5)
+
+		require.Equal(t, "params", items[0].ID)
+		require.Equal(t, http.StatusOK, items[0].Status)
+		require.JSONEq(t, fmt.Sprintf(`{"chain_id": "%s"}`, flow.Testnet), string(items[0].Body))
+
+		require.Equal(t, "unknown", items[1].ID)
+		require.Equal(t, http.StatusNotFound, items[1].Status)
+		require.JSONEq(t, `{"code": 404, "message": "404 page not found"}`, string(items[1].Body))
+
+		require.Equal(t, http.StatusBadRequest, items[2].Status)
+		require.JSONEq(t, `{"code": 400, "message": "unsupported method: \"DELETE\""}`, string(items[2].Body))
+
+		require.Equal(t, http.StatusBadRequest, items[3].Status)
+		require.Equal(t, http.StatusBadRequest, items[4].Status)
+	})
+
+	t.Run("responses exceeding the size limit are rejected", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.
+			On("GetNetworkParameters", mocktestify.Anything).
+			Return(access.NetworkParameters{ChainID: flow.Testnet})
+
+		config := DefaultBatchConfig()
+		config.MaxConcurrency = 1
+		// the budget is accounted for the responses as written by the routes
+		config.MaxResponseSize = int64(len(fmt.Sprintf("{\n\t\"chain_id\": \"%s\"\n}", flow.Testnet)))
+
+		rr := executeBatchRequest(t, backend, config, `[
+			{"id": "1", "method": "GET", "path": "/network/parameters"},
+			{"id": "2", "method": "GET", "path": "/network/parameters"},
+			{"id": "3", "method": "GET", "path": "/network/parameters"}
+		]`)
+		items := decodeBatchResponse(t, rr)
+		require.Len(t, items, 3)
+
+		require.Equal(t, http.StatusOK, items[0].Status)
+		for _, item := range items[1:] {
+			require.Equal(t, http.StatusRequestEntityTooLarge, item.Status)
+			require.JSONEq(t,
+				fmt.Sprintf(`{"code": 413, "message": "batch response size limit of %d bytes exceeded"}`, config.MaxResponseSize),
+				string(item.Body),
+			)
+		}
+	})
+
+	t.Run("responses are limited while they are written", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.
+			On("GetNetworkParameters", mocktestify.Anything).
+			Return(access.NetworkParameters{ChainID: flow.Testnet})
+
+		// a single response larger than the budget is rejected
+		config := DefaultBatchConfig()
+		config.MaxResponseSize = 10
+
+		rr := executeBatchRequest(t, backend, config, `[
+			{"id": "1", "method": "GET", "path": "/network/parameters"}
+		]`)
+		items := decodeBatchResponse(t, rr)
+		require.Len(t, items, 1)
+		require.Equal(t, http.StatusRequestEntityTooLarge, items[0].Status)
+	})
+
+	t.Run("concurrent sub-requests cancelled by an exceeded budget are rejected", func(t *testing.T) {
+		backend := mock.NewAPI(t)
+		backend.
+			On("GetNetworkParameters", mocktestify.Anything).
+			Return(access.NetworkParameters{ChainID: flow.Testnet})
+		// block requests are in progress until the batch is cancelled, and then fail with an unrelated error
+		backend.
+			On("GetBlockByID", mocktestify.Anything, mocktestify.Anything).
+			Run(func(args mocktestify.Arguments) {
+				<-args.Get(0).(context.Context).Done()
+			}).
+			Return(nil, flow.BlockStatusUnknown, status.Error(codes.Canceled, "context canceled")).
+			Maybe()
+
+		config := DefaultBatchConfig()
+		config.MaxConcurrency = 4
+		// the budget fits a single network parameters response. The whitespace removed from the first
+		// response is returned to the budget, which must not undo the exceeded budget
+		config.MaxResponseSize = int64(len(fmt.Sprintf("{\n\t\"chain_id\": \"%s\"\n}", flow.Testnet)))
+
+		rr := executeBatchRequest(t, backend, config, fmt.Sprintf(`[
+			{"id": "block-1", "method": "GET", "path": "/blocks/%s"},
+			{"id": "block-2", "method": "GET", "path": "/blocks/%s"},
+			{"id": "params-1", "method": "GET", "path": "/network/parameters"},
+			{"id": "params-2", "method": "GET", "path": "/network/parameters"}
+		]`, unittest.IdentifierFixture(), unittest.IdentifierFixture()))
+		items := decodeBatchResponse(t, rr)
+		require.Len(t, items, 4)
+
+		succeeded := 0
+		for _, item := range items {
+			if item.Status == http.StatusOK {
+				require.Contains(t, item.ID, "params")
+				succeeded++
+				continue
+			}
+			require.Equal(t, http.StatusRequestEntityTooLarge, item.Status, string(item.Body))
+		}
+		require.Equal(t, 1, succeeded)
+	})
+
+	t.Run("invalid batches are rejected", func(t *testing.T) {
+		config := DefaultBatchConfig()
+		config.MaxRequests = 1
+
+		tests := []struct {
+			name    string
+			body    string
+			message string
+		}{
+			{
+				name:    "malformed body",
+				body:    `{"id": "1"}`,
+				message: "invalid batch request",
+			},
+			{
+				name:    "empty batch",
+				body:    `[]`,
+				message: "batch must contain at least one request",
+			},
+			{
+				name:    "too many requests",
+				body:    `[{"id": "1", "method": "GET", "path": "/blocks"}, {"id": "2", "method": "GET", "path": "/blocks"}]`,
+				message: "batch contains 2 requests, at most 1 are allowed",
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				rr := executeBatchRequest(t, mock.NewAPI(t), config, tt.body)
+				require.Equal(t, http.StatusBadRequest, rr.Code)
+				require.Contains(t, rr.Body.String(), tt.message)
+			})
+		}
+	})
+}
+
+// TestBatchBudget tests that the budget stays exhausted once exceeded, even if bytes are released afterwards.
+func TestBatchBudget(t *testing.T) {
+	cancelled := false
+	budget := newBatchBudget(15, func() { cancelled = true })
+
+	require.True(t, budget.reserve(10))
+	require.False(t, budget.exhausted())
+
+	require.False(t, budget.reserve(10))
+	require.True(t, budget.exhausted())
+	require.True(t, cancelled)
+
+	// a sub-request which completed in the meantime releases its unused bytes
+	budget.release(10)
+	require.True(t, budget.exhausted())
+	require.False(t, budget.reserve(1))
+}
@@ -29,6 +29,16 @@ var Routes = []route{{
 	Pattern: "/transaction_results/{id}",
 	Name:    "getTransactionResultByID",
 	Handler: routes.GetTransactionResultByID,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/transactions/{id}/timeline",
+	Name:    "getTransactionTimeline",
+	Handler: routes.GetTransactionTimeline,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/transactions/estimate",
+	Name:    "estimateTransaction",
+	Handler: routes.EstimateTransaction,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/blocks/{id}",
@@ -44,6 +54,11 @@ var Routes = []route{{
 	Pattern: "/blocks/{id}/payload",
 	Name:    "getBlockPayloadByID",
 	Handler: routes.GetBlockPayloadByID,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/blocks/{id}/register_proofs",
+	Name:    "getRegistersWithProof",
+	Handler: routes.GetRegistersWithProof,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/execution_results/{id}",
@@ -64,6 +79,11 @@ var Routes = []route{{
 	Pattern: "/scripts",
 	Name:    "executeScript",
 	Handler: routes.ExecuteScript,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/scripts/overrides",
+	Name:    "executeScriptWithOverrides",
+	Handler: routes.ExecuteScriptWithOverrides,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/accounts/{address}",
@@ -74,6 +94,16 @@ var Routes = []route{{
 	Pattern: "/accounts/{address}/balance",
 	Name:    "getAccountBalance",
 	Handler: routes.GetAccountBalance,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/storage",
+	Name:    "getAccountStorage",
+	Handler: routes.GetAccountStorage,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/register_history",
+	Name:    "getAccountRegisterHistory",
+	Handler: routes.GetAccountRegisterHistory,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/accounts/{address}/keys/{index}",
@@ -84,6 +114,11 @@ var Routes = []route{{
 	Pattern: "/accounts/{address}/keys",
 	Name:    "getAccountKeys",
 	Handler: routes.GetAccountKeys,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/transactions",
+	Name:    "getAccountTransactions",
+	Handler: routes.GetAccountTransactions,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/events",
@@ -24,9 +24,10 @@ import (
 
 // RouterBuilder is a utility for building HTTP routers with common middleware and routes.
 type RouterBuilder struct {
-	logger      zerolog.Logger
-	router      *mux.Router
-	v1SubRouter *mux.Router
+	logger        zerolog.Logger
+	restCollector module.RestMetrics
+	router        *mux.Router
+	v1SubRouter   *mux.Router
 
 	LinkGenerator models.LinkGenerator
 }
@@ -46,6 +47,7 @@ func NewRouterBuilder(
 
 	return &RouterBuilder{
 		logger:        logger,
+		restCollector: restCollector,
 		router:        router,
 		v1SubRouter:   v1SubRouter,
 		LinkGenerator: models.NewLinkGeneratorImpl(v1SubRouter),
@@ -69,6 +71,29 @@ func (b *RouterBuilder) AddRestRoutes(
 	return b
 }
 
+// AddBatchRoute adds the batch route to the router. Sub-requests of a batch are dispatched to a
+// dedicated router serving the rest routes with the same middleware, so that sub-requests are logged
+// and accounted for in the metrics like regular requests.
+func (b *RouterBuilder) AddBatchRoute(
+	backend access.API,
+	chain flow.Chain,
+	maxRequestSize int64,
+	config BatchConfig,
+) *RouterBuilder {
+	subRouter := NewRouterBuilder(b.logger, b.restCollector).
+		AddRestRoutes(backend, chain, maxRequestSize).
+		Build()
+
+	h := NewBatchHandler(b.logger, subRouter, chain, maxRequestSize, config)
+	b.v1SubRouter.
+		Methods(http.MethodPost).
+		Path("/batch").
+		Name("batch").
+		Handler(h)
+
+	return b
+}
+
 // AddLegacyWebsocketsRoutes adds WebSocket routes to the router.
 //
 // Deprecated: Use AddWebsocketsRoute instead, which allows managing multiple streams with
@@ -122,6 +147,7 @@ func init() {
 	for _, r := range WSLegacyRoutes {
 		routeUrlMap[r.Pattern] = r.Name
 	}
+	routeUrlMap["/batch"] = "batch"
 }
 
 func URLToRoute(url string) (string, error) {
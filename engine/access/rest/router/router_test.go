@@ -29,6 +29,16 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/transaction_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
 			expected: "getTransactionResultByID",
 		},
+		{
+			name:     "/v1/transactions/{id}/timeline",
+			url:      "/v1/transactions/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/timeline",
+			expected: "getTransactionTimeline",
+		},
+		{
+			name:     "/v1/transactions/estimate",
+			url:      "/v1/transactions/estimate",
+			expected: "estimateTransaction",
+		},
 		{
 			name:     "/v1/blocks",
 			url:      "/v1/blocks",
@@ -44,6 +54,11 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/payload",
 			expected: "getBlockPayloadByID",
 		},
+		{
+			name:     "/v1/blocks/{id}/register_proofs",
+			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/register_proofs",
+			expected: "getRegistersWithProof",
+		},
 		{
 			name:     "/v1/execution_results/{id}",
 			url:      "/v1/execution_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
@@ -64,6 +79,11 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/scripts",
 			expected: "executeScript",
 		},
+		{
+			name:     "/v1/scripts/overrides",
+			url:      "/v1/scripts/overrides",
+			expected: "executeScriptWithOverrides",
+		},
 		{
 			name:     "/v1/accounts/{address}",
 			url:      "/v1/accounts/6a587be304c1224c",
@@ -74,6 +94,16 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/accounts/6a587be304c1224c/balance",
 			expected: "getAccountBalance",
 		},
+		{
+			name:     "/v1/accounts/{address}/storage",
+			url:      "/v1/accounts/6a587be304c1224c/storage",
+			expected: "getAccountStorage",
+		},
+		{
+			name:     "/v1/accounts/{address}/register_history",
+			url:      "/v1/accounts/6a587be304c1224c/register_history",
+			expected: "getAccountRegisterHistory",
+		},
 		{
 			name:     "/v1/accounts/{address}/keys/{index}",
 			url:      "/v1/accounts/6a587be304c1224c/keys/0",
@@ -84,6 +114,11 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/accounts/6a587be304c1224c/keys",
 			expected: "getAccountKeys",
 		},
+		{
+			name:     "/v1/accounts/{address}/transactions",
+			url:      "/v1/accounts/6a587be304c1224c/transactions",
+			expected: "getAccountTransactions",
+		},
 		{
 			name:     "/v1/events",
 			url:      "/v1/events",
@@ -104,6 +139,11 @@ func TestParseURL(t *testing.T) {
 			url:      "/v1/subscribe_events",
 			expected: "subscribeEvents",
 		},
+		{
+			name:     "/v1/batch",
+			url:      "/v1/batch",
+			expected: "batch",
+		},
 	}
 
 	for _, tt := range tests {
@@ -136,6 +176,16 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/transaction_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
 			expected: "getTransactionResultByID",
 		},
+		{
+			name:     "/v1/transactions/{id}/timeline",
+			url:      "/v1/transactions/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/timeline",
+			expected: "getTransactionTimeline",
+		},
+		{
+			name:     "/v1/transactions/estimate",
+			url:      "/v1/transactions/estimate",
+			expected: "estimateTransaction",
+		},
 		{
 			name:     "/v1/blocks",
 			url:      "/v1/blocks",
@@ -151,6 +201,11 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/payload",
 			expected: "getBlockPayloadByID",
 		},
+		{
+			name:     "/v1/blocks/{id}/register_proofs",
+			url:      "/v1/blocks/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76/register_proofs",
+			expected: "getRegistersWithProof",
+		},
 		{
 			name:     "/v1/execution_results/{id}",
 			url:      "/v1/execution_results/53730d3f3d2d2f46cb910b16db817d3a62adaaa72fdb3a92ee373c37c5b55a76",
@@ -171,6 +226,11 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/scripts",
 			expected: "executeScript",
 		},
+		{
+			name:     "/v1/scripts/overrides",
+			url:      "/v1/scripts/overrides",
+			expected: "executeScriptWithOverrides",
+		},
 		{
 			name:     "/v1/accounts/{address}",
 			url:      "/v1/accounts/6a587be304c1224c",
@@ -181,6 +241,16 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/accounts/6a587be304c1224c/balance",
 			expected: "getAccountBalance",
 		},
+		{
+			name:     "/v1/accounts/{address}/storage",
+			url:      "/v1/accounts/6a587be304c1224c/storage",
+			expected: "getAccountStorage",
+		},
+		{
+			name:     "/v1/accounts/{address}/register_history",
+			url:      "/v1/accounts/6a587be304c1224c/register_history",
+			expected: "getAccountRegisterHistory",
+		},
 		{
 			name:     "/v1/accounts/{address}/keys/{index}",
 			url:      "/v1/accounts/6a587be304c1224c/keys/0",
@@ -191,6 +261,11 @@ func TestBenchmarkParseURL(t *testing.T) {
 			url:      "/v1/accounts/6a587be304c1224c/keys",
 			expected: "getAccountKeys",
 		},
+		{
+			name:     "/v1/accounts/{address}/transactions",
+			url:      "/v1/accounts/6a587be304c1224c/transactions",
+			expected: "getAccountTransactions",
+		},
 		{
 			name:     "/v1/events",
 			url:      "/v1/events",
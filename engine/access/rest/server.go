@@ -34,6 +34,7 @@ type Config struct {
 	ReadTimeout    time.Duration
 	IdleTimeout    time.Duration
 	MaxRequestSize int64
+	Batch          router.BatchConfig
 }
 
 // NewServer returns an HTTP server initialized with the REST API handler
@@ -51,6 +52,9 @@ func NewServer(serverAPI access.API,
 	if stateStreamApi != nil {
 		builder.AddLegacyWebsocketsRoutes(stateStreamApi, chain, stateStreamConfig, config.MaxRequestSize)
 	}
+	if config.Batch.MaxRequests > 0 {
+		builder.AddBatchRoute(serverAPI, chain, config.MaxRequestSize, config.Batch)
+	}
 
 	dataProviderFactory := dp.NewDataProviderFactory(
 		logger,
@@ -59,6 +63,7 @@ func NewServer(serverAPI access.API,
 		chain,
 		stateStreamConfig.EventFilterConfig,
 		stateStreamConfig.HeartbeatInterval,
+		stateStreamConfig.RegisterIDsRequestLimit,
 		builder.LinkGenerator,
 	)
 
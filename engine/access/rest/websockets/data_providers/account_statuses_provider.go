@@ -24,6 +24,7 @@ type accountStatusesArguments struct {
 	StartBlockID      flow.Identifier                  // ID of the block to start subscription from
 	StartBlockHeight  uint64                           // Height of the block to start subscription from
 	Filter            state_stream.AccountStatusFilter // Filter applied to events for a given subscription
+	ResumeFrom        *models.Cursor                   // Cursor of the last message received by the client. Nil if not set
 	HeartbeatInterval *uint64                          // Maximum number of blocks message won't be sent. Nil if not set
 }
 
@@ -79,6 +80,7 @@ func NewAccountStatusesDataProvider(
 		cancel,
 		send,
 		p.createSubscription(subCtx, accountStatusesArgs), // Set up a subscription to account statuses based on arguments.
+		accountStatusesArgs.ResumeFrom,
 	)
 
 	return p, nil
@@ -130,10 +132,7 @@ func (p *AccountStatusesDataProvider) handleResponse() func(accountStatusesRespo
 		var accountStatusesPayload models.AccountStatusesResponse
 		accountStatusesPayload.Build(accountStatusesResponse, index)
 
-		var response models.BaseDataProvidersResponse
-		response.Build(p.ID(), p.Topic(), &accountStatusesPayload)
-
-		p.send <- &response
+		p.sendResponse(accountStatusesResponse.Height, &accountStatusesPayload)
 
 		return nil
 	}
@@ -151,6 +150,7 @@ func parseAccountStatusesArguments(
 		"event_types",
 		"account_addresses",
 		"heartbeat_interval",
+		"resume_from_cursor",
 	}
 	err := ensureAllowedFields(arguments, allowedFields)
 	if err != nil {
@@ -167,6 +167,15 @@ func parseAccountStatusesArguments(
 	args.StartBlockID = startBlockID
 	args.StartBlockHeight = startBlockHeight
 
+	// Parse 'resume_from_cursor'
+	args.ResumeFrom, err = parseResumeCursor(arguments)
+	if err != nil {
+		return accountStatusesArguments{}, err
+	}
+	if args.ResumeFrom != nil {
+		args.StartBlockHeight = args.ResumeFrom.Height
+	}
+
 	// Parse 'event_types' as a JSON array
 	var eventTypes parser.EventTypes
 	if eventTypesIn, ok := arguments["event_types"]; ok && eventTypesIn != "" {
@@ -54,6 +54,7 @@ func (s *AccountStatusesProviderSuite) SetupTest() {
 		s.chain,
 		state_stream.DefaultEventFilterConfig,
 		subscription.DefaultHeartbeatInterval,
+		state_stream.DefaultRegisterIDsRequestLimit,
 		nil,
 	)
 	s.Require().NotNil(s.factory)
@@ -15,6 +15,12 @@ type baseDataProvider struct {
 	cancel         context.CancelFunc
 	send           chan<- interface{}
 	subscription   subscription.Subscription
+
+	// resumeFrom is the cursor of the last message the client received before resuming the subscription.
+	// Messages at or before it are not sent again. Nil if the subscription was not resumed.
+	resumeFrom *models.Cursor
+	// lastCursor is the cursor of the last message produced by the provider. Nil if no message was produced yet.
+	lastCursor *models.Cursor
 }
 
 // newBaseDataProvider creates a new instance of baseDataProvider.
@@ -25,6 +31,7 @@ func newBaseDataProvider(
 	cancel context.CancelFunc,
 	send chan<- interface{},
 	subscription subscription.Subscription,
+	resumeFrom *models.Cursor,
 ) *baseDataProvider {
 	return &baseDataProvider{
 		subscriptionID: subscriptionID,
@@ -33,6 +40,7 @@ func newBaseDataProvider(
 		cancel:         cancel,
 		send:           send,
 		subscription:   subscription,
+		resumeFrom:     resumeFrom,
 	}
 }
 
@@ -57,3 +65,36 @@ func (b *baseDataProvider) Arguments() models.Arguments {
 func (b *baseDataProvider) Close() {
 	b.cancel()
 }
+
+// sendResponse sends the payload produced for the block at the given height to the client, with the cursor of
+// the message attached. Messages which the client already received before resuming the subscription are dropped,
+// which guarantees that each message is delivered exactly once across reconnects.
+//
+// Must be called in the order the messages are produced, as the position of a message within a block is derived
+// from the previous message.
+func (b *baseDataProvider) sendResponse(height uint64, payload interface{}) {
+	cursor := models.Cursor{Height: height}
+	if b.lastCursor != nil && b.lastCursor.Height == height {
+		cursor.Position = b.lastCursor.Position + 1
+	}
+
+	b.sendResponseWithCursor(cursor, payload)
+}
+
+// sendResponseWithCursor sends the payload to the client with the given cursor attached. It is used by providers
+// whose messages are not produced per block, and which therefore derive a cursor that is unique per message from
+// the message itself. Messages which the client already received before resuming the subscription are dropped.
+//
+// Must be called in the order the messages are produced, and cursors must be increasing.
+func (b *baseDataProvider) sendResponseWithCursor(cursor models.Cursor, payload interface{}) {
+	b.lastCursor = &cursor
+
+	if b.resumeFrom != nil && !cursor.After(*b.resumeFrom) {
+		return
+	}
+
+	var response models.BaseDataProvidersResponse
+	response.Build(b.subscriptionID, b.topic, cursor, payload)
+
+	b.send <- &response
+}
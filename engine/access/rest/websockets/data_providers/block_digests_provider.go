@@ -52,6 +52,7 @@ func NewBlockDigestsDataProvider(
 		cancel,
 		send,
 		p.createSubscription(subCtx, blockArgs), // Set up a subscription to block digests based on arguments.
+		blockArgs.ResumeFrom,
 	)
 
 	return p, nil
@@ -63,19 +64,14 @@ func NewBlockDigestsDataProvider(
 func (p *BlockDigestsDataProvider) Run() error {
 	return subscription.HandleSubscription(
 		p.subscription,
-		subscription.HandleResponse(p.send, func(b *flow.BlockDigest) (interface{}, error) {
+		func(b *flow.BlockDigest) error {
 			var block models.BlockDigest
 			block.Build(b)
 
-			var response models.BaseDataProvidersResponse
-			response.Build(
-				p.ID(),
-				p.Topic(),
-				&block,
-			)
+			p.sendResponse(b.Height, &block)
 
-			return &response, nil
-		}),
+			return nil
+		},
 	)
 }
 
@@ -53,6 +53,7 @@ func NewBlockHeadersDataProvider(
 		cancel,
 		send,
 		p.createSubscription(subCtx, blockArgs), // Set up a subscription to block headers based on arguments.
+		blockArgs.ResumeFrom,
 	)
 
 	return p, nil
@@ -64,19 +65,14 @@ func NewBlockHeadersDataProvider(
 func (p *BlockHeadersDataProvider) Run() error {
 	return subscription.HandleSubscription(
 		p.subscription,
-		subscription.HandleResponse(p.send, func(h *flow.Header) (interface{}, error) {
+		func(h *flow.Header) error {
 			var header commonmodels.BlockHeader
 			header.Build(h)
 
-			var response models.BaseDataProvidersResponse
-			response.Build(
-				p.ID(),
-				p.Topic(),
-				&header,
-			)
+			p.sendResponse(h.Height, &header)
 
-			return &response, nil
-		}),
+			return nil
+		},
 	)
 }
 
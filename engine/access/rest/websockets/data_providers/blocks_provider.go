@@ -21,6 +21,7 @@ type blocksArguments struct {
 	StartBlockID     flow.Identifier  // ID of the block to start subscription from
 	StartBlockHeight uint64           // Height of the block to start subscription from
 	BlockStatus      flow.BlockStatus // Status of blocks to subscribe to
+	ResumeFrom       *models.Cursor   // Cursor of the last message received by the client. Nil if not set
 }
 
 // BlocksDataProvider is responsible for providing blocks
@@ -67,6 +68,7 @@ func NewBlocksDataProvider(
 		cancel,
 		send,
 		p.createSubscription(subCtx, p.arguments), // Set up a subscription to blocks based on arguments.
+		p.arguments.ResumeFrom,
 	)
 
 	return p, nil
@@ -78,20 +80,19 @@ func NewBlocksDataProvider(
 func (p *BlocksDataProvider) Run() error {
 	return subscription.HandleSubscription(
 		p.subscription,
-		subscription.HandleResponse(p.send, func(b *flow.Block) (interface{}, error) {
+		func(b *flow.Block) error {
 			var block commonmodels.Block
 
 			expandPayload := map[string]bool{commonmodels.ExpandableFieldPayload: true}
 			err := block.Build(b, nil, p.linkGenerator, p.arguments.BlockStatus, expandPayload)
 			if err != nil {
-				return nil, fmt.Errorf("failed to build block response :%w", err)
+				return fmt.Errorf("failed to build block response :%w", err)
 			}
 
-			var response models.BaseDataProvidersResponse
-			response.Build(p.ID(), p.Topic(), &block)
+			p.sendResponse(b.Header.Height, &block)
 
-			return &response, nil
-		}),
+			return nil
+		},
 	)
 }
 
@@ -114,6 +115,7 @@ func parseBlocksArguments(arguments models.Arguments) (blocksArguments, error) {
 		"start_block_id",
 		"start_block_height",
 		"block_status",
+		"resume_from_cursor",
 	}
 	err := ensureAllowedFields(arguments, allowedFields)
 	if err != nil {
@@ -145,6 +147,15 @@ func parseBlocksArguments(arguments models.Arguments) (blocksArguments, error) {
 	args.StartBlockID = startBlockID
 	args.StartBlockHeight = startBlockHeight
 
+	// Parse 'resume_from_cursor'
+	args.ResumeFrom, err = parseResumeCursor(arguments)
+	if err != nil {
+		return blocksArguments{}, err
+	}
+	if args.ResumeFrom != nil {
+		args.StartBlockHeight = args.ResumeFrom.Height
+	}
+
 	return args, nil
 }
 
@@ -186,3 +197,30 @@ func parseStartBlock(arguments models.Arguments) (flow.Identifier, uint64, error
 
 	return flow.ZeroID, request.EmptyHeight, nil
 }
+
+// parseResumeCursor parses the optional 'resume_from_cursor' argument. A resumed subscription restarts at the
+// height of the cursor, so the argument cannot be combined with 'start_block_id' or 'start_block_height'.
+// Returns nil if the argument is not provided.
+func parseResumeCursor(arguments models.Arguments) (*models.Cursor, error) {
+	cursorIn, ok := arguments["resume_from_cursor"]
+	if !ok {
+		return nil, nil
+	}
+
+	_, hasStartBlockID := arguments["start_block_id"]
+	_, hasStartBlockHeight := arguments["start_block_height"]
+	if hasStartBlockID || hasStartBlockHeight {
+		return nil, fmt.Errorf("can only provide either 'resume_from_cursor' or 'start_block_id' or 'start_block_height'")
+	}
+
+	result, ok := cursorIn.(string)
+	if !ok {
+		return nil, fmt.Errorf("'resume_from_cursor' must be a string")
+	}
+	cursor, err := models.ParseCursor(result)
+	if err != nil {
+		return nil, fmt.Errorf("invalid 'resume_from_cursor': %w", err)
+	}
+
+	return &cursor, nil
+}
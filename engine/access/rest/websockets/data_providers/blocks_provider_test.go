@@ -76,6 +76,7 @@ func (s *BlocksProviderSuite) SetupTest() {
 		flow.Testnet.Chain(),
 		state_stream.DefaultEventFilterConfig,
 		subscription.DefaultHeartbeatInterval,
+		state_stream.DefaultRegisterIDsRequestLimit,
 		s.linkGenerator,
 	)
 	s.Require().NotNil(s.factory)
@@ -177,6 +178,23 @@ func (s *BlocksProviderSuite) validBlockArgumentsTestCases() []testType {
 			},
 			expectedResponses: expectedResponses,
 		},
+		{
+			// the subscription restarts at the height of the cursor, and blocks up to the cursor are not sent again
+			name: "happy path with resume_from_cursor argument",
+			arguments: models.Arguments{
+				"resume_from_cursor": models.Cursor{Height: s.blocks[1].Header.Height}.String(),
+				"block_status":       parser.Finalized,
+			},
+			setupBackend: func(sub *statestreamsmock.Subscription) {
+				s.api.On(
+					"SubscribeBlocksFromStartHeight",
+					mock.Anything,
+					s.blocks[1].Header.Height,
+					flow.BlockStatusFinalized,
+				).Return(sub).Once()
+			},
+			expectedResponses: expectedResponses[2:],
+		},
 	}
 }
 
@@ -186,6 +204,7 @@ func (s *BlocksProviderSuite) requireBlock(actual interface{}, expected interfac
 	actualResponse, actualResponsePayload := extractPayload[*commonmodels.Block](s.T(), actual)
 
 	s.Require().Equal(expectedResponse.Topic, actualResponse.Topic)
+	s.Require().Equal(expectedResponse.Cursor, actualResponse.Cursor)
 	s.Require().Equal(expectedResponsePayload, actualResponsePayload)
 }
 
@@ -203,6 +222,7 @@ func (s *BlocksProviderSuite) expectedBlockResponses(
 
 		responses[i] = &models.BaseDataProvidersResponse{
 			Topic:   BlocksTopic,
+			Cursor:  models.Cursor{Height: b.Header.Height}.String(),
 			Payload: &block,
 		}
 	}
@@ -218,6 +238,8 @@ func (s *BlocksProviderSuite) expectedBlockResponses(
 // 2. Invalid 'block_status' argument.
 // 3. Providing both 'start_block_id' and 'start_block_height' simultaneously.
 // 4. Providing unexpected argument.
+// 5. Providing both 'resume_from_cursor' and a start block.
+// 6. Invalid 'resume_from_cursor' argument.
 func (s *BlocksProviderSuite) TestBlocksDataProvider_InvalidArguments() {
 	ctx := context.Background()
 	send := make(chan interface{})
@@ -241,6 +263,8 @@ func (s *BlocksProviderSuite) TestBlocksDataProvider_InvalidArguments() {
 // 2. Providing an unknown or invalid 'block_status' value.
 // 3. Supplying both 'start_block_id' and 'start_block_height' simultaneously, which is not allowed.
 // 4. Providing unexpected argument.
+// 5. Supplying both 'resume_from_cursor' and a start block, which is not allowed.
+// 6. Providing a malformed 'resume_from_cursor' value.
 func (s *BlocksProviderSuite) invalidArgumentsTestCases() []testErrType {
 	return []testErrType{
 		{
@@ -275,5 +299,22 @@ func (s *BlocksProviderSuite) invalidArgumentsTestCases() []testErrType {
 			},
 			expectedErrorMsg: "unexpected field: 'unexpected_argument'",
 		},
+		{
+			name: "provide both 'resume_from_cursor' and 'start_block_height' arguments",
+			arguments: models.Arguments{
+				"block_status":       parser.Finalized,
+				"start_block_height": fmt.Sprintf("%d", s.rootBlock.Header.Height),
+				"resume_from_cursor": models.Cursor{Height: s.rootBlock.Header.Height}.String(),
+			},
+			expectedErrorMsg: "can only provide either 'resume_from_cursor' or 'start_block_id' or 'start_block_height'",
+		},
+		{
+			name: "invalid 'resume_from_cursor' argument",
+			arguments: models.Arguments{
+				"block_status":       parser.Finalized,
+				"resume_from_cursor": "invalid",
+			},
+			expectedErrorMsg: "invalid 'resume_from_cursor'",
+		},
 	}
 }
@@ -22,6 +22,7 @@ type eventsArguments struct {
 	StartBlockID      flow.Identifier          // ID of the block to start subscription from
 	StartBlockHeight  uint64                   // Height of the block to start subscription from
 	Filter            state_stream.EventFilter // Filter applied to events for a given subscription
+	ResumeFrom        *models.Cursor           // Cursor of the last message received by the client. Nil if not set
 	HeartbeatInterval *uint64                  // Maximum number of blocks message won't be sent. Nil if not set
 }
 
@@ -78,6 +79,7 @@ func NewEventsDataProvider(
 		cancel,
 		send,
 		p.createSubscription(subCtx, eventArgs), // Set up a subscription to events based on arguments.
+		eventArgs.ResumeFrom,
 	)
 
 	return p, nil
@@ -116,10 +118,7 @@ func (p *EventsDataProvider) handleResponse() func(eventsResponse *backend.Event
 		var eventsPayload models.EventResponse
 		eventsPayload.Build(eventsResponse, index)
 
-		var response models.BaseDataProvidersResponse
-		response.Build(p.ID(), p.Topic(), &eventsPayload)
-
-		p.send <- &response
+		p.sendResponse(eventsResponse.Height, &eventsPayload)
 
 		return nil
 	}
@@ -151,6 +150,7 @@ func parseEventsArguments(
 		"addresses",
 		"contracts",
 		"heartbeat_interval",
+		"resume_from_cursor",
 	}
 	err := ensureAllowedFields(arguments, allowedFields)
 	if err != nil {
@@ -167,6 +167,15 @@ func parseEventsArguments(
 	args.StartBlockID = startBlockID
 	args.StartBlockHeight = startBlockHeight
 
+	// Parse 'resume_from_cursor'
+	args.ResumeFrom, err = parseResumeCursor(arguments)
+	if err != nil {
+		return eventsArguments{}, err
+	}
+	if args.ResumeFrom != nil {
+		args.StartBlockHeight = args.ResumeFrom.Height
+	}
+
 	// Parse 'event_types' as a JSON array
 	var eventTypes parser.EventTypes
 	if eventTypesIn, ok := arguments["event_types"]; ok && eventTypesIn != "" {
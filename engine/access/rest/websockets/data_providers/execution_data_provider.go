@@ -0,0 +1,157 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/counters"
+)
+
+// executionDataArguments contains the arguments required for subscribing to execution data
+type executionDataArguments struct {
+	StartBlockID     flow.Identifier // ID of the block to start subscription from
+	StartBlockHeight uint64          // Height of the block to start subscription from
+	ResumeFrom       *models.Cursor  // Cursor of the last message received by the client. Nil if not set
+}
+
+// ExecutionDataProvider is responsible for providing the execution data of blocks
+type ExecutionDataProvider struct {
+	*baseDataProvider
+
+	logger         zerolog.Logger
+	stateStreamApi state_stream.API
+	linkGenerator  commonmodels.LinkGenerator
+}
+
+var _ DataProvider = (*ExecutionDataProvider)(nil)
+
+// NewExecutionDataProvider creates a new instance of ExecutionDataProvider.
+func NewExecutionDataProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	stateStreamApi state_stream.API,
+	subscriptionID string,
+	linkGenerator commonmodels.LinkGenerator,
+	topic string,
+	arguments models.Arguments,
+	send chan<- interface{},
+) (*ExecutionDataProvider, error) {
+	if stateStreamApi == nil {
+		return nil, fmt.Errorf("this access node does not support streaming execution data")
+	}
+
+	p := &ExecutionDataProvider{
+		logger:         logger.With().Str("component", "execution-data-provider").Logger(),
+		stateStreamApi: stateStreamApi,
+		linkGenerator:  linkGenerator,
+	}
+
+	// Initialize arguments passed to the provider.
+	executionDataArgs, err := parseExecutionDataArguments(arguments)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments for execution data provider: %w", err)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+
+	p.baseDataProvider = newBaseDataProvider(
+		subscriptionID,
+		topic,
+		arguments,
+		cancel,
+		send,
+		p.createSubscription(subCtx, executionDataArgs), // Set up a subscription to execution data based on arguments.
+		executionDataArgs.ResumeFrom,
+	)
+
+	return p, nil
+}
+
+// Run starts processing the subscription for execution data and handles responses.
+//
+// No errors are expected during normal operations.
+func (p *ExecutionDataProvider) Run() error {
+	return subscription.HandleSubscription(p.subscription, p.handleResponse())
+}
+
+// createSubscription creates a new subscription using the specified input arguments.
+func (p *ExecutionDataProvider) createSubscription(ctx context.Context, args executionDataArguments) subscription.Subscription {
+	if args.StartBlockID != flow.ZeroID {
+		return p.stateStreamApi.SubscribeExecutionDataFromStartBlockID(ctx, args.StartBlockID)
+	}
+
+	if args.StartBlockHeight != request.EmptyHeight {
+		return p.stateStreamApi.SubscribeExecutionDataFromStartBlockHeight(ctx, args.StartBlockHeight)
+	}
+
+	return p.stateStreamApi.SubscribeExecutionDataFromLatest(ctx)
+}
+
+// handleResponse processes execution data and sends the formatted response.
+//
+// No errors are expected during normal operations.
+func (p *ExecutionDataProvider) handleResponse() func(executionDataResponse *backend.ExecutionDataResponse) error {
+	messageIndex := counters.NewMonotonicCounter(0)
+
+	return func(executionDataResponse *backend.ExecutionDataResponse) error {
+		index := messageIndex.Value()
+		if ok := messageIndex.Set(messageIndex.Value() + 1); !ok {
+			return status.Errorf(codes.Internal, "message index already incremented to %d", messageIndex.Value())
+		}
+
+		var executionDataPayload models.ExecutionDataResponse
+		err := executionDataPayload.Build(executionDataResponse, p.linkGenerator, index)
+		if err != nil {
+			return fmt.Errorf("failed to build execution data response: %w", err)
+		}
+
+		p.sendResponse(executionDataResponse.Height, &executionDataPayload)
+
+		return nil
+	}
+}
+
+// parseExecutionDataArguments validates and initializes the execution data arguments.
+func parseExecutionDataArguments(arguments models.Arguments) (executionDataArguments, error) {
+	allowedFields := []string{
+		"start_block_id",
+		"start_block_height",
+		"resume_from_cursor",
+	}
+	err := ensureAllowedFields(arguments, allowedFields)
+	if err != nil {
+		return executionDataArguments{}, err
+	}
+
+	var args executionDataArguments
+
+	// Parse block arguments
+	startBlockID, startBlockHeight, err := parseStartBlock(arguments)
+	if err != nil {
+		return executionDataArguments{}, err
+	}
+	args.StartBlockID = startBlockID
+	args.StartBlockHeight = startBlockHeight
+
+	// Parse 'resume_from_cursor'
+	args.ResumeFrom, err = parseResumeCursor(arguments)
+	if err != nil {
+		return executionDataArguments{}, err
+	}
+	if args.ResumeFrom != nil {
+		args.StartBlockHeight = args.ResumeFrom.Height
+	}
+
+	return args, nil
+}
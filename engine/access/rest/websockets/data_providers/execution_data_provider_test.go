@@ -0,0 +1,218 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	mockcommonmodels "github.com/onflow/flow-go/engine/access/rest/common/models/mock"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	ssmock "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// ExecutionDataProviderSuite is a test suite for testing the execution data provider functionality.
+type ExecutionDataProviderSuite struct {
+	suite.Suite
+
+	log           zerolog.Logger
+	api           *ssmock.API
+	linkGenerator *mockcommonmodels.LinkGenerator
+
+	chain     flow.Chain
+	rootBlock flow.Block
+
+	factory *DataProviderFactoryImpl
+}
+
+func TestExecutionDataProviderSuite(t *testing.T) {
+	suite.Run(t, new(ExecutionDataProviderSuite))
+}
+
+func (s *ExecutionDataProviderSuite) SetupTest() {
+	s.log = unittest.Logger()
+	s.api = ssmock.NewAPI(s.T())
+	s.linkGenerator = mockcommonmodels.NewLinkGenerator(s.T())
+
+	s.chain = flow.Testnet.Chain()
+
+	s.rootBlock = unittest.BlockFixture()
+	s.rootBlock.Header.Height = 0
+
+	s.factory = NewDataProviderFactory(
+		s.log,
+		s.api,
+		nil,
+		s.chain,
+		state_stream.DefaultEventFilterConfig,
+		subscription.DefaultHeartbeatInterval,
+		state_stream.DefaultRegisterIDsRequestLimit,
+		s.linkGenerator,
+	)
+	s.Require().NotNil(s.factory)
+}
+
+// TestExecutionDataProvider_HappyPath tests the behavior of the execution data provider
+// when it is configured correctly and operating under normal conditions. It
+// validates that execution data is correctly streamed to the channel and ensures
+// no unexpected errors occur.
+func (s *ExecutionDataProviderSuite) TestExecutionDataProvider_HappyPath() {
+	s.linkGenerator.On("TransactionLink", mock.AnythingOfType("flow.Identifier")).Return(
+		func(id flow.Identifier) (string, error) {
+			return fmt.Sprintf("/v1/transactions/%s", id), nil
+		},
+	)
+	s.linkGenerator.On("TransactionResultLink", mock.AnythingOfType("flow.Identifier")).Return(
+		func(id flow.Identifier) (string, error) {
+			return fmt.Sprintf("/v1/transaction_results/%s", id), nil
+		},
+	)
+
+	backendResponses := make([]*backend.ExecutionDataResponse, 3)
+	for i := range backendResponses {
+		backendResponses[i] = &backend.ExecutionDataResponse{
+			Height: s.rootBlock.Header.Height + uint64(i),
+			ExecutionData: unittest.BlockExecutionDataFixture(
+				unittest.WithChunkExecutionDatas(unittest.ChunkExecutionDataFixture(s.T(), 0)),
+			),
+			BlockTimestamp: s.rootBlock.Header.Timestamp,
+		}
+	}
+
+	testHappyPath(
+		s.T(),
+		ExecutionDataTopic,
+		s.factory,
+		s.subscribeExecutionDataTestCases(backendResponses),
+		func(dataChan chan interface{}) {
+			for _, response := range backendResponses {
+				dataChan <- response
+			}
+		},
+		s.requireExecutionData,
+	)
+}
+
+// subscribeExecutionDataTestCases generates test cases for execution data providers.
+func (s *ExecutionDataProviderSuite) subscribeExecutionDataTestCases(backendResponses []*backend.ExecutionDataResponse) []testType {
+	expectedResponses := make([]interface{}, len(backendResponses))
+	for i, resp := range backendResponses {
+		var expectedResponsePayload models.ExecutionDataResponse
+		err := expectedResponsePayload.Build(resp, s.linkGenerator, uint64(i))
+		s.Require().NoError(err)
+
+		expectedResponses[i] = &models.BaseDataProvidersResponse{
+			Topic:   ExecutionDataTopic,
+			Cursor:  models.Cursor{Height: resp.Height}.String(),
+			Payload: &expectedResponsePayload,
+		}
+	}
+
+	return []testType{
+		{
+			name: "SubscribeExecutionDataFromStartBlockID happy path",
+			arguments: models.Arguments{
+				"start_block_id": s.rootBlock.ID().String(),
+			},
+			setupBackend: func(sub *ssmock.Subscription) {
+				s.api.On("SubscribeExecutionDataFromStartBlockID", mock.Anything, s.rootBlock.ID()).Return(sub).Once()
+			},
+			expectedResponses: expectedResponses,
+		},
+		{
+			name: "SubscribeExecutionDataFromStartBlockHeight happy path",
+			arguments: models.Arguments{
+				"start_block_height": strconv.FormatUint(s.rootBlock.Header.Height, 10),
+			},
+			setupBackend: func(sub *ssmock.Subscription) {
+				s.api.On("SubscribeExecutionDataFromStartBlockHeight", mock.Anything, s.rootBlock.Header.Height).Return(sub).Once()
+			},
+			expectedResponses: expectedResponses,
+		},
+		{
+			name:      "SubscribeExecutionDataFromLatest happy path",
+			arguments: models.Arguments{},
+			setupBackend: func(sub *ssmock.Subscription) {
+				s.api.On("SubscribeExecutionDataFromLatest", mock.Anything).Return(sub).Once()
+			},
+			expectedResponses: expectedResponses,
+		},
+	}
+}
+
+// requireExecutionData ensures that the received execution data matches the expected data.
+func (s *ExecutionDataProviderSuite) requireExecutionData(actual interface{}, expected interface{}) {
+	expectedResponse, expectedResponsePayload := extractPayload[*models.ExecutionDataResponse](s.T(), expected)
+	actualResponse, actualResponsePayload := extractPayload[*models.ExecutionDataResponse](s.T(), actual)
+
+	s.Require().Equal(expectedResponse.Topic, actualResponse.Topic)
+	s.Require().Equal(expectedResponse.Cursor, actualResponse.Cursor)
+	s.Require().Equal(expectedResponsePayload, actualResponsePayload)
+}
+
+// TestExecutionDataProvider_InvalidArguments tests the behavior of the execution data provider
+// when invalid arguments are provided. It verifies that appropriate errors are returned
+// for missing or conflicting arguments.
+func (s *ExecutionDataProviderSuite) TestExecutionDataProvider_InvalidArguments() {
+	ctx := context.Background()
+	send := make(chan interface{})
+
+	testCases := []testErrType{
+		{
+			name: "provide both 'start_block_id' and 'start_block_height' arguments",
+			arguments: models.Arguments{
+				"start_block_id":     s.rootBlock.ID().String(),
+				"start_block_height": fmt.Sprintf("%d", s.rootBlock.Header.Height),
+			},
+			expectedErrorMsg: "can only provide either 'start_block_id' or 'start_block_height'",
+		},
+		{
+			name: "invalid 'start_block_height' argument",
+			arguments: models.Arguments{
+				"start_block_height": "-1",
+			},
+			expectedErrorMsg: "value must be an unsigned 64 bit integer",
+		},
+		{
+			name: "unexpected argument",
+			arguments: models.Arguments{
+				"heartbeat_interval": "1",
+			},
+			expectedErrorMsg: "unexpected field: 'heartbeat_interval'",
+		},
+	}
+
+	for _, test := range testCases {
+		s.Run(test.name, func() {
+			provider, err := NewExecutionDataProvider(ctx, s.log, s.api, "dummy-id", s.linkGenerator, ExecutionDataTopic, test.arguments, send)
+			s.Require().Nil(provider)
+			s.Require().Error(err)
+			s.Require().Contains(err.Error(), test.expectedErrorMsg)
+		})
+	}
+}
+
+func (s *ExecutionDataProviderSuite) TestExecutionDataProvider_StateStreamNotConfigured() {
+	provider, err := NewExecutionDataProvider(
+		context.Background(),
+		s.log,
+		nil,
+		"dummy-id",
+		s.linkGenerator,
+		ExecutionDataTopic,
+		models.Arguments{},
+		make(chan interface{}),
+	)
+	s.Require().Nil(provider)
+	s.Require().Error(err)
+	s.Require().Contains(err.Error(), "does not support streaming execution data")
+}
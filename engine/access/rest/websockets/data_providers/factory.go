@@ -23,6 +23,8 @@ const (
 	BlockDigestsTopic                  = "block_digests"
 	TransactionStatusesTopic           = "transaction_statuses"
 	SendAndGetTransactionStatusesTopic = "send_and_get_transaction_statuses"
+	ExecutionDataTopic                 = "execution_data"
+	RegisterUpdatesTopic               = "register_updates"
 )
 
 // DataProviderFactory defines an interface for creating data providers
@@ -55,7 +57,8 @@ type DataProviderFactoryImpl struct {
 
 	chain             flow.Chain
 	eventFilterConfig state_stream.EventFilterConfig
-	heartbeatInterval uint64
+	heartbeatInterval       uint64
+	registerIDsRequestLimit uint32
 
 	linkGenerator commonmodels.LinkGenerator
 }
@@ -67,6 +70,7 @@ type DataProviderFactoryImpl struct {
 // - eventFilterConfig: Configuration for filtering events from state streams.
 // - stateStreamApi: API for accessing data from the Flow state stream API.
 // - accessApi: API for accessing data from the Flow Access API.
+// - registerIDsRequestLimit: Maximum number of registers the state stream API returns per request.
 func NewDataProviderFactory(
 	logger zerolog.Logger,
 	stateStreamApi state_stream.API,
@@ -74,16 +78,18 @@ func NewDataProviderFactory(
 	chain flow.Chain,
 	eventFilterConfig state_stream.EventFilterConfig,
 	heartbeatInterval uint64,
+	registerIDsRequestLimit uint32,
 	linkGenerator commonmodels.LinkGenerator,
 ) *DataProviderFactoryImpl {
 	return &DataProviderFactoryImpl{
-		logger:            logger,
-		stateStreamApi:    stateStreamApi,
-		accessApi:         accessApi,
-		chain:             chain,
-		eventFilterConfig: eventFilterConfig,
-		heartbeatInterval: heartbeatInterval,
-		linkGenerator:     linkGenerator,
+		logger:                  logger,
+		stateStreamApi:          stateStreamApi,
+		accessApi:               accessApi,
+		chain:                   chain,
+		eventFilterConfig:       eventFilterConfig,
+		heartbeatInterval:       heartbeatInterval,
+		registerIDsRequestLimit: registerIDsRequestLimit,
+		linkGenerator:           linkGenerator,
 	}
 }
 
@@ -113,6 +119,10 @@ func (s *DataProviderFactoryImpl) NewDataProvider(ctx context.Context, subscript
 		return NewTransactionStatusesDataProvider(ctx, s.logger, s.accessApi, subscriptionID, s.linkGenerator, topic, arguments, ch)
 	case SendAndGetTransactionStatusesTopic:
 		return NewSendAndGetTransactionStatusesDataProvider(ctx, s.logger, s.accessApi, subscriptionID, s.linkGenerator, topic, arguments, ch)
+	case ExecutionDataTopic:
+		return NewExecutionDataProvider(ctx, s.logger, s.stateStreamApi, subscriptionID, s.linkGenerator, topic, arguments, ch)
+	case RegisterUpdatesTopic:
+		return NewRegisterUpdatesDataProvider(ctx, s.logger, s.stateStreamApi, subscriptionID, topic, arguments, ch, s.chain, s.eventFilterConfig, s.heartbeatInterval, s.registerIDsRequestLimit)
 	default:
 		return nil, fmt.Errorf("unsupported topic \"%s\"", topic)
 	}
@@ -52,6 +52,7 @@ func (s *DataProviderFactorySuite) SetupTest() {
 		flow.Testnet.Chain(),
 		state_stream.DefaultEventFilterConfig,
 		subscription.DefaultHeartbeatInterval,
+		state_stream.DefaultRegisterIDsRequestLimit,
 		nil,
 	)
 	s.Require().NotNil(s.factory)
@@ -131,6 +132,28 @@ func (s *DataProviderFactorySuite) TestSupportedTopics() {
 				s.stateStreamApi.AssertExpectations(s.T())
 			},
 		},
+		{
+			name:      "execution data topic",
+			topic:     ExecutionDataTopic,
+			arguments: models.Arguments{},
+			setupSubscription: func() {
+				s.setupSubscription(s.stateStreamApi.On("SubscribeExecutionDataFromLatest", mock.Anything))
+			},
+			assertExpectations: func() {
+				s.stateStreamApi.AssertExpectations(s.T())
+			},
+		},
+		{
+			name:      "register updates topic",
+			topic:     RegisterUpdatesTopic,
+			arguments: models.Arguments{},
+			setupSubscription: func() {
+				s.setupSubscription(s.stateStreamApi.On("SubscribeExecutionDataFromLatest", mock.Anything))
+			},
+			assertExpectations: func() {
+				s.stateStreamApi.AssertExpectations(s.T())
+			},
+		},
 		{
 			name:      "transaction statuses topic",
 			topic:     TransactionStatusesTopic,
@@ -0,0 +1,355 @@
+package data_providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/rest/http/request"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/counters"
+	"github.com/onflow/flow-go/module/state_synchronization/indexer"
+)
+
+// registerUpdatesArguments contains the arguments required for subscribing to register updates
+type registerUpdatesArguments struct {
+	StartBlockID      flow.Identifier           // ID of the block to start subscription from
+	StartBlockHeight  uint64                    // Height of the block to start subscription from
+	ResumeFrom        *models.Cursor            // Cursor of the last message received by the client. Nil if not set
+	Owners            map[flow.Address]struct{} // Owners of the registers to include. Empty if all registers are included
+	HeartbeatInterval *uint64                   // Maximum number of blocks message won't be sent. Nil if not set
+}
+
+// RegisterUpdatesDataProvider is responsible for providing the registers updated by each block,
+// together with their previous values.
+type RegisterUpdatesDataProvider struct {
+	*baseDataProvider
+
+	logger         zerolog.Logger
+	stateStreamApi state_stream.API
+	owners         map[flow.Address]struct{}
+
+	heartbeatInterval       uint64
+	registerIDsRequestLimit int // maximum number of registers looked up with a single GetRegisterValues call
+}
+
+var _ DataProvider = (*RegisterUpdatesDataProvider)(nil)
+
+// NewRegisterUpdatesDataProvider creates a new instance of RegisterUpdatesDataProvider.
+func NewRegisterUpdatesDataProvider(
+	ctx context.Context,
+	logger zerolog.Logger,
+	stateStreamApi state_stream.API,
+	subscriptionID string,
+	topic string,
+	arguments models.Arguments,
+	send chan<- interface{},
+	chain flow.Chain,
+	eventFilterConfig state_stream.EventFilterConfig,
+	heartbeatInterval uint64,
+	registerIDsRequestLimit uint32,
+) (*RegisterUpdatesDataProvider, error) {
+	if stateStreamApi == nil {
+		return nil, fmt.Errorf("this access node does not support streaming register updates")
+	}
+
+	if registerIDsRequestLimit == 0 {
+		registerIDsRequestLimit = state_stream.DefaultRegisterIDsRequestLimit
+	}
+
+	p := &RegisterUpdatesDataProvider{
+		logger:                  logger.With().Str("component", "register-updates-data-provider").Logger(),
+		stateStreamApi:          stateStreamApi,
+		heartbeatInterval:       heartbeatInterval,
+		registerIDsRequestLimit: int(registerIDsRequestLimit),
+	}
+
+	// Initialize arguments passed to the provider.
+	registerUpdatesArgs, err := parseRegisterUpdatesArguments(arguments, chain, eventFilterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid arguments for register updates data provider: %w", err)
+	}
+	if registerUpdatesArgs.HeartbeatInterval != nil {
+		p.heartbeatInterval = *registerUpdatesArgs.HeartbeatInterval
+	}
+	p.owners = registerUpdatesArgs.Owners
+
+	subCtx, cancel := context.WithCancel(ctx)
+
+	p.baseDataProvider = newBaseDataProvider(
+		subscriptionID,
+		topic,
+		arguments,
+		cancel,
+		send,
+		p.createSubscription(subCtx, registerUpdatesArgs), // Set up a subscription to execution data based on arguments.
+		registerUpdatesArgs.ResumeFrom,
+	)
+
+	return p, nil
+}
+
+// Run starts processing the subscription for register updates and handles responses.
+//
+// No errors are expected during normal operations.
+func (p *RegisterUpdatesDataProvider) Run() error {
+	return subscription.HandleSubscription(p.subscription, p.handleResponse())
+}
+
+// createSubscription creates a new subscription using the specified input arguments.
+func (p *RegisterUpdatesDataProvider) createSubscription(ctx context.Context, args registerUpdatesArguments) subscription.Subscription {
+	if args.StartBlockID != flow.ZeroID {
+		return p.stateStreamApi.SubscribeExecutionDataFromStartBlockID(ctx, args.StartBlockID)
+	}
+
+	if args.StartBlockHeight != request.EmptyHeight {
+		return p.stateStreamApi.SubscribeExecutionDataFromStartBlockHeight(ctx, args.StartBlockHeight)
+	}
+
+	return p.stateStreamApi.SubscribeExecutionDataFromLatest(ctx)
+}
+
+// handleResponse extracts the register updates from the execution data and sends the formatted response.
+//
+// No errors are expected during normal operations.
+func (p *RegisterUpdatesDataProvider) handleResponse() func(executionDataResponse *backend.ExecutionDataResponse) error {
+	blocksSinceLastMessage := uint64(0)
+	messageIndex := counters.NewMonotonicCounter(0)
+
+	return func(executionDataResponse *backend.ExecutionDataResponse) error {
+		updates, err := p.registerUpdates(executionDataResponse)
+		if err != nil {
+			return err
+		}
+
+		// check if there are any updates in the response. if not, do not send a message unless the last
+		// response was more than HeartbeatInterval blocks ago
+		if len(updates) == 0 {
+			blocksSinceLastMessage++
+			if blocksSinceLastMessage < p.heartbeatInterval {
+				return nil
+			}
+		}
+		blocksSinceLastMessage = 0
+
+		index := messageIndex.Value()
+		if ok := messageIndex.Set(messageIndex.Value() + 1); !ok {
+			return status.Errorf(codes.Internal, "message index already incremented to %d", messageIndex.Value())
+		}
+
+		var registerUpdatesPayload models.RegisterUpdatesResponse
+		registerUpdatesPayload.Build(executionDataResponse.ExecutionData.BlockID, executionDataResponse.Height, updates, index)
+
+		p.sendResponse(executionDataResponse.Height, &registerUpdatesPayload)
+
+		return nil
+	}
+}
+
+// registerUpdates returns the final value of every register updated by the block which matches the owner
+// filter, in the order the registers were first updated. The previous values of the registers are looked up
+// together in the register index at the parent height.
+//
+// No errors are expected during normal operations.
+func (p *RegisterUpdatesDataProvider) registerUpdates(executionDataResponse *backend.ExecutionDataResponse) ([]models.RegisterUpdate, error) {
+	var registerIDs flow.RegisterIDs
+	values := make(map[flow.RegisterID]flow.RegisterValue)
+
+	for _, chunk := range executionDataResponse.ExecutionData.ChunkExecutionDatas {
+		if chunk.TrieUpdate == nil {
+			continue
+		}
+
+		for _, payload := range chunk.TrieUpdate.Payloads {
+			registerID, value, err := convert.PayloadToRegister(payload)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert payload to register: %w", err)
+			}
+
+			if !p.matchesOwner(registerID) {
+				continue
+			}
+
+			// later chunks overwrite the values written by earlier chunks
+			if _, ok := values[registerID]; !ok {
+				registerIDs = append(registerIDs, registerID)
+			}
+			values[registerID] = value
+		}
+	}
+
+	// the old values are not available if the parent height is not indexed
+	var oldValues []flow.RegisterValue
+	if executionDataResponse.Height > 0 && len(registerIDs) > 0 {
+		var err error
+		oldValues, err = p.previousValues(registerIDs, executionDataResponse.Height-1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	updates := make([]models.RegisterUpdate, len(registerIDs))
+	for i, registerID := range registerIDs {
+		var oldValue *flow.RegisterValue
+		if oldValues != nil {
+			oldValue = &oldValues[i]
+		}
+
+		updates[i].Build(registerID, oldValue, values[registerID])
+	}
+
+	return updates, nil
+}
+
+// previousValues returns the values of the registers at the given height, in the order of the register IDs.
+// The registers are looked up with one GetRegisterValues call per registerIDsRequestLimit registers. The value
+// of a register which did not exist at the height is empty. If the height is not covered by the register
+// index, nil is returned.
+//
+// No errors are expected during normal operations.
+func (p *RegisterUpdatesDataProvider) previousValues(registerIDs flow.RegisterIDs, height uint64) ([]flow.RegisterValue, error) {
+	values := make([]flow.RegisterValue, 0, len(registerIDs))
+	for start := 0; start < len(registerIDs); start += p.registerIDsRequestLimit {
+		end := min(start+p.registerIDsRequestLimit, len(registerIDs))
+
+		batch, available, err := p.registerValues(registerIDs[start:end], height)
+		if err != nil || !available {
+			return nil, err
+		}
+		values = append(values, batch...)
+	}
+
+	return values, nil
+}
+
+// registerValues returns the values of the registers at the given height, looked up with a single
+// GetRegisterValues call. GetRegisterValues fails for all registers if one of them did not exist at
+// the height, e.g. because it was created by the block. In that case, the registers are split in halves
+// which are looked up separately, until the registers which did not exist are found. Their value is empty.
+// If the height is not covered by the register index, false is returned.
+//
+// No errors are expected during normal operations.
+func (p *RegisterUpdatesDataProvider) registerValues(registerIDs flow.RegisterIDs, height uint64) ([]flow.RegisterValue, bool, error) {
+	values, err := p.stateStreamApi.GetRegisterValues(registerIDs, height)
+	if err == nil {
+		return values, true, nil
+	}
+
+	switch {
+	case status.Code(err) == codes.NotFound:
+		if len(registerIDs) == 1 {
+			return []flow.RegisterValue{{}}, true, nil
+		}
+
+		half := len(registerIDs) / 2
+		first, available, err := p.registerValues(registerIDs[:half], height)
+		if err != nil || !available {
+			return nil, available, err
+		}
+		second, available, err := p.registerValues(registerIDs[half:], height)
+		if err != nil || !available {
+			return nil, available, err
+		}
+		return append(first, second...), true, nil
+	case status.Code(err) == codes.OutOfRange, errors.Is(err, indexer.ErrIndexNotInitialized):
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("failed to get values of %d registers at height %d: %w", len(registerIDs), height, err)
+	}
+}
+
+// matchesOwner returns true if the register is owned by one of the addresses of the filter,
+// or if no filter was provided.
+func (p *RegisterUpdatesDataProvider) matchesOwner(registerID flow.RegisterID) bool {
+	if len(p.owners) == 0 {
+		return true
+	}
+
+	_, ok := p.owners[flow.BytesToAddress([]byte(registerID.Owner))]
+	return ok && registerID.Owner != ""
+}
+
+// parseRegisterUpdatesArguments validates and initializes the register updates arguments.
+func parseRegisterUpdatesArguments(
+	arguments models.Arguments,
+	chain flow.Chain,
+	eventFilterConfig state_stream.EventFilterConfig,
+) (registerUpdatesArguments, error) {
+	allowedFields := []string{
+		"start_block_id",
+		"start_block_height",
+		"addresses",
+		"heartbeat_interval",
+		"resume_from_cursor",
+	}
+	err := ensureAllowedFields(arguments, allowedFields)
+	if err != nil {
+		return registerUpdatesArguments{}, err
+	}
+
+	var args registerUpdatesArguments
+
+	// Parse block arguments
+	startBlockID, startBlockHeight, err := parseStartBlock(arguments)
+	if err != nil {
+		return registerUpdatesArguments{}, err
+	}
+	args.StartBlockID = startBlockID
+	args.StartBlockHeight = startBlockHeight
+
+	// Parse 'resume_from_cursor'
+	args.ResumeFrom, err = parseResumeCursor(arguments)
+	if err != nil {
+		return registerUpdatesArguments{}, err
+	}
+	if args.ResumeFrom != nil {
+		args.StartBlockHeight = args.ResumeFrom.Height
+	}
+
+	// Parse 'addresses' as []string{}
+	if addressesIn, ok := arguments["addresses"]; ok && addressesIn != "" {
+		addresses, ok := addressesIn.([]string)
+		if !ok {
+			return registerUpdatesArguments{}, fmt.Errorf("'addresses' must be an array of string")
+		}
+		if len(addresses) > eventFilterConfig.MaxAddresses {
+			return registerUpdatesArguments{}, fmt.Errorf("too many addresses (%d). use %d or fewer", len(addresses), eventFilterConfig.MaxAddresses)
+		}
+
+		args.Owners = make(map[flow.Address]struct{}, len(addresses))
+		for _, address := range addresses {
+			addr, err := flow.StringToAddress(address)
+			if err != nil || !chain.IsValid(addr) {
+				return registerUpdatesArguments{}, fmt.Errorf("invalid address for chain %s: %s", chain.ChainID(), address)
+			}
+			args.Owners[addr] = struct{}{}
+		}
+	}
+
+	var heartbeatInterval uint64
+	if heartbeatIntervalIn, ok := arguments["heartbeat_interval"]; ok && heartbeatIntervalIn != "" {
+		result, ok := heartbeatIntervalIn.(string)
+		if !ok {
+			return registerUpdatesArguments{}, fmt.Errorf("'heartbeat_interval' must be a string")
+		}
+
+		heartbeatInterval, err = util.ToUint64(result)
+		if err != nil {
+			return registerUpdatesArguments{}, fmt.Errorf("invalid 'heartbeat_interval': %w", err)
+		}
+
+		args.HeartbeatInterval = &heartbeatInterval
+	}
+
+	return args, nil
+}
@@ -0,0 +1,306 @@
+package data_providers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
+	"github.com/onflow/flow-go/engine/access/state_stream"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	ssmock "github.com/onflow/flow-go/engine/access/state_stream/mock"
+	"github.com/onflow/flow-go/engine/access/subscription"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// RegisterUpdatesProviderSuite is a test suite for testing the register updates provider functionality.
+type RegisterUpdatesProviderSuite struct {
+	suite.Suite
+
+	log   zerolog.Logger
+	api   *ssmock.API
+	chain flow.Chain
+
+	registerIDsRequestLimit uint32
+}
+
+func TestRegisterUpdatesProviderSuite(t *testing.T) {
+	suite.Run(t, new(RegisterUpdatesProviderSuite))
+}
+
+func (s *RegisterUpdatesProviderSuite) SetupTest() {
+	s.log = unittest.Logger()
+	s.api = ssmock.NewAPI(s.T())
+	s.chain = flow.Testnet.Chain()
+	s.registerIDsRequestLimit = state_stream.DefaultRegisterIDsRequestLimit
+}
+
+// TestRegisterUpdatesDataProvider_HappyPath tests that the provider sends the final value of each register
+// updated by a block which matches the owner filter, together with the value at the parent height.
+func (s *RegisterUpdatesProviderSuite) TestRegisterUpdatesDataProvider_HappyPath() {
+	owner, err := s.chain.AddressAtIndex(1)
+	s.Require().NoError(err)
+	other, err := s.chain.AddressAtIndex(2)
+	s.Require().NoError(err)
+
+	register1 := flow.NewRegisterID(owner, "key1")
+	register2 := flow.NewRegisterID(owner, "key2")
+	otherRegister := flow.NewRegisterID(other, "key1")
+	globalRegister := flow.NewRegisterID(flow.EmptyAddress, "global")
+
+	height := uint64(100)
+
+	// the first block updates register1 twice and creates register2, registers of other owners are filtered out
+	first := &backend.ExecutionDataResponse{
+		Height: height,
+		ExecutionData: unittest.BlockExecutionDataFixture(unittest.WithChunkExecutionDatas(
+			chunkWithUpdates(
+				flow.RegisterEntry{Key: register1, Value: []byte("value1")},
+				flow.RegisterEntry{Key: otherRegister, Value: []byte("other")},
+				flow.RegisterEntry{Key: globalRegister, Value: []byte("global")},
+			),
+			chunkWithUpdates(flow.RegisterEntry{Key: register1, Value: []byte("value2")}),
+			chunkWithUpdates(flow.RegisterEntry{Key: register2, Value: []byte("new")}),
+		)),
+	}
+	// the parent of the second block is not indexed
+	second := &backend.ExecutionDataResponse{
+		Height: height + 1,
+		ExecutionData: unittest.BlockExecutionDataFixture(unittest.WithChunkExecutionDatas(
+			chunkWithUpdates(
+				flow.RegisterEntry{Key: register1, Value: []byte("value3")},
+				flow.RegisterEntry{Key: register2, Value: []byte("updated")},
+			),
+		)),
+	}
+
+	// the previous values are looked up together, register2 did not exist so the registers are looked up separately
+	s.api.On("GetRegisterValues", flow.RegisterIDs{register1, register2}, height-1).
+		Return(nil, status.Error(codes.NotFound, "not found")).Once()
+	s.api.On("GetRegisterValues", flow.RegisterIDs{register1}, height-1).
+		Return([]flow.RegisterValue{[]byte("value0")}, nil).Once()
+	s.api.On("GetRegisterValues", flow.RegisterIDs{register2}, height-1).
+		Return(nil, status.Error(codes.NotFound, "not found")).Once()
+	s.api.On("GetRegisterValues", mock.Anything, height).
+		Return(nil, status.Error(codes.OutOfRange, "not indexed")).Once()
+
+	responses := s.runProvider(
+		models.Arguments{
+			"start_block_height": util.FromUint(height),
+			"addresses":          []string{owner.Hex()},
+		},
+		func(sub *ssmock.Subscription) {
+			s.api.On("SubscribeExecutionDataFromStartBlockHeight", mock.Anything, height).Return(sub).Once()
+		},
+		first, second,
+	)
+	s.Require().Len(responses, 2)
+
+	s.Require().Equal(models.Cursor{Height: height}.String(), responses[0].Cursor)
+	firstPayload := responses[0].Payload.(*models.RegisterUpdatesResponse)
+	s.Require().Equal(util.FromUint(height), firstPayload.Height)
+	s.Require().Equal(first.ExecutionData.BlockID.String(), firstPayload.BlockID)
+	s.Require().Equal(uint64(0), firstPayload.MessageIndex)
+	s.Require().Equal([]models.RegisterUpdate{
+		expectedRegisterUpdate(register1, []byte("value0"), []byte("value2")),
+		expectedRegisterUpdate(register2, []byte{}, []byte("new")),
+	}, firstPayload.Updates)
+
+	secondPayload := responses[1].Payload.(*models.RegisterUpdatesResponse)
+	s.Require().Equal(uint64(1), secondPayload.MessageIndex)
+	s.Require().Len(secondPayload.Updates, 2)
+	for _, update := range secondPayload.Updates {
+		s.Require().Nil(update.OldValue)
+	}
+}
+
+// TestRegisterUpdatesDataProvider_BatchesPreviousValues tests that the previous values of all registers updated
+// by a block are looked up with one call per register request limit, and that only the batches containing
+// registers created by the block are split.
+func (s *RegisterUpdatesProviderSuite) TestRegisterUpdatesDataProvider_BatchesPreviousValues() {
+	s.registerIDsRequestLimit = 4
+
+	owner, err := s.chain.AddressAtIndex(1)
+	s.Require().NoError(err)
+
+	height := uint64(100)
+
+	registerIDs := make(flow.RegisterIDs, 6)
+	entries := make([]flow.RegisterEntry, len(registerIDs))
+	expected := make([]models.RegisterUpdate, len(registerIDs))
+	for i := range registerIDs {
+		registerIDs[i] = flow.NewRegisterID(owner, fmt.Sprintf("key%d", i))
+		entries[i] = flow.RegisterEntry{Key: registerIDs[i], Value: []byte(fmt.Sprintf("new%d", i))}
+
+		// the last register is created by the block
+		oldValue := flow.RegisterValue(fmt.Sprintf("old%d", i))
+		if i == len(registerIDs)-1 {
+			oldValue = flow.RegisterValue{}
+		}
+		expected[i] = expectedRegisterUpdate(registerIDs[i], oldValue, entries[i].Value)
+	}
+
+	response := &backend.ExecutionDataResponse{
+		Height:        height,
+		ExecutionData: unittest.BlockExecutionDataFixture(unittest.WithChunkExecutionDatas(chunkWithUpdates(entries...))),
+	}
+
+	s.api.On("GetRegisterValues", registerIDs[:4], height-1).
+		Return([]flow.RegisterValue{[]byte("old0"), []byte("old1"), []byte("old2"), []byte("old3")}, nil).Once()
+	s.api.On("GetRegisterValues", registerIDs[4:], height-1).
+		Return(nil, status.Error(codes.NotFound, "not found")).Once()
+	s.api.On("GetRegisterValues", registerIDs[4:5], height-1).
+		Return([]flow.RegisterValue{[]byte("old4")}, nil).Once()
+	s.api.On("GetRegisterValues", registerIDs[5:], height-1).
+		Return(nil, status.Error(codes.NotFound, "not found")).Once()
+
+	responses := s.runProvider(
+		models.Arguments{"start_block_height": util.FromUint(height)},
+		func(sub *ssmock.Subscription) {
+			s.api.On("SubscribeExecutionDataFromStartBlockHeight", mock.Anything, height).Return(sub).Once()
+		},
+		response,
+	)
+	s.Require().Len(responses, 1)
+	s.Require().Equal(expected, responses[0].Payload.(*models.RegisterUpdatesResponse).Updates)
+}
+
+// TestRegisterUpdatesDataProvider_InvalidArguments tests the behavior of the register updates data provider
+// when invalid arguments are provided.
+func (s *RegisterUpdatesProviderSuite) TestRegisterUpdatesDataProvider_InvalidArguments() {
+	testCases := []testErrType{
+		{
+			name: "invalid 'addresses' argument",
+			arguments: models.Arguments{
+				"addresses": []string{"0x1234"},
+			},
+			expectedErrorMsg: "invalid address for chain",
+		},
+		{
+			name: "invalid 'addresses' argument type",
+			arguments: models.Arguments{
+				"addresses": "0x1234",
+			},
+			expectedErrorMsg: "'addresses' must be an array of string",
+		},
+		{
+			name: "invalid 'heartbeat_interval' argument",
+			arguments: models.Arguments{
+				"heartbeat_interval": "-1",
+			},
+			expectedErrorMsg: "value must be an unsigned 64 bit integer",
+		},
+		{
+			name: "unexpected argument",
+			arguments: models.Arguments{
+				"event_types": []string{"flow.AccountCreated"},
+			},
+			expectedErrorMsg: "unexpected field: 'event_types'",
+		},
+	}
+
+	for _, test := range testCases {
+		s.Run(test.name, func() {
+			provider, err := NewRegisterUpdatesDataProvider(
+				context.Background(),
+				s.log,
+				s.api,
+				"dummy-id",
+				RegisterUpdatesTopic,
+				test.arguments,
+				make(chan interface{}),
+				s.chain,
+				state_stream.DefaultEventFilterConfig,
+				subscription.DefaultHeartbeatInterval,
+				state_stream.DefaultRegisterIDsRequestLimit,
+			)
+			s.Require().Nil(provider)
+			s.Require().Error(err)
+			s.Require().Contains(err.Error(), test.expectedErrorMsg)
+		})
+	}
+}
+
+// runProvider runs a register updates provider which receives the given execution data, and returns all
+// responses sent by the provider.
+func (s *RegisterUpdatesProviderSuite) runProvider(
+	arguments models.Arguments,
+	setupBackend func(sub *ssmock.Subscription),
+	executionData ...*backend.ExecutionDataResponse,
+) []*models.BaseDataProvidersResponse {
+	dataChan := make(chan interface{})
+	sub := ssmock.NewSubscription(s.T())
+	sub.On("Channel").Return((<-chan interface{})(dataChan))
+	sub.On("Err").Return(nil)
+
+	setupBackend(sub)
+
+	send := make(chan interface{}, 10)
+	provider, err := NewRegisterUpdatesDataProvider(
+		context.Background(),
+		s.log,
+		s.api,
+		"dummy-id",
+		RegisterUpdatesTopic,
+		arguments,
+		send,
+		s.chain,
+		state_stream.DefaultEventFilterConfig,
+		subscription.DefaultHeartbeatInterval,
+		s.registerIDsRequestLimit,
+	)
+	s.Require().NoError(err)
+	defer provider.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Require().NoError(provider.Run())
+	}()
+
+	go func() {
+		defer close(dataChan)
+		for _, data := range executionData {
+			dataChan <- data
+		}
+	}()
+
+	unittest.RequireCloseBefore(s.T(), done, time.Second, "provider failed to stop")
+	close(send)
+
+	var responses []*models.BaseDataProvidersResponse
+	for v := range send {
+		responses = append(responses, v.(*models.BaseDataProvidersResponse))
+	}
+	return responses
+}
+
+// chunkWithUpdates returns chunk execution data with a trie update writing the given registers.
+func chunkWithUpdates(registers ...flow.RegisterEntry) *execution_data.ChunkExecutionData {
+	update := &ledger.TrieUpdate{}
+	for _, register := range registers {
+		update.Paths = append(update.Paths, ledger.Path{})
+		update.Payloads = append(update.Payloads, ledger.NewPayload(convert.RegisterIDToLedgerKey(register.Key), register.Value))
+	}
+
+	return &execution_data.ChunkExecutionData{TrieUpdate: update}
+}
+
+// expectedRegisterUpdate returns the expected update of the register.
+func expectedRegisterUpdate(registerID flow.RegisterID, oldValue flow.RegisterValue, newValue flow.RegisterValue) models.RegisterUpdate {
+	var update models.RegisterUpdate
+	update.Build(registerID, &oldValue, newValue)
+	return update
+}
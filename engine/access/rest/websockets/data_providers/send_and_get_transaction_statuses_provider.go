@@ -66,6 +66,7 @@ func NewSendAndGetTransactionStatusesDataProvider(
 		cancel,
 		send,
 		p.createSubscription(subCtx, sendTxStatusesArgs), // Set up a subscription to tx statuses based on arguments.
+		nil,
 	)
 
 	return p, nil
@@ -102,10 +103,7 @@ func (p *SendAndGetTransactionStatusesDataProvider) handleResponse() func(txResu
 			var txStatusesPayload models.TransactionStatusesResponse
 			txStatusesPayload.Build(p.linkGenerator, txResults[i], index)
 
-			var response models.BaseDataProvidersResponse
-			response.Build(p.ID(), p.Topic(), &txStatusesPayload)
-
-			p.send <- &response
+			p.sendResponseWithCursor(transactionStatusCursor(txResults[i]), &txStatusesPayload)
 		}
 
 		return nil
@@ -56,6 +56,7 @@ func (s *SendTransactionStatusesProviderSuite) SetupTest() {
 		s.chain,
 		state_stream.DefaultEventFilterConfig,
 		subscription.DefaultHeartbeatInterval,
+		state_stream.DefaultRegisterIDsRequestLimit,
 		s.linkGenerator,
 	)
 	s.Require().NotNil(s.factory)
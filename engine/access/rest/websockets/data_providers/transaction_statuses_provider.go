@@ -25,6 +25,7 @@ type transactionStatusesArguments struct {
 	TxID             flow.Identifier // ID of the transaction to monitor.
 	StartBlockID     flow.Identifier // ID of the block to start subscription from
 	StartBlockHeight uint64          // Height of the block to start subscription from
+	ResumeFrom       *models.Cursor  // Cursor of the last message received by the client. Nil if not set
 }
 
 // TransactionStatusesDataProvider is responsible for providing tx statuses
@@ -69,6 +70,7 @@ func NewTransactionStatusesDataProvider(
 		cancel,
 		send,
 		p.createSubscription(subCtx, txStatusesArgs), // Set up a subscription to tx statuses based on arguments.
+		txStatusesArgs.ResumeFrom,
 	)
 
 	return p, nil
@@ -113,16 +115,26 @@ func (p *TransactionStatusesDataProvider) handleResponse() func(txResults []*acc
 			var txStatusesPayload models.TransactionStatusesResponse
 			txStatusesPayload.Build(p.linkGenerator, txResults[i], index)
 
-			var response models.BaseDataProvidersResponse
-			response.Build(p.ID(), p.Topic(), &txStatusesPayload)
-
-			p.send <- &response
+			p.sendResponseWithCursor(transactionStatusCursor(txResults[i]), &txStatusesPayload)
 		}
 
 		return nil
 	}
 }
 
+// transactionStatusCursor returns the cursor of a transaction status message.
+//
+// Statuses reported before the transaction is included in a block all have block height 0, so the messages of a
+// block cannot be counted to derive their position. Instead, the position is the status itself: a subscription
+// reports every status of a transaction at most once and in increasing order, so the cursor is unique per message
+// and remains valid for a resumed subscription.
+func transactionStatusCursor(txResult *access.TransactionResult) models.Cursor {
+	return models.Cursor{
+		Height:   txResult.BlockHeight,
+		Position: uint64(txResult.Status),
+	}
+}
+
 // parseAccountStatusesArguments validates and initializes the account statuses arguments.
 func parseTransactionStatusesArguments(
 	arguments models.Arguments,
@@ -131,6 +143,7 @@ func parseTransactionStatusesArguments(
 		"start_block_id",
 		"start_block_height",
 		"tx_id",
+		"resume_from_cursor",
 	}
 	err := ensureAllowedFields(arguments, allowedFields)
 	if err != nil {
@@ -147,6 +160,17 @@ func parseTransactionStatusesArguments(
 	args.StartBlockID = startBlockID
 	args.StartBlockHeight = startBlockHeight
 
+	// Parse 'resume_from_cursor'
+	args.ResumeFrom, err = parseResumeCursor(arguments)
+	if err != nil {
+		return transactionStatusesArguments{}, err
+	}
+	// Statuses reported before the transaction is included in a block have no block height, so the subscription
+	// is resumed from the latest block in that case.
+	if args.ResumeFrom != nil && args.ResumeFrom.Height > 0 {
+		args.StartBlockHeight = args.ResumeFrom.Height
+	}
+
 	if txIDIn, ok := arguments["tx_id"]; ok && txIDIn != "" {
 		result, ok := txIDIn.(string)
 		if !ok {
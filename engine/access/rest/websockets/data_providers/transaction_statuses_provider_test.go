@@ -14,6 +14,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	accessmock "github.com/onflow/flow-go/access/mock"
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
 	mockcommonmodels "github.com/onflow/flow-go/engine/access/rest/common/models/mock"
 	"github.com/onflow/flow-go/engine/access/rest/websockets/models"
 	"github.com/onflow/flow-go/engine/access/state_stream"
@@ -60,6 +61,7 @@ func (s *TransactionStatusesProviderSuite) SetupTest() {
 		s.chain,
 		state_stream.DefaultEventFilterConfig,
 		subscription.DefaultHeartbeatInterval,
+		state_stream.DefaultRegisterIDsRequestLimit,
 		s.linkGenerator,
 	)
 	s.Require().NotNil(s.factory)
@@ -296,6 +298,109 @@ func (s *TransactionStatusesProviderSuite) TestMessageIndexTransactionStatusesPr
 	}
 }
 
+// TestTransactionStatusesDataProvider_ResumeFromCursor tests that a subscription resumed from a cursor restarts at
+// the height of the cursor, and only sends the statuses which were produced after the cursor.
+func (s *TransactionStatusesProviderSuite) TestTransactionStatusesDataProvider_ResumeFromCursor() {
+	txID := unittest.IdentifierFixture()
+	height := s.rootBlock.Header.Height + 10
+
+	// the restarted subscription reports all statuses of the transaction again
+	txResults := []*access.TransactionResult{
+		{TransactionID: txID, Status: flow.TransactionStatusPending},
+		{TransactionID: txID, Status: flow.TransactionStatusFinalized, BlockHeight: height},
+		{TransactionID: txID, Status: flow.TransactionStatusExecuted, BlockHeight: height},
+		{TransactionID: txID, Status: flow.TransactionStatusSealed, BlockHeight: height},
+	}
+
+	s.linkGenerator.On("TransactionResultLink", mock.AnythingOfType("flow.Identifier")).Return(
+		func(id flow.Identifier) (string, error) {
+			return "some_link", nil
+		},
+	)
+
+	s.Run("resume after the transaction was included", func() {
+		// the client already received the pending and finalized statuses
+		cursor := models.Cursor{Height: height, Position: uint64(flow.TransactionStatusFinalized)}
+
+		responses := s.resumeTransactionStatuses(txID, cursor, "SubscribeTransactionStatusesFromStartHeight", txResults, height)
+
+		s.Require().Len(responses, 2)
+		s.Require().Equal(models.Cursor{Height: height, Position: uint64(flow.TransactionStatusExecuted)}.String(), responses[0].Cursor)
+		s.Require().Equal(models.Cursor{Height: height, Position: uint64(flow.TransactionStatusSealed)}.String(), responses[1].Cursor)
+
+		_, executed := extractPayload[*models.TransactionStatusesResponse](s.T(), responses[0])
+		s.Require().Equal(commonmodels.EXECUTED, *executed.TransactionResult.Status)
+		_, sealed := extractPayload[*models.TransactionStatusesResponse](s.T(), responses[1])
+		s.Require().Equal(commonmodels.SEALED, *sealed.TransactionResult.Status)
+	})
+
+	s.Run("resume before the transaction was included", func() {
+		// the client only received the pending status, which has no block height
+		cursor := transactionStatusCursor(txResults[0])
+
+		responses := s.resumeTransactionStatuses(txID, cursor, "SubscribeTransactionStatusesFromLatest", txResults)
+
+		s.Require().Len(responses, 3)
+		_, finalized := extractPayload[*models.TransactionStatusesResponse](s.T(), responses[0])
+		s.Require().Equal(commonmodels.FINALIZED, *finalized.TransactionResult.Status)
+	})
+}
+
+// resumeTransactionStatuses runs a transaction statuses data provider resumed from the given cursor, which receives
+// the given results from the subscription created with the given API method, and returns the sent responses.
+func (s *TransactionStatusesProviderSuite) resumeTransactionStatuses(
+	txID flow.Identifier,
+	cursor models.Cursor,
+	subscribeMethod string,
+	txResults []*access.TransactionResult,
+	startHeight ...uint64,
+) []*models.BaseDataProvidersResponse {
+	ctx := context.Background()
+	send := make(chan interface{}, 10)
+
+	txStatusesChan := make(chan interface{})
+	sub := ssmock.NewSubscription(s.T())
+	sub.On("Channel").Return((<-chan interface{})(txStatusesChan))
+	sub.On("Err").Return(nil).Once()
+
+	args := []interface{}{mock.Anything, txID}
+	for _, height := range startHeight {
+		args = append(args, height)
+	}
+	args = append(args, entities.EventEncodingVersion_JSON_CDC_V0)
+	s.api.On(subscribeMethod, args...).Return(sub).Once()
+
+	arguments := map[string]interface{}{
+		"tx_id":              txID.String(),
+		"resume_from_cursor": cursor.String(),
+	}
+
+	provider, err := NewTransactionStatusesDataProvider(ctx, s.log, s.api, "dummy-id", s.linkGenerator, TransactionStatusesTopic, arguments, send)
+	s.Require().NoError(err)
+	defer provider.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.Require().NoError(provider.Run())
+	}()
+
+	go func() {
+		defer close(txStatusesChan)
+		txStatusesChan <- txResults
+	}()
+
+	unittest.RequireCloseBefore(s.T(), done, time.Second, "provider failed to stop")
+	close(send)
+
+	var responses []*models.BaseDataProvidersResponse
+	for res := range send {
+		response, _ := extractPayload[*models.TransactionStatusesResponse](s.T(), res)
+		responses = append(responses, response)
+	}
+	return responses
+}
+
 // TestTransactionStatusesDataProvider_InvalidArguments tests the behavior of the transaction statuses data provider
 // when invalid arguments are provided. It verifies that appropriate errors are returned
 // for missing or conflicting arguments.
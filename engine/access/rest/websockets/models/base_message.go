@@ -30,14 +30,16 @@ type ErrorMessage struct {
 type BaseDataProvidersResponse struct {
 	SubscriptionID string      `json:"subscription_id"` // Unique subscriptionID
 	Topic          string      `json:"topic"`           // Topic of the subscription
+	Cursor         string      `json:"cursor"`          // Cursor of the message, used to resume the subscription after it
 	Payload        interface{} `json:"payload"`         // Payload that's being returned within a subscription.
 }
 
 // Build creates BaseDataProvidersResponse instance for consistent responses of the data providers.
-func (b *BaseDataProvidersResponse) Build(subscriptionID string, topic string, payload interface{}) {
+func (b *BaseDataProvidersResponse) Build(subscriptionID string, topic string, cursor Cursor, payload interface{}) {
 	*b = BaseDataProvidersResponse{
 		SubscriptionID: subscriptionID,
 		Topic:          topic,
+		Cursor:         cursor.String(),
 		Payload:        payload,
 	}
 }
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+)
+
+// cursorLength is the length of an encoded cursor in bytes: 8 bytes block height followed by 8 bytes position.
+const cursorLength = 16
+
+// Cursor identifies the position of a message within a subscription. It is attached to every message sent
+// by the data providers, and allows clients to resume a subscription after the message it points to.
+//
+// Clients must treat cursors as opaque strings, the encoding is not part of the API.
+type Cursor struct {
+	Height   uint64 // Height of the block the message was produced for
+	Position uint64 // Position of the message among the messages produced for the block, or a provider specific value which is unique per message
+}
+
+// String returns the opaque encoding of the cursor.
+func (c Cursor) String() string {
+	buf := make([]byte, cursorLength)
+	binary.BigEndian.PutUint64(buf[:8], c.Height)
+	binary.BigEndian.PutUint64(buf[8:], c.Position)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// After returns true if the cursor points to a message that was produced after the message of the other cursor.
+func (c Cursor) After(other Cursor) bool {
+	if c.Height != other.Height {
+		return c.Height > other.Height
+	}
+	return c.Position > other.Position
+}
+
+// ParseCursor decodes a cursor previously returned by String.
+//
+// All errors indicate that the cursor is invalid.
+func ParseCursor(raw string) (Cursor, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	if len(buf) != cursorLength {
+		return Cursor{}, fmt.Errorf("invalid cursor length: %d", len(buf))
+	}
+
+	return Cursor{
+		Height:   binary.BigEndian.Uint64(buf[:8]),
+		Position: binary.BigEndian.Uint64(buf[8:]),
+	}, nil
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+)
+
+// ExecutionDataResponse is the response message for 'execution_data' topic.
+type ExecutionDataResponse struct {
+	BlockID            string               `json:"block_id"`
+	Height             string               `json:"height"`
+	BlockTimestamp     time.Time            `json:"block_timestamp"`
+	ChunkExecutionData []ChunkExecutionData `json:"chunk_execution_data"`
+	MessageIndex       uint64               `json:"message_index"`
+}
+
+// ChunkExecutionData is the execution data of a single chunk of a block.
+type ChunkExecutionData struct {
+	Transactions       commonmodels.Transactions `json:"transactions"`
+	Events             commonmodels.Events       `json:"events"`
+	TrieUpdate         *TrieUpdate               `json:"trie_update"` // TrieUpdate is nil if the chunk did not update any registers
+	TransactionResults []LightTransactionResult  `json:"transaction_results"`
+}
+
+// TrieUpdate is the list of payloads written to the execution state trie by a chunk.
+type TrieUpdate struct {
+	RootHash string    `json:"root_hash"` // Hex encoded root hash of the trie the update was applied to
+	Paths    []string  `json:"paths"`     // Hex encoded trie paths of the payloads
+	Payloads []Payload `json:"payloads"`
+}
+
+// Payload is a single ledger payload of a trie update.
+type Payload struct {
+	KeyParts []KeyPart `json:"key_parts"`
+	Value    string    `json:"value"` // Base64 encoded value
+}
+
+// KeyPart is a typed part of a ledger key.
+type KeyPart struct {
+	Type  uint16 `json:"type"`
+	Value string `json:"value"` // Base64 encoded value
+}
+
+// LightTransactionResult is the result of a transaction as included in the execution data.
+type LightTransactionResult struct {
+	TransactionID   string `json:"transaction_id"`
+	Failed          bool   `json:"failed"`
+	ComputationUsed string `json:"computation_used"`
+}
@@ -0,0 +1,123 @@
+package models
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	commonmodels "github.com/onflow/flow-go/engine/access/rest/common/models"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/engine/access/state_stream/backend"
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+)
+
+// Build creates ExecutionDataResponse instance. Events are converted to JSON-CDC, consistent with the 'events' topic.
+//
+// No errors are expected during normal operations.
+func (e *ExecutionDataResponse) Build(
+	executionDataResponse *backend.ExecutionDataResponse,
+	linkGenerator commonmodels.LinkGenerator,
+	index uint64,
+) error {
+	chunks := make([]ChunkExecutionData, len(executionDataResponse.ExecutionData.ChunkExecutionDatas))
+	for i, chunk := range executionDataResponse.ExecutionData.ChunkExecutionDatas {
+		err := chunks[i].Build(chunk, linkGenerator)
+		if err != nil {
+			return fmt.Errorf("failed to build chunk %d execution data: %w", i, err)
+		}
+	}
+
+	*e = ExecutionDataResponse{
+		BlockID:            executionDataResponse.ExecutionData.BlockID.String(),
+		Height:             util.FromUint(executionDataResponse.Height),
+		BlockTimestamp:     executionDataResponse.BlockTimestamp,
+		ChunkExecutionData: chunks,
+		MessageIndex:       index,
+	}
+
+	return nil
+}
+
+// Build creates ChunkExecutionData instance.
+//
+// No errors are expected during normal operations.
+func (c *ChunkExecutionData) Build(chunk *execution_data.ChunkExecutionData, linkGenerator commonmodels.LinkGenerator) error {
+	var transactions commonmodels.Transactions
+	if chunk.Collection != nil {
+		transactions.Build(chunk.Collection.Transactions, linkGenerator)
+	}
+
+	jsonEvents, err := convert.CcfEventsToJsonEvents(chunk.Events)
+	if err != nil {
+		return fmt.Errorf("failed to convert events to JSON-CDC: %w", err)
+	}
+	var events commonmodels.Events
+	events.Build(jsonEvents)
+
+	var trieUpdate *TrieUpdate
+	if chunk.TrieUpdate != nil {
+		trieUpdate = &TrieUpdate{}
+		err = trieUpdate.Build(chunk.TrieUpdate)
+		if err != nil {
+			return err
+		}
+	}
+
+	results := make([]LightTransactionResult, len(chunk.TransactionResults))
+	for i, result := range chunk.TransactionResults {
+		results[i] = LightTransactionResult{
+			TransactionID:   result.TransactionID.String(),
+			Failed:          result.Failed,
+			ComputationUsed: util.FromUint(result.ComputationUsed),
+		}
+	}
+
+	*c = ChunkExecutionData{
+		Transactions:       transactions,
+		Events:             events,
+		TrieUpdate:         trieUpdate,
+		TransactionResults: results,
+	}
+
+	return nil
+}
+
+// Build creates TrieUpdate instance.
+//
+// No errors are expected during normal operations.
+func (t *TrieUpdate) Build(update *ledger.TrieUpdate) error {
+	paths := make([]string, len(update.Paths))
+	for i, path := range update.Paths {
+		paths[i] = hex.EncodeToString(path[:])
+	}
+
+	payloads := make([]Payload, len(update.Payloads))
+	for i, payload := range update.Payloads {
+		key, err := payload.Key()
+		if err != nil {
+			return fmt.Errorf("failed to decode key of payload %d: %w", i, err)
+		}
+
+		keyParts := make([]KeyPart, len(key.KeyParts))
+		for j, part := range key.KeyParts {
+			keyParts[j] = KeyPart{
+				Type:  part.Type,
+				Value: util.ToBase64(part.Value),
+			}
+		}
+
+		payloads[i] = Payload{
+			KeyParts: keyParts,
+			Value:    util.ToBase64(payload.Value()),
+		}
+	}
+
+	*t = TrieUpdate{
+		RootHash: hex.EncodeToString(update.RootHash[:]),
+		Paths:    paths,
+		Payloads: payloads,
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+package models
+
+// RegisterUpdatesResponse is the response message for 'register_updates' topic.
+type RegisterUpdatesResponse struct {
+	BlockID      string           `json:"block_id"`
+	Height       string           `json:"height"`
+	Updates      []RegisterUpdate `json:"updates"`
+	MessageIndex uint64           `json:"message_index"`
+}
+
+// RegisterUpdate is the change of a single register's value within a block.
+type RegisterUpdate struct {
+	Owner string `json:"owner"` // Hex encoded address of the register owner, empty for global registers
+	Key   string `json:"key"`   // Base64 encoded register key
+	// OldValue is the base64 encoded value of the register at the previous block. It is empty if the register
+	// did not exist, and omitted if the previous block is not covered by the register index.
+	OldValue *string `json:"old_value,omitempty"`
+	NewValue string  `json:"new_value"` // Base64 encoded value of the register after the block
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Build creates RegisterUpdatesResponse instance.
+func (r *RegisterUpdatesResponse) Build(
+	blockID flow.Identifier,
+	height uint64,
+	updates []RegisterUpdate,
+	index uint64,
+) {
+	*r = RegisterUpdatesResponse{
+		BlockID:      blockID.String(),
+		Height:       util.FromUint(height),
+		Updates:      updates,
+		MessageIndex: index,
+	}
+}
+
+// Build creates RegisterUpdate instance. The old value is nil if it is unknown.
+func (r *RegisterUpdate) Build(registerID flow.RegisterID, oldValue *flow.RegisterValue, newValue flow.RegisterValue) {
+	var owner string
+	if registerID.Owner != "" {
+		owner = flow.BytesToAddress([]byte(registerID.Owner)).Hex()
+	}
+
+	var old *string
+	if oldValue != nil {
+		encoded := util.ToBase64(*oldValue)
+		old = &encoded
+	}
+
+	*r = RegisterUpdate{
+		Owner:    owner,
+		Key:      util.ToBase64([]byte(registerID.Key)),
+		OldValue: old,
+		NewValue: util.ToBase64(newValue),
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/counters"
 	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/state_synchronization"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
@@ -53,6 +54,7 @@ const DefaultConnectionPoolSize = 250
 // Block details related calls are handled by backendBlockDetails.
 // Event related calls are handled by backendEvents.
 // Account related calls are handled by backendAccounts.
+// Account transactions index calls are handled by backendAccountTransactions.
 //
 // All remaining calls are handled by the base Backend in this file.
 type Backend struct {
@@ -62,7 +64,10 @@ type Backend struct {
 	backendBlockHeaders
 	backendBlockDetails
 	backendAccounts
+	backendAccountTransactions
 	backendExecutionResults
+	backendRegisterProofs
+	backendRegisterHistory
 	backendNetwork
 	backendSubscribeBlocks
 	backendSubscribeTransactions
@@ -108,10 +113,37 @@ type Params struct {
 	EventsIndex                *index.EventsIndex
 	TxResultQueryMode          IndexQueryMode
 	TxResultsIndex             *index.TransactionResultsIndex
+	AccountTransactionsIndex   *index.AccountTransactionsIndex
 	LastFullBlockHeight        *counters.PersistentStrictMonotonicCounter
 	IndexReporter              state_synchronization.IndexReporter
 	VersionControl             *version.VersionControl
 	ExecNodeIdentitiesProvider *commonrpc.ExecutionNodeIdentitiesProvider
+
+	// MaxEventQueryHeightRange is the maximum size of the height range of event queries, which are
+	// served from the local event index. If 0, DefaultMaxEventQueryHeightRange is used.
+	MaxEventQueryHeightRange uint
+
+	// AccountTransactionsMaxPageSize is the maximum number of entries returned per page of account
+	// transactions. If 0, DefaultMaxAccountTransactionsPageSize is used.
+	AccountTransactionsMaxPageSize uint32
+
+	// TransactionTimelines stores the lifecycle timelines of transactions submitted to the node.
+	// If nil, timelines are not tracked.
+	TransactionTimelines mempool.TransactionTimings
+
+	// RegisterProver proves register values against the state commitments of sealed blocks.
+	// If nil, register proofs are not available.
+	RegisterProver execution.RegisterProver
+
+	// Registers provides the locally indexed registers, used to serve register history.
+	// If nil, register history is not available.
+	Registers *execution.RegistersAsyncStore
+
+	// ScriptResultCacheSize is the number of script results cached. If 0, results are not cached.
+	ScriptResultCacheSize uint
+	// ScriptResultCacheMaxResultSize is the maximum size in bytes of a cached script result.
+	// If 0, DefaultScriptResultCacheMaxResultSize is used.
+	ScriptResultCacheMaxResultSize uint
 }
 
 var _ TransactionErrorMessage = (*Backend)(nil)
@@ -136,6 +168,18 @@ func New(params Params) (*Backend, error) {
 		}
 	}
 
+	var scriptResCache *scriptResultCache
+	if params.ScriptResultCacheSize > 0 {
+		maxResultSize := params.ScriptResultCacheMaxResultSize
+		if maxResultSize == 0 {
+			maxResultSize = DefaultScriptResultCacheMaxResultSize
+		}
+		scriptResCache, err = newScriptResultCache(params.ScriptResultCacheSize, maxResultSize, params.AccessMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to init cache for script results: %w", err)
+		}
+	}
+
 	// the system tx is hardcoded and never changes during runtime
 	systemTx, err := blueprints.SystemChunkTransaction(params.ChainID.Chain())
 	if err != nil {
@@ -143,6 +187,16 @@ func New(params Params) (*Backend, error) {
 	}
 	systemTxID := systemTx.ID()
 
+	maxEventQueryHeightRange := params.MaxEventQueryHeightRange
+	if maxEventQueryHeightRange == 0 {
+		maxEventQueryHeightRange = DefaultMaxEventQueryHeightRange
+	}
+
+	accountTransactionsMaxPageSize := params.AccountTransactionsMaxPageSize
+	if accountTransactionsMaxPageSize == 0 {
+		accountTransactionsMaxPageSize = DefaultMaxAccountTransactionsPageSize
+	}
+
 	transactionsLocalDataProvider := &TransactionsLocalDataProvider{
 		state:               params.State,
 		collections:         params.Collections,
@@ -168,6 +222,7 @@ func New(params Params) (*Backend, error) {
 			scriptExecutor:             params.ScriptExecutor,
 			scriptExecMode:             params.ScriptExecutionMode,
 			execNodeIdentitiesProvider: params.ExecNodeIdentitiesProvider,
+			resultCache:                scriptResCache,
 		},
 		backendEvents: backendEvents{
 			log:                        params.Log,
@@ -176,6 +231,7 @@ func New(params Params) (*Backend, error) {
 			headers:                    params.Headers,
 			connFactory:                params.ConnFactory,
 			maxHeightRange:             params.MaxHeightRange,
+			maxQueryHeightRange:        maxEventQueryHeightRange,
 			nodeCommunicator:           params.Communicator,
 			queryMode:                  params.EventQueryMode,
 			eventsIndex:                params.EventsIndex,
@@ -199,9 +255,25 @@ func New(params Params) (*Backend, error) {
 			scriptExecMode:             params.ScriptExecutionMode,
 			execNodeIdentitiesProvider: params.ExecNodeIdentitiesProvider,
 		},
+		backendAccountTransactions: backendAccountTransactions{
+			log:         params.Log,
+			index:       params.AccountTransactionsIndex,
+			maxPageSize: accountTransactionsMaxPageSize,
+		},
 		backendExecutionResults: backendExecutionResults{
 			executionResults: params.ExecutionResults,
 		},
+		backendRegisterProofs: backendRegisterProofs{
+			log:              params.Log,
+			state:            params.State,
+			headers:          params.Headers,
+			executionResults: params.ExecutionResults,
+			prover:           params.RegisterProver,
+		},
+		backendRegisterHistory: backendRegisterHistory{
+			log:       params.Log,
+			registers: params.Registers,
+		},
 		backendNetwork: backendNetwork{
 			state:                params.State,
 			chainID:              params.ChainID,
@@ -245,6 +317,7 @@ func New(params Params) (*Backend, error) {
 		nodeCommunicator:              params.Communicator,
 		txResultCache:                 txResCache,
 		txResultQueryMode:             params.TxResultQueryMode,
+		txTimelines:                   params.TransactionTimelines,
 		systemTx:                      systemTx,
 		systemTxID:                    systemTxID,
 		execNodeIdentitiesProvider:    params.ExecNodeIdentitiesProvider,
@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DefaultMaxAccountTransactionsPageSize is the default maximum number of entries returned in a single page
+// of account transactions.
+const DefaultMaxAccountTransactionsPageSize = 250
+
+// backendAccountTransactions serves queries against the account-to-transaction index.
+// Since the index is keyed by address and height, the cost of a query is bound by the page size
+// rather than by the height range, so the range is not limited.
+type backendAccountTransactions struct {
+	log         zerolog.Logger
+	index       *index.AccountTransactionsIndex
+	maxPageSize uint32
+}
+
+// GetTransactionsByAccount returns a page of transactions the account with the given address participated
+// in as proposer, payer or authorizer, within the height range [startHeight, endHeight] (inclusive).
+// If limit is 0, the maximum page size is used. If cursor is not nil, results start at the cursor position.
+//
+// The end height is limited to the highest indexed height. Clients should use the returned cursor to
+// request the next page, since cursors remain valid across restarts of the node.
+//
+// Expected errors:
+//   - codes.Unimplemented if the account transactions index is disabled
+//   - codes.InvalidArgument if the request parameters are invalid
+//   - codes.OutOfRange if the requested heights are not indexed
+//   - codes.FailedPrecondition if the index is not initialized yet
+func (b *backendAccountTransactions) GetTransactionsByAccount(
+	_ context.Context,
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	limit uint32,
+	cursor *flow.AccountTransactionCursor,
+) (*flow.AccountTransactionsPage, error) {
+	if b.index == nil {
+		return nil, status.Error(codes.Unimplemented, "account transactions index is disabled")
+	}
+
+	if endHeight < startHeight {
+		return nil, status.Error(codes.InvalidArgument, "start height must not be larger than end height")
+	}
+
+	if limit == 0 || limit > b.maxPageSize {
+		limit = b.maxPageSize
+	}
+
+	highestHeight, err := b.index.HighestIndexedHeight()
+	if err != nil {
+		return nil, rpc.ConvertIndexError(err, endHeight, "failed to get highest indexed height")
+	}
+
+	if startHeight > highestHeight {
+		return nil, status.Errorf(codes.OutOfRange,
+			"start height %d is greater than the highest indexed height %d", startHeight, highestHeight)
+	}
+
+	// limit max height to the highest indexed block, similar to event range queries
+	if endHeight > highestHeight {
+		endHeight = highestHeight
+	}
+
+	if cursor != nil && (cursor.BlockHeight < startHeight || cursor.BlockHeight > endHeight) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"cursor height %d is outside the requested range [%d, %d]", cursor.BlockHeight, startHeight, endHeight)
+	}
+
+	page, err := b.index.ByAddress(address, startHeight, endHeight, cursor, limit)
+	if err != nil {
+		return nil, rpc.ConvertIndexError(err, startHeight, "failed to get account transactions")
+	}
+
+	return page, nil
+}
@@ -0,0 +1,133 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/access/index"
+	"github.com/onflow/flow-go/model/flow"
+	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetTransactionsByAccount(t *testing.T) {
+	address := unittest.AddressFixture()
+	lowest := uint64(10)
+	highest := uint64(100)
+
+	setupWithFirstHeight := func(t *testing.T, firstHeight uint64, firstHeightErr error) (*backendAccountTransactions, *storagemock.AccountTransactions) {
+		reporter := syncmock.NewIndexReporter(t)
+		reporter.On("LowestIndexedHeight").Return(lowest, nil).Maybe()
+		reporter.On("HighestIndexedHeight").Return(highest, nil).Maybe()
+
+		accountTransactions := storagemock.NewAccountTransactions(t)
+		accountTransactions.On("FirstIndexedHeight").Return(firstHeight, firstHeightErr).Maybe()
+		indexReporter := index.NewReporter()
+		require.NoError(t, indexReporter.Initialize(reporter))
+
+		return &backendAccountTransactions{
+			log:         unittest.Logger(),
+			index:       index.NewAccountTransactionsIndex(indexReporter, accountTransactions),
+			maxPageSize: 5,
+		}, accountTransactions
+	}
+
+	setup := func(t *testing.T) (*backendAccountTransactions, *storagemock.AccountTransactions) {
+		return setupWithFirstHeight(t, lowest, nil)
+	}
+
+	t.Run("returns page from index", func(t *testing.T) {
+		backend, accountTransactions := setup(t)
+
+		expected := &flow.AccountTransactionsPage{
+			Transactions: []flow.AccountTransaction{{
+				Address:       address,
+				BlockHeight:   20,
+				TransactionID: unittest.IdentifierFixture(),
+				Roles:         flow.TransactionRolePayer,
+			}},
+		}
+		accountTransactions.
+			On("ByAddress", address, uint64(20), uint64(30), (*flow.AccountTransactionCursor)(nil), uint32(3)).
+			Return(expected, nil).
+			Once()
+
+		page, err := backend.GetTransactionsByAccount(context.Background(), address, 20, 30, 3, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expected, page)
+	})
+
+	t.Run("limits end height and page size", func(t *testing.T) {
+		backend, accountTransactions := setup(t)
+
+		accountTransactions.
+			On("ByAddress", address, uint64(20), highest, (*flow.AccountTransactionCursor)(nil), uint32(5)).
+			Return(&flow.AccountTransactionsPage{}, nil).
+			Once()
+
+		_, err := backend.GetTransactionsByAccount(context.Background(), address, 20, highest+100, 0, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		_, err := backend.GetTransactionsByAccount(context.Background(), address, 30, 20, 0, nil)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		cursor := &flow.AccountTransactionCursor{BlockHeight: 50}
+		_, err = backend.GetTransactionsByAccount(context.Background(), address, 10, 20, 0, cursor)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("heights not indexed", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		_, err := backend.GetTransactionsByAccount(context.Background(), address, highest+1, highest+10, 0, nil)
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+
+		_, err = backend.GetTransactionsByAccount(context.Background(), address, 0, 20, 0, nil)
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("index enabled after earlier heights were indexed", func(t *testing.T) {
+		firstHeight := uint64(50)
+		backend, accountTransactions := setupWithFirstHeight(t, firstHeight, nil)
+
+		// heights indexed by the execution state indexer before the index was enabled are not available
+		_, err := backend.GetTransactionsByAccount(context.Background(), address, 20, 60, 0, nil)
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+
+		accountTransactions.
+			On("ByAddress", address, firstHeight, uint64(60), (*flow.AccountTransactionCursor)(nil), uint32(5)).
+			Return(&flow.AccountTransactionsPage{}, nil).
+			Once()
+
+		_, err = backend.GetTransactionsByAccount(context.Background(), address, firstHeight, 60, 0, nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("nothing indexed since the index was enabled", func(t *testing.T) {
+		backend, _ := setupWithFirstHeight(t, 0, storage.ErrNotFound)
+
+		_, err := backend.GetTransactionsByAccount(context.Background(), address, 20, 30, 0, nil)
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("index disabled", func(t *testing.T) {
+		backend := &backendAccountTransactions{
+			log:         unittest.Logger(),
+			maxPageSize: 5,
+		}
+
+		_, err := backend.GetTransactionsByAccount(context.Background(), address, 10, 20, 0, nil)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+}
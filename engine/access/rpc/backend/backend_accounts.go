@@ -10,6 +10,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/onflow/cadence/common"
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
 
 	"github.com/onflow/flow-go/engine/access/rpc/connection"
@@ -24,6 +25,9 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
+// MaxAccountStoragePageSize is the maximum number of values returned in a single page of account storage.
+const MaxAccountStoragePageSize = 500
+
 type backendAccounts struct {
 	log                        zerolog.Logger
 	state                      protocol.State
@@ -210,6 +214,54 @@ func (b *backendAccounts) GetAccountKeysAtBlockHeight(
 
 }
 
+// GetAccountStorageAtLatestBlock returns a page of the values stored in the account at the latest sealed block.
+// If limit is 0, MaxAccountStoragePageSize is used. If cursor is not nil, results start at the cursor position.
+func (b *backendAccounts) GetAccountStorageAtLatestBlock(
+	ctx context.Context,
+	address flow.Address,
+	limit uint32,
+	cursor *flow.AccountStorageCursor,
+) (*flow.AccountStorage, error) {
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		err := irrecoverable.NewExceptionf("failed to lookup sealed header: %w", err)
+		irrecoverable.Throw(ctx, err)
+		return nil, err
+	}
+
+	accountStorage, err := b.getAccountStorageFromLocalStorage(ctx, address, sealed.Height, limit, cursor)
+	if err != nil {
+		b.log.Debug().Err(err).Msgf("failed to get account storage at blockID: %v", sealed.ID())
+		return nil, err
+	}
+
+	return accountStorage, nil
+}
+
+// GetAccountStorageAtBlockHeight returns a page of the values stored in the account at the given block height.
+// If limit is 0, MaxAccountStoragePageSize is used. If cursor is not nil, results start at the cursor position.
+// Cursors are only valid for the height they were returned for.
+func (b *backendAccounts) GetAccountStorageAtBlockHeight(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+	limit uint32,
+	cursor *flow.AccountStorageCursor,
+) (*flow.AccountStorage, error) {
+	_, err := b.headers.BlockIDByHeight(height)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(resolveHeightError(b.state.Params(), height, err))
+	}
+
+	accountStorage, err := b.getAccountStorageFromLocalStorage(ctx, address, height, limit, cursor)
+	if err != nil {
+		b.log.Debug().Err(err).Msgf("failed to get account storage at height: %d", height)
+		return nil, err
+	}
+
+	return accountStorage, nil
+}
+
 // getAccountAtBlock returns the account details at the given block
 //
 // The data may be sourced from the local storage or from an execution node depending on the nodes's
@@ -406,6 +458,36 @@ func (b *backendAccounts) getAccountFromLocalStorage(
 	return account, nil
 }
 
+// getAccountStorageFromLocalStorage inspects the storage of the given account using the locally indexed registers.
+// Execution nodes do not expose account storage, so the data is never sourced from them.
+func (b *backendAccounts) getAccountStorageFromLocalStorage(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+	limit uint32,
+	cursor *flow.AccountStorageCursor,
+) (*flow.AccountStorage, error) {
+	if b.scriptExecutor == nil {
+		return nil, status.Error(codes.Unimplemented, "account storage inspection requires the execution state index")
+	}
+
+	if cursor != nil {
+		if _, ok := common.StorageDomainFromIdentifier(cursor.Domain); !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid cursor: unknown storage domain %s", cursor.Domain)
+		}
+	}
+
+	if limit == 0 || limit > MaxAccountStoragePageSize {
+		limit = MaxAccountStoragePageSize
+	}
+
+	accountStorage, err := b.scriptExecutor.GetAccountStorage(ctx, address, height, uint(limit), cursor)
+	if err != nil {
+		return nil, convertAccountError(resolveHeightError(b.state.Params(), height, err), address, height)
+	}
+	return accountStorage, nil
+}
+
 // getAccountFromAnyExeNode retrieves the given account from any EN in `execNodes`.
 // We attempt querying each EN in sequence. If any EN returns a valid response, then errors from
 // other ENs are logged and swallowed. If all ENs fail to return a valid response, then an
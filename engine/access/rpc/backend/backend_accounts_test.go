@@ -587,6 +587,98 @@ func (s *BackendAccountsSuite) TestGetAccountKeyFromFailover_HappyPath() {
 	}
 }
 
+// TestGetAccountStorageFromStorage_HappyPath tests successfully inspecting the account storage from local
+// storage. Account storage is always inspected locally, regardless of the script execution mode.
+func (s *BackendAccountsSuite) TestGetAccountStorageFromStorage_HappyPath() {
+	ctx := context.Background()
+
+	accountStorage := &flow.AccountStorage{
+		Address:     s.account.Address,
+		StorageUsed: 1000,
+		Domains: []flow.AccountStorageDomain{{
+			Name:  "storage",
+			Items: []flow.AccountStorageItem{{Key: "/storage/foo", Type: "String", Size: 10}},
+		}},
+	}
+
+	cursor := &flow.AccountStorageCursor{Domain: "storage", Index: 5}
+
+	scriptExecutor := execmock.NewScriptExecutor(s.T())
+	// the limit defaults to the maximum page size, and larger limits are capped
+	scriptExecutor.On("GetAccountStorage", mock.Anything, s.account.Address, s.block.Header.Height, uint(MaxAccountStoragePageSize), (*flow.AccountStorageCursor)(nil)).
+		Return(accountStorage, nil)
+	scriptExecutor.On("GetAccountStorage", mock.Anything, s.account.Address, s.block.Header.Height, uint(10), cursor).
+		Return(accountStorage, nil)
+
+	backend := s.defaultBackend()
+	backend.scriptExecMode = IndexQueryModeExecutionNodesOnly
+	backend.scriptExecutor = scriptExecutor
+
+	s.Run("GetAccountStorageAtLatestBlock - happy path", func() {
+		s.state.On("Sealed").Return(s.snapshot, nil).Once()
+		s.snapshot.On("Head").Return(s.block.Header, nil).Once()
+
+		actual, err := backend.GetAccountStorageAtLatestBlock(ctx, s.account.Address, 0, nil)
+		s.Require().NoError(err)
+		s.Require().Equal(accountStorage, actual)
+	})
+
+	s.Run("GetAccountStorageAtBlockHeight - happy path", func() {
+		s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.Header.ID(), nil).Once()
+
+		actual, err := backend.GetAccountStorageAtBlockHeight(ctx, s.account.Address, s.block.Header.Height, MaxAccountStoragePageSize+1, nil)
+		s.Require().NoError(err)
+		s.Require().Equal(accountStorage, actual)
+	})
+
+	s.Run("GetAccountStorageAtBlockHeight - with limit and cursor", func() {
+		s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.Header.ID(), nil).Once()
+
+		actual, err := backend.GetAccountStorageAtBlockHeight(ctx, s.account.Address, s.block.Header.Height, 10, cursor)
+		s.Require().NoError(err)
+		s.Require().Equal(accountStorage, actual)
+	})
+}
+
+// TestGetAccountStorageFromStorage_Fails tests that errors received while inspecting the account storage
+// are converted to the appropriate status code
+func (s *BackendAccountsSuite) TestGetAccountStorageFromStorage_Fails() {
+	ctx := context.Background()
+
+	s.Run("script executor not configured", func() {
+		backend := s.defaultBackend()
+		s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.Header.ID(), nil).Once()
+
+		_, err := backend.GetAccountStorageAtBlockHeight(ctx, s.account.Address, s.block.Header.Height, 0, nil)
+		s.Require().Equal(codes.Unimplemented, status.Code(err))
+	})
+
+	s.Run("unknown cursor domain", func() {
+		backend := s.defaultBackend()
+		backend.scriptExecutor = execmock.NewScriptExecutor(s.T())
+		s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.Header.ID(), nil).Once()
+
+		cursor := &flow.AccountStorageCursor{Domain: "unknown", Index: 1}
+		_, err := backend.GetAccountStorageAtBlockHeight(ctx, s.account.Address, s.block.Header.Height, 0, cursor)
+		s.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	s.Run("height not indexed", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("GetAccountStorage", mock.Anything, s.failingAddress, s.block.Header.Height, mock.Anything, mock.Anything).
+			Return(nil, storage.ErrHeightNotIndexed).Once()
+
+		backend := s.defaultBackend()
+		backend.scriptExecutor = scriptExecutor
+
+		s.headers.On("BlockIDByHeight", s.block.Header.Height).Return(s.block.Header.ID(), nil).Once()
+		s.state.On("Params").Return(s.params).Once()
+
+		_, err := backend.GetAccountStorageAtBlockHeight(ctx, s.failingAddress, s.block.Header.Height, 0, nil)
+		s.Require().Equal(codes.OutOfRange, status.Code(err))
+	})
+}
+
 func (s *BackendAccountsSuite) testGetAccount(ctx context.Context, backend *backendAccounts, statusCode codes.Code) {
 	s.state.On("Sealed").Return(s.snapshot, nil).Once()
 	s.snapshot.On("Head").Return(s.block.Header, nil).Once()
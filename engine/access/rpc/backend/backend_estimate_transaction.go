@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// EstimateTransaction executes the transaction against the execution state of the latest sealed block, without
+// verifying its signatures and sequence number, and returns the computation, memory, events and fees it would
+// use. The resulting state changes are discarded.
+//
+// Estimation requires the local execution state index. Errors of the transaction itself are reported in the
+// returned estimate rather than as an error.
+func (b *backendScripts) EstimateTransaction(ctx context.Context, tx *flow.TransactionBody) (*flow.TransactionEstimate, error) {
+	if b.scriptExecutor == nil {
+		return nil, status.Error(codes.Unimplemented, "transaction estimation requires the execution state index")
+	}
+
+	if len(tx.Script) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "transaction script is empty")
+	}
+
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		err := irrecoverable.NewExceptionf("failed to lookup sealed header: %w", err)
+		irrecoverable.Throw(ctx, err)
+		return nil, err
+	}
+
+	estimate, err := b.scriptExecutor.EstimateTransaction(ctx, tx, sealed.Height)
+	if err != nil {
+		b.log.Debug().Err(err).
+			Hex("tx_id", logging.Entity(tx)).
+			Uint64("height", sealed.Height).
+			Msg("failed to estimate transaction")
+		return nil, rpc.ConvertIndexError(
+			resolveHeightError(b.state.Params(), sealed.Height, err),
+			sealed.Height,
+			"failed to estimate transaction",
+		)
+	}
+
+	return estimate, nil
+}
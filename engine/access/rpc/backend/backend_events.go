@@ -27,6 +27,11 @@ import (
 	"github.com/onflow/flow-go/storage"
 )
 
+// DefaultMaxEventQueryHeightRange is the default maximum size of the height range of event queries.
+// Queries are served from the local event index and only return matching events, so they can cover
+// a much larger range than GetEventsForHeightRange, which may fetch events from execution nodes.
+const DefaultMaxEventQueryHeightRange = 10_000
+
 type backendEvents struct {
 	headers                    storage.Headers
 	state                      protocol.State
@@ -34,6 +39,7 @@ type backendEvents struct {
 	connFactory                connection.ConnectionFactory
 	log                        zerolog.Logger
 	maxHeightRange             uint
+	maxQueryHeightRange        uint
 	nodeCommunicator           Communicator
 	queryMode                  IndexQueryMode
 	eventsIndex                *index.EventsIndex
@@ -67,6 +73,69 @@ func (b *backendEvents) GetEventsForHeightRange(
 			"requested block range (%d) exceeded maximum (%d)", rangeSize, b.maxHeightRange)
 	}
 
+	blockHeaders, err := b.blocksForHeightRange(ctx, startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.getBlockEvents(ctx, blockHeaders, eventType, requiredEventEncodingVersion)
+}
+
+// GetEventsByQuery retrieves events for all sealed blocks between the start block height and the end
+// block height (inclusive) that match the query.
+//
+// Field predicates are evaluated against the event payloads, so the query is only served from the
+// local event index and is not supported in the execution-nodes-only query mode. For the same reason,
+// the height range is limited by the larger maxQueryHeightRange rather than maxHeightRange.
+func (b *backendEvents) GetEventsByQuery(
+	ctx context.Context,
+	query *events.Query,
+	startHeight, endHeight uint64,
+	requiredEventEncodingVersion entities.EventEncodingVersion,
+) ([]flow.BlockEvents, error) {
+	if b.queryMode == IndexQueryModeExecutionNodesOnly {
+		return nil, status.Error(codes.Unimplemented, "event queries require the local event index to be enabled")
+	}
+
+	if query == nil {
+		return nil, status.Error(codes.InvalidArgument, "query must be provided")
+	}
+
+	if endHeight < startHeight {
+		return nil, status.Error(codes.InvalidArgument, "start height must not be larger than end height")
+	}
+
+	rangeSize := endHeight - startHeight + 1 // range is inclusive on both ends
+	if rangeSize > uint64(b.maxQueryHeightRange) {
+		return nil, status.Errorf(codes.InvalidArgument,
+			"requested block range (%d) exceeded maximum (%d)", rangeSize, b.maxQueryHeightRange)
+	}
+
+	blockHeaders, err := b.blocksForHeightRange(ctx, startHeight, endHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	response, missingBlocks, err := b.getBlockEventsFromStorage(ctx, blockHeaders, query.Match, requiredEventEncodingVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// all blocks should be available, since execution nodes cannot evaluate the query.
+	if len(missingBlocks) > 0 {
+		return nil, status.Errorf(codes.NotFound, "events not found in local storage for %d blocks", len(missingBlocks))
+	}
+
+	return response, nil
+}
+
+// blocksForHeightRange returns the metadata of all sealed blocks between the start and end height (inclusive).
+// The end height is limited to the latest sealed height.
+//
+// Expected errors during normal operations:
+//   - codes.OutOfRange if the start height is greater than the latest sealed height
+//   - codes.NotFound if a block within the range is not available in storage
+func (b *backendEvents) blocksForHeightRange(ctx context.Context, startHeight, endHeight uint64) ([]blockMetadata, error) {
 	// get the latest sealed block header
 	sealed, err := b.state.Sealed().Head()
 	if err != nil {
@@ -118,7 +187,7 @@ func (b *backendEvents) GetEventsForHeightRange(
 		})
 	}
 
-	return b.getBlockEvents(ctx, blockHeaders, eventType, requiredEventEncodingVersion)
+	return blockHeaders, nil
 }
 
 // GetEventsForBlockIDs retrieves events for all the specified block IDs that have the given type
@@ -165,12 +234,16 @@ func (b *backendEvents) getBlockEvents(
 		return nil, status.Errorf(codes.InvalidArgument, "invalid event type: %v", err)
 	}
 
+	matchType := func(e flow.Event) bool {
+		return e.Type == target
+	}
+
 	switch b.queryMode {
 	case IndexQueryModeExecutionNodesOnly:
 		return b.getBlockEventsFromExecutionNode(ctx, blockInfos, eventType, requiredEventEncodingVersion)
 
 	case IndexQueryModeLocalOnly:
-		localResponse, missingBlocks, err := b.getBlockEventsFromStorage(ctx, blockInfos, target, requiredEventEncodingVersion)
+		localResponse, missingBlocks, err := b.getBlockEventsFromStorage(ctx, blockInfos, matchType, requiredEventEncodingVersion)
 		if err != nil {
 			return nil, err
 		}
@@ -181,7 +254,7 @@ func (b *backendEvents) getBlockEvents(
 		return localResponse, nil
 
 	case IndexQueryModeFailover:
-		localResponse, missingBlocks, err := b.getBlockEventsFromStorage(ctx, blockInfos, target, requiredEventEncodingVersion)
+		localResponse, missingBlocks, err := b.getBlockEventsFromStorage(ctx, blockInfos, matchType, requiredEventEncodingVersion)
 		if err != nil {
 			// if there was an error, request all blocks from execution nodes
 			missingBlocks = blockInfos
@@ -218,12 +291,12 @@ func (b *backendEvents) getBlockEvents(
 	}
 }
 
-// getBlockEventsFromStorage retrieves events for all the specified blocks that have the given type
-// from the local storage
+// getBlockEventsFromStorage retrieves events for all the specified blocks that are accepted by the
+// match function from the local storage
 func (b *backendEvents) getBlockEventsFromStorage(
 	ctx context.Context,
 	blockInfos []blockMetadata,
-	eventType flow.EventType,
+	match func(flow.Event) bool,
 	requiredEventEncodingVersion entities.EventEncodingVersion,
 ) ([]flow.BlockEvents, []blockMetadata, error) {
 	missing := make([]blockMetadata, 0)
@@ -248,7 +321,7 @@ func (b *backendEvents) getBlockEventsFromStorage(
 
 		filteredEvents := make([]flow.Event, 0)
 		for _, e := range events {
-			if e.Type != eventType {
+			if !match(e) {
 				continue
 			}
 
@@ -23,6 +23,7 @@ import (
 	connectionmock "github.com/onflow/flow-go/engine/access/rpc/connection/mock"
 	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/model/events"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/irrecoverable"
 	syncmock "github.com/onflow/flow-go/module/state_synchronization/mock"
@@ -177,15 +178,16 @@ func (s *BackendEventsSuite) SetupTest() {
 
 func (s *BackendEventsSuite) defaultBackend() *backendEvents {
 	return &backendEvents{
-		log:              s.log,
-		chain:            s.chainID.Chain(),
-		state:            s.state,
-		headers:          s.headers,
-		connFactory:      s.connectionFactory,
-		nodeCommunicator: NewNodeCommunicator(false),
-		maxHeightRange:   DefaultMaxHeightRange,
-		queryMode:        IndexQueryModeExecutionNodesOnly,
-		eventsIndex:      s.eventsIndex,
+		log:                 s.log,
+		chain:               s.chainID.Chain(),
+		state:               s.state,
+		headers:             s.headers,
+		connFactory:         s.connectionFactory,
+		nodeCommunicator:    NewNodeCommunicator(false),
+		maxHeightRange:      DefaultMaxHeightRange,
+		maxQueryHeightRange: DefaultMaxEventQueryHeightRange,
+		queryMode:           IndexQueryModeExecutionNodesOnly,
+		eventsIndex:         s.eventsIndex,
 		execNodeIdentitiesProvider: commonrpc.NewExecutionNodeIdentitiesProvider(
 			s.log,
 			s.state,
@@ -509,6 +511,104 @@ func (s *BackendEventsSuite) TestGetEventsForBlockIDs_HandlesErrors() {
 	})
 }
 
+func (s *BackendEventsSuite) TestGetEventsByQuery() {
+	ctx := context.Background()
+
+	startHeight := s.blocks[0].Header.Height
+	endHeight := s.sealedHead.Height
+
+	reporter := syncmock.NewIndexReporter(s.T())
+	reporter.On("LowestIndexedHeight").Return(startHeight, nil)
+	reporter.On("HighestIndexedHeight").Return(endHeight+10, nil)
+	err := s.eventsIndex.Initialize(reporter)
+	s.Require().NoError(err)
+
+	s.state.On("Sealed").Return(s.snapshot)
+	s.snapshot.On("Head").Return(s.sealedHead, nil)
+
+	for _, tt := range s.testCases {
+		s.Run(fmt.Sprintf("event type and field predicate - %s - %s", tt.encoding.String(), tt.queryMode), func() {
+			backend := s.defaultBackend()
+			backend.queryMode = tt.queryMode
+
+			query, err := events.NewQuery(s.chainID.Chain(), []string{targetEvent}, nil, nil, []events.FieldPredicate{
+				{Field: "b", Operator: events.FieldOperatorEqual, Values: []string{"foo"}},
+			})
+			s.Require().NoError(err)
+
+			response, err := backend.GetEventsByQuery(ctx, query, startHeight, endHeight, tt.encoding)
+			if tt.queryMode == IndexQueryModeExecutionNodesOnly {
+				s.Assert().Equal(codes.Unimplemented, status.Code(err))
+				s.Assert().Nil(response)
+				return
+			}
+			s.Require().NoError(err)
+			s.assertResponse(response, tt.encoding)
+		})
+	}
+
+	s.Run("numeric range over all event types", func() {
+		backend := s.defaultBackend()
+		backend.queryMode = IndexQueryModeLocalOnly
+
+		query, err := events.NewQuery(s.chainID.Chain(), nil, nil, nil, []events.FieldPredicate{
+			{Field: "a", Operator: events.FieldOperatorGreaterOrEqual, Values: []string{"3"}},
+			{Field: "a", Operator: events.FieldOperatorLessOrEqual, Values: []string{"5"}},
+		})
+		s.Require().NoError(err)
+
+		response, err := backend.GetEventsByQuery(ctx, query, startHeight, endHeight, entities.EventEncodingVersion_CCF_V0)
+		s.Require().NoError(err)
+		s.Require().Len(response, len(s.blocks))
+		for _, blockEvents := range response {
+			s.Require().Len(blockEvents.Events, 3)
+			// events are returned in execution order
+			for i, event := range blockEvents.Events {
+				s.Assert().Equal(s.blockEvents[i+2].Type, event.Type)
+			}
+		}
+	})
+
+	s.Run("range is not limited by max height range", func() {
+		backend := s.defaultBackend()
+		backend.queryMode = IndexQueryModeLocalOnly
+		backend.maxHeightRange = 1
+
+		query, err := events.NewQuery(s.chainID.Chain(), []string{targetEvent}, nil, nil, nil)
+		s.Require().NoError(err)
+
+		response, err := backend.GetEventsByQuery(ctx, query, startHeight, endHeight, entities.EventEncodingVersion_CCF_V0)
+		s.Require().NoError(err)
+		s.Assert().Len(response, len(s.blocks))
+	})
+
+	s.Run("returns error for range larger than max", func() {
+		backend := s.defaultBackend()
+		backend.queryMode = IndexQueryModeLocalOnly
+
+		query, err := events.NewQuery(s.chainID.Chain(), []string{targetEvent}, nil, nil, nil)
+		s.Require().NoError(err)
+
+		response, err := backend.GetEventsByQuery(ctx, query, startHeight, startHeight+DefaultMaxEventQueryHeightRange, entities.EventEncodingVersion_CCF_V0)
+		s.Assert().Equal(codes.InvalidArgument, status.Code(err))
+		s.Assert().Nil(response)
+	})
+
+	s.Run("returns error if blocks are not indexed", func() {
+		eventsStorage := storagemock.NewEvents(s.T())
+		backend := s.defaultBackend()
+		backend.queryMode = IndexQueryModeFailover
+		backend.eventsIndex = index.NewEventsIndex(index.NewReporter(), eventsStorage)
+
+		query, err := events.NewQuery(s.chainID.Chain(), []string{targetEvent}, nil, nil, nil)
+		s.Require().NoError(err)
+
+		response, err := backend.GetEventsByQuery(ctx, query, startHeight, endHeight, entities.EventEncodingVersion_CCF_V0)
+		s.Assert().Equal(codes.NotFound, status.Code(err))
+		s.Assert().Nil(response)
+	})
+}
+
 func (s *BackendEventsSuite) assertResponse(response []flow.BlockEvents, encoding entities.EventEncodingVersion) {
 	s.Assert().Len(response, len(s.blocks))
 	for i, block := range s.blocks {
@@ -0,0 +1,129 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/storage"
+)
+
+// MaxRegisterHistoryPageSize is the maximum number of register changes returned in a single page.
+// The height range of a request is not limited, since the history is read by seeking to the values within
+// the range, so the cost of a page depends on the number of registers visited, not on the number of heights.
+const MaxRegisterHistoryPageSize = 1000
+
+type backendRegisterHistory struct {
+	log       zerolog.Logger
+	registers *execution.RegistersAsyncStore // nil if the register index is not available
+}
+
+// GetRegisterHistory returns a page of the values written to the register within the height range
+// [startHeight, endHeight], from the most recent to the oldest value.
+// If limit is 0, the maximum page size is used. If cursor is not nil, the page starts at the cursor position.
+//
+// Expected errors:
+//   - codes.Unimplemented if the register index is not available
+//   - codes.InvalidArgument if the request parameters are invalid
+//   - codes.OutOfRange if the requested heights are not indexed
+//   - codes.FailedPrecondition if the index is not initialized yet
+func (b *backendRegisterHistory) GetRegisterHistory(
+	_ context.Context,
+	registerID flow.RegisterID,
+	startHeight uint64,
+	endHeight uint64,
+	limit uint32,
+	cursor *flow.RegisterHistoryCursor,
+) (*flow.RegisterHistoryPage, error) {
+	err := b.validateRequest(startHeight, endHeight, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor != nil && cursor.Key != registerID.Key {
+		return nil, status.Error(codes.InvalidArgument, "cursor does not point to the requested register")
+	}
+
+	page, err := b.registers.RegisterHistory(registerID, startHeight, endHeight, registerHistoryPageSize(limit), cursor)
+	if err != nil {
+		b.log.Debug().Err(err).
+			Str("register_id", registerID.String()).
+			Msg("failed to get register history")
+		return nil, convertRegisterHistoryError(err, startHeight, endHeight)
+	}
+
+	return page, nil
+}
+
+// GetAccountRegisterHistory returns a page of the values written to all registers of the account within the
+// height range [startHeight, endHeight], grouped by register and ordered from the most recent to the oldest value.
+// If limit is 0, the maximum page size is used. If cursor is not nil, the page starts at the cursor position.
+//
+// Expected errors:
+//   - codes.Unimplemented if the register index is not available
+//   - codes.InvalidArgument if the request parameters are invalid
+//   - codes.OutOfRange if the requested heights are not indexed
+//   - codes.FailedPrecondition if the index is not initialized yet
+func (b *backendRegisterHistory) GetAccountRegisterHistory(
+	_ context.Context,
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	limit uint32,
+	cursor *flow.RegisterHistoryCursor,
+) (*flow.RegisterHistoryPage, error) {
+	err := b.validateRequest(startHeight, endHeight, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := flow.AddressToRegisterOwner(address)
+	page, err := b.registers.OwnerRegisterHistory(owner, startHeight, endHeight, registerHistoryPageSize(limit), cursor)
+	if err != nil {
+		b.log.Debug().Err(err).
+			Str("address", address.String()).
+			Msg("failed to get account register history")
+		return nil, convertRegisterHistoryError(err, startHeight, endHeight)
+	}
+
+	return page, nil
+}
+
+func (b *backendRegisterHistory) validateRequest(startHeight uint64, endHeight uint64, cursor *flow.RegisterHistoryCursor) error {
+	if b.registers == nil {
+		return status.Error(codes.Unimplemented, "register history requires the execution state index")
+	}
+
+	if startHeight > endHeight {
+		return status.Errorf(codes.InvalidArgument, "start height %d must not be above end height %d", startHeight, endHeight)
+	}
+
+	if cursor != nil && (cursor.Height < startHeight || cursor.Height > endHeight) {
+		return status.Errorf(codes.InvalidArgument,
+			"cursor height %d is outside the requested range [%d, %d]", cursor.Height, startHeight, endHeight)
+	}
+
+	return nil
+}
+
+// registerHistoryPageSize returns the number of changes to return for the requested limit.
+func registerHistoryPageSize(limit uint32) uint {
+	if limit == 0 || limit > MaxRegisterHistoryPageSize {
+		return MaxRegisterHistoryPageSize
+	}
+	return uint(limit)
+}
+
+func convertRegisterHistoryError(err error, startHeight uint64, endHeight uint64) error {
+	if errors.Is(err, storage.ErrHeightNotIndexed) {
+		return status.Errorf(codes.OutOfRange, "data for height range [%d-%d] is not available", startHeight, endHeight)
+	}
+
+	return rpc.ConvertIndexError(err, endHeight, "failed to get register history")
+}
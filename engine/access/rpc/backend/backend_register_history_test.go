@@ -0,0 +1,168 @@
+package backend
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetRegisterHistory(t *testing.T) {
+	address := unittest.RandomAddressFixture()
+	registerID := flow.NewRegisterID(address, "balance")
+
+	historyOf := func(changes []flow.RegisterChange, err error) iter.Seq2[flow.RegisterChange, error] {
+		return func(yield func(flow.RegisterChange, error) bool) {
+			for _, change := range changes {
+				if !yield(change, nil) {
+					return
+				}
+			}
+			if err != nil {
+				yield(flow.RegisterChange{}, err)
+			}
+		}
+	}
+
+	setup := func(t *testing.T) (*backendRegisterHistory, *storagemock.RegisterIndex) {
+		registers := storagemock.NewRegisterIndex(t)
+		registersAsync := execution.NewRegistersAsyncStore()
+		require.NoError(t, registersAsync.Initialize(registers))
+
+		return &backendRegisterHistory{
+			log:       unittest.Logger(),
+			registers: registersAsync,
+		}, registers
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		backend := &backendRegisterHistory{}
+
+		_, err := backend.GetRegisterHistory(context.Background(), registerID, 1, 10, 0, nil)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+		_, err = backend.GetAccountRegisterHistory(context.Background(), address, 1, 10, 0, nil)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("index not initialized", func(t *testing.T) {
+		backend := &backendRegisterHistory{
+			log:       unittest.Logger(),
+			registers: execution.NewRegistersAsyncStore(),
+		}
+
+		_, err := backend.GetRegisterHistory(context.Background(), registerID, 1, 10, 0, nil)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("returns register history", func(t *testing.T) {
+		backend, registers := setup(t)
+		changes := []flow.RegisterChange{
+			{Key: registerID, Height: 8, Value: []byte{2}},
+			{Key: registerID, Height: 3, Value: []byte{1}},
+		}
+		registers.On("History", registerID, uint64(1), uint64(10)).Return(historyOf(changes, nil))
+
+		actual, err := backend.GetRegisterHistory(context.Background(), registerID, 1, 10, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, changes, actual.Changes)
+		assert.Nil(t, actual.NextCursor)
+	})
+
+	t.Run("returns account register history", func(t *testing.T) {
+		backend, registers := setup(t)
+		changes := []flow.RegisterChange{
+			{Key: registerID, Height: 8, Value: []byte{2}},
+			{Key: flow.NewRegisterID(address, "storage_used"), Height: 8, Value: []byte{3}},
+		}
+		registers.On("OwnerHistory", registerID.Owner, uint64(1), uint64(10), (*flow.RegisterHistoryCursor)(nil)).Return(historyOf(changes, nil))
+
+		actual, err := backend.GetAccountRegisterHistory(context.Background(), address, 1, 10, 0, nil)
+		require.NoError(t, err)
+		assert.Equal(t, changes, actual.Changes)
+		assert.Nil(t, actual.NextCursor)
+	})
+
+	t.Run("invalid height range", func(t *testing.T) {
+		backend, _ := setup(t)
+
+		_, err := backend.GetRegisterHistory(context.Background(), registerID, 10, 1, 0, nil)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("height range not indexed", func(t *testing.T) {
+		backend, registers := setup(t)
+		registers.On("History", registerID, uint64(1), uint64(10)).Return(historyOf(nil, storage.ErrHeightNotIndexed))
+
+		_, err := backend.GetRegisterHistory(context.Background(), registerID, 1, 10, 0, nil)
+		assert.Equal(t, codes.OutOfRange, status.Code(err))
+	})
+
+	t.Run("returns page with next cursor", func(t *testing.T) {
+		backend, registers := setup(t)
+		changes := []flow.RegisterChange{
+			{Key: registerID, Height: 8, Value: []byte{2}},
+			{Key: registerID, Height: 3, Value: []byte{1}},
+		}
+		registers.On("History", registerID, uint64(1), uint64(10)).Return(historyOf(changes, nil))
+
+		actual, err := backend.GetRegisterHistory(context.Background(), registerID, 1, 10, 1, nil)
+		require.NoError(t, err)
+		assert.Equal(t, changes[:1], actual.Changes)
+		require.NotNil(t, actual.NextCursor)
+		assert.Equal(t, changes[1].Cursor(), *actual.NextCursor)
+	})
+
+	t.Run("limit is capped to the maximum page size", func(t *testing.T) {
+		backend, registers := setup(t)
+		changes := make([]flow.RegisterChange, MaxRegisterHistoryPageSize+1)
+		registers.On("OwnerHistory", registerID.Owner, uint64(1), uint64(10), (*flow.RegisterHistoryCursor)(nil)).Return(historyOf(changes, nil))
+
+		actual, err := backend.GetAccountRegisterHistory(context.Background(), address, 1, 10, MaxRegisterHistoryPageSize+10, nil)
+		require.NoError(t, err)
+		assert.Len(t, actual.Changes, MaxRegisterHistoryPageSize)
+		assert.NotNil(t, actual.NextCursor)
+	})
+
+	t.Run("continues account register history from cursor", func(t *testing.T) {
+		backend, registers := setup(t)
+		cursor := &flow.RegisterHistoryCursor{Key: "storage_used", Height: 8}
+		changes := []flow.RegisterChange{
+			{Key: flow.NewRegisterID(address, "storage_used"), Height: 8, Value: []byte{3}},
+		}
+		registers.On("OwnerHistory", registerID.Owner, uint64(1), uint64(10), cursor).Return(historyOf(changes, nil))
+
+		actual, err := backend.GetAccountRegisterHistory(context.Background(), address, 1, 10, 0, cursor)
+		require.NoError(t, err)
+		assert.Equal(t, changes, actual.Changes)
+	})
+
+	t.Run("cursor outside of height range", func(t *testing.T) {
+		backend, _ := setup(t)
+		cursor := &flow.RegisterHistoryCursor{Key: registerID.Key, Height: 11}
+
+		_, err := backend.GetRegisterHistory(context.Background(), registerID, 1, 10, 0, cursor)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = backend.GetAccountRegisterHistory(context.Background(), address, 1, 10, 0, cursor)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("cursor of another register", func(t *testing.T) {
+		backend, _ := setup(t)
+		cursor := &flow.RegisterHistoryCursor{Key: "storage_used", Height: 5}
+
+		_, err := backend.GetRegisterHistory(context.Background(), registerID, 1, 10, 0, cursor)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,89 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+type backendRegisterProofs struct {
+	log              zerolog.Logger
+	state            protocol.State
+	headers          storage.Headers
+	executionResults storage.ExecutionResults
+	prover           execution.RegisterProver // nil if register proofs are not available
+}
+
+// GetRegistersWithProofAtBlockID returns the values of the registers at the end of the given sealed block,
+// together with a proof of the values against the final state commitment of the sealed execution result.
+func (b *backendRegisterProofs) GetRegistersWithProofAtBlockID(
+	ctx context.Context,
+	blockID flow.Identifier,
+	registerIDs flow.RegisterIDs,
+) (*flow.RegistersProof, error) {
+	if b.prover == nil {
+		return nil, status.Error(codes.Unimplemented, "register proofs are not available")
+	}
+
+	if len(registerIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no registers requested")
+	}
+	if len(registerIDs) > execution.MaxRegistersPerProof {
+		return nil, status.Errorf(codes.InvalidArgument, "too many registers requested: %d > %d", len(registerIDs), execution.MaxRegistersPerProof)
+	}
+
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		err := irrecoverable.NewExceptionf("failed to lookup sealed header: %w", err)
+		irrecoverable.Throw(ctx, err)
+		return nil, err
+	}
+
+	if header.Height > sealed.Height {
+		return nil, status.Errorf(codes.FailedPrecondition, "block %v is not sealed", blockID)
+	}
+
+	result, err := b.executionResults.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	commit, err := result.FinalStateCommitment()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get final state commitment of execution result %v: %v", result.ID(), err)
+	}
+
+	registers, proof, err := b.prover.ProveRegisters(ctx, blockID, commit, registerIDs)
+	if err != nil {
+		b.log.Debug().Err(err).
+			Hex("block_id", blockID[:]).
+			Msg("failed to prove registers")
+
+		if errors.Is(err, execution.ErrStateNotAvailable) {
+			return nil, status.Errorf(codes.NotFound, "state of block %v is not available: %v", blockID, err)
+		}
+		return nil, rpc.ConvertError(err, "failed to prove registers", codes.Internal)
+	}
+
+	return &flow.RegistersProof{
+		BlockID:         blockID,
+		StateCommitment: commit,
+		Registers:       registers,
+		Proof:           proof,
+	}, nil
+}
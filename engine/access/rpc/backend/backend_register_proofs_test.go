@@ -0,0 +1,120 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	executionmock "github.com/onflow/flow-go/module/execution/mock"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetRegistersWithProofAtBlockID(t *testing.T) {
+	header := unittest.BlockHeaderFixture()
+	sealedHeader := unittest.BlockHeaderWithParentFixture(header)
+	blockID := header.ID()
+
+	commit := unittest.StateCommitmentFixture()
+	result := unittest.ExecutionResultFixture(
+		unittest.WithExecutionResultBlockID(blockID),
+		unittest.WithFinalState(commit))
+
+	registerIDs := flow.RegisterIDs{flow.NewRegisterID(unittest.RandomAddressFixture(), "balance")}
+	registers := []flow.RegisterEntry{{Key: registerIDs[0], Value: []byte{1}}}
+	proof := unittest.RandomBytes(64)
+
+	setup := func(t *testing.T) (*backendRegisterProofs, *storagemock.Headers, *storagemock.ExecutionResults, *executionmock.RegisterProver) {
+		state := protocol.NewState(t)
+		snapshot := protocol.NewSnapshot(t)
+		state.On("Sealed").Return(snapshot).Maybe()
+		snapshot.On("Head").Return(sealedHeader, nil).Maybe()
+
+		headers := storagemock.NewHeaders(t)
+		results := storagemock.NewExecutionResults(t)
+		prover := executionmock.NewRegisterProver(t)
+
+		return &backendRegisterProofs{
+			log:              unittest.Logger(),
+			state:            state,
+			headers:          headers,
+			executionResults: results,
+			prover:           prover,
+		}, headers, results, prover
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		backend := &backendRegisterProofs{}
+
+		_, err := backend.GetRegistersWithProofAtBlockID(context.Background(), blockID, registerIDs)
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("proves registers at sealed state commitment", func(t *testing.T) {
+		backend, headers, results, prover := setup(t)
+		headers.On("ByBlockID", blockID).Return(header, nil)
+		results.On("ByBlockID", blockID).Return(result, nil)
+		prover.On("ProveRegisters", mock.Anything, blockID, commit, registerIDs).Return(registers, proof, nil)
+
+		registersProof, err := backend.GetRegistersWithProofAtBlockID(context.Background(), blockID, registerIDs)
+		require.NoError(t, err)
+		assert.Equal(t, &flow.RegistersProof{
+			BlockID:         blockID,
+			StateCommitment: commit,
+			Registers:       registers,
+			Proof:           proof,
+		}, registersProof)
+	})
+
+	t.Run("no registers", func(t *testing.T) {
+		backend, _, _, _ := setup(t)
+
+		_, err := backend.GetRegistersWithProofAtBlockID(context.Background(), blockID, nil)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("too many registers", func(t *testing.T) {
+		backend, _, _, _ := setup(t)
+
+		_, err := backend.GetRegistersWithProofAtBlockID(context.Background(), blockID, make(flow.RegisterIDs, execution.MaxRegistersPerProof+1))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		backend, headers, _, _ := setup(t)
+		headers.On("ByBlockID", blockID).Return(nil, storage.ErrNotFound)
+
+		_, err := backend.GetRegistersWithProofAtBlockID(context.Background(), blockID, registerIDs)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("block not sealed", func(t *testing.T) {
+		backend, headers, _, _ := setup(t)
+		unsealed := unittest.BlockHeaderWithParentFixture(sealedHeader)
+		headers.On("ByBlockID", unsealed.ID()).Return(unsealed, nil)
+
+		_, err := backend.GetRegistersWithProofAtBlockID(context.Background(), unsealed.ID(), registerIDs)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("state not available", func(t *testing.T) {
+		backend, headers, results, prover := setup(t)
+		headers.On("ByBlockID", blockID).Return(header, nil)
+		results.On("ByBlockID", blockID).Return(result, nil)
+		prover.On("ProveRegisters", mock.Anything, blockID, commit, registerIDs).
+			Return(nil, nil, fmt.Errorf("pruned: %w", execution.ErrStateNotAvailable))
+
+		_, err := backend.GetRegistersWithProofAtBlockID(context.Background(), blockID, registerIDs)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
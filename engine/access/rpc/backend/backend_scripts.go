@@ -38,6 +38,7 @@ type backendScripts struct {
 	scriptExecutor             execution.ScriptExecutor
 	scriptExecMode             IndexQueryMode
 	execNodeIdentitiesProvider *commonrpc.ExecutionNodeIdentitiesProvider
+	resultCache                *scriptResultCache // nil if result caching is disabled
 }
 
 // scriptExecutionRequest encapsulates the data needed to execute a script to make it easier
@@ -111,11 +112,68 @@ func (b *backendScripts) ExecuteScriptAtBlockHeight(
 	return b.executeScript(ctx, newScriptExecutionRequest(header.ID(), blockHeight, script, arguments))
 }
 
-// executeScript executes the provided script using either the local execution state or the execution
-// nodes depending on the node's configuration and the availability of the data.
+// executeScript executes the provided script, returning the cached result if the same script was
+// already executed with the same arguments at the same sealed block.
 func (b *backendScripts) executeScript(
 	ctx context.Context,
 	scriptRequest *scriptExecutionRequest,
+) ([]byte, error) {
+	if b.resultCache == nil {
+		return b.executeScriptWithMode(ctx, scriptRequest)
+	}
+
+	cacheable, err := b.isSealedBlock(ctx, scriptRequest)
+	if err != nil {
+		return nil, err
+	}
+	if !cacheable {
+		return b.executeScriptWithMode(ctx, scriptRequest)
+	}
+
+	key := newScriptResultCacheKey(scriptRequest)
+	if result, ok := b.resultCache.get(ctx, key); ok {
+		return result, nil
+	}
+
+	result, err := b.executeScriptWithMode(ctx, scriptRequest)
+	if err != nil {
+		return nil, err
+	}
+	b.resultCache.add(key, result)
+
+	return result, nil
+}
+
+// isSealedBlock returns true if the block of the script execution request is sealed. Only results at
+// sealed blocks are deterministic, since execution nodes may disagree on the result of unsealed blocks.
+//
+// No errors are expected during normal operations.
+func (b *backendScripts) isSealedBlock(ctx context.Context, r *scriptExecutionRequest) (bool, error) {
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		// the latest sealed header MUST be available
+		err := irrecoverable.NewExceptionf("failed to lookup sealed header: %w", err)
+		irrecoverable.Throw(ctx, err)
+		return false, err
+	}
+	if r.height > sealed.Height {
+		return false, nil
+	}
+
+	// blocks by ID may be on an orphaned fork below the sealed height
+	finalizedID, err := b.headers.BlockIDByHeight(r.height)
+	if err != nil {
+		return false, rpc.ConvertStorageError(err)
+	}
+
+	return finalizedID == r.blockID, nil
+}
+
+// executeScriptWithMode executes the provided script using either the local execution state or the execution
+// nodes depending on the node's configuration and the availability of the data.
+func (b *backendScripts) executeScriptWithMode(
+	ctx context.Context,
+	scriptRequest *scriptExecutionRequest,
 ) ([]byte, error) {
 	switch b.scriptExecMode {
 	case IndexQueryModeExecutionNodesOnly:
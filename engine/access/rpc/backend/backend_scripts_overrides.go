@@ -0,0 +1,75 @@
+package backend
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/execution/computation/query"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+const (
+	// MaxStateOverrides is the maximum number of state overrides of a single script execution.
+	MaxStateOverrides = 20
+
+	// MaxBalanceOverrides is the maximum number of balance overrides of a single script execution. Each
+	// balance override executes a transaction, so the number of balance overrides bounds the cost of the
+	// request.
+	MaxBalanceOverrides = 5
+)
+
+// ExecuteScriptAtBlockHeightWithOverrides executes provided script at the provided block height, with the
+// given overrides applied on top of the execution state of the block. The overrides are only used for this
+// execution and are never persisted, the result reports the overrides which were applied.
+//
+// Execution with overrides requires the local execution state index. Results are never cached, since they
+// do not reflect the state of the chain.
+func (b *backendScripts) ExecuteScriptAtBlockHeightWithOverrides(
+	ctx context.Context,
+	blockHeight uint64,
+	script []byte,
+	arguments [][]byte,
+	overrides *flow.StateOverrides,
+) (*flow.ScriptResultWithOverrides, error) {
+	if b.scriptExecutor == nil {
+		return nil, status.Error(codes.Unimplemented, "script execution with state overrides requires the execution state index")
+	}
+
+	if overrides.Len() > MaxStateOverrides {
+		return nil, status.Errorf(codes.InvalidArgument, "too many state overrides: %d, maximum is %d",
+			overrides.Len(), MaxStateOverrides)
+	}
+	if overrides != nil && len(overrides.Balances) > MaxBalanceOverrides {
+		return nil, status.Errorf(codes.InvalidArgument, "too many balance overrides: %d, maximum is %d",
+			len(overrides.Balances), MaxBalanceOverrides)
+	}
+
+	header, err := b.headers.ByHeight(blockHeight)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(resolveHeightError(b.state.Params(), blockHeight, err))
+	}
+
+	result, err := b.scriptExecutor.ExecuteAtBlockHeightWithOverrides(ctx, script, arguments, blockHeight, overrides)
+	if err != nil {
+		b.log.Debug().Err(err).
+			Hex("block_id", logging.ID(header.ID())).
+			Uint64("height", blockHeight).
+			Int("overrides", overrides.Len()).
+			Msg("failed to execute script with state overrides")
+
+		if errors.Is(err, query.ErrInvalidStateOverrides) {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to apply state overrides: %v", err)
+		}
+		return nil, convertScriptExecutionError(
+			resolveHeightError(b.state.Params(), blockHeight, err),
+			blockHeight,
+		)
+	}
+
+	return result, nil
+}
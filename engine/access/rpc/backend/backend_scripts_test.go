@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	execproto "github.com/onflow/flow/protobuf/go/flow/execution"
@@ -438,6 +439,114 @@ func (s *BackendScriptsSuite) TestExecuteScriptAtLatestBlockFromStorage_Inconsis
 	})
 }
 
+// TestExecuteScriptWithResultCache tests that results of scripts executed at sealed blocks are served
+// from the result cache, unless the client requests to bypass it.
+func (s *BackendScriptsSuite) TestExecuteScriptWithResultCache() {
+	height := s.block.Header.Height
+
+	newBackend := func(scriptExecutor *execmock.ScriptExecutor) *backendScripts {
+		resultCache, err := newScriptResultCache(10, DefaultScriptResultCacheMaxResultSize, metrics.NewNoopCollector())
+		s.Require().NoError(err)
+
+		backend := s.defaultBackend()
+		backend.scriptExecMode = IndexQueryModeLocalOnly
+		backend.scriptExecutor = scriptExecutor
+		backend.resultCache = resultCache
+		return backend
+	}
+
+	s.Run("results at sealed blocks are cached", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, s.arguments, height).
+			Return(expectedResponse, nil).Once()
+		backend := newBackend(scriptExecutor)
+
+		s.state.On("Sealed").Return(s.snapshot, nil)
+		s.snapshot.On("Head").Return(s.block.Header, nil)
+		s.headers.On("ByHeight", height).Return(s.block.Header, nil)
+		s.headers.On("BlockIDByHeight", height).Return(s.block.ID(), nil)
+
+		for i := 0; i < 3; i++ {
+			actual, err := backend.ExecuteScriptAtBlockHeight(context.Background(), height, s.script, s.arguments)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedResponse, actual)
+		}
+
+		// results at the latest sealed block are keyed by the same block
+		actual, err := backend.ExecuteScriptAtLatestBlock(context.Background(), s.script, s.arguments)
+		s.Require().NoError(err)
+		s.Require().Equal(expectedResponse, actual)
+
+		// different arguments are not served from the cache
+		otherArguments := [][]byte{[]byte("arg3")}
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, otherArguments, height).
+			Return([]byte("other"), nil).Once()
+
+		actual, err = backend.ExecuteScriptAtBlockHeight(context.Background(), height, s.script, otherArguments)
+		s.Require().NoError(err)
+		s.Require().Equal([]byte("other"), actual)
+	})
+
+	s.Run("cache is bypassed when requested by the client", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, s.arguments, height).
+			Return(expectedResponse, nil).Twice()
+		backend := newBackend(scriptExecutor)
+
+		s.state.On("Sealed").Return(s.snapshot, nil)
+		s.snapshot.On("Head").Return(s.block.Header, nil)
+		s.headers.On("ByHeight", height).Return(s.block.Header, nil)
+		s.headers.On("BlockIDByHeight", height).Return(s.block.ID(), nil)
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("cache-control", "no-cache"))
+		for i := 0; i < 2; i++ {
+			actual, err := backend.ExecuteScriptAtBlockHeight(ctx, height, s.script, s.arguments)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedResponse, actual)
+		}
+	})
+
+	s.Run("results at unsealed blocks are not cached", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, s.arguments, height).
+			Return(expectedResponse, nil).Twice()
+		backend := newBackend(scriptExecutor)
+
+		sealedSnapshot := protocol.NewSnapshot(s.T())
+		sealedSnapshot.On("Head").Return(unittest.BlockHeaderWithHeight(height-1), nil)
+		sealedState := protocol.NewState(s.T())
+		sealedState.On("Sealed").Return(sealedSnapshot, nil)
+		backend.state = sealedState
+		s.headers.On("ByHeight", height).Return(s.block.Header, nil)
+
+		for i := 0; i < 2; i++ {
+			actual, err := backend.ExecuteScriptAtBlockHeight(context.Background(), height, s.script, s.arguments)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedResponse, actual)
+		}
+	})
+
+	s.Run("results at orphaned blocks are not cached", func() {
+		scriptExecutor := execmock.NewScriptExecutor(s.T())
+		scriptExecutor.On("ExecuteAtBlockHeight", mock.Anything, s.script, s.arguments, height).
+			Return(expectedResponse, nil).Twice()
+		backend := newBackend(scriptExecutor)
+
+		orphanedHeaders := storagemock.NewHeaders(s.T())
+		orphanedHeaders.On("ByBlockID", s.block.ID()).Return(s.block.Header, nil)
+		orphanedHeaders.On("BlockIDByHeight", height).Return(unittest.IdentifierFixture(), nil)
+		backend.headers = orphanedHeaders
+		s.state.On("Sealed").Return(s.snapshot, nil)
+		s.snapshot.On("Head").Return(s.block.Header, nil)
+
+		for i := 0; i < 2; i++ {
+			actual, err := backend.ExecuteScriptAtBlockID(context.Background(), s.block.ID(), s.script, s.arguments)
+			s.Require().NoError(err)
+			s.Require().Equal(expectedResponse, actual)
+		}
+	})
+}
+
 func (s *BackendScriptsSuite) testExecuteScriptAtLatestBlock(ctx context.Context, backend *backendScripts, statusCode codes.Code) {
 	s.state.On("Sealed").Return(s.snapshot, nil).Once()
 	s.snapshot.On("Head").Return(s.block.Header, nil).Once()
@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// GetTransactionTimeline returns the lifecycle timeline of a transaction submitted to this access node.
+//
+// Timelines are only tracked for transactions received by this node, and are kept in memory until they
+// are evicted by newer transactions.
+//
+// Expected errors:
+//   - codes.Unimplemented if timeline tracking is disabled
+//   - codes.NotFound if no timeline is tracked for the transaction
+func (b *backendTransactions) GetTransactionTimeline(_ context.Context, txID flow.Identifier) (*flow.TransactionTimeline, error) {
+	if b.txTimelines == nil {
+		return nil, status.Error(codes.Unimplemented, "transaction timeline tracking is disabled")
+	}
+
+	timing, ok := b.txTimelines.ByID(txID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "no timeline tracked for transaction %v", txID)
+	}
+
+	return timing.Timeline(), nil
+}
+
+// trackTransactionTimeline starts tracking the timeline of a transaction received at the given time.
+func (b *backendTransactions) trackTransactionTimeline(txID flow.Identifier, received time.Time) {
+	if b.txTimelines == nil {
+		return
+	}
+
+	// if the transaction is resubmitted, the timeline of the first submission is kept
+	_ = b.txTimelines.Add(&flow.TransactionTiming{
+		TransactionID: txID,
+		Received:      received,
+		Events: []flow.TransactionTimelineEvent{{
+			Stage:     flow.TransactionStageReceived,
+			Timestamp: received,
+		}},
+	})
+}
+
+// recordTransactionTimelineEvent records the stage in the timeline of the transaction, if it is tracked.
+func (b *backendTransactions) recordTransactionTimelineEvent(txID flow.Identifier, stage flow.TransactionStage, attributes map[string]string) {
+	if b.txTimelines == nil {
+		return
+	}
+
+	_ = b.txTimelines.Record(txID, flow.TransactionTimelineEvent{
+		Stage:      stage,
+		Timestamp:  time.Now().UTC(),
+		Attributes: attributes,
+	})
+}
@@ -0,0 +1,62 @@
+package backend
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestGetTransactionTimeline(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		backend := &backendTransactions{}
+
+		_, err := backend.GetTransactionTimeline(context.Background(), unittest.IdentifierFixture())
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+
+		// tracking is a no-op when disabled
+		backend.trackTransactionTimeline(unittest.IdentifierFixture(), time.Now())
+	})
+
+	t.Run("untracked transaction", func(t *testing.T) {
+		timelines, err := stdmap.NewTransactionTimings(10)
+		require.NoError(t, err)
+		backend := &backendTransactions{txTimelines: timelines}
+
+		_, err = backend.GetTransactionTimeline(context.Background(), unittest.IdentifierFixture())
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("tracked transaction", func(t *testing.T) {
+		timelines, err := stdmap.NewTransactionTimings(10)
+		require.NoError(t, err)
+		backend := &backendTransactions{txTimelines: timelines}
+
+		txID := unittest.IdentifierFixture()
+		received := time.Now().UTC()
+		backend.trackTransactionTimeline(txID, received)
+		backend.recordTransactionTimelineEvent(txID, flow.TransactionStageForwarded, map[string]string{
+			flow.TimelineAttributeCollectionNode: "collection-1:9000",
+		})
+
+		// resubmitting the transaction keeps the original timeline
+		backend.trackTransactionTimeline(txID, received.Add(time.Second))
+
+		timeline, err := backend.GetTransactionTimeline(context.Background(), txID)
+		require.NoError(t, err)
+		assert.Equal(t, txID, timeline.TransactionID)
+		require.Len(t, timeline.Events, 2)
+		assert.Equal(t, flow.TransactionStageReceived, timeline.Events[0].Stage)
+		assert.Equal(t, received, timeline.Events[0].Timestamp)
+		assert.Equal(t, flow.TransactionStageForwarded, timeline.Events[1].Stage)
+		assert.Equal(t, "collection-1:9000", timeline.Events[1].Attributes[flow.TimelineAttributeCollectionNode])
+	})
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/state"
 	"github.com/onflow/flow-go/storage"
 )
@@ -47,6 +48,7 @@ type backendTransactions struct {
 	nodeCommunicator    Communicator
 	txResultCache       *lru.Cache[flow.Identifier, *access.TransactionResult]
 	txResultQueryMode   IndexQueryMode
+	txTimelines         mempool.TransactionTimings // nil if timeline tracking is disabled
 
 	systemTxID                 flow.Identifier
 	systemTx                   *flow.TransactionBody
@@ -67,6 +69,8 @@ func (b *backendTransactions) SendTransaction(
 		return status.Errorf(codes.InvalidArgument, "invalid transaction: %s", err.Error())
 	}
 
+	b.trackTransactionTimeline(tx.ID(), now)
+
 	// send the transaction to the collection node if valid
 	err = b.trySendTransaction(ctx, tx)
 	if err != nil {
@@ -93,7 +97,12 @@ func (b *backendTransactions) SendTransaction(
 func (b *backendTransactions) trySendTransaction(ctx context.Context, tx *flow.TransactionBody) error {
 	// if a collection node rpc client was provided at startup, just use that
 	if b.staticCollectionRPC != nil {
-		return b.grpcTxSend(ctx, b.staticCollectionRPC, tx)
+		err := b.grpcTxSend(ctx, b.staticCollectionRPC, tx)
+		if err != nil {
+			return err
+		}
+		b.recordTransactionTimelineEvent(tx.ID(), flow.TransactionStageForwarded, nil)
+		return nil
 	}
 
 	// otherwise choose all collection nodes to try
@@ -118,6 +127,9 @@ func (b *backendTransactions) trySendTransaction(ctx context.Context, tx *flow.T
 			if err != nil {
 				return err
 			}
+			b.recordTransactionTimelineEvent(tx.ID(), flow.TransactionStageForwarded, map[string]string{
+				flow.TimelineAttributeCollectionNode: node.Address,
+			})
 			return nil
 		},
 		nil,
@@ -13,6 +13,7 @@ type Config struct {
 	CollectionClientTimeout   time.Duration                   // collection API GRPC client timeout
 	ConnectionPoolSize        uint                            // size of the cache for storing collection and execution connections
 	MaxHeightRange            uint                            // max size of height range requests
+	MaxEventQueryHeightRange  uint                            // max size of height range requests for indexed event queries
 	PreferredExecutionNodeIDs []string                        // preferred list of upstream execution node IDs
 	FixedExecutionNodeIDs     []string                        // fixed list of execution node IDs to choose from if no node ID can be chosen from the PreferredExecutionNodeIDs
 	CircuitBreakerConfig      connection.CircuitBreakerConfig // the configuration for circuit breaker
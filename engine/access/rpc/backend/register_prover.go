@@ -0,0 +1,157 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/registerproof"
+	"github.com/onflow/flow-go/engine/access/rpc/connection"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/execution/rpc/extended"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+)
+
+// ExecutionNodeRegisterProver proves register values by requesting the proofs from the execution nodes
+// which executed the block. Each proof is verified against the requested state commitment before it is
+// returned, so that an execution node serving an invalid proof is skipped in favor of the next one.
+type ExecutionNodeRegisterProver struct {
+	log                        zerolog.Logger
+	connFactory                connection.ConnectionFactory
+	nodeCommunicator           Communicator
+	execNodeIdentitiesProvider *commonrpc.ExecutionNodeIdentitiesProvider
+}
+
+var _ execution.RegisterProver = (*ExecutionNodeRegisterProver)(nil)
+
+func NewExecutionNodeRegisterProver(
+	log zerolog.Logger,
+	connFactory connection.ConnectionFactory,
+	nodeCommunicator Communicator,
+	execNodeIdentitiesProvider *commonrpc.ExecutionNodeIdentitiesProvider,
+) *ExecutionNodeRegisterProver {
+	return &ExecutionNodeRegisterProver{
+		log:                        log.With().Str("component", "execution_node_register_prover").Logger(),
+		connFactory:                connFactory,
+		nodeCommunicator:           nodeCommunicator,
+		execNodeIdentitiesProvider: execNodeIdentitiesProvider,
+	}
+}
+
+// ProveRegisters returns the values of the registers at the given state commitment of the given block,
+// in the order of the given register IDs, together with an encoded ledger.TrieBatchProof of the values.
+//
+// Execution nodes are queried in sequence until one of them returns a valid proof. If all execution
+// nodes fail, an error aggregating all failures is returned.
+func (p *ExecutionNodeRegisterProver) ProveRegisters(
+	ctx context.Context,
+	blockID flow.Identifier,
+	commit flow.StateCommitment,
+	registerIDs flow.RegisterIDs,
+) ([]flow.RegisterEntry, []byte, error) {
+	req := &extended.GetRegistersWithProofRequest{
+		BlockId:         blockID[:],
+		StateCommitment: commit[:],
+		RegisterIds:     make([]*extended.RegisterID, len(registerIDs)),
+	}
+	for i, registerID := range registerIDs {
+		req.RegisterIds[i] = &extended.RegisterID{
+			Owner: []byte(registerID.Owner),
+			Key:   registerID.Key,
+		}
+	}
+
+	execNodes, err := p.execNodeIdentitiesProvider.ExecutionNodesForBlockID(ctx, blockID)
+	if err != nil {
+		return nil, nil, rpc.ConvertError(err, "failed to find execution node to query", codes.Internal)
+	}
+
+	var registers []flow.RegisterEntry
+	var proof []byte
+	errToReturn := p.nodeCommunicator.CallAvailableNode(
+		execNodes,
+		func(node *flow.IdentitySkeleton) error {
+			var err error
+			start := time.Now()
+
+			registers, proof, err = p.tryProveRegisters(ctx, node, req, commit, registerIDs)
+			duration := time.Since(start)
+
+			lg := p.log.With().
+				Str("execution_node", node.String()).
+				Hex("block_id", blockID[:]).
+				Int("registers", len(registerIDs)).
+				Int64("rtt_ms", duration.Milliseconds()).
+				Logger()
+
+			if err != nil {
+				lg.Err(err).Msg("failed to prove registers")
+				return err
+			}
+
+			lg.Debug().Msg("successfully proved registers")
+			return nil
+		},
+		nil,
+	)
+	if errToReturn != nil {
+		return nil, nil, rpc.ConvertError(errToReturn, "failed to prove registers on the execution nodes", codes.Internal)
+	}
+
+	return registers, proof, nil
+}
+
+// tryProveRegisters requests the register proof from the given execution node, and verifies it against
+// the state commitment.
+func (p *ExecutionNodeRegisterProver) tryProveRegisters(
+	ctx context.Context,
+	execNode *flow.IdentitySkeleton,
+	req *extended.GetRegistersWithProofRequest,
+	commit flow.StateCommitment,
+	registerIDs flow.RegisterIDs,
+) ([]flow.RegisterEntry, []byte, error) {
+	client, closer, err := p.connFactory.GetExtendedExecutionAPIClient(execNode.Address)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer closer.Close()
+
+	resp, err := client.GetRegistersWithProof(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !bytes.Equal(resp.GetStateCommitment(), commit[:]) {
+		return nil, nil, status.Errorf(codes.Internal, "execution node proved registers against state commitment %x, not %v",
+			resp.GetStateCommitment(), commit)
+	}
+
+	if len(resp.GetRegisters()) != len(registerIDs) {
+		return nil, nil, status.Errorf(codes.Internal, "execution node returned %d registers, expected %d",
+			len(resp.GetRegisters()), len(registerIDs))
+	}
+
+	registers := make([]flow.RegisterEntry, len(registerIDs))
+	for i, register := range resp.GetRegisters() {
+		if string(register.GetId().GetOwner()) != registerIDs[i].Owner || register.GetId().GetKey() != registerIDs[i].Key {
+			return nil, nil, status.Errorf(codes.Internal, "execution node returned register %d out of order", i)
+		}
+		registers[i] = flow.RegisterEntry{
+			Key:   registerIDs[i],
+			Value: register.GetValue(),
+		}
+	}
+
+	err = registerproof.VerifyRegisters(registers, resp.GetProof(), commit)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "execution node returned an invalid proof: %v", err)
+	}
+
+	return registers, resp.GetProof(), nil
+}
@@ -102,6 +102,29 @@ func (s *ScriptExecutor) ExecuteAtBlockHeight(ctx context.Context, script []byte
 	return s.scriptExecutor.ExecuteAtBlockHeight(ctx, script, arguments, height)
 }
 
+// ExecuteAtBlockHeightWithOverrides executes provided script at the provided block height against a local
+// execution state, with the state overrides applied on top of it.
+//
+// Expected errors:
+//   - query.ErrInvalidStateOverrides if the overrides can not be applied to the state of the block.
+//   - storage.ErrNotFound if the register or block height is not found
+//   - storage.ErrHeightNotIndexed if the ScriptExecutor is not initialized, or if the height is not indexed yet,
+//     or if the height is before the lowest indexed height.
+//   - ErrIncompatibleNodeVersion if the block height is not compatible with the node version.
+func (s *ScriptExecutor) ExecuteAtBlockHeightWithOverrides(
+	ctx context.Context,
+	script []byte,
+	arguments [][]byte,
+	height uint64,
+	overrides *flow.StateOverrides,
+) (*flow.ScriptResultWithOverrides, error) {
+	if err := s.checkHeight(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.ExecuteAtBlockHeightWithOverrides(ctx, script, arguments, height, overrides)
+}
+
 // GetAccountAtBlockHeight returns the account at the provided block height from a local execution state.
 //
 // Expected errors:
@@ -173,6 +196,42 @@ func (s *ScriptExecutor) GetAccountKey(ctx context.Context, address flow.Address
 	return s.scriptExecutor.GetAccountKey(ctx, address, keyIndex, height)
 }
 
+// GetAccountStorage returns a page of at most limit values stored in a Flow account by the provided address and
+// block height, starting at the cursor position if cursor is not nil.
+// Expected errors:
+//   - Script execution related errors
+//   - storage.ErrHeightNotIndexed if the ScriptExecutor is not initialized, or if the height is not indexed yet,
+//     or if the height is before the lowest indexed height.
+//   - ErrIncompatibleNodeVersion if the block height is not compatible with the node version.
+func (s *ScriptExecutor) GetAccountStorage(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+	limit uint,
+	cursor *flow.AccountStorageCursor,
+) (*flow.AccountStorage, error) {
+	if err := s.checkHeight(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.GetAccountStorage(ctx, address, height, limit, cursor)
+}
+
+// EstimateTransaction executes the transaction at the provided block height without verifying its signatures
+// and sequence number, and returns the resources it would use. State changes are discarded.
+// Expected errors:
+//   - Transaction execution related errors
+//   - storage.ErrHeightNotIndexed if the ScriptExecutor is not initialized, or if the height is not indexed yet,
+//     or if the height is before the lowest indexed height.
+//   - ErrIncompatibleNodeVersion if the block height is not compatible with the node version.
+func (s *ScriptExecutor) EstimateTransaction(ctx context.Context, tx *flow.TransactionBody, height uint64) (*flow.TransactionEstimate, error) {
+	if err := s.checkHeight(height); err != nil {
+		return nil, err
+	}
+
+	return s.scriptExecutor.EstimateTransaction(ctx, tx, height)
+}
+
 // checkHeight checks if the provided block height is within the range of indexed heights
 // and compatible with the node's version.
 //
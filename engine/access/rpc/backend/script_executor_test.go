@@ -137,6 +137,7 @@ func (s *ScriptExecutorSuite) SetupTest() {
 		nil,
 		nil,
 		nil,
+		nil,
 		s.chain,
 		derivedChainData,
 		nil,
@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"context"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+)
+
+// DefaultScriptResultCacheMaxResultSize is the default maximum size in bytes of a script result
+// stored in the script result cache.
+const DefaultScriptResultCacheMaxResultSize = 64 << 10 // 64KB
+
+// scriptResultCacheKey identifies the result of a script executed with the given arguments at a block.
+type scriptResultCacheKey struct {
+	blockID       flow.Identifier
+	scriptHash    flow.Identifier
+	argumentsHash flow.Identifier
+}
+
+// scriptResultCache caches the results of scripts executed at sealed blocks.
+//
+// Script results at a sealed block are deterministic, so cached results never become stale. Since
+// results are keyed by block ID, results of scripts executed at the latest sealed block are only
+// returned until the next block is sealed.
+type scriptResultCache struct {
+	cache         *lru.Cache[scriptResultCacheKey, []byte]
+	maxResultSize int
+	metrics       module.ScriptResultCacheMetrics
+}
+
+// newScriptResultCache creates a script result cache holding up to size results, each at most
+// maxResultSize bytes long.
+func newScriptResultCache(size uint, maxResultSize uint, metrics module.ScriptResultCacheMetrics) (*scriptResultCache, error) {
+	cache, err := lru.New[scriptResultCacheKey, []byte](int(size))
+	if err != nil {
+		return nil, err
+	}
+
+	return &scriptResultCache{
+		cache:         cache,
+		maxResultSize: int(maxResultSize),
+		metrics:       metrics,
+	}, nil
+}
+
+// newScriptResultCacheKey returns the cache key of the script execution request.
+func newScriptResultCacheKey(r *scriptExecutionRequest) scriptResultCacheKey {
+	return scriptResultCacheKey{
+		blockID:       r.blockID,
+		scriptHash:    flow.MakeIDFromFingerPrint(r.script),
+		argumentsHash: flow.MakeID(r.arguments),
+	}
+}
+
+// get returns the cached result of the script execution request, if any.
+// The cache is skipped if the client requested to bypass it.
+func (c *scriptResultCache) get(ctx context.Context, key scriptResultCacheKey) ([]byte, bool) {
+	if access.IsScriptCacheBypassed(ctx) {
+		c.metrics.ScriptResultCacheBypassed()
+		return nil, false
+	}
+
+	result, ok := c.cache.Get(key)
+	if !ok {
+		c.metrics.ScriptResultCacheMiss()
+		return nil, false
+	}
+
+	c.metrics.ScriptResultCacheHit()
+	return result, true
+}
+
+// add stores the result of the script execution request. Results larger than the configured
+// maximum size are not cached.
+func (c *scriptResultCache) add(key scriptResultCacheKey, result []byte) {
+	if len(result) > c.maxResultSize {
+		return
+	}
+
+	c.cache.Add(key, result)
+	c.metrics.ScriptResultCacheSize(c.cache.Len())
+}
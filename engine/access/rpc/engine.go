@@ -13,6 +13,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/engine/access/graphql"
 	"github.com/onflow/flow-go/engine/access/rest"
 	"github.com/onflow/flow-go/engine/access/rest/websockets"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
@@ -45,6 +46,7 @@ type Config struct {
 	CompressorName            string         // GRPC compressor name
 	WebSocketConfig           websockets.Config
 	EnableWebSocketsStreamAPI bool
+	GraphQLConfig             graphql.Config // the GraphQL server configuration
 }
 
 // Engine exposes the server with a simplified version of the Access API.
@@ -64,6 +66,7 @@ type Engine struct {
 	secureGrpcServer   *grpcserver.GrpcServer // the secure gRPC server
 	httpServer         *http.Server
 	restServer         *http.Server
+	graphqlServer      *http.Server
 	config             Config
 	chain              flow.Chain
 
@@ -133,6 +136,7 @@ func NewBuilder(
 		}).
 		AddWorker(eng.serveGRPCWebProxyWorker).
 		AddWorker(eng.serveREST).
+		AddWorker(eng.serveGraphQL).
 		AddWorker(finalizedCacheWorker).
 		AddWorker(backendNotifierWorker).
 		AddWorker(eng.shutdownWorker).
@@ -169,6 +173,12 @@ func (e *Engine) shutdown() {
 			e.log.Error().Err(err).Msg("error stopping http REST server")
 		}
 	}
+	if e.graphqlServer != nil {
+		err := e.graphqlServer.Shutdown(ctx)
+		if err != nil {
+			e.log.Error().Err(err).Msg("error stopping http GraphQL server")
+		}
+	}
 }
 
 // OnFinalizedBlock responds to block finalization events.
@@ -290,3 +300,50 @@ func (e *Engine) serveREST(ctx irrecoverable.SignalerContext, ready component.Re
 		ctx.Throw(err)
 	}
 }
+
+// serveGraphQL is a worker routine which starts the HTTP GraphQL server.
+// The ready callback is called after the server address is bound.
+// Note: The irrecoverable.SignalerContext is used as base context for error handling.
+func (e *Engine) serveGraphQL(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	if e.config.GraphQLConfig.ListenAddress == "" {
+		e.log.Debug().Msg("no GraphQL API address specified - not starting the server")
+		ready()
+		return
+	}
+
+	e.log.Info().Str("graphql_api_address", e.config.GraphQLConfig.ListenAddress).Msg("starting GraphQL server on address")
+
+	s, err := graphql.NewServer(
+		e.restHandler,
+		e.config.GraphQLConfig,
+		e.log,
+		e.chain,
+	)
+	if err != nil {
+		e.log.Err(err).Msg("failed to initialize the GraphQL server")
+		ctx.Throw(err)
+		return
+	}
+	e.graphqlServer = s
+
+	e.graphqlServer.BaseContext = func(_ net.Listener) context.Context {
+		return irrecoverable.WithSignalerContext(ctx, ctx)
+	}
+
+	l, err := net.Listen("tcp", e.config.GraphQLConfig.ListenAddress)
+	if err != nil {
+		e.log.Err(err).Msg("failed to start the GraphQL server")
+		ctx.Throw(err)
+		return
+	}
+	ready()
+
+	err = e.graphqlServer.Serve(l) // blocking call
+	if err != nil {
+		if errors.Is(err, http.ErrServerClosed) {
+			return
+		}
+		e.log.Err(err).Msg("fatal error in GraphQL server")
+		ctx.Throw(err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	legacyaccessproto "github.com/onflow/flow/protobuf/go/flow/legacy/access"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/extended"
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/module"
@@ -108,5 +109,9 @@ func (builder *RPCEngineBuilder) Build() (*Engine, error) {
 	}
 	accessproto.RegisterAccessAPIServer(builder.unsecureGrpcServer.Server, rpcHandler)
 	accessproto.RegisterAccessAPIServer(builder.secureGrpcServer.Server, rpcHandler)
+
+	extendedHandler := access.NewExtendedHandler(builder.Engine.backend, builder.Engine.chain)
+	extended.RegisterExtendedAccessAPIServer(builder.unsecureGrpcServer.Server, extendedHandler)
+	extended.RegisterExtendedAccessAPIServer(builder.secureGrpcServer.Server, extendedHandler)
 	return builder.Engine, nil
 }
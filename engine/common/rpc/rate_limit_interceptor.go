@@ -14,8 +14,9 @@ import (
 const defaultRateLimit = 1000 // aggregate default rate limit for all unspecified API calls
 const defaultBurst = 100      // default burst limit (calls made at the same time) for an API
 
-// rateLimiterInterceptor rate limits the
-type rateLimiterInterceptor struct {
+// RateLimiterInterceptor rate limits API calls per method. A single instance may be shared by several
+// servers, so that the limits apply to the calls made through all of them.
+type RateLimiterInterceptor struct {
 	log zerolog.Logger
 
 	// a shared default rate limiter for APIs whose rate limit is not explicitly defined
@@ -27,7 +28,7 @@ type rateLimiterInterceptor struct {
 
 // NewRateLimiterInterceptor creates a new rate limiter interceptor with the defined per second rate limits and the
 // optional burst limit for each API.
-func NewRateLimiterInterceptor(log zerolog.Logger, apiRateLimits map[string]int, apiBurstLimits map[string]int) *rateLimiterInterceptor {
+func NewRateLimiterInterceptor(log zerolog.Logger, apiRateLimits map[string]int, apiBurstLimits map[string]int) *RateLimiterInterceptor {
 
 	defaultLimiter := rate.NewLimiter(rate.Limit(defaultRateLimit), defaultBurst)
 	methodLimiterMap := make(map[string]*rate.Limiter, len(apiRateLimits))
@@ -46,15 +47,15 @@ func NewRateLimiterInterceptor(log zerolog.Logger, apiRateLimits map[string]int,
 		log.Info().Int("default_rate_limit", defaultRateLimit).Msg("no rate limits specified, using the default limit")
 	}
 
-	return &rateLimiterInterceptor{
+	return &RateLimiterInterceptor{
 		defaultLimiter:   defaultLimiter,
 		methodLimiterMap: methodLimiterMap,
 		log:              log,
 	}
 }
 
-// UnaryServerInterceptor rate limits the given request based on the limits defined when creating the rateLimiterInterceptor
-func (interceptor *rateLimiterInterceptor) UnaryServerInterceptor(ctx context.Context,
+// UnaryServerInterceptor rate limits the given request based on the limits defined when creating the RateLimiterInterceptor
+func (interceptor *RateLimiterInterceptor) UnaryServerInterceptor(ctx context.Context,
 	req interface{},
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler) (resp interface{}, err error) {
@@ -62,25 +63,13 @@ func (interceptor *rateLimiterInterceptor) UnaryServerInterceptor(ctx context.Co
 	// remove the package name (e.g. "/flow.access.AccessAPI/Ping" to "Ping")
 	methodName := filepath.Base(info.FullMethod)
 
-	// look up the limiter
-	limiter := interceptor.methodLimiterMap[methodName]
-
-	// if not found, use the default limiter
-	if limiter == nil {
-
-		interceptor.log.Trace().Str("method", methodName).Msg("rate limit not defined, using default limit")
-
-		limiter = interceptor.defaultLimiter
-	}
-
 	// check if request within limit
-	if !limiter.Allow() {
+	if !interceptor.Allow(methodName) {
 
 		// log the limit violation
 		interceptor.log.Trace().
 			Str("method", methodName).
 			Interface("request", req).
-			Float64("limit", float64(limiter.Limit())).
 			Msg("rate limit exceeded")
 
 		// reject the request
@@ -93,3 +82,20 @@ func (interceptor *rateLimiterInterceptor) UnaryServerInterceptor(ctx context.Co
 
 	return h, err
 }
+
+// Allow returns true if a call to the API method with the given name (e.g. "Ping") is within the rate
+// limits, and consumes one token of the method's limiter.
+func (interceptor *RateLimiterInterceptor) Allow(methodName string) bool {
+	// look up the limiter
+	limiter := interceptor.methodLimiterMap[methodName]
+
+	// if not found, use the default limiter
+	if limiter == nil {
+
+		interceptor.log.Trace().Str("method", methodName).Msg("rate limit not defined, using default limit")
+
+		limiter = interceptor.defaultLimiter
+	}
+
+	return limiter.Allow()
+}
@@ -90,6 +90,18 @@ func (er *BlockExecutionResult) AllTransactionResults() flow.TransactionResults
 	return res
 }
 
+// AllTransactionWriteSets returns the register writes of each transaction of the block, in
+// the order of AllTransactionResults. Returns nil if the writes of transactions were not recorded.
+func (er *BlockExecutionResult) AllTransactionWriteSets() []map[flow.RegisterID]flow.RegisterValue {
+	var res []map[flow.RegisterID]flow.RegisterValue
+	for _, ce := range er.collectionExecutionResults {
+		if len(ce.transactionWriteSets) > 0 {
+			res = append(res, ce.transactionWriteSets...)
+		}
+	}
+	return res
+}
+
 func (er *BlockExecutionResult) AllExecutionSnapshots() []*snapshot.ExecutionSnapshot {
 	res := make([]*snapshot.ExecutionSnapshot, 0)
 	for _, ce := range er.collectionExecutionResults {
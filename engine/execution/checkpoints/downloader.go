@@ -0,0 +1,645 @@
+package checkpoints
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+// partialFileSuffix is appended to the names of the files which are being downloaded.
+const partialFileSuffix = ".download"
+
+// unverifiedFileSuffix is appended to the name of the downloaded checkpoint header until the
+// checkpoint has been verified.
+const unverifiedFileSuffix = ".unverified"
+
+// manifestFileSuffix is appended to the checkpoint file name to get the name of the file storing the
+// manifest of the checkpoint being downloaded.
+const manifestFileSuffix = ".manifest.json"
+
+// DefaultDownloadWorkers is the default number of checkpoint files downloaded in parallel.
+const DefaultDownloadWorkers = 4
+
+// DefaultStallTimeout is the default time after which a file transfer is aborted if no data was received.
+const DefaultStallTimeout = time.Minute
+
+// defaultRetryAfter is the delay before retrying a file request which was rejected because the server
+// is busy, if the server did not suggest a delay.
+const defaultRetryAfter = 10 * time.Second
+
+// ErrCheckpointUnavailable is returned when the checkpoint described by a manifest is no longer
+// served by the execution node, e.g. because it was removed after a newer checkpoint was created.
+var ErrCheckpointUnavailable = errors.New("checkpoint is no longer available")
+
+// errTransferStalled is the cause of the cancellation of a file transfer which stopped receiving data.
+var errTransferStalled = errors.New("no data received within the stall timeout")
+
+// errServerBusy is returned when the server rejects a file request because of too many transfers.
+type errServerBusy struct {
+	retryAfter time.Duration
+}
+
+func (e *errServerBusy) Error() string {
+	return fmt.Sprintf("checkpoint server is busy, retry after %v", e.retryAfter)
+}
+
+// NewHTTPClient returns a http client suitable for downloading checkpoints.
+// Connecting and waiting for response headers are bounded by timeouts. The client has no overall
+// request timeout, since checkpoint files can take hours to download. Stalled transfers are
+// detected by the Downloader instead.
+func NewHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: time.Minute,
+			IdleConnTimeout:       90 * time.Second,
+			MaxIdleConnsPerHost:   DefaultDownloadWorkers,
+		},
+	}
+}
+
+// Downloader downloads checkpoints from an execution node serving them with Server.
+//
+// Interrupted downloads are resumed: files which were downloaded completely are not downloaded
+// again, and partially downloaded files are continued from where the download stopped.
+type Downloader struct {
+	log          zerolog.Logger
+	client       *http.Client
+	baseURL      string
+	token        string
+	workers      int
+	stallTimeout time.Duration
+}
+
+// NewDownloader creates a downloader for the checkpoint server at the given base URL,
+// which downloads the given number of checkpoint files in parallel.
+// If token is not empty, it is sent as bearer token with every request.
+func NewDownloader(log zerolog.Logger, client *http.Client, baseURL string, token string, workers int) *Downloader {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Downloader{
+		log:          log.With().Str("component", "checkpoint-downloader").Str("source", baseURL).Logger(),
+		client:       client,
+		baseURL:      strings.TrimSuffix(baseURL, "/"),
+		token:        token,
+		workers:      workers,
+		stallTimeout: DefaultStallTimeout,
+	}
+}
+
+// LatestManifest returns the manifest of the latest checkpoint served by the execution node.
+// No errors are expected during normal operation.
+func (d *Downloader) LatestManifest(ctx context.Context) (*Manifest, error) {
+	req, err := d.newRequest(ctx, d.baseURL+ManifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not request checkpoint manifest: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not request checkpoint manifest: unexpected status %s", resp.Status)
+	}
+
+	var manifest Manifest
+	err = json.NewDecoder(resp.Body).Decode(&manifest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode checkpoint manifest: %w", err)
+	}
+
+	err = manifest.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint manifest: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+// DownloadLatest downloads the latest checkpoint served by the execution node into the given directory,
+// stored with the given file name (e.g. root.checkpoint). See Download for details.
+//
+// The manifest of the checkpoint is stored next to the downloaded files until the download is complete,
+// so that an interrupted download is resumed with the same checkpoint, even if the execution node has
+// created newer checkpoints in the meantime. If the execution node no longer serves that checkpoint,
+// the files downloaded so far are removed and the latest checkpoint is downloaded instead.
+// No errors are expected during normal operation.
+func (d *Downloader) DownloadLatest(
+	ctx context.Context,
+	dir string,
+	fileName string,
+	expectedRootHash ledger.RootHash,
+) error {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create checkpoint directory: %w", err)
+	}
+
+	manifestPath := filepath.Join(dir, fileName+manifestFileSuffix)
+
+	manifest, err := readManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	resumed := manifest != nil
+	if resumed {
+		d.log.Info().Int("checkpoint", manifest.Checkpoint).Msg("resuming checkpoint download")
+	} else {
+		manifest, err = d.fetchAndStoreManifest(ctx, manifestPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = d.Download(ctx, manifest, dir, fileName, expectedRootHash)
+	if errors.Is(err, ErrCheckpointUnavailable) && resumed {
+		d.log.Warn().Err(err).
+			Int("checkpoint", manifest.Checkpoint).
+			Msg("checkpoint of interrupted download is no longer available, downloading the latest checkpoint")
+
+		err = removeDownload(dir, fileName)
+		if err != nil {
+			return err
+		}
+		manifest, err = d.fetchAndStoreManifest(ctx, manifestPath)
+		if err != nil {
+			return err
+		}
+		err = d.Download(ctx, manifest, dir, fileName, expectedRootHash)
+	}
+	if err != nil {
+		if !errors.Is(err, ErrCheckpointUnavailable) && !isInvalidCheckpoint(err) {
+			// keep the manifest, so that the next attempt resumes the download
+			return err
+		}
+		removeErr := removeDownload(dir, fileName)
+		if removeErr != nil {
+			d.log.Warn().Err(removeErr).Msg("could not remove failed checkpoint download")
+		}
+		return err
+	}
+
+	err = os.Remove(manifestPath)
+	if err != nil {
+		return fmt.Errorf("could not remove checkpoint manifest: %w", err)
+	}
+
+	return nil
+}
+
+// fetchAndStoreManifest requests the manifest of the latest checkpoint and stores it at the given path.
+func (d *Downloader) fetchAndStoreManifest(ctx context.Context, manifestPath string) (*Manifest, error) {
+	manifest, err := d.LatestManifest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode checkpoint manifest: %w", err)
+	}
+
+	// write to a temporary file first, so that an interrupted write doesn't leave a corrupted manifest
+	tmpPath := manifestPath + partialFileSuffix
+	err = os.WriteFile(tmpPath, encoded, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not write checkpoint manifest: %w", err)
+	}
+	err = os.Rename(tmpPath, manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not move checkpoint manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// readManifest reads the manifest stored at the given path, it returns nil if there is no manifest.
+func readManifest(manifestPath string) (*Manifest, error) {
+	encoded, err := os.ReadFile(manifestPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not read checkpoint manifest: %w", err)
+	}
+
+	var manifest Manifest
+	err = json.Unmarshal(encoded, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode checkpoint manifest %v: %w", manifestPath, err)
+	}
+
+	err = manifest.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid checkpoint manifest %v: %w", manifestPath, err)
+	}
+
+	return &manifest, nil
+}
+
+// removeDownload removes the downloaded and partially downloaded files of the checkpoint with the
+// given file name, as well as the stored manifest.
+func removeDownload(dir string, fileName string) error {
+	paths := []string{filepath.Join(dir, fileName+manifestFileSuffix)}
+	for _, name := range wal.CheckpointFileNames(fileName) {
+		paths = append(paths, filepath.Join(dir, name), filepath.Join(dir, name+partialFileSuffix))
+	}
+	headerPath := filepath.Join(dir, fileName)
+	paths = append(paths, headerPath+unverifiedFileSuffix, headerPath+unverifiedFileSuffix+partialFileSuffix)
+
+	for _, path := range paths {
+		err := os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("could not remove checkpoint download file %v: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// invalidCheckpointError is returned by Download when the downloaded checkpoint is invalid.
+type invalidCheckpointError struct {
+	err error
+}
+
+func (e *invalidCheckpointError) Error() string {
+	return e.err.Error()
+}
+
+func (e *invalidCheckpointError) Unwrap() error {
+	return e.err
+}
+
+func isInvalidCheckpoint(err error) bool {
+	var invalidErr *invalidCheckpointError
+	return errors.As(err, &invalidErr)
+}
+
+// Download downloads the checkpoint described by the manifest into the given directory, stored with
+// the given file name (e.g. root.checkpoint). The checksum of every file is verified once it is
+// downloaded. Once all the files are downloaded, the tries of the checkpoint are rebuilt and the hashes
+// of all their nodes are recomputed, and the checkpoint must contain a trie with the expected root hash.
+//
+// The part files are downloaded first, and the header file is only downloaded once all part files
+// have been downloaded and verified. The header file is only moved into place once the checkpoint
+// has been verified. Therefore, the checkpoint is complete and valid if the header file exists.
+//
+// Expected errors:
+//   - ErrCheckpointUnavailable if the execution node no longer serves the checkpoint
+func (d *Downloader) Download(
+	ctx context.Context,
+	manifest *Manifest,
+	dir string,
+	fileName string,
+	expectedRootHash ledger.RootHash,
+) error {
+	err := manifest.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint manifest: %w", err)
+	}
+
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create checkpoint directory: %w", err)
+	}
+
+	lg := d.log.With().
+		Int("checkpoint", manifest.Checkpoint).
+		Str("checkpoint_file", filepath.Join(dir, fileName)).
+		Logger()
+
+	lg.Info().Int("files", len(manifest.Files)).Msg("downloading checkpoint")
+
+	names := wal.CheckpointFileNames(fileName)
+
+	// the first file is the header, which is downloaded after all the parts
+	group, gctx := errgroup.WithContext(ctx)
+	group.SetLimit(d.workers)
+	for i, file := range manifest.Files[1:] {
+		destPath := filepath.Join(dir, names[i+1])
+		group.Go(func() error {
+			return d.downloadFile(gctx, lg, file, destPath)
+		})
+	}
+
+	err = group.Wait()
+	if err != nil {
+		return fmt.Errorf("could not download checkpoint %d: %w", manifest.Checkpoint, err)
+	}
+
+	// the header is only moved into place once the checkpoint has been verified, a header left by
+	// a previous download is verified again
+	headerPath := filepath.Join(dir, names[0])
+	unverifiedHeaderPath := headerPath + unverifiedFileSuffix
+	if _, err := os.Stat(headerPath); err == nil {
+		err = os.Rename(headerPath, unverifiedHeaderPath)
+		if err != nil {
+			return fmt.Errorf("could not move checkpoint header %v: %w", headerPath, err)
+		}
+	}
+
+	err = d.downloadFile(ctx, lg, manifest.Files[0], unverifiedHeaderPath)
+	if err != nil {
+		return fmt.Errorf("could not download header of checkpoint %d: %w", manifest.Checkpoint, err)
+	}
+
+	err = verifyCheckpoint(lg, unverifiedHeaderPath, dir, fileName, expectedRootHash)
+	if err != nil {
+		// remove the downloaded checkpoint, so that it isn't mistaken for a valid checkpoint
+		for _, name := range names[1:] {
+			removeInvalidFile(lg, filepath.Join(dir, name))
+		}
+		removeInvalidFile(lg, unverifiedHeaderPath)
+		return &invalidCheckpointError{
+			err: fmt.Errorf("downloaded checkpoint %d is invalid: %w", manifest.Checkpoint, err),
+		}
+	}
+
+	err = os.Rename(unverifiedHeaderPath, headerPath)
+	if err != nil {
+		return fmt.Errorf("could not move checkpoint header %v: %w", unverifiedHeaderPath, err)
+	}
+
+	lg.Info().Msg("downloaded checkpoint")
+
+	return nil
+}
+
+// removeInvalidFile removes a file of an invalid checkpoint, failures are only logged.
+func removeInvalidFile(log zerolog.Logger, path string) {
+	err := os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		log.Warn().Err(err).Str("file", path).Msg("could not remove invalid checkpoint file")
+	}
+}
+
+// verifyCheckpoint rebuilds the tries of the checkpoint whose header is stored at headerPath, and
+// recomputes the hashes of all the trie nodes bottom-up. The checkpoint is valid if no node is corrupt
+// and it contains a trie with the expected root hash.
+func verifyCheckpoint(
+	log zerolog.Logger,
+	headerPath string,
+	dir string,
+	fileName string,
+	expectedRootHash ledger.RootHash,
+) error {
+	result, err := wal.VerifyCheckpointWithHeader(headerPath, dir, fileName, log)
+	if err != nil {
+		return fmt.Errorf("could not verify checkpoint: %w", err)
+	}
+
+	if result.IsCorrupted() {
+		return fmt.Errorf("checkpoint has %d corrupt subtries, first: %v", len(result.Corrupted), result.Corrupted[0])
+	}
+
+	if !slices.Contains(result.RootHashes, expectedRootHash) {
+		return fmt.Errorf("checkpoint does not contain a trie with root hash %v", expectedRootHash)
+	}
+
+	return nil
+}
+
+// downloadFile downloads the given checkpoint file to the destination path, resuming a previously
+// interrupted download if there is one. The file is only moved to the destination path once its
+// checksum has been verified.
+func (d *Downloader) downloadFile(ctx context.Context, log zerolog.Logger, file File, destPath string) error {
+	lg := log.With().Str("file", file.Name).Logger()
+
+	if _, err := os.Stat(destPath); err == nil {
+		// the file was downloaded completely by a previous attempt
+		err = verifyFile(destPath, file)
+		if err == nil {
+			lg.Info().Msg("checkpoint file already downloaded")
+			return nil
+		}
+
+		// the file is left over from a download of another checkpoint, or corrupted
+		lg.Warn().Err(err).Msg("existing checkpoint file does not match the manifest, downloading it again")
+		err = os.Remove(destPath)
+		if err != nil {
+			return fmt.Errorf("could not remove stale checkpoint file %v: %w", destPath, err)
+		}
+	}
+
+	partialPath := destPath + partialFileSuffix
+
+	for {
+		err := d.fetchFile(ctx, lg, file, partialPath)
+		if err == nil {
+			break
+		}
+
+		var busyErr *errServerBusy
+		if !errors.As(err, &busyErr) {
+			return err
+		}
+
+		lg.Info().Dur("retry_after", busyErr.retryAfter).Msg("checkpoint server is busy, waiting to retry")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(busyErr.retryAfter):
+		}
+	}
+
+	err := verifyFile(partialPath, file)
+	if err != nil {
+		// the partial file is corrupted, download it from the start on the next attempt
+		removeErr := os.Remove(partialPath)
+		if removeErr != nil {
+			lg.Warn().Err(removeErr).Msg("could not remove corrupted checkpoint file")
+		}
+		return fmt.Errorf("downloaded checkpoint file %v is invalid: %w", file.Name, err)
+	}
+
+	err = os.Rename(partialPath, destPath)
+	if err != nil {
+		return fmt.Errorf("could not move downloaded checkpoint file %v: %w", file.Name, err)
+	}
+
+	lg.Info().Uint64("size", file.Size).Msg("downloaded checkpoint file")
+
+	return nil
+}
+
+// fetchFile downloads the remaining bytes of the given file into the partial file.
+// The transfer is aborted if no data is received within the stall timeout.
+//
+// Expected errors:
+//   - ErrCheckpointUnavailable if the server doesn't have the file
+//   - errServerBusy if the server rejected the request because of too many transfers
+func (d *Downloader) fetchFile(ctx context.Context, log zerolog.Logger, file File, partialPath string) (errToReturn error) {
+	f, err := os.OpenFile(partialPath, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("could not open partial checkpoint file: %w", err)
+	}
+	defer func() {
+		closeErr := f.Close()
+		if errToReturn == nil && closeErr != nil {
+			errToReturn = fmt.Errorf("could not close partial checkpoint file: %w", closeErr)
+		}
+	}()
+
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("could not get partial checkpoint file info: %w", err)
+	}
+
+	offset := uint64(fileInfo.Size())
+	if offset > file.Size {
+		offset = 0
+	}
+	if offset == file.Size {
+		// the download was interrupted after all the bytes were written
+		return nil
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stallTimer := time.AfterFunc(d.stallTimeout, func() {
+		cancel(errTransferStalled)
+	})
+	defer stallTimer.Stop()
+
+	req, err := d.newRequest(ctx, d.baseURL+FilesPath+url.PathEscape(file.Name))
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not request checkpoint file %v: %w", file.Name, transferError(ctx, err))
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		log.Info().Uint64("offset", offset).Msg("resuming checkpoint file download")
+	case http.StatusOK:
+		// the server sent the whole file
+		offset = 0
+	case http.StatusNotFound:
+		return fmt.Errorf("could not request checkpoint file %v: %w", file.Name, ErrCheckpointUnavailable)
+	case http.StatusTooManyRequests:
+		return &errServerBusy{retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	default:
+		return fmt.Errorf("could not request checkpoint file %v: unexpected status %s", file.Name, resp.Status)
+	}
+
+	err = f.Truncate(int64(offset))
+	if err != nil {
+		return fmt.Errorf("could not truncate partial checkpoint file: %w", err)
+	}
+	_, err = f.Seek(int64(offset), io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("could not seek partial checkpoint file: %w", err)
+	}
+
+	body := &stallDetectingReader{
+		reader:  io.LimitReader(resp.Body, int64(file.Size-offset)),
+		timer:   stallTimer,
+		timeout: d.stallTimeout,
+	}
+	written, err := io.Copy(f, body)
+	if err != nil {
+		return fmt.Errorf("could not download checkpoint file %v: %w", file.Name, transferError(ctx, err))
+	}
+	if offset+uint64(written) != file.Size {
+		return fmt.Errorf("could not download checkpoint file %v: got %d bytes, expected %d",
+			file.Name, offset+uint64(written), file.Size)
+	}
+
+	err = f.Sync()
+	if err != nil {
+		return fmt.Errorf("could not sync partial checkpoint file: %w", err)
+	}
+
+	return nil
+}
+
+func (d *Downloader) newRequest(ctx context.Context, requestURL string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	if d.token != "" {
+		req.Header.Set("Authorization", "Bearer "+d.token)
+	}
+	return req, nil
+}
+
+// transferError returns the cause of the cancellation if the transfer stalled, and err otherwise.
+func transferError(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); errors.Is(cause, errTransferStalled) {
+		return cause
+	}
+	return err
+}
+
+// parseRetryAfter parses the delay in seconds of a Retry-After header.
+func parseRetryAfter(value string) time.Duration {
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds <= 0 {
+		return defaultRetryAfter
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// stallDetectingReader resets the stall timer whenever data is read.
+type stallDetectingReader struct {
+	reader  io.Reader
+	timer   *time.Timer
+	timeout time.Duration
+}
+
+func (r *stallDetectingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.timer.Reset(r.timeout)
+	}
+	return n, err
+}
+
+// verifyFile checks that the file at the given path matches the size and checksum of the manifest file.
+func verifyFile(filePath string, file File) error {
+	checksum, size, err := wal.ReadCheckpointFileChecksum(filePath)
+	if err != nil {
+		return err
+	}
+	if size != file.Size {
+		return fmt.Errorf("size mismatch, expected %d, got %d", file.Size, size)
+	}
+	if checksum != file.Checksum {
+		return fmt.Errorf("checksum mismatch, expected %d, got %d", file.Checksum, checksum)
+	}
+	return nil
+}
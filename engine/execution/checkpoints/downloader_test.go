@@ -0,0 +1,555 @@
+package checkpoints
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// storeRandomCheckpoint stores a v6 checkpoint with random tries as the given checkpoint number,
+// and returns the stored tries.
+func storeRandomCheckpoint(t *testing.T, dir string, checkpoint int) []*trie.MTrie {
+	tries := make([]*trie.MTrie, 0, 3)
+	activeTrie := trie.NewEmptyMTrie()
+	for i := 0; i < 3; i++ {
+		paths := testutils.RandomPaths(200)
+		payloads := testutils.RandomPayloads(len(paths), 10, 100)
+		values := make([]ledger.Payload, len(payloads))
+		for j, p := range payloads {
+			values[j] = *p
+		}
+
+		var err error
+		activeTrie, _, err = trie.NewTrieWithUpdatedRegisters(activeTrie, paths, values, true)
+		require.NoError(t, err)
+		tries = append(tries, activeTrie)
+	}
+
+	err := wal.StoreCheckpointV6Concurrently(tries, dir, wal.NumberToFilename(checkpoint), unittest.Logger())
+	require.NoError(t, err)
+
+	return tries
+}
+
+func runWithTempDirs(t *testing.T, f func(serverDir, clientDir string)) {
+	unittest.RunWithTempDir(t, func(serverDir string) {
+		unittest.RunWithTempDir(t, func(clientDir string) {
+			f(serverDir, clientDir)
+		})
+	})
+}
+
+func newTestHandler(log zerolog.Logger, dir string) http.Handler {
+	return NewHandler(log, dir, ServerConfig{}, NewTransferLeases(DefaultTransferLeaseDuration))
+}
+
+func requireTriesEqual(t *testing.T, expected, actual []*trie.MTrie) {
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.True(t, expected[i].Equals(actual[i]), "%v-th trie is different", i)
+	}
+}
+
+func TestDownloadCheckpoint(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		storeRandomCheckpoint(t, serverDir, 5)
+		tries := storeRandomCheckpoint(t, serverDir, 10)
+
+		server := httptest.NewServer(newTestHandler(log, serverDir))
+		defer server.Close()
+
+		downloader := NewDownloader(log, server.Client(), server.URL, "", DefaultDownloadWorkers)
+
+		manifest, err := downloader.LatestManifest(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 10, manifest.Checkpoint)
+		require.Equal(t, tries[len(tries)-1].RootHash().String(), manifest.RootHash)
+		require.Len(t, manifest.Files, 18)
+
+		rootHash, err := manifest.LedgerRootHash()
+		require.NoError(t, err)
+
+		err = downloader.Download(context.Background(), manifest, clientDir, bootstrap.FilenameWALRootCheckpoint, rootHash)
+		require.NoError(t, err)
+
+		downloaded, err := wal.LoadCheckpoint(filepath.Join(clientDir, bootstrap.FilenameWALRootCheckpoint), log)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, downloaded)
+
+		// downloading again doesn't download the files again
+		err = downloader.Download(context.Background(), manifest, clientDir, bootstrap.FilenameWALRootCheckpoint, tries[0].RootHash())
+		require.NoError(t, err)
+	})
+}
+
+func TestDownloadCheckpoint_Resume(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		tries := storeRandomCheckpoint(t, serverDir, 1)
+
+		var requests []string
+		handler := newTestHandler(log, serverDir)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.URL.Path+" "+r.Header.Get("Range"))
+			handler.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		// a single worker, so that requests are recorded sequentially
+		downloader := NewDownloader(log, server.Client(), server.URL, "", 1)
+		manifest, err := downloader.LatestManifest(context.Background())
+		require.NoError(t, err)
+
+		fileName := bootstrap.FilenameWALRootCheckpoint
+		names := wal.CheckpointFileNames(fileName)
+
+		// the first file was downloaded completely
+		data, err := os.ReadFile(filepath.Join(serverDir, manifest.Files[0].Name))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(clientDir, names[0]), data, 0600))
+
+		// the download of the second file was interrupted halfway
+		data, err = os.ReadFile(filepath.Join(serverDir, manifest.Files[1].Name))
+		require.NoError(t, err)
+		half := len(data) / 2
+		require.NoError(t, os.WriteFile(filepath.Join(clientDir, names[1]+partialFileSuffix), data[:half], 0600))
+
+		requests = nil
+		err = downloader.Download(context.Background(), manifest, clientDir, fileName, tries[len(tries)-1].RootHash())
+		require.NoError(t, err)
+
+		// the first file is not requested, and the second file is requested from where it was interrupted
+		require.Len(t, requests, len(manifest.Files)-1)
+		require.Contains(t, requests[0], manifest.Files[1].Name)
+		require.Contains(t, requests[0], "bytes=")
+
+		downloaded, err := wal.LoadCheckpoint(filepath.Join(clientDir, fileName), log)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, downloaded)
+	})
+}
+
+func TestDownloadCheckpoint_CorruptedPartialFile(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		tries := storeRandomCheckpoint(t, serverDir, 1)
+
+		server := httptest.NewServer(newTestHandler(log, serverDir))
+		defer server.Close()
+
+		downloader := NewDownloader(log, server.Client(), server.URL, "", DefaultDownloadWorkers)
+		manifest, err := downloader.LatestManifest(context.Background())
+		require.NoError(t, err)
+
+		fileName := bootstrap.FilenameWALRootCheckpoint
+		names := wal.CheckpointFileNames(fileName)
+
+		// the partially downloaded file is corrupted
+		data, err := os.ReadFile(filepath.Join(serverDir, manifest.Files[2].Name))
+		require.NoError(t, err)
+		corrupted := append([]byte{}, data[:len(data)/2]...)
+		corrupted[len(corrupted)-1] ^= 0xff
+		partialPath := filepath.Join(clientDir, names[2]+partialFileSuffix)
+		require.NoError(t, os.WriteFile(partialPath, corrupted, 0600))
+
+		err = downloader.Download(context.Background(), manifest, clientDir, fileName, tries[len(tries)-1].RootHash())
+		require.ErrorContains(t, err, "invalid checksum")
+		require.NoFileExists(t, partialPath)
+
+		// the next attempt downloads the corrupted file from the start
+		err = downloader.Download(context.Background(), manifest, clientDir, fileName, tries[len(tries)-1].RootHash())
+		require.NoError(t, err)
+	})
+}
+
+func TestDownloadCheckpoint_RootHashMismatch(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		storeRandomCheckpoint(t, serverDir, 1)
+
+		server := httptest.NewServer(newTestHandler(log, serverDir))
+		defer server.Close()
+
+		downloader := NewDownloader(log, server.Client(), server.URL, "", DefaultDownloadWorkers)
+		manifest, err := downloader.LatestManifest(context.Background())
+		require.NoError(t, err)
+
+		err = downloader.Download(context.Background(), manifest, clientDir, bootstrap.FilenameWALRootCheckpoint, testutils.RootHashFixture())
+		require.ErrorContains(t, err, "invalid")
+
+		// the invalid checkpoint is removed
+		require.NoFileExists(t, filepath.Join(clientDir, bootstrap.FilenameWALRootCheckpoint))
+	})
+}
+
+func TestDownloadCheckpoint_CorruptNode(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+
+		// an interim node whose stored hash doesn't match its children, the checksums of the
+		// files and the root hash are consistent with the stored hash
+		path := testutils.PathByUint8(1)
+		payload := testutils.LightPayload8(1, 1)
+		leaf := node.NewLeaf(path, payload, 250)
+		n := node.NewNode(251, leaf, nil, ledger.DummyPath, nil, hash.Hash{1, 2, 3})
+		for height := 252; height <= ledger.NodeMaxHeight; height++ {
+			n = node.NewInterimNode(height, n, nil)
+		}
+		tr, err := trie.NewMTrie(n, 1, uint64(payload.Size()))
+		require.NoError(t, err)
+		require.NoError(t, wal.StoreCheckpointV6Concurrently([]*trie.MTrie{tr}, serverDir, wal.NumberToFilename(1), log))
+
+		server := httptest.NewServer(newTestHandler(log, serverDir))
+		defer server.Close()
+
+		downloader := NewDownloader(log, server.Client(), server.URL, "", DefaultDownloadWorkers)
+		manifest, err := downloader.LatestManifest(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, tr.RootHash().String(), manifest.RootHash)
+
+		fileName := bootstrap.FilenameWALRootCheckpoint
+		err = downloader.Download(context.Background(), manifest, clientDir, fileName, tr.RootHash())
+		require.ErrorContains(t, err, "corrupt")
+		require.True(t, isInvalidCheckpoint(err))
+
+		// the header is never moved into place, and the invalid checkpoint is removed
+		for _, name := range wal.CheckpointFileNames(fileName) {
+			require.NoFileExists(t, filepath.Join(clientDir, name))
+		}
+		require.NoFileExists(t, filepath.Join(clientDir, fileName+unverifiedFileSuffix))
+	})
+}
+
+func TestDownloadCheckpoint_HeaderDownloadedLast(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		tries := storeRandomCheckpoint(t, serverDir, 1)
+
+		fail := true
+		var requests []string
+		handler := newTestHandler(log, serverDir)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.URL.Path)
+			if fail && strings.HasSuffix(r.URL.Path, ".016") {
+				http.Error(w, "failed", http.StatusInternalServerError)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		// a single worker, so that requests are recorded sequentially
+		downloader := NewDownloader(log, server.Client(), server.URL, "", 1)
+		manifest, err := downloader.LatestManifest(context.Background())
+		require.NoError(t, err)
+
+		fileName := bootstrap.FilenameWALRootCheckpoint
+		rootHash := tries[len(tries)-1].RootHash()
+
+		// the header is not downloaded if a part file fails, so the checkpoint isn't mistaken as complete
+		err = downloader.Download(context.Background(), manifest, clientDir, fileName, rootHash)
+		require.Error(t, err)
+		require.NoFileExists(t, filepath.Join(clientDir, fileName))
+
+		fail = false
+		requests = nil
+		err = downloader.Download(context.Background(), manifest, clientDir, fileName, rootHash)
+		require.NoError(t, err)
+		require.Equal(t, []string{FilesPath + manifest.Files[17].Name, FilesPath + manifest.Files[0].Name}, requests)
+	})
+}
+
+func TestDownloadLatest_ResumesManifest(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		tries := storeRandomCheckpoint(t, serverDir, 1)
+		rootHash := tries[len(tries)-1].RootHash()
+		fileName := bootstrap.FilenameWALRootCheckpoint
+
+		fail := true
+		handler := newTestHandler(log, serverDir)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if fail && strings.HasSuffix(r.URL.Path, ".016") {
+				http.Error(w, "failed", http.StatusInternalServerError)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		downloader := NewDownloader(log, server.Client(), server.URL, "", DefaultDownloadWorkers)
+
+		err := downloader.DownloadLatest(context.Background(), clientDir, fileName, rootHash)
+		require.Error(t, err)
+		require.FileExists(t, filepath.Join(clientDir, fileName+manifestFileSuffix))
+
+		// a newer checkpoint is created while the download is interrupted
+		storeRandomCheckpoint(t, serverDir, 2)
+
+		// the download is resumed with the checkpoint of the first attempt
+		fail = false
+		err = downloader.DownloadLatest(context.Background(), clientDir, fileName, rootHash)
+		require.NoError(t, err)
+		require.NoFileExists(t, filepath.Join(clientDir, fileName+manifestFileSuffix))
+
+		downloaded, err := wal.LoadCheckpoint(filepath.Join(clientDir, fileName), log)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, downloaded)
+	})
+}
+
+func TestDownloadLatest_CheckpointRemoved(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		storeRandomCheckpoint(t, serverDir, 1)
+		fileName := bootstrap.FilenameWALRootCheckpoint
+
+		fail := true
+		handler := newTestHandler(log, serverDir)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if fail && strings.HasSuffix(r.URL.Path, ".016") {
+				http.Error(w, "failed", http.StatusInternalServerError)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		downloader := NewDownloader(log, server.Client(), server.URL, "", DefaultDownloadWorkers)
+
+		err := downloader.DownloadLatest(context.Background(), clientDir, fileName, testutils.RootHashFixture())
+		require.Error(t, err)
+
+		// the checkpoint of the interrupted download is replaced by a newer checkpoint
+		tries := storeRandomCheckpoint(t, serverDir, 2)
+		for _, name := range wal.CheckpointFileNames(wal.NumberToFilename(1)) {
+			require.NoError(t, os.Remove(filepath.Join(serverDir, name)))
+		}
+
+		// the stale files are removed, and the latest checkpoint is downloaded
+		fail = false
+		err = downloader.DownloadLatest(context.Background(), clientDir, fileName, tries[len(tries)-1].RootHash())
+		require.NoError(t, err)
+
+		downloaded, err := wal.LoadCheckpoint(filepath.Join(clientDir, fileName), log)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, downloaded)
+	})
+}
+
+func TestDownloadCheckpoint_ServerBusy(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		tries := storeRandomCheckpoint(t, serverDir, 1)
+
+		rejected := false
+		handler := newTestHandler(log, serverDir)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !rejected && strings.HasPrefix(r.URL.Path, FilesPath) {
+				rejected = true
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, "busy", http.StatusTooManyRequests)
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		downloader := NewDownloader(log, server.Client(), server.URL, "", 1)
+		manifest, err := downloader.LatestManifest(context.Background())
+		require.NoError(t, err)
+
+		// the rejected request is retried
+		err = downloader.Download(context.Background(), manifest, clientDir, bootstrap.FilenameWALRootCheckpoint, tries[len(tries)-1].RootHash())
+		require.NoError(t, err)
+		require.True(t, rejected)
+	})
+}
+
+func TestDownloadCheckpoint_StalledTransfer(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		tries := storeRandomCheckpoint(t, serverDir, 1)
+
+		handler := newTestHandler(log, serverDir)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasPrefix(r.URL.Path, FilesPath) {
+				// send the headers and a few bytes, then stop sending data
+				w.Header().Set("Content-Length", "1000000")
+				_, _ = w.Write([]byte("partial"))
+				w.(http.Flusher).Flush()
+				<-r.Context().Done()
+				return
+			}
+			handler.ServeHTTP(w, r)
+		}))
+		defer server.Close()
+
+		downloader := NewDownloader(log, server.Client(), server.URL, "", 1)
+		downloader.stallTimeout = 100 * time.Millisecond
+		manifest, err := downloader.LatestManifest(context.Background())
+		require.NoError(t, err)
+
+		err = downloader.Download(context.Background(), manifest, clientDir, bootstrap.FilenameWALRootCheckpoint, tries[len(tries)-1].RootHash())
+		require.ErrorIs(t, err, errTransferStalled)
+	})
+}
+
+func TestServer_Token(t *testing.T) {
+	runWithTempDirs(t, func(serverDir, clientDir string) {
+		log := unittest.Logger()
+		tries := storeRandomCheckpoint(t, serverDir, 1)
+
+		handler := NewHandler(log, serverDir, ServerConfig{Token: "secret"}, NewTransferLeases(DefaultTransferLeaseDuration))
+		server := httptest.NewServer(handler)
+		defer server.Close()
+
+		for _, token := range []string{"", "wrong"} {
+			_, err := NewDownloader(log, server.Client(), server.URL, token, 1).LatestManifest(context.Background())
+			require.ErrorContains(t, err, "401")
+		}
+
+		downloader := NewDownloader(log, server.Client(), server.URL, "secret", DefaultDownloadWorkers)
+		err := downloader.DownloadLatest(context.Background(), clientDir, bootstrap.FilenameWALRootCheckpoint, tries[len(tries)-1].RootHash())
+		require.NoError(t, err)
+	})
+}
+
+func TestServer_MaxConcurrentTransfers(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		log := unittest.Logger()
+		storeRandomCheckpoint(t, dir, 1)
+
+		handler := NewHandler(log, dir, ServerConfig{MaxConcurrentTransfers: 1}, NewTransferLeases(DefaultTransferLeaseDuration))
+
+		// hold the only transfer slot by not reading the response of the first request
+		blocked := make(chan struct{})
+		release := make(chan struct{})
+		go func() {
+			req := httptest.NewRequest(http.MethodGet, FilesPath+wal.NumberToFilename(1)+".000", nil)
+			handler.ServeHTTP(&blockingResponseWriter{ResponseRecorder: httptest.NewRecorder(), blocked: blocked, release: release}, req)
+		}()
+		<-blocked
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, FilesPath+wal.NumberToFilename(1)+".001", nil))
+		require.Equal(t, http.StatusTooManyRequests, rr.Code)
+		require.NotEmpty(t, rr.Header().Get("Retry-After"))
+
+		close(release)
+	})
+}
+
+// blockingResponseWriter blocks the first write until released.
+type blockingResponseWriter struct {
+	*httptest.ResponseRecorder
+	blocked chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (w *blockingResponseWriter) Write(b []byte) (int, error) {
+	w.once.Do(func() {
+		close(w.blocked)
+		<-w.release
+	})
+	return w.ResponseRecorder.Write(b)
+}
+
+func TestServer_ExtendsLeases(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		log := unittest.Logger()
+		storeRandomCheckpoint(t, dir, 1)
+		storeRandomCheckpoint(t, dir, 2)
+
+		leases := NewTransferLeases(DefaultTransferLeaseDuration)
+		handler := NewHandler(log, dir, ServerConfig{}, leases)
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, ManifestPath, nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.True(t, leases.InUse(2))
+		require.False(t, leases.InUse(1))
+
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, FilesPath+wal.NumberToFilename(1)+".003", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		require.True(t, leases.InUse(1))
+	})
+}
+
+func TestTransferLeases(t *testing.T) {
+	now := time.Now()
+	leases := NewTransferLeases(time.Minute)
+	leases.now = func() time.Time { return now }
+
+	require.False(t, leases.InUse(1))
+
+	leases.Extend(1)
+	require.True(t, leases.InUse(1))
+
+	now = now.Add(time.Minute)
+	require.True(t, leases.InUse(1))
+
+	now = now.Add(time.Second)
+	require.False(t, leases.InUse(1))
+
+	// expired leases are dropped when another lease is extended
+	leases.Extend(2)
+	require.NotContains(t, leases.expiry, 1)
+	require.True(t, leases.InUse(2))
+}
+
+func TestServer_Files(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		log := unittest.Logger()
+
+		server := httptest.NewServer(newTestHandler(log, dir))
+		defer server.Close()
+
+		// no checkpoint available
+		resp, err := server.Client().Get(server.URL + ManifestPath)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		storeRandomCheckpoint(t, dir, 1)
+
+		// files other than checkpoint files are not served
+		for _, name := range []string{"..%2Fsecret", "00000001", "checkpoint.1"} {
+			resp, err = server.Client().Get(server.URL + FilesPath + name)
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			require.NotEqual(t, http.StatusOK, resp.StatusCode, name)
+		}
+
+		resp, err = server.Client().Get(server.URL + FilesPath + wal.NumberToFilename(2))
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+		resp, err = server.Client().Get(server.URL + FilesPath + wal.NumberToFilename(1) + ".016")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
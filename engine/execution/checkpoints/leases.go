@@ -0,0 +1,59 @@
+package checkpoints
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultTransferLeaseDuration is the default time a checkpoint is protected from removal after
+// its manifest or one of its files was last requested.
+const DefaultTransferLeaseDuration = 10 * time.Minute
+
+// TransferLeases keeps track of the checkpoints which are being downloaded from the Server.
+//
+// A download consists of many independent requests (the manifest, then each file, possibly resumed
+// several times), so the server can't tell when a download is finished. Instead, every request for
+// a checkpoint extends its lease, and the checkpoint is considered in use until the lease expires.
+// The compactor must not remove checkpoints which are in use, otherwise downloads in progress fail.
+//
+// TransferLeases is safe for concurrent use.
+type TransferLeases struct {
+	mu       sync.Mutex
+	duration time.Duration
+	expiry   map[int]time.Time // checkpoint number -> time the lease expires
+	now      func() time.Time
+}
+
+// NewTransferLeases creates a new TransferLeases with the given lease duration.
+func NewTransferLeases(duration time.Duration) *TransferLeases {
+	return &TransferLeases{
+		duration: duration,
+		expiry:   make(map[int]time.Time),
+		now:      time.Now,
+	}
+}
+
+// Extend extends the lease of the given checkpoint.
+func (l *TransferLeases) Extend(checkpoint int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.expiry[checkpoint] = now.Add(l.duration)
+
+	// drop expired leases, so that the map doesn't grow with every served checkpoint
+	for c, expiry := range l.expiry {
+		if now.After(expiry) {
+			delete(l.expiry, c)
+		}
+	}
+}
+
+// InUse returns true if the given checkpoint has been requested within the lease duration.
+func (l *TransferLeases) InUse(checkpoint int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	expiry, ok := l.expiry[checkpoint]
+	return ok && !l.now().After(expiry)
+}
@@ -0,0 +1,133 @@
+package checkpoints
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+)
+
+// ErrNoCheckpoint is returned when there is no full checkpoint which can be served.
+var ErrNoCheckpoint = errors.New("no checkpoint available")
+
+// Manifest describes a checkpoint which can be downloaded from an execution node.
+type Manifest struct {
+	// Checkpoint is the number of the checkpoint.
+	Checkpoint int `json:"checkpoint"`
+	// FileName is the name of the checkpoint header file.
+	FileName string `json:"file_name"`
+	// RootHash is the hex-encoded root hash of the last trie in the checkpoint.
+	RootHash string `json:"root_hash"`
+	// Files are the header file followed by the part files of the checkpoint.
+	Files []File `json:"files"`
+}
+
+// File describes a single file of a checkpoint.
+type File struct {
+	Name     string `json:"name"`
+	Size     uint64 `json:"size"`
+	Checksum uint32 `json:"checksum"` // CRC32 checksum of the file, as stored at the end of the file
+}
+
+// LedgerRootHash returns the decoded root hash of the manifest.
+func (m *Manifest) LedgerRootHash() (ledger.RootHash, error) {
+	decoded, err := hex.DecodeString(m.RootHash)
+	if err != nil {
+		return ledger.RootHash{}, fmt.Errorf("could not decode root hash: %w", err)
+	}
+	return ledger.ToRootHash(decoded)
+}
+
+// Validate checks that the manifest describes a v6 checkpoint.
+func (m *Manifest) Validate() error {
+	expected := wal.CheckpointFileNames(m.FileName)
+	if len(m.Files) != len(expected) {
+		return fmt.Errorf("expected %d checkpoint files, got %d", len(expected), len(m.Files))
+	}
+	for i, name := range expected {
+		if m.Files[i].Name != name {
+			return fmt.Errorf("expected checkpoint file %v at index %d, got %v", name, i, m.Files[i].Name)
+		}
+	}
+	if m.FileName != wal.NumberToFilename(m.Checkpoint) {
+		return fmt.Errorf("checkpoint file name %v does not match checkpoint number %d", m.FileName, m.Checkpoint)
+	}
+	_, err := m.LedgerRootHash()
+	return err
+}
+
+// LatestManifest returns the manifest of the latest full checkpoint in the given directory.
+// Delta checkpoints are skipped, since they can't be used without their base checkpoints.
+//
+// Expected errors:
+//   - ErrNoCheckpoint if there is no full checkpoint in the directory
+func LatestManifest(log zerolog.Logger, dir string) (*Manifest, error) {
+	checkpoints, err := wal.Checkpoints(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not list checkpoints: %w", err)
+	}
+
+	for i := len(checkpoints) - 1; i >= 0; i-- {
+		fileName := wal.NumberToFilename(checkpoints[i])
+		version, err := wal.ReadCheckpointVersion(dir, fileName)
+		if err != nil {
+			return nil, fmt.Errorf("could not read version of checkpoint %d: %w", checkpoints[i], err)
+		}
+		if version != wal.VersionV6 {
+			continue
+		}
+
+		return NewManifest(log, dir, checkpoints[i])
+	}
+
+	return nil, ErrNoCheckpoint
+}
+
+// NewManifest creates the manifest of the given v6 checkpoint.
+// The checksums of the checkpoint files are taken from the checkpoint header, after checking that
+// they match the checksums stored in the part files.
+// No errors are expected during normal operation.
+func NewManifest(log zerolog.Logger, dir string, checkpoint int) (*Manifest, error) {
+	fileName := wal.NumberToFilename(checkpoint)
+
+	// ReadTriesRootHash validates the checksums of the header and the part files
+	rootHashes, err := wal.ReadTriesRootHash(log, dir, fileName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read root hashes of checkpoint %d: %w", checkpoint, err)
+	}
+	if len(rootHashes) == 0 {
+		return nil, fmt.Errorf("checkpoint %d has no tries", checkpoint)
+	}
+
+	checksums, err := wal.ReadCheckpointFileChecksums(dir, fileName, log)
+	if err != nil {
+		return nil, fmt.Errorf("could not read checksums of checkpoint %d: %w", checkpoint, err)
+	}
+
+	names := wal.CheckpointFileNames(fileName)
+	files := make([]File, 0, len(names))
+	for i, name := range names {
+		fileInfo, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("could not get file info of checkpoint file %v: %w", name, err)
+		}
+		files = append(files, File{
+			Name:     name,
+			Size:     uint64(fileInfo.Size()),
+			Checksum: checksums[i],
+		})
+	}
+
+	return &Manifest{
+		Checkpoint: checkpoint,
+		FileName:   fileName,
+		RootHash:   rootHashes[len(rootHashes)-1].String(),
+		Files:      files,
+	}, nil
+}
@@ -0,0 +1,249 @@
+package checkpoints
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+const (
+	// ManifestPath is the path of the manifest of the latest checkpoint.
+	ManifestPath = "/checkpoints/latest"
+	// FilesPath is the path prefix of the checkpoint files.
+	FilesPath = "/checkpoints/files/"
+)
+
+// DefaultMaxConcurrentTransfers is the default number of checkpoint files served at the same time.
+const DefaultMaxConcurrentTransfers = 8
+
+// serverShutdownTimeout is the time to wait for the server to shut down gracefully
+const serverShutdownTimeout = 5 * time.Second
+
+// retryAfterSeconds is the delay suggested to clients when all transfer slots are taken
+const retryAfterSeconds = 10
+
+// checkpointFileNameRegex matches the names of checkpoint header and part files,
+// and captures the name of the checkpoint header file
+var checkpointFileNameRegex = regexp.MustCompile(`^(checkpoint\.\d{8})(\.\d{3})?$`)
+
+// ServerConfig is the configuration of the checkpoint Server.
+type ServerConfig struct {
+	// Address is the address to listen on.
+	Address string
+	// Token is the bearer token clients must send in the Authorization header.
+	// If empty, checkpoints are served to anyone who can reach the address.
+	Token string
+	// MaxConcurrentTransfers is the maximum number of checkpoint files served at the same time.
+	// Further file requests are rejected with 429 Too Many Requests until a transfer finishes.
+	MaxConcurrentTransfers uint
+}
+
+// Server is the http server which serves the latest checkpoint of an execution node, so that other
+// execution nodes can download it when bootstrapping.
+//
+// The server exposes two endpoints:
+//   - GET /checkpoints/latest returns the Manifest of the latest full checkpoint
+//   - GET /checkpoints/files/{name} returns a checkpoint file, range requests are supported
+//
+// Every request for a checkpoint extends its lease in TransferLeases, which the compactor uses to
+// keep the checkpoint until the download is finished.
+type Server struct {
+	component.Component
+
+	address string
+	server  *http.Server
+	log     zerolog.Logger
+}
+
+// NewServer creates a new server which serves the checkpoints in the given directory.
+func NewServer(log zerolog.Logger, dir string, config ServerConfig, leases *TransferLeases) *Server {
+	log = log.With().Str("component", "checkpoint-server").Str("address", config.Address).Logger()
+
+	if config.Token == "" {
+		log.Warn().Msg("checkpoint server has no token configured, checkpoints are served to anyone who can reach the address")
+	}
+
+	s := &Server{
+		address: config.Address,
+		server: &http.Server{
+			Addr:              config.Address,
+			Handler:           NewHandler(log, dir, config, leases),
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       time.Minute,
+		},
+		log: log,
+	}
+
+	s.Component = component.NewComponentManagerBuilder().
+		AddWorker(s.serve).
+		AddWorker(s.shutdownOnContextDone).
+		Build()
+
+	return s
+}
+
+func (s *Server) serve(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	s.log.Info().Msg("starting checkpoint server on address")
+
+	l, err := net.Listen("tcp", s.address)
+	if err != nil {
+		s.log.Err(err).Msg("failed to start the checkpoint server")
+		ctx.Throw(err)
+		return
+	}
+
+	ready()
+
+	// pass the signaler context to the server so that the signaler context
+	// can control the server's lifetime
+	s.server.BaseContext = func(_ net.Listener) context.Context {
+		return ctx
+	}
+
+	err = s.server.Serve(l) // blocking call
+	if err != nil {
+		if errors.Is(err, http.ErrServerClosed) {
+			return
+		}
+		s.log.Err(err).Msg("fatal error in the checkpoint server")
+		ctx.Throw(err)
+	}
+}
+
+func (s *Server) shutdownOnContextDone(ictx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+	<-ictx.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+	defer cancel()
+
+	// shutdown the server gracefully
+	err := s.server.Shutdown(ctx)
+	if err == nil {
+		s.log.Info().Msg("checkpoint server graceful shutdown completed")
+		return
+	}
+
+	if errors.Is(err, ctx.Err()) {
+		s.log.Warn().Msg("checkpoint server graceful shutdown timed out")
+		// shutdown the server forcefully
+		err := s.server.Close()
+		if err != nil {
+			s.log.Err(err).Msg("error closing checkpoint server")
+		}
+	} else {
+		s.log.Err(err).Msg("error shutting down checkpoint server")
+	}
+}
+
+// NewHandler returns the http handler which serves the checkpoints in the given directory.
+// The Address of the config is ignored.
+func NewHandler(log zerolog.Logger, dir string, config ServerConfig, leases *TransferLeases) http.Handler {
+	mux := http.NewServeMux()
+
+	maxTransfers := config.MaxConcurrentTransfers
+	if maxTransfers == 0 {
+		maxTransfers = DefaultMaxConcurrentTransfers
+	}
+	transfers := make(chan struct{}, maxTransfers)
+
+	mux.HandleFunc("GET "+ManifestPath, func(w http.ResponseWriter, r *http.Request) {
+		manifest, err := LatestManifest(log, dir)
+		if err != nil {
+			if errors.Is(err, ErrNoCheckpoint) {
+				http.Error(w, err.Error(), http.StatusNotFound)
+				return
+			}
+			log.Err(err).Msg("failed to create checkpoint manifest")
+			http.Error(w, "failed to create checkpoint manifest", http.StatusInternalServerError)
+			return
+		}
+
+		leases.Extend(manifest.Checkpoint)
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(manifest)
+		if err != nil {
+			log.Warn().Err(err).Msg("failed to write checkpoint manifest")
+		}
+	})
+
+	mux.HandleFunc("GET "+FilesPath+"{name}", func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		match := checkpointFileNameRegex.FindStringSubmatch(name)
+		if match == nil {
+			http.Error(w, "invalid checkpoint file name", http.StatusBadRequest)
+			return
+		}
+		checkpoint, ok := wal.FilenameToNumber(match[1])
+		if !ok {
+			http.Error(w, "invalid checkpoint file name", http.StatusBadRequest)
+			return
+		}
+
+		select {
+		case transfers <- struct{}{}:
+			defer func() { <-transfers }()
+		default:
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+			http.Error(w, "too many concurrent checkpoint transfers", http.StatusTooManyRequests)
+			return
+		}
+
+		// extend the lease before opening the file, and again once the transfer is finished,
+		// so that the checkpoint is kept while the client requests the remaining files
+		leases.Extend(checkpoint)
+		defer leases.Extend(checkpoint)
+
+		file, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				http.Error(w, "checkpoint file not found", http.StatusNotFound)
+				return
+			}
+			log.Err(err).Str("file", name).Msg("failed to open checkpoint file")
+			http.Error(w, "failed to open checkpoint file", http.StatusInternalServerError)
+			return
+		}
+		defer file.Close()
+
+		fileInfo, err := file.Stat()
+		if err != nil {
+			log.Err(err).Str("file", name).Msg("failed to get checkpoint file info")
+			http.Error(w, "failed to open checkpoint file", http.StatusInternalServerError)
+			return
+		}
+
+		log.Debug().Str("file", name).Str("range", r.Header.Get("Range")).Msg("serving checkpoint file")
+
+		// ServeContent handles range requests, which are used to resume interrupted downloads
+		http.ServeContent(w, r, name, fileInfo.ModTime(), file)
+	})
+
+	if config.Token == "" {
+		return mux
+	}
+
+	expected := []byte("Bearer " + config.Token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			http.Error(w, "invalid or missing checkpoint server token", http.StatusUnauthorized)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
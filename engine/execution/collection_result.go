@@ -13,6 +13,10 @@ type CollectionExecutionResult struct {
 	convertedServiceEvents flow.ServiceEventList
 	transactionResults     flow.TransactionResults
 	executionSnapshot      *snapshot.ExecutionSnapshot
+
+	// transactionWriteSets holds the register writes of each transaction,
+	// only when the block computer records them.
+	transactionWriteSets []map[flow.RegisterID]flow.RegisterValue
 }
 
 // NewEmptyCollectionExecutionResult constructs a new  CollectionExecutionResult
@@ -37,6 +41,19 @@ func (c *CollectionExecutionResult) AppendTransactionResults(
 	c.transactionResults = append(c.transactionResults, transactionResult)
 }
 
+// AppendTransactionWriteSet records the register writes of the next transaction of the collection.
+func (c *CollectionExecutionResult) AppendTransactionWriteSet(
+	writeSet map[flow.RegisterID]flow.RegisterValue,
+) {
+	c.transactionWriteSets = append(c.transactionWriteSets, writeSet)
+}
+
+// TransactionWriteSets returns the register writes of each transaction of the collection,
+// or nil if they were not recorded.
+func (c *CollectionExecutionResult) TransactionWriteSets() []map[flow.RegisterID]flow.RegisterValue {
+	return c.transactionWriteSets
+}
+
 func (c *CollectionExecutionResult) UpdateExecutionSnapshot(
 	executionSnapshot *snapshot.ExecutionSnapshot,
 ) {
@@ -117,6 +117,11 @@ type blockComputer struct {
 	colResCons            []result.ExecutedCollectionConsumer
 	protocolState         protocol.SnapshotExecutionSubsetProvider
 	maxConcurrency        int
+	transactionProfiler   *TransactionProfiler
+
+	// recordTransactionWriteSets determines if the register writes of each transaction
+	// are recorded in the computation result, e.g. to compare them with a shadow execution.
+	recordTransactionWriteSets bool
 }
 
 func SystemChunkContext(vmCtx fvm.Context, metrics module.ExecutionMetrics) fvm.Context {
@@ -136,6 +141,24 @@ func SystemChunkContext(vmCtx fvm.Context, metrics module.ExecutionMetrics) fvm.
 	)
 }
 
+// BlockComputerOption configures optional behaviour of a block computer.
+type BlockComputerOption func(*blockComputer)
+
+// WithTransactionProfiler profiles the computation of transactions on request of the given profiler.
+func WithTransactionProfiler(transactionProfiler *TransactionProfiler) BlockComputerOption {
+	return func(e *blockComputer) {
+		e.transactionProfiler = transactionProfiler
+	}
+}
+
+// WithTransactionWriteSets records the register writes of each transaction in the computation result,
+// e.g. to compare them with a shadow execution.
+func WithTransactionWriteSets() BlockComputerOption {
+	return func(e *blockComputer) {
+		e.recordTransactionWriteSets = true
+	}
+}
+
 // NewBlockComputer creates a new block executor.
 func NewBlockComputer(
 	vm fvm.VM,
@@ -149,6 +172,7 @@ func NewBlockComputer(
 	colResCons []result.ExecutedCollectionConsumer,
 	state protocol.SnapshotExecutionSubsetProvider,
 	maxConcurrency int,
+	options ...BlockComputerOption,
 ) (BlockComputer, error) {
 	if maxConcurrency < 1 {
 		return nil, fmt.Errorf("invalid maxConcurrency: %d", maxConcurrency)
@@ -165,7 +189,7 @@ func NewBlockComputer(
 		vmCtx,
 		fvm.WithMetricsReporter(metrics),
 		fvm.WithTracer(tracer))
-	return &blockComputer{
+	e := &blockComputer{
 		vm:                    vm,
 		vmCtx:                 vmCtx,
 		metrics:               metrics,
@@ -180,7 +204,11 @@ func NewBlockComputer(
 		colResCons:            colResCons,
 		protocolState:         state,
 		maxConcurrency:        maxConcurrency,
-	}, nil
+	}
+	for _, apply := range options {
+		apply(e)
+	}
+	return e, nil
 }
 
 // ExecuteBlock executes a block and returns the resulting chunks.
@@ -374,6 +402,7 @@ func (e *blockComputer) executeBlock(
 		e.colResCons,
 		baseSnapshot,
 		versionedChunkConstructor,
+		e.recordTransactionWriteSets,
 	)
 	defer collector.Stop()
 
@@ -437,12 +466,24 @@ func (e *blockComputer) executeTransactions(
 	defer wg.Done()
 
 	for request := range requestQueue {
+		profiler := e.transactionProfiler.next(request)
+		if profiler != nil {
+			request.ctx = fvm.NewContextFromParent(
+				request.ctx,
+				fvm.WithComputationProfiler(profiler))
+		}
+
 		attempt := 0
 		for {
 			request.ctx.Logger.Info().
 				Int("attempt", attempt).
 				Msg("executing transaction")
 
+			if profiler != nil {
+				// only the committed attempt is profiled
+				profiler.Reset()
+			}
+
 			attempt += 1
 			err := e.executeTransaction(blockSpan, database, request, attempt)
 
@@ -459,6 +500,10 @@ func (e *blockComputer) executeTransactions(
 				return
 			}
 
+			if profiler != nil {
+				e.transactionProfiler.write(request.txnId, profiler)
+			}
+
 			break // process next transaction
 		}
 	}
@@ -75,6 +75,8 @@ type resultCollector struct {
 	result    *execution.ComputationResult
 	consumers []result.ExecutedCollectionConsumer
 
+	recordTransactionWriteSets bool
+
 	spockSignatures []crypto.Signature
 
 	blockStartTime time.Time
@@ -102,6 +104,7 @@ func newResultCollector(
 	consumers []result.ExecutedCollectionConsumer,
 	previousBlockSnapshot snapshot.StorageSnapshot,
 	versionAwareChunkConstructor flow.ChunkConstructor,
+	recordTransactionWriteSets bool,
 ) *resultCollector {
 	numCollections := len(block.Collections()) + 1
 	now := time.Now()
@@ -119,6 +122,7 @@ func newResultCollector(
 		parentBlockExecutionResultID: parentBlockExecutionResultID,
 		result:                       execution.NewEmptyComputationResult(block, versionAwareChunkConstructor),
 		consumers:                    consumers,
+		recordTransactionWriteSets:   recordTransactionWriteSets,
 		spockSignatures:              make([]crypto.Signature, 0, numCollections),
 		blockStartTime:               now,
 		blockMeter:                   meter.NewMeter(meter.DefaultParameters()),
@@ -274,14 +278,16 @@ func (collector *resultCollector) processTransactionResult(
 		txnResult.ErrorMessage = output.Err.Error()
 	}
 
-	collector.result.
-		CollectionExecutionResultAt(txn.collectionIndex).
-		AppendTransactionResults(
-			output.Events,
-			output.ServiceEvents,
-			output.ConvertedServiceEvents,
-			txnResult,
-		)
+	collectionResult := collector.result.CollectionExecutionResultAt(txn.collectionIndex)
+	collectionResult.AppendTransactionResults(
+		output.Events,
+		output.ServiceEvents,
+		output.ConvertedServiceEvents,
+		txnResult,
+	)
+	if collector.recordTransactionWriteSets {
+		collectionResult.AppendTransactionWriteSet(txnExecutionSnapshot.WriteSet)
+	}
 
 	err := collector.currentCollectionState.Merge(txnExecutionSnapshot)
 	if err != nil {
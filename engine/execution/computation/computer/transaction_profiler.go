@@ -0,0 +1,86 @@
+package computer
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rs/zerolog"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/profiler"
+)
+
+// TransactionProfiler profiles the computation used by transactions on request. Each profiled
+// transaction is written as a pprof profile to <dir>/cadence-tx-<transaction ID>.pb.gz, which
+// attributes the computation and memory used by the transaction to Cadence call stacks.
+//
+// Profiling is opt-in, since it disables the reuse of Cadence runtimes for the profiled transactions.
+type TransactionProfiler struct {
+	log       zerolog.Logger
+	dir       string
+	remaining *atomic.Uint64
+}
+
+// NewTransactionProfiler creates a new TransactionProfiler which writes profiles to the given directory.
+// No transactions are profiled until ProfileNext is called.
+func NewTransactionProfiler(log zerolog.Logger, dir string) (*TransactionProfiler, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("could not create profile dir %v: %w", dir, err)
+	}
+
+	return &TransactionProfiler{
+		log:       log.With().Str("component", "transaction_profiler").Logger(),
+		dir:       dir,
+		remaining: atomic.NewUint64(0),
+	}, nil
+}
+
+// ProfileNext profiles the next n executed transactions. System transactions are not profiled.
+// Replaces the pending request, if any, so n = 0 cancels profiling.
+func (p *TransactionProfiler) ProfileNext(n uint64) {
+	p.remaining.Store(n)
+	p.log.Info().Uint64("count", n).Msg("transaction profiling requested")
+}
+
+// Remaining returns the number of transactions which remain to be profiled.
+func (p *TransactionProfiler) Remaining() uint64 {
+	return p.remaining.Load()
+}
+
+// next returns the profiler for the given transaction request, or nil if the transaction
+// is not profiled. Safe to call on a nil TransactionProfiler.
+func (p *TransactionProfiler) next(request TransactionRequest) *environment.ComputationProfiler {
+	if p == nil || request.isSystemTransaction {
+		return nil
+	}
+
+	for {
+		remaining := p.remaining.Load()
+		if remaining == 0 {
+			return nil
+		}
+		if p.remaining.CompareAndSwap(remaining, remaining-1) {
+			return environment.NewComputationProfiler()
+		}
+	}
+}
+
+// write writes the profile of the given transaction in the background.
+func (p *TransactionProfiler) write(
+	txID flow.Identifier,
+	computationProfiler *environment.ComputationProfiler,
+) {
+	prof := computationProfiler.Profile()
+
+	go func() {
+		path, err := profiler.WriteProfile(p.dir, fmt.Sprintf("cadence-tx-%s", txID), prof)
+		if err != nil {
+			p.log.Error().Err(err).Hex("tx_id", txID[:]).Msg("failed to write transaction profile")
+			return
+		}
+		p.log.Info().Hex("tx_id", txID[:]).Str("file", path).Msg("transaction profile written")
+	}()
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	"github.com/onflow/flow-go/engine/execution/computation/query"
+	"github.com/onflow/flow-go/engine/execution/computation/shadow"
 	"github.com/onflow/flow-go/fvm"
 	reusableRuntime "github.com/onflow/flow-go/fvm/runtime"
 	"github.com/onflow/flow-go/fvm/storage/derived"
@@ -67,6 +68,14 @@ type ComputationConfig struct {
 	DerivedDataCacheSize uint
 	MaxConcurrency       int
 
+	// TransactionProfiler, when set, profiles the computation of transactions
+	// on request.
+	TransactionProfiler *computer.TransactionProfiler
+
+	// ShadowExecutor, when set, re-executes every computed block with a candidate
+	// configuration and reports divergences from the computed results.
+	ShadowExecutor *shadow.Executor
+
 	// When NewCustomVirtualMachine is nil, the manager will create a standard
 	// fvm virtual machine via fvm.NewVirtualMachine.  Otherwise, the manager
 	// will create a virtual machine using this function.
@@ -82,6 +91,7 @@ type Manager struct {
 	blockComputer    computer.BlockComputer
 	queryExecutor    query.Executor
 	derivedChainData *derived.DerivedChainData
+	shadowExecutor   *shadow.Executor
 }
 
 var _ ComputationManager = &Manager{}
@@ -110,6 +120,14 @@ func New(
 	options := DefaultFVMOptions(chainID, params.CadenceTracing, params.ExtensiveTracing)
 	vmCtx = fvm.NewContextFromParent(vmCtx, options...)
 
+	computerOptions := []computer.BlockComputerOption{
+		computer.WithTransactionProfiler(params.TransactionProfiler),
+	}
+	if params.ShadowExecutor != nil {
+		// the shadow executor compares the register writes of each transaction
+		computerOptions = append(computerOptions, computer.WithTransactionWriteSets())
+	}
+
 	blockComputer, err := computer.NewBlockComputer(
 		vm,
 		vmCtx,
@@ -122,6 +140,7 @@ func New(
 		nil, // TODO(ramtin): update me with proper consumers
 		protoState,
 		params.MaxConcurrency,
+		computerOptions...,
 	)
 
 	if err != nil {
@@ -149,6 +168,7 @@ func New(
 		blockComputer:    blockComputer,
 		queryExecutor:    queryExecutor,
 		derivedChainData: derivedChainData,
+		shadowExecutor:   params.ShadowExecutor,
 	}
 
 	return &e, nil
@@ -187,6 +207,10 @@ func (e *Manager) ComputeBlock(
 		Hex("block_id", logging.Entity(result.ExecutableBlock.Block)).
 		Msg("computed block result")
 
+	if e.shadowExecutor != nil {
+		e.shadowExecutor.Submit(parentBlockExecutionResultID, block, snapshot, result)
+	}
+
 	return result, nil
 }
 
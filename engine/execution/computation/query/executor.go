@@ -14,12 +14,14 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/environment"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/utils/debug"
+	"github.com/onflow/flow-go/utils/logging"
 	"github.com/onflow/flow-go/utils/rand"
 )
 
@@ -92,6 +94,40 @@ type Executor interface {
 		*flow.AccountPublicKey,
 		error,
 	)
+
+	GetAccountStorage(
+		ctx context.Context,
+		addr flow.Address,
+		limit uint,
+		cursor *flow.AccountStorageCursor,
+		header *flow.Header,
+		snapshot snapshot.StorageSnapshot,
+	) (
+		*flow.AccountStorage,
+		error,
+	)
+
+	EstimateTransaction(
+		ctx context.Context,
+		tx *flow.TransactionBody,
+		header *flow.Header,
+		snapshot snapshot.StorageSnapshot,
+	) (
+		*flow.TransactionEstimate,
+		error,
+	)
+
+	ExecuteScriptWithOverrides(
+		ctx context.Context,
+		script []byte,
+		arguments [][]byte,
+		blockHeader *flow.Header,
+		snapshot snapshot.StorageSnapshot,
+		overrides *flow.StateOverrides,
+	) (
+		*flow.ScriptResultWithOverrides,
+		error,
+	)
 }
 
 type QueryConfig struct {
@@ -99,6 +135,10 @@ type QueryConfig struct {
 	ExecutionTimeLimit  time.Duration
 	ComputationLimit    uint64
 	MaxErrorMessageSize int
+
+	// ComputationProfiler, when set, records the computation and memory used
+	// by scripts, attributed to Cadence call stacks.
+	ComputationProfiler *environment.ComputationProfiler
 }
 
 func NewDefaultConfig() QueryConfig {
@@ -135,6 +175,9 @@ func NewQueryExecutor(
 	if config.ComputationLimit > 0 {
 		vmCtx = fvm.NewContextFromParent(vmCtx, fvm.WithComputationLimit(config.ComputationLimit))
 	}
+	if config.ComputationProfiler != nil {
+		vmCtx = fvm.NewContextFromParent(vmCtx, fvm.WithComputationProfiler(config.ComputationProfiler))
+	}
 	return &QueryExecutor{
 		config:                config,
 		logger:                logger,
@@ -158,6 +201,29 @@ func (e *QueryExecutor) ExecuteScript(
 	computationUsed uint64,
 	err error,
 ) {
+	return e.executeScript(
+		ctx,
+		script,
+		arguments,
+		blockHeader,
+		snapshot,
+		e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID()))
+}
+
+// executeScript executes the script on top of the given snapshot, using the given derived block data
+// to cache programs.
+func (e *QueryExecutor) executeScript(
+	ctx context.Context,
+	script []byte,
+	arguments [][]byte,
+	blockHeader *flow.Header,
+	snapshot snapshot.StorageSnapshot,
+	derivedBlockData *derived.DerivedBlockData,
+) (
+	encodedValue []byte,
+	computationUsed uint64,
+	err error,
+) {
 
 	startedAt := time.Now()
 	memAllocBefore := debug.GetHeapAllocsBytes()
@@ -217,8 +283,7 @@ func (e *QueryExecutor) ExecuteScript(
 			e.vmCtx,
 			fvm.WithBlockHeader(blockHeader),
 			fvm.WithProtocolStateSnapshot(e.protocolStateSnapshot.AtBlockID(blockHeader.ID())),
-			fvm.WithDerivedBlockData(
-				e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID()))),
+			fvm.WithDerivedBlockData(derivedBlockData)),
 		fvm.NewScriptWithContextAndArgs(script, requestCtx, arguments...),
 		snapshot)
 	if err != nil {
@@ -406,3 +471,125 @@ func (e *QueryExecutor) GetAccountKey(
 
 	return accountKey, nil
 }
+
+func (e *QueryExecutor) GetAccountStorage(
+	_ context.Context,
+	address flow.Address,
+	limit uint,
+	cursor *flow.AccountStorageCursor,
+	blockHeader *flow.Header,
+	snapshot snapshot.StorageSnapshot,
+) (*flow.AccountStorage, error) {
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithDerivedBlockData(
+			e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID())))
+
+	accountStorage, err := fvm.GetAccountStorage(
+		blockCtx,
+		address,
+		snapshot,
+		limit,
+		cursor)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get account storage (%s) at block (%s): %w",
+			address.String(),
+			blockHeader.ID(),
+			err)
+	}
+
+	return accountStorage, nil
+}
+
+// EstimateTransaction executes the transaction against the given snapshot without checking its
+// signatures and sequence number, and without charging fees. The resulting state changes are discarded.
+// Errors of the transaction itself are reported in the returned estimate.
+func (e *QueryExecutor) EstimateTransaction(
+	_ context.Context,
+	tx *flow.TransactionBody,
+	blockHeader *flow.Header,
+	snapshot snapshot.StorageSnapshot,
+) (
+	estimate *flow.TransactionEstimate,
+	err error,
+) {
+	startedAt := time.Now()
+
+	defer func() {
+		elapsed := time.Since(startedAt)
+
+		if r := recover(); r != nil {
+			e.logger.Error().
+				Hex("tx_id", logging.Entity(tx)).
+				Interface("recovered", r).
+				Msg("transaction estimation caused runtime panic")
+
+			err = fmt.Errorf("cadence runtime error: %s", r)
+			return
+		}
+		if elapsed >= e.config.LogTimeThreshold {
+			e.logger.Error().
+				Hex("tx_id", logging.Entity(tx)).
+				Dur("duration", elapsed).
+				Msg("transaction estimation exceeded threshold")
+		}
+	}()
+
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithProtocolStateSnapshot(e.protocolStateSnapshot.AtBlockID(blockHeader.ID())),
+		fvm.WithDerivedBlockData(
+			e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID())),
+		fvm.WithAuthorizationChecksEnabled(false),
+		fvm.WithSequenceNumberCheckAndIncrementEnabled(false),
+		fvm.WithTransactionFeesEnabled(false))
+
+	proc := fvm.Transaction(tx, 0)
+
+	// the execution snapshot is dropped, so the transaction never changes the state
+	_, output, err := e.vm.Run(blockCtx, proc, snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate transaction (internal error): %w", err)
+	}
+
+	estimate = &flow.TransactionEstimate{
+		ComputationUsed:        output.ComputationUsed,
+		ComputationIntensities: make(map[uint64]uint64, len(output.ComputationIntensities)),
+		MemoryEstimate:         output.MemoryEstimate,
+		Events:                 output.Events,
+	}
+	for kind, intensity := range output.ComputationIntensities {
+		estimate.ComputationIntensities[uint64(kind)] = uint64(intensity)
+	}
+	if output.Err != nil {
+		estimate.ErrorMessage = summarizeLog(output.Err.Error(), e.config.MaxErrorMessageSize)
+	}
+
+	// the fees are charged for at most the computation limit of the transaction
+	executionEffort := output.ComputationUsed
+	if limit := proc.ComputationLimit(blockCtx); executionEffort > limit {
+		executionEffort = limit
+	}
+
+	feesCtx := fvm.NewContextFromParent(
+		blockCtx,
+		fvm.WithDerivedBlockData(
+			e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID())))
+
+	estimate.Fees, err = fvm.GetTransactionFees(
+		feesCtx,
+		tx.InclusionEffort(),
+		executionEffort,
+		snapshot)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to get transaction fees at block (%s): %w",
+			blockHeader.ID(),
+			err)
+	}
+
+	return estimate, nil
+}
@@ -16,6 +16,36 @@ type Executor struct {
 	mock.Mock
 }
 
+// EstimateTransaction provides a mock function with given fields: ctx, tx, header, _a3
+func (_m *Executor) EstimateTransaction(ctx context.Context, tx *flow.TransactionBody, header *flow.Header, _a3 snapshot.StorageSnapshot) (*flow.TransactionEstimate, error) {
+	ret := _m.Called(ctx, tx, header, _a3)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateTransaction")
+	}
+
+	var r0 *flow.TransactionEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, *flow.Header, snapshot.StorageSnapshot) (*flow.TransactionEstimate, error)); ok {
+		return rf(ctx, tx, header, _a3)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, *flow.Header, snapshot.StorageSnapshot) *flow.TransactionEstimate); ok {
+		r0 = rf(ctx, tx, header, _a3)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionEstimate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, *flow.Header, snapshot.StorageSnapshot) error); ok {
+		r1 = rf(ctx, tx, header, _a3)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteScript provides a mock function with given fields: ctx, script, arguments, blockHeader, _a4
 func (_m *Executor) ExecuteScript(ctx context.Context, script []byte, arguments [][]byte, blockHeader *flow.Header, _a4 snapshot.StorageSnapshot) ([]byte, uint64, error) {
 	ret := _m.Called(ctx, script, arguments, blockHeader, _a4)
@@ -53,6 +83,36 @@ func (_m *Executor) ExecuteScript(ctx context.Context, script []byte, arguments
 	return r0, r1, r2
 }
 
+// ExecuteScriptWithOverrides provides a mock function with given fields: ctx, script, arguments, blockHeader, _a4, overrides
+func (_m *Executor) ExecuteScriptWithOverrides(ctx context.Context, script []byte, arguments [][]byte, blockHeader *flow.Header, _a4 snapshot.StorageSnapshot, overrides *flow.StateOverrides) (*flow.ScriptResultWithOverrides, error) {
+	ret := _m.Called(ctx, script, arguments, blockHeader, _a4, overrides)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteScriptWithOverrides")
+	}
+
+	var r0 *flow.ScriptResultWithOverrides
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte, *flow.Header, snapshot.StorageSnapshot, *flow.StateOverrides) (*flow.ScriptResultWithOverrides, error)); ok {
+		return rf(ctx, script, arguments, blockHeader, _a4, overrides)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte, *flow.Header, snapshot.StorageSnapshot, *flow.StateOverrides) *flow.ScriptResultWithOverrides); ok {
+		r0 = rf(ctx, script, arguments, blockHeader, _a4, overrides)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ScriptResultWithOverrides)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, [][]byte, *flow.Header, snapshot.StorageSnapshot, *flow.StateOverrides) error); ok {
+		r1 = rf(ctx, script, arguments, blockHeader, _a4, overrides)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccount provides a mock function with given fields: ctx, addr, header, _a3
 func (_m *Executor) GetAccount(ctx context.Context, addr flow.Address, header *flow.Header, _a3 snapshot.StorageSnapshot) (*flow.Account, error) {
 	ret := _m.Called(ctx, addr, header, _a3)
@@ -199,6 +259,36 @@ func (_m *Executor) GetAccountKeys(ctx context.Context, addr flow.Address, heade
 	return r0, r1
 }
 
+// GetAccountStorage provides a mock function with given fields: ctx, addr, limit, cursor, header, _a5
+func (_m *Executor) GetAccountStorage(ctx context.Context, addr flow.Address, limit uint, cursor *flow.AccountStorageCursor, header *flow.Header, _a5 snapshot.StorageSnapshot) (*flow.AccountStorage, error) {
+	ret := _m.Called(ctx, addr, limit, cursor, header, _a5)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountStorage")
+	}
+
+	var r0 *flow.AccountStorage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint, *flow.AccountStorageCursor, *flow.Header, snapshot.StorageSnapshot) (*flow.AccountStorage, error)); ok {
+		return rf(ctx, addr, limit, cursor, header, _a5)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint, *flow.AccountStorageCursor, *flow.Header, snapshot.StorageSnapshot) *flow.AccountStorage); ok {
+		r0 = rf(ctx, addr, limit, cursor, header, _a5)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountStorage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint, *flow.AccountStorageCursor, *flow.Header, snapshot.StorageSnapshot) error); ok {
+		r1 = rf(ctx, addr, limit, cursor, header, _a5)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewExecutor creates a new instance of Executor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewExecutor(t interface {
@@ -0,0 +1,197 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence"
+
+	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/blueprints"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/storage/derived"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/fvm/systemcontracts"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ErrInvalidStateOverrides is returned if the state overrides of a script can not be applied to the
+// state of the block.
+var ErrInvalidStateOverrides = errors.New("invalid state overrides")
+
+// balanceOverrideComputationLimit is the computation limit of the transaction applying a balance override.
+// Transactions can not be interrupted by the context of the request, so their duration is bounded by their
+// computation limit instead.
+const balanceOverrideComputationLimit = flow.DefaultMaxTransactionGasLimit
+
+// ExecuteScriptWithOverrides executes the script on top of the state of the block with the given
+// overrides applied. The overrides are only applied for the execution of the script, the snapshot is
+// never modified.
+//
+// Register overrides are applied first, then contract overrides, and finally balance overrides, which
+// are applied by minting or burning FLOW with the token admin of the service account.
+// The overrides are applied within the execution time limit of the script: the time spent applying
+// them is deducted from the time available to the script.
+//
+// Expected errors during normal operation:
+//   - ErrInvalidStateOverrides if the overrides can not be applied to the state of the block.
+//   - errors returned by ExecuteScript
+func (e *QueryExecutor) ExecuteScriptWithOverrides(
+	ctx context.Context,
+	script []byte,
+	arguments [][]byte,
+	blockHeader *flow.Header,
+	storageSnapshot snapshot.StorageSnapshot,
+	overrides *flow.StateOverrides,
+) (
+	*flow.ScriptResultWithOverrides,
+	error,
+) {
+	result := &flow.ScriptResultWithOverrides{}
+
+	requestCtx, cancel := context.WithTimeout(ctx, e.config.ExecutionTimeLimit)
+	defer cancel()
+
+	// the programs cached for the block were derived from the code of the block. They can only be reused
+	// if the code is not overridden, balance overrides are applied by transactions which invalidate the
+	// programs they change.
+	var derivedBlockData *derived.DerivedBlockData
+	if len(overrides.Registers) == 0 && len(overrides.Contracts) == 0 {
+		derivedBlockData = e.derivedChainData.NewDerivedBlockDataForScript(blockHeader.ID())
+	} else {
+		derivedBlockData = derived.NewEmptyDerivedBlockData(0)
+	}
+
+	overridden, err := e.applyOverrides(requestCtx, blockHeader, storageSnapshot, derivedBlockData, overrides, result)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Value, _, err = e.executeScript(
+		requestCtx,
+		script,
+		arguments,
+		blockHeader,
+		overridden,
+		derivedBlockData)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// applyOverrides returns a snapshot of the state of the block with the overrides applied, and records
+// the number of applied overrides in the result. The programs loaded by the balance override
+// transactions are cached in the given derived block data.
+func (e *QueryExecutor) applyOverrides(
+	ctx context.Context,
+	blockHeader *flow.Header,
+	storageSnapshot snapshot.StorageSnapshot,
+	derivedBlockData *derived.DerivedBlockData,
+	overrides *flow.StateOverrides,
+	result *flow.ScriptResultWithOverrides,
+) (
+	snapshot.StorageSnapshot,
+	error,
+) {
+	tree := snapshot.NewSnapshotTree(storageSnapshot)
+	if overrides.IsEmpty() {
+		return tree, nil
+	}
+
+	writeSet := make(map[flow.RegisterID]flow.RegisterValue, len(overrides.Registers)+len(overrides.Contracts))
+	for _, register := range overrides.Registers {
+		writeSet[register.Key] = register.Value
+	}
+
+	for _, contract := range overrides.Contracts {
+		if len(contract.Code) == 0 {
+			return nil, fmt.Errorf("%w: empty code for contract %s.%s",
+				ErrInvalidStateOverrides, contract.Address, contract.Name)
+		}
+
+		id := flow.ContractRegisterID(contract.Address, contract.Name)
+		code, err := tree.Get(id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contract %s.%s: %w", contract.Address, contract.Name, err)
+		}
+		// only deployed contracts can be replaced, new contracts would also require updating the
+		// contract names of the account
+		if len(code) == 0 {
+			return nil, fmt.Errorf("%w: contract %s.%s is not deployed",
+				ErrInvalidStateOverrides, contract.Address, contract.Name)
+		}
+
+		writeSet[id] = contract.Code
+	}
+
+	tree = tree.Append(&snapshot.ExecutionSnapshot{WriteSet: writeSet})
+	result.OverriddenRegisters = len(writeSet)
+	result.OverriddenContracts = len(overrides.Contracts)
+
+	if len(overrides.Balances) == 0 {
+		return tree, nil
+	}
+
+	chain := e.vmCtx.Chain
+	sc := systemcontracts.SystemContractsForChain(chain.ChainID())
+
+	blockCtx := fvm.NewContextFromParent(
+		e.vmCtx,
+		fvm.WithBlockHeader(blockHeader),
+		fvm.WithProtocolStateSnapshot(e.protocolStateSnapshot.AtBlockID(blockHeader.ID())),
+		fvm.WithDerivedBlockData(derivedBlockData),
+		fvm.WithAuthorizationChecksEnabled(false),
+		fvm.WithSequenceNumberCheckAndIncrementEnabled(false),
+		fvm.WithTransactionFeesEnabled(false),
+		fvm.WithAccountStorageLimit(false))
+
+	for i, balance := range overrides.Balances {
+		// the context is checked between transactions, see balanceOverrideComputationLimit
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, fvmerrors.NewScriptExecutionTimedOutError()
+		}
+		if err != nil {
+			return nil, fvmerrors.NewScriptExecutionCancelledError(err)
+		}
+
+		startedAt := time.Now()
+
+		tx := blueprints.SetFlowTokenBalanceTransaction(
+			sc.FungibleToken.Address,
+			sc.FlowToken.Address,
+			sc.Burner.Address,
+			chain.ServiceAddress(),
+			balance.Address,
+			cadence.UFix64(balance.Balance)).
+			SetComputeLimit(balanceOverrideComputationLimit)
+
+		// the transactions are committed to the derived block data in order, so that the programs
+		// invalidated by a transaction are not used by the next ones
+		executionSnapshot, output, err := e.vm.Run(blockCtx, fvm.Transaction(tx, uint32(i)), tree)
+		if err != nil {
+			return nil, fmt.Errorf("failed to override balance of %s (internal error): %w", balance.Address, err)
+		}
+		if output.Err != nil {
+			return nil, fmt.Errorf("%w: failed to override balance of %s: %s",
+				ErrInvalidStateOverrides, balance.Address,
+				summarizeLog(output.Err.Error(), e.config.MaxErrorMessageSize))
+		}
+
+		tree = tree.Append(executionSnapshot)
+		result.OverriddenRegisters += len(executionSnapshot.WriteSet)
+
+		e.logger.Debug().
+			Str("address", balance.Address.Hex()).
+			Uint64("balance", balance.Balance).
+			Dur("duration", time.Since(startedAt)).
+			Msg("applied balance override")
+	}
+	result.OverriddenBalances = len(overrides.Balances)
+
+	return tree, nil
+}
@@ -0,0 +1,186 @@
+package shadow
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DivergenceKind is the kind of difference between the primary and the candidate execution of a transaction.
+type DivergenceKind string
+
+const (
+	DivergenceKindError          DivergenceKind = "error"
+	DivergenceKindComputation    DivergenceKind = "computation"
+	DivergenceKindEvents         DivergenceKind = "events"
+	DivergenceKindRegisterWrites DivergenceKind = "register_writes"
+)
+
+// maxReportedRegisters is the maximum number of diverged registers listed in a register writes divergence.
+const maxReportedRegisters = 10
+
+// Divergence is a difference between the primary and the candidate execution of a transaction.
+// Primary and Candidate describe the differing part of the respective results.
+type Divergence struct {
+	TransactionID    flow.Identifier `json:"transaction_id"`
+	TransactionIndex uint32          `json:"transaction_index"`
+	Kind             DivergenceKind  `json:"kind"`
+	Primary          string          `json:"primary"`
+	Candidate        string          `json:"candidate"`
+}
+
+// Compare compares the primary and the candidate execution results of the same block, transaction by
+// transaction. Events, errors, computation used and register writes are compared. Register writes are
+// only compared if they were recorded for both results.
+//
+// No errors are expected during normal operation. An error is returned if the results are not results
+// of the same transactions, in which case the results can not be compared.
+func Compare(primary *execution.ComputationResult, candidate *execution.ComputationResult) ([]Divergence, error) {
+	primaryResults := primary.AllTransactionResults()
+	candidateResults := candidate.AllTransactionResults()
+	if len(primaryResults) != len(candidateResults) {
+		return nil, fmt.Errorf("number of transaction results differ: primary %d, candidate %d",
+			len(primaryResults), len(candidateResults))
+	}
+
+	primaryEvents := eventsByTransaction(primary.AllEvents())
+	candidateEvents := eventsByTransaction(candidate.AllEvents())
+
+	primaryWriteSets := primary.AllTransactionWriteSets()
+	candidateWriteSets := candidate.AllTransactionWriteSets()
+	compareWriteSets := len(primaryWriteSets) == len(primaryResults) &&
+		len(candidateWriteSets) == len(candidateResults)
+
+	var divergences []Divergence
+	for i := range primaryResults {
+		primaryResult := primaryResults[i]
+		candidateResult := candidateResults[i]
+		if primaryResult.TransactionID != candidateResult.TransactionID {
+			return nil, fmt.Errorf("transaction %d differs: primary %v, candidate %v",
+				i, primaryResult.TransactionID, candidateResult.TransactionID)
+		}
+
+		index := uint32(i)
+		diverged := func(kind DivergenceKind, primary string, candidate string) {
+			divergences = append(divergences, Divergence{
+				TransactionID:    primaryResult.TransactionID,
+				TransactionIndex: index,
+				Kind:             kind,
+				Primary:          primary,
+				Candidate:        candidate,
+			})
+		}
+
+		if primaryResult.ErrorMessage != candidateResult.ErrorMessage {
+			diverged(DivergenceKindError, primaryResult.ErrorMessage, candidateResult.ErrorMessage)
+		}
+
+		if primaryResult.ComputationUsed != candidateResult.ComputationUsed {
+			diverged(DivergenceKindComputation,
+				fmt.Sprint(primaryResult.ComputationUsed),
+				fmt.Sprint(candidateResult.ComputationUsed))
+		}
+
+		if p, c, ok := compareEvents(primaryEvents[index], candidateEvents[index]); !ok {
+			diverged(DivergenceKindEvents, p, c)
+		}
+
+		if compareWriteSets {
+			if p, c, ok := compareRegisterWrites(primaryWriteSets[i], candidateWriteSets[i]); !ok {
+				diverged(DivergenceKindRegisterWrites, p, c)
+			}
+		}
+	}
+
+	return divergences, nil
+}
+
+func eventsByTransaction(events flow.EventsList) map[uint32]flow.EventsList {
+	byTransaction := make(map[uint32]flow.EventsList)
+	for _, event := range events {
+		byTransaction[event.TransactionIndex] = append(byTransaction[event.TransactionIndex], event)
+	}
+	return byTransaction
+}
+
+// compareEvents compares the events emitted by a transaction, and describes the first differing event
+// of each execution if they differ.
+func compareEvents(primary flow.EventsList, candidate flow.EventsList) (string, string, bool) {
+	for i := 0; i < len(primary) || i < len(candidate); i++ {
+		if i < len(primary) && i < len(candidate) &&
+			primary[i].Type == candidate[i].Type &&
+			bytes.Equal(primary[i].Payload, candidate[i].Payload) {
+			continue
+		}
+		return describeEvent(primary, i), describeEvent(candidate, i), false
+	}
+	return "", "", true
+}
+
+func describeEvent(events flow.EventsList, i int) string {
+	if i >= len(events) {
+		return fmt.Sprintf("%d events, no event %d", len(events), i)
+	}
+	return fmt.Sprintf("%d events, event %d: %s (payload %s)",
+		len(events), i, events[i].Type, fingerprint(events[i].Payload))
+}
+
+// compareRegisterWrites compares the registers written by a transaction, and lists the differing
+// registers with the values written by each execution if they differ.
+func compareRegisterWrites(
+	primary map[flow.RegisterID]flow.RegisterValue,
+	candidate map[flow.RegisterID]flow.RegisterValue,
+) (string, string, bool) {
+	var diverged []flow.RegisterID
+	for id, value := range primary {
+		candidateValue, ok := candidate[id]
+		if !ok || !bytes.Equal(value, candidateValue) {
+			diverged = append(diverged, id)
+		}
+	}
+	for id := range candidate {
+		if _, ok := primary[id]; !ok {
+			diverged = append(diverged, id)
+		}
+	}
+
+	if len(diverged) == 0 {
+		return "", "", true
+	}
+
+	sort.Slice(diverged, func(i, j int) bool {
+		return diverged[i].String() < diverged[j].String()
+	})
+
+	return describeRegisterWrites(primary, diverged), describeRegisterWrites(candidate, diverged), false
+}
+
+func describeRegisterWrites(writeSet map[flow.RegisterID]flow.RegisterValue, diverged []flow.RegisterID) string {
+	descriptions := make([]string, 0, maxReportedRegisters+1)
+	for i, id := range diverged {
+		if i == maxReportedRegisters {
+			descriptions = append(descriptions, fmt.Sprintf("... %d more", len(diverged)-i))
+			break
+		}
+		value, ok := writeSet[id]
+		if !ok {
+			descriptions = append(descriptions, fmt.Sprintf("%s: not written", id))
+			continue
+		}
+		descriptions = append(descriptions, fmt.Sprintf("%s: %s", id, fingerprint(value)))
+	}
+	return fmt.Sprintf("%d registers written, %d diverged: %s",
+		len(writeSet), len(diverged), strings.Join(descriptions, ", "))
+}
+
+// fingerprint describes a value by its length and a short hash, since values can be large.
+func fingerprint(value []byte) string {
+	hash := sha256.Sum256(value)
+	return fmt.Sprintf("%d bytes, sha256 %s", len(value), hex.EncodeToString(hash[:8]))
+}
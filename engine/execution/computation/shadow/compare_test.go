@@ -0,0 +1,137 @@
+package shadow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+type transactionFixture struct {
+	result   flow.TransactionResult
+	events   flow.EventsList
+	writeSet map[flow.RegisterID]flow.RegisterValue
+}
+
+// computationResultFixture returns a result of the block, in which all transactions are part of
+// the first collection.
+func computationResultFixture(
+	block *entity.ExecutableBlock,
+	transactions ...transactionFixture,
+) *execution.ComputationResult {
+	result := &execution.ComputationResult{
+		BlockExecutionResult: execution.NewPopulatedBlockExecutionResult(block),
+	}
+	collectionResult := result.CollectionExecutionResultAt(0)
+	for _, tx := range transactions {
+		collectionResult.AppendTransactionResults(tx.events, nil, nil, tx.result)
+		collectionResult.AppendTransactionWriteSet(tx.writeSet)
+	}
+	return result
+}
+
+func transactionsFixture(n int) []transactionFixture {
+	registerID := flow.NewRegisterID(unittest.RandomAddressFixture(), "key")
+
+	transactions := make([]transactionFixture, n)
+	for i := range transactions {
+		txID := unittest.IdentifierFixture()
+		transactions[i] = transactionFixture{
+			result: flow.TransactionResult{
+				TransactionID:   txID,
+				ComputationUsed: 10,
+			},
+			events: flow.EventsList{
+				unittest.EventFixture("A.0000000000000001.Foo.Bar", uint32(i), 0, txID, 0),
+			},
+			writeSet: map[flow.RegisterID]flow.RegisterValue{
+				registerID: []byte{byte(i)},
+			},
+		}
+	}
+	return transactions
+}
+
+func TestCompare(t *testing.T) {
+	block := unittest.ExecutableBlockFixture([][]flow.Identifier{{unittest.IdentifierFixture()}}, nil)
+
+	t.Run("equal results", func(t *testing.T) {
+		transactions := transactionsFixture(3)
+
+		divergences, err := Compare(
+			computationResultFixture(block, transactions...),
+			computationResultFixture(block, transactions...))
+		require.NoError(t, err)
+		require.Empty(t, divergences)
+	})
+
+	t.Run("diverged results", func(t *testing.T) {
+		primary := transactionsFixture(3)
+		candidate := make([]transactionFixture, len(primary))
+		copy(candidate, primary)
+
+		candidate[0].result.ErrorMessage = "failed"
+		candidate[0].result.ComputationUsed = 11
+		candidate[1].events = append(candidate[1].events,
+			unittest.EventFixture("A.0000000000000001.Foo.Baz", 1, 1, primary[1].result.TransactionID, 0))
+		candidate[2].writeSet = map[flow.RegisterID]flow.RegisterValue{
+			flow.NewRegisterID(unittest.RandomAddressFixture(), "key"): []byte{2},
+		}
+
+		divergences, err := Compare(
+			computationResultFixture(block, primary...),
+			computationResultFixture(block, candidate...))
+		require.NoError(t, err)
+
+		kinds := make([]DivergenceKind, len(divergences))
+		for i, divergence := range divergences {
+			kinds[i] = divergence.Kind
+			require.Equal(t, primary[divergence.TransactionIndex].result.TransactionID, divergence.TransactionID)
+		}
+		require.Equal(t, []DivergenceKind{
+			DivergenceKindError,
+			DivergenceKindComputation,
+			DivergenceKindEvents,
+			DivergenceKindRegisterWrites,
+		}, kinds)
+
+		require.Equal(t, "", divergences[0].Primary)
+		require.Equal(t, "failed", divergences[0].Candidate)
+		require.Equal(t, "10", divergences[1].Primary)
+		require.Equal(t, "11", divergences[1].Candidate)
+		require.Contains(t, divergences[2].Primary, "no event 1")
+		require.Contains(t, divergences[2].Candidate, "A.0000000000000001.Foo.Baz")
+		require.Contains(t, divergences[3].Primary, "1 registers written, 2 diverged")
+	})
+
+	t.Run("register writes not recorded", func(t *testing.T) {
+		transactions := transactionsFixture(1)
+
+		primaryResult := computationResultFixture(block, transactions...)
+		candidateResult := &execution.ComputationResult{
+			BlockExecutionResult: execution.NewPopulatedBlockExecutionResult(block),
+		}
+		candidateResult.CollectionExecutionResultAt(0).
+			AppendTransactionResults(transactions[0].events, nil, nil, transactions[0].result)
+
+		divergences, err := Compare(primaryResult, candidateResult)
+		require.NoError(t, err)
+		require.Empty(t, divergences)
+	})
+
+	t.Run("different transactions", func(t *testing.T) {
+		_, err := Compare(
+			computationResultFixture(block, transactionsFixture(2)...),
+			computationResultFixture(block, transactionsFixture(1)...))
+		require.Error(t, err)
+
+		_, err = Compare(
+			computationResultFixture(block, transactionsFixture(1)...),
+			computationResultFixture(block, transactionsFixture(1)...))
+		require.Error(t, err)
+	})
+}
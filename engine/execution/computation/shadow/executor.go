@@ -0,0 +1,363 @@
+package shadow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/onflow/cadence/common"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine/execution"
+	"github.com/onflow/flow-go/engine/execution/computation/committer"
+	"github.com/onflow/flow-go/engine/execution/computation/computer"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/storehouse"
+	"github.com/onflow/flow-go/fvm"
+	reusableRuntime "github.com/onflow/flow-go/fvm/runtime"
+	"github.com/onflow/flow-go/fvm/storage/derived"
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/mempool/entity"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+const (
+	DefaultQueueSize = 10
+
+	// candidateRuntimePoolSize is the size of the Cadence runtime pool of the candidate.
+	// Blocks are shadow executed one transaction at a time, so few runtimes are needed.
+	candidateRuntimePoolSize = 10
+
+	// candidateDerivedDataCacheSize is the number of blocks for which the derived data
+	// (e.g. programs) of the candidate is cached.
+	candidateDerivedDataCacheSize = 100
+
+	skipReasonQueueFull           = "queue_full"
+	skipReasonExecutionFailed     = "execution_failed"
+	skipReasonComparisonFailed    = "comparison_failed"
+	skipReasonSnapshotUnavailable = "snapshot_unavailable"
+)
+
+// Config configures the shadow execution of blocks.
+type Config struct {
+	Enabled   bool
+	QueueSize uint
+	// Dir is the directory in which the reports of diverged blocks are stored.
+	Dir       string
+	Candidate CandidateConfig
+}
+
+// CandidateConfig is the configuration of the candidate, which differs from the configuration
+// of the primary execution. Zero limits and unset weights are inherited from the primary execution.
+type CandidateConfig struct {
+	EVMEnabled                   bool
+	AtreeValidationEnabled       bool
+	LegacyContractUpgradeEnabled bool
+	MaxStateInteractionSize      uint64
+	EventCollectionSizeLimit     uint64
+	ComputationLimit             uint64
+	MemoryLimit                  uint64
+	// ComputationWeights and MemoryWeights replace the weights of the given computation and memory
+	// kinds, indexed by the numeric kind. Weights have the fixed-point precision of the weights stored
+	// in the state.
+	ComputationWeights map[string]string
+	MemoryWeights      map[string]string
+}
+
+// Options returns the FVM options of the candidate, which are applied on top of the given context of
+// the primary execution. The Cadence runtimes of the candidate are configured like the runtimes of the
+// primary execution, with the candidate settings applied.
+//
+// No errors are expected during normal operation, invalid weights are reported as errors.
+func (c CandidateConfig) Options(primaryCtx fvm.Context) ([]fvm.Option, error) {
+	runtimeConfig := primaryCtx.ReusableCadenceRuntimePool.Config()
+	runtimeConfig.AtreeValidationEnabled = c.AtreeValidationEnabled
+	runtimeConfig.LegacyContractUpgradeEnabled = c.LegacyContractUpgradeEnabled
+
+	computationWeights, err := parseWeights[common.ComputationKind](c.ComputationWeights)
+	if err != nil {
+		return nil, fmt.Errorf("invalid computation weights: %w", err)
+	}
+	memoryWeights, err := parseWeights[common.MemoryKind](c.MemoryWeights)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory weights: %w", err)
+	}
+
+	options := []fvm.Option{
+		fvm.WithReusableCadenceRuntimePool(
+			reusableRuntime.NewReusableCadenceRuntimePool(
+				candidateRuntimePoolSize,
+				runtimeConfig,
+			)),
+		fvm.WithEVMEnabled(c.EVMEnabled),
+		fvm.WithExecutionParametersOverrides(fvm.ExecutionParametersOverrides{
+			ComputationWeights: computationWeights,
+			MemoryWeights:      memoryWeights,
+			ComputationLimit:   c.ComputationLimit,
+			MemoryLimit:        c.MemoryLimit,
+		}),
+	}
+
+	if c.MaxStateInteractionSize > 0 {
+		options = append(options, fvm.WithMaxStateInteractionSize(c.MaxStateInteractionSize))
+	}
+	if c.EventCollectionSizeLimit > 0 {
+		options = append(options, fvm.WithEventCollectionSizeLimit(c.EventCollectionSizeLimit))
+	}
+
+	return options, nil
+}
+
+// parseWeights parses weights indexed by the numeric computation or memory kind.
+func parseWeights[K ~uint](weights map[string]string) (map[K]uint64, error) {
+	if len(weights) == 0 {
+		return nil, nil
+	}
+
+	parsed := make(map[K]uint64, len(weights))
+	for kind, weight := range weights {
+		k, err := strconv.ParseUint(kind, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid kind %q: %w", kind, err)
+		}
+		w, err := strconv.ParseUint(weight, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q of kind %s: %w", weight, kind, err)
+		}
+		parsed[K(k)] = w
+	}
+	return parsed, nil
+}
+
+// NewCandidateBlockComputer creates a block computer which executes blocks with the candidate context,
+// without committing their state and without providing their execution data.
+func NewCandidateBlockComputer(
+	log zerolog.Logger,
+	me module.Local,
+	protoState protocol.SnapshotExecutionSubsetProvider,
+	candidateCtx fvm.Context,
+) (computer.BlockComputer, error) {
+	return computer.NewBlockComputer(
+		fvm.NewVirtualMachine(),
+		candidateCtx,
+		metrics.NewNoopCollector(),
+		trace.NewNoopTracer(),
+		log.With().Str("component", "shadow_block_computer").Logger(),
+		committer.NewNoopViewCommitter(),
+		me,
+		noopProvider{},
+		nil,
+		protoState,
+		1,
+		computer.WithTransactionWriteSets(),
+	)
+}
+
+// noopProvider discards the execution data of shadow executed blocks.
+type noopProvider struct{}
+
+func (noopProvider) Provide(
+	context.Context,
+	uint64,
+	*execution_data.BlockExecutionData,
+) (flow.Identifier, *flow.BlockExecutionDataRoot, error) {
+	return flow.ZeroID, nil, nil
+}
+
+// Executor re-executes blocks with a candidate configuration in the background, and compares the
+// results with the results of the primary execution, e.g. to validate an FVM or Cadence upgrade
+// before it is rolled out. The results of the candidate are never committed.
+//
+// Blocks are executed in the order they are submitted. Blocks submitted while the queue is full
+// are skipped, so that shadow execution never slows down the primary execution.
+type Executor struct {
+	component.Component
+
+	log              zerolog.Logger
+	metrics          module.ShadowExecutionMetrics
+	blockComputer    computer.BlockComputer
+	derivedChainData *derived.DerivedChainData
+	store            *DivergenceStore
+	requests         chan *request
+}
+
+type request struct {
+	parentBlockExecutionResultID flow.Identifier
+	block                        *entity.ExecutableBlock
+	snapshot                     snapshot.StorageSnapshot
+	primary                      *execution.ComputationResult
+}
+
+// NewExecutor creates a new Executor which executes blocks with the given block computer, and
+// stores the reports of diverged blocks in the given store.
+//
+// No errors are expected during normal operation.
+func NewExecutor(
+	log zerolog.Logger,
+	metrics module.ShadowExecutionMetrics,
+	blockComputer computer.BlockComputer,
+	store *DivergenceStore,
+	queueSize uint,
+) (*Executor, error) {
+	derivedChainData, err := derived.NewDerivedChainData(candidateDerivedDataCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create derived data cache: %w", err)
+	}
+
+	e := &Executor{
+		log:              log.With().Str("component", "shadow_executor").Logger(),
+		metrics:          metrics,
+		blockComputer:    blockComputer,
+		derivedChainData: derivedChainData,
+		store:            store,
+		requests:         make(chan *request, queueSize),
+	}
+
+	e.Component = component.NewComponentManagerBuilder().
+		AddWorker(e.executionWorker).
+		Build()
+
+	return e, nil
+}
+
+// Submit queues the block for shadow execution, given the result of its primary execution, which
+// must have recorded the register writes of its transactions. The snapshot must remain readable
+// until the block is executed. Skips the block if the queue is full.
+func (e *Executor) Submit(
+	parentBlockExecutionResultID flow.Identifier,
+	block *entity.ExecutableBlock,
+	snapshot snapshot.StorageSnapshot,
+	primary *execution.ComputationResult,
+) {
+	select {
+	case e.requests <- &request{
+		parentBlockExecutionResultID: parentBlockExecutionResultID,
+		block:                        block,
+		snapshot:                     snapshot,
+		primary:                      primary,
+	}:
+	default:
+		e.metrics.ShadowBlockSkipped(skipReasonQueueFull)
+		e.log.Warn().
+			Hex("block_id", logging.Entity(block)).
+			Uint64("height", block.Height()).
+			Msg("shadow execution queue is full, skipping block")
+	}
+}
+
+func (e *Executor) executionWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case req := <-e.requests:
+			e.execute(ctx, req)
+		}
+	}
+}
+
+// execute executes the block with the candidate and compares the results.
+// Failures are reported rather than returned, since they are divergences from the primary execution.
+func (e *Executor) execute(ctx context.Context, req *request) {
+	start := time.Now()
+	block := req.block
+	log := e.log.With().
+		Hex("block_id", logging.Entity(block)).
+		Uint64("height", block.Height()).
+		Logger()
+
+	report := &BlockReport{
+		BlockID:      block.ID(),
+		BlockHeight:  block.Height(),
+		ExecutedAt:   start,
+		Transactions: len(req.primary.AllTransactionResults()),
+	}
+
+	derivedBlockData := e.derivedChainData.GetOrCreateDerivedBlockData(
+		block.ID(),
+		block.ParentID())
+
+	candidate, err := e.blockComputer.ExecuteBlock(
+		ctx,
+		req.parentBlockExecutionResultID,
+		block,
+		req.snapshot,
+		derivedBlockData)
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		if isSnapshotUnavailable(err) {
+			// the state of the block is no longer readable, e.g. because its fork was abandoned or the
+			// state was pruned while the block was queued. This is not a divergence of the candidate.
+			e.metrics.ShadowBlockSkipped(skipReasonSnapshotUnavailable)
+			log.Warn().Err(err).Msg("state of block is no longer available, skipping shadow execution")
+			return
+		}
+		e.metrics.ShadowBlockSkipped(skipReasonExecutionFailed)
+		log.Error().Err(err).Msg("candidate failed to execute block")
+		report.Error = fmt.Sprintf("candidate failed to execute block: %v", err)
+		e.storeReport(log, report)
+		return
+	}
+
+	divergences, err := Compare(req.primary, candidate)
+	if err != nil {
+		e.metrics.ShadowBlockSkipped(skipReasonComparisonFailed)
+		log.Error().Err(err).Msg("could not compare results of candidate")
+		report.Error = fmt.Sprintf("could not compare results: %v", err)
+		e.storeReport(log, report)
+		return
+	}
+
+	divergedTransactions := make(map[uint32]struct{})
+	for _, divergence := range divergences {
+		divergedTransactions[divergence.TransactionIndex] = struct{}{}
+		e.metrics.ShadowDivergence(string(divergence.Kind))
+	}
+	e.metrics.ShadowBlockExecuted(time.Since(start), report.Transactions, len(divergedTransactions))
+
+	if len(divergences) == 0 {
+		log.Debug().Msg("shadow execution matches primary execution")
+		return
+	}
+
+	log.Warn().
+		Int("diverged_transactions", len(divergedTransactions)).
+		Int("divergences", len(divergences)).
+		Msg("shadow execution diverged from primary execution")
+
+	report.Divergences = divergences
+	e.storeReport(log, report)
+}
+
+// isSnapshotUnavailable returns true if the error was caused by reading the state of a block which is
+// no longer available.
+func isSnapshotUnavailable(err error) bool {
+	if _, ok := storehouse.IsPrunedError(err); ok {
+		return true
+	}
+	return errors.Is(err, storehouse.ErrNotExecuted) ||
+		errors.Is(err, state.ErrExecutionStatePruned) ||
+		errors.Is(err, storage.ErrHeightNotIndexed)
+}
+
+func (e *Executor) storeReport(log zerolog.Logger, report *BlockReport) {
+	err := e.store.Store(report)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to store shadow execution report")
+	}
+}
@@ -0,0 +1,220 @@
+package shadow
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/runtime"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	computermock "github.com/onflow/flow-go/engine/execution/computation/computer/mock"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/storehouse"
+	"github.com/onflow/flow-go/fvm"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
+	reusableRuntime "github.com/onflow/flow-go/fvm/runtime"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestExecutor(t *testing.T) {
+	block := unittest.ExecutableBlockFixture([][]flow.Identifier{{unittest.IdentifierFixture()}}, nil)
+	parentResultID := unittest.IdentifierFixture()
+
+	setup := func(t *testing.T, queueSize uint) (
+		*Executor,
+		*computermock.BlockComputer,
+		*modulemock.ShadowExecutionMetrics,
+		*DivergenceStore,
+	) {
+		blockComputer := computermock.NewBlockComputer(t)
+		metrics := modulemock.NewShadowExecutionMetrics(t)
+		store, err := NewDivergenceStore(t.TempDir())
+		require.NoError(t, err)
+
+		executor, err := NewExecutor(unittest.Logger(), metrics, blockComputer, store, queueSize)
+		require.NoError(t, err)
+
+		return executor, blockComputer, metrics, store
+	}
+
+	start := func(t *testing.T, executor *Executor) {
+		ctx, cancel := irrecoverable.NewMockSignalerContextWithCancel(t, context.Background())
+		executor.Start(ctx)
+		unittest.RequireComponentsReadyBefore(t, time.Second, executor)
+		t.Cleanup(func() {
+			cancel()
+			unittest.RequireComponentsDoneBefore(t, time.Second, executor)
+		})
+	}
+
+	t.Run("stores diverged blocks", func(t *testing.T) {
+		executor, blockComputer, metrics, store := setup(t, DefaultQueueSize)
+
+		primary := transactionsFixture(2)
+		candidate := make([]transactionFixture, len(primary))
+		copy(candidate, primary)
+		candidate[1].result.ErrorMessage = "failed"
+
+		blockComputer.
+			On("ExecuteBlock", mock.Anything, parentResultID, block, nil, mock.Anything).
+			Return(computationResultFixture(block, candidate...), nil).
+			Once()
+		metrics.On("ShadowDivergence", string(DivergenceKindError)).Once()
+		metrics.On("ShadowBlockExecuted", mock.Anything, 2, 1).Once()
+
+		start(t, executor)
+		executor.Submit(parentResultID, block, nil, computationResultFixture(block, primary...))
+
+		var reports []*BlockReport
+		require.Eventually(t, func() bool {
+			var err error
+			reports, err = store.Reports(10)
+			require.NoError(t, err)
+			return len(reports) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		report := reports[0]
+		require.Equal(t, block.ID(), report.BlockID)
+		require.Equal(t, block.Height(), report.BlockHeight)
+		require.Equal(t, 2, report.Transactions)
+		require.Empty(t, report.Error)
+		require.Len(t, report.Divergences, 1)
+		require.Equal(t, primary[1].result.TransactionID, report.Divergences[0].TransactionID)
+		require.Equal(t, DivergenceKindError, report.Divergences[0].Kind)
+	})
+
+	t.Run("does not store matching blocks", func(t *testing.T) {
+		executor, blockComputer, metrics, store := setup(t, DefaultQueueSize)
+
+		transactions := transactionsFixture(2)
+		blockComputer.
+			On("ExecuteBlock", mock.Anything, parentResultID, block, nil, mock.Anything).
+			Return(computationResultFixture(block, transactions...), nil).
+			Once()
+
+		executed := make(chan struct{})
+		metrics.On("ShadowBlockExecuted", mock.Anything, 2, 0).
+			Run(func(mock.Arguments) { close(executed) }).
+			Once()
+
+		start(t, executor)
+		executor.Submit(parentResultID, block, nil, computationResultFixture(block, transactions...))
+		unittest.RequireCloseBefore(t, executed, time.Second, "block was not shadow executed")
+
+		reports, err := store.Reports(10)
+		require.NoError(t, err)
+		require.Empty(t, reports)
+	})
+
+	t.Run("stores failed executions", func(t *testing.T) {
+		executor, blockComputer, metrics, store := setup(t, DefaultQueueSize)
+
+		blockComputer.
+			On("ExecuteBlock", mock.Anything, parentResultID, block, nil, mock.Anything).
+			Return(nil, fmt.Errorf("failed")).
+			Once()
+		metrics.On("ShadowBlockSkipped", skipReasonExecutionFailed).Once()
+
+		start(t, executor)
+		executor.Submit(parentResultID, block, nil, computationResultFixture(block, transactionsFixture(1)...))
+
+		var reports []*BlockReport
+		require.Eventually(t, func() bool {
+			var err error
+			reports, err = store.Reports(10)
+			require.NoError(t, err)
+			return len(reports) == 1
+		}, time.Second, 10*time.Millisecond)
+
+		require.Contains(t, reports[0].Error, "candidate failed to execute block")
+		require.Empty(t, reports[0].Divergences)
+	})
+
+	t.Run("skips blocks whose state is unavailable", func(t *testing.T) {
+		for _, readErr := range []error{
+			storehouse.NewPrunedError(block.Height(), block.Height()+1, unittest.IdentifierFixture()),
+			storehouse.ErrNotExecuted,
+			state.ErrExecutionStatePruned,
+		} {
+			executor, blockComputer, metrics, store := setup(t, DefaultQueueSize)
+
+			blockComputer.
+				On("ExecuteBlock", mock.Anything, parentResultID, block, nil, mock.Anything).
+				Return(nil, fvmerrors.NewLedgerFailure(readErr)).
+				Once()
+
+			skipped := make(chan struct{})
+			metrics.On("ShadowBlockSkipped", skipReasonSnapshotUnavailable).
+				Run(func(mock.Arguments) { close(skipped) }).
+				Once()
+
+			start(t, executor)
+			executor.Submit(parentResultID, block, nil, computationResultFixture(block, transactionsFixture(1)...))
+			unittest.RequireCloseBefore(t, skipped, time.Second, "block was not skipped")
+
+			reports, err := store.Reports(10)
+			require.NoError(t, err)
+			require.Empty(t, reports)
+		}
+	})
+
+	t.Run("skips blocks when queue is full", func(t *testing.T) {
+		// the executor is not started, so that submitted blocks stay queued
+		executor, _, metrics, _ := setup(t, 1)
+
+		metrics.On("ShadowBlockSkipped", skipReasonQueueFull).Once()
+
+		result := computationResultFixture(block, transactionsFixture(1)...)
+		executor.Submit(parentResultID, block, nil, result)
+		executor.Submit(parentResultID, block, nil, result)
+	})
+}
+
+func TestCandidateConfig_Options(t *testing.T) {
+	primaryCtx := fvm.NewContext(
+		fvm.WithReusableCadenceRuntimePool(
+			reusableRuntime.NewReusableCadenceRuntimePool(
+				0,
+				runtime.Config{
+					TracingEnabled:         true,
+					StorageFormatV2Enabled: true,
+				},
+			)))
+
+	t.Run("inherits the runtime configuration of the primary execution", func(t *testing.T) {
+		config := CandidateConfig{
+			AtreeValidationEnabled: true,
+			ComputationLimit:       100,
+			ComputationWeights:     map[string]string{"1001": "65536"},
+			MemoryWeights:          map[string]string{"2": "10"},
+		}
+		options, err := config.Options(primaryCtx)
+		require.NoError(t, err)
+
+		candidateCtx := fvm.NewContextFromParent(primaryCtx, options...)
+		runtimeConfig := candidateCtx.ReusableCadenceRuntimePool.Config()
+		require.True(t, runtimeConfig.AtreeValidationEnabled)
+		require.True(t, runtimeConfig.TracingEnabled)
+		require.True(t, runtimeConfig.StorageFormatV2Enabled)
+
+		overrides := candidateCtx.ExecutionParametersOverrides
+		require.Equal(t, uint64(100), overrides.ComputationLimit)
+		require.Equal(t, uint64(65536), overrides.ComputationWeights[common.ComputationKindStatement])
+		require.Equal(t, uint64(10), overrides.MemoryWeights[common.MemoryKind(2)])
+	})
+
+	t.Run("invalid weights", func(t *testing.T) {
+		_, err := CandidateConfig{ComputationWeights: map[string]string{"statement": "1"}}.Options(primaryCtx)
+		require.Error(t, err)
+
+		_, err = CandidateConfig{MemoryWeights: map[string]string{"2": "-1"}}.Options(primaryCtx)
+		require.Error(t, err)
+	})
+}
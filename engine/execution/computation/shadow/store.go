@@ -0,0 +1,129 @@
+package shadow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/multierr"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const reportExtension = ".json"
+
+// BlockReport is the report of a block whose shadow execution diverged from the primary execution.
+// Error is set if the candidate failed to execute the block, or if the results could not be compared.
+type BlockReport struct {
+	BlockID      flow.Identifier `json:"block_id"`
+	BlockHeight  uint64          `json:"block_height"`
+	ExecutedAt   time.Time       `json:"executed_at"`
+	Transactions int             `json:"transactions"`
+	Error        string          `json:"error,omitempty"`
+	Divergences  []Divergence    `json:"divergences"`
+}
+
+// DivergenceStore stores the reports of diverged blocks on disk, as one JSON file per block named
+// <height>-<block ID>.json, so that the reports survive restarts and can be inspected without the node.
+//
+// Safe for concurrent use.
+type DivergenceStore struct {
+	dir string
+}
+
+// NewDivergenceStore creates a new DivergenceStore which stores reports in the given directory.
+//
+// No errors are expected during normal operation.
+func NewDivergenceStore(dir string) (*DivergenceStore, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("could not create shadow execution dir %v: %w", dir, err)
+	}
+
+	return &DivergenceStore{
+		dir: dir,
+	}, nil
+}
+
+// Store stores the report, replacing any previous report of the same block.
+// The file is written atomically, so that partially written reports are never observed.
+//
+// No errors are expected during normal operation.
+func (s *DivergenceStore) Store(report *BlockReport) (err error) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	f, err := os.CreateTemp(s.dir, "report")
+	if err != nil {
+		return fmt.Errorf("failed to create temp report: %w", err)
+	}
+
+	// Remove temp file if it still exists.
+	defer func() {
+		if _, statErr := os.Stat(f.Name()); errors.Is(statErr, os.ErrNotExist) {
+			return
+		}
+		multierr.AppendInto(&err, os.Remove(f.Name()))
+	}()
+
+	_, err = f.Write(data)
+	multierr.AppendInto(&err, f.Close())
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	path := filepath.Join(s.dir, fmt.Sprintf("%020d-%s%s", report.BlockHeight, report.BlockID, reportExtension))
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return fmt.Errorf("failed to rename report: %w", err)
+	}
+
+	return nil
+}
+
+// Reports returns up to limit stored reports, ordered by descending block height.
+//
+// No errors are expected during normal operation.
+func (s *DivergenceStore) Reports(limit int) ([]*BlockReport, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), reportExtension) {
+			names = append(names, entry.Name())
+		}
+	}
+
+	// names start with the zero padded height, so that they sort by height
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	if len(names) > limit {
+		names = names[:limit]
+	}
+
+	reports := make([]*BlockReport, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report %s: %w", name, err)
+		}
+
+		var report BlockReport
+		err = json.Unmarshal(data, &report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode report %s: %w", name, err)
+		}
+		reports = append(reports, &report)
+	}
+
+	return reports, nil
+}
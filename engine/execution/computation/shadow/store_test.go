@@ -0,0 +1,50 @@
+package shadow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestDivergenceStore(t *testing.T) {
+	store, err := NewDivergenceStore(t.TempDir())
+	require.NoError(t, err)
+
+	reports, err := store.Reports(10)
+	require.NoError(t, err)
+	require.Empty(t, reports)
+
+	// heights which sort differently as decimal strings
+	heights := []uint64{9, 100, 10}
+	for _, height := range heights {
+		err := store.Store(&BlockReport{
+			BlockID:      unittest.IdentifierFixture(),
+			BlockHeight:  height,
+			ExecutedAt:   time.Now().UTC(),
+			Transactions: 1,
+			Divergences: []Divergence{{
+				TransactionID: unittest.IdentifierFixture(),
+				Kind:          DivergenceKindEvents,
+				Primary:       "primary",
+				Candidate:     "candidate",
+			}},
+		})
+		require.NoError(t, err)
+	}
+
+	reports, err = store.Reports(10)
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+	require.Equal(t, uint64(100), reports[0].BlockHeight)
+	require.Equal(t, uint64(10), reports[1].BlockHeight)
+	require.Equal(t, uint64(9), reports[2].BlockHeight)
+	require.Equal(t, DivergenceKindEvents, reports[0].Divergences[0].Kind)
+
+	reports, err = store.Reports(2)
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	require.Equal(t, uint64(100), reports[0].BlockHeight)
+}
@@ -28,9 +28,11 @@ import (
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	exeEng "github.com/onflow/flow-go/engine/execution"
 	"github.com/onflow/flow-go/engine/execution/computation/metrics"
+	"github.com/onflow/flow-go/engine/execution/rpc/extended"
 	"github.com/onflow/flow-go/engine/execution/state"
 	fvmerrors "github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/model/flow"
+	modexecution "github.com/onflow/flow-go/module/execution"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
@@ -69,6 +71,7 @@ func New(
 	signerIndicesDecoder hotstuff.BlockSignerDecoder,
 	apiRatelimits map[string]int, // the api rate limit (max calls per second) for each of the gRPC API e.g. Ping->100, ExecuteScriptAtBlockID->300
 	apiBurstLimits map[string]int, // the api burst limit (max calls at the same time) for each of the gRPC API e.g. Ping->50, ExecuteScriptAtBlockID->10
+	registerProver modexecution.RegisterProver, // proves registers for the ExtendedExecutionAPI, nil to disable it
 ) *Engine {
 	log = log.With().Str("engine", "rpc").Logger()
 	serverOptions := []grpc.ServerOption{
@@ -123,6 +126,14 @@ func New(
 
 	execution.RegisterExecutionAPIServer(eng.server, eng.handler)
 
+	if registerProver != nil {
+		extended.RegisterExtendedExecutionAPIServer(eng.server, &extendedHandler{
+			log:     log,
+			commits: commits,
+			prover:  registerProver,
+		})
+	}
+
 	return eng
 }
 
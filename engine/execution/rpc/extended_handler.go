@@ -0,0 +1,130 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/engine/common/rpc/convert"
+	"github.com/onflow/flow-go/engine/execution/rpc/extended"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	"github.com/onflow/flow-go/storage"
+)
+
+// extendedHandler implements the ExtendedExecutionAPI, which exposes flow-go specific execution node
+// features that are not part of the Flow Execution API.
+type extendedHandler struct {
+	extended.UnimplementedExtendedExecutionAPIServer
+	log     zerolog.Logger
+	commits storage.Commits
+	prover  execution.RegisterProver
+}
+
+var _ extended.ExtendedExecutionAPIServer = (*extendedHandler)(nil)
+
+// GetRegistersWithProof returns the values of registers at the end of an executed block, together with
+// a proof of the values against the state commitment of the block.
+//
+// The request fails with codes.FailedPrecondition if the node computed a different state commitment for
+// the block than the one expected by the caller, and with codes.NotFound if the block was not executed
+// or its state is no longer held by the ledger.
+func (h *extendedHandler) GetRegistersWithProof(
+	ctx context.Context,
+	req *extended.GetRegistersWithProofRequest,
+) (*extended.GetRegistersWithProofResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	expected, err := flow.ToStateCommitment(req.GetStateCommitment())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid state commitment: %v", err)
+	}
+
+	registerIDs, err := messagesToRegisterIDs(req.GetRegisterIds())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid register ids: %v", err)
+	}
+	if len(registerIDs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "no registers requested")
+	}
+	if len(registerIDs) > execution.MaxRegistersPerProof {
+		return nil, status.Errorf(codes.InvalidArgument, "too many registers requested: %d > %d", len(registerIDs), execution.MaxRegistersPerProof)
+	}
+
+	commit, err := h.commits.ByBlockID(blockID)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "block %s has not been executed by node or was pruned", blockID)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get state commitment for block %s: %v", blockID, err)
+	}
+
+	if commit != expected {
+		return nil, status.Errorf(codes.FailedPrecondition, "state commitment of block %s is %v, not %v", blockID, commit, expected)
+	}
+
+	registers, proof, err := h.prover.ProveRegisters(ctx, blockID, commit, registerIDs)
+	if err != nil {
+		if errors.Is(err, execution.ErrStateNotAvailable) {
+			return nil, status.Errorf(codes.NotFound, "state of block %s is not available: %v", blockID, err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to prove registers: %v", err)
+	}
+
+	return &extended.GetRegistersWithProofResponse{
+		StateCommitment: commit[:],
+		Registers:       registersToMessages(registers),
+		Proof:           proof,
+	}, nil
+}
+
+// messagesToRegisterIDs converts register ID messages of the ExtendedExecutionAPI to register IDs.
+// Expected errors during normal operations:
+//   - an error if the owner of a register is neither empty nor an address
+func messagesToRegisterIDs(messages []*extended.RegisterID) (flow.RegisterIDs, error) {
+	registerIDs := make(flow.RegisterIDs, len(messages))
+	for i, m := range messages {
+		registerID, err := messageToRegisterID(m)
+		if err != nil {
+			return nil, fmt.Errorf("invalid register id %d: %w", i, err)
+		}
+		registerIDs[i] = registerID
+	}
+	return registerIDs, nil
+}
+
+// messageToRegisterID converts a register ID message of the ExtendedExecutionAPI to a register ID.
+// Expected errors during normal operations:
+//   - an error if the owner of the register is neither empty nor an address
+func messageToRegisterID(m *extended.RegisterID) (flow.RegisterID, error) {
+	owner := m.GetOwner()
+	if len(owner) != 0 && len(owner) != flow.AddressLength {
+		return flow.RegisterID{}, fmt.Errorf("invalid owner length %d", len(owner))
+	}
+	return flow.RegisterID{
+		Owner: string(owner),
+		Key:   m.GetKey(),
+	}, nil
+}
+
+// registersToMessages converts register entries to register messages of the ExtendedExecutionAPI.
+func registersToMessages(registers []flow.RegisterEntry) []*extended.Register {
+	messages := make([]*extended.Register, len(registers))
+	for i, register := range registers {
+		messages[i] = &extended.Register{
+			Id: &extended.RegisterID{
+				Owner: []byte(register.Key.Owner),
+				Key:   register.Key.Key,
+			},
+			Value: register.Value,
+		}
+	}
+	return messages
+}
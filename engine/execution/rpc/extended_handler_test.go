@@ -0,0 +1,275 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access/registerproof"
+	"github.com/onflow/flow-go/engine/access/rpc/backend"
+	connectionmock "github.com/onflow/flow-go/engine/access/rpc/connection/mock"
+	commonrpc "github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/execution/rpc/extended"
+	executionState "github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/execution"
+	executionmock "github.com/onflow/flow-go/module/execution/mock"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	realstorage "github.com/onflow/flow-go/storage"
+	storage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/mocks"
+)
+
+func TestExtendedHandler_GetRegistersWithProof(t *testing.T) {
+	blockID := unittest.IdentifierFixture()
+	commit := unittest.StateCommitmentFixture()
+	registerID := flow.NewRegisterID(unittest.RandomAddressFixture(), "balance")
+	registers := []flow.RegisterEntry{{Key: registerID, Value: []byte{1}}}
+	proof := unittest.RandomBytes(64)
+
+	request := func() *extended.GetRegistersWithProofRequest {
+		return &extended.GetRegistersWithProofRequest{
+			BlockId:         blockID[:],
+			StateCommitment: commit[:],
+			RegisterIds: []*extended.RegisterID{{
+				Owner: []byte(registerID.Owner),
+				Key:   registerID.Key,
+			}},
+		}
+	}
+
+	setup := func(t *testing.T) (*extendedHandler, *storage.Commits, *executionmock.RegisterProver) {
+		commits := storage.NewCommits(t)
+		prover := executionmock.NewRegisterProver(t)
+		return &extendedHandler{
+			log:     unittest.Logger(),
+			commits: commits,
+			prover:  prover,
+		}, commits, prover
+	}
+
+	t.Run("proves registers", func(t *testing.T) {
+		handler, commits, prover := setup(t)
+		commits.On("ByBlockID", blockID).Return(commit, nil)
+		prover.On("ProveRegisters", mock.Anything, blockID, commit, flow.RegisterIDs{registerID}).Return(registers, proof, nil)
+
+		resp, err := handler.GetRegistersWithProof(context.Background(), request())
+		require.NoError(t, err)
+		require.Equal(t, commit[:], resp.StateCommitment)
+		require.Equal(t, proof, resp.Proof)
+		require.Len(t, resp.Registers, 1)
+		require.Equal(t, []byte(registerID.Owner), resp.Registers[0].Id.Owner)
+		require.Equal(t, registerID.Key, resp.Registers[0].Id.Key)
+		require.Equal(t, []byte{1}, resp.Registers[0].Value)
+	})
+
+	t.Run("different state commitment", func(t *testing.T) {
+		handler, commits, _ := setup(t)
+		commits.On("ByBlockID", blockID).Return(unittest.StateCommitmentFixture(), nil)
+
+		_, err := handler.GetRegistersWithProof(context.Background(), request())
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+
+	t.Run("block not executed", func(t *testing.T) {
+		handler, commits, _ := setup(t)
+		commits.On("ByBlockID", blockID).Return(flow.DummyStateCommitment, realstorage.ErrNotFound)
+
+		_, err := handler.GetRegistersWithProof(context.Background(), request())
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("state not available", func(t *testing.T) {
+		handler, commits, prover := setup(t)
+		commits.On("ByBlockID", blockID).Return(commit, nil)
+		prover.On("ProveRegisters", mock.Anything, blockID, commit, flow.RegisterIDs{registerID}).
+			Return(nil, nil, execution.ErrStateNotAvailable)
+
+		_, err := handler.GetRegistersWithProof(context.Background(), request())
+		require.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("invalid owner", func(t *testing.T) {
+		handler, _, _ := setup(t)
+		req := request()
+		req.RegisterIds[0].Owner = []byte{1, 2, 3}
+
+		_, err := handler.GetRegistersWithProof(context.Background(), req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("too many registers", func(t *testing.T) {
+		handler, _, _ := setup(t)
+		req := request()
+		req.RegisterIds = make([]*extended.RegisterID, execution.MaxRegistersPerProof+1)
+
+		_, err := handler.GetRegistersWithProof(context.Background(), req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// inProcessExtendedClient is an ExtendedExecutionAPIClient which calls the handler of an execution node
+// directly. If tamper is set, the register values returned by the handler are modified.
+type inProcessExtendedClient struct {
+	handler extended.ExtendedExecutionAPIServer
+	tamper  bool
+}
+
+func (c *inProcessExtendedClient) GetRegistersWithProof(
+	ctx context.Context,
+	in *extended.GetRegistersWithProofRequest,
+	_ ...grpc.CallOption,
+) (*extended.GetRegistersWithProofResponse, error) {
+	resp, err := c.handler.GetRegistersWithProof(ctx, in)
+	if err != nil || !c.tamper {
+		return resp, err
+	}
+	for _, register := range resp.Registers {
+		register.Value = []byte("tampered")
+	}
+	return resp, nil
+}
+
+// TestRegisterProofsFromExecutionNode proves registers held by the ledger of an execution node through
+// the register prover of the access node, and verifies the proof against the seal of the block.
+func TestRegisterProofsFromExecutionNode(t *testing.T) {
+	l, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	compactor := fixtures.NewNoopCompactor(l)
+	<-compactor.Ready()
+	t.Cleanup(func() {
+		<-l.Done()
+		<-compactor.Done()
+	})
+
+	owner := unittest.RandomAddressFixture()
+	balance := flow.NewRegisterID(owner, "balance")
+	unset := flow.NewRegisterID(owner, "unset")
+
+	keys, values := executionState.RegisterEntriesToKeysValues(flow.RegisterEntries{
+		{Key: balance, Value: []byte{1, 2, 3}},
+	})
+	update, err := ledger.NewUpdate(l.InitialState(), keys, values)
+	require.NoError(t, err)
+	state, _, err := l.Set(update)
+	require.NoError(t, err)
+	commit := flow.StateCommitment(state)
+
+	block := unittest.BlockFixture()
+	blockID := block.ID()
+	result := unittest.ExecutionResultFixture(
+		unittest.WithExecutionResultBlockID(blockID),
+		unittest.WithFinalState(commit))
+	seal := &flow.Seal{
+		BlockID:    blockID,
+		ResultID:   result.ID(),
+		FinalState: commit,
+	}
+
+	commits := storage.NewCommits(t)
+	commits.On("ByBlockID", blockID).Return(commit, nil).Maybe()
+	handler := &extendedHandler{
+		log:     unittest.Logger(),
+		commits: commits,
+		prover:  execution.NewLedgerRegisterProver(l),
+	}
+
+	executionNodes := unittest.IdentityListFixture(2, unittest.WithRole(flow.RoleExecution))
+
+	// setup creates the register prover of an access node, querying the given execution node clients
+	setup := func(t *testing.T, clients ...*inProcessExtendedClient) *backend.ExecutionNodeRegisterProver {
+		protocolState := protocol.NewState(t)
+		params := protocol.NewParams(t)
+		snapshot := protocol.NewSnapshot(t)
+		params.On("FinalizedRoot").Return(unittest.BlockHeaderFixture(), nil)
+		protocolState.On("Params").Return(params)
+		protocolState.On("Final").Return(snapshot).Maybe()
+		snapshot.On("Identities", mock.Anything).Return(executionNodes, nil).Maybe()
+
+		receipts := storage.NewExecutionReceipts(t)
+		receipts.On("ByBlockID", blockID).
+			Return(flow.ExecutionReceiptList(unittest.ReceiptsForBlockFixture(&block, executionNodes.NodeIDs())), nil)
+
+		connFactory := connectionmock.NewConnectionFactory(t)
+		for i, client := range clients {
+			connFactory.On("GetExtendedExecutionAPIClient", executionNodes[i].Address).
+				Return(client, &mocks.MockCloser{}, nil).
+				Maybe()
+		}
+
+		log := unittest.Logger()
+		return backend.NewExecutionNodeRegisterProver(
+			log,
+			connFactory,
+			backend.NewNodeCommunicator(false),
+			commonrpc.NewExecutionNodeIdentitiesProvider(log, protocolState, receipts, nil, nil),
+		)
+	}
+
+	registerIDs := flow.RegisterIDs{balance, unset}
+
+	t.Run("proof verifies against seal", func(t *testing.T) {
+		prover := setup(t,
+			&inProcessExtendedClient{handler: handler},
+			&inProcessExtendedClient{handler: handler},
+		)
+
+		registers, encodedProof, err := prover.ProveRegisters(context.Background(), blockID, commit, registerIDs)
+		require.NoError(t, err)
+		require.Equal(t, []flow.RegisterEntry{
+			{Key: balance, Value: []byte{1, 2, 3}},
+			{Key: unset, Value: []byte{}},
+		}, registers)
+
+		proof := &flow.RegistersProof{
+			BlockID:         blockID,
+			StateCommitment: commit,
+			Registers:       registers,
+			Proof:           encodedProof,
+		}
+		require.NoError(t, registerproof.VerifyWithSeal(proof, result, seal))
+	})
+
+	t.Run("invalid proof falls back to next execution node", func(t *testing.T) {
+		prover := setup(t,
+			&inProcessExtendedClient{handler: handler, tamper: true},
+			&inProcessExtendedClient{handler: handler},
+		)
+
+		registers, _, err := prover.ProveRegisters(context.Background(), blockID, commit, registerIDs)
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 3}, registers[0].Value)
+	})
+
+	t.Run("invalid proofs from all execution nodes", func(t *testing.T) {
+		prover := setup(t,
+			&inProcessExtendedClient{handler: handler, tamper: true},
+			&inProcessExtendedClient{handler: handler, tamper: true},
+		)
+
+		_, _, err := prover.ProveRegisters(context.Background(), blockID, commit, registerIDs)
+		require.Error(t, err)
+	})
+
+	t.Run("unsealed state commitment", func(t *testing.T) {
+		prover := setup(t,
+			&inProcessExtendedClient{handler: handler},
+			&inProcessExtendedClient{handler: handler},
+		)
+
+		_, _, err := prover.ProveRegisters(context.Background(), blockID, unittest.StateCommitmentFixture(), registerIDs)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
@@ -0,0 +1,260 @@
+package fvm
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/onflow/atree"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+	"github.com/onflow/cadence/runtime"
+
+	"github.com/onflow/flow-go/fvm/storage/snapshot"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// AccountStorageMaxDepth is the maximum depth of nested slabs which are included in the size of an
+// inspected value. It bounds the work done for deeply nested values.
+const AccountStorageMaxDepth = 32
+
+// GetAccountStorage returns a page of at most limit values stored in an account, together with the
+// storage used and the storage capacity of the account. If cursor is not nil, the page starts at
+// the cursor position. The limit must be greater than zero.
+//
+// Values are returned in the order of the storage domains, and in the iteration order of the storage
+// map within a domain.
+func GetAccountStorage(
+	ctx Context,
+	address flow.Address,
+	storageSnapshot snapshot.StorageSnapshot,
+	limit uint,
+	cursor *flow.AccountStorageCursor,
+) (
+	*flow.AccountStorage,
+	error,
+) {
+	env, _ := getScriptEnvironment(ctx, storageSnapshot)
+	runtimeAddress := common.MustBytesToAddress(address.Bytes())
+
+	used, err := env.GetStorageUsed(runtimeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get account storage used: %w", err)
+	}
+
+	capacity, err := env.GetStorageCapacity(runtimeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get account storage capacity: %w", err)
+	}
+
+	// the environment is the ledger of the Cadence storage, so that register reads are subject to
+	// the same limits as scripts.
+	storage := runtime.NewStorage(
+		env,
+		nil,
+		runtime.StorageConfig{
+			StorageFormatV2Enabled: true,
+		},
+	)
+
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{Storage: storage})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create interpreter: %w", err)
+	}
+
+	domains, nextCursor, err := inspectStorageDomains(inter, storage, runtimeAddress, limit, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("cannot inspect account storage: %w", err)
+	}
+
+	return &flow.AccountStorage{
+		Address:         address,
+		StorageUsed:     used,
+		StorageCapacity: capacity,
+		Domains:         domains,
+		NextCursor:      nextCursor,
+	}, nil
+}
+
+// inspectStorageDomains lists at most limit values stored in the non-empty storage domains of the account,
+// starting at the cursor position if cursor is not nil. Returns the cursor of the next page, nil if there
+// are no more values.
+func inspectStorageDomains(
+	inter *interpreter.Interpreter,
+	storage *runtime.Storage,
+	address common.Address,
+	limit uint,
+	cursor *flow.AccountStorageCursor,
+) (
+	[]flow.AccountStorageDomain,
+	*flow.AccountStorageCursor,
+	error,
+) {
+	domains := common.AllStorageDomains
+	var start uint64
+	if cursor != nil {
+		cursorDomain, ok := common.StorageDomainFromIdentifier(cursor.Domain)
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown storage domain in cursor: %s", cursor.Domain)
+		}
+		for i, domain := range common.AllStorageDomains {
+			if domain == cursorDomain {
+				domains = common.AllStorageDomains[i:]
+				break
+			}
+		}
+		start = cursor.Index
+	}
+
+	var result []flow.AccountStorageDomain
+	var count uint
+
+	for _, domain := range domains {
+		storageMap := storage.GetDomainStorageMap(inter, address, domain, false)
+		if storageMap == nil || storageMap.Count() <= start {
+			start = 0
+			continue
+		}
+
+		isPathDomain := common.PathDomainFromIdentifier(domain.Identifier()) != common.PathDomainUnknown
+
+		var items []flow.AccountStorageItem
+		iterator := storageMap.Iterator(inter)
+		index := uint64(0)
+		for key, value := iterator.Next(); key != nil; key, value = iterator.Next() {
+			if index < start {
+				index++
+				continue
+			}
+
+			if count == limit {
+				if len(items) > 0 {
+					result = append(result, flow.AccountStorageDomain{Name: domain.Identifier(), Items: items})
+				}
+				return result, &flow.AccountStorageCursor{Domain: domain.Identifier(), Index: index}, nil
+			}
+
+			item := flow.AccountStorageItem{
+				Key:  storageMapKeyString(key),
+				Type: string(value.StaticType(inter).ID()),
+			}
+			if isPathDomain {
+				item.Key = fmt.Sprintf("/%s/%s", domain.Identifier(), item.Key)
+			}
+
+			size, truncated, err := storedValueSize(storage, address, value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("cannot get size of %s in domain %s: %w", item.Key, domain.Identifier(), err)
+			}
+			item.Size = size
+			item.SizeTruncated = truncated
+
+			items = append(items, item)
+			count++
+			index++
+		}
+		start = 0
+
+		result = append(result, flow.AccountStorageDomain{
+			Name:  domain.Identifier(),
+			Items: items,
+		})
+	}
+
+	return result, nil, nil
+}
+
+// storageMapKeyString returns the string representation of a key of a domain storage map.
+func storageMapKeyString(key atree.Value) string {
+	switch key := key.(type) {
+	case interpreter.StringAtreeValue:
+		return string(key)
+	case interpreter.Uint64AtreeValue:
+		return strconv.FormatUint(uint64(key), 10)
+	default:
+		return fmt.Sprintf("%v", key)
+	}
+}
+
+// storedValueSize returns the number of bytes used to store the value, including the slabs of nested values
+// which are not inlined, up to AccountStorageMaxDepth. Returns true if slabs below the depth limit were skipped.
+func storedValueSize(
+	storage *runtime.Storage,
+	address common.Address,
+	value interpreter.Value,
+) (
+	uint64,
+	bool,
+	error,
+) {
+	var storable atree.Storable
+
+	// containers stored in their own slab are referenced by slab ID. Otherwise, the storable of the
+	// value is its inlined encoding.
+	container, ok := value.(interface {
+		Inlined() bool
+		SlabID() atree.SlabID
+	})
+	if ok && !container.Inlined() {
+		storable = atree.SlabIDStorable(container.SlabID())
+	} else {
+		var err error
+		storable, err = value.Storable(storage, atree.Address(address), math.MaxUint64)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	return storableSize(storage, storable, 0)
+}
+
+// storableSize returns the size of the storable at the given depth, including the slabs it references.
+// The size of a slab ID storable is the size of the referenced slab.
+func storableSize(storage *runtime.Storage, storable atree.Storable, depth uint) (uint64, bool, error) {
+	if slabID, ok := storable.(atree.SlabIDStorable); ok {
+		if depth >= AccountStorageMaxDepth {
+			return 0, true, nil
+		}
+		slab, found, err := storage.Retrieve(atree.SlabID(slabID))
+		if err != nil {
+			return 0, false, err
+		}
+		if !found {
+			return 0, false, fmt.Errorf("slab %s not found", atree.SlabID(slabID))
+		}
+		storable = slab
+		depth++
+	}
+
+	referencedSize, truncated, err := referencedSlabsSize(storage, storable, depth)
+	if err != nil {
+		return 0, false, err
+	}
+
+	return uint64(storable.ByteSize()) + referencedSize, truncated, nil
+}
+
+// referencedSlabsSize returns the total size of the slabs referenced by the storable, recursively.
+// Inlined children are already accounted for in the size of their parent.
+func referencedSlabsSize(storage *runtime.Storage, storable atree.Storable, depth uint) (uint64, bool, error) {
+	var size uint64
+	truncated := false
+
+	for _, child := range storable.ChildStorables() {
+		var childSize uint64
+		var childTruncated bool
+		var err error
+		if _, ok := child.(atree.SlabIDStorable); ok {
+			childSize, childTruncated, err = storableSize(storage, child, depth)
+		} else {
+			childSize, childTruncated, err = referencedSlabsSize(storage, child, depth)
+		}
+		if err != nil {
+			return 0, false, err
+		}
+		size += childSize
+		truncated = truncated || childTruncated
+	}
+
+	return size, truncated, nil
+}
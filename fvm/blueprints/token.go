@@ -90,6 +90,9 @@ var createFlowTokenMinterTransactionTemplate string
 //go:embed scripts/mintFlowTokenTransactionTemplate.cdc
 var mintFlowTokenTransactionTemplate string
 
+//go:embed scripts/setFlowTokenBalanceTransactionTemplate.cdc
+var setFlowTokenBalanceTransactionTemplate string
+
 func DeployFlowTokenContractTransaction(service, flowToken flow.Address, contract []byte) *flow.TransactionBody {
 
 	return flow.NewTransactionBody().
@@ -132,3 +135,28 @@ func MintFlowTokenTransaction(
 		AddArgument(initialSupplyArg).
 		AddAuthorizer(service)
 }
+
+// SetFlowTokenBalanceTransaction returns a transaction which sets the FLOW balance of the account, by
+// minting tokens with the token admin of the service account or burning tokens of the account.
+// It must be signed by the service account and by the account.
+func SetFlowTokenBalanceTransaction(
+	fungibleToken, flowToken, burner, service, account flow.Address,
+	balance cadence.UFix64,
+) *flow.TransactionBody {
+	balanceArg, err := jsoncdc.Encode(balance)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode token balance: %s", err.Error()))
+	}
+
+	return flow.NewTransactionBody().
+		SetScript([]byte(templates.ReplaceAddresses(setFlowTokenBalanceTransactionTemplate,
+			templates.Environment{
+				FlowTokenAddress:     flowToken.Hex(),
+				FungibleTokenAddress: fungibleToken.Hex(),
+				BurnerAddress:        burner.Hex(),
+			})),
+		).
+		AddArgument(balanceArg).
+		AddAuthorizer(service).
+		AddAuthorizer(account)
+}
@@ -47,6 +47,10 @@ type Context struct {
 	// AllowProgramCacheWritesInScripts determines if the program cache can be written to in scripts
 	// By default, the program cache is only updated by transactions.
 	AllowProgramCacheWritesInScripts bool
+
+	// ExecutionParametersOverrides replace the execution parameters of procedures, including the
+	// parameters stored in the state.
+	ExecutionParametersOverrides ExecutionParametersOverrides
 }
 
 // NewContext initializes a new execution context with the provided options.
@@ -126,6 +130,16 @@ func WithMemoryLimit(limit uint64) Option {
 	}
 }
 
+// WithExecutionParametersOverrides sets the execution parameters which replace the parameters of
+// procedures, including the parameters stored in the state, e.g. to execute blocks with candidate
+// parameters.
+func WithExecutionParametersOverrides(overrides ExecutionParametersOverrides) Option {
+	return func(ctx Context) Context {
+		ctx.ExecutionParametersOverrides = overrides
+		return ctx
+	}
+}
+
 // WithLogger sets the context logger
 func WithLogger(logger zerolog.Logger) Option {
 	return func(ctx Context) Context {
@@ -339,6 +353,18 @@ func WithReusableCadenceRuntimePool(
 	}
 }
 
+// WithComputationProfiler sets the profiler which records the computation and
+// memory used by the transaction/script, attributed to Cadence call stacks.
+// Profiling is disabled when the profiler is nil.
+func WithComputationProfiler(
+	profiler *environment.ComputationProfiler,
+) Option {
+	return func(ctx Context) Context {
+		ctx.ComputationProfiler = profiler
+		return ctx
+	}
+}
+
 // WithDerivedBlockData sets the derived data cache storage to be used by the
 // transaction/script.
 func WithDerivedBlockData(derivedBlockData *derived.DerivedBlockData) Option {
@@ -0,0 +1,480 @@
+package environment
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/pprof/profile"
+	"github.com/onflow/cadence/ast"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/interpreter"
+
+	"github.com/onflow/flow-go/fvm/meter"
+	"github.com/onflow/flow-go/fvm/storage/state"
+)
+
+const (
+	// computation is metered with a fixed-point precision, profiles report it in thousandths of a
+	// computation unit so that cheap operations are not rounded away.
+	profileComputationScale = 1000
+
+	profileKindLabel = "kind"
+
+	// fvmProfileFunction is the function of computation and memory used outside of Cadence code,
+	// e.g. when the FVM processes a transaction before invoking Cadence.
+	fvmProfileFunction = "[fvm]"
+
+	// truncatedProfileFunction is the function of computation and memory used at call stacks which
+	// were first seen after the profiler reached its maximum number of samples.
+	truncatedProfileFunction = "[truncated]"
+
+	// DefaultMaxProfileSamples is the default maximum number of samples recorded by a profiler.
+	DefaultMaxProfileSamples = 10_000
+)
+
+// ComputationProfiler attributes the computation and memory used by procedures to the Cadence call
+// stacks which used them, and produces pprof profiles of the usage.
+//
+// Each frame of a call stack is a line of a function of a Cadence program (contract, transaction or
+// script). Computation and memory used outside of Cadence code is attributed to the [fvm] frame.
+//
+// The profiler is enabled for the procedures of a context with fvm.WithComputationProfiler. It can be
+// shared by concurrent procedures, in which case their usage is aggregated.
+//
+// A sample is recorded per call stack and kind of usage. Once the maximum number of samples is
+// reached, the usage at new call stacks is attributed to the [truncated] frame instead, so that the
+// memory used by a long-lived profiler is bounded.
+type ComputationProfiler struct {
+	mu         sync.Mutex
+	samples    map[profileSampleKey]*profileSample
+	maxSamples int
+}
+
+type profileFrame struct {
+	location string
+	function string
+	line     int
+}
+
+type profileSampleKey struct {
+	stack  string
+	kind   string
+	memory bool
+}
+
+type profileSample struct {
+	frames []profileFrame
+	value  uint64
+}
+
+// NewComputationProfiler returns a new profiler with no recorded usage, which records at most
+// DefaultMaxProfileSamples samples.
+func NewComputationProfiler() *ComputationProfiler {
+	return NewComputationProfilerWithMaxSamples(DefaultMaxProfileSamples)
+}
+
+// NewComputationProfilerWithMaxSamples returns a new profiler with no recorded usage, which records at
+// most the given number of samples, in addition to the samples of the [truncated] frame.
+func NewComputationProfilerWithMaxSamples(maxSamples int) *ComputationProfiler {
+	return &ComputationProfiler{
+		samples:    make(map[profileSampleKey]*profileSample),
+		maxSamples: maxSamples,
+	}
+}
+
+func (p *ComputationProfiler) add(key profileSampleKey, frames func() []profileFrame, value uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sample, ok := p.samples[key]
+	if !ok {
+		if len(p.samples) >= p.maxSamples {
+			// there is at most one truncated sample per kind of usage
+			key.stack = truncatedProfileFunction
+			frames = func() []profileFrame {
+				return []profileFrame{{function: truncatedProfileFunction}}
+			}
+			sample, ok = p.samples[key]
+		}
+		if !ok {
+			sample = &profileSample{frames: frames()}
+			p.samples[key] = sample
+		}
+	}
+	sample.value += value
+}
+
+// Reset drops the recorded usage.
+func (p *ComputationProfiler) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.samples = make(map[profileSampleKey]*profileSample)
+}
+
+// Profile returns the recorded usage as a pprof profile. Samples have a computation value, in
+// thousandths of computation units, and a memory value, in estimated bytes. Samples are labelled
+// with the kind of the metered computation or memory.
+func (p *ComputationProfiler) Profile() *profile.Profile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.profile()
+}
+
+// Flush returns the recorded usage as a pprof profile, see Profile, and resets the profiler.
+func (p *ComputationProfiler) Flush() *profile.Profile {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	prof := p.profile()
+	p.samples = make(map[profileSampleKey]*profileSample)
+	return prof
+}
+
+// WriteProfile writes the recorded usage as a gzipped pprof protobuf, see Profile.
+func (p *ComputationProfiler) WriteProfile(w io.Writer) error {
+	return p.Profile().Write(w)
+}
+
+func (p *ComputationProfiler) profile() *profile.Profile {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "computation", Unit: "milliunits"},
+			{Type: "memory", Unit: "bytes"},
+		},
+		DefaultSampleType: "computation",
+		PeriodType:        &profile.ValueType{Type: "computation", Unit: "milliunits"},
+		Period:            1,
+	}
+
+	// samples are sorted to produce deterministic profiles
+	keys := make([]profileSampleKey, 0, len(p.samples))
+	for key := range p.samples {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].stack != keys[j].stack {
+			return keys[i].stack < keys[j].stack
+		}
+		if keys[i].memory != keys[j].memory {
+			return !keys[i].memory
+		}
+		return keys[i].kind < keys[j].kind
+	})
+
+	functions := make(map[[2]string]*profile.Function)
+	locations := make(map[profileFrame]*profile.Location)
+
+	for _, key := range keys {
+		sample := p.samples[key]
+
+		// pprof stacks start with the leaf
+		stack := make([]*profile.Location, len(sample.frames))
+		for i, frame := range sample.frames {
+			location, ok := locations[frame]
+			if !ok {
+				function, ok := functions[[2]string{frame.location, frame.function}]
+				if !ok {
+					function = &profile.Function{
+						ID:         uint64(len(prof.Function) + 1),
+						Name:       profileFunctionName(frame),
+						SystemName: frame.function,
+						Filename:   frame.location,
+					}
+					functions[[2]string{frame.location, frame.function}] = function
+					prof.Function = append(prof.Function, function)
+				}
+
+				location = &profile.Location{
+					ID: uint64(len(prof.Location) + 1),
+					Line: []profile.Line{{
+						Function: function,
+						Line:     int64(frame.line),
+					}},
+				}
+				locations[frame] = location
+				prof.Location = append(prof.Location, location)
+			}
+			stack[len(sample.frames)-1-i] = location
+		}
+
+		value := make([]int64, 2)
+		if key.memory {
+			value[1] = int64(sample.value)
+		} else {
+			value[0] = int64(sample.value * profileComputationScale >> meter.MeterExecutionInternalPrecisionBytes)
+		}
+
+		prof.Sample = append(prof.Sample, &profile.Sample{
+			Location: stack,
+			Value:    value,
+			Label:    map[string][]string{profileKindLabel: {key.kind}},
+		})
+	}
+
+	return prof
+}
+
+func profileFunctionName(frame profileFrame) string {
+	if frame.location == "" {
+		return frame.function
+	}
+	// the ID of a contract location ends with the name of the contract, which
+	// also qualifies the functions declared in the contract
+	i := strings.LastIndexByte(frame.location, '.')
+	if i >= 0 && strings.HasPrefix(frame.function, frame.location[i+1:]+".") {
+		return frame.location[:i] + "." + frame.function
+	}
+	return frame.location + "." + frame.function
+}
+
+// profilerCallStack tracks the Cadence call stack of a procedure, and records the usage of the procedure
+// at the current call stack.
+type profilerCallStack struct {
+	profiler *ComputationProfiler
+
+	// invocations are the nested Cadence invocations of the procedure, e.g. the contract functions
+	// invoked by the FVM while it executes a transaction.
+	invocations []*profiledInvocation
+
+	// statements is the computation of the statement which is about to be
+	// executed. Statements are metered before they are observed, their
+	// computation is recorded once the call stack includes the statement.
+	statements uint64
+
+	functions map[*ast.Program][]profiledFunction
+}
+
+type profiledInvocation struct {
+	inter *interpreter.Interpreter
+	// frames[i] is the frame of the last statement executed at call depth i
+	frames []profileFrame
+}
+
+type profiledFunction struct {
+	name       string
+	start, end ast.Position
+}
+
+func newProfilerCallStack(profiler *ComputationProfiler) *profilerCallStack {
+	return &profilerCallStack{
+		profiler:  profiler,
+		functions: make(map[*ast.Program][]profiledFunction),
+	}
+}
+
+func (s *profilerCallStack) enter() {
+	s.invocations = append(s.invocations, &profiledInvocation{})
+}
+
+func (s *profilerCallStack) exit() {
+	s.recordStatements()
+	if len(s.invocations) > 0 {
+		s.invocations = s.invocations[:len(s.invocations)-1]
+	}
+}
+
+// observe hooks the call stack into the interpreters of the given configuration.
+func (s *profilerCallStack) observe(config *interpreter.Config) {
+	config.OnStatement = s.onStatement
+}
+
+func (s *profilerCallStack) onStatement(inter *interpreter.Interpreter, statement ast.Statement) {
+	if len(s.invocations) == 0 {
+		return
+	}
+	invocation := s.invocations[len(s.invocations)-1]
+	invocation.inter = inter
+
+	depth := len(inter.CallStack())
+	for len(invocation.frames) <= depth {
+		invocation.frames = append(invocation.frames, profileFrame{})
+	}
+	invocation.frames = invocation.frames[:depth+1]
+
+	position := statement.StartPosition()
+	invocation.frames[depth] = profileFrame{
+		location: inter.Location.ID(),
+		function: s.function(inter.Program, position),
+		line:     position.Line,
+	}
+
+	s.recordStatements()
+}
+
+func (s *profilerCallStack) meterStatements(value uint64) {
+	if len(s.invocations) == 0 {
+		s.record(common.ComputationKindStatement.String(), false, value)
+		return
+	}
+	s.statements += value
+}
+
+func (s *profilerCallStack) recordStatements() {
+	if s.statements == 0 {
+		return
+	}
+	s.record(common.ComputationKindStatement.String(), false, s.statements)
+	s.statements = 0
+}
+
+// function returns the name of the innermost function of the program declared at the position.
+func (s *profilerCallStack) function(program *interpreter.Program, position ast.Position) string {
+	if program == nil || program.Program == nil {
+		return ""
+	}
+
+	functions, ok := s.functions[program.Program]
+	if !ok {
+		functions = programFunctions(program.Program)
+		s.functions[program.Program] = functions
+	}
+
+	name := ""
+	start := ast.Position{}
+	for _, function := range functions {
+		if function.start.Compare(position) <= 0 &&
+			function.end.Compare(position) >= 0 &&
+			function.start.Compare(start) >= 0 {
+
+			name = function.name
+			start = function.start
+		}
+	}
+	return name
+}
+
+// frames returns the frames of the current call stack, starting with the root frame.
+func (s *profilerCallStack) frames() []profileFrame {
+	var frames []profileFrame
+	for _, invocation := range s.invocations {
+		if invocation.inter == nil {
+			continue
+		}
+		// frames deeper than the call stack belong to functions which already returned
+		depth := min(len(invocation.inter.CallStack()), len(invocation.frames)-1)
+		for _, frame := range invocation.frames[:depth+1] {
+			if frame != (profileFrame{}) {
+				frames = append(frames, frame)
+			}
+		}
+	}
+	if len(frames) == 0 {
+		frames = append(frames, profileFrame{function: fvmProfileFunction})
+	}
+	return frames
+}
+
+func (s *profilerCallStack) record(kind string, memory bool, value uint64) {
+	if value == 0 {
+		return
+	}
+
+	frames := s.frames()
+
+	var stack strings.Builder
+	for _, frame := range frames {
+		stack.WriteString(frame.location)
+		stack.WriteByte('.')
+		stack.WriteString(frame.function)
+		stack.WriteByte(':')
+		stack.WriteString(strconv.Itoa(frame.line))
+		stack.WriteByte(';')
+	}
+
+	s.profiler.add(
+		profileSampleKey{stack: stack.String(), kind: kind, memory: memory},
+		func() []profileFrame { return frames },
+		value)
+}
+
+// programFunctions returns the functions declared by the program, qualified by the names of their
+// enclosing declarations.
+func programFunctions(program *ast.Program) []profiledFunction {
+	var functions []profiledFunction
+
+	var addFunction func(prefix string, name string, declaration *ast.FunctionDeclaration)
+	addFunction = func(prefix string, name string, declaration *ast.FunctionDeclaration) {
+		if declaration == nil {
+			return
+		}
+		functions = append(functions, profiledFunction{
+			name:  prefix + name,
+			start: declaration.StartPosition(),
+			end:   declaration.EndPosition(nil),
+		})
+	}
+
+	var addDeclarations func(prefix string, declarations []ast.Declaration)
+	addDeclarations = func(prefix string, declarations []ast.Declaration) {
+		for _, declaration := range declarations {
+			switch declaration := declaration.(type) {
+			case *ast.FunctionDeclaration:
+				addFunction(prefix, declaration.Identifier.Identifier, declaration)
+			case *ast.SpecialFunctionDeclaration:
+				addFunction(prefix, declaration.Kind.Keywords(), declaration.FunctionDeclaration)
+			case *ast.TransactionDeclaration:
+				if declaration.Prepare != nil {
+					addFunction(prefix, "prepare", declaration.Prepare.FunctionDeclaration)
+				}
+				if declaration.Execute != nil {
+					addFunction(prefix, "execute", declaration.Execute.FunctionDeclaration)
+				}
+			default:
+				members := declaration.DeclarationMembers()
+				identifier := declaration.DeclarationIdentifier()
+				if members != nil && identifier != nil {
+					addDeclarations(prefix+identifier.Identifier+".", members.Declarations())
+				}
+			}
+		}
+	}
+
+	addDeclarations("", program.Declarations())
+	return functions
+}
+
+// profilingMeter records the metered computation and memory at the current call stack of the procedure.
+type profilingMeter struct {
+	Meter
+
+	txnState  state.NestedTransactionPreparer
+	callStack *profilerCallStack
+}
+
+func newProfilingMeter(
+	meter Meter,
+	txnState state.NestedTransactionPreparer,
+	callStack *profilerCallStack,
+) Meter {
+	return &profilingMeter{
+		Meter:     meter,
+		txnState:  txnState,
+		callStack: callStack,
+	}
+}
+
+func (m *profilingMeter) MeterComputation(
+	kind common.ComputationKind,
+	intensity uint,
+) error {
+	weight := m.txnState.ExecutionParameters().ComputationWeights()[kind]
+	if kind == common.ComputationKindStatement {
+		m.callStack.meterStatements(weight * uint64(intensity))
+	} else {
+		m.callStack.record(kind.String(), false, weight*uint64(intensity))
+	}
+
+	return m.Meter.MeterComputation(kind, intensity)
+}
+
+func (m *profilingMeter) MeterMemory(usage common.MemoryUsage) error {
+	weight := m.txnState.ExecutionParameters().MemoryWeights()[usage.Kind]
+	m.callStack.record(usage.Kind.String(), true, weight*usage.Amount)
+
+	return m.Meter.MeterMemory(usage)
+}
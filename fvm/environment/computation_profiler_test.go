@@ -0,0 +1,51 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/meter"
+)
+
+func TestComputationProfiler_MaxSamples(t *testing.T) {
+	profiler := NewComputationProfilerWithMaxSamples(2)
+
+	add := func(function string, kind string, value uint64) {
+		frames := []profileFrame{{location: "s.0000000000000000000000000000000000000000000000000000000000000000", function: function, line: 1}}
+		profiler.add(
+			profileSampleKey{stack: function, kind: kind},
+			func() []profileFrame { return frames },
+			value<<meter.MeterExecutionInternalPrecisionBytes)
+	}
+
+	add("a", "statement", 1)
+	add("b", "statement", 2)
+	// the usage at new call stacks is truncated
+	add("c", "statement", 3)
+	add("d", "statement", 4)
+	add("d", "loop", 5)
+	// the usage at recorded call stacks is still aggregated
+	add("a", "statement", 6)
+
+	values := make(map[string]int64)
+	for _, sample := range profiler.Profile().Sample {
+		require.Len(t, sample.Location, 1)
+		function := sample.Location[0].Line[0].Function.SystemName
+		values[function+"/"+sample.Label[profileKindLabel][0]] = sample.Value[0] / profileComputationScale
+	}
+
+	assert.Equal(t, map[string]int64{
+		"a/statement":                           7,
+		"b/statement":                           2,
+		truncatedProfileFunction + "/statement": 7,
+		truncatedProfileFunction + "/loop":      5,
+	}, values)
+
+	// the samples are dropped on reset
+	profiler.Reset()
+	add("c", "statement", 3)
+	assert.Len(t, profiler.Profile().Sample, 1)
+	assert.Equal(t, "c", profiler.Profile().Sample[0].Location[0].Line[0].Function.SystemName)
+}
@@ -61,6 +61,13 @@ type Environment interface {
 		cadence.Value,
 		error,
 	)
+	ComputeTransactionFees(
+		inclusionEffort uint64,
+		executionEffort uint64,
+	) (
+		cadence.Value,
+		error,
+	)
 
 	// AccountInfo
 	GetAccount(address flow.Address) (*flow.Account, error)
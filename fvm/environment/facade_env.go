@@ -65,6 +65,9 @@ func newFacadeEnvironment(
 	accounts := NewAccounts(txnState)
 	logger := NewProgramLogger(tracer, params.ProgramLoggerParams)
 	runtime := NewRuntime(params.RuntimeParams)
+	if runtime.callStack != nil {
+		meter = newProfilingMeter(meter, txnState, runtime.callStack)
+	}
 	chain := params.Chain
 	systemContracts := NewSystemContracts(
 		chain,
@@ -338,8 +341,11 @@ func (*facadeEnvironment) ResourceOwnerChanged(
 	// NO-OP
 }
 
-func (*facadeEnvironment) SetInterpreterSharedState(_ *interpreter.SharedState) {
-	// NO-OP
+func (env *facadeEnvironment) SetInterpreterSharedState(state *interpreter.SharedState) {
+	// the shared state is only used to attribute computation to call stacks when profiling
+	if env.Runtime.callStack != nil {
+		env.Runtime.callStack.observe(state.Config)
+	}
 }
 
 func (*facadeEnvironment) GetInterpreterSharedState() *interpreter.SharedState {
@@ -366,6 +366,36 @@ func (_m *Environment) ComputationUsed() (uint64, error) {
 	return r0, r1
 }
 
+// ComputeTransactionFees provides a mock function with given fields: inclusionEffort, executionEffort
+func (_m *Environment) ComputeTransactionFees(inclusionEffort uint64, executionEffort uint64) (cadence.Value, error) {
+	ret := _m.Called(inclusionEffort, executionEffort)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ComputeTransactionFees")
+	}
+
+	var r0 cadence.Value
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint64, uint64) (cadence.Value, error)); ok {
+		return rf(inclusionEffort, executionEffort)
+	}
+	if rf, ok := ret.Get(0).(func(uint64, uint64) cadence.Value); ok {
+		r0 = rf(inclusionEffort, executionEffort)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(cadence.Value)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint64, uint64) error); ok {
+		r1 = rf(inclusionEffort, executionEffort)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConvertedServiceEvents provides a mock function with given fields:
 func (_m *Environment) ConvertedServiceEvents() flow.ServiceEventList {
 	ret := _m.Called()
@@ -8,6 +8,10 @@ import (
 
 type RuntimeParams struct {
 	runtime.ReusableCadenceRuntimePool
+
+	// ComputationProfiler, when set, attributes the computation and memory
+	// used by procedures to their Cadence call stacks.
+	ComputationProfiler *ComputationProfiler
 }
 
 func DefaultRuntimeParams() RuntimeParams {
@@ -24,12 +28,20 @@ type Runtime struct {
 	RuntimeParams
 
 	env Environment
+
+	// callStack tracks the Cadence call stack of the procedure, when the
+	// procedure is profiled.
+	callStack *profilerCallStack
 }
 
 func NewRuntime(params RuntimeParams) *Runtime {
-	return &Runtime{
+	runtime := &Runtime{
 		RuntimeParams: params,
 	}
+	if params.ComputationProfiler != nil {
+		runtime.callStack = newProfilerCallStack(params.ComputationProfiler)
+	}
+	return runtime
 }
 
 func (runtime *Runtime) SetEnvironment(env Environment) {
@@ -37,11 +49,22 @@ func (runtime *Runtime) SetEnvironment(env Environment) {
 }
 
 func (runtime *Runtime) BorrowCadenceRuntime() *runtime.ReusableCadenceRuntime {
+	if runtime.callStack != nil {
+		// the profiler hooks into the interpreter configuration of the
+		// runtime, hence profiled runtimes are never reused.
+		runtime.callStack.enter()
+		return runtime.ReusableCadenceRuntimePool.BorrowUnpooled(runtime.env)
+	}
 	return runtime.ReusableCadenceRuntimePool.Borrow(runtime.env)
 }
 
 func (runtime *Runtime) ReturnCadenceRuntime(
 	reusable *runtime.ReusableCadenceRuntime,
 ) {
+	if runtime.callStack != nil {
+		runtime.callStack.exit()
+		reusable.SetFvmEnvironment(nil)
+		return
+	}
 	runtime.ReusableCadenceRuntimePool.Return(reusable)
 }
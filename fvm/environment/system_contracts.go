@@ -163,6 +163,31 @@ func (sys *SystemContracts) DeductTransactionFees(
 	)
 }
 
+var computeTransactionFeesSpec = ContractFunctionSpec{
+	AddressFromChain: FlowFeesAddress,
+	LocationName:     systemcontracts.ContractNameFlowFees,
+	FunctionName:     systemcontracts.ContractFlowFeesFunction_computeFees,
+	ArgumentTypes: []sema.Type{
+		sema.UFix64Type,
+		sema.UFix64Type,
+	},
+}
+
+// ComputeTransactionFees executes the fee computation function on the FlowFees
+// account, returning the fees that would be deducted for the given efforts.
+func (sys *SystemContracts) ComputeTransactionFees(
+	inclusionEffort uint64,
+	executionEffort uint64,
+) (cadence.Value, error) {
+	return sys.Invoke(
+		computeTransactionFeesSpec,
+		[]cadence.Value{
+			cadence.UFix64(inclusionEffort),
+			cadence.UFix64(executionEffort),
+		},
+	)
+}
+
 // uses `FlowServiceAccount.setupNewAccount` from https://github.com/onflow/flow-core-contracts/blob/master/contracts/FlowServiceAccount.cdc
 var setupNewAccountSpec = ContractFunctionSpec{
 	AddressFromChain: ServiceAddress,
@@ -2,6 +2,7 @@ package sync
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	"github.com/onflow/atree"
@@ -85,6 +86,75 @@ func ReplayBlockExecution(
 	return results, nil
 }
 
+// ReplayTransactionExecution re-executes the transactions of a block up to the transaction
+// at the given index, and returns the result of that transaction.
+// only the execution of that transaction is traced, the preceding transactions
+// are executed without a tracer to build the state the transaction was executed on.
+// The context is checked before each transaction, and its error is returned if it is done.
+func ReplayTransactionExecution(
+	ctx context.Context,
+	rootAddr flow.Address,
+	storage types.BackendStorage,
+	blockSnapshot types.BlockSnapshot,
+	tracer *gethTracer.Tracer,
+	transactionEvents []events.TransactionEventPayload,
+	txIndex int,
+	validateResults bool,
+) (*types.Result, error) {
+	if txIndex < 0 || txIndex >= len(transactionEvents) {
+		return nil, fmt.Errorf("transaction index %d out of range [0, %d)", txIndex, len(transactionEvents))
+	}
+
+	blockCtx, err := blockSnapshot.BlockContext()
+	if err != nil {
+		return nil, err
+	}
+
+	gasConsumedSoFar := uint64(0)
+	for idx := 0; idx < txIndex; idx++ {
+		err := ctx.Err()
+		if err != nil {
+			return nil, err
+		}
+
+		tx := transactionEvents[idx]
+		_, err = replayTransactionExecution(
+			rootAddr,
+			blockCtx,
+			uint(idx),
+			gasConsumedSoFar,
+			storage,
+			&tx,
+			validateResults,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("transaction execution failed, txIndex: %d, err: %w", idx, err)
+		}
+		gasConsumedSoFar += tx.GasConsumed
+	}
+
+	err = ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	// trace the requested transaction
+	blockCtx.Tracer = tracer
+	result, err := replayTransactionExecution(
+		rootAddr,
+		blockCtx,
+		uint(txIndex),
+		gasConsumedSoFar,
+		storage,
+		&transactionEvents[txIndex],
+		validateResults,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("transaction execution failed, txIndex: %d, err: %w", txIndex, err)
+	}
+	return result, nil
+}
+
 func replayTransactionExecution(
 	rootAddr flow.Address,
 	ctx types.BlockContext,
@@ -1,6 +1,8 @@
 package sync
 
 import (
+	"context"
+
 	gethTracers "github.com/onflow/go-ethereum/eth/tracers"
 	"github.com/rs/zerolog"
 
@@ -102,3 +104,45 @@ func (cr *Replayer) ReplayBlockEvents(
 
 	return state, results, nil
 }
+
+// ReplayTransaction replays the execution of the transactions of an EVM block
+// up to the transaction at the given index, and returns the result of that transaction.
+// only the execution of that transaction is traced with the given tracer, the tracer
+// of the replayer is not used.
+//
+// Warning! the list of transaction events has to be sorted based on their
+// execution, as for ReplayBlockEvents.
+// The replay stops before the next transaction once the context is done, and returns the context error.
+func (cr *Replayer) ReplayTransaction(
+	ctx context.Context,
+	transactionEvents []events.TransactionEventPayload,
+	blockEvent *events.BlockEventPayload,
+	txIndex int,
+	tracer *gethTracers.Tracer,
+) (*types.Result, error) {
+	// prepare storage
+	st, err := cr.storageProvider.GetSnapshotAt(blockEvent.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	// create storage
+	state := storage.NewEphemeralStorage(storage.NewReadOnlyStorage(st))
+
+	// get block snapshot
+	bs, err := cr.blockProvider.GetSnapshotAt(blockEvent.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReplayTransactionExecution(
+		ctx,
+		cr.rootAddr,
+		state,
+		bs,
+		tracer,
+		transactionEvents,
+		txIndex,
+		cr.validateResults,
+	)
+}
@@ -1,11 +1,16 @@
 package sync_test
 
 import (
+	"context"
+	"encoding/json"
 	"math"
 	"math/big"
+	"strings"
 	"testing"
 
 	gethCommon "github.com/onflow/go-ethereum/common"
+	gethTracers "github.com/onflow/go-ethereum/eth/tracers"
+	_ "github.com/onflow/go-ethereum/eth/tracers/native" // imported so callTracer is registered in init
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 
@@ -169,6 +174,32 @@ func TestChainReplay(t *testing.T) {
 
 						require.Len(t, results, totalTxCount)
 
+						// check replaying a single transaction with a tracer
+						txIndex := 2
+						tracer, err := gethTracers.DefaultDirectory.New("callTracer", &gethTracers.Context{}, nil)
+						require.NoError(t, err)
+						result, err := cr.ReplayTransaction(context.Background(), txEventPayloads, blockEventPayload, txIndex, tracer)
+						require.NoError(t, err)
+						require.Equal(t, results[txIndex].TxHash, result.TxHash)
+						require.Equal(t, results[txIndex].GasConsumed, result.GasConsumed)
+
+						trace, err := tracer.GetResult()
+						require.NoError(t, err)
+						var callFrame struct {
+							To string `json:"to"`
+						}
+						require.NoError(t, json.Unmarshal(trace, &callFrame))
+						require.Equal(t, strings.ToLower(testContract.DeployedAt.ToCommon().Hex()), callFrame.To)
+
+						_, err = cr.ReplayTransaction(context.Background(), txEventPayloads, blockEventPayload, len(txEventPayloads), nil)
+						require.Error(t, err)
+
+						// the replay stops once the context is done
+						ctx, cancel := context.WithCancel(context.Background())
+						cancel()
+						_, err = cr.ReplayTransaction(ctx, txEventPayloads, blockEventPayload, txIndex, nil)
+						require.ErrorIs(t, err, context.Canceled)
+
 						proposal := blocks.ReconstructProposal(blockEventPayload, results)
 
 						err = bp.OnBlockExecuted(blockEventPayload.Height, res, proposal)
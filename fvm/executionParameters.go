@@ -3,6 +3,7 @@ package fvm
 import (
 	"context"
 	"fmt"
+	"maps"
 	"math"
 
 	"github.com/rs/zerolog"
@@ -22,6 +23,44 @@ import (
 	"github.com/onflow/flow-go/fvm/systemcontracts"
 )
 
+// ExecutionParametersOverrides replace the execution parameters of procedures. Unset parameters are
+// not overridden.
+type ExecutionParametersOverrides struct {
+	// ComputationWeights replace the execution effort weights of the given kinds. The weights of other
+	// kinds are still read from the state.
+	ComputationWeights meter.ExecutionEffortWeights
+	// MemoryWeights replace the execution memory weights of the given kinds. The weights of other kinds
+	// are still read from the state.
+	MemoryWeights meter.ExecutionMemoryWeights
+	// ComputationLimit, if non-zero, replaces the computation limit of procedures.
+	ComputationLimit uint64
+	// MemoryLimit, if non-zero, replaces the memory limit of procedures, including the limit stored
+	// in the state.
+	MemoryLimit uint64
+}
+
+func (overrides ExecutionParametersOverrides) apply(params meter.MeterParameters) meter.MeterParameters {
+	if len(overrides.ComputationWeights) > 0 {
+		weights := make(meter.ExecutionEffortWeights, len(params.ComputationWeights()))
+		maps.Copy(weights, params.ComputationWeights())
+		maps.Copy(weights, overrides.ComputationWeights)
+		params = params.WithComputationWeights(weights)
+	}
+	if len(overrides.MemoryWeights) > 0 {
+		weights := make(meter.ExecutionMemoryWeights, len(params.MemoryWeights()))
+		maps.Copy(weights, params.MemoryWeights())
+		maps.Copy(weights, overrides.MemoryWeights)
+		params = params.WithMemoryWeights(weights)
+	}
+	if overrides.ComputationLimit > 0 {
+		params = params.WithComputationLimit(uint(overrides.ComputationLimit))
+	}
+	if overrides.MemoryLimit > 0 {
+		params = params.WithMemoryLimit(overrides.MemoryLimit)
+	}
+	return params
+}
+
 func ProcedureStateParameters(
 	ctx Context,
 	proc Procedure,
@@ -44,6 +83,7 @@ func getBasicMeterParameters(
 		WithMemoryLimit(proc.MemoryLimit(ctx)).
 		WithEventEmitByteLimit(ctx.EventCollectionByteSizeLimit).
 		WithStorageInteractionLimit(ctx.MaxStateInteractionSize)
+	params = ctx.ExecutionParametersOverrides.apply(params)
 
 	// NOTE: The memory limit (and interaction limit) may be overridden by the
 	// environment.  We need to ignore the override in that case.
@@ -88,6 +128,8 @@ func getExecutionParameters(
 		meterParams = meterParams.WithMemoryLimit(*executionParams.MemoryLimit)
 	}
 
+	meterParams = ctx.ExecutionParametersOverrides.apply(meterParams)
+
 	// NOTE: The memory limit (and interaction limit) may be overridden by the
 	// environment.  We need to ignore the override in that case.
 	if proc.ShouldDisableMemoryAndInteractionLimits(ctx) {
@@ -302,6 +302,31 @@ func GetAccountKey(
 	return accountKey, nil
 }
 
+// GetTransactionFees returns the fees, in the smallest FLOW unit, the FlowFees contract charges for the
+// given inclusion and execution efforts.
+func GetTransactionFees(
+	ctx Context,
+	inclusionEffort uint64,
+	executionEffort uint64,
+	storageSnapshot snapshot.StorageSnapshot,
+) (
+	uint64,
+	error,
+) {
+	env, _ := getScriptEnvironment(ctx, storageSnapshot)
+
+	fees, err := env.ComputeTransactionFees(inclusionEffort, executionEffort)
+	if err != nil {
+		return 0, fmt.Errorf("cannot compute transaction fees: %w", err)
+	}
+
+	value, ok := fees.(cadence.UFix64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected transaction fees type: %T", fees)
+	}
+	return uint64(value), nil
+}
+
 // Helper function to initialize common components.
 func getScriptEnvironment(
 	ctx Context,
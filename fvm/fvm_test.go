@@ -1129,6 +1129,62 @@ func TestSettingExecutionWeights(t *testing.T) {
 		},
 	))
 
+	t.Run("overridden weights replace the weights stored in the state", newVMTest().withBootstrapProcedureOptions(
+		fvm.WithMinimumStorageReservation(fvm.DefaultMinimumStorageReservation),
+		fvm.WithAccountCreationFee(fvm.DefaultAccountCreationFee),
+		fvm.WithStorageMBPerFLOW(fvm.DefaultStorageMBPerFLOW),
+		fvm.WithExecutionEffortWeights(
+			meter.ExecutionEffortWeights{
+				common.ComputationKindLoop: 100_000 << meter.MeterExecutionInternalPrecisionBytes,
+			},
+		),
+	).withContextOptions(
+		fvm.WithChain(chain),
+		fvm.WithExecutionParametersOverrides(fvm.ExecutionParametersOverrides{
+			ComputationWeights: meter.ExecutionEffortWeights{
+				common.ComputationKindLoop: 1 << meter.MeterExecutionInternalPrecisionBytes,
+			},
+		}),
+	).run(
+		func(t *testing.T, vm fvm.VM, chain flow.Chain, ctx fvm.Context, snapshotTree snapshot.SnapshotTree) {
+			txBody := flow.NewTransactionBody().
+				SetScript([]byte(`
+				transaction {
+                  prepare(signer: &Account) {
+					var a = 0
+					while a < 100 {
+						a = a + 1
+					}
+                  }
+                }
+			`)).
+				SetProposalKey(chain.ServiceAddress(), 0, 0).
+				AddAuthorizer(chain.ServiceAddress()).
+				SetPayer(chain.ServiceAddress())
+
+			err := testutil.SignTransactionAsServiceAccount(txBody, 0, chain)
+			require.NoError(t, err)
+
+			_, output, err := vm.Run(
+				ctx,
+				fvm.Transaction(txBody, 0),
+				snapshotTree)
+			require.NoError(t, err)
+			require.NoError(t, output.Err)
+
+			// the overridden computation limit applies to the transaction
+			overrides := ctx.ExecutionParametersOverrides
+			overrides.ComputationLimit = 10
+			ctx = fvm.NewContextFromParent(ctx, fvm.WithExecutionParametersOverrides(overrides))
+			_, output, err = vm.Run(
+				ctx,
+				fvm.Transaction(txBody, 0),
+				snapshotTree)
+			require.NoError(t, err)
+			require.True(t, errors.IsComputationLimitExceededError(output.Err))
+		},
+	))
+
 	memoryWeights := make(map[common.MemoryKind]uint64)
 	for k, v := range meter.DefaultMemoryWeights {
 		memoryWeights[k] = v
@@ -3520,3 +3576,73 @@ func Test_BlockHashListShouldWriteOnPush(t *testing.T) {
 			require.Equal(t, expectedBlockHashListBucket, newBlockHashListBucket)
 		}))
 }
+
+func TestComputationProfiler(t *testing.T) {
+	profiler := environment.NewComputationProfiler()
+
+	t.Run("script", newVMTest().withContextOptions(
+		fvm.WithComputationProfiler(profiler),
+	).run(
+		func(t *testing.T, vm fvm.VM, chain flow.Chain, ctx fvm.Context, snapshotTree snapshot.SnapshotTree) {
+			// drop the usage of the bootstrap procedure
+			profiler.Reset()
+
+			script := fvm.Script([]byte(`
+				access(all) fun loop(_ n: Int): Int {
+					var i = 0
+					while i < n {
+						i = i + 1
+					}
+					return i
+				}
+
+				access(all) fun main(): Int {
+					return loop(100)
+				}
+			`))
+
+			_, output, err := vm.Run(ctx, script, snapshotTree)
+			require.NoError(t, err)
+			require.NoError(t, output.Err)
+
+			prof := profiler.Flush()
+			require.NoError(t, prof.CheckValid())
+
+			// the computation of the loop is attributed to the loop function,
+			// called by the main function
+			loopComputation := int64(0)
+			for _, sample := range prof.Sample {
+				leaf := sample.Location[0].Line[0]
+				if !strings.HasSuffix(leaf.Function.Name, ".loop") {
+					continue
+				}
+				require.Len(t, sample.Location, 2)
+				require.True(t, strings.HasSuffix(sample.Location[1].Line[0].Function.Name, ".main"))
+				require.Equal(t, int64(11), sample.Location[1].Line[0].Line)
+				require.Contains(t, []int64{3, 4, 5, 7}, leaf.Line)
+
+				loopComputation += sample.Value[0]
+			}
+			require.Greater(t, loopComputation, int64(0))
+			require.LessOrEqual(t, loopComputation, int64(output.ComputationUsed*1000))
+
+			require.Empty(t, profiler.Flush().Sample)
+		}),
+	)
+
+	t.Run("disabled", newVMTest().run(
+		func(t *testing.T, vm fvm.VM, chain flow.Chain, ctx fvm.Context, snapshotTree snapshot.SnapshotTree) {
+			script := fvm.Script([]byte(`
+				access(all) fun main(): Int {
+					return 1
+				}
+			`))
+
+			_, output, err := vm.Run(ctx, script, snapshotTree)
+			require.NoError(t, err)
+			require.NoError(t, output.Err)
+
+			require.Empty(t, profiler.Flush().Sample)
+		}),
+	)
+}
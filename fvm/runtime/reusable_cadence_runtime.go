@@ -230,6 +230,11 @@ func NewCustomReusableCadenceRuntimePool(
 	)
 }
 
+// Config returns the configuration of the runtimes of the pool.
+func (pool ReusableCadenceRuntimePool) Config() runtime.Config {
+	return pool.config
+}
+
 func (pool ReusableCadenceRuntimePool) newRuntime() runtime.Runtime {
 	if pool.newCustomRuntime != nil {
 		return pool.newCustomRuntime(pool.config)
@@ -245,18 +250,33 @@ func (pool ReusableCadenceRuntimePool) Borrow(
 	case reusable = <-pool.pool:
 		// Do nothing.
 	default:
-		reusable = NewReusableCadenceRuntime(
-			WrappedCadenceRuntime{
-				pool.newRuntime(),
-			},
-			pool.config,
-		)
+		reusable = pool.newReusableRuntime()
 	}
 
 	reusable.SetFvmEnvironment(fvmEnv)
 	return reusable
 }
 
+// BorrowUnpooled returns a new runtime which is never shared with other
+// procedures. The runtime must not be returned to the pool, since its
+// interpreter configuration may be modified by the borrower.
+func (pool ReusableCadenceRuntimePool) BorrowUnpooled(
+	fvmEnv Environment,
+) *ReusableCadenceRuntime {
+	reusable := pool.newReusableRuntime()
+	reusable.SetFvmEnvironment(fvmEnv)
+	return reusable
+}
+
+func (pool ReusableCadenceRuntimePool) newReusableRuntime() *ReusableCadenceRuntime {
+	return NewReusableCadenceRuntime(
+		WrappedCadenceRuntime{
+			pool.newRuntime(),
+		},
+		pool.config,
+	)
+}
+
 func (pool ReusableCadenceRuntimePool) Return(
 	reusable *ReusableCadenceRuntime,
 ) {
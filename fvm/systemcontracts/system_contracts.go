@@ -71,6 +71,7 @@ const (
 	ContractStorageFeesFunction_calculateAccountCapacity                      = "calculateAccountCapacity"
 	ContractStorageFeesFunction_getAccountsCapacityForTransactionStorageCheck = "getAccountsCapacityForTransactionStorageCheck"
 	ContractStorageFeesFunction_defaultTokenAvailableBalance                  = "defaultTokenAvailableBalance"
+	ContractFlowFeesFunction_computeFees                                      = "computeFees"
 
 	// These are the account indexes of system contracts as deployed by the default bootstrapping.
 	// On long-running networks some of these contracts might have been deployed after bootstrapping,
@@ -59,6 +59,37 @@ type Compactor struct {
 	trieUpdateCh                         <-chan *WALTrieUpdate
 	triggerCheckpointOnNextSegmentFinish *atomic.Bool // to trigger checkpoint manually
 	metrics                              module.WALMetrics
+
+	// deltaCheckpoints is the number of delta checkpoints written between two full checkpoints,
+	// 0 means delta checkpoints are disabled.
+	deltaCheckpoints uint
+	// checkpointInUse returns true if the given checkpoint must not be removed by the cleanup,
+	// e.g. because it is being downloaded by another node. nil if no checkpoint is in use.
+	checkpointInUse func(checkpoint int) bool
+	// the following fields are only accessed by the checkpointing goroutine
+	baseCheckpointNum int         // number of the last created checkpoint
+	baseTrie          *trie.MTrie // last trie of the last created checkpoint, nil if unknown
+	deltasSinceFull   uint        // number of delta checkpoints created since the last full checkpoint
+}
+
+// CompactorOption is an option for the Compactor.
+type CompactorOption func(*Compactor)
+
+// WithDeltaCheckpoints enables delta checkpoints, which only store the trie nodes created since
+// the previous checkpoint. After the given number of delta checkpoints, a full checkpoint is created
+// to consolidate the chain of delta checkpoints. 0 disables delta checkpoints.
+func WithDeltaCheckpoints(deltaCheckpoints uint) CompactorOption {
+	return func(c *Compactor) {
+		c.deltaCheckpoints = deltaCheckpoints
+	}
+}
+
+// WithCheckpointInUse prevents the cleanup of old checkpoints from removing checkpoints for which
+// inUse returns true. These checkpoints are removed by a later cleanup once they are no longer in use.
+func WithCheckpointInUse(inUse func(checkpoint int) bool) CompactorOption {
+	return func(c *Compactor) {
+		c.checkpointInUse = inUse
+	}
 }
 
 // NewCompactor creates new Compactor which writes WAL record and triggers
@@ -79,6 +110,7 @@ func NewCompactor(
 	checkpointsToKeep uint,
 	triggerCheckpointOnNextSegmentFinish *atomic.Bool,
 	metrics module.WALMetrics,
+	opts ...CompactorOption,
 ) (*Compactor, error) {
 	if checkpointDistance < 1 {
 		checkpointDistance = 1
@@ -105,7 +137,7 @@ func NewCompactor(
 	// Create trieQueue with initial values from ledger state.
 	trieQueue := realWAL.NewTrieQueueWithValues(checkpointCapacity, tries)
 
-	return &Compactor{
+	c := &Compactor{
 		checkpointer:                         checkpointer,
 		wal:                                  w,
 		trieQueue:                            trieQueue,
@@ -118,7 +150,14 @@ func NewCompactor(
 		checkpointsToKeep:                    checkpointsToKeep,
 		triggerCheckpointOnNextSegmentFinish: triggerCheckpointOnNextSegmentFinish,
 		metrics:                              metrics,
-	}, nil
+		baseCheckpointNum:                    -1,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
 }
 
 // Subscribe subscribes observer to Compactor.
@@ -292,9 +331,25 @@ Loop:
 // Since this function is only for checkpointing, Compactor isn't affected by returned error.
 func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpointNum int) error {
 
-	err := createCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, c.metrics)
-	if err != nil {
-		return &createCheckpointError{num: checkpointNum, err: err}
+	// A delta checkpoint is only created when the base trie is known, which is not the case
+	// for the first checkpoint after startup.
+	if c.deltaCheckpoints > 0 && c.baseTrie != nil && c.deltasSinceFull < c.deltaCheckpoints {
+		err := createDeltaCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, c.baseCheckpointNum, c.baseTrie, c.metrics)
+		if err != nil {
+			return &createCheckpointError{num: checkpointNum, err: err}
+		}
+		c.deltasSinceFull++
+	} else {
+		err := createCheckpoint(c.checkpointer, c.logger, tries, checkpointNum, c.metrics)
+		if err != nil {
+			return &createCheckpointError{num: checkpointNum, err: err}
+		}
+		c.deltasSinceFull = 0
+	}
+
+	if len(tries) > 0 {
+		c.baseCheckpointNum = checkpointNum
+		c.baseTrie = tries[len(tries)-1]
 	}
 
 	// Return if context is canceled.
@@ -304,7 +359,7 @@ func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpo
 	default:
 	}
 
-	err = cleanupCheckpoints(c.checkpointer, int(c.checkpointsToKeep))
+	err := cleanupCheckpoints(c.checkpointer, int(c.checkpointsToKeep), c.checkpointInUse)
 	if err != nil {
 		return &removeCheckpointError{err: err}
 	}
@@ -354,9 +409,48 @@ func createCheckpoint(checkpointer *realWAL.Checkpointer, logger zerolog.Logger,
 	return nil
 }
 
+// createDeltaCheckpoint creates delta checkpoint with given checkpointNum and tries,
+// based on the checkpoint with given baseCheckpointNum whose last trie is baseTrie.
+// Errors indicate that checkpoint file can't be created.
+// Caller should handle returned errors by retrying checkpointing when appropriate.
+func createDeltaCheckpoint(
+	checkpointer *realWAL.Checkpointer,
+	logger zerolog.Logger,
+	tries []*trie.MTrie,
+	checkpointNum int,
+	baseCheckpointNum int,
+	baseTrie *trie.MTrie,
+	metrics module.WALMetrics,
+) error {
+
+	logger.Info().Msgf("serializing delta checkpoint %d of checkpoint %d with %v tries", checkpointNum, baseCheckpointNum, len(tries))
+
+	startTime := time.Now()
+
+	fileName := realWAL.NumberToFilename(checkpointNum)
+	err := realWAL.StoreDeltaCheckpoint(tries, baseCheckpointNum, baseTrie, checkpointer.Dir(), fileName, logger)
+	if err != nil {
+		return fmt.Errorf("error serializing delta checkpoint (%d): %w", checkpointNum, err)
+	}
+
+	size, err := realWAL.ReadCheckpointFileSize(checkpointer.Dir(), fileName)
+	if err != nil {
+		return fmt.Errorf("error reading checkpoint file size (%d): %w", checkpointNum, err)
+	}
+
+	metrics.ExecutionCheckpointSize(size)
+
+	duration := time.Since(startTime)
+	logger.Info().Float64("total_time_s", duration.Seconds()).Msgf("created delta checkpoint %d", checkpointNum)
+
+	return nil
+}
+
 // cleanupCheckpoints deletes prior checkpoint files if needed.
+// Checkpoints which are the base of a kept delta checkpoint are not deleted, nor are checkpoints
+// for which inUse returns true. inUse may be nil.
 // Since the function is side-effect free, all failures are simply a no-op.
-func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep int) error {
+func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep int, inUse func(checkpoint int) bool) error {
 	// Don't list checkpoints if we keep them all
 	if checkpointsToKeep == 0 {
 		return nil
@@ -369,7 +463,24 @@ func cleanupCheckpoints(checkpointer *realWAL.Checkpointer, checkpointsToKeep in
 		// if condition guarantees this never fails
 		checkpointsToRemove := checkpoints[:len(checkpoints)-int(checkpointsToKeep)]
 
+		required := make(map[int]struct{})
+		for _, checkpoint := range checkpoints[len(checkpoints)-int(checkpointsToKeep):] {
+			chain, err := checkpointer.CheckpointChain(checkpoint)
+			if err != nil {
+				return fmt.Errorf("cannot read chain of checkpoint %d: %w", checkpoint, err)
+			}
+			for _, base := range chain {
+				required[base] = struct{}{}
+			}
+		}
+
 		for _, checkpoint := range checkpointsToRemove {
+			if _, ok := required[checkpoint]; ok {
+				continue
+			}
+			if inUse != nil && inUse(checkpoint) {
+				continue
+			}
 			err := checkpointer.RemoveCheckpoint(checkpoint)
 			if err != nil {
 				return fmt.Errorf("cannot remove checkpoint %d: %w", checkpoint, err)
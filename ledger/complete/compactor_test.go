@@ -523,6 +523,141 @@ func TestCompactorAccuracy(t *testing.T) {
 	})
 }
 
+// TestCompactorDeltaCheckpoints tests that the compactor creates delta checkpoints between full checkpoints,
+// that it doesn't remove the base checkpoints of kept delta checkpoints, and that the checkpointed tries
+// match the tries replayed from segments.
+func TestCompactorDeltaCheckpoints(t *testing.T) {
+
+	const (
+		numInsPerStep      = 2
+		pathByteSize       = 32
+		minPayloadByteSize = 2<<11 - 256 // 3840 bytes
+		maxPayloadByteSize = 2 << 11     // 4096 bytes
+		size               = 40
+		checkpointDistance = 3
+		checkpointsToKeep  = 2
+		deltaCheckpoints   = 2
+		forestCapacity     = 500
+	)
+
+	metricsCollector := &metrics.NoopCollector{}
+
+	unittest.RunWithTempDir(t, func(dir string) {
+
+		wal, err := realWAL.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), dir, forestCapacity, pathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		l, err := NewLedger(wal, forestCapacity, metricsCollector, zerolog.Logger{}, DefaultPathFinderVersion)
+		require.NoError(t, err)
+
+		compactor, err := NewCompactor(l, wal, unittest.Logger(), forestCapacity, checkpointDistance, checkpointsToKeep, atomic.NewBool(false), metrics.NewNoopCollector(), WithDeltaCheckpoints(deltaCheckpoints))
+		require.NoError(t, err)
+
+		// wait for a full checkpoint followed by two delta checkpoints and another full checkpoint
+		co := CompactorObserver{fromBound: 4 * checkpointDistance, done: make(chan struct{})}
+		compactor.Subscribe(&co)
+
+		// Run Compactor in background.
+		<-compactor.Ready()
+
+		rootHash := trie.EmptyTrieRootHash()
+
+		// Generate the tree and create WAL
+		for i := 0; i < size+2; i++ {
+			// slow down updating the ledger, because running too fast would cause the previous checkpoint
+			// to not finish and get delayed
+			time.Sleep(LedgerUpdateDelay)
+
+			payloads := testutils.RandomPayloads(numInsPerStep, minPayloadByteSize, maxPayloadByteSize)
+
+			keys := make([]ledger.Key, len(payloads))
+			values := make([]ledger.Value, len(payloads))
+			for i, p := range payloads {
+				k, err := p.Key()
+				require.NoError(t, err)
+				keys[i] = k
+				values[i] = p.Value()
+			}
+
+			update, err := ledger.NewUpdate(ledger.State(rootHash), keys, values)
+			require.NoError(t, err)
+
+			newState, _, err := l.Set(update)
+			require.NoError(t, err)
+
+			rootHash = ledger.RootHash(newState)
+		}
+
+		// wait for the bound-checking observer to confirm checkpoints have been made
+		select {
+		case <-co.done:
+			// continue
+		case <-time.After(60 * time.Second):
+			assert.FailNow(t, "timed out")
+		}
+
+		// Shutdown ledger and compactor
+		<-l.Done()
+		<-compactor.Done()
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+
+		nums, err := checkpointer.Checkpoints()
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(nums), checkpointsToKeep)
+
+		// the base checkpoints of the kept checkpoints must not be removed
+		deltaCount := 0
+		for _, n := range nums[len(nums)-checkpointsToKeep:] {
+			chain, err := checkpointer.CheckpointChain(n)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(chain), deltaCheckpoints)
+			if len(chain) > 0 {
+				deltaCount++
+			}
+			for _, base := range chain {
+				require.Contains(t, nums, base)
+			}
+		}
+		require.Greater(t, deltaCount, 0)
+
+		for _, n := range nums {
+			testCheckpointedTriesMatchReplayedTriesFromSegments(t, checkpointer, n, dir, true)
+		}
+	})
+}
+
+// TestCleanupCheckpointsInUse tests that checkpoints which are in use are not removed by the cleanup.
+func TestCleanupCheckpointsInUse(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		wal, err := realWAL.NewDiskWAL(unittest.Logger(), nil, metrics.NewNoopCollector(), dir, 10, 32, 32*1024)
+		require.NoError(t, err)
+
+		checkpointer, err := wal.NewCheckpointer()
+		require.NoError(t, err)
+
+		for i := 1; i <= 5; i++ {
+			err := realWAL.StoreCheckpointV6SingleThread([]*trie.MTrie{trie.NewEmptyMTrie()}, dir, realWAL.NumberToFilename(i), unittest.Logger())
+			require.NoError(t, err)
+		}
+
+		inUse := func(checkpoint int) bool { return checkpoint == 2 }
+		require.NoError(t, cleanupCheckpoints(checkpointer, 2, inUse))
+
+		nums, err := checkpointer.Checkpoints()
+		require.NoError(t, err)
+		require.Equal(t, []int{2, 4, 5}, nums)
+
+		// the checkpoint is removed once it is no longer in use
+		require.NoError(t, cleanupCheckpoints(checkpointer, 2, nil))
+
+		nums, err = checkpointer.Checkpoints()
+		require.NoError(t, err)
+		require.Equal(t, []int{4, 5}, nums)
+	})
+}
+
 // TestCompactorTriggeredByAdminTool tests that the compactor will listen to the signal from admin tool
 // to trigger checkpoint when current segment file is finished.
 func TestCompactorTriggeredByAdminTool(t *testing.T) {
@@ -189,6 +189,13 @@ func (n *Node) VerifyCachedHash() bool {
 	return verifyCachedHashRecursive(n)
 }
 
+// VerifyHash verifies the hash of the node against the hash computed from its payload (leaf)
+// or from the hashes of its children (interim node). Unlike VerifyCachedHash, the hashes
+// of the children are not verified.
+func (n *Node) VerifyHash() bool {
+	return n.hashValue == n.computeHash()
+}
+
 // Hash returns the Node's hash value.
 // Do NOT MODIFY returned slice!
 func (n *Node) Hash() hash.Hash {
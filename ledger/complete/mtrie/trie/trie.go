@@ -1,6 +1,7 @@
 package trie
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -859,3 +860,95 @@ func traverseRecursive(n *node.Node, processNode func(*node.Node) error) error {
 
 	return nil
 }
+
+// DiffTries calls fn for every register whose value differs between the two tries, in ascending path order.
+// A register which is not allocated in one of the tries is passed to fn with a nil payload for that trie.
+// The tries are walked in parallel, and subtries with equal hashes are skipped, so the cost is
+// proportional to the number of changed registers rather than to the size of the tries.
+// Any error returned by fn is returned without wrapping and stops the walk.
+func DiffTries(trie1, trie2 *MTrie, fn func(path ledger.Path, payload1, payload2 *ledger.Payload) error) error {
+	return diffNodes(trie1.root, trie2.root, fn)
+}
+
+// diffNodes compares two subtries at the same height.
+func diffNodes(n1, n2 *node.Node, fn func(path ledger.Path, payload1, payload2 *ledger.Payload) error) error {
+	if n1 == nil && n2 == nil {
+		return nil
+	}
+	if n1 != nil && n2 != nil && n1.Hash() == n2.Hash() {
+		return nil
+	}
+
+	// compactified leaves can be located at any height, so once either side is a leaf
+	// (or an empty subtrie), the registers of both subtries are compared by path
+	if n1 == nil || n2 == nil || n1.IsLeaf() || n2.IsLeaf() {
+		return diffLeaves(appendLeaves(nil, n1), appendLeaves(nil, n2), fn)
+	}
+
+	err := diffNodes(n1.LeftChild(), n2.LeftChild(), fn)
+	if err != nil {
+		return err
+	}
+
+	return diffNodes(n1.RightChild(), n2.RightChild(), fn)
+}
+
+// diffLeaves compares two lists of leaves sorted by path.
+func diffLeaves(leaves1, leaves2 []*node.Node, fn func(path ledger.Path, payload1, payload2 *ledger.Payload) error) error {
+	i, j := 0, 0
+	for i < len(leaves1) || j < len(leaves2) {
+		var cmp int
+		switch {
+		case i == len(leaves1):
+			cmp = 1
+		case j == len(leaves2):
+			cmp = -1
+		default:
+			cmp = bytes.Compare(leaves1[i].Path()[:], leaves2[j].Path()[:])
+		}
+
+		var err error
+		switch {
+		case cmp < 0:
+			// leaves with empty payloads are not allocated registers, they exist in unpruned tries
+			if !leaves1[i].Payload().IsEmpty() {
+				err = fn(*leaves1[i].Path(), leaves1[i].Payload(), nil)
+			}
+			i++
+		case cmp > 0:
+			if !leaves2[j].Payload().IsEmpty() {
+				err = fn(*leaves2[j].Path(), nil, leaves2[j].Payload())
+			}
+			j++
+		default:
+			payload1, payload2 := leaves1[i].Payload(), leaves2[j].Payload()
+			if !payload1.ValueEquals(payload2) {
+				if payload1.IsEmpty() {
+					payload1 = nil
+				}
+				if payload2.IsEmpty() {
+					payload2 = nil
+				}
+				err = fn(*leaves1[i].Path(), payload1, payload2)
+			}
+			i++
+			j++
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendLeaves appends the leaves of the given subtrie to result, in ascending path order.
+func appendLeaves(result []*node.Node, n *node.Node) []*node.Node {
+	if n == nil {
+		return result
+	}
+	if n.IsLeaf() {
+		return append(result, n)
+	}
+	result = appendLeaves(result, n.LeftChild())
+	return appendLeaves(result, n.RightChild())
+}
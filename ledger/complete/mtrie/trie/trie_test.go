@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 	"math"
 	"sort"
 	"testing"
@@ -1197,3 +1198,91 @@ func TestReadSinglePayload(t *testing.T) {
 		}
 	})
 }
+
+// TestDiffTries tests that DiffTries reports exactly the registers which were updated, added or removed.
+func TestDiffTries(t *testing.T) {
+	const regCount = 1000
+
+	paths := testutils.RandomPaths(regCount)
+	payloads := testutils.RandomPayloads(regCount, 1, 100)
+	values := make([]ledger.Payload, regCount)
+	for i, p := range payloads {
+		values[i] = *p
+	}
+
+	trie1, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths[:900], values[:900], true)
+	require.NoError(t, err)
+
+	// update 10 registers, remove 10 registers, and add 100 registers
+	updatedPaths := append([]ledger.Path{}, paths[:10]...)
+	updatedValues := make([]ledger.Payload, 0, 10)
+	for _, p := range testutils.RandomPayloads(10, 1, 100) {
+		updatedValues = append(updatedValues, *p)
+	}
+	removedPaths := paths[10:20]
+	removedValues := make([]ledger.Payload, 10)
+	addedPaths := paths[900:]
+	addedValues := values[900:]
+
+	trie2, _, err := trie.NewTrieWithUpdatedRegisters(
+		trie1,
+		append(append(updatedPaths, removedPaths...), addedPaths...),
+		append(append(updatedValues, removedValues...), addedValues...),
+		true,
+	)
+	require.NoError(t, err)
+
+	type change struct {
+		payload1 *ledger.Payload
+		payload2 *ledger.Payload
+	}
+	changes := make(map[ledger.Path]change)
+	var lastPath *ledger.Path
+	err = trie.DiffTries(trie1, trie2, func(path ledger.Path, payload1, payload2 *ledger.Payload) error {
+		if lastPath != nil {
+			require.Equal(t, -1, bytes.Compare(lastPath[:], path[:]), "paths must be ascending")
+		}
+		lastPath = &path
+		changes[path] = change{payload1: payload1, payload2: payload2}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, changes, 120)
+
+	for i, path := range updatedPaths {
+		require.True(t, values[i].Equals(changes[path].payload1))
+		require.True(t, updatedValues[i].Equals(changes[path].payload2))
+	}
+	for i, path := range removedPaths {
+		require.True(t, values[10+i].Equals(changes[path].payload1))
+		require.Nil(t, changes[path].payload2)
+	}
+	for i, path := range addedPaths {
+		require.Nil(t, changes[path].payload1)
+		require.True(t, addedValues[i].Equals(changes[path].payload2))
+	}
+
+	// diffing in the other direction swaps the payloads
+	count := 0
+	err = trie.DiffTries(trie2, trie1, func(path ledger.Path, payload1, payload2 *ledger.Payload) error {
+		require.Equal(t, changes[path].payload1, payload2)
+		require.Equal(t, changes[path].payload2, payload1)
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(changes), count)
+
+	// equal tries have no changes
+	err = trie.DiffTries(trie2, trie2, func(ledger.Path, *ledger.Payload, *ledger.Payload) error {
+		return fmt.Errorf("unexpected change")
+	})
+	require.NoError(t, err)
+
+	// errors returned by the callback stop the walk
+	expectedErr := fmt.Errorf("expected error")
+	err = trie.DiffTries(trie1, trie2, func(ledger.Path, *ledger.Payload, *ledger.Payload) error {
+		return expectedErr
+	})
+	require.ErrorIs(t, err, expectedErr)
+}
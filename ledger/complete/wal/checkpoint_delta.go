@@ -0,0 +1,584 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+const (
+	encBaseCheckpointSize = 8
+	encHeightSize         = 2
+	encEntryTypeSize      = 1
+
+	deltaHeaderSize = headerSize + encBaseCheckpointSize + hash.HashLen
+	deltaFooterSize = encNodeCountSize + encNodeCountSize + encTrieCountSize // footer doesn't include crc32 sum
+)
+
+// entry types of the nodes stored in a delta checkpoint
+const (
+	deltaEntryBaseNode byte = 0 // reference to a node of the base checkpoint
+	deltaEntryNode     byte = 1 // node created since the base checkpoint
+)
+
+// ErrDeltaCheckpoint is returned by readers which only support full v6 checkpoints, such as
+// ReadTriesRootHash and the leaf node readers, when they are given a delta checkpoint.
+// Delta checkpoints can only be read with LoadCheckpoint, which resolves their base checkpoints.
+var ErrDeltaCheckpoint = errors.New("checkpoint is a delta checkpoint, which can only be loaded together with its base checkpoints")
+
+// DeltaCheckpointInfo describes a delta checkpoint file.
+type DeltaCheckpointInfo struct {
+	BaseCheckpoint int             // number of the checkpoint the delta is based on
+	BaseRootHash   ledger.RootHash // root hash of the last trie of the base checkpoint
+	NodeCount      uint64          // number of nodes stored in the delta
+	BaseNodeCount  uint64          // number of references to nodes of the base checkpoint
+	TrieCount      uint16          // number of tries stored in the delta
+}
+
+// StoreDeltaCheckpoint stores the given tries as a delta checkpoint of the base checkpoint.
+//
+// A delta checkpoint is a single file which only contains the trie nodes which are not part of
+// baseTrie, which must be the last trie of the base checkpoint. Subtries which are unchanged since
+// the base checkpoint are stored as references to the base trie, and are resolved by loading the
+// base checkpoint (which might itself be a delta checkpoint) when the delta checkpoint is loaded.
+//
+// The delta checkpoint file contains:
+//   - magic bytes and version
+//   - base checkpoint number
+//   - root hash of the base trie
+//   - nodes, each prefixed with its entry type. Nodes of the base trie are encoded as
+//     (height, path, hash), new nodes are encoded with flattener.EncodeNode
+//   - tries
+//   - footer with node count, base node count and trie count
+//   - checksum
+//
+// CAUTION: the base checkpoint must not be removed as long as the delta checkpoint is needed.
+func StoreDeltaCheckpoint(
+	tries []*trie.MTrie,
+	baseCheckpoint int,
+	baseTrie *trie.MTrie,
+	outputDir string,
+	outputFile string,
+	logger zerolog.Logger,
+) error {
+	err := storeDeltaCheckpoint(tries, baseCheckpoint, baseTrie, outputDir, outputFile, logger)
+	if err != nil {
+		cleanupErr := deleteCheckpointFiles(outputDir, outputFile)
+		if cleanupErr != nil {
+			return fmt.Errorf("fail to cleanup temp file %s, after running into error: %w", cleanupErr, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func storeDeltaCheckpoint(
+	tries []*trie.MTrie,
+	baseCheckpoint int,
+	baseTrie *trie.MTrie,
+	outputDir string,
+	outputFile string,
+	logger zerolog.Logger,
+) (errToReturn error) {
+	if len(tries) == 0 {
+		logger.Info().Msg("no tries to be checkpointed")
+		return nil
+	}
+
+	if baseCheckpoint < 0 {
+		return fmt.Errorf("invalid base checkpoint number %d", baseCheckpoint)
+	}
+
+	if len(tries) > int(^uint16(0)) {
+		return fmt.Errorf("too many tries to checkpoint: %d", len(tries))
+	}
+
+	lg := logger.With().
+		Int("trie_count", len(tries)).
+		Int("base_checkpoint", baseCheckpoint).
+		Str("checkpoint_file", path.Join(outputDir, outputFile)).
+		Logger()
+
+	lg.Info().Msg("storing delta checkpoint")
+
+	closable, err := createClosableWriter(outputDir, outputFile, logger)
+	if err != nil {
+		return fmt.Errorf("could not create writer for delta checkpoint: %w", err)
+	}
+
+	defer func() {
+		errToReturn = closeAndMergeError(closable, errToReturn)
+	}()
+
+	writer := NewCRC32Writer(closable)
+
+	_, err = writer.Write(encodeDeltaHeader(baseCheckpoint, baseTrie.RootHash()))
+	if err != nil {
+		return fmt.Errorf("cannot write delta checkpoint header: %w", err)
+	}
+
+	w := &deltaNodeWriter{
+		writer:       writer,
+		visitedNodes: make(map[*node.Node]uint64),
+		scratch:      make([]byte, 1024*4),
+	}
+
+	rootIndexes := make([]uint64, len(tries))
+	for i, t := range tries {
+		rootIndexes[i], err = w.storeNode(t.RootNode(), baseTrie.RootNode(), ledger.Path{}, 0)
+		if err != nil {
+			return fmt.Errorf("could not store nodes of trie %d: %w", i, err)
+		}
+	}
+
+	for i, t := range tries {
+		_, err = writer.Write(flattener.EncodeTrie(t, rootIndexes[i], w.scratch))
+		if err != nil {
+			return fmt.Errorf("cannot write trie %d: %w", i, err)
+		}
+	}
+
+	_, err = writer.Write(encodeDeltaFooter(w.nodeCount, w.baseNodeCount, uint16(len(tries))))
+	if err != nil {
+		return fmt.Errorf("cannot write delta checkpoint footer: %w", err)
+	}
+
+	_, err = writer.Write(encodeCRC32Sum(writer.Crc32()))
+	if err != nil {
+		return fmt.Errorf("cannot write CRC32 checksum to delta checkpoint: %w", err)
+	}
+
+	lg.Info().
+		Uint64("node_count", w.nodeCount).
+		Uint64("base_node_count", w.baseNodeCount).
+		Msg("stored delta checkpoint")
+
+	return nil
+}
+
+// deltaNodeWriter writes the nodes of tries which are not part of the base trie.
+type deltaNodeWriter struct {
+	writer        *Crc32Writer
+	visitedNodes  map[*node.Node]uint64
+	nodeCount     uint64 // number of all stored nodes, including base nodes
+	baseNodeCount uint64
+	scratch       []byte
+}
+
+// storeNode stores the subtrie rooted at n, and returns the index of n.
+// The base node is the node of the base trie at the same position as n, nil if there is none.
+// Nodes are stored in descendants-first order, and index 0 means nil.
+func (w *deltaNodeWriter) storeNode(n *node.Node, base *node.Node, nodePath ledger.Path, depth int) (uint64, error) {
+	if n == nil {
+		return 0, nil
+	}
+
+	if index, ok := w.visitedNodes[n]; ok {
+		return index, nil
+	}
+
+	if base != nil && base.Height() == n.Height() && base.Hash() == n.Hash() {
+		// the subtrie is unchanged since the base checkpoint
+		_, err := w.writer.Write(encodeDeltaBaseNode(n.Height(), nodePath, n.Hash()))
+		if err != nil {
+			return 0, fmt.Errorf("cannot write base node: %w", err)
+		}
+		w.baseNodeCount++
+		return w.visit(n), nil
+	}
+
+	var lchildIndex, rchildIndex uint64
+	if !n.IsLeaf() {
+		var baseLeft, baseRight *node.Node
+		if base != nil && !base.IsLeaf() {
+			baseLeft, baseRight = base.LeftChild(), base.RightChild()
+		}
+
+		var err error
+		lchildIndex, err = w.storeNode(n.LeftChild(), baseLeft, nodePath, depth+1)
+		if err != nil {
+			return 0, err
+		}
+
+		rightPath := nodePath
+		bitutils.SetBit(rightPath[:], depth)
+		rchildIndex, err = w.storeNode(n.RightChild(), baseRight, rightPath, depth+1)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	_, err := w.writer.Write([]byte{deltaEntryNode})
+	if err != nil {
+		return 0, fmt.Errorf("cannot write node entry type: %w", err)
+	}
+	_, err = w.writer.Write(flattener.EncodeNode(n, lchildIndex, rchildIndex, w.scratch))
+	if err != nil {
+		return 0, fmt.Errorf("cannot write node: %w", err)
+	}
+
+	return w.visit(n), nil
+}
+
+func (w *deltaNodeWriter) visit(n *node.Node) uint64 {
+	w.nodeCount++
+	w.visitedNodes[n] = w.nodeCount
+	return w.nodeCount
+}
+
+// readCheckpointDelta reads a delta checkpoint, and resolves the nodes of the base checkpoint
+// by loading the base checkpoint from the same directory.
+// Base checkpoints might themselves be delta checkpoints, in which case the whole chain
+// of checkpoints down to the full checkpoint is loaded.
+//
+// it returns (tries, nil) if there was no error
+// it returns (nil, os.ErrNotExist) if the base checkpoint is missing, use (os.IsNotExist to check)
+// it returns (nil, err) if running into any exception
+func readCheckpointDelta(f *os.File, logger zerolog.Logger) ([]*trie.MTrie, error) {
+	dir, fileName := filepath.Split(f.Name())
+
+	lg := logger.With().Str("checkpoint_file", f.Name()).Logger()
+
+	info, err := readDeltaCheckpointInfo(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// base checkpoints must be older than the delta, which also prevents cycles in the chain
+	num, err := strconv.Atoi(strings.TrimPrefix(fileName, checkpointFilenamePrefix))
+	if err == nil && info.BaseCheckpoint >= num {
+		return nil, fmt.Errorf("base checkpoint %d is not older than delta checkpoint %d", info.BaseCheckpoint, num)
+	}
+
+	lg.Info().Int("base_checkpoint", info.BaseCheckpoint).Msg("reading base of delta checkpoint")
+
+	baseTries, err := LoadCheckpoint(path.Join(dir, NumberToFilename(info.BaseCheckpoint)), logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not load base checkpoint %d: %w", info.BaseCheckpoint, err)
+	}
+	if len(baseTries) == 0 {
+		return nil, fmt.Errorf("base checkpoint %d has no tries", info.BaseCheckpoint)
+	}
+
+	baseTrie := baseTries[len(baseTries)-1]
+	if baseTrie.RootHash() != info.BaseRootHash {
+		return nil, fmt.Errorf("base checkpoint %d has root hash %v, but delta checkpoint expects %v",
+			info.BaseCheckpoint, baseTrie.RootHash(), info.BaseRootHash)
+	}
+
+	lg.Info().Msg("finish reading base checkpoint, start reading delta checkpoint")
+
+	// restart from the beginning of the file, make sure CRC32Reader has seen all the bytes
+	// in order to compute the correct checksum
+	_, err = f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("could not seek to 0: %w", err)
+	}
+
+	reader := NewCRC32Reader(bufio.NewReaderSize(f, defaultBufioReadSize))
+
+	// read header again for calculating checksum
+	_, err = io.ReadFull(reader, make([]byte, deltaHeaderSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not read delta checkpoint header: %w", err)
+	}
+
+	nodes := make([]*node.Node, info.NodeCount+1) // +1 for 0 index meaning nil
+	scratch := make([]byte, 1024*4)               // must not be less than 1024
+
+	getNode := func(nodeIndex uint64) (*node.Node, error) {
+		if nodeIndex >= uint64(len(nodes)) {
+			return nil, fmt.Errorf("node index %d out of range", nodeIndex)
+		}
+		return nodes[nodeIndex], nil
+	}
+
+	baseNodeCount := uint64(0)
+	for i := uint64(1); i <= info.NodeCount; i++ {
+		_, err := io.ReadFull(reader, scratch[:encEntryTypeSize])
+		if err != nil {
+			return nil, fmt.Errorf("cannot read entry type of node %d: %w", i, err)
+		}
+
+		switch scratch[0] {
+		case deltaEntryBaseNode:
+			nodes[i], err = readDeltaBaseNode(reader, scratch, baseTrie.RootNode())
+			baseNodeCount++
+		case deltaEntryNode:
+			nodes[i], err = flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+				if nodeIndex >= i {
+					return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
+				}
+				return getNode(nodeIndex)
+			})
+		default:
+			err = fmt.Errorf("unknown entry type %d", scratch[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read node %d: %w", i, err)
+		}
+	}
+
+	if baseNodeCount != info.BaseNodeCount {
+		return nil, fmt.Errorf("mismatch base node count, footer has %v, but read %v", info.BaseNodeCount, baseNodeCount)
+	}
+
+	tries := make([]*trie.MTrie, info.TrieCount)
+	for i := range tries {
+		tries[i], err = flattener.ReadTrie(reader, scratch, getNode)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read trie at index %d: %w", i, err)
+		}
+	}
+
+	// read footer and discard, since we only care about checksum
+	_, err = io.ReadFull(reader, scratch[:deltaFooterSize])
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	actualSum := reader.Crc32()
+
+	expectedSum, err := readCRC32Sum(reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not read checksum from delta checkpoint: %w", err)
+	}
+
+	if actualSum != expectedSum {
+		return nil, fmt.Errorf("invalid checksum in delta checkpoint, expected %v, actual %v", expectedSum, actualSum)
+	}
+
+	err = ensureReachedEOF(reader)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read delta checkpoint: %w", err)
+	}
+
+	lg.Info().
+		Uint64("node_count", info.NodeCount).
+		Uint64("base_node_count", info.BaseNodeCount).
+		Int("trie_count", len(tries)).
+		Msg("finish reading delta checkpoint")
+
+	return tries, nil
+}
+
+// readDeltaBaseNode reads a reference to a node of the base trie, and returns the referenced node.
+func readDeltaBaseNode(reader io.Reader, scratch []byte, baseRoot *node.Node) (*node.Node, error) {
+	const size = encHeightSize + ledger.PathLen + hash.HashLen
+
+	_, err := io.ReadFull(reader, scratch[:size])
+	if err != nil {
+		return nil, fmt.Errorf("cannot read base node: %w", err)
+	}
+
+	height := int(binary.BigEndian.Uint16(scratch))
+	if height > ledger.NodeMaxHeight {
+		return nil, fmt.Errorf("invalid base node height %d", height)
+	}
+
+	nodePath, err := ledger.ToPath(scratch[encHeightSize : encHeightSize+ledger.PathLen])
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode base node path: %w", err)
+	}
+
+	nodeHash, err := hash.ToHash(scratch[encHeightSize+ledger.PathLen : size])
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode base node hash: %w", err)
+	}
+
+	n := baseRoot
+	for depth := 0; depth < ledger.NodeMaxHeight-height; depth++ {
+		if n == nil || n.IsLeaf() {
+			return nil, fmt.Errorf("base trie has no node at height %d with path %v", height, nodePath)
+		}
+		if bitutils.ReadBit(nodePath[:], depth) == 0 {
+			n = n.LeftChild()
+		} else {
+			n = n.RightChild()
+		}
+	}
+
+	if n == nil || n.Height() != height {
+		return nil, fmt.Errorf("base trie has no node at height %d with path %v", height, nodePath)
+	}
+
+	if n.Hash() != nodeHash {
+		return nil, fmt.Errorf("base node at height %d with path %v has hash %v, but expected %v",
+			height, nodePath, n.Hash(), nodeHash)
+	}
+
+	return n, nil
+}
+
+// ReadDeltaCheckpointInfo returns the description of the given delta checkpoint.
+// It returns (nil, nil) if the checkpoint is a full checkpoint.
+// any error returned are exceptions
+func ReadDeltaCheckpointInfo(dir string, fileName string, logger zerolog.Logger) (
+	infoToReturn *DeltaCheckpointInfo,
+	errToReturn error,
+) {
+	errToReturn = withFile(logger, filePathCheckpointHeader(dir, fileName), func(file *os.File) error {
+		magic, version, err := readFileHeader(file)
+		if err != nil {
+			return err
+		}
+
+		if magic != MagicBytesCheckpointHeader {
+			return fmt.Errorf("wrong magic bytes, expect %#x, bot got: %#x", MagicBytesCheckpointHeader, magic)
+		}
+
+		if version != VersionDeltaV1 {
+			return nil
+		}
+
+		infoToReturn, err = readDeltaCheckpointInfo(file)
+		return err
+	})
+
+	return infoToReturn, errToReturn
+}
+
+// CheckpointChain returns the numbers of the checkpoints the given checkpoint depends on,
+// starting with its base checkpoint and ending with a full checkpoint.
+// It returns an empty list if the given checkpoint is a full checkpoint.
+// any error returned are exceptions
+func CheckpointChain(dir string, fileName string, logger zerolog.Logger) ([]int, error) {
+	var chain []int
+	for {
+		info, err := ReadDeltaCheckpointInfo(dir, fileName, logger)
+		if err != nil {
+			return nil, fmt.Errorf("could not read checkpoint %v: %w", fileName, err)
+		}
+
+		if info == nil {
+			return chain, nil
+		}
+
+		if len(chain) > 0 && info.BaseCheckpoint >= chain[len(chain)-1] {
+			return nil, fmt.Errorf("base checkpoint %d is not older than delta checkpoint %d",
+				info.BaseCheckpoint, chain[len(chain)-1])
+		}
+
+		chain = append(chain, info.BaseCheckpoint)
+		fileName = NumberToFilename(info.BaseCheckpoint)
+	}
+}
+
+// isDeltaCheckpoint returns true if the given checkpoint is a delta checkpoint.
+// any error returned are exceptions
+func isDeltaCheckpoint(dir string, fileName string) (bool, error) {
+	version, err := ReadCheckpointVersion(dir, fileName)
+	if err != nil {
+		return false, err
+	}
+
+	return version == VersionDeltaV1, nil
+}
+
+// readDeltaCheckpointInfo reads the header and footer of the given delta checkpoint file.
+func readDeltaCheckpointInfo(f *os.File) (*DeltaCheckpointInfo, error) {
+	_, err := f.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, fmt.Errorf("could not seek to 0: %w", err)
+	}
+
+	header := make([]byte, deltaHeaderSize)
+	_, err = io.ReadFull(f, header)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read delta checkpoint header: %w", err)
+	}
+
+	magic, version, err := decodeVersion(header[:headerSize])
+	if err != nil {
+		return nil, err
+	}
+	if magic != MagicBytesCheckpointHeader || version != VersionDeltaV1 {
+		return nil, fmt.Errorf("not a delta checkpoint, magic bytes %#x, version %v", magic, version)
+	}
+
+	baseCheckpoint, baseRootHash, err := decodeDeltaHeader(header[headerSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = f.Seek(-(deltaFooterSize + crc32SumSize), io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("cannot seek to footer: %w", err)
+	}
+
+	footer := make([]byte, deltaFooterSize)
+	_, err = io.ReadFull(f, footer)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read footer: %w", err)
+	}
+
+	return &DeltaCheckpointInfo{
+		BaseCheckpoint: baseCheckpoint,
+		BaseRootHash:   baseRootHash,
+		NodeCount:      binary.BigEndian.Uint64(footer),
+		BaseNodeCount:  binary.BigEndian.Uint64(footer[encNodeCountSize:]),
+		TrieCount:      binary.BigEndian.Uint16(footer[2*encNodeCountSize:]),
+	}, nil
+}
+
+func encodeDeltaHeader(baseCheckpoint int, baseRootHash ledger.RootHash) []byte {
+	header := make([]byte, 0, deltaHeaderSize)
+	header = append(header, encodeVersion(MagicBytesCheckpointHeader, VersionDeltaV1)...)
+	header = binary.BigEndian.AppendUint64(header, uint64(baseCheckpoint))
+	header = append(header, baseRootHash[:]...)
+	return header
+}
+
+// decodeDeltaHeader decodes the base checkpoint number and root hash, which follow the magic bytes and version.
+func decodeDeltaHeader(encoded []byte) (int, ledger.RootHash, error) {
+	if len(encoded) != encBaseCheckpointSize+hash.HashLen {
+		return 0, ledger.RootHash{}, fmt.Errorf("wrong delta header size, expect %v, got %v",
+			encBaseCheckpointSize+hash.HashLen, len(encoded))
+	}
+
+	baseCheckpoint := binary.BigEndian.Uint64(encoded)
+	if baseCheckpoint > uint64(^uint32(0)) {
+		return 0, ledger.RootHash{}, fmt.Errorf("invalid base checkpoint number %d", baseCheckpoint)
+	}
+
+	baseRootHash, err := ledger.ToRootHash(encoded[encBaseCheckpointSize:])
+	if err != nil {
+		return 0, ledger.RootHash{}, fmt.Errorf("could not decode base root hash: %w", err)
+	}
+
+	return int(baseCheckpoint), baseRootHash, nil
+}
+
+func encodeDeltaBaseNode(height int, nodePath ledger.Path, nodeHash hash.Hash) []byte {
+	buf := make([]byte, 0, encEntryTypeSize+encHeightSize+ledger.PathLen+hash.HashLen)
+	buf = append(buf, deltaEntryBaseNode)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(height))
+	buf = append(buf, nodePath[:]...)
+	buf = append(buf, nodeHash[:]...)
+	return buf
+}
+
+func encodeDeltaFooter(nodeCount uint64, baseNodeCount uint64, trieCount uint16) []byte {
+	footer := make([]byte, deltaFooterSize)
+	binary.BigEndian.PutUint64(footer, nodeCount)
+	binary.BigEndian.PutUint64(footer[encNodeCountSize:], baseNodeCount)
+	binary.BigEndian.PutUint16(footer[2*encNodeCountSize:], trieCount)
+	return footer
+}
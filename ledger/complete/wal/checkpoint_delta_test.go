@@ -0,0 +1,186 @@
+package wal
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestDeltaHeaderEncoding(t *testing.T) {
+	tries := createSimpleTrie(t)
+	rootHash := tries[0].RootHash()
+
+	encoded := encodeDeltaHeader(42, rootHash)
+	require.Len(t, encoded, deltaHeaderSize)
+
+	m, v, err := decodeVersion(encoded[:headerSize])
+	require.NoError(t, err)
+	require.Equal(t, MagicBytesCheckpointHeader, m)
+	require.Equal(t, VersionDeltaV1, v)
+
+	base, baseRootHash, err := decodeDeltaHeader(encoded[headerSize:])
+	require.NoError(t, err)
+	require.Equal(t, 42, base)
+	require.Equal(t, rootHash, baseRootHash)
+}
+
+func TestWriteAndReadDeltaCheckpoint(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		baseTries, newTries := tries[:len(tries)/2], tries[len(tries)/2:]
+		logger := unittest.Logger()
+
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+		require.NoError(t, StoreDeltaCheckpoint(newTries, 1, baseTries[len(baseTries)-1], dir, NumberToFilename(2), logger))
+
+		decoded, err := LoadCheckpoint(path.Join(dir, NumberToFilename(2)), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, newTries, decoded)
+
+		info, err := ReadDeltaCheckpointInfo(dir, NumberToFilename(2), logger)
+		require.NoError(t, err)
+		require.NotNil(t, info)
+		require.Equal(t, 1, info.BaseCheckpoint)
+		require.Equal(t, baseTries[len(baseTries)-1].RootHash(), info.BaseRootHash)
+		require.Equal(t, uint16(len(newTries)), info.TrieCount)
+		require.Greater(t, info.BaseNodeCount, uint64(0))
+
+		// the size of a delta checkpoint is the size of its single file
+		size, err := ReadCheckpointFileSize(dir, NumberToFilename(2))
+		require.NoError(t, err)
+		fileInfo, err := os.Stat(path.Join(dir, NumberToFilename(2)))
+		require.NoError(t, err)
+		require.Equal(t, uint64(fileInfo.Size()), size)
+
+		// full checkpoints are not delta checkpoints
+		info, err = ReadDeltaCheckpointInfo(dir, NumberToFilename(1), logger)
+		require.NoError(t, err)
+		require.Nil(t, info)
+	})
+}
+
+func TestWriteAndReadDeltaCheckpointEmptyTrie(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		baseTries := createSimpleTrie(t)
+		newTries := []*trie.MTrie{trie.NewEmptyMTrie(), baseTries[0]}
+		logger := unittest.Logger()
+
+		require.NoError(t, StoreCheckpointV6Concurrently(baseTries, dir, NumberToFilename(1), logger))
+		require.NoError(t, StoreDeltaCheckpoint(newTries, 1, baseTries[0], dir, NumberToFilename(2), logger))
+
+		decoded, err := LoadCheckpoint(path.Join(dir, NumberToFilename(2)), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, newTries, decoded)
+	})
+}
+
+// TestDeltaCheckpointChain tests that a chain of delta checkpoints is resolved down to the full checkpoint.
+func TestDeltaCheckpointChain(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		logger := unittest.Logger()
+
+		require.NoError(t, StoreCheckpointV6Concurrently(tries[:30], dir, NumberToFilename(1), logger))
+		require.NoError(t, StoreDeltaCheckpoint(tries[20:60], 1, tries[29], dir, NumberToFilename(3), logger))
+		require.NoError(t, StoreDeltaCheckpoint(tries[50:], 3, tries[59], dir, NumberToFilename(5), logger))
+
+		decoded, err := LoadCheckpoint(path.Join(dir, NumberToFilename(5)), logger)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries[50:], decoded)
+
+		chain, err := CheckpointChain(dir, NumberToFilename(5), logger)
+		require.NoError(t, err)
+		require.Equal(t, []int{3, 1}, chain)
+
+		chain, err = CheckpointChain(dir, NumberToFilename(1), logger)
+		require.NoError(t, err)
+		require.Empty(t, chain)
+
+		// removing the base of the chain makes the delta checkpoints unreadable
+		require.NoError(t, deleteCheckpointFiles(dir, NumberToFilename(1)))
+		_, err = LoadCheckpoint(path.Join(dir, NumberToFilename(5)), logger)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestDeltaCheckpointBaseMismatch(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		logger := unittest.Logger()
+
+		// the delta is based on a different trie than the last trie of the base checkpoint
+		require.NoError(t, StoreCheckpointV6Concurrently(tries[:30], dir, NumberToFilename(1), logger))
+		require.NoError(t, StoreDeltaCheckpoint(tries[30:], 1, tries[28], dir, NumberToFilename(2), logger))
+
+		_, err := LoadCheckpoint(path.Join(dir, NumberToFilename(2)), logger)
+		require.ErrorContains(t, err, "root hash")
+	})
+}
+
+func TestDeltaCheckpointBaseMustBeOlder(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createSimpleTrie(t)
+		logger := unittest.Logger()
+
+		require.NoError(t, StoreCheckpointV6Concurrently(tries, dir, NumberToFilename(2), logger))
+		require.NoError(t, StoreDeltaCheckpoint(tries, 2, tries[0], dir, NumberToFilename(1), logger))
+
+		_, err := LoadCheckpoint(path.Join(dir, NumberToFilename(1)), logger)
+		require.ErrorContains(t, err, "not older")
+	})
+}
+
+func TestDeltaCheckpointCorrupted(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		logger := unittest.Logger()
+
+		require.NoError(t, StoreCheckpointV6Concurrently(tries[:30], dir, NumberToFilename(1), logger))
+		require.NoError(t, StoreDeltaCheckpoint(tries[30:], 1, tries[29], dir, NumberToFilename(2), logger))
+
+		filePath := path.Join(dir, NumberToFilename(2))
+		f, err := os.OpenFile(filePath, os.O_RDWR, 0644)
+		require.NoError(t, err)
+		// flip a byte of the first node
+		b := make([]byte, 1)
+		_, err = f.ReadAt(b, deltaHeaderSize+encEntryTypeSize+4)
+		require.NoError(t, err)
+		b[0] ^= 0xff
+		_, err = f.WriteAt(b, deltaHeaderSize+encEntryTypeSize+4)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+
+		_, err = LoadCheckpoint(filePath, logger)
+		require.Error(t, err)
+	})
+}
+
+func TestDeltaCheckpointRejectedByV6Readers(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		logger := unittest.Logger()
+
+		require.NoError(t, StoreCheckpointV6Concurrently(tries[:30], dir, NumberToFilename(1), logger))
+		require.NoError(t, StoreDeltaCheckpoint(tries[30:31], 1, tries[29], dir, NumberToFilename(2), logger))
+		fileName := NumberToFilename(2)
+
+		_, err := ReadTriesRootHash(logger, dir, fileName)
+		require.ErrorIs(t, err, ErrDeltaCheckpoint)
+
+		_, err = ReadCheckpointFileChecksums(dir, fileName, logger)
+		require.ErrorIs(t, err, ErrDeltaCheckpoint)
+
+		leafNodes := make(chan *LeafNode, 1)
+		err = OpenAndReadLeafNodesFromCheckpointV6(leafNodes, dir, fileName, tries[30].RootHash(), logger)
+		require.ErrorIs(t, err, ErrDeltaCheckpoint)
+
+		err = OpenAndReadLeafNodesFromCheckpointV6Concurrently(dir, fileName, tries[30].RootHash(), 1, logger,
+			func(int, <-chan *LeafNode) error { return nil })
+		require.ErrorIs(t, err, ErrDeltaCheckpoint)
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/hash"
@@ -30,6 +31,8 @@ func nodeToLeaf(leaf *node.Node) *LeafNode {
 // the given checkpoint file specified by dir and fileName.
 // It returns when finish reading the checkpoint file and the input channel can be closed.
 // It requires the checkpoint file only has one trie.
+// It returns ErrDeltaCheckpoint if the checkpoint is a delta checkpoint, since its leaf nodes are
+// partly stored in its base checkpoints.
 func OpenAndReadLeafNodesFromCheckpointV6(
 	allLeafNodesCh chan<- *LeafNode,
 	dir string,
@@ -80,6 +83,69 @@ func OpenAndReadLeafNodesFromCheckpointV6(
 	return nil
 }
 
+// OpenAndReadLeafNodesFromCheckpointV6Concurrently reads the leaf nodes of the given checkpoint file,
+// reading up to `workers` subtrie part files at the same time.
+// For each part file, processLeafNodes is called with the index of the part and a channel receiving
+// the leaf nodes of that part. The channel is closed once the part has been read. processLeafNodes is
+// called concurrently for different parts, and must consume the channel until it is closed or return an error.
+// It requires the checkpoint file only has one trie.
+// It returns ErrDeltaCheckpoint if the checkpoint is a delta checkpoint.
+func OpenAndReadLeafNodesFromCheckpointV6Concurrently(
+	dir string,
+	fileName string,
+	expectedRootHash ledger.RootHash,
+	workers int,
+	logger zerolog.Logger,
+	processLeafNodes func(part int, leafNodes <-chan *LeafNode) error,
+) error {
+	err := checkpointHasSingleRootHash(logger, dir, fileName, expectedRootHash)
+	if err != nil {
+		return fmt.Errorf("fail to check checkpoint has single root hash: %w", err)
+	}
+
+	subtrieChecksums, _, err := readCheckpointHeader(filePathCheckpointHeader(dir, fileName), logger)
+	if err != nil {
+		return fmt.Errorf("could not read header: %w", err)
+	}
+
+	err = allPartFileExist(dir, fileName, len(subtrieChecksums))
+	if err != nil {
+		return fmt.Errorf("fail to check all checkpoint part file exist: %w", err)
+	}
+
+	g := errgroup.Group{}
+	g.SetLimit(workers)
+	for i, checksum := range subtrieChecksums {
+		g.Go(func() error {
+			leafNodesCh := make(chan *LeafNode, 1000)
+
+			processErr := make(chan error, 1)
+			go func() {
+				err := processLeafNodes(i, leafNodesCh)
+				// drain the channel, so that reading the part is not blocked if processing stopped early
+				for range leafNodesCh {
+				}
+				processErr <- err
+			}()
+
+			err := readCheckpointSubTrieLeafNodes(leafNodesCh, dir, fileName, i, checksum, logger)
+			close(leafNodesCh)
+
+			// wait for the processing of the part to finish, even if reading failed
+			perr := <-processErr
+			if err != nil {
+				return fmt.Errorf("fail to read checkpoint leaf nodes from %v-th subtrie file: %w", i, err)
+			}
+			if perr != nil {
+				return fmt.Errorf("fail to process checkpoint leaf nodes from %v-th subtrie file: %w", i, perr)
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
 func readCheckpointSubTrieLeafNodes(leafNodesCh chan<- *LeafNode, dir string, fileName string, index int, checksum uint32, logger zerolog.Logger) error {
 	return processCheckpointSubTrie(dir, fileName, index, checksum, logger,
 		func(reader *Crc32Reader, nodesCount uint64) error {
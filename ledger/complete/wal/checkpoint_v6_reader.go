@@ -20,6 +20,9 @@ import (
 // ErrEOFNotReached for indicating end of file not reached error
 var ErrEOFNotReached = errors.New("expect to reach EOF, but actually didn't")
 
+// ReadTriesRootHash returns the root hashes of the tries stored in the given v6 checkpoint,
+// after validating the checksums of the checkpoint files.
+// It returns ErrDeltaCheckpoint if the checkpoint is a delta checkpoint.
 func ReadTriesRootHash(logger zerolog.Logger, dir string, fileName string) (
 	[]ledger.RootHash,
 	error,
@@ -119,6 +122,16 @@ func OpenAndReadCheckpointV6(dir string, fileName string, logger zerolog.Logger)
 // ReadCheckpointFileSize returns the total size of the checkpoint file
 func ReadCheckpointFileSize(dir string, fileName string) (uint64, error) {
 	paths := allFilePaths(dir, fileName)
+
+	isDelta, err := isDeltaCheckpoint(dir, fileName)
+	if err != nil {
+		return 0, fmt.Errorf("could not read checkpoint version: %w", err)
+	}
+	if isDelta {
+		// delta checkpoints are stored in a single file
+		paths = []string{filePathCheckpointHeader(dir, fileName)}
+	}
+
 	totalSize := uint64(0)
 	for _, path := range paths {
 		fileInfo, err := os.Stat(path)
@@ -132,6 +145,122 @@ func ReadCheckpointFileSize(dir string, fileName string) (uint64, error) {
 	return totalSize, nil
 }
 
+// ReadCheckpointVersion returns the version of the given checkpoint.
+// any error returned are exceptions
+func ReadCheckpointVersion(dir string, fileName string) (_ uint16, errToReturn error) {
+	file, err := os.Open(filePathCheckpointHeader(dir, fileName))
+	if err != nil {
+		return 0, fmt.Errorf("could not open checkpoint file %v: %w", fileName, err)
+	}
+	defer func() {
+		errToReturn = closeAndMergeError(file, errToReturn)
+	}()
+
+	magic, version, err := readFileHeader(file)
+	if err != nil {
+		return 0, err
+	}
+
+	if magic != MagicBytesCheckpointHeader {
+		return 0, fmt.Errorf("wrong magic bytes, expect %#x, bot got: %#x", MagicBytesCheckpointHeader, magic)
+	}
+
+	return version, nil
+}
+
+// CheckpointFileNames returns the names of all the files of the given v6 checkpoint,
+// starting with the header file, followed by the subtrie part files and the top trie part file.
+func CheckpointFileNames(fileName string) []string {
+	names := make([]string, 0, 1+subtrieCount+1)
+	names = append(names, fileName)
+	for i := 0; i <= subtrieCount; i++ {
+		names = append(names, partFileName(fileName, i))
+	}
+	return names
+}
+
+// ReadCheckpointFileChecksums returns the checksums of all the files of the given v6 checkpoint,
+// in the same order as CheckpointFileNames. The checksums of the part files are read from the
+// checkpoint header, the checksum of the header file is read from the end of the header file.
+// any error returned are exceptions
+func ReadCheckpointFileChecksums(dir string, fileName string, logger zerolog.Logger) ([]uint32, error) {
+	headerPath := filePathCheckpointHeader(dir, fileName)
+
+	// the header checksum is validated when reading the header
+	subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(headerPath, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not read header: %w", err)
+	}
+
+	if len(subtrieChecksums) != subtrieCount {
+		return nil, fmt.Errorf("unexpected subtrie count %d, expected %d", len(subtrieChecksums), subtrieCount)
+	}
+
+	var headerChecksum uint32
+	err = withFile(logger, headerPath, func(file *os.File) error {
+		_, err := file.Seek(-crc32SumSize, io.SeekEnd)
+		if err != nil {
+			return fmt.Errorf("cannot seek to header checksum: %w", err)
+		}
+		headerChecksum, err = readCRC32Sum(file)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read header checksum: %w", err)
+	}
+
+	checksums := make([]uint32, 0, 1+subtrieCount+1)
+	checksums = append(checksums, headerChecksum)
+	checksums = append(checksums, subtrieChecksums...)
+	checksums = append(checksums, topTrieChecksum)
+
+	return checksums, nil
+}
+
+// ReadCheckpointFileChecksum computes the checksum of the given v6 checkpoint file (header or part file),
+// and ensures it matches the checksum stored at the end of the file.
+// It returns the checksum and the size of the file.
+// any error returned are exceptions
+func ReadCheckpointFileChecksum(filePath string) (_ uint32, _ uint64, errToReturn error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not open file %v: %w", filePath, err)
+	}
+	defer func() {
+		errToReturn = closeAndMergeError(file, errToReturn)
+	}()
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not get file info for %v: %w", filePath, err)
+	}
+
+	size := fileInfo.Size()
+	if size < crc32SumSize {
+		return 0, 0, fmt.Errorf("file %v is too small to contain a checksum: %d bytes", filePath, size)
+	}
+
+	reader := bufio.NewReaderSize(file, defaultBufioReadSize)
+
+	writer := NewCRC32Writer(io.Discard)
+	_, err = io.CopyN(writer, reader, size-crc32SumSize)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not read file %v: %w", filePath, err)
+	}
+
+	expectedSum, err := readCRC32Sum(reader)
+	if err != nil {
+		return 0, 0, fmt.Errorf("could not read checksum of file %v: %w", filePath, err)
+	}
+
+	actualSum := writer.Crc32()
+	if actualSum != expectedSum {
+		return 0, 0, fmt.Errorf("invalid checksum of file %v, expected %v, actual %v", filePath, expectedSum, actualSum)
+	}
+
+	return actualSum, uint64(size), nil
+}
+
 func allFilePaths(dir string, fileName string) []string {
 	paths := make([]string, 0, 1+subtrieCount+1)
 	paths = append(paths, filePathCheckpointHeader(dir, fileName))
@@ -193,10 +322,19 @@ func readCheckpointHeader(filepath string, logger zerolog.Logger) (
 	var bufReader io.Reader = bufio.NewReaderSize(closable, defaultBufioReadSize)
 	reader := NewCRC32Reader(bufReader)
 	// read the magic bytes and check version
-	err = validateFileHeader(MagicBytesCheckpointHeader, VersionV6, reader)
+	magic, version, err := readFileHeader(reader)
 	if err != nil {
 		return nil, 0, err
 	}
+	if magic != MagicBytesCheckpointHeader {
+		return nil, 0, fmt.Errorf("wrong magic bytes, expect %#x, bot got: %#x", MagicBytesCheckpointHeader, magic)
+	}
+	if version == VersionDeltaV1 {
+		return nil, 0, fmt.Errorf("cannot read %v: %w", filepath, ErrDeltaCheckpoint)
+	}
+	if version != VersionV6 {
+		return nil, 0, fmt.Errorf("wrong version, expect %v, bot got: %v", VersionV6, version)
+	}
 
 	// read the subtrie count
 	subtrieCount, err := readSubtrieCount(reader)
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -435,6 +436,61 @@ func TestWriteAndReadCheckpointV6LeafMultipleTriesOK(t *testing.T) {
 	})
 }
 
+func TestWriteAndReadCheckpointV6LeafConcurrently(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		fileName := "checkpoint-multi-leaf-file"
+		_, last := createMultipleRandomTriesMini(t)
+
+		tries := []*trie.MTrie{last}
+
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+
+		allPayloads := tries[0].AllPayloads()
+		payloadMap := make(map[string]ledger.Payload, len(allPayloads))
+		for _, payload := range allPayloads {
+			payloadMap[hex.EncodeToString(payload.EncodedKey())] = *payload
+		}
+
+		var mu sync.Mutex
+		parts := make(map[int]struct{})
+		err := OpenAndReadLeafNodesFromCheckpointV6Concurrently(dir, fileName, tries[0].RootHash(), 4, logger,
+			func(part int, leafNodes <-chan *LeafNode) error {
+				mu.Lock()
+				defer mu.Unlock()
+				parts[part] = struct{}{}
+
+				for leafNode := range leafNodes {
+					// avoid dummy payload from empty trie
+					if leafNode.Payload == nil {
+						continue
+					}
+					key := hex.EncodeToString(leafNode.Payload.EncodedKey())
+					expected, ok := payloadMap[key]
+					if !ok {
+						return fmt.Errorf("payload not found")
+					}
+					if !expected.Equals(leafNode.Payload) {
+						return fmt.Errorf("payload not equal")
+					}
+					delete(payloadMap, key)
+				}
+				return nil
+			})
+		require.NoError(t, err)
+		require.Len(t, parts, subtrieCount)
+		require.Empty(t, payloadMap, fmt.Sprintf("not all payloads are read: %v", len(payloadMap)))
+
+		// errors returned while processing a part are returned
+		expectedErr := fmt.Errorf("expected error")
+		err = OpenAndReadLeafNodesFromCheckpointV6Concurrently(dir, fileName, tries[0].RootHash(), 4, logger,
+			func(int, <-chan *LeafNode) error {
+				return expectedErr
+			})
+		require.ErrorIs(t, err, expectedErr)
+	})
+}
+
 // compareFiles takes two files' full path, and read them bytes by bytes and compare if
 // the two files are identical
 // it returns nil if identical
@@ -730,3 +786,27 @@ func TestCheckpointHasRootHash(t *testing.T) {
 		require.Error(t, CheckpointHasRootHash(logger, dir, fileName, nonExist))
 	})
 }
+
+func TestReadCheckpointFileChecksums(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createSimpleTrie(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger), "fail to store checkpoint")
+
+		checksums, err := ReadCheckpointFileChecksums(dir, fileName, logger)
+		require.NoError(t, err)
+
+		names := CheckpointFileNames(fileName)
+		require.Len(t, checksums, len(names))
+		for i, name := range names {
+			checksum, size, err := ReadCheckpointFileChecksum(path.Join(dir, name))
+			require.NoError(t, err)
+			require.Equal(t, checksums[i], checksum, name)
+
+			fileInfo, err := os.Stat(path.Join(dir, name))
+			require.NoError(t, err)
+			require.Equal(t, uint64(fileInfo.Size()), size)
+		}
+	})
+}
@@ -0,0 +1,459 @@
+package wal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	prometheusWAL "github.com/onflow/wal/wal"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/bitutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+// CorruptSubtrie is a part of a checkpoint which failed verification.
+// The subtrie contains all the paths which share the first (ledger.NodeMaxHeight - Height) bits with Path.
+type CorruptSubtrie struct {
+	File   string      // path of the checkpoint file containing the subtrie
+	Path   ledger.Path // path of the subtrie root, only the bits above Height are meaningful
+	Height int         // height of the subtrie root, ledger.NodeMaxHeight if the whole trie is affected
+	Err    error
+}
+
+// PathPrefix returns the bits shared by all the paths of the subtrie, as a string of '0' and '1'.
+func (s CorruptSubtrie) PathPrefix() string {
+	var b strings.Builder
+	for i := 0; i < ledger.NodeMaxHeight-s.Height; i++ {
+		b.WriteByte(byte('0' + bitutils.ReadBit(s.Path[:], i)))
+	}
+	return b.String()
+}
+
+func (s CorruptSubtrie) String() string {
+	return fmt.Sprintf("subtrie at height %d with path prefix %q in %s: %v", s.Height, s.PathPrefix(), s.File, s.Err)
+}
+
+// CheckpointVerificationResult is the result of verifying a checkpoint.
+type CheckpointVerificationResult struct {
+	// RootHashes are the root hashes of the tries of the checkpoint,
+	// empty if the top level tries could not be read.
+	RootHashes []ledger.RootHash
+	// NodeCount is the number of nodes whose hash was verified.
+	NodeCount uint64
+	// Corrupted are the subtries which failed verification.
+	Corrupted []CorruptSubtrie
+}
+
+// IsCorrupted returns true if any part of the checkpoint failed verification.
+func (r *CheckpointVerificationResult) IsCorrupted() bool {
+	return len(r.Corrupted) > 0
+}
+
+// VerifyCheckpoint verifies the integrity of the given checkpoint.
+//
+// For v6 checkpoints, the checksum of every part file is validated, and the hashes of all the trie
+// nodes are recomputed bottom-up and compared with the stored hashes, up to the stored root hashes
+// of the tries. Corrupt part files and nodes are reported as the subtries they contain, so that the
+// corrupt subtries of a part can be located while the remaining parts are still verified.
+// Checkpoints of other versions are loaded and verified as a whole.
+//
+// It returns os.ErrNotExist if the checkpoint doesn't exist (use os.IsNotExist to check),
+// any other error returned is an exception.
+func VerifyCheckpoint(dir string, fileName string, logger zerolog.Logger) (*CheckpointVerificationResult, error) {
+	return VerifyCheckpointWithHeader(filePathCheckpointHeader(dir, fileName), dir, fileName, logger)
+}
+
+// VerifyCheckpointWithHeader verifies the integrity of the given checkpoint like VerifyCheckpoint,
+// reading the header file from headerPath instead of its location in dir. This allows verifying a
+// checkpoint before its header is moved into place, such as a downloaded checkpoint.
+//
+// It returns os.ErrNotExist if the header file doesn't exist (use os.IsNotExist to check),
+// any other error returned is an exception.
+func VerifyCheckpointWithHeader(headerPath string, dir string, fileName string, logger zerolog.Logger) (*CheckpointVerificationResult, error) {
+	_, err := os.Stat(headerPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not find checkpoint file %v: %w", headerPath, err)
+	}
+
+	version, err := ReadCheckpointVersion(filepath.Dir(headerPath), filepath.Base(headerPath))
+	if err != nil {
+		return &CheckpointVerificationResult{
+			Corrupted: []CorruptSubtrie{wholeTrie(headerPath, err)},
+		}, nil
+	}
+
+	if version == VersionV6 {
+		return verifyCheckpointV6(headerPath, dir, fileName, logger)
+	}
+
+	result := &CheckpointVerificationResult{}
+	tries, err := LoadCheckpoint(headerPath, logger)
+	if err != nil {
+		result.Corrupted = append(result.Corrupted, wholeTrie(headerPath, err))
+		return result, nil
+	}
+
+	verifier := newNodeHashVerifier(headerPath)
+	for _, t := range tries {
+		verifier.verify(t.RootNode(), ledger.DummyPath, 0)
+		result.RootHashes = append(result.RootHashes, t.RootHash())
+	}
+	result.NodeCount = uint64(len(verifier.visited))
+	result.Corrupted = verifier.corrupted
+	return result, nil
+}
+
+// VerifyCheckpointChecksums verifies the checksums of the files of the given checkpoint, without
+// decoding the trie nodes. The files are streamed one at a time, so the memory used doesn't depend
+// on the size of the checkpoint, which makes it suitable for verifying checkpoints on a running node.
+// Unlike VerifyCheckpoint, it can't detect nodes whose stored hash doesn't match their content, if
+// the node was already corrupt when the checkpoint was written.
+//
+// For v6 checkpoints, each corrupt part file is reported as the subtrie it contains, and the root
+// hashes of the tries are read from the top level part file if its checksum is valid. For other
+// versions, only the checksum of the checkpoint file is verified, and no root hashes are returned.
+// The returned NodeCount is always 0.
+//
+// It returns os.ErrNotExist if the checkpoint doesn't exist (use os.IsNotExist to check),
+// any other error returned is an exception.
+func VerifyCheckpointChecksums(dir string, fileName string, logger zerolog.Logger) (*CheckpointVerificationResult, error) {
+	headerPath := filePathCheckpointHeader(dir, fileName)
+	_, err := os.Stat(headerPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not find checkpoint file %v: %w", headerPath, err)
+	}
+
+	result := &CheckpointVerificationResult{}
+
+	version, err := ReadCheckpointVersion(dir, fileName)
+	if err != nil {
+		result.Corrupted = append(result.Corrupted, wholeTrie(headerPath, err))
+		return result, nil
+	}
+
+	if version != VersionV6 {
+		_, _, err = ReadCheckpointFileChecksum(headerPath)
+		if err != nil {
+			result.Corrupted = append(result.Corrupted, wholeTrie(headerPath, err))
+		}
+		return result, nil
+	}
+
+	lg := logger.With().Str("checkpoint_file", headerPath).Logger()
+	lg.Info().Msgf("verifying checksums of v6 checkpoint file")
+
+	subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(headerPath, logger)
+	if err != nil {
+		result.Corrupted = append(result.Corrupted, wholeTrie(headerPath, fmt.Errorf("could not read header: %w", err)))
+		return result, nil
+	}
+
+	if len(subtrieChecksums) != subtrieCount {
+		result.Corrupted = append(result.Corrupted, wholeTrie(headerPath,
+			fmt.Errorf("unexpected subtrie count %d, expected %d", len(subtrieChecksums), subtrieCount)))
+		return result, nil
+	}
+
+	subtrieHeight := ledger.NodeMaxHeight - subtrieLevel
+	for i, expected := range subtrieChecksums {
+		subtriePath, _, _ := filePathSubTries(dir, fileName, i)
+		err := verifyPartFileChecksum(subtriePath, expected)
+		if err != nil {
+			result.Corrupted = append(result.Corrupted, CorruptSubtrie{
+				File:   subtriePath,
+				Path:   subtriePathPrefix(i),
+				Height: subtrieHeight,
+				Err:    err,
+			})
+			continue
+		}
+
+		lg.Info().Msgf("verified checksum of %v-th subtrie part", i)
+	}
+
+	topTriePath, _ := filePathTopTries(dir, fileName)
+	err = verifyPartFileChecksum(topTriePath, topTrieChecksum)
+	if err != nil {
+		result.Corrupted = append(result.Corrupted, wholeTrie(topTriePath, err))
+		return result, nil
+	}
+
+	result.RootHashes, err = readTriesRootHash(logger, dir, fileName)
+	if err != nil {
+		result.Corrupted = append(result.Corrupted, wholeTrie(topTriePath, err))
+		return result, nil
+	}
+
+	lg.Info().
+		Int("trie_count", len(result.RootHashes)).
+		Int("corrupted", len(result.Corrupted)).
+		Msgf("finished verifying checksums of v6 checkpoint file")
+
+	return result, nil
+}
+
+// verifyPartFileChecksum verifies that the checksum of the given part file is valid, and matches the
+// checksum stored in the checkpoint header.
+func verifyPartFileChecksum(filePath string, expected uint32) error {
+	checksum, _, err := ReadCheckpointFileChecksum(filePath)
+	if err != nil {
+		return err
+	}
+	if checksum != expected {
+		return fmt.Errorf("mismatch checksum of file %v, header file has %v, part file has %v",
+			filePath, expected, checksum)
+	}
+	return nil
+}
+
+func verifyCheckpointV6(headerPath string, dir string, fileName string, logger zerolog.Logger) (*CheckpointVerificationResult, error) {
+	lg := logger.With().Str("checkpoint_file", headerPath).Logger()
+	lg.Info().Msgf("verifying v6 checkpoint file")
+
+	result := &CheckpointVerificationResult{}
+
+	subtrieChecksums, topTrieChecksum, err := readCheckpointHeader(headerPath, logger)
+	if err != nil {
+		result.Corrupted = append(result.Corrupted, wholeTrie(headerPath, fmt.Errorf("could not read header: %w", err)))
+		return result, nil
+	}
+
+	if len(subtrieChecksums) != subtrieCount {
+		result.Corrupted = append(result.Corrupted, wholeTrie(headerPath,
+			fmt.Errorf("unexpected subtrie count %d, expected %d", len(subtrieChecksums), subtrieCount)))
+		return result, nil
+	}
+
+	// read all the subtrie parts, each part is read even if other parts are corrupt
+	subtrieNodes := make([][]*node.Node, subtrieCount)
+	subtrieErrs := make([]error, subtrieCount)
+	var g errgroup.Group
+	for i, checksum := range subtrieChecksums {
+		g.Go(func() error {
+			subtrieNodes[i], subtrieErrs[i] = readCheckpointSubTrie(dir, fileName, i, checksum, lg)
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	// the nodes of the i-th part are the nodes below the i-th node at subtrieLevel,
+	// verify the subtries of the parts which could be read
+	subtrieHeight := ledger.NodeMaxHeight - subtrieLevel
+	var verifiedNodes map[*node.Node]struct{}
+	allSubtriesRead := true
+	for i := 0; i < subtrieCount; i++ {
+		subtriePath, _, _ := filePathSubTries(dir, fileName, i)
+		verifier := newNodeHashVerifier(subtriePath)
+		verifier.visited = verifiedNodes
+
+		prefix := subtriePathPrefix(i)
+		if subtrieErrs[i] != nil {
+			allSubtriesRead = false
+			result.Corrupted = append(result.Corrupted, CorruptSubtrie{
+				File:   subtriePath,
+				Path:   prefix,
+				Height: subtrieHeight,
+				Err:    subtrieErrs[i],
+			})
+			continue
+		}
+
+		for _, n := range subtrieNodes[i] {
+			if n.Height() == subtrieHeight {
+				verifier.verify(n, prefix, subtrieLevel)
+			}
+		}
+		verifiedNodes = verifier.visited
+		result.Corrupted = append(result.Corrupted, verifier.corrupted...)
+
+		lg.Info().Msgf("verified %v-th subtrie part with %d nodes", i, len(subtrieNodes[i]))
+	}
+	result.NodeCount = uint64(len(verifiedNodes))
+
+	topTriePath, _ := filePathTopTries(dir, fileName)
+	if !allSubtriesRead {
+		// the top level nodes reference the nodes of all the parts, so they can only be read
+		// if all the parts could be read, validate the checksum of the top level part instead
+		checksum, _, err := ReadCheckpointFileChecksum(topTriePath)
+		if err == nil && checksum != topTrieChecksum {
+			err = fmt.Errorf("mismatch top trie checksum, header file has %v, toptrie file has %v",
+				topTrieChecksum, checksum)
+		}
+		if err != nil {
+			result.Corrupted = append(result.Corrupted, wholeTrie(topTriePath, err))
+		}
+		return result, nil
+	}
+
+	// reading the top level tries validates that the stored root hashes match the hashes of the root nodes
+	tries, err := readTopLevelTries(dir, fileName, subtrieNodes, topTrieChecksum, lg)
+	if err != nil {
+		result.Corrupted = append(result.Corrupted, wholeTrie(topTriePath, err))
+		return result, nil
+	}
+
+	verifier := newNodeHashVerifier(topTriePath)
+	verifier.visited = verifiedNodes
+	verifier.stopHeight = subtrieHeight
+	for _, t := range tries {
+		verifier.verify(t.RootNode(), ledger.DummyPath, 0)
+		result.RootHashes = append(result.RootHashes, t.RootHash())
+	}
+	result.NodeCount = uint64(len(verifier.visited))
+	result.Corrupted = append(result.Corrupted, verifier.corrupted...)
+
+	lg.Info().
+		Uint64("node_count", result.NodeCount).
+		Int("trie_count", len(tries)).
+		Int("corrupted", len(result.Corrupted)).
+		Msgf("finished verifying v6 checkpoint file")
+
+	return result, nil
+}
+
+// nodeHashVerifier recomputes the hashes of trie nodes bottom-up, and records the nodes whose
+// stored hash doesn't match the hash computed from their payload or the hashes of their children.
+// Since the hash of a node is computed from the stored hashes of its children, only the corrupt
+// node itself is recorded, and not its ancestors.
+type nodeHashVerifier struct {
+	file       string
+	visited    map[*node.Node]struct{} // nodes shared by multiple tries are only verified once
+	stopHeight int                     // nodes at this height were verified already
+	corrupted  []CorruptSubtrie
+}
+
+func newNodeHashVerifier(file string) *nodeHashVerifier {
+	return &nodeHashVerifier{
+		file:       file,
+		stopHeight: -1,
+	}
+}
+
+// verify verifies the subtrie rooted at n, which is located at the given path and depth.
+func (v *nodeHashVerifier) verify(n *node.Node, path ledger.Path, depth int) {
+	if n == nil || n.Height() == v.stopHeight {
+		return
+	}
+	if v.visited == nil {
+		v.visited = make(map[*node.Node]struct{})
+	}
+	if _, ok := v.visited[n]; ok {
+		return
+	}
+
+	if !n.IsLeaf() {
+		v.verify(n.LeftChild(), path, depth+1)
+
+		rightPath := path
+		bitutils.SetBit(rightPath[:], depth)
+		v.verify(n.RightChild(), rightPath, depth+1)
+	} else if n.Payload() != nil {
+		path = *n.Path()
+	}
+
+	if !n.VerifyHash() {
+		v.corrupted = append(v.corrupted, CorruptSubtrie{
+			File:   v.file,
+			Path:   path,
+			Height: n.Height(),
+			Err:    fmt.Errorf("stored hash %v of node doesn't match computed hash", n.Hash()),
+		})
+	}
+	v.visited[n] = struct{}{}
+}
+
+// subtriePathPrefix returns the path of the index-th node at subtrieLevel.
+func subtriePathPrefix(index int) ledger.Path {
+	var path ledger.Path
+	for i := 0; i < subtrieLevel; i++ {
+		if index&(1<<(subtrieLevel-1-i)) != 0 {
+			bitutils.SetBit(path[:], i)
+		}
+	}
+	return path
+}
+
+func wholeTrie(file string, err error) CorruptSubtrie {
+	return CorruptSubtrie{
+		File:   file,
+		Height: ledger.NodeMaxHeight,
+		Err:    err,
+	}
+}
+
+// CheckpointRebuildSource returns the number of the checkpoint from which the given checkpoint can be
+// rebuilt by replaying the WAL segments following it, or -1 if it is rebuilt from the root checkpoint
+// (or from scratch). It returns false if the WAL segments needed to rebuild the checkpoint are missing.
+// The previous checkpoint is assumed to be valid, if it is not, rebuilding the checkpoint will fall back
+// to an earlier checkpoint, and might need more segments.
+// any error returned are exceptions
+func CheckpointRebuildSource(dir string, checkpoint int) (int, bool, error) {
+	checkpoints, err := Checkpoints(dir)
+	if err != nil {
+		return 0, false, err
+	}
+
+	previous := -1
+	for _, c := range checkpoints {
+		if c < checkpoint && c > previous {
+			previous = c
+		}
+	}
+
+	first, last, err := prometheusWAL.Segments(dir)
+	if err != nil {
+		return 0, false, fmt.Errorf("cannot get range of segments: %w", err)
+	}
+
+	if first == -1 || first > previous+1 || last < checkpoint {
+		return previous, false, nil
+	}
+
+	return previous, true, nil
+}
+
+// RepairCheckpoint rebuilds the given checkpoint from the previous checkpoint and the WAL segments
+// following it. The files of the corrupt checkpoint are moved into backupDir before rebuilding it.
+// any error returned are exceptions
+func RepairCheckpoint(w *DiskWAL, checkpoint int, forestCapacity int, backupDir string) error {
+	previous, ok, err := CheckpointRebuildSource(w.dir, checkpoint)
+	if err != nil {
+		return fmt.Errorf("could not find checkpoint to rebuild from: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("could not rebuild checkpoint %d from checkpoint %d: WAL segments %d to %d are not available",
+			checkpoint, previous, previous+1, checkpoint)
+	}
+
+	err = os.MkdirAll(backupDir, 0755)
+	if err != nil {
+		return fmt.Errorf("could not create backup directory: %w", err)
+	}
+
+	fileName := NumberToFilename(checkpoint)
+	files, err := findCheckpointPartFiles(w.dir, fileName)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		err = os.Rename(file, filepath.Join(backupDir, filepath.Base(file)))
+		if err != nil {
+			return fmt.Errorf("could not move checkpoint file %v into backup directory: %w", file, err)
+		}
+	}
+
+	w.log.Info().Msgf("moved %d files of checkpoint %d into %v, rebuilding it from checkpoint %d and segments %d to %d",
+		len(files), checkpoint, backupDir, previous, previous+1, checkpoint)
+
+	checkpointer := NewCheckpointer(w, 0, forestCapacity)
+	err = checkpointer.buildCheckpoint(checkpoint)
+	if err != nil {
+		return fmt.Errorf("could not rebuild checkpoint %d: %w", checkpoint, err)
+	}
+
+	return nil
+}
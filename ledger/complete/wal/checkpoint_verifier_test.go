@@ -0,0 +1,219 @@
+package wal
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// flipByte modifies the byte in the middle of the given file.
+func flipByte(t *testing.T, filePath string) {
+	data, err := os.ReadFile(filePath)
+	require.NoError(t, err)
+	data[len(data)/2]++
+	require.NoError(t, os.WriteFile(filePath, data, 0644))
+}
+
+func TestVerifyCheckpoint(t *testing.T) {
+	tries := createMultipleRandomTries(t)
+	fileName := "checkpoint-verify"
+	logger := unittest.Logger()
+
+	storeCheckpoint := func(t *testing.T, dir string) {
+		require.NoError(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger))
+	}
+
+	t.Run("valid checkpoint", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			storeCheckpoint(t, dir)
+
+			result, err := VerifyCheckpoint(dir, fileName, logger)
+			require.NoError(t, err)
+			require.False(t, result.IsCorrupted(), "%v", result.Corrupted)
+			require.Len(t, result.RootHashes, len(tries))
+			for i, tr := range tries {
+				require.Equal(t, tr.RootHash(), result.RootHashes[i])
+			}
+			require.Greater(t, result.NodeCount, uint64(0))
+		})
+	})
+
+	t.Run("missing checkpoint", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			_, err := VerifyCheckpoint(dir, fileName, logger)
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	})
+
+	t.Run("corrupt subtrie part", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			storeCheckpoint(t, dir)
+			partPath, _, err := filePathSubTries(dir, fileName, 3)
+			require.NoError(t, err)
+			flipByte(t, partPath)
+
+			result, err := VerifyCheckpoint(dir, fileName, logger)
+			require.NoError(t, err)
+			require.True(t, result.IsCorrupted())
+			require.Len(t, result.Corrupted, 1)
+			require.Equal(t, partPath, result.Corrupted[0].File)
+			require.Equal(t, ledger.NodeMaxHeight-subtrieLevel, result.Corrupted[0].Height)
+			require.Equal(t, "0011", result.Corrupted[0].PathPrefix())
+			require.Empty(t, result.RootHashes)
+		})
+	})
+
+	t.Run("corrupt top level part", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			storeCheckpoint(t, dir)
+			topPath, _ := filePathTopTries(dir, fileName)
+			flipByte(t, topPath)
+
+			result, err := VerifyCheckpoint(dir, fileName, logger)
+			require.NoError(t, err)
+			require.Len(t, result.Corrupted, 1)
+			require.Equal(t, topPath, result.Corrupted[0].File)
+			require.Equal(t, ledger.NodeMaxHeight, result.Corrupted[0].Height)
+			require.Equal(t, "", result.Corrupted[0].PathPrefix())
+		})
+	})
+
+	t.Run("corrupt header", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			storeCheckpoint(t, dir)
+			headerPath := filePathCheckpointHeader(dir, fileName)
+			flipByte(t, headerPath)
+
+			result, err := VerifyCheckpoint(dir, fileName, logger)
+			require.NoError(t, err)
+			require.Len(t, result.Corrupted, 1)
+			require.Equal(t, headerPath, result.Corrupted[0].File)
+		})
+	})
+}
+
+func TestVerifyCheckpointChecksums(t *testing.T) {
+	tries := createMultipleRandomTries(t)
+	fileName := "checkpoint-verify"
+	logger := unittest.Logger()
+
+	storeCheckpoint := func(t *testing.T, dir string) {
+		require.NoError(t, StoreCheckpointV6Concurrently(tries, dir, fileName, logger))
+	}
+
+	t.Run("valid checkpoint", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			storeCheckpoint(t, dir)
+
+			result, err := VerifyCheckpointChecksums(dir, fileName, logger)
+			require.NoError(t, err)
+			require.False(t, result.IsCorrupted(), "%v", result.Corrupted)
+			require.Len(t, result.RootHashes, len(tries))
+			for i, tr := range tries {
+				require.Equal(t, tr.RootHash(), result.RootHashes[i])
+			}
+			require.Equal(t, uint64(0), result.NodeCount)
+		})
+	})
+
+	t.Run("missing checkpoint", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			_, err := VerifyCheckpointChecksums(dir, fileName, logger)
+			require.ErrorIs(t, err, os.ErrNotExist)
+		})
+	})
+
+	t.Run("corrupt subtrie parts", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			storeCheckpoint(t, dir)
+			partPath3, _, err := filePathSubTries(dir, fileName, 3)
+			require.NoError(t, err)
+			flipByte(t, partPath3)
+			partPath9, _, err := filePathSubTries(dir, fileName, 9)
+			require.NoError(t, err)
+			require.NoError(t, os.Remove(partPath9))
+
+			// all the parts are verified, and the root hashes are still read from the top level part
+			result, err := VerifyCheckpointChecksums(dir, fileName, logger)
+			require.NoError(t, err)
+			require.Len(t, result.Corrupted, 2)
+			require.Equal(t, partPath3, result.Corrupted[0].File)
+			require.Equal(t, ledger.NodeMaxHeight-subtrieLevel, result.Corrupted[0].Height)
+			require.Equal(t, "0011", result.Corrupted[0].PathPrefix())
+			require.Equal(t, partPath9, result.Corrupted[1].File)
+			require.Equal(t, "1001", result.Corrupted[1].PathPrefix())
+			require.Len(t, result.RootHashes, len(tries))
+		})
+	})
+
+	t.Run("corrupt top level part", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			storeCheckpoint(t, dir)
+			topPath, _ := filePathTopTries(dir, fileName)
+			flipByte(t, topPath)
+
+			result, err := VerifyCheckpointChecksums(dir, fileName, logger)
+			require.NoError(t, err)
+			require.Len(t, result.Corrupted, 1)
+			require.Equal(t, topPath, result.Corrupted[0].File)
+			require.Equal(t, ledger.NodeMaxHeight, result.Corrupted[0].Height)
+			require.Empty(t, result.RootHashes)
+		})
+	})
+
+	t.Run("corrupt header", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			storeCheckpoint(t, dir)
+			headerPath := filePathCheckpointHeader(dir, fileName)
+			flipByte(t, headerPath)
+
+			result, err := VerifyCheckpointChecksums(dir, fileName, logger)
+			require.NoError(t, err)
+			require.Len(t, result.Corrupted, 1)
+			require.Equal(t, headerPath, result.Corrupted[0].File)
+		})
+	})
+}
+
+// TestVerifyCheckpointNodeHash tests that a node with a stored hash not matching its payload is
+// reported, even if the checksums of the checkpoint files are valid.
+func TestVerifyCheckpointNodeHash(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+
+		// a leaf at height 250 below the first node at subtrieLevel, with a wrong hash
+		path := testutils.PathByUint8(1)
+		payload := testutils.LightPayload8(1, 1)
+		leaf := node.NewNode(250, nil, nil, path, payload, hash.Hash{1, 2, 3})
+
+		n := leaf
+		for height := 251; height <= ledger.NodeMaxHeight; height++ {
+			n = node.NewInterimNode(height, n, nil)
+		}
+		tr, err := trie.NewMTrie(n, 1, uint64(payload.Size()))
+		require.NoError(t, err)
+
+		require.NoError(t, StoreCheckpointV6Concurrently([]*trie.MTrie{tr}, dir, "checkpoint", logger))
+
+		result, err := VerifyCheckpoint(dir, "checkpoint", logger)
+		require.NoError(t, err)
+		require.Len(t, result.Corrupted, 1)
+
+		corrupted := result.Corrupted[0]
+		partPath, _, err := filePathSubTries(dir, "checkpoint", 0)
+		require.NoError(t, err)
+		require.Equal(t, partPath, corrupted.File)
+		require.Equal(t, 250, corrupted.Height)
+		require.Equal(t, path, corrupted.Path)
+		require.Equal(t, "000000", corrupted.PathPrefix())
+		require.Equal(t, []ledger.RootHash{tr.RootHash()}, result.RootHashes)
+	})
+}
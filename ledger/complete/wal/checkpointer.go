@@ -60,6 +60,11 @@ const VersionV5 uint16 = 0x05
 //     file name extension
 const VersionV6 uint16 = 0x06
 
+// VersionDeltaV1 is the version of delta checkpoints, which only contain the trie nodes
+// created since a base checkpoint. See StoreDeltaCheckpoint() for more details.
+// Delta checkpoints are versioned separately from full checkpoints.
+const VersionDeltaV1 uint16 = 0x0101
+
 // MaxVersion is the latest checkpoint version we support.
 // Need to update MaxVersion when creating a newer version.
 const MaxVersion = VersionV6
@@ -219,6 +224,12 @@ func (c *Checkpointer) Checkpoint(to int) (err error) {
 		return fmt.Errorf("no segments to checkpoint to %d, latests not checkpointed segment: %d", to, notCheckpointedTo)
 	}
 
+	return c.buildCheckpoint(to)
+}
+
+// buildCheckpoint creates the checkpoint for the given segment by replaying the WAL
+// on top of the latest checkpoint before it.
+func (c *Checkpointer) buildCheckpoint(to int) error {
 	forest, err := mtrie.NewForest(c.forestCapacity, &metrics.NoopCollector{}, nil)
 	if err != nil {
 		return fmt.Errorf("cannot create Forest: %w", err)
@@ -277,6 +288,19 @@ func NumberToFilename(n int) string {
 	return fmt.Sprintf("%s%s", checkpointFilenamePrefix, NumberToFilenamePart(n))
 }
 
+// FilenameToNumber returns the number of the checkpoint with the given file name,
+// or false if the file is not a numbered checkpoint, such as the root checkpoint.
+func FilenameToNumber(fileName string) (int, bool) {
+	if !strings.HasPrefix(fileName, checkpointFilenamePrefix) {
+		return 0, false
+	}
+	n, err := strconv.Atoi(fileName[len(checkpointFilenamePrefix):])
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
 func (c *Checkpointer) CheckpointWriter(to int) (io.WriteCloser, error) {
 	return CreateCheckpointWriterForFile(c.dir, NumberToFilename(to), c.wal.log)
 }
@@ -640,6 +664,12 @@ func HasRootCheckpoint(dir string) (bool, error) {
 	}
 }
 
+// CheckpointChain returns the numbers of the checkpoints the given checkpoint depends on.
+// See CheckpointChain for more details.
+func (c *Checkpointer) CheckpointChain(checkpoint int) ([]int, error) {
+	return CheckpointChain(c.dir, NumberToFilename(checkpoint), c.wal.log)
+}
+
 func (c *Checkpointer) RemoveCheckpoint(checkpoint int) error {
 	name := NumberToFilename(checkpoint)
 	return deleteCheckpointFiles(c.dir, name)
@@ -697,6 +727,8 @@ func readCheckpoint(f *os.File, logger zerolog.Logger) ([]*trie.MTrie, error) {
 		return readCheckpointV5(f, logger)
 	case VersionV6:
 		return readCheckpointV6(f, logger)
+	case VersionDeltaV1:
+		return readCheckpointDelta(f, logger)
 	default:
 		return nil, fmt.Errorf("unsupported file version %x", version)
 	}
@@ -581,3 +581,86 @@ func (wc *writeCloserWithErrors) Write(p []byte) (n int, err error) {
 func (wc *writeCloserWithErrors) Close() error {
 	return wc.closeError
 }
+
+func TestRepairCheckpoint(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		logger := unittest.Logger()
+		const capacity = 100
+
+		// write enough updates to create multiple segments
+		w, err := realWAL.NewDiskWAL(logger, nil, metrics.NewNoopCollector(), dir, capacity, pathfinder.PathByteSize, 32*1024)
+		require.NoError(t, err)
+
+		forest, err := mtrie.NewForest(capacity, metrics.NewNoopCollector(), nil)
+		require.NoError(t, err)
+		rootHash := forest.GetEmptyRootHash()
+		for i := 0; i < 10; i++ {
+			keys := testutils.RandomUniqueKeys(2, 2, 10, 20)
+			values := testutils.RandomValues(2, 16*1024, 32*1024)
+			update, err := ledger.NewUpdate(ledger.State(rootHash), keys, values)
+			require.NoError(t, err)
+			trieUpdate, err := pathfinder.UpdateToTrieUpdate(update, pathFinderVersion)
+			require.NoError(t, err)
+
+			_, _, err = w.RecordUpdate(trieUpdate)
+			require.NoError(t, err)
+			rootHash, err = forest.Update(trieUpdate)
+			require.NoError(t, err)
+		}
+		<-w.Done()
+		require.FileExists(t, path.Join(dir, "00000005"))
+
+		w, err = realWAL.NewDiskWAL(logger, nil, metrics.NewNoopCollector(), dir, capacity, pathfinder.PathByteSize, 32*1024)
+		require.NoError(t, err)
+		defer func() {
+			<-w.Done()
+		}()
+
+		checkpointer, err := w.NewCheckpointer()
+		require.NoError(t, err)
+		require.NoError(t, checkpointer.Checkpoint(2))
+		require.NoError(t, checkpointer.Checkpoint(5))
+
+		expected, err := checkpointer.LoadCheckpoint(5)
+		require.NoError(t, err)
+
+		fileNames := realWAL.CheckpointFileNames(realWAL.NumberToFilename(5))
+		topPath := path.Join(dir, fileNames[len(fileNames)-1])
+		data, err := os.ReadFile(topPath)
+		require.NoError(t, err)
+		data[len(data)/2]++
+		require.NoError(t, os.WriteFile(topPath, data, 0644))
+
+		result, err := realWAL.VerifyCheckpoint(dir, realWAL.NumberToFilename(5), logger)
+		require.NoError(t, err)
+		require.True(t, result.IsCorrupted())
+
+		previous, ok, err := realWAL.CheckpointRebuildSource(dir, 5)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, 2, previous)
+
+		// segments following the last segment don't exist yet
+		_, ok, err = realWAL.CheckpointRebuildSource(dir, 1000)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		backupDir := path.Join(dir, "backup")
+		require.NoError(t, realWAL.RepairCheckpoint(w, 5, capacity, backupDir))
+
+		backups, err := os.ReadDir(backupDir)
+		require.NoError(t, err)
+		require.Len(t, backups, len(fileNames))
+
+		result, err = realWAL.VerifyCheckpoint(dir, realWAL.NumberToFilename(5), logger)
+		require.NoError(t, err)
+		require.False(t, result.IsCorrupted(), "%v", result.Corrupted)
+
+		repaired, err := checkpointer.LoadCheckpoint(5)
+		require.NoError(t, err)
+		require.Len(t, repaired, len(expected))
+		for i := range expected {
+			require.True(t, expected[i].Equals(repaired[i]))
+		}
+	})
+}
@@ -0,0 +1,278 @@
+package events
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/encoding/ccf"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// FieldOperator is the comparison a FieldPredicate applies to an event field.
+type FieldOperator string
+
+const (
+	// FieldOperatorEqual matches if the field is equal to the single predicate value.
+	FieldOperatorEqual FieldOperator = "eq"
+	// FieldOperatorIn matches if the field is equal to any of the predicate values.
+	FieldOperatorIn FieldOperator = "in"
+	// FieldOperatorGreaterOrEqual matches if the numeric field is greater than or equal to the predicate value.
+	FieldOperatorGreaterOrEqual FieldOperator = "gte"
+	// FieldOperatorLessOrEqual matches if the numeric field is less than or equal to the predicate value.
+	FieldOperatorLessOrEqual FieldOperator = "lte"
+)
+
+// FieldPredicate is a condition on a single field of a CCF encoded event payload.
+//
+// Values are compared against the textual representation of the field. String fields are compared
+// without quotes, address fields are compared as addresses (with or without 0x prefix), and optional
+// fields are compared using their inner value. Range operators only match numeric fields, including
+// fixed point values such as UFix64.
+type FieldPredicate struct {
+	Field    string
+	Operator FieldOperator
+	Values   []string
+}
+
+// Query describes a set of events across event types and contracts, optionally narrowed down by
+// predicates on the decoded event fields.
+//
+// Each of the event types, addresses and contracts is a filter which is only applied if set. An event
+// matches the query if it matches one of the values of every set filter, and all field predicates.
+type Query struct {
+	EventTypes      map[flow.EventType]struct{}
+	Addresses       map[string]struct{}
+	Contracts       map[string]struct{}
+	FieldPredicates []FieldPredicate
+
+	// numeric contains the parsed bound of each range predicate, indexed like FieldPredicates.
+	numeric []*big.Rat
+}
+
+// NewQuery validates the provided criteria and returns a Query for them.
+//
+// Expected errors during normal operations:
+//   - an error if any of the event types, addresses, contracts or predicates is invalid
+func NewQuery(
+	chain flow.Chain,
+	eventTypes []string,
+	addresses []string,
+	contracts []string,
+	predicates []FieldPredicate,
+) (*Query, error) {
+	q := &Query{
+		EventTypes:      make(map[flow.EventType]struct{}, len(eventTypes)),
+		Addresses:       make(map[string]struct{}, len(addresses)),
+		Contracts:       make(map[string]struct{}, len(contracts)),
+		FieldPredicates: predicates,
+		numeric:         make([]*big.Rat, len(predicates)),
+	}
+
+	for _, event := range eventTypes {
+		eventType := flow.EventType(event)
+		if _, err := ValidateEvent(eventType, chain); err != nil {
+			return nil, fmt.Errorf("invalid event type %s: %w", eventType, err)
+		}
+		q.EventTypes[eventType] = struct{}{}
+	}
+
+	for _, address := range addresses {
+		addr := flow.HexToAddress(address)
+		if !chain.IsValid(addr) {
+			return nil, fmt.Errorf("invalid address for chain: %s", address)
+		}
+		// use the parsed address to make sure it will match the event address string exactly
+		q.Addresses[addr.String()] = struct{}{}
+	}
+
+	for _, contract := range contracts {
+		parts := strings.Split(contract, ".")
+		if contract != "flow" && (len(parts) != 3 || parts[0] != "A") {
+			return nil, fmt.Errorf("invalid contract: %s", contract)
+		}
+		q.Contracts[contract] = struct{}{}
+	}
+
+	for i, predicate := range predicates {
+		if predicate.Field == "" {
+			return nil, fmt.Errorf("field predicate %d: field name must be provided", i)
+		}
+
+		switch predicate.Operator {
+		case FieldOperatorEqual, FieldOperatorGreaterOrEqual, FieldOperatorLessOrEqual:
+			if len(predicate.Values) != 1 {
+				return nil, fmt.Errorf("field predicate %d: operator %s requires exactly one value", i, predicate.Operator)
+			}
+		case FieldOperatorIn:
+			if len(predicate.Values) == 0 {
+				return nil, fmt.Errorf("field predicate %d: operator %s requires at least one value", i, predicate.Operator)
+			}
+		default:
+			return nil, fmt.Errorf("field predicate %d: unsupported operator %q", i, predicate.Operator)
+		}
+
+		if predicate.Operator == FieldOperatorGreaterOrEqual || predicate.Operator == FieldOperatorLessOrEqual {
+			bound, ok := new(big.Rat).SetString(predicate.Values[0])
+			if !ok {
+				return nil, fmt.Errorf("field predicate %d: value %q is not a number", i, predicate.Values[0])
+			}
+			q.numeric[i] = bound
+		}
+	}
+
+	return q, nil
+}
+
+// Match returns true if the event matches the query.
+// Field predicates are evaluated against the CCF encoded payload, events that cannot be decoded never
+// match a query with field predicates.
+func (q *Query) Match(event flow.Event) bool {
+	if !q.matchType(event.Type) {
+		return false
+	}
+
+	if len(q.FieldPredicates) == 0 {
+		return true
+	}
+
+	fields, err := decodeEventFields(event.Payload)
+	if err != nil {
+		return false
+	}
+
+	for i, predicate := range q.FieldPredicates {
+		value, ok := fields[predicate.Field]
+		if !ok {
+			return false
+		}
+		if !q.matchPredicate(i, value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchType returns true if the event type matches the event types, addresses and contracts filters
+// of the query. Filters which were not provided match all events.
+func (q *Query) matchType(eventType flow.EventType) bool {
+	if len(q.EventTypes) > 0 {
+		if _, ok := q.EventTypes[eventType]; !ok {
+			return false
+		}
+	}
+
+	if len(q.Addresses) == 0 && len(q.Contracts) == 0 {
+		return true
+	}
+
+	parsed, err := ParseEvent(eventType)
+	if err != nil {
+		return false
+	}
+
+	if len(q.Contracts) > 0 {
+		if _, ok := q.Contracts[parsed.Contract]; !ok {
+			return false
+		}
+	}
+
+	if len(q.Addresses) > 0 {
+		// protocol events are not emitted by an account, so they never match an address filter
+		if parsed.Type != AccountEventType {
+			return false
+		}
+		if _, ok := q.Addresses[parsed.Address]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchPredicate returns true if the value satisfies the i-th field predicate.
+func (q *Query) matchPredicate(i int, value cadence.Value) bool {
+	// compare optionals by their inner value. a nil optional does not match any predicate
+	for {
+		optional, ok := value.(cadence.Optional)
+		if !ok {
+			break
+		}
+		if optional.Value == nil {
+			return false
+		}
+		value = optional.Value
+	}
+
+	predicate := q.FieldPredicates[i]
+	switch predicate.Operator {
+	case FieldOperatorEqual, FieldOperatorIn:
+		for _, expected := range predicate.Values {
+			if fieldValueEquals(value, expected) {
+				return true
+			}
+		}
+		return false
+
+	case FieldOperatorGreaterOrEqual, FieldOperatorLessOrEqual:
+		if _, ok := value.(cadence.NumberValue); !ok {
+			return false
+		}
+		actual, ok := new(big.Rat).SetString(value.String())
+		if !ok {
+			return false
+		}
+		cmp := actual.Cmp(q.numeric[i])
+		if predicate.Operator == FieldOperatorGreaterOrEqual {
+			return cmp >= 0
+		}
+		return cmp <= 0
+	}
+
+	return false
+}
+
+// fieldValueEquals returns true if the cadence value is equal to the textual expected value.
+func fieldValueEquals(value cadence.Value, expected string) bool {
+	switch v := value.(type) {
+	case cadence.String:
+		return string(v) == expected
+	case cadence.Address:
+		return flow.Address(v) == flow.HexToAddress(expected)
+	case cadence.NumberValue:
+		// compare numerically so that e.g. "1.0" matches the UFix64 value 1.00000000
+		actual, ok := new(big.Rat).SetString(v.String())
+		if !ok {
+			return false
+		}
+		target, ok := new(big.Rat).SetString(expected)
+		if !ok {
+			return false
+		}
+		return actual.Cmp(target) == 0
+	default:
+		return value.String() == expected
+	}
+}
+
+// decodeEventFields decodes a CCF encoded event payload and returns its fields by name.
+func decodeEventFields(payload []byte) (map[string]cadence.Value, error) {
+	data, err := ccf.Decode(nil, payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode event payload: %w", err)
+	}
+
+	cdcEvent, ok := data.(cadence.Event)
+	if !ok {
+		return nil, fmt.Errorf("payload is not an event: %T", data)
+	}
+
+	fields := cadence.FieldsMappedByName(cdcEvent)
+	if fields == nil {
+		return nil, fmt.Errorf("fields are empty")
+	}
+	return fields, nil
+}
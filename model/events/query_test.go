@@ -0,0 +1,240 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/common"
+	"github.com/onflow/cadence/encoding/ccf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/events"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// depositedEvent returns a CCF encoded FlowToken.TokensDeposited event with the given amount and receiver.
+func depositedEvent(t *testing.T, contract flow.Address, amount string, to *flow.Address) flow.Event {
+	ufix, err := cadence.NewUFix64(amount)
+	require.NoError(t, err)
+
+	receiver := cadence.NewOptional(nil)
+	if to != nil {
+		receiver = cadence.NewOptional(cadence.NewAddress(*to))
+	}
+
+	location := common.AddressLocation{Address: common.Address(contract), Name: "FlowToken"}
+	cadenceEvent := cadence.NewEvent([]cadence.Value{ufix, receiver}).
+		WithType(cadence.NewEventType(
+			location,
+			"FlowToken.TokensDeposited",
+			[]cadence.Field{
+				{Identifier: "amount", Type: cadence.UFix64Type},
+				{Identifier: "to", Type: cadence.NewOptionalType(cadence.AddressType)},
+			},
+			nil,
+		))
+
+	payload, err := ccf.Encode(cadenceEvent)
+	require.NoError(t, err)
+
+	event := unittest.EventFixture(
+		flow.EventType(location.TypeID(nil, "FlowToken.TokensDeposited")),
+		0,
+		0,
+		unittest.IdentifierFixture(),
+		0,
+	)
+	event.Payload = payload
+
+	return event
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.Testnet.Chain()
+	contract := chain.ServiceAddress()
+	receiver := unittest.RandomAddressFixtureForChain(flow.Testnet)
+	other := unittest.RandomAddressFixtureForChain(flow.Testnet)
+
+	small := depositedEvent(t, contract, "1.5", &receiver)
+	large := depositedEvent(t, contract, "250.0", &receiver)
+	toOther := depositedEvent(t, contract, "10.0", &other)
+	toNobody := depositedEvent(t, contract, "10.0", nil)
+	created := unittest.EventFixture("flow.AccountCreated", 0, 0, unittest.IdentifierFixture(), 0)
+
+	depositedType := string(small.Type)
+	all := flow.EventsList{small, large, toOther, toNobody, created}
+
+	tests := []struct {
+		name       string
+		eventTypes []string
+		addresses  []string
+		contracts  []string
+		predicates []events.FieldPredicate
+		expected   flow.EventsList
+	}{
+		{
+			name:     "no criteria matches all events",
+			expected: all,
+		},
+		{
+			name:       "event type",
+			eventTypes: []string{depositedType},
+			expected:   flow.EventsList{small, large, toOther, toNobody},
+		},
+		{
+			name:       "multiple event types",
+			eventTypes: []string{depositedType, "flow.AccountCreated"},
+			expected:   all,
+		},
+		{
+			name:      "contract address",
+			addresses: []string{contract.Hex()},
+			expected:  flow.EventsList{small, large, toOther, toNobody},
+		},
+		{
+			name:      "contract",
+			contracts: []string{"flow"},
+			expected:  flow.EventsList{created},
+		},
+		{
+			name:       "event type and contract intersect",
+			eventTypes: []string{depositedType},
+			contracts:  []string{"flow"},
+			expected:   flow.EventsList{},
+		},
+		{
+			name:      "address and contract intersect",
+			addresses: []string{contract.Hex()},
+			contracts: []string{"A." + contract.Hex() + ".FlowToken", "flow"},
+			expected:  flow.EventsList{small, large, toOther, toNobody},
+		},
+		{
+			name:       "event type and address intersect",
+			eventTypes: []string{depositedType, "flow.AccountCreated"},
+			addresses:  []string{other.Hex()},
+			expected:   flow.EventsList{},
+		},
+		{
+			name:       "address equality",
+			eventTypes: []string{depositedType},
+			predicates: []events.FieldPredicate{
+				{Field: "to", Operator: events.FieldOperatorEqual, Values: []string{receiver.HexWithPrefix()}},
+			},
+			expected: flow.EventsList{small, large},
+		},
+		{
+			name:       "address membership",
+			eventTypes: []string{depositedType},
+			predicates: []events.FieldPredicate{
+				{Field: "to", Operator: events.FieldOperatorIn, Values: []string{receiver.Hex(), other.Hex()}},
+			},
+			expected: flow.EventsList{small, large, toOther},
+		},
+		{
+			name:       "numeric equality",
+			eventTypes: []string{depositedType},
+			predicates: []events.FieldPredicate{
+				{Field: "amount", Operator: events.FieldOperatorEqual, Values: []string{"10"}},
+			},
+			expected: flow.EventsList{toOther, toNobody},
+		},
+		{
+			name:       "numeric range",
+			eventTypes: []string{depositedType},
+			predicates: []events.FieldPredicate{
+				{Field: "amount", Operator: events.FieldOperatorGreaterOrEqual, Values: []string{"2"}},
+				{Field: "amount", Operator: events.FieldOperatorLessOrEqual, Values: []string{"100.0"}},
+			},
+			expected: flow.EventsList{toOther, toNobody},
+		},
+		{
+			name:       "all predicates must match",
+			eventTypes: []string{depositedType},
+			predicates: []events.FieldPredicate{
+				{Field: "to", Operator: events.FieldOperatorEqual, Values: []string{receiver.Hex()}},
+				{Field: "amount", Operator: events.FieldOperatorGreaterOrEqual, Values: []string{"100"}},
+			},
+			expected: flow.EventsList{large},
+		},
+		{
+			name: "missing field does not match",
+			predicates: []events.FieldPredicate{
+				{Field: "from", Operator: events.FieldOperatorEqual, Values: []string{receiver.Hex()}},
+			},
+			expected: flow.EventsList{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := events.NewQuery(chain, tt.eventTypes, tt.addresses, tt.contracts, tt.predicates)
+			require.NoError(t, err)
+
+			matched := make(flow.EventsList, 0)
+			for _, event := range all {
+				if query.Match(event) {
+					matched = append(matched, event)
+				}
+			}
+			assert.Equal(t, tt.expected, matched)
+		})
+	}
+}
+
+func TestNewQuery_Invalid(t *testing.T) {
+	t.Parallel()
+
+	chain := flow.Testnet.Chain()
+
+	tests := []struct {
+		name       string
+		eventTypes []string
+		addresses  []string
+		contracts  []string
+		predicates []events.FieldPredicate
+	}{
+		{
+			name:       "invalid event type",
+			eventTypes: []string{"invalid"},
+		},
+		{
+			name:      "invalid address",
+			addresses: []string{"0x1234"},
+		},
+		{
+			name:      "invalid contract",
+			contracts: []string{"FlowToken"},
+		},
+		{
+			name:       "missing field name",
+			predicates: []events.FieldPredicate{{Operator: events.FieldOperatorEqual, Values: []string{"1"}}},
+		},
+		{
+			name:       "unknown operator",
+			predicates: []events.FieldPredicate{{Field: "amount", Operator: "like", Values: []string{"1"}}},
+		},
+		{
+			name:       "equality with multiple values",
+			predicates: []events.FieldPredicate{{Field: "amount", Operator: events.FieldOperatorEqual, Values: []string{"1", "2"}}},
+		},
+		{
+			name:       "membership without values",
+			predicates: []events.FieldPredicate{{Field: "amount", Operator: events.FieldOperatorIn}},
+		},
+		{
+			name:       "non numeric range",
+			predicates: []events.FieldPredicate{{Field: "amount", Operator: events.FieldOperatorGreaterOrEqual, Values: []string{"abc"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := events.NewQuery(chain, tt.eventTypes, tt.addresses, tt.contracts, tt.predicates)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package flow
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+// AccountStorage is a breakdown of the values stored in an account, used to inspect what occupies
+// the storage of the account.
+type AccountStorage struct {
+	Address Address
+	// StorageUsed is the number of bytes the account is charged for, as tracked by the account status.
+	StorageUsed uint64
+	// StorageCapacity is the number of bytes the account is allowed to use, as determined by the
+	// storage fees contract.
+	StorageCapacity uint64
+	// Domains contains the non-empty storage domains of the account which have items in this page.
+	Domains []AccountStorageDomain
+	// NextCursor points to the first item of the next page, nil if there are no more items.
+	NextCursor *AccountStorageCursor
+}
+
+// AccountStorageDomain is a Cadence storage domain of an account, such as "storage", "public" or
+// "contract", with the values stored in it.
+type AccountStorageDomain struct {
+	Name  string
+	Items []AccountStorageItem
+}
+
+// AccountStorageItem is a single value stored in a storage domain of an account.
+type AccountStorageItem struct {
+	// Key identifies the value in its domain. For path domains the key is the full path of the
+	// value (e.g. /storage/flowTokenVault).
+	Key string
+	// Type is the Cadence type ID of the stored value.
+	Type string
+	// Size is the number of bytes used to store the value, including the slabs of nested values
+	// which are not inlined.
+	Size uint64
+	// SizeTruncated is true if the value is nested deeper than the inspection depth limit, in which
+	// case Size does not include the slabs below the limit.
+	SizeTruncated bool
+}
+
+// accountStorageCursorIndexLength is the length of the binary encoding of the item index of
+// AccountStorageCursor, which is followed by the domain name.
+const accountStorageCursorIndexLength = 8
+
+// AccountStorageCursor identifies a position in the storage of an account.
+// Items of a domain are iterated in the order of the storage map of the domain, so the cursor is
+// only valid for the block height it was returned for.
+type AccountStorageCursor struct {
+	// Domain is the name of the storage domain.
+	Domain string
+	// Index is the position of the item in the iteration order of the domain.
+	Index uint64
+}
+
+// Encode returns the opaque string representation of the cursor.
+func (c AccountStorageCursor) Encode() string {
+	b := make([]byte, accountStorageCursorIndexLength, accountStorageCursorIndexLength+len(c.Domain))
+	binary.BigEndian.PutUint64(b, c.Index)
+	return hex.EncodeToString(append(b, c.Domain...))
+}
+
+// DecodeAccountStorageCursor parses a cursor previously produced by AccountStorageCursor.Encode.
+// Expected errors during normal operations:
+//   - an error if the cursor is malformed
+func DecodeAccountStorageCursor(raw string) (AccountStorageCursor, error) {
+	b, err := hex.DecodeString(raw)
+	if err != nil {
+		return AccountStorageCursor{}, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	if len(b) <= accountStorageCursorIndexLength {
+		return AccountStorageCursor{}, fmt.Errorf("invalid cursor length %d, expected more than %d", len(b), accountStorageCursorIndexLength)
+	}
+
+	return AccountStorageCursor{
+		Domain: string(b[accountStorageCursorIndexLength:]),
+		Index:  binary.BigEndian.Uint64(b[:accountStorageCursorIndexLength]),
+	}, nil
+}
@@ -0,0 +1,113 @@
+package flow
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// TransactionRole is a bitmask describing the ways an account participated in a transaction.
+// An account may hold several roles within the same transaction, e.g. be both payer and authorizer.
+type TransactionRole uint8
+
+const (
+	// TransactionRoleProposer indicates the account provided the proposal key of the transaction.
+	TransactionRoleProposer TransactionRole = 1 << iota
+	// TransactionRolePayer indicates the account paid the fees of the transaction.
+	TransactionRolePayer
+	// TransactionRoleAuthorizer indicates the account authorized the transaction.
+	TransactionRoleAuthorizer
+)
+
+// Has returns true if the role set includes the given role.
+func (r TransactionRole) Has(role TransactionRole) bool {
+	return r&role == role
+}
+
+// Strings returns the names of all roles included in the role set.
+func (r TransactionRole) Strings() []string {
+	roles := make([]string, 0, 3)
+	if r.Has(TransactionRoleProposer) {
+		roles = append(roles, "proposer")
+	}
+	if r.Has(TransactionRolePayer) {
+		roles = append(roles, "payer")
+	}
+	if r.Has(TransactionRoleAuthorizer) {
+		roles = append(roles, "authorizer")
+	}
+	return roles
+}
+
+// String returns the role set as a comma separated list of role names.
+func (r TransactionRole) String() string {
+	return strings.Join(r.Strings(), ",")
+}
+
+// AccountTransaction is an entry in the account-to-transaction index. It records that the account
+// with the given address participated in the transaction at the given height and position.
+type AccountTransaction struct {
+	// Address is the address of the account.
+	Address Address
+	// BlockHeight is the height of the block that contains the transaction.
+	BlockHeight uint64
+	// TransactionIndex is the position of the transaction within its block.
+	TransactionIndex uint32
+	// TransactionID is the ID of the transaction.
+	TransactionID Identifier
+	// Roles are all roles the account held in the transaction.
+	Roles TransactionRole
+}
+
+// Cursor returns the cursor pointing at this entry.
+func (a AccountTransaction) Cursor() AccountTransactionCursor {
+	return AccountTransactionCursor{
+		BlockHeight:      a.BlockHeight,
+		TransactionIndex: a.TransactionIndex,
+	}
+}
+
+// accountTransactionCursorLength is the length of the binary encoding of AccountTransactionCursor.
+const accountTransactionCursorLength = 8 + 4
+
+// AccountTransactionCursor identifies a position in the account-to-transaction index.
+// Since entries are keyed by (height, transaction index) the cursor remains valid across restarts.
+type AccountTransactionCursor struct {
+	BlockHeight      uint64
+	TransactionIndex uint32
+}
+
+// Encode returns the opaque string representation of the cursor.
+func (c AccountTransactionCursor) Encode() string {
+	b := make([]byte, accountTransactionCursorLength)
+	binary.BigEndian.PutUint64(b[:8], c.BlockHeight)
+	binary.BigEndian.PutUint32(b[8:], c.TransactionIndex)
+	return hex.EncodeToString(b)
+}
+
+// DecodeAccountTransactionCursor parses a cursor previously produced by AccountTransactionCursor.Encode.
+// Expected errors during normal operations:
+//   - an error if the cursor is malformed
+func DecodeAccountTransactionCursor(raw string) (AccountTransactionCursor, error) {
+	b, err := hex.DecodeString(raw)
+	if err != nil {
+		return AccountTransactionCursor{}, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	if len(b) != accountTransactionCursorLength {
+		return AccountTransactionCursor{}, fmt.Errorf("invalid cursor length %d, expected %d", len(b), accountTransactionCursorLength)
+	}
+
+	return AccountTransactionCursor{
+		BlockHeight:      binary.BigEndian.Uint64(b[:8]),
+		TransactionIndex: binary.BigEndian.Uint32(b[8:]),
+	}, nil
+}
+
+// AccountTransactionsPage is a single page of results from the account-to-transaction index.
+type AccountTransactionsPage struct {
+	// Transactions are the entries of the page, ordered by height and transaction index.
+	Transactions []AccountTransaction
+	// NextCursor points to the first entry of the next page, nil if there are no more entries.
+	NextCursor *AccountTransactionCursor
+}
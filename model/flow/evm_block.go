@@ -0,0 +1,25 @@
+package flow
+
+import (
+	gethCommon "github.com/onflow/go-ethereum/common"
+)
+
+// EVMBlock is an entry of the EVM block index. It holds what is needed to look up EVM blocks and
+// transactions by hash, and to filter logs, and locates the Flow blocks containing the events emitted
+// for the EVM block.
+type EVMBlock struct {
+	// Height is the height of the EVM block.
+	Height uint64
+	// Hash is the hash of the EVM block.
+	Hash gethCommon.Hash
+	// TransactionHashes are the hashes of the transactions of the EVM block, in execution order.
+	TransactionHashes []gethCommon.Hash
+	// LogsBloom is the bloom filter of the logs emitted by all transactions of the EVM block.
+	LogsBloom []byte
+	// FirstFlowHeight is the height of the first Flow block containing EVM.TransactionExecuted
+	// events of the EVM block.
+	FirstFlowHeight uint64
+	// FlowHeight is the height of the Flow block containing the EVM.BlockExecuted event of the EVM block.
+	// Since every Flow block emits its own EVM block, it is usually the same as FirstFlowHeight.
+	FlowHeight uint64
+}
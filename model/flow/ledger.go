@@ -193,6 +193,13 @@ type RegisterEntry struct {
 	Value RegisterValue
 }
 
+// RegisterChange is a value written to a register at a block height.
+type RegisterChange struct {
+	Key    RegisterID
+	Height uint64
+	Value  RegisterValue
+}
+
 // handy container for sorting
 // TODO(ramtin): add canonical encoding and fingerprint for RegisterEntries
 type RegisterEntries []RegisterEntry
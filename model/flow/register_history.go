@@ -0,0 +1,63 @@
+package flow
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+)
+
+// Cursor returns the cursor pointing at this change.
+func (c RegisterChange) Cursor() RegisterHistoryCursor {
+	return RegisterHistoryCursor{
+		Key:    c.Key.Key,
+		Height: c.Height,
+	}
+}
+
+// registerHistoryCursorHeightLength is the length of the binary encoding of the height of
+// RegisterHistoryCursor, which is followed by the register key.
+const registerHistoryCursorHeightLength = 8
+
+// RegisterHistoryCursor identifies a position in the history of the registers of an owner.
+// Since values are keyed by (register, height) the cursor remains valid across restarts, as long
+// as the height has not been pruned.
+type RegisterHistoryCursor struct {
+	// Key is the key of the register, within the owner of the requested history.
+	Key string
+	// Height is the height at which the value was written.
+	Height uint64
+}
+
+// Encode returns the opaque string representation of the cursor.
+func (c RegisterHistoryCursor) Encode() string {
+	b := make([]byte, registerHistoryCursorHeightLength, registerHistoryCursorHeightLength+len(c.Key))
+	binary.BigEndian.PutUint64(b, c.Height)
+	return hex.EncodeToString(append(b, c.Key...))
+}
+
+// DecodeRegisterHistoryCursor parses a cursor previously produced by RegisterHistoryCursor.Encode.
+// Expected errors during normal operations:
+//   - an error if the cursor is malformed
+func DecodeRegisterHistoryCursor(raw string) (RegisterHistoryCursor, error) {
+	b, err := hex.DecodeString(raw)
+	if err != nil {
+		return RegisterHistoryCursor{}, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+	if len(b) <= registerHistoryCursorHeightLength {
+		return RegisterHistoryCursor{}, fmt.Errorf("invalid cursor length %d, expected more than %d", len(b), registerHistoryCursorHeightLength)
+	}
+
+	return RegisterHistoryCursor{
+		Key:    string(b[registerHistoryCursorHeightLength:]),
+		Height: binary.BigEndian.Uint64(b[:registerHistoryCursorHeightLength]),
+	}, nil
+}
+
+// RegisterHistoryPage is a single page of the values written to registers.
+type RegisterHistoryPage struct {
+	// Changes are the values of the page, grouped by register and ordered from the most recent to the
+	// oldest value within each register.
+	Changes []RegisterChange
+	// NextCursor points to the first change of the next page, nil if there are no more changes.
+	NextCursor *RegisterHistoryCursor
+}
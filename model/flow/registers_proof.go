@@ -0,0 +1,14 @@
+package flow
+
+// RegistersProof contains the values of registers in the execution state at the end of a sealed block,
+// together with a proof of the values against the state commitment of the block.
+type RegistersProof struct {
+	// BlockID is the ID of the block at which the registers were read
+	BlockID Identifier
+	// StateCommitment is the final state commitment of the sealed execution result of the block
+	StateCommitment StateCommitment
+	// Registers contains the values of the requested registers. Registers which are not set have an empty value.
+	Registers []RegisterEntry
+	// Proof is the encoded ledger.TrieBatchProof of the register values against the state commitment
+	Proof []byte
+}
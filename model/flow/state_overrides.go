@@ -0,0 +1,54 @@
+package flow
+
+// StateOverrides are changes applied to the execution state of a block before executing a script, to
+// query the result of the script as if the state was different. They are only used for the execution of
+// the script, and are never persisted.
+type StateOverrides struct {
+	// Registers are register values replacing the values at the block. An empty value removes the register.
+	Registers []RegisterEntry
+	// Contracts are contract code replacing the code of contracts deployed at the block.
+	Contracts []ContractOverride
+	// Balances are FLOW balances replacing the balances of accounts at the block.
+	Balances []BalanceOverride
+}
+
+// ContractOverride replaces the code of a deployed contract.
+type ContractOverride struct {
+	Address Address
+	Name    string
+	Code    []byte
+}
+
+// BalanceOverride replaces the FLOW balance of an account.
+type BalanceOverride struct {
+	Address Address
+	// Balance is the balance of the account, in the smallest FLOW unit (1e-8)
+	Balance uint64
+}
+
+// IsEmpty returns true if no overrides are set.
+func (o *StateOverrides) IsEmpty() bool {
+	return o == nil || len(o.Registers)+len(o.Contracts)+len(o.Balances) == 0
+}
+
+// Len returns the total number of overrides.
+func (o *StateOverrides) Len() int {
+	if o == nil {
+		return 0
+	}
+	return len(o.Registers) + len(o.Contracts) + len(o.Balances)
+}
+
+// ScriptResultWithOverrides is the result of a script executed on top of a state with overrides applied.
+// The result does not reflect the state of the chain.
+type ScriptResultWithOverrides struct {
+	// Value is the JSON-CDC encoded value returned by the script
+	Value []byte
+	// OverriddenRegisters is the number of registers changed by the overrides, including the registers
+	// of contract overrides and the registers written by balance overrides
+	OverriddenRegisters int
+	// OverriddenContracts is the number of contract overrides applied to the state
+	OverriddenContracts int
+	// OverriddenBalances is the number of balance overrides applied to the state
+	OverriddenBalances int
+}
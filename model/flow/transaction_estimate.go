@@ -0,0 +1,20 @@
+package flow
+
+// TransactionEstimate contains the artifacts generated by a dry-run of a transaction, which is executed
+// without verifying its signatures and sequence number, and whose state changes are discarded.
+type TransactionEstimate struct {
+	// ErrorMessage contains the error message of any error that occurred when the transaction was executed
+	ErrorMessage string
+	// ComputationUsed is the computation effort used by the transaction
+	ComputationUsed uint64
+	// ComputationIntensities is the metered intensity of each computation kind used by the transaction,
+	// keyed by computation kind
+	ComputationIntensities map[uint64]uint64
+	// MemoryEstimate is the estimated memory used by the transaction
+	MemoryEstimate uint64
+	// Events contains the events emitted by the transaction
+	Events []Event
+	// Fees is the amount, in the smallest FLOW unit (1e-8), the FlowFees contract would charge for the
+	// inclusion and execution of the transaction
+	Fees uint64
+}
@@ -0,0 +1,52 @@
+package flow
+
+import (
+	"time"
+)
+
+// TransactionStage identifies a step in the lifecycle of a transaction, as observed by an access node.
+type TransactionStage string
+
+const (
+	// TransactionStageReceived is recorded when the access node accepted the transaction from a client.
+	TransactionStageReceived TransactionStage = "received"
+	// TransactionStageForwarded is recorded when the transaction was sent to a collection node.
+	TransactionStageForwarded TransactionStage = "forwarded"
+	// TransactionStageCollected is recorded when the access node ingested the collection including
+	// the transaction. This is the local ingestion time, not the time the collection was guaranteed.
+	// Collections can be ingested after the block including them was finalized, in which case this
+	// stage follows TransactionStageIncluded in the timeline.
+	TransactionStageCollected TransactionStage = "collected"
+	// TransactionStageIncluded is recorded when a finalized block including the collection of the
+	// transaction was observed.
+	TransactionStageIncluded TransactionStage = "included"
+	// TransactionStageExecuted is recorded when the first execution receipt for the block including
+	// the transaction was received.
+	TransactionStageExecuted TransactionStage = "executed"
+	// TransactionStageSealed is recorded when a seal for the block including the transaction was finalized.
+	TransactionStageSealed TransactionStage = "sealed"
+)
+
+// Attribute keys of transaction timeline events. The keys follow the OpenTelemetry attribute naming
+// conventions, so that timelines can be exported as span events without translation.
+const (
+	TimelineAttributeCollectionNode = "flow.collection_node.address"
+	TimelineAttributeCollectionID   = "flow.collection.id"
+	TimelineAttributeBlockID        = "flow.block.id"
+	TimelineAttributeBlockHeight    = "flow.block.height"
+	TimelineAttributeExecutorID     = "flow.executor.id"
+)
+
+// TransactionTimelineEvent is a single step in the lifecycle of a transaction.
+type TransactionTimelineEvent struct {
+	Stage      TransactionStage
+	Timestamp  time.Time
+	Attributes map[string]string
+}
+
+// TransactionTimeline is the lifecycle of a transaction submitted to an access node. Events are
+// ordered by timestamp, not by stage, and each stage is recorded at most once.
+type TransactionTimeline struct {
+	TransactionID Identifier
+	Events        []TransactionTimelineEvent
+}
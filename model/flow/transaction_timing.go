@@ -4,13 +4,15 @@ import (
 	"time"
 )
 
-// TransactionTiming is used to track the timing/durations of a transaction through the system
+// TransactionTiming is used to track the timing/durations of a transaction through the system.
+// Events holds the lifecycle timeline of the transaction, if timelines are tracked.
 type TransactionTiming struct {
 	TransactionID Identifier
 	Received      time.Time
 	Finalized     time.Time
 	Executed      time.Time
 	Sealed        time.Time
+	Events        []TransactionTimelineEvent
 }
 
 func (t TransactionTiming) ID() Identifier {
@@ -20,3 +22,21 @@ func (t TransactionTiming) ID() Identifier {
 func (t TransactionTiming) Checksum() Identifier {
 	return t.TransactionID
 }
+
+// HasStage returns true if a timeline event for the given stage was already recorded.
+func (t TransactionTiming) HasStage(stage TransactionStage) bool {
+	for _, event := range t.Events {
+		if event.Stage == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// Timeline returns the lifecycle timeline of the transaction.
+func (t TransactionTiming) Timeline() *TransactionTimeline {
+	return &TransactionTimeline{
+		TransactionID: t.TransactionID,
+		Events:        t.Events,
+	}
+}
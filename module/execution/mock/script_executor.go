@@ -15,6 +15,36 @@ type ScriptExecutor struct {
 	mock.Mock
 }
 
+// EstimateTransaction provides a mock function with given fields: ctx, tx, height
+func (_m *ScriptExecutor) EstimateTransaction(ctx context.Context, tx *flow.TransactionBody, height uint64) (*flow.TransactionEstimate, error) {
+	ret := _m.Called(ctx, tx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateTransaction")
+	}
+
+	var r0 *flow.TransactionEstimate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64) (*flow.TransactionEstimate, error)); ok {
+		return rf(ctx, tx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody, uint64) *flow.TransactionEstimate); ok {
+		r0 = rf(ctx, tx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.TransactionEstimate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody, uint64) error); ok {
+		r1 = rf(ctx, tx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ExecuteAtBlockHeight provides a mock function with given fields: ctx, script, arguments, height
 func (_m *ScriptExecutor) ExecuteAtBlockHeight(ctx context.Context, script []byte, arguments [][]byte, height uint64) ([]byte, error) {
 	ret := _m.Called(ctx, script, arguments, height)
@@ -45,6 +75,36 @@ func (_m *ScriptExecutor) ExecuteAtBlockHeight(ctx context.Context, script []byt
 	return r0, r1
 }
 
+// ExecuteAtBlockHeightWithOverrides provides a mock function with given fields: ctx, script, arguments, height, overrides
+func (_m *ScriptExecutor) ExecuteAtBlockHeightWithOverrides(ctx context.Context, script []byte, arguments [][]byte, height uint64, overrides *flow.StateOverrides) (*flow.ScriptResultWithOverrides, error) {
+	ret := _m.Called(ctx, script, arguments, height, overrides)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteAtBlockHeightWithOverrides")
+	}
+
+	var r0 *flow.ScriptResultWithOverrides
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte, uint64, *flow.StateOverrides) (*flow.ScriptResultWithOverrides, error)); ok {
+		return rf(ctx, script, arguments, height, overrides)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []byte, [][]byte, uint64, *flow.StateOverrides) *flow.ScriptResultWithOverrides); ok {
+		r0 = rf(ctx, script, arguments, height, overrides)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.ScriptResultWithOverrides)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []byte, [][]byte, uint64, *flow.StateOverrides) error); ok {
+		r1 = rf(ctx, script, arguments, height, overrides)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAccountAtBlockHeight provides a mock function with given fields: ctx, address, height
 func (_m *ScriptExecutor) GetAccountAtBlockHeight(ctx context.Context, address flow.Address, height uint64) (*flow.Account, error) {
 	ret := _m.Called(ctx, address, height)
@@ -191,6 +251,36 @@ func (_m *ScriptExecutor) GetAccountKeys(ctx context.Context, address flow.Addre
 	return r0, r1
 }
 
+// GetAccountStorage provides a mock function with given fields: ctx, address, height, limit, cursor
+func (_m *ScriptExecutor) GetAccountStorage(ctx context.Context, address flow.Address, height uint64, limit uint, cursor *flow.AccountStorageCursor) (*flow.AccountStorage, error) {
+	ret := _m.Called(ctx, address, height, limit, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountStorage")
+	}
+
+	var r0 *flow.AccountStorage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint, *flow.AccountStorageCursor) (*flow.AccountStorage, error)); ok {
+		return rf(ctx, address, height, limit, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64, uint, *flow.AccountStorageCursor) *flow.AccountStorage); ok {
+		r0 = rf(ctx, address, height, limit, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*flow.AccountStorage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64, uint, *flow.AccountStorageCursor) error); ok {
+		r1 = rf(ctx, address, height, limit, cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewScriptExecutor creates a new instance of ScriptExecutor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewScriptExecutor(t interface {
@@ -0,0 +1,92 @@
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/convert"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// MaxRegistersPerProof is the maximum number of registers which can be proven in one request.
+const MaxRegistersPerProof = 100
+
+// ErrStateNotAvailable is returned when the state commitment is not available in the ledger, for
+// example because it was pruned from the forest.
+var ErrStateNotAvailable = errors.New("state is not available in the ledger")
+
+// RegisterProver proves register values against a state commitment.
+type RegisterProver interface {
+	// ProveRegisters returns the values of the registers at the given state commitment of the given block,
+	// in the order of the given register IDs, together with an encoded ledger.TrieBatchProof of the values.
+	// Expected errors:
+	// - ErrStateNotAvailable if the state commitment is not available
+	ProveRegisters(
+		ctx context.Context,
+		blockID flow.Identifier,
+		commit flow.StateCommitment,
+		registerIDs flow.RegisterIDs,
+	) ([]flow.RegisterEntry, []byte, error)
+}
+
+// LedgerRegisterProver proves register values using a ledger which holds the tries of the execution state.
+type LedgerRegisterProver struct {
+	ledger ledger.Ledger
+}
+
+var _ RegisterProver = (*LedgerRegisterProver)(nil)
+
+func NewLedgerRegisterProver(ledger ledger.Ledger) *LedgerRegisterProver {
+	return &LedgerRegisterProver{
+		ledger: ledger,
+	}
+}
+
+// ProveRegisters returns the values of the registers at the given state commitment, in the order of the
+// given register IDs, together with an encoded ledger.TrieBatchProof of the values. The block ID is not
+// used, since the ledger is addressed by state commitment.
+// Expected errors:
+// - ErrStateNotAvailable if the state commitment is not available
+func (p *LedgerRegisterProver) ProveRegisters(
+	_ context.Context,
+	_ flow.Identifier,
+	commit flow.StateCommitment,
+	registerIDs flow.RegisterIDs,
+) ([]flow.RegisterEntry, []byte, error) {
+	state := ledger.State(commit)
+	if !p.ledger.HasState(state) {
+		return nil, nil, fmt.Errorf("could not prove registers at state %v: %w", commit, ErrStateNotAvailable)
+	}
+
+	keys := make([]ledger.Key, len(registerIDs))
+	for i, registerID := range registerIDs {
+		keys[i] = convert.RegisterIDToLedgerKey(registerID)
+	}
+
+	query, err := ledger.NewQuery(state, keys)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create ledger query: %w", err)
+	}
+
+	values, err := p.ledger.Get(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read registers: %w", err)
+	}
+
+	proof, err := p.ledger.Prove(query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not prove registers: %w", err)
+	}
+
+	registers := make([]flow.RegisterEntry, len(registerIDs))
+	for i, registerID := range registerIDs {
+		registers[i] = flow.RegisterEntry{
+			Key:   registerID,
+			Value: values[i],
+		}
+	}
+
+	return registers, proof, nil
+}
@@ -0,0 +1,63 @@
+package execution
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	executionState "github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestLedgerRegisterProver(t *testing.T) {
+	l, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+
+	compactor := fixtures.NewNoopCompactor(l)
+	<-compactor.Ready()
+	t.Cleanup(func() {
+		<-l.Done()
+		<-compactor.Done()
+	})
+
+	owner := unittest.RandomAddressFixture()
+	first := flow.NewRegisterID(owner, "first")
+	second := flow.NewRegisterID(owner, "second")
+	unset := flow.NewRegisterID(owner, "unset")
+
+	keys, values := executionState.RegisterEntriesToKeysValues(flow.RegisterEntries{
+		{Key: first, Value: []byte{1}},
+		{Key: second, Value: []byte{2}},
+	})
+	update, err := ledger.NewUpdate(l.InitialState(), keys, values)
+	require.NoError(t, err)
+
+	state, _, err := l.Set(update)
+	require.NoError(t, err)
+
+	prover := NewLedgerRegisterProver(l)
+
+	t.Run("registers in requested order", func(t *testing.T) {
+		registers, proof, err := prover.ProveRegisters(context.Background(), unittest.IdentifierFixture(), flow.StateCommitment(state), flow.RegisterIDs{second, unset, first})
+		require.NoError(t, err)
+		require.NotEmpty(t, proof)
+
+		require.Equal(t, []flow.RegisterEntry{
+			{Key: second, Value: []byte{2}},
+			{Key: unset, Value: []byte{}},
+			{Key: first, Value: []byte{1}},
+		}, registers)
+	})
+
+	t.Run("unknown state", func(t *testing.T) {
+		_, _, err := prover.ProveRegisters(context.Background(), unittest.IdentifierFixture(), unittest.StateCommitmentFixture(), flow.RegisterIDs{first})
+		require.ErrorIs(t, err, ErrStateNotAvailable)
+	})
+}
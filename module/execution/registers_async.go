@@ -2,6 +2,7 @@ package execution
 
 import (
 	"fmt"
+	"iter"
 
 	"go.uber.org/atomic"
 
@@ -58,6 +59,73 @@ func (r *RegistersAsyncStore) RegisterValues(ids flow.RegisterIDs, height uint64
 	return result, nil
 }
 
+// RegisterHistory returns a page of the values written to the register within the height range
+// [fromHeight, toHeight], from the most recent to the oldest value. At most limit changes are returned.
+// If cursor is not nil, the page starts at the change the cursor points to.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the store is still bootstrapping
+//   - storage.ErrHeightNotIndexed if the height range is not indexed
+func (r *RegistersAsyncStore) RegisterHistory(
+	id flow.RegisterID,
+	fromHeight uint64,
+	toHeight uint64,
+	limit uint,
+	cursor *flow.RegisterHistoryCursor,
+) (*flow.RegisterHistoryPage, error) {
+	registerStore, err := r.getRegisterStore()
+	if err != nil {
+		return nil, err
+	}
+
+	// values of a single register are ordered by height, so the page starts at the cursor height
+	if cursor != nil {
+		toHeight = cursor.Height
+	}
+
+	return collectRegisterChanges(registerStore.History(id, fromHeight, toHeight), limit)
+}
+
+// OwnerRegisterHistory returns a page of the values written to all registers of the owner within the height
+// range [fromHeight, toHeight], grouped by register and ordered from the most recent to the oldest value.
+// At most limit changes are returned. If cursor is not nil, the page starts at the change the cursor points to.
+// Expected errors:
+//   - indexer.ErrIndexNotInitialized if the store is still bootstrapping
+//   - storage.ErrHeightNotIndexed if the height range is not indexed
+func (r *RegistersAsyncStore) OwnerRegisterHistory(
+	owner string,
+	fromHeight uint64,
+	toHeight uint64,
+	limit uint,
+	cursor *flow.RegisterHistoryCursor,
+) (*flow.RegisterHistoryPage, error) {
+	registerStore, err := r.getRegisterStore()
+	if err != nil {
+		return nil, err
+	}
+
+	return collectRegisterChanges(registerStore.OwnerHistory(owner, fromHeight, toHeight, cursor), limit)
+}
+
+// collectRegisterChanges collects up to limit changes of the history into a page. If the history has more
+// changes, the cursor of the page points to the first change that was not collected.
+func collectRegisterChanges(history iter.Seq2[flow.RegisterChange, error], limit uint) (*flow.RegisterHistoryPage, error) {
+	page := &flow.RegisterHistoryPage{
+		Changes: make([]flow.RegisterChange, 0),
+	}
+	for change, err := range history {
+		if err != nil {
+			return nil, err
+		}
+		if uint(len(page.Changes)) >= limit {
+			next := change.Cursor()
+			page.NextCursor = &next
+			break
+		}
+		page.Changes = append(page.Changes, change)
+	}
+	return page, nil
+}
+
 func (r *RegistersAsyncStore) getRegisterStore() (storage.RegisterIndex, error) {
 	registerStore := r.registerIndex.Load()
 	if registerStore == nil {
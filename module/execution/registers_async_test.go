@@ -1,6 +1,7 @@
 package execution
 
 import (
+	"iter"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -85,3 +86,90 @@ func TestInitDataRepeatedCalls(t *testing.T) {
 	require.NoError(t, registersAsync.Initialize(registers1))
 	require.Error(t, registersAsync.Initialize(registers2))
 }
+
+func TestRegisterHistory(t *testing.T) {
+	t.Parallel()
+
+	registerID := unittest.RegisterIDFixture()
+	changes := []flow.RegisterChange{
+		{Key: registerID, Height: 5, Value: []byte("value5")},
+		{Key: registerID, Height: 3, Value: []byte("value3")},
+	}
+	history := func(yield func(flow.RegisterChange, error) bool) {
+		for _, change := range changes {
+			if !yield(change, nil) {
+				return
+			}
+		}
+	}
+
+	t.Run("changes returned", func(t *testing.T) {
+		registersAsync := NewRegistersAsyncStore()
+		registers := storagemock.NewRegisterIndex(t)
+		registers.On("History", registerID, uint64(1), uint64(5)).Return(iter.Seq2[flow.RegisterChange, error](history))
+		registers.On("OwnerHistory", registerID.Owner, uint64(1), uint64(5), (*flow.RegisterHistoryCursor)(nil)).
+			Return(iter.Seq2[flow.RegisterChange, error](history))
+
+		require.NoError(t, registersAsync.Initialize(registers))
+
+		page, err := registersAsync.RegisterHistory(registerID, 1, 5, 2, nil)
+		require.NoError(t, err)
+		require.Equal(t, changes, page.Changes)
+		require.Nil(t, page.NextCursor)
+
+		page, err = registersAsync.OwnerRegisterHistory(registerID.Owner, 1, 5, 2, nil)
+		require.NoError(t, err)
+		require.Equal(t, changes, page.Changes)
+		require.Nil(t, page.NextCursor)
+	})
+
+	t.Run("limit exceeded next cursor returned", func(t *testing.T) {
+		registersAsync := NewRegistersAsyncStore()
+		registers := storagemock.NewRegisterIndex(t)
+		registers.On("History", registerID, uint64(1), uint64(5)).Return(iter.Seq2[flow.RegisterChange, error](history))
+
+		require.NoError(t, registersAsync.Initialize(registers))
+
+		page, err := registersAsync.RegisterHistory(registerID, 1, 5, 1, nil)
+		require.NoError(t, err)
+		require.Equal(t, changes[:1], page.Changes)
+		require.Equal(t, &flow.RegisterHistoryCursor{Key: registerID.Key, Height: 3}, page.NextCursor)
+	})
+
+	t.Run("cursor of register history limits the height range", func(t *testing.T) {
+		registersAsync := NewRegistersAsyncStore()
+		registers := storagemock.NewRegisterIndex(t)
+		registers.On("History", registerID, uint64(1), uint64(3)).Return(iter.Seq2[flow.RegisterChange, error](
+			func(yield func(flow.RegisterChange, error) bool) {
+				yield(changes[1], nil)
+			}))
+
+		require.NoError(t, registersAsync.Initialize(registers))
+
+		page, err := registersAsync.RegisterHistory(registerID, 1, 5, 1, &flow.RegisterHistoryCursor{Key: registerID.Key, Height: 3})
+		require.NoError(t, err)
+		require.Equal(t, changes[1:], page.Changes)
+		require.Nil(t, page.NextCursor)
+	})
+
+	t.Run("iteration error returned", func(t *testing.T) {
+		registersAsync := NewRegistersAsyncStore()
+		registers := storagemock.NewRegisterIndex(t)
+		registers.On("History", registerID, uint64(1), uint64(5)).Return(iter.Seq2[flow.RegisterChange, error](
+			func(yield func(flow.RegisterChange, error) bool) {
+				yield(flow.RegisterChange{}, storage.ErrHeightNotIndexed)
+			}))
+
+		require.NoError(t, registersAsync.Initialize(registers))
+
+		_, err := registersAsync.RegisterHistory(registerID, 1, 5, 2, nil)
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+	})
+
+	t.Run("registersDB not bootstrapped correct error returned", func(t *testing.T) {
+		registersAsync := NewRegistersAsyncStore()
+
+		_, err := registersAsync.RegisterHistory(registerID, 1, 5, 2, nil)
+		require.ErrorIs(t, err, indexer.ErrIndexNotInitialized)
+	})
+}
@@ -38,6 +38,20 @@ type ScriptExecutor interface {
 		height uint64,
 	) ([]byte, error)
 
+	// ExecuteAtBlockHeightWithOverrides executes provided script against the block height, with the
+	// state overrides applied on top of the state of the block. The overrides are never persisted.
+	// Expected errors:
+	// - query.ErrInvalidStateOverrides if the overrides can not be applied to the state of the block.
+	// - storage.ErrNotFound if block or register value at height was not found.
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	ExecuteAtBlockHeightWithOverrides(
+		ctx context.Context,
+		script []byte,
+		arguments [][]byte,
+		height uint64,
+		overrides *flow.StateOverrides,
+	) (*flow.ScriptResultWithOverrides, error)
+
 	// GetAccountAtBlockHeight returns a Flow account by the provided address and block height.
 	// Expected errors:
 	// - storage.ErrHeightNotIndexed if the data for the block height is not available
@@ -62,6 +76,18 @@ type ScriptExecutor interface {
 	// Expected errors:
 	// - storage.ErrHeightNotIndexed if the data for the block height is not available
 	GetAccountKey(ctx context.Context, address flow.Address, keyIndex uint32, height uint64) (*flow.AccountPublicKey, error)
+
+	// GetAccountStorage returns a page of at most limit values stored in a Flow account by the provided address and
+	// block height, starting at the cursor position if cursor is not nil.
+	// Expected errors:
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	GetAccountStorage(ctx context.Context, address flow.Address, height uint64, limit uint, cursor *flow.AccountStorageCursor) (*flow.AccountStorage, error)
+
+	// EstimateTransaction executes the transaction at the provided block height without verifying its signatures
+	// and sequence number, and returns the resources it would use. State changes are discarded.
+	// Expected errors:
+	// - storage.ErrHeightNotIndexed if the data for the block height is not available
+	EstimateTransaction(ctx context.Context, tx *flow.TransactionBody, height uint64) (*flow.TransactionEstimate, error)
 }
 
 var _ ScriptExecutor = (*Scripts)(nil)
@@ -133,6 +159,27 @@ func (s *Scripts) ExecuteAtBlockHeight(
 	return value, err
 }
 
+// ExecuteAtBlockHeightWithOverrides executes provided script against the block height, with the
+// state overrides applied on top of the state of the block. The overrides are never persisted.
+// Expected errors:
+// - Script execution related errors
+// - query.ErrInvalidStateOverrides if the overrides can not be applied to the state of the block.
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) ExecuteAtBlockHeightWithOverrides(
+	ctx context.Context,
+	script []byte,
+	arguments [][]byte,
+	height uint64,
+	overrides *flow.StateOverrides,
+) (*flow.ScriptResultWithOverrides, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.executor.ExecuteScriptWithOverrides(ctx, script, arguments, header, snap, overrides)
+}
+
 // GetAccountAtBlockHeight returns a Flow account by the provided address and block height.
 // Expected errors:
 // - Script execution related errors
@@ -198,6 +245,40 @@ func (s *Scripts) GetAccountKey(ctx context.Context, address flow.Address, keyIn
 	return s.executor.GetAccountKey(ctx, address, keyIndex, header, snap)
 }
 
+// GetAccountStorage returns a page of at most limit values stored in a Flow account by the provided address and
+// block height, starting at the cursor position if cursor is not nil.
+// Expected errors:
+// - Script execution related errors
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) GetAccountStorage(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+	limit uint,
+	cursor *flow.AccountStorageCursor,
+) (*flow.AccountStorage, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.executor.GetAccountStorage(ctx, address, limit, cursor, header, snap)
+}
+
+// EstimateTransaction executes the transaction at the provided block height without verifying its signatures
+// and sequence number, and returns the resources it would use. State changes are discarded.
+// Expected errors:
+// - Transaction execution related errors
+// - storage.ErrHeightNotIndexed if the data for the block height is not available
+func (s *Scripts) EstimateTransaction(ctx context.Context, tx *flow.TransactionBody, height uint64) (*flow.TransactionEstimate, error) {
+	snap, header, err := s.snapshotWithBlock(height)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.executor.EstimateTransaction(ctx, tx, header, snap)
+}
+
 // snapshotWithBlock is a common function for executing scripts and get account functionality.
 // It creates a storage snapshot that is needed by the FVM to execute scripts.
 func (s *Scripts) snapshotWithBlock(height uint64) (snapshot.StorageSnapshot, *flow.Header, error) {
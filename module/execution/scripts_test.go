@@ -17,6 +17,7 @@ import (
 	"github.com/onflow/flow-go/engine/execution/computation/query"
 	"github.com/onflow/flow-go/engine/execution/testutil"
 	"github.com/onflow/flow-go/fvm"
+	"github.com/onflow/flow-go/fvm/blueprints"
 	"github.com/onflow/flow-go/fvm/errors"
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
@@ -152,6 +153,252 @@ func (s *scriptTestSuite) TestGetAccountKeys() {
 
 }
 
+func (s *scriptTestSuite) TestGetAccountStorage() {
+	s.Run("Get Service Account Storage", func() {
+		address := s.chain.ServiceAddress()
+		accountStorage, err := s.scripts.GetAccountStorage(context.Background(), address, s.height, 1000, nil)
+		s.Require().NoError(err)
+		s.Assert().Equal(address, accountStorage.Address)
+		s.Assert().NotZero(accountStorage.StorageUsed)
+
+		domains := make(map[string]flow.AccountStorageDomain)
+		for _, domain := range accountStorage.Domains {
+			domains[domain.Name] = domain
+		}
+		s.Require().Contains(domains, "storage")
+		s.Require().Contains(domains, "contract")
+
+		var vault *flow.AccountStorageItem
+		for i, item := range domains["storage"].Items {
+			if item.Key == "/storage/flowTokenVault" {
+				vault = &domains["storage"].Items[i]
+			}
+		}
+		s.Require().NotNil(vault)
+		s.Assert().Contains(vault.Type, "FlowToken.Vault")
+		s.Assert().NotZero(vault.Size)
+		s.Assert().Nil(accountStorage.NextCursor)
+	})
+
+	s.Run("Get Account Storage in Pages", func() {
+		address := s.chain.ServiceAddress()
+		all, err := s.scripts.GetAccountStorage(context.Background(), address, s.height, 1000, nil)
+		s.Require().NoError(err)
+
+		var expected []flow.AccountStorageItem
+		for _, domain := range all.Domains {
+			expected = append(expected, domain.Items...)
+		}
+		s.Require().Greater(len(expected), 3)
+
+		var paged []flow.AccountStorageItem
+		var cursor *flow.AccountStorageCursor
+		for {
+			page, err := s.scripts.GetAccountStorage(context.Background(), address, s.height, 3, cursor)
+			s.Require().NoError(err)
+
+			count := 0
+			for _, domain := range page.Domains {
+				count += len(domain.Items)
+				paged = append(paged, domain.Items...)
+			}
+			s.Require().LessOrEqual(count, 3)
+
+			if page.NextCursor == nil {
+				break
+			}
+			cursor = page.NextCursor
+		}
+		s.Assert().Equal(expected, paged)
+	})
+
+	s.Run("Get Non-existing Account Storage", func() {
+		address := flow.HexToAddress("0x0000000000000fff")
+		_, err := s.scripts.GetAccountStorage(context.Background(), address, s.height, 1000, nil)
+		s.Require().Error(err)
+	})
+}
+
+func (s *scriptTestSuite) TestEstimateTransaction() {
+	s.Run("Estimate Account Creation", func() {
+		const createAccountTransaction = `
+			transaction {
+			  prepare(signer: auth(Storage, Capabilities) &Account) {
+				let account = Account(payer: signer)
+			  }
+			}`
+
+		// the sequence number is not checked, so an unsigned transaction with a stale proposal key can be estimated
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(createAccountTransaction)).
+			SetProposalKey(s.chain.ServiceAddress(), 0, 1000).
+			SetPayer(s.chain.ServiceAddress()).
+			AddAuthorizer(s.chain.ServiceAddress())
+
+		estimate, err := s.scripts.EstimateTransaction(context.Background(), txBody, s.height)
+		s.Require().NoError(err)
+		s.Assert().Empty(estimate.ErrorMessage)
+		s.Assert().NotZero(estimate.ComputationUsed)
+		s.Assert().NotEmpty(estimate.ComputationIntensities)
+
+		var accountCreated bool
+		for _, event := range estimate.Events {
+			if event.Type == flow.EventAccountCreated {
+				accountCreated = true
+			}
+		}
+		s.Assert().True(accountCreated)
+
+		// the account created by the estimation is not committed
+		second, err := s.scripts.EstimateTransaction(context.Background(), txBody, s.height)
+		s.Require().NoError(err)
+		s.Assert().Equal(estimate.Events, second.Events)
+	})
+
+	s.Run("Estimate Failing Transaction", func() {
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(`transaction { execute { panic("estimation failure") } }`)).
+			SetPayer(s.chain.ServiceAddress())
+
+		estimate, err := s.scripts.EstimateTransaction(context.Background(), txBody, s.height)
+		s.Require().NoError(err)
+		s.Assert().Contains(estimate.ErrorMessage, "estimation failure")
+	})
+
+	s.Run("Estimate At Non-indexed Height", func() {
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(`transaction { execute {} }`)).
+			SetPayer(s.chain.ServiceAddress())
+
+		_, err := s.scripts.EstimateTransaction(context.Background(), txBody, s.height+1)
+		s.Require().ErrorIs(err, storage.ErrHeightNotIndexed)
+	})
+}
+
+func (s *scriptTestSuite) TestScriptExecutionWithOverrides() {
+	const contract = `access(all) contract Test { access(all) fun value(): Int { return 1 } }`
+	s.deployContract("Test", contract)
+
+	service := s.chain.ServiceAddress()
+	code := []byte(fmt.Sprintf(`
+		import Test from 0x%s
+		access(all) fun main(): Int { return Test.value() }`, service.Hex()))
+
+	s.Run("Contract Override", func() {
+		overrides := &flow.StateOverrides{
+			Contracts: []flow.ContractOverride{{
+				Address: service,
+				Name:    "Test",
+				Code:    []byte(`access(all) contract Test { access(all) fun value(): Int { return 2 } }`),
+			}},
+		}
+
+		result, err := s.scripts.ExecuteAtBlockHeightWithOverrides(context.Background(), code, nil, s.height, overrides)
+		s.Require().NoError(err)
+		s.Assert().Equal(jsoncdc.MustEncode(cadence.NewInt(2)), result.Value)
+		s.Assert().Equal(1, result.OverriddenRegisters)
+		s.Assert().Equal(1, result.OverriddenContracts)
+		s.Assert().Zero(result.OverriddenBalances)
+
+		// the overridden program is not cached for scripts without overrides
+		value, err := s.scripts.ExecuteAtBlockHeight(context.Background(), code, nil, s.height)
+		s.Require().NoError(err)
+		s.Assert().Equal(jsoncdc.MustEncode(cadence.NewInt(1)), value)
+	})
+
+	s.Run("Register Override", func() {
+		overrides := &flow.StateOverrides{
+			Registers: []flow.RegisterEntry{{
+				Key:   flow.ContractRegisterID(service, "Test"),
+				Value: []byte(`access(all) contract Test { access(all) fun value(): Int { return 3 } }`),
+			}},
+		}
+
+		result, err := s.scripts.ExecuteAtBlockHeightWithOverrides(context.Background(), code, nil, s.height, overrides)
+		s.Require().NoError(err)
+		s.Assert().Equal(jsoncdc.MustEncode(cadence.NewInt(3)), result.Value)
+		s.Assert().Equal(1, result.OverriddenRegisters)
+	})
+
+	s.Run("Contract Not Deployed", func() {
+		overrides := &flow.StateOverrides{
+			Contracts: []flow.ContractOverride{{
+				Address: service,
+				Name:    "Missing",
+				Code:    []byte(`access(all) contract Missing {}`),
+			}},
+		}
+
+		_, err := s.scripts.ExecuteAtBlockHeightWithOverrides(context.Background(), code, nil, s.height, overrides)
+		s.Require().ErrorIs(err, query.ErrInvalidStateOverrides)
+	})
+
+	s.Run("Balance Override", func() {
+		address := s.createAccount()
+		balanceCode := []byte(`access(all) fun main(address: Address): UFix64 { return getAccount(address).balance }`)
+
+		for _, balance := range []uint64{500_000_000, 0} {
+			for _, account := range []flow.Address{address, service} {
+				overrides := &flow.StateOverrides{
+					Balances: []flow.BalanceOverride{{Address: account, Balance: balance}},
+				}
+				args := [][]byte{jsoncdc.MustEncode(cadence.NewAddress(account))}
+
+				result, err := s.scripts.ExecuteAtBlockHeightWithOverrides(context.Background(), balanceCode, args, s.height, overrides)
+				s.Require().NoError(err)
+				s.Assert().Equal(jsoncdc.MustEncode(cadence.UFix64(balance)), result.Value)
+				s.Assert().Equal(1, result.OverriddenBalances)
+			}
+		}
+
+		// the overrides are never persisted
+		balance, err := s.scripts.GetAccountBalance(context.Background(), address, s.height)
+		s.Require().NoError(err)
+		s.Assert().Zero(balance)
+	})
+
+	s.Run("Multiple Balance Overrides", func() {
+		address := s.createAccount()
+		balanceCode := []byte(`access(all) fun main(address: Address): UFix64 { return getAccount(address).balance }`)
+
+		// the balance of the same account is overridden twice, the last override is applied on top of the first one
+		overrides := &flow.StateOverrides{
+			Balances: []flow.BalanceOverride{
+				{Address: service, Balance: 100_000_000},
+				{Address: address, Balance: 500_000_000},
+				{Address: address, Balance: 200_000_000},
+			},
+		}
+		args := [][]byte{jsoncdc.MustEncode(cadence.NewAddress(address))}
+
+		result, err := s.scripts.ExecuteAtBlockHeightWithOverrides(context.Background(), balanceCode, args, s.height, overrides)
+		s.Require().NoError(err)
+		s.Assert().Equal(jsoncdc.MustEncode(cadence.UFix64(200_000_000)), result.Value)
+		s.Assert().Equal(3, result.OverriddenBalances)
+	})
+
+	s.Run("Cancelled Balance Override", func() {
+		overrides := &flow.StateOverrides{
+			Balances: []flow.BalanceOverride{{Address: service, Balance: 1}},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := s.scripts.ExecuteAtBlockHeightWithOverrides(ctx, code, nil, s.height, overrides)
+		s.Require().ErrorIs(err, context.Canceled)
+	})
+
+	s.Run("Balance Override Without Vault", func() {
+		overrides := &flow.StateOverrides{
+			Balances: []flow.BalanceOverride{{Address: unittest.RandomAddressFixture(), Balance: 1}},
+		}
+
+		_, err := s.scripts.ExecuteAtBlockHeightWithOverrides(context.Background(), code, nil, s.height, overrides)
+		s.Require().ErrorIs(err, query.ErrInvalidStateOverrides)
+	})
+}
+
 func (s *scriptTestSuite) SetupTest() {
 	logger := unittest.LoggerForTest(s.Suite.T(), zerolog.InfoLevel)
 	entropyProvider := testutil.ProtocolStateWithSourceFixture(nil)
@@ -187,6 +434,7 @@ func (s *scriptTestSuite) SetupTest() {
 		nil,
 		nil,
 		nil,
+		nil,
 		flow.Testnet.Chain(),
 		derivedChainData,
 		nil,
@@ -232,6 +480,24 @@ func (s *scriptTestSuite) bootstrap() {
 	s.snapshot = s.snapshot.Append(executionSnapshot)
 }
 
+func (s *scriptTestSuite) deployContract(name string, code string) {
+	txBody := blueprints.DeployContractTransaction(s.chain.ServiceAddress(), []byte(code), name)
+
+	executionSnapshot, output, err := s.vm.Run(
+		s.vmCtx,
+		fvm.Transaction(txBody, 0),
+		s.snapshot,
+	)
+	s.Require().NoError(err)
+	s.Require().NoError(output.Err)
+
+	s.height++
+	err = s.registerIndex.Store(executionSnapshot.UpdatedRegisters(), s.height)
+	s.Require().NoError(err)
+
+	s.snapshot = s.snapshot.Append(executionSnapshot)
+}
+
 func (s *scriptTestSuite) createAccount() flow.Address {
 	const createAccountTransaction = `
 		transaction {
@@ -29,6 +29,15 @@ func WithStreamInterceptor() Option {
 	}
 }
 
+// WithRateLimiter sets the rate limiter used for unary calls to the grpc server. This allows sharing
+// the per-method limits with other servers. If not set, a new limiter is created from the rate limits
+// passed to the builder.
+func WithRateLimiter(limiter *rpc.RateLimiterInterceptor) Option {
+	return func(c *GrpcServerBuilder) {
+		c.rateLimiter = limiter
+	}
+}
+
 // GrpcServerBuilder created for separating the creation and starting GrpcServer,
 // cause services need to be registered before the server starts.
 type GrpcServerBuilder struct {
@@ -39,6 +48,7 @@ type GrpcServerBuilder struct {
 
 	transportCredentials         credentials.TransportCredentials // the GRPC credentials
 	stateStreamInterceptorEnable bool
+	rateLimiter                  *rpc.RateLimiterInterceptor
 }
 
 // NewGrpcServerBuilder creates a new builder for configuring and initializing a gRPC server.
@@ -117,11 +127,13 @@ func NewGrpcServerBuilder(log zerolog.Logger,
 			log.Info().Msg("stateStreamInterceptorEnable false")
 		}
 	}
-	if len(apiRateLimits) > 0 {
+	if grpcServerBuilder.rateLimiter == nil && len(apiRateLimits) > 0 {
 		// create a rate limit interceptor
-		rateLimitInterceptor := rpc.NewRateLimiterInterceptor(log, apiRateLimits, apiBurstLimits).UnaryServerInterceptor
+		grpcServerBuilder.rateLimiter = rpc.NewRateLimiterInterceptor(log, apiRateLimits, apiBurstLimits)
+	}
+	if grpcServerBuilder.rateLimiter != nil {
 		// append the rate limit interceptor to the list of interceptors
-		interceptors = append(interceptors, rateLimitInterceptor)
+		interceptors = append(interceptors, grpcServerBuilder.rateLimiter.UnaryServerInterceptor)
 	}
 	// add the logging interceptor, ensure it is innermost wrapper
 	interceptors = append(interceptors, rpc.LoggingInterceptor(log))
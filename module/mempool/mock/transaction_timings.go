@@ -111,6 +111,24 @@ func (_m *TransactionTimings) ByID(txID flow.Identifier) (*flow.TransactionTimin
 	return r0, r1
 }
 
+// Record provides a mock function with given fields: txID, event
+func (_m *TransactionTimings) Record(txID flow.Identifier, event flow.TransactionTimelineEvent) bool {
+	ret := _m.Called(txID, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Record")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(flow.Identifier, flow.TransactionTimelineEvent) bool); ok {
+		r0 = rf(txID, event)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // Remove provides a mock function with given fields: txID
 func (_m *TransactionTimings) Remove(txID flow.Identifier) bool {
 	ret := _m.Called(txID)
@@ -1,6 +1,8 @@
 package stdmap
 
 import (
+	"slices"
+
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -58,6 +60,41 @@ func (t *TransactionTimings) Adjust(txID flow.Identifier, f func(*flow.Transacti
 	return tt, updated
 }
 
+// Record adds the timeline event to the transaction timing with the given ID. Events are kept ordered
+// by timestamp, since stages can be recorded after later stages, e.g. when the collection of the
+// transaction is ingested after the block including it was finalized. Events with the same timestamp
+// keep the order they were recorded in. The event is dropped if the transaction is not tracked, or
+// if an event for the same stage was already recorded.
+// Returns true if the event was recorded.
+func (t *TransactionTimings) Record(txID flow.Identifier, event flow.TransactionTimelineEvent) bool {
+	recorded := false
+	_, _ = t.Backend.Adjust(txID, func(e flow.Entity) flow.Entity {
+		tt, ok := e.(*flow.TransactionTiming)
+		if !ok || tt.HasStage(event.Stage) {
+			return e
+		}
+		recorded = true
+
+		// timings returned by ByID may be read concurrently, so the timing is copied instead
+		// of inserting the event in place
+		i := slices.IndexFunc(tt.Events, func(recorded flow.TransactionTimelineEvent) bool {
+			return recorded.Timestamp.After(event.Timestamp)
+		})
+		if i < 0 {
+			i = len(tt.Events)
+		}
+		events := make([]flow.TransactionTimelineEvent, 0, len(tt.Events)+1)
+		events = append(events, tt.Events[:i]...)
+		events = append(events, event)
+		events = append(events, tt.Events[i:]...)
+
+		updated := *tt
+		updated.Events = events
+		return &updated
+	})
+	return recorded
+}
+
 // All returns all transaction timings from the mempool.
 func (t *TransactionTimings) All() []*flow.TransactionTiming {
 	entities := t.Backend.All()
@@ -71,3 +71,76 @@ func TestTransactionTimingsPool(t *testing.T) {
 		assert.Nil(t, entity)
 	})
 }
+
+func TestTransactionTimingsPoolRecord(t *testing.T) {
+	txID := unittest.IdentifierFixture()
+	received := flow.TransactionTimelineEvent{Stage: flow.TransactionStageReceived, Timestamp: time.Now()}
+
+	pool, err := stdmap.NewTransactionTimings(1000)
+	require.NoError(t, err)
+
+	t.Run("should not record events of untracked transactions", func(t *testing.T) {
+		recorded := pool.Record(txID, received)
+		assert.False(t, recorded)
+	})
+
+	t.Run("should be able to record new stage", func(t *testing.T) {
+		added := pool.Add(&flow.TransactionTiming{
+			TransactionID: txID,
+			Received:      received.Timestamp,
+			Events:        []flow.TransactionTimelineEvent{received},
+		})
+		require.True(t, added)
+
+		before, exists := pool.ByID(txID)
+		require.True(t, exists)
+
+		collected := flow.TransactionTimelineEvent{
+			Stage:      flow.TransactionStageCollected,
+			Timestamp:  time.Now(),
+			Attributes: map[string]string{flow.TimelineAttributeCollectionID: unittest.IdentifierFixture().String()},
+		}
+		recorded := pool.Record(txID, collected)
+		assert.True(t, recorded)
+
+		after, exists := pool.ByID(txID)
+		require.True(t, exists)
+		assert.Equal(t, []flow.TransactionTimelineEvent{received, collected}, after.Events)
+		assert.Equal(t, received.Timestamp, after.Received)
+
+		// previously returned timings are not modified
+		assert.Len(t, before.Events, 1)
+	})
+
+	t.Run("should not record stage twice", func(t *testing.T) {
+		recorded := pool.Record(txID, flow.TransactionTimelineEvent{Stage: flow.TransactionStageCollected, Timestamp: time.Now()})
+		assert.False(t, recorded)
+
+		tt, exists := pool.ByID(txID)
+		require.True(t, exists)
+		assert.Len(t, tt.Events, 2)
+	})
+
+	t.Run("should order events by timestamp", func(t *testing.T) {
+		txID := unittest.IdentifierFixture()
+		received := flow.TransactionTimelineEvent{Stage: flow.TransactionStageReceived, Timestamp: time.Now()}
+		added := pool.Add(&flow.TransactionTiming{
+			TransactionID: txID,
+			Received:      received.Timestamp,
+			Events:        []flow.TransactionTimelineEvent{received},
+		})
+		require.True(t, added)
+
+		// the collection is ingested after the block including it was finalized
+		collected := flow.TransactionTimelineEvent{Stage: flow.TransactionStageCollected, Timestamp: received.Timestamp.Add(2 * time.Second)}
+		included := flow.TransactionTimelineEvent{Stage: flow.TransactionStageIncluded, Timestamp: received.Timestamp.Add(time.Second)}
+		sealed := flow.TransactionTimelineEvent{Stage: flow.TransactionStageSealed, Timestamp: collected.Timestamp}
+		require.True(t, pool.Record(txID, collected))
+		require.True(t, pool.Record(txID, included))
+		require.True(t, pool.Record(txID, sealed))
+
+		tt, exists := pool.ByID(txID)
+		require.True(t, exists)
+		assert.Equal(t, []flow.TransactionTimelineEvent{received, included, collected, sealed}, tt.Events)
+	})
+}
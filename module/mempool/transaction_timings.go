@@ -17,6 +17,11 @@ type TransactionTimings interface {
 	Adjust(txID flow.Identifier, f func(*flow.TransactionTiming) *flow.TransactionTiming) (*flow.TransactionTiming,
 		bool)
 
+	// Record appends the timeline event to the transaction timing with the given ID. The event is
+	// dropped if the transaction is not tracked, or if an event for the same stage was already recorded.
+	// Returns true if the event was recorded.
+	Record(txID flow.Identifier, event flow.TransactionTimelineEvent) bool
+
 	// All returns all transaction timings from the mempool.
 	All() []*flow.TransactionTiming
 
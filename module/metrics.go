@@ -820,6 +820,25 @@ type ExecutionStateIndexerMetrics interface {
 	InitializeLatestHeight(height uint64)
 }
 
+type RegisterPrunerMetrics interface {
+	// RegistersPruned records a pruning run of the register index, which removed the given number of
+	// register values below the pruned height.
+	RegistersPruned(prunedHeight uint64, prunedValues uint64, duration time.Duration)
+}
+
+type ShadowExecutionMetrics interface {
+	// ShadowBlockExecuted records the shadow execution of a block with a candidate configuration, and the
+	// number of its transactions which diverged from the primary execution.
+	ShadowBlockExecuted(duration time.Duration, transactions int, divergedTransactions int)
+
+	// ShadowBlockSkipped records a block which was not shadow executed, either because the shadow execution
+	// queue was full or because the candidate failed to execute the block.
+	ShadowBlockSkipped(reason string)
+
+	// ShadowDivergence records a divergence of a transaction of the given kind, e.g. events or errors.
+	ShadowDivergence(kind string)
+}
+
 type RuntimeMetrics interface {
 	// RuntimeTransactionParsed reports the time spent parsing a single transaction
 	RuntimeTransactionParsed(dur time.Duration)
@@ -912,6 +931,7 @@ type AccessMetrics interface {
 	TransactionMetrics
 	TransactionValidationMetrics
 	BackendScriptsMetrics
+	ScriptResultCacheMetrics
 
 	// UpdateExecutionReceiptMaxHeight is called whenever we store an execution receipt from a block from a newer height
 	UpdateExecutionReceiptMaxHeight(height uint64)
@@ -1070,6 +1090,22 @@ type BackendScriptsMetrics interface {
 	ScriptExecutionNotIndexed()
 }
 
+type ScriptResultCacheMetrics interface {
+	// ScriptResultCacheHit records a script execution served from the script result cache
+	ScriptResultCacheHit()
+
+	// ScriptResultCacheMiss records a cacheable script execution which was not found in the
+	// script result cache
+	ScriptResultCacheMiss()
+
+	// ScriptResultCacheBypassed records a script execution for which the client requested to
+	// bypass the script result cache
+	ScriptResultCacheBypassed()
+
+	// ScriptResultCacheSize records the number of results in the script result cache
+	ScriptResultCacheSize(size int)
+}
+
 type TransactionMetrics interface {
 	// Record the round trip time while getting a transaction result
 	TransactionResultFetched(dur time.Duration, size int)
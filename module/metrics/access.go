@@ -50,6 +50,11 @@ type AccessCollector struct {
 	lastFullBlockHeight   prometheus.Gauge
 	maxReceiptHeight      prometheus.Gauge
 
+	scriptResultCacheHits     prometheus.Counter
+	scriptResultCacheMisses   prometheus.Counter
+	scriptResultCacheBypassed prometheus.Counter
+	scriptResultCacheSize     prometheus.Gauge
+
 	// used to skip heights that are lower than the current max height
 	maxReceiptHeightValue counters.StrictMonotonicCounter
 }
@@ -112,6 +117,30 @@ func NewAccessCollector(opts ...AccessCollectorOpts) *AccessCollector {
 			Subsystem: subsystemIngestion,
 			Help:      "gauge to track the maximum block height of execution receipts received",
 		}),
+		scriptResultCacheHits: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "script_result_cache_hits_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemCache,
+			Help:      "counter for the number of script executions served from the script result cache",
+		}),
+		scriptResultCacheMisses: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "script_result_cache_misses_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemCache,
+			Help:      "counter for the number of cacheable script executions not found in the script result cache",
+		}),
+		scriptResultCacheBypassed: promauto.NewCounter(prometheus.CounterOpts{
+			Name:      "script_result_cache_bypassed_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemCache,
+			Help:      "counter for the number of script executions which requested to bypass the script result cache",
+		}),
+		scriptResultCacheSize: promauto.NewGauge(prometheus.GaugeOpts{
+			Name:      "script_result_cache_size",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemCache,
+			Help:      "gauge to track the number of results in the script result cache",
+		}),
 		maxReceiptHeightValue: counters.NewMonotonicCounter(0),
 	}
 
@@ -160,3 +189,19 @@ func (ac *AccessCollector) UpdateExecutionReceiptMaxHeight(height uint64) {
 		ac.maxReceiptHeight.Set(float64(height))
 	}
 }
+
+func (ac *AccessCollector) ScriptResultCacheHit() {
+	ac.scriptResultCacheHits.Inc()
+}
+
+func (ac *AccessCollector) ScriptResultCacheMiss() {
+	ac.scriptResultCacheMisses.Inc()
+}
+
+func (ac *AccessCollector) ScriptResultCacheBypassed() {
+	ac.scriptResultCacheBypassed.Inc()
+}
+
+func (ac *AccessCollector) ScriptResultCacheSize(size int) {
+	ac.scriptResultCacheSize.Set(float64(size))
+}
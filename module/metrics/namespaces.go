@@ -76,6 +76,7 @@ const (
 	subsystemEVM               = "evm"
 	subsystemProvider          = "provider"
 	subsystemBlockDataUploader = "block_data_uploader"
+	subsystemShadowExecution   = "shadow_execution"
 )
 
 // Verification Subsystems
@@ -95,6 +96,7 @@ const (
 	subsystemExeDataPruner          = "pruner"
 	subsystemExecutionDataRequester = "execution_data_requester"
 	subsystemExecutionStateIndexer  = "execution_state_indexer"
+	subsystemRegisterPruner         = "register_pruner"
 	subsystemExeDataBlobstore       = "blobstore"
 )
 
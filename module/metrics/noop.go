@@ -36,6 +36,7 @@ func (nc *NoopCollector) BlockProposalDuration(duration time.Duration)
 
 // interface check
 var _ module.BackendScriptsMetrics = (*NoopCollector)(nil)
+var _ module.ScriptResultCacheMetrics = (*NoopCollector)(nil)
 var _ module.TransactionMetrics = (*NoopCollector)(nil)
 var _ module.TransactionValidationMetrics = (*NoopCollector)(nil)
 var _ module.HotstuffMetrics = (*NoopCollector)(nil)
@@ -214,6 +215,10 @@ func (nc *NoopCollector) ScriptExecutionResultMatch()
 func (nc *NoopCollector) ScriptExecutionErrorMismatch()                                         {}
 func (nc *NoopCollector) ScriptExecutionErrorMatch()                                            {}
 func (nc *NoopCollector) ScriptExecutionNotIndexed()                                            {}
+func (nc *NoopCollector) ScriptResultCacheHit()                                                 {}
+func (nc *NoopCollector) ScriptResultCacheMiss()                                                {}
+func (nc *NoopCollector) ScriptResultCacheBypassed()                                            {}
+func (nc *NoopCollector) ScriptResultCacheSize(size int)                                        {}
 func (nc *NoopCollector) TransactionResultFetched(dur time.Duration, size int)                  {}
 func (nc *NoopCollector) TransactionReceived(txID flow.Identifier, when time.Time)              {}
 func (nc *NoopCollector) TransactionFinalized(txID flow.Identifier, when time.Time)             {}
@@ -374,6 +379,16 @@ func (nc *NoopCollector) BlockIndexed(uint64, time.Duration, int, int, int) {}
 func (nc *NoopCollector) BlockReindexed()                                   {}
 func (nc *NoopCollector) InitializeLatestHeight(height uint64)              {}
 
+var _ module.RegisterPrunerMetrics = (*NoopCollector)(nil)
+
+func (nc *NoopCollector) RegistersPruned(uint64, uint64, time.Duration) {}
+
+var _ module.ShadowExecutionMetrics = (*NoopCollector)(nil)
+
+func (nc *NoopCollector) ShadowBlockExecuted(time.Duration, int, int) {}
+func (nc *NoopCollector) ShadowBlockSkipped(string)                   {}
+func (nc *NoopCollector) ShadowDivergence(string)                     {}
+
 var _ module.GossipSubScoringRegistryMetrics = (*NoopCollector)(nil)
 
 func (nc *NoopCollector) DuplicateMessagePenalties(penalty float64) {}
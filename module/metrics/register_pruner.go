@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/module"
+)
+
+var _ module.RegisterPrunerMetrics = (*RegisterPrunerCollector)(nil)
+
+type RegisterPrunerCollector struct {
+	pruneDuration prometheus.Histogram
+	prunedHeight  prometheus.Gauge
+	prunedValues  prometheus.Counter
+}
+
+func NewRegisterPrunerCollector() *RegisterPrunerCollector {
+	return &RegisterPrunerCollector{
+		pruneDuration: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRegisterPruner,
+			Name:      "prune_duration_seconds",
+			Help:      "the duration of a pruning run of the register index",
+			Buckets:   []float64{1, 10, 60, 600, 3600, 6 * 3600},
+		}),
+		prunedHeight: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRegisterPruner,
+			Name:      "pruned_height",
+			Help:      "the height below which register values have been pruned",
+		}),
+		prunedValues: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceAccess,
+			Subsystem: subsystemRegisterPruner,
+			Name:      "pruned_values_total",
+			Help:      "the number of register values removed by pruning",
+		}),
+	}
+}
+
+func (c *RegisterPrunerCollector) RegistersPruned(prunedHeight uint64, prunedValues uint64, duration time.Duration) {
+	c.pruneDuration.Observe(duration.Seconds())
+	c.prunedHeight.Set(float64(prunedHeight))
+	c.prunedValues.Add(float64(prunedValues))
+}
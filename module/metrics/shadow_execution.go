@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/onflow/flow-go/module"
+)
+
+var _ module.ShadowExecutionMetrics = (*ShadowExecutionCollector)(nil)
+
+type ShadowExecutionCollector struct {
+	blockExecutionDuration prometheus.Histogram
+	blocksExecuted         prometheus.Counter
+	blocksDiverged         prometheus.Counter
+	transactionsExecuted   prometheus.Counter
+	transactionsDiverged   prometheus.Counter
+	blocksSkipped          *prometheus.CounterVec
+	divergences            *prometheus.CounterVec
+}
+
+func NewShadowExecutionCollector() *ShadowExecutionCollector {
+	return &ShadowExecutionCollector{
+		blockExecutionDuration: promauto.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemShadowExecution,
+			Name:      "block_execution_duration_seconds",
+			Help:      "the duration of the shadow execution of a block",
+			Buckets:   []float64{0.05, 0.2, 0.5, 1, 2, 5, 10, 30},
+		}),
+		blocksExecuted: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemShadowExecution,
+			Name:      "blocks_executed_total",
+			Help:      "the number of blocks executed with the candidate configuration",
+		}),
+		blocksDiverged: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemShadowExecution,
+			Name:      "blocks_diverged_total",
+			Help:      "the number of shadow executed blocks with at least one diverged transaction",
+		}),
+		transactionsExecuted: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemShadowExecution,
+			Name:      "transactions_executed_total",
+			Help:      "the number of transactions executed with the candidate configuration",
+		}),
+		transactionsDiverged: promauto.NewCounter(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemShadowExecution,
+			Name:      "transactions_diverged_total",
+			Help:      "the number of shadow executed transactions which diverged from the primary execution",
+		}),
+		blocksSkipped: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemShadowExecution,
+			Name:      "blocks_skipped_total",
+			Help:      "the number of blocks which were not shadow executed, by reason",
+		}, []string{"reason"}),
+		divergences: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceExecution,
+			Subsystem: subsystemShadowExecution,
+			Name:      "divergences_total",
+			Help:      "the number of divergences of shadow executed transactions, by kind",
+		}, []string{"kind"}),
+	}
+}
+
+func (c *ShadowExecutionCollector) ShadowBlockExecuted(duration time.Duration, transactions int, divergedTransactions int) {
+	c.blockExecutionDuration.Observe(duration.Seconds())
+	c.blocksExecuted.Inc()
+	c.transactionsExecuted.Add(float64(transactions))
+	c.transactionsDiverged.Add(float64(divergedTransactions))
+	if divergedTransactions > 0 {
+		c.blocksDiverged.Inc()
+	}
+}
+
+func (c *ShadowExecutionCollector) ShadowBlockSkipped(reason string) {
+	c.blocksSkipped.WithLabelValues(reason).Inc()
+}
+
+func (c *ShadowExecutionCollector) ShadowDivergence(kind string) {
+	c.divergences.WithLabelValues(kind).Inc()
+}
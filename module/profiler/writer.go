@@ -0,0 +1,131 @@
+package profiler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/rs/zerolog"
+	"go.uber.org/multierr"
+
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+// ProfileSource provides profiles which are not collected by the Go runtime, e.g. profiles of the
+// computation used by Cadence programs.
+type ProfileSource interface {
+	// Flush returns the profile of the usage recorded since the previous call.
+	Flush() *profile.Profile
+}
+
+// ProfileWriter periodically writes the profile of a source to the profile directory.
+// Empty profiles are skipped.
+type ProfileWriter struct {
+	component.Component
+
+	log      zerolog.Logger
+	source   ProfileSource
+	dir      string
+	name     string
+	interval time.Duration
+}
+
+// NewProfileWriter creates a new ProfileWriter which writes the profile of the source to
+// <dir>/<name>-<time>.pb.gz every interval.
+func NewProfileWriter(
+	log zerolog.Logger,
+	source ProfileSource,
+	dir string,
+	name string,
+	interval time.Duration,
+) (*ProfileWriter, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("could not create profile dir %v: %w", dir, err)
+	}
+
+	w := &ProfileWriter{
+		log:      log.With().Str("component", "profile-writer").Str("profile", name).Logger(),
+		source:   source,
+		dir:      dir,
+		name:     name,
+		interval: interval,
+	}
+
+	w.Component = component.NewComponentManagerBuilder().
+		AddWorker(w.writeWorker).
+		Build()
+
+	return w, nil
+}
+
+func (w *ProfileWriter) writeWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	t := time.NewTicker(w.interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			prof := w.source.Flush()
+			if len(prof.Sample) == 0 {
+				continue
+			}
+
+			name := fmt.Sprintf("%s-%s", w.name, time.Now().Format(time.RFC3339))
+			path, err := WriteProfile(w.dir, name, prof)
+			if err != nil {
+				w.log.Error().Err(err).Msg("failed to write profile")
+				continue
+			}
+			w.log.Info().Str("file", path).Msg("profile written")
+		}
+	}
+}
+
+// WriteProfile writes the profile to <dir>/<name>.pb.gz, and returns the path of the profile.
+// The file is written atomically, so that partially written profiles are never observed.
+//
+// No errors are expected during normal operation.
+func WriteProfile(dir string, name string, prof *profile.Profile) (path string, err error) {
+	path = filepath.Join(dir, name+".pb.gz")
+
+	f, err := os.CreateTemp(dir, "profile")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp profile: %w", err)
+	}
+
+	// Remove temp file if it still exists.
+	defer func() {
+		if _, statErr := os.Stat(f.Name()); errors.Is(statErr, os.ErrNotExist) {
+			return
+		}
+		multierr.AppendInto(&err, os.Remove(f.Name()))
+	}()
+
+	err = prof.Write(f)
+	multierr.AppendInto(&err, f.Close())
+	if err != nil {
+		return "", fmt.Errorf("failed to write profile: %w", err)
+	}
+
+	// default CreateTemp permissions are 0600.
+	err = os.Chmod(f.Name(), 0644)
+	if err != nil {
+		return "", fmt.Errorf("failed to set profile permissions: %w", err)
+	}
+
+	err = os.Rename(f.Name(), path)
+	if err != nil {
+		return "", fmt.Errorf("failed to rename profile: %w", err)
+	}
+
+	return path, nil
+}
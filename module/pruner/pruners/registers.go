@@ -0,0 +1,127 @@
+package pruners
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/storage"
+)
+
+type RegisterPrunerConfig struct {
+	Threshold                 uint64        // The number of most recent heights for which register values are retained.
+	BatchSize                 uint          // The number of register values deleted in one batch.
+	SleepAfterEachBatchCommit time.Duration // The sleep time after each batch commit.
+	PruningInterval           time.Duration // The time between two pruning runs.
+}
+
+var DefaultRegisterPrunerConfig = RegisterPrunerConfig{
+	Threshold:                 100_000,
+	BatchSize:                 10_000,
+	SleepAfterEachBatchCommit: 100 * time.Millisecond,
+	PruningInterval:           6 * time.Hour,
+}
+
+// RegisterPruner periodically removes the register values which are older than the configured
+// retention window from the register index.
+//
+// For each register, the most recent value below the pruned height is retained, so that reads at or
+// above the pruned height continue to return correct values.
+type RegisterPruner struct {
+	component.Component
+
+	log       zerolog.Logger
+	metrics   module.RegisterPrunerMetrics
+	registers storage.PrunableRegisterIndex
+	config    RegisterPrunerConfig
+}
+
+// NewRegisterPruner creates a component which prunes the given register index.
+func NewRegisterPruner(
+	log zerolog.Logger,
+	metrics module.RegisterPrunerMetrics,
+	registers storage.PrunableRegisterIndex,
+	config RegisterPrunerConfig,
+) (*RegisterPruner, error) {
+	if config.Threshold == 0 {
+		return nil, fmt.Errorf("pruning threshold must be greater than 0")
+	}
+	if config.PruningInterval <= 0 {
+		return nil, fmt.Errorf("pruning interval must be greater than 0")
+	}
+
+	p := &RegisterPruner{
+		log:       log.With().Str("component", "register-pruner").Logger(),
+		metrics:   metrics,
+		registers: registers,
+		config:    config,
+	}
+
+	p.Component = component.NewComponentManagerBuilder().
+		AddWorker(p.loop).
+		Build()
+
+	return p, nil
+}
+
+// loop prunes the register index once at startup, and after every pruning interval.
+func (p *RegisterPruner) loop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	ticker := time.NewTicker(p.config.PruningInterval)
+	defer ticker.Stop()
+
+	for {
+		err := p.Prune(ctx)
+		if err != nil {
+			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+				return
+			}
+			ctx.Throw(err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune removes the register values below the retention window once.
+//
+// Expected errors:
+// - context.Canceled or context.DeadlineExceeded if ctx is done before pruning completes.
+func (p *RegisterPruner) Prune(ctx context.Context) error {
+	latestHeight := p.registers.LatestHeight()
+	if latestHeight <= p.config.Threshold {
+		return nil
+	}
+
+	pruneHeight := latestHeight - p.config.Threshold
+
+	start := time.Now()
+	pruned, err := p.registers.PruneUpToHeight(ctx, pruneHeight, p.config.BatchSize, p.config.SleepAfterEachBatchCommit)
+	if err != nil {
+		return fmt.Errorf("failed to prune registers up to height %d: %w", pruneHeight, err)
+	}
+	duration := time.Since(start)
+
+	p.metrics.RegistersPruned(pruneHeight, pruned, duration)
+
+	p.log.Info().
+		Uint64("prune_height", pruneHeight).
+		Uint64("latest_height", latestHeight).
+		Uint64("pruned_values", pruned).
+		Dur("duration", duration).
+		Msg("pruned register index")
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package pruners
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestRegisterPruner_Prune(t *testing.T) {
+	config := RegisterPrunerConfig{
+		Threshold:                 100,
+		BatchSize:                 10,
+		SleepAfterEachBatchCommit: time.Millisecond,
+		PruningInterval:           time.Hour,
+	}
+
+	t.Run("prunes below the retention window", func(t *testing.T) {
+		registers := storagemock.NewPrunableRegisterIndex(t)
+		registers.On("LatestHeight").Return(uint64(1000)).Once()
+		registers.On("PruneUpToHeight", mock.Anything, uint64(900), config.BatchSize, config.SleepAfterEachBatchCommit).
+			Return(uint64(42), nil).Once()
+
+		pruner, err := NewRegisterPruner(unittest.Logger(), metrics.NewNoopCollector(), registers, config)
+		require.NoError(t, err)
+		require.NoError(t, pruner.Prune(context.Background()))
+	})
+
+	t.Run("skips pruning within the retention window", func(t *testing.T) {
+		registers := storagemock.NewPrunableRegisterIndex(t)
+		registers.On("LatestHeight").Return(uint64(100)).Once()
+
+		pruner, err := NewRegisterPruner(unittest.Logger(), metrics.NewNoopCollector(), registers, config)
+		require.NoError(t, err)
+		require.NoError(t, pruner.Prune(context.Background()))
+	})
+
+	t.Run("returns pruning errors", func(t *testing.T) {
+		registers := storagemock.NewPrunableRegisterIndex(t)
+		registers.On("LatestHeight").Return(uint64(1000)).Once()
+		registers.On("PruneUpToHeight", mock.Anything, uint64(900), config.BatchSize, config.SleepAfterEachBatchCommit).
+			Return(uint64(0), context.Canceled).Once()
+
+		pruner, err := NewRegisterPruner(unittest.Logger(), metrics.NewNoopCollector(), registers, config)
+		require.NoError(t, err)
+		require.ErrorIs(t, pruner.Prune(context.Background()), context.Canceled)
+	})
+
+	t.Run("rejects invalid config", func(t *testing.T) {
+		registers := storagemock.NewPrunableRegisterIndex(t)
+
+		invalid := config
+		invalid.Threshold = 0
+		_, err := NewRegisterPruner(unittest.Logger(), metrics.NewNoopCollector(), registers, invalid)
+		require.Error(t, err)
+
+		invalid = config
+		invalid.PruningInterval = 0
+		_, err = NewRegisterPruner(unittest.Logger(), metrics.NewNoopCollector(), registers, invalid)
+		require.Error(t, err)
+	})
+}
+
+// TestRegisterPruner_Component tests that the pruner prunes on startup, and stops pruning on shutdown.
+func TestRegisterPruner_Component(t *testing.T) {
+	config := DefaultRegisterPrunerConfig
+	config.Threshold = 100
+
+	pruned := make(chan struct{})
+	registers := storagemock.NewPrunableRegisterIndex(t)
+	registers.On("LatestHeight").Return(uint64(1000))
+	registers.On("PruneUpToHeight", mock.Anything, uint64(900), config.BatchSize, config.SleepAfterEachBatchCommit).
+		Run(func(mock.Arguments) { close(pruned) }).
+		Return(uint64(1), nil).Once()
+
+	pruner, err := NewRegisterPruner(unittest.Logger(), metrics.NewNoopCollector(), registers, config)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	signalerCtx, errChan := irrecoverable.WithSignaler(ctx)
+	pruner.Start(signalerCtx)
+
+	unittest.RequireCloseBefore(t, pruner.Ready(), time.Second, "pruner did not start")
+	unittest.RequireCloseBefore(t, pruned, time.Second, "pruner did not prune")
+
+	cancel()
+	unittest.RequireCloseBefore(t, pruner.Done(), time.Second, "pruner did not stop")
+	select {
+	case err := <-errChan:
+		require.NoError(t, err)
+	default:
+	}
+}
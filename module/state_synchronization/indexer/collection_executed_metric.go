@@ -2,12 +2,14 @@ package indexer
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/storage"
 )
@@ -27,6 +29,8 @@ type CollectionExecutedMetricImpl struct {
 	blocks      storage.Blocks
 
 	blockTransactions *stdmap.IdentifierMap // Map to track transactions for each block for sealed metrics
+
+	transactionTimelines mempool.TransactionTimings // nil if transaction timelines are not tracked
 }
 
 func NewCollectionExecutedMetricImpl(
@@ -38,6 +42,7 @@ func NewCollectionExecutedMetricImpl(
 	collections storage.Collections,
 	blocks storage.Blocks,
 	blockTransactions *stdmap.IdentifierMap,
+	transactionTimelines mempool.TransactionTimings,
 ) (*CollectionExecutedMetricImpl, error) {
 	return &CollectionExecutedMetricImpl{
 		log:                        log,
@@ -48,12 +53,22 @@ func NewCollectionExecutedMetricImpl(
 		collections:                collections,
 		blocks:                     blocks,
 		blockTransactions:          blockTransactions,
+		transactionTimelines:       transactionTimelines,
 	}, nil
 }
 
-// CollectionFinalized tracks collections to mark finalized
+// CollectionFinalized is called when a collection is ingested. It records the collection in the timelines
+// of its transactions, and tracks collections to mark finalized.
 func (c *CollectionExecutedMetricImpl) CollectionFinalized(light flow.LightCollection) {
 	lightID := light.ID()
+
+	collected := time.Now().UTC()
+	for _, t := range light.Transactions {
+		c.recordTimelineEvent(t, flow.TransactionStageCollected, collected, map[string]string{
+			flow.TimelineAttributeCollectionID: lightID.String(),
+		})
+	}
+
 	if ti, found := c.collectionsToMarkFinalized.ByID(lightID); found {
 
 		block, err := c.blocks.ByCollectionID(lightID)
@@ -65,6 +80,7 @@ func (c *CollectionExecutedMetricImpl) CollectionFinalized(light flow.LightColle
 
 		for _, t := range light.Transactions {
 			c.accessMetrics.TransactionFinalized(t, ti)
+			c.transactionIncluded(t, block.Header, ti)
 
 			err = c.blockTransactions.Append(blockID, t)
 			if err != nil {
@@ -81,6 +97,9 @@ func (c *CollectionExecutedMetricImpl) CollectionExecuted(light flow.LightCollec
 	if ti, found := c.collectionsToMarkExecuted.ByID(light.ID()); found {
 		for _, t := range light.Transactions {
 			c.accessMetrics.TransactionExecuted(t, ti)
+			c.recordTimelineEvent(t, flow.TransactionStageExecuted, ti, map[string]string{
+				flow.TimelineAttributeCollectionID: light.ID().String(),
+			})
 		}
 		c.collectionsToMarkExecuted.Remove(light.ID())
 	}
@@ -107,6 +126,7 @@ func (c *CollectionExecutedMetricImpl) BlockFinalized(block *flow.Block) {
 
 		for _, t := range l.Transactions {
 			c.accessMetrics.TransactionFinalized(t, now)
+			c.transactionIncluded(t, block.Header, now)
 			err = c.blockTransactions.Append(blockID, t)
 
 			if err != nil {
@@ -123,13 +143,16 @@ func (c *CollectionExecutedMetricImpl) BlockFinalized(block *flow.Block) {
 		if found {
 			for _, t := range transactions {
 				c.accessMetrics.TransactionSealed(t, now)
+				c.recordTimelineEvent(t, flow.TransactionStageSealed, now, map[string]string{
+					flow.TimelineAttributeBlockID: s.BlockID.String(),
+				})
 			}
 			c.blockTransactions.Remove(s.BlockID)
 		}
 	}
 
 	if ti, found := c.blocksToMarkExecuted.ByID(blockID); found {
-		c.blockExecuted(block, ti)
+		c.blockExecuted(block, ti, flow.ZeroID)
 		c.accessMetrics.UpdateExecutionReceiptMaxHeight(block.Header.Height)
 		c.blocksToMarkExecuted.Remove(blockID)
 	}
@@ -156,15 +179,23 @@ func (c *CollectionExecutedMetricImpl) ExecutionReceiptReceived(r *flow.Executio
 
 	c.accessMetrics.UpdateExecutionReceiptMaxHeight(b.Header.Height)
 
-	c.blockExecuted(b, now)
+	c.blockExecuted(b, now, r.ExecutorID)
 }
 
 func (c *CollectionExecutedMetricImpl) UpdateLastFullBlockHeight(height uint64) {
 	c.accessMetrics.UpdateLastFullBlockHeight(height)
 }
 
-// blockExecuted tracks executed metric for block
-func (c *CollectionExecutedMetricImpl) blockExecuted(block *flow.Block, ti time.Time) {
+// blockExecuted tracks executed metric for block. The executor ID is flow.ZeroID if the executor is not known.
+func (c *CollectionExecutedMetricImpl) blockExecuted(block *flow.Block, ti time.Time, executorID flow.Identifier) {
+	attributes := map[string]string{
+		flow.TimelineAttributeBlockID:     block.ID().String(),
+		flow.TimelineAttributeBlockHeight: strconv.FormatUint(block.Header.Height, 10),
+	}
+	if executorID != flow.ZeroID {
+		attributes[flow.TimelineAttributeExecutorID] = executorID.String()
+	}
+
 	// mark all transactions as executed
 	// TODO: sample to reduce performance overhead
 	for _, g := range block.Payload.Guarantees {
@@ -180,6 +211,29 @@ func (c *CollectionExecutedMetricImpl) blockExecuted(block *flow.Block, ti time.
 
 		for _, t := range l.Transactions {
 			c.accessMetrics.TransactionExecuted(t, ti)
+			c.recordTimelineEvent(t, flow.TransactionStageExecuted, ti, attributes)
 		}
 	}
 }
+
+// transactionIncluded records the inclusion of the transaction's collection in a finalized block in the
+// timeline of the transaction.
+func (c *CollectionExecutedMetricImpl) transactionIncluded(txID flow.Identifier, header *flow.Header, ti time.Time) {
+	c.recordTimelineEvent(txID, flow.TransactionStageIncluded, ti, map[string]string{
+		flow.TimelineAttributeBlockID:     header.ID().String(),
+		flow.TimelineAttributeBlockHeight: strconv.FormatUint(header.Height, 10),
+	})
+}
+
+// recordTimelineEvent records the stage in the timeline of the transaction, if transaction timelines are tracked.
+func (c *CollectionExecutedMetricImpl) recordTimelineEvent(txID flow.Identifier, stage flow.TransactionStage, ti time.Time, attributes map[string]string) {
+	if c.transactionTimelines == nil {
+		return
+	}
+
+	_ = c.transactionTimelines.Record(txID, flow.TransactionTimelineEvent{
+		Stage:      stage,
+		Timestamp:  ti,
+		Attributes: attributes,
+	})
+}
@@ -0,0 +1,81 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestCollectionExecutedMetric_TransactionTimeline tests that the collection of a transaction is recorded
+// in its timeline when the collection is ingested, independently of the block including it, and that the
+// timeline stays ordered by timestamp when the collection is ingested after the block was finalized.
+func TestCollectionExecutedMetric_TransactionTimeline(t *testing.T) {
+	collectionsToMarkFinalized, err := stdmap.NewTimes(100)
+	require.NoError(t, err)
+	collectionsToMarkExecuted, err := stdmap.NewTimes(100)
+	require.NoError(t, err)
+	blocksToMarkExecuted, err := stdmap.NewTimes(100)
+	require.NoError(t, err)
+	blockTransactions, err := stdmap.NewIdentifierMap(100)
+	require.NoError(t, err)
+	timelines, err := stdmap.NewTransactionTimings(100)
+	require.NoError(t, err)
+
+	collections := storagemock.NewCollections(t)
+	blocks := storagemock.NewBlocks(t)
+
+	collectionExecutedMetric, err := NewCollectionExecutedMetricImpl(
+		unittest.Logger(),
+		metrics.NewNoopCollector(),
+		collectionsToMarkFinalized,
+		collectionsToMarkExecuted,
+		blocksToMarkExecuted,
+		collections,
+		blocks,
+		blockTransactions,
+		timelines,
+	)
+	require.NoError(t, err)
+
+	collection := unittest.CollectionFixture(1)
+	light := collection.Light()
+	txID := light.Transactions[0]
+
+	block := unittest.BlockFixture()
+	block.SetPayload(unittest.PayloadFixture(unittest.WithGuarantees(&flow.CollectionGuarantee{
+		CollectionID: light.ID(),
+	})))
+
+	added := timelines.Add(&flow.TransactionTiming{TransactionID: txID})
+	require.True(t, added)
+
+	// the block is finalized before the collection was ingested
+	collections.On("LightByID", light.ID()).Return(nil, storage.ErrNotFound).Once()
+	collectionExecutedMetric.BlockFinalized(&block)
+
+	included := time.Now().UTC()
+	blocks.On("ByCollectionID", light.ID()).Return(&block, nil).Once()
+	collectionExecutedMetric.CollectionFinalized(light)
+
+	timing, found := timelines.ByID(txID)
+	require.True(t, found)
+	require.Len(t, timing.Events, 2)
+
+	// the collection is recorded after the inclusion, so the events are ordered by timestamp
+	assert.Equal(t, flow.TransactionStageIncluded, timing.Events[0].Stage)
+	assert.Equal(t, block.ID().String(), timing.Events[0].Attributes[flow.TimelineAttributeBlockID])
+	assert.True(t, timing.Events[0].Timestamp.Before(included))
+
+	assert.Equal(t, flow.TransactionStageCollected, timing.Events[1].Stage)
+	assert.Equal(t, light.ID().String(), timing.Events[1].Attributes[flow.TimelineAttributeCollectionID])
+	assert.False(t, timing.Events[1].Timestamp.Before(included))
+}
@@ -32,6 +32,9 @@ type IndexerCore struct {
 	results      storage.LightTransactionResults
 	batcher      bstorage.BatchBuilder
 
+	// accountTransactions is optional, indexing transactions by account is disabled if nil
+	accountTransactions storage.AccountTransactions
+
 	collectionExecutedMetric module.CollectionExecutedMetric
 
 	derivedChainData *derived.DerivedChainData
@@ -41,6 +44,7 @@ type IndexerCore struct {
 // New execution state indexer used to ingest block execution data and index it by height.
 // The passed RegisterIndex storage must be populated to include the first and last height otherwise the indexer
 // won't be initialized to ensure we have bootstrapped the storage first.
+// The passed AccountTransactions storage is optional, if nil transactions are not indexed by account.
 func New(
 	log zerolog.Logger,
 	metrics module.ExecutionStateIndexerMetrics,
@@ -51,6 +55,7 @@ func New(
 	collections storage.Collections,
 	transactions storage.Transactions,
 	results storage.LightTransactionResults,
+	accountTransactions storage.AccountTransactions,
 	chain flow.Chain,
 	derivedChainData *derived.DerivedChainData,
 	collectionExecutedMetric module.CollectionExecutedMetric,
@@ -76,6 +81,8 @@ func New(
 		serviceAddress:   chain.ServiceAddress(),
 		derivedChainData: derivedChainData,
 
+		accountTransactions: accountTransactions,
+
 		collectionExecutedMetric: collectionExecutedMetric,
 	}, nil
 }
@@ -157,6 +164,14 @@ func (c *IndexerCore) IndexBlockData(data *execution_data.BlockExecutionDataEnti
 			return fmt.Errorf("could not index transaction results at height %d: %w", header.Height, err)
 		}
 
+		if c.accountTransactions != nil {
+			entries := accountTransactionEntries(header.Height, data.ChunkExecutionDatas)
+			err = c.accountTransactions.BatchStore(header.Height, entries, batch)
+			if err != nil {
+				return fmt.Errorf("could not index account transactions at height %d: %w", header.Height, err)
+			}
+		}
+
 		batch.Flush()
 		if err != nil {
 			return fmt.Errorf("batch flush error: %w", err)
@@ -212,6 +212,7 @@ func (i *indexCoreTest) initIndexer() *indexCoreTest {
 		i.collections,
 		blocks,
 		blockTransactions,
+		nil,
 	)
 	require.NoError(i.t, err)
 
@@ -228,6 +229,7 @@ func (i *indexCoreTest) initIndexer() *indexCoreTest {
 		i.collections,
 		i.transactions,
 		i.results,
+		nil,
 		flow.Testnet.Chain(),
 		derivedChainData,
 		collectionExecutedMetric,
@@ -706,6 +708,7 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
@@ -740,6 +743,7 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
@@ -767,6 +771,7 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
@@ -811,6 +816,7 @@ func TestIndexerIntegration_StoreAndGet(t *testing.T) {
 				nil,
 				nil,
 				nil,
+				nil,
 				flow.Testnet.Chain(),
 				derivedChainData,
 				nil,
@@ -11,6 +11,7 @@ import (
 	"github.com/onflow/flow-go/fvm/storage/derived"
 	"github.com/onflow/flow-go/fvm/storage/snapshot"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 )
 
 var (
@@ -33,6 +34,55 @@ func hasAuthorizedTransaction(collections []*flow.Collection, address flow.Addre
 	return false
 }
 
+// accountTransactionEntries returns the account-to-transaction index entries for all transactions
+// within the provided chunks, except for the system chunk. Each account that is the proposer, payer or
+// an authorizer of a transaction gets a single entry per transaction with all its roles combined.
+func accountTransactionEntries(height uint64, chunks []*execution_data.ChunkExecutionData) []flow.AccountTransaction {
+	entries := make([]flow.AccountTransaction, 0)
+	if len(chunks) == 0 {
+		return entries
+	}
+
+	txIndex := uint32(0)
+	for _, chunk := range chunks[:len(chunks)-1] {
+		if chunk.Collection == nil {
+			continue
+		}
+
+		for _, tx := range chunk.Collection.Transactions {
+			roles := make(map[flow.Address]flow.TransactionRole)
+			// keep the order in which accounts are first seen so entries are deterministic
+			addresses := make([]flow.Address, 0, len(tx.Authorizers)+2)
+			add := func(address flow.Address, role flow.TransactionRole) {
+				if _, ok := roles[address]; !ok {
+					addresses = append(addresses, address)
+				}
+				roles[address] |= role
+			}
+
+			add(tx.ProposalKey.Address, flow.TransactionRoleProposer)
+			add(tx.Payer, flow.TransactionRolePayer)
+			for _, authorizer := range tx.Authorizers {
+				add(authorizer, flow.TransactionRoleAuthorizer)
+			}
+
+			txID := tx.ID()
+			for _, address := range addresses {
+				entries = append(entries, flow.AccountTransaction{
+					Address:          address,
+					BlockHeight:      height,
+					TransactionIndex: txIndex,
+					TransactionID:    txID,
+					Roles:            roles[address],
+				})
+			}
+			txIndex++
+		}
+	}
+
+	return entries
+}
+
 // findContractUpdates returns a map of common.AddressLocation for all contracts updated within the
 // provided events.
 // No errors are expected during normal operation and indicate an invalid protocol event was encountered
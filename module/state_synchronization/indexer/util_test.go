@@ -13,6 +13,7 @@ import (
 	"github.com/onflow/flow/protobuf/go/flow/entities"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 	"github.com/onflow/flow-go/utils/unittest"
 	"github.com/onflow/flow-go/utils/unittest/generator"
 )
@@ -57,6 +58,71 @@ func TestFindContractUpdates(t *testing.T) {
 	assert.Truef(t, ok, "could not find %s", expected2.ID())
 }
 
+// TestAccountTransactionEntries tests the accountTransactionEntries function returns one entry per account
+// and transaction with the combined roles, skipping the system chunk
+func TestAccountTransactionEntries(t *testing.T) {
+	t.Parallel()
+
+	height := uint64(42)
+	proposer := unittest.RandomAddressFixture()
+	payer := unittest.RandomAddressFixture()
+	authorizer := unittest.RandomAddressFixture()
+
+	tx1 := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.ProposalKey.Address = proposer
+		tx.Payer = payer
+		tx.Authorizers = []flow.Address{authorizer, proposer}
+	})
+	tx2 := unittest.TransactionBodyFixture(func(tx *flow.TransactionBody) {
+		tx.ProposalKey.Address = payer
+		tx.Payer = payer
+		tx.Authorizers = []flow.Address{payer}
+	})
+	systemTx := unittest.TransactionBodyFixture()
+
+	chunks := []*execution_data.ChunkExecutionData{
+		{Collection: &flow.Collection{Transactions: []*flow.TransactionBody{&tx1}}},
+		{Collection: &flow.Collection{Transactions: []*flow.TransactionBody{&tx2}}},
+		{Collection: &flow.Collection{Transactions: []*flow.TransactionBody{&systemTx}}},
+	}
+
+	entries := accountTransactionEntries(height, chunks)
+
+	expected := []flow.AccountTransaction{
+		{
+			Address:          proposer,
+			BlockHeight:      height,
+			TransactionIndex: 0,
+			TransactionID:    tx1.ID(),
+			Roles:            flow.TransactionRoleProposer | flow.TransactionRoleAuthorizer,
+		},
+		{
+			Address:          payer,
+			BlockHeight:      height,
+			TransactionIndex: 0,
+			TransactionID:    tx1.ID(),
+			Roles:            flow.TransactionRolePayer,
+		},
+		{
+			Address:          authorizer,
+			BlockHeight:      height,
+			TransactionIndex: 0,
+			TransactionID:    tx1.ID(),
+			Roles:            flow.TransactionRoleAuthorizer,
+		},
+		{
+			Address:          payer,
+			BlockHeight:      height,
+			TransactionIndex: 1,
+			TransactionID:    tx2.ID(),
+			Roles:            flow.TransactionRoleProposer | flow.TransactionRolePayer | flow.TransactionRoleAuthorizer,
+		},
+	}
+	assert.Equal(t, expected, entries)
+
+	assert.Empty(t, accountTransactionEntries(height, nil))
+}
+
 func contractUpdatedFixture(t *testing.T, address common.Address, contractName string) flow.Event {
 	contractUpdateEventType := cadence.NewEventType(
 		stdlib.AccountContractAddedEventType.Location,
@@ -0,0 +1,37 @@
+package storage
+
+import "github.com/onflow/flow-go/model/flow"
+
+// AccountTransactions represents persistent storage for the account-to-transaction index, which
+// maps each account address to the transactions it participated in as proposer, payer or authorizer.
+type AccountTransactions interface {
+
+	// BatchStore inserts the index entries for all transactions of the block at the given height into a batch.
+	// All entries must have their BlockHeight set to height. The first stored height is recorded as the
+	// first indexed height.
+	//
+	// No errors are expected during normal operation.
+	BatchStore(height uint64, entries []flow.AccountTransaction, batch BatchStorage) error
+
+	// FirstIndexedHeight returns the first height indexed by the account-to-transaction index.
+	// The index can be enabled after the node has already indexed blocks, so this height can be
+	// above the lowest height indexed by the execution state indexer.
+	//
+	// Expected errors during normal operation:
+	//   - storage.ErrNotFound if no height has been indexed yet.
+	FirstIndexedHeight() (uint64, error)
+
+	// ByAddress returns up to limit entries for the given address within the height range [startHeight, endHeight],
+	// ordered by height and transaction index. If cursor is not nil, the iteration starts at the cursor position
+	// (inclusive) instead of the beginning of the range. The returned page contains a cursor to the next entry if
+	// more entries are available within the range.
+	//
+	// No errors are expected during normal operation.
+	ByAddress(
+		address flow.Address,
+		startHeight uint64,
+		endHeight uint64,
+		cursor *flow.AccountTransactionCursor,
+		limit uint32,
+	) (*flow.AccountTransactionsPage, error)
+}
@@ -17,6 +17,8 @@ type All struct {
 	Commits                        Commits
 	Transactions                   Transactions
 	LightTransactionResults        LightTransactionResults
+	AccountTransactions            AccountTransactions
+	EVMBlocks                      EVMBlocks
 	TransactionResults             TransactionResults
 	TransactionResultErrorMessages TransactionResultErrorMessages
 	Collections                    Collections
@@ -0,0 +1,115 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+var _ storage.AccountTransactions = (*AccountTransactions)(nil)
+
+// AccountTransactions implements the account-to-transaction index.
+// Entries are keyed by address, height and transaction index, which makes range queries over
+// heights cheap and cursors deterministic.
+type AccountTransactions struct {
+	db *badger.DB
+}
+
+func NewAccountTransactions(db *badger.DB) *AccountTransactions {
+	return &AccountTransactions{
+		db: db,
+	}
+}
+
+// BatchStore inserts the index entries for all transactions of the block at the given height into a batch.
+// If no height has been indexed yet, the height is also recorded as the first indexed height.
+// No errors are expected during normal operation, but it may return generic error
+// if badger fails to process request
+func (a *AccountTransactions) BatchStore(height uint64, entries []flow.AccountTransaction, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+
+	_, err := a.FirstIndexedHeight()
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+
+		err = operation.BatchInsertAccountTransactionFirstHeight(height)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch insert first indexed height: %w", err)
+		}
+	}
+
+	for i := range entries {
+		if entries[i].BlockHeight != height {
+			return fmt.Errorf("entry height %d does not match indexed height %d", entries[i].BlockHeight, height)
+		}
+
+		err = operation.BatchIndexAccountTransaction(&entries[i])(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch index account transaction: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FirstIndexedHeight returns the first height indexed by the account-to-transaction index.
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no height has been indexed yet.
+func (a *AccountTransactions) FirstIndexedHeight() (uint64, error) {
+	var height uint64
+	err := a.db.View(operation.RetrieveAccountTransactionFirstHeight(&height))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve first indexed height: %w", err)
+	}
+	return height, nil
+}
+
+// ByAddress returns up to limit entries for the given address within the height range [startHeight, endHeight].
+// No errors are expected during normal operation.
+func (a *AccountTransactions) ByAddress(
+	address flow.Address,
+	startHeight uint64,
+	endHeight uint64,
+	cursor *flow.AccountTransactionCursor,
+	limit uint32,
+) (*flow.AccountTransactionsPage, error) {
+	if startHeight > endHeight {
+		return nil, fmt.Errorf("start height %d is greater than end height %d", startHeight, endHeight)
+	}
+
+	fromHeight := startHeight
+	fromIndex := uint32(0)
+	if cursor != nil {
+		if cursor.BlockHeight < startHeight || cursor.BlockHeight > endHeight {
+			return nil, fmt.Errorf("cursor height %d is outside the requested range [%d, %d]", cursor.BlockHeight, startHeight, endHeight)
+		}
+		fromHeight = cursor.BlockHeight
+		fromIndex = cursor.TransactionIndex
+	}
+
+	var entries []flow.AccountTransaction
+	err := a.db.View(operation.LookupAccountTransactions(address, fromHeight, fromIndex, endHeight, limit, &entries))
+	if err != nil {
+		return nil, fmt.Errorf("could not lookup account transactions: %w", err)
+	}
+
+	page := &flow.AccountTransactionsPage{
+		Transactions: entries,
+	}
+
+	// the lookup returns one entry beyond the limit if more entries are available
+	if uint32(len(entries)) > limit {
+		next := entries[limit].Cursor()
+		page.Transactions = entries[:limit]
+		page.NextCursor = &next
+	}
+
+	return page, nil
+}
@@ -0,0 +1,141 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestAccountTransactions(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewAccountTransactions(db)
+
+		address := unittest.RandomAddressFixture()
+		other := unittest.RandomAddressFixture()
+
+		// index 3 transactions per height for heights 10 to 19 for the address,
+		// and one transaction per height for another address
+		expected := make([]flow.AccountTransaction, 0)
+		for height := uint64(10); height < 20; height++ {
+			entries := make([]flow.AccountTransaction, 0)
+			for i := uint32(0); i < 3; i++ {
+				entries = append(entries, flow.AccountTransaction{
+					Address:          address,
+					BlockHeight:      height,
+					TransactionIndex: i,
+					TransactionID:    unittest.IdentifierFixture(),
+					Roles:            flow.TransactionRolePayer | flow.TransactionRoleAuthorizer,
+				})
+			}
+			expected = append(expected, entries...)
+
+			entries = append(entries, flow.AccountTransaction{
+				Address:          other,
+				BlockHeight:      height,
+				TransactionIndex: 3,
+				TransactionID:    unittest.IdentifierFixture(),
+				Roles:            flow.TransactionRoleProposer,
+			})
+
+			batch := bstorage.NewBatch(db)
+			require.NoError(t, store.BatchStore(height, entries, batch))
+			require.NoError(t, batch.Flush())
+		}
+
+		t.Run("all entries in range", func(t *testing.T) {
+			page, err := store.ByAddress(address, 0, 100, nil, 100)
+			require.NoError(t, err)
+			assert.Equal(t, expected, page.Transactions)
+			assert.Nil(t, page.NextCursor)
+		})
+
+		t.Run("entries within height range", func(t *testing.T) {
+			page, err := store.ByAddress(address, 12, 13, nil, 100)
+			require.NoError(t, err)
+			assert.Equal(t, expected[6:12], page.Transactions)
+			assert.Nil(t, page.NextCursor)
+		})
+
+		t.Run("paginate with cursor", func(t *testing.T) {
+			actual := make([]flow.AccountTransaction, 0)
+			var cursor *flow.AccountTransactionCursor
+			pages := 0
+			for {
+				page, err := store.ByAddress(address, 10, 19, cursor, 4)
+				require.NoError(t, err)
+				require.LessOrEqual(t, len(page.Transactions), 4)
+				actual = append(actual, page.Transactions...)
+				pages++
+
+				if page.NextCursor == nil {
+					break
+				}
+
+				// round trip the cursor through its opaque encoding
+				decoded, err := flow.DecodeAccountTransactionCursor(page.NextCursor.Encode())
+				require.NoError(t, err)
+				cursor = &decoded
+			}
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, 8, pages)
+		})
+
+		t.Run("entries of other address", func(t *testing.T) {
+			page, err := store.ByAddress(other, 10, 19, nil, 100)
+			require.NoError(t, err)
+			require.Len(t, page.Transactions, 10)
+			for _, entry := range page.Transactions {
+				assert.Equal(t, other, entry.Address)
+				assert.True(t, entry.Roles.Has(flow.TransactionRoleProposer))
+			}
+		})
+
+		t.Run("unknown address", func(t *testing.T) {
+			page, err := store.ByAddress(unittest.RandomAddressFixture(), 10, 19, nil, 100)
+			require.NoError(t, err)
+			assert.Empty(t, page.Transactions)
+			assert.Nil(t, page.NextCursor)
+		})
+
+		t.Run("invalid range", func(t *testing.T) {
+			_, err := store.ByAddress(address, 19, 10, nil, 100)
+			require.Error(t, err)
+
+			_, err = store.ByAddress(address, 10, 19, &flow.AccountTransactionCursor{BlockHeight: 25}, 100)
+			require.Error(t, err)
+		})
+	})
+}
+
+// TestAccountTransactionsFirstIndexedHeight tests that the first stored height is recorded, so heights
+// indexed before the index was enabled can be told apart from heights without transactions.
+func TestAccountTransactionsFirstIndexedHeight(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewAccountTransactions(db)
+
+		_, err := store.FirstIndexedHeight()
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		for height := uint64(50); height < 55; height++ {
+			batch := bstorage.NewBatch(db)
+			require.NoError(t, store.BatchStore(height, nil, batch))
+			require.NoError(t, batch.Flush())
+		}
+
+		firstHeight, err := store.FirstIndexedHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50), firstHeight)
+
+		// the first height survives restarts
+		firstHeight, err = bstorage.NewAccountTransactions(db).FirstIndexedHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(50), firstHeight)
+	})
+}
@@ -0,0 +1,122 @@
+package badger
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+var _ storage.EVMBlocks = (*EVMBlocks)(nil)
+
+// EVMBlocks implements the EVM block index.
+// Blocks are keyed by EVM height, and their heights are indexed by block hash and transaction hash.
+type EVMBlocks struct {
+	db *badger.DB
+}
+
+func NewEVMBlocks(db *badger.DB) *EVMBlocks {
+	return &EVMBlocks{
+		db: db,
+	}
+}
+
+// BatchStore inserts the given EVM blocks into a batch, and sets the latest indexed Flow height to flowHeight.
+// No errors are expected during normal operation, but it may return generic error
+// if badger fails to process request
+func (e *EVMBlocks) BatchStore(flowHeight uint64, blocks []flow.EVMBlock, batch storage.BatchStorage) error {
+	writeBatch := batch.GetWriter()
+
+	for i := range blocks {
+		if i > 0 && blocks[i].Height != blocks[i-1].Height+1 {
+			return fmt.Errorf("EVM block height %d does not follow previous height %d", blocks[i].Height, blocks[i-1].Height)
+		}
+		if blocks[i].FlowHeight > flowHeight {
+			return fmt.Errorf("EVM block %d was emitted at Flow height %d above indexed height %d",
+				blocks[i].Height, blocks[i].FlowHeight, flowHeight)
+		}
+
+		err := operation.BatchIndexEVMBlock(&blocks[i])(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch index EVM block %d: %w", blocks[i].Height, err)
+		}
+	}
+
+	if len(blocks) > 0 {
+		err := operation.BatchUpdateEVMLatestHeight(blocks[len(blocks)-1].Height)(writeBatch)
+		if err != nil {
+			return fmt.Errorf("cannot batch update latest EVM height: %w", err)
+		}
+	}
+
+	err := operation.BatchUpdateEVMLatestFlowHeight(flowHeight)(writeBatch)
+	if err != nil {
+		return fmt.Errorf("cannot batch update latest EVM indexed Flow height: %w", err)
+	}
+
+	return nil
+}
+
+// ByHeight returns the EVM block at the given height.
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no EVM block with the given height is indexed.
+func (e *EVMBlocks) ByHeight(height uint64) (*flow.EVMBlock, error) {
+	var block flow.EVMBlock
+	err := e.db.View(operation.RetrieveEVMBlock(height, &block))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve EVM block %d: %w", height, err)
+	}
+	return &block, nil
+}
+
+// HeightByHash returns the height of the EVM block with the given hash.
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no EVM block with the given hash is indexed.
+func (e *EVMBlocks) HeightByHash(hash gethCommon.Hash) (uint64, error) {
+	var height uint64
+	err := e.db.View(operation.LookupEVMBlockHeight(hash, &height))
+	if err != nil {
+		return 0, fmt.Errorf("could not lookup EVM block %s: %w", hash, err)
+	}
+	return height, nil
+}
+
+// HeightByTransactionHash returns the height of the EVM block containing the transaction with the given hash.
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no EVM transaction with the given hash is indexed.
+func (e *EVMBlocks) HeightByTransactionHash(hash gethCommon.Hash) (uint64, error) {
+	var height uint64
+	err := e.db.View(operation.LookupEVMTransactionBlockHeight(hash, &height))
+	if err != nil {
+		return 0, fmt.Errorf("could not lookup EVM transaction %s: %w", hash, err)
+	}
+	return height, nil
+}
+
+// LatestHeight returns the height of the latest indexed EVM block.
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no EVM block is indexed yet.
+func (e *EVMBlocks) LatestHeight() (uint64, error) {
+	var height uint64
+	err := e.db.View(operation.RetrieveEVMLatestHeight(&height))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve latest EVM height: %w", err)
+	}
+	return height, nil
+}
+
+// LatestFlowHeight returns the latest Flow height whose EVM events are indexed.
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no Flow height is indexed yet.
+func (e *EVMBlocks) LatestFlowHeight() (uint64, error) {
+	var height uint64
+	err := e.db.View(operation.RetrieveEVMLatestFlowHeight(&height))
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve latest EVM indexed Flow height: %w", err)
+	}
+	return height, nil
+}
@@ -0,0 +1,102 @@
+package badger_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	gethCommon "github.com/onflow/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestEVMBlocks(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store := bstorage.NewEVMBlocks(db)
+
+		_, err := store.LatestHeight()
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		_, err = store.LatestFlowHeight()
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		// Flow height 10 emits no EVM block, 11 emits EVM blocks 1 and 2
+		batch := bstorage.NewBatch(db)
+		require.NoError(t, store.BatchStore(10, nil, batch))
+		require.NoError(t, batch.Flush())
+
+		latestFlowHeight, err := store.LatestFlowHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), latestFlowHeight)
+		_, err = store.LatestHeight()
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		blocks := []flow.EVMBlock{
+			{
+				Height:            1,
+				Hash:              gethCommon.Hash(unittest.IdentifierFixture()),
+				TransactionHashes: []gethCommon.Hash{gethCommon.Hash(unittest.IdentifierFixture())},
+				LogsBloom:         []byte{1, 2, 3},
+				FirstFlowHeight:   10,
+				FlowHeight:        11,
+			},
+			{
+				Height:          2,
+				Hash:            gethCommon.Hash(unittest.IdentifierFixture()),
+				FirstFlowHeight: 11,
+				FlowHeight:      11,
+			},
+		}
+		batch = bstorage.NewBatch(db)
+		require.NoError(t, store.BatchStore(11, blocks, batch))
+		require.NoError(t, batch.Flush())
+
+		latestHeight, err := store.LatestHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), latestHeight)
+		latestFlowHeight, err = store.LatestFlowHeight()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(11), latestFlowHeight)
+
+		for _, expected := range blocks {
+			block, err := store.ByHeight(expected.Height)
+			require.NoError(t, err)
+			assert.Equal(t, expected.Height, block.Height)
+			assert.Equal(t, expected.Hash, block.Hash)
+			assert.Equal(t, expected.LogsBloom, block.LogsBloom)
+			assert.Equal(t, expected.FirstFlowHeight, block.FirstFlowHeight)
+			assert.Equal(t, expected.FlowHeight, block.FlowHeight)
+			assert.Len(t, block.TransactionHashes, len(expected.TransactionHashes))
+
+			height, err := store.HeightByHash(expected.Hash)
+			require.NoError(t, err)
+			assert.Equal(t, expected.Height, height)
+		}
+
+		height, err := store.HeightByTransactionHash(blocks[0].TransactionHashes[0])
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), height)
+
+		_, err = store.ByHeight(3)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		_, err = store.HeightByHash(gethCommon.Hash{1})
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		_, err = store.HeightByTransactionHash(gethCommon.Hash{1})
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		t.Run("blocks must be ordered", func(t *testing.T) {
+			batch := bstorage.NewBatch(db)
+			err := store.BatchStore(12, []flow.EVMBlock{{Height: 3, FlowHeight: 12}, {Height: 5, FlowHeight: 12}}, batch)
+			require.Error(t, err)
+		})
+
+		t.Run("blocks must be emitted at or below the indexed height", func(t *testing.T) {
+			batch := bstorage.NewBatch(db)
+			err := store.BatchStore(12, []flow.EVMBlock{{Height: 3, FlowHeight: 13}}, batch)
+			require.Error(t, err)
+		})
+	})
+}
@@ -0,0 +1,84 @@
+package operation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/vmihailenco/msgpack/v4"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+// BatchIndexAccountTransaction indexes the given account transaction entry by address, height and transaction index.
+func BatchIndexAccountTransaction(entry *flow.AccountTransaction) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeAccountTransaction, entry.Address, entry.BlockHeight, entry.TransactionIndex), entry)
+}
+
+// BatchInsertAccountTransactionFirstHeight sets the first height indexed by the account-to-transaction index.
+func BatchInsertAccountTransactionFirstHeight(height uint64) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeAccountTransactionFirstHeight), height)
+}
+
+// RetrieveAccountTransactionFirstHeight retrieves the first height indexed by the account-to-transaction index.
+// Returns storage.ErrNotFound if no height has been indexed yet.
+func RetrieveAccountTransactionFirstHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeAccountTransactionFirstHeight), height)
+}
+
+// LookupAccountTransactions retrieves up to limit+1 account transaction entries for the given address,
+// starting at (startHeight, startIndex) inclusive and ending at endHeight inclusive, ordered by height and
+// transaction index. Retrieving one entry beyond the limit allows the caller to determine the next cursor.
+// No errors are expected during normal operation.
+func LookupAccountTransactions(
+	address flow.Address,
+	startHeight uint64,
+	startIndex uint32,
+	endHeight uint64,
+	limit uint32,
+	entries *[]flow.AccountTransaction,
+) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		prefix := makePrefix(codeAccountTransaction, address)
+		start := makePrefix(codeAccountTransaction, address, startHeight, startIndex)
+		end := makePrefix(codeAccountTransaction, address, endHeight)
+
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		result := make([]flow.AccountTransaction, 0)
+		for it.Seek(start); it.ValidForPrefix(prefix); it.Next() {
+			if uint32(len(result)) > limit {
+				break
+			}
+
+			item := it.Item()
+
+			// keys are ordered by height, so we can stop as soon as the height part exceeds the end height
+			if bytes.Compare(item.Key()[:len(end)], end) > 0 {
+				break
+			}
+
+			var entry flow.AccountTransaction
+			err := item.Value(func(val []byte) error {
+				err := msgpack.Unmarshal(val, &entry)
+				if err != nil {
+					return irrecoverable.NewExceptionf("could not decode entity: %w", err)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("could not process value: %w", err)
+			}
+
+			result = append(result, entry)
+		}
+
+		*entries = result
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// BatchIndexEVMBlock indexes the given EVM block by height, and indexes its height by block hash and by
+// the hashes of its transactions.
+func BatchIndexEVMBlock(block *flow.EVMBlock) func(batch *badger.WriteBatch) error {
+	return func(batch *badger.WriteBatch) error {
+		err := batchWrite(makePrefix(codeEVMBlock, block.Height), block)(batch)
+		if err != nil {
+			return err
+		}
+
+		err = batchWrite(makePrefix(codeEVMBlockHeightByHash, flow.Identifier(block.Hash)), block.Height)(batch)
+		if err != nil {
+			return err
+		}
+
+		for _, txHash := range block.TransactionHashes {
+			err = batchWrite(makePrefix(codeEVMTransactionBlockHeight, flow.Identifier(txHash)), block.Height)(batch)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// BatchUpdateEVMLatestHeight sets the height of the latest indexed EVM block.
+func BatchUpdateEVMLatestHeight(height uint64) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEVMLatestHeight), height)
+}
+
+// BatchUpdateEVMLatestFlowHeight sets the latest Flow height whose EVM events are indexed.
+func BatchUpdateEVMLatestFlowHeight(height uint64) func(batch *badger.WriteBatch) error {
+	return batchWrite(makePrefix(codeEVMLatestFlowHeight), height)
+}
+
+// RetrieveEVMBlock retrieves the EVM block index entry at the given EVM height.
+func RetrieveEVMBlock(height uint64, block *flow.EVMBlock) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMBlock, height), block)
+}
+
+// LookupEVMBlockHeight retrieves the height of the EVM block with the given hash.
+func LookupEVMBlockHeight(hash gethCommon.Hash, height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMBlockHeightByHash, flow.Identifier(hash)), height)
+}
+
+// LookupEVMTransactionBlockHeight retrieves the height of the EVM block containing the transaction with the given hash.
+func LookupEVMTransactionBlockHeight(hash gethCommon.Hash, height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMTransactionBlockHeight, flow.Identifier(hash)), height)
+}
+
+// RetrieveEVMLatestHeight retrieves the height of the latest indexed EVM block.
+func RetrieveEVMLatestHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMLatestHeight), height)
+}
+
+// RetrieveEVMLatestFlowHeight retrieves the latest Flow height whose EVM events are indexed.
+func RetrieveEVMLatestFlowHeight(height *uint64) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeEVMLatestFlowHeight), height)
+}
@@ -32,6 +32,8 @@ const (
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
 	codeEpochFirstHeight        = 26 // the height of the first block in a given epoch
 	codeSealedRootHeight        = 27 // the height of the highest sealed block contained in the root snapshot
+	codeEVMLatestHeight         = 28 // the height of the latest indexed EVM block
+	codeEVMLatestFlowHeight     = 29 // the latest Flow height whose EVM events are indexed
 
 	// codes for single entity storage
 	codeHeader               = 30
@@ -102,6 +104,11 @@ const (
 	codeLightTransactionResultIndex        = 109
 	codeTransactionResultErrorMessage      = 110
 	codeTransactionResultErrorMessageIndex = 111
+	codeAccountTransaction                 = 112 // index mapping account address and height to transactions
+	codeEVMBlock                           = 113 // index mapping EVM block height to EVM block index entry
+	codeEVMBlockHeightByHash               = 114 // index mapping EVM block hash to EVM block height
+	codeEVMTransactionBlockHeight          = 115 // index mapping EVM transaction hash to EVM block height
+	codeAccountTransactionFirstHeight      = 116 // the first height indexed by the account-to-transaction index
 	codeIndexCollection                    = 200
 	codeIndexExecutionResultByBlock        = 202
 	codeIndexCollectionByTransaction       = 203
@@ -142,6 +149,8 @@ func b(v interface{}) []byte {
 		return []byte{byte(i)}
 	case flow.Identifier:
 		return i[:]
+	case flow.Address:
+		return i[:]
 	case flow.ChainID:
 		return []byte(i)
 	default:
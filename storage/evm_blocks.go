@@ -0,0 +1,49 @@
+package storage
+
+import (
+	gethCommon "github.com/onflow/go-ethereum/common"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EVMBlocks represents persistent storage for the EVM block index, which maps the heights and hashes of
+// EVM blocks, and the hashes of EVM transactions, to the Flow blocks containing their events.
+type EVMBlocks interface {
+
+	// BatchStore inserts the given EVM blocks, indexed by height, block hash and transaction hashes, into a
+	// batch, and sets the latest indexed Flow height to flowHeight.
+	// The blocks must be ordered by height, and follow the latest indexed EVM block.
+	//
+	// No errors are expected during normal operation.
+	BatchStore(flowHeight uint64, blocks []flow.EVMBlock, batch BatchStorage) error
+
+	// ByHeight returns the EVM block at the given height.
+	//
+	// Expected errors during normal operation:
+	//   - storage.ErrNotFound if no EVM block with the given height is indexed.
+	ByHeight(height uint64) (*flow.EVMBlock, error)
+
+	// HeightByHash returns the height of the EVM block with the given hash.
+	//
+	// Expected errors during normal operation:
+	//   - storage.ErrNotFound if no EVM block with the given hash is indexed.
+	HeightByHash(hash gethCommon.Hash) (uint64, error)
+
+	// HeightByTransactionHash returns the height of the EVM block containing the transaction with the given hash.
+	//
+	// Expected errors during normal operation:
+	//   - storage.ErrNotFound if no EVM transaction with the given hash is indexed.
+	HeightByTransactionHash(hash gethCommon.Hash) (uint64, error)
+
+	// LatestHeight returns the height of the latest indexed EVM block.
+	//
+	// Expected errors during normal operation:
+	//   - storage.ErrNotFound if no EVM block is indexed yet.
+	LatestHeight() (uint64, error)
+
+	// LatestFlowHeight returns the latest Flow height whose EVM events are indexed.
+	//
+	// Expected errors during normal operation:
+	//   - storage.ErrNotFound if no Flow height is indexed yet.
+	LatestFlowHeight() (uint64, error)
+}
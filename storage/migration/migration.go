@@ -0,0 +1,437 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation"
+)
+
+const (
+	// MarkerStarted is the name of the file created in the pebble directory when a migration
+	// is started. It is kept until the migration has completed and has been verified.
+	MarkerStarted = "MIGRATION_STARTED"
+	// MarkerCompleted is the name of the file created in the pebble directory once all prefixes
+	// have been copied and verified.
+	MarkerCompleted = "MIGRATION_COMPLETED"
+	// progressFile records the prefixes that have already been copied, so that an interrupted
+	// migration can be resumed without copying them again.
+	progressFile = "MIGRATION_PROGRESS.json"
+
+	DefaultWorkers       = 4
+	DefaultBatchByteSize = 32 * 1024 * 1024 // 32 MB
+)
+
+// ErrHalfMigrated is returned when a directory contains a migration that was started but not completed.
+var ErrHalfMigrated = errors.New("database migration was started but has not completed")
+
+// Config specifies how the migration is run.
+type Config struct {
+	// PebbleDir is the directory of the pebble database, where the migration markers are stored.
+	PebbleDir string
+	// Workers is the number of prefixes copied and verified concurrently.
+	Workers int
+	// BatchByteSize is the size of the key and value data written to pebble in a single batch.
+	BatchByteSize int
+}
+
+// PrefixStats is the number of keys stored under a prefix and the checksum of its key-value pairs.
+type PrefixStats struct {
+	Prefix   byte   `json:"prefix"`
+	Keys     uint64 `json:"keys"`
+	Checksum string `json:"checksum"`
+}
+
+// progress is the persisted state of a migration.
+type progress struct {
+	// Copied contains the stats of the prefixes that have been fully copied, as read from badger.
+	Copied map[byte]PrefixStats `json:"copied"`
+}
+
+// CheckMigrationStatus returns ErrHalfMigrated if a migration into the given pebble directory
+// was started and did not complete.
+// No errors are expected if no migration was ever started, or if the migration has completed.
+func CheckMigrationStatus(pebbleDir string) error {
+	started, err := fileExists(filepath.Join(pebbleDir, MarkerStarted))
+	if err != nil {
+		return fmt.Errorf("could not check migration marker: %w", err)
+	}
+	if !started {
+		return nil
+	}
+
+	completed, err := fileExists(filepath.Join(pebbleDir, MarkerCompleted))
+	if err != nil {
+		return fmt.Errorf("could not check migration marker: %w", err)
+	}
+	if !completed {
+		return fmt.Errorf("%w (dir: %s): resume the migration before starting the node", ErrHalfMigrated, pebbleDir)
+	}
+	return nil
+}
+
+// RunMigration copies all prefixes listed by operation.Prefixes from the badger database to the pebble
+// database, then verifies that both databases hold the same number of keys and the same checksum for
+// every prefix.
+//
+// The prefixes are copied concurrently. A prefix is marked as copied once all its data has been committed
+// to pebble, so an interrupted migration can be resumed by calling RunMigration again: copied prefixes
+// are skipped, and a partially copied prefix is copied again, which is safe since writes overwrite
+// existing keys.
+// The pebble directory is marked as half-migrated until the verification has succeeded, see CheckMigrationStatus.
+//
+// The migration fails before copying anything if badger contains keys under prefixes which are not listed
+// by operation.Prefixes, since they would be lost; such prefixes must be listed or the keys removed first.
+func RunMigration(log zerolog.Logger, badgerDB storage.DB, pebbleDB storage.DB, cfg Config) error {
+	log = log.With().Str("component", "badger-pebble-migration").Logger()
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWorkers
+	}
+	if cfg.BatchByteSize <= 0 {
+		cfg.BatchByteSize = DefaultBatchByteSize
+	}
+
+	completed, err := fileExists(filepath.Join(cfg.PebbleDir, MarkerCompleted))
+	if err != nil {
+		return fmt.Errorf("could not check migration marker: %w", err)
+	}
+	if completed {
+		log.Info().Msg("migration already completed, nothing to do")
+		return nil
+	}
+
+	err = checkUnknownPrefixes(badgerDB.Reader())
+	if err != nil {
+		return err
+	}
+
+	err = writeMarker(cfg.PebbleDir, MarkerStarted)
+	if err != nil {
+		return err
+	}
+
+	state, err := readProgress(cfg.PebbleDir)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	prefixes := operation.Prefixes()
+
+	g := errgroup.Group{}
+	g.SetLimit(cfg.Workers)
+	for _, prefix := range prefixes {
+		if _, ok := state.Copied[prefix]; ok {
+			log.Info().Uint8("prefix", prefix).Msg("prefix already copied, skipping")
+			continue
+		}
+
+		g.Go(func() error {
+			stats, err := copyPrefix(badgerDB, pebbleDB, prefix, cfg.BatchByteSize)
+			if err != nil {
+				return fmt.Errorf("could not copy prefix %d: %w", prefix, err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			state.Copied[prefix] = stats
+			err = writeProgress(cfg.PebbleDir, state)
+			if err != nil {
+				return err
+			}
+
+			log.Info().
+				Uint8("prefix", prefix).
+				Uint64("keys", stats.Keys).
+				Int("copied_prefixes", len(state.Copied)).
+				Int("total_prefixes", len(prefixes)).
+				Msg("prefix copied")
+			return nil
+		})
+	}
+	err = g.Wait()
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msg("all prefixes copied, verifying")
+
+	err = Verify(log, badgerDB, pebbleDB, cfg.Workers)
+	if err != nil {
+		return err
+	}
+
+	err = writeMarker(cfg.PebbleDir, MarkerCompleted)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Msg("migration completed")
+	return nil
+}
+
+// Verify checks that the badger and pebble databases hold the same number of keys and the same checksum
+// for all prefixes listed by operation.Prefixes.
+// The verification fails if badger contains keys stored under other prefixes, since they are not migrated.
+func Verify(log zerolog.Logger, badgerDB storage.DB, pebbleDB storage.DB, workers int) error {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	g := errgroup.Group{}
+	g.SetLimit(workers)
+	for _, prefix := range operation.Prefixes() {
+		g.Go(func() error {
+			expected, err := PrefixChecksum(badgerDB.Reader(), prefix)
+			if err != nil {
+				return fmt.Errorf("could not compute checksum of prefix %d in badger: %w", prefix, err)
+			}
+			actual, err := PrefixChecksum(pebbleDB.Reader(), prefix)
+			if err != nil {
+				return fmt.Errorf("could not compute checksum of prefix %d in pebble: %w", prefix, err)
+			}
+
+			if expected.Keys != actual.Keys {
+				return fmt.Errorf("key count mismatch for prefix %d: badger has %d keys, pebble has %d keys",
+					prefix, expected.Keys, actual.Keys)
+			}
+			if expected.Checksum != actual.Checksum {
+				return fmt.Errorf("checksum mismatch for prefix %d: badger checksum %s, pebble checksum %s",
+					prefix, expected.Checksum, actual.Checksum)
+			}
+
+			log.Debug().
+				Uint8("prefix", prefix).
+				Uint64("keys", actual.Keys).
+				Str("checksum", actual.Checksum).
+				Msg("prefix verified")
+			return nil
+		})
+	}
+	err := g.Wait()
+	if err != nil {
+		return err
+	}
+
+	return checkUnknownPrefixes(badgerDB.Reader())
+}
+
+// PrefixChecksum returns the number of keys stored under the given prefix and a checksum of their
+// keys and values, in key order.
+// No errors are expected during normal operation.
+func PrefixChecksum(reader storage.Reader, prefix byte) (PrefixStats, error) {
+	h := sha256.New()
+	var keys uint64
+	err := iteratePrefix(reader, prefix, func(key []byte, value []byte) error {
+		writeChecksumEntry(h, key, value)
+		keys++
+		return nil
+	})
+	if err != nil {
+		return PrefixStats{}, err
+	}
+
+	return PrefixStats{
+		Prefix:   prefix,
+		Keys:     keys,
+		Checksum: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// copyPrefix copies all keys of the given prefix from badger to pebble, and returns the stats of the
+// copied data.
+func copyPrefix(badgerDB storage.DB, pebbleDB storage.DB, prefix byte, batchByteSize int) (PrefixStats, error) {
+	h := sha256.New()
+	var keys uint64
+
+	batch := pebbleDB.NewBatch()
+	batchSize := 0
+	err := iteratePrefix(badgerDB.Reader(), prefix, func(key []byte, value []byte) error {
+		err := batch.Writer().Set(key, value)
+		if err != nil {
+			return fmt.Errorf("could not write key: %w", err)
+		}
+		writeChecksumEntry(h, key, value)
+		keys++
+
+		batchSize += len(key) + len(value)
+		if batchSize < batchByteSize {
+			return nil
+		}
+
+		err = batch.Commit()
+		if err != nil {
+			return fmt.Errorf("could not commit batch: %w", err)
+		}
+		batch = pebbleDB.NewBatch()
+		batchSize = 0
+		return nil
+	})
+	if err != nil {
+		return PrefixStats{}, err
+	}
+
+	err = batch.Commit()
+	if err != nil {
+		return PrefixStats{}, fmt.Errorf("could not commit batch: %w", err)
+	}
+
+	return PrefixStats{
+		Prefix:   prefix,
+		Keys:     keys,
+		Checksum: hex.EncodeToString(h.Sum(nil)),
+	}, nil
+}
+
+// iteratePrefix calls fn for all keys stored under the given prefix, in key order.
+// The key and value passed to fn are only valid during the call.
+func iteratePrefix(reader storage.Reader, prefix byte, fn func(key []byte, value []byte) error) error {
+	it, err := reader.NewIter([]byte{prefix}, []byte{prefix}, storage.DefaultIteratorOptions())
+	if err != nil {
+		return fmt.Errorf("could not create iterator: %w", err)
+	}
+	defer it.Close()
+
+	for it.First(); it.Valid(); it.Next() {
+		item := it.IterItem()
+		key := item.Key()
+		err := item.Value(func(value []byte) error {
+			return fn(key, value)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUnknownPrefixes returns an error if the database contains keys stored under prefixes which are
+// not listed by operation.Prefixes, such as deprecated prefixes, since these keys are not migrated.
+func checkUnknownPrefixes(reader storage.Reader) error {
+	unknown, err := countUnknownKeys(reader)
+	if err != nil {
+		return fmt.Errorf("could not count keys with unknown prefixes: %w", err)
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	prefixes := slices.Sorted(maps.Keys(unknown))
+	var keys uint64
+	for _, count := range unknown {
+		keys += count
+	}
+	return fmt.Errorf("badger contains %d keys under prefixes %v which are not listed by operation.Prefixes and would not be migrated",
+		keys, prefixes)
+}
+
+// countUnknownKeys returns the number of keys stored under each prefix which is not listed by
+// operation.Prefixes.
+func countUnknownKeys(reader storage.Reader) (map[byte]uint64, error) {
+	known := make(map[byte]struct{})
+	for _, prefix := range operation.Prefixes() {
+		known[prefix] = struct{}{}
+	}
+
+	it, err := reader.NewIter([]byte{0x00}, []byte{0xff}, storage.IteratorOption{BadgerIterateKeyOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("could not create iterator: %w", err)
+	}
+	defer it.Close()
+
+	counts := make(map[byte]uint64)
+	for it.First(); it.Valid(); it.Next() {
+		key := it.IterItem().Key()
+		if len(key) == 0 {
+			continue
+		}
+		if _, ok := known[key[0]]; !ok {
+			counts[key[0]]++
+		}
+	}
+	return counts, nil
+}
+
+// writeChecksumEntry adds a length-prefixed key and value to the checksum, so that the
+// boundary between keys and values is unambiguous.
+func writeChecksumEntry(h hash.Hash, key []byte, value []byte) {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(key)))
+	_, _ = h.Write(length[:])
+	_, _ = h.Write(key)
+	binary.BigEndian.PutUint32(length[:], uint32(len(value)))
+	_, _ = h.Write(length[:])
+	_, _ = h.Write(value)
+}
+
+func readProgress(dir string) (*progress, error) {
+	state := &progress{Copied: make(map[byte]PrefixStats)}
+
+	data, err := os.ReadFile(filepath.Join(dir, progressFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read migration progress: %w", err)
+	}
+
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode migration progress: %w", err)
+	}
+	return state, nil
+}
+
+// writeProgress persists the migration progress atomically, by writing it to a temporary file first.
+func writeProgress(dir string, state *progress) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not encode migration progress: %w", err)
+	}
+
+	tmpPath := filepath.Join(dir, progressFile+".tmp")
+	err = os.WriteFile(tmpPath, data, 0600)
+	if err != nil {
+		return fmt.Errorf("could not write migration progress: %w", err)
+	}
+
+	err = os.Rename(tmpPath, filepath.Join(dir, progressFile))
+	if err != nil {
+		return fmt.Errorf("could not rename migration progress file: %w", err)
+	}
+	return nil
+}
+
+func writeMarker(dir string, name string) error {
+	err := os.WriteFile(filepath.Join(dir, name), nil, 0600)
+	if err != nil {
+		return fmt.Errorf("could not write migration marker %s: %w", name, err)
+	}
+	return nil
+}
+
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,177 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/operation"
+	"github.com/onflow/flow-go/storage/operation/badgerimpl"
+	"github.com/onflow/flow-go/storage/operation/pebbleimpl"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// migratedPrefixes are the prefixes populated by the tests, all of them are listed by operation.Prefixes.
+var migratedPrefixes = []byte{2, 30, 100, 255}
+
+// runWithDatabases runs fn with a populated badger database and an empty pebble database.
+func runWithDatabases(t *testing.T, fn func(badgerDB storage.DB, pebbleDB storage.DB, pebbleDir string)) {
+	unittest.RunWithBadgerDB(t, func(bdb *badger.DB) {
+		unittest.RunWithTempDir(t, func(dir string) {
+			pdb := unittest.PebbleDB(t, dir)
+			defer func() {
+				require.NoError(t, pdb.Close())
+			}()
+
+			badgerDB := badgerimpl.ToDB(bdb)
+			populate(t, badgerDB)
+			fn(badgerDB, pebbleimpl.ToDB(pdb), dir)
+		})
+	})
+}
+
+func populate(t *testing.T, db storage.DB) {
+	require.NoError(t, db.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+		for _, prefix := range migratedPrefixes {
+			for i := 0; i < 100; i++ {
+				key := operation.MakePrefix(prefix, uint64(i))
+				err := rw.Writer().Set(key, unittest.RandomBytes(32))
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}))
+}
+
+func TestPrefixes(t *testing.T) {
+	prefixes := operation.Prefixes()
+	seen := make(map[byte]struct{})
+	for i, prefix := range prefixes {
+		_, ok := seen[prefix]
+		require.False(t, ok, "duplicate prefix %d", prefix)
+		seen[prefix] = struct{}{}
+		if i > 0 {
+			require.Less(t, prefixes[i-1], prefix)
+		}
+	}
+	for _, prefix := range migratedPrefixes {
+		require.Contains(t, prefixes, prefix)
+	}
+}
+
+func TestRunMigration(t *testing.T) {
+	runWithDatabases(t, func(badgerDB storage.DB, pebbleDB storage.DB, pebbleDir string) {
+		// use a small batch size so that prefixes are copied in multiple batches
+		err := RunMigration(unittest.Logger(), badgerDB, pebbleDB, Config{
+			PebbleDir:     pebbleDir,
+			Workers:       2,
+			BatchByteSize: 1024,
+		})
+		require.NoError(t, err)
+		require.NoError(t, CheckMigrationStatus(pebbleDir))
+
+		for _, prefix := range migratedPrefixes {
+			expected, err := PrefixChecksum(badgerDB.Reader(), prefix)
+			require.NoError(t, err)
+			actual, err := PrefixChecksum(pebbleDB.Reader(), prefix)
+			require.NoError(t, err)
+			require.Equal(t, uint64(100), actual.Keys)
+			require.Equal(t, expected, actual)
+		}
+
+		// running the migration again is a no-op
+		err = RunMigration(unittest.Logger(), badgerDB, pebbleDB, Config{PebbleDir: pebbleDir})
+		require.NoError(t, err)
+	})
+}
+
+// TestRunMigrationUnknownPrefix tests that the migration fails if badger contains keys under prefixes
+// which are not listed by operation.Prefixes, since they would not be migrated.
+func TestRunMigrationUnknownPrefix(t *testing.T) {
+	runWithDatabases(t, func(badgerDB storage.DB, pebbleDB storage.DB, pebbleDir string) {
+		// a key with a deprecated prefix
+		unknownKey := []byte{15, 1, 2, 3}
+		require.NotContains(t, operation.Prefixes(), unknownKey[0])
+		require.NoError(t, badgerDB.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+			return rw.Writer().Set(unknownKey, []byte{1})
+		}))
+
+		err := RunMigration(unittest.Logger(), badgerDB, pebbleDB, Config{PebbleDir: pebbleDir})
+		require.ErrorContains(t, err, "not listed by operation.Prefixes")
+		require.NoFileExists(t, filepath.Join(pebbleDir, MarkerCompleted))
+		require.NoError(t, CheckMigrationStatus(pebbleDir))
+
+		// the verification of a migrated database fails as well
+		for _, prefix := range migratedPrefixes {
+			_, err := copyPrefix(badgerDB, pebbleDB, prefix, DefaultBatchByteSize)
+			require.NoError(t, err)
+		}
+		err = Verify(unittest.Logger(), badgerDB, pebbleDB, DefaultWorkers)
+		require.ErrorContains(t, err, "1 keys under prefixes [15]")
+	})
+}
+
+// TestResumeMigration tests that an interrupted migration is detected, and that resuming it skips
+// the prefixes that were already copied.
+func TestResumeMigration(t *testing.T) {
+	runWithDatabases(t, func(badgerDB storage.DB, pebbleDB storage.DB, pebbleDir string) {
+		// simulate a migration that was interrupted after copying the first prefix
+		require.NoError(t, writeMarker(pebbleDir, MarkerStarted))
+		stats, err := copyPrefix(badgerDB, pebbleDB, migratedPrefixes[0], DefaultBatchByteSize)
+		require.NoError(t, err)
+		require.NoError(t, writeProgress(pebbleDir, &progress{Copied: map[byte]PrefixStats{migratedPrefixes[0]: stats}}))
+
+		require.ErrorIs(t, CheckMigrationStatus(pebbleDir), ErrHalfMigrated)
+
+		// a prefix marked as copied is skipped; data written to it after it was copied is not
+		// migrated, and is reported by the verification
+		require.NoError(t, badgerDB.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+			return rw.Writer().Set(operation.MakePrefix(migratedPrefixes[0], uint64(1000)), []byte{1})
+		}))
+		err = RunMigration(unittest.Logger(), badgerDB, pebbleDB, Config{PebbleDir: pebbleDir})
+		require.ErrorContains(t, err, "key count mismatch")
+		require.ErrorIs(t, CheckMigrationStatus(pebbleDir), ErrHalfMigrated)
+
+		// once the data is consistent again, the migration completes
+		require.NoError(t, badgerDB.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+			return rw.Writer().Delete(operation.MakePrefix(migratedPrefixes[0], uint64(1000)))
+		}))
+		err = RunMigration(unittest.Logger(), badgerDB, pebbleDB, Config{PebbleDir: pebbleDir})
+		require.NoError(t, err)
+		require.NoError(t, CheckMigrationStatus(pebbleDir))
+	})
+}
+
+func TestVerifyChecksumMismatch(t *testing.T) {
+	runWithDatabases(t, func(badgerDB storage.DB, pebbleDB storage.DB, pebbleDir string) {
+		err := RunMigration(unittest.Logger(), badgerDB, pebbleDB, Config{PebbleDir: pebbleDir})
+		require.NoError(t, err)
+
+		// same number of keys, different value
+		require.NoError(t, pebbleDB.WithReaderBatchWriter(func(rw storage.ReaderBatchWriter) error {
+			return rw.Writer().Set(operation.MakePrefix(migratedPrefixes[1], uint64(1)), []byte{1})
+		}))
+
+		err = Verify(unittest.Logger(), badgerDB, pebbleDB, DefaultWorkers)
+		require.ErrorContains(t, err, "checksum mismatch")
+	})
+}
+
+func TestCheckMigrationStatus(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		// no migration was started
+		require.NoError(t, CheckMigrationStatus(dir))
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, MarkerStarted), nil, 0600))
+		require.ErrorIs(t, CheckMigrationStatus(dir), ErrHalfMigrated)
+
+		require.NoError(t, os.WriteFile(filepath.Join(dir, MarkerCompleted), nil, 0600))
+		require.NoError(t, CheckMigrationStatus(dir))
+	})
+}
@@ -3,7 +3,10 @@
 package mock
 
 import (
+	iter "iter"
+
 	flow "github.com/onflow/flow-go/model/flow"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -60,6 +63,26 @@ func (_m *RegisterIndex) Get(ID flow.RegisterID, height uint64) ([]byte, error)
 	return r0, r1
 }
 
+// History provides a mock function with given fields: ID, fromHeight, toHeight
+func (_m *RegisterIndex) History(ID flow.RegisterID, fromHeight uint64, toHeight uint64) iter.Seq2[flow.RegisterChange, error] {
+	ret := _m.Called(ID, fromHeight, toHeight)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 iter.Seq2[flow.RegisterChange, error]
+	if rf, ok := ret.Get(0).(func(flow.RegisterID, uint64, uint64) iter.Seq2[flow.RegisterChange, error]); ok {
+		r0 = rf(ID, fromHeight, toHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[flow.RegisterChange, error])
+		}
+	}
+
+	return r0
+}
+
 // LatestHeight provides a mock function with given fields:
 func (_m *RegisterIndex) LatestHeight() uint64 {
 	ret := _m.Called()
@@ -78,6 +101,26 @@ func (_m *RegisterIndex) LatestHeight() uint64 {
 	return r0
 }
 
+// OwnerHistory provides a mock function with given fields: owner, fromHeight, toHeight, cursor
+func (_m *RegisterIndex) OwnerHistory(owner string, fromHeight uint64, toHeight uint64, cursor *flow.RegisterHistoryCursor) iter.Seq2[flow.RegisterChange, error] {
+	ret := _m.Called(owner, fromHeight, toHeight, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OwnerHistory")
+	}
+
+	var r0 iter.Seq2[flow.RegisterChange, error]
+	if rf, ok := ret.Get(0).(func(string, uint64, uint64, *flow.RegisterHistoryCursor) iter.Seq2[flow.RegisterChange, error]); ok {
+		r0 = rf(owner, fromHeight, toHeight, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[flow.RegisterChange, error])
+		}
+	}
+
+	return r0
+}
+
 // Store provides a mock function with given fields: entries, height
 func (_m *RegisterIndex) Store(entries flow.RegisterEntries, height uint64) error {
 	ret := _m.Called(entries, height)
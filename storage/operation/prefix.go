@@ -4,6 +4,7 @@ package operation
 import (
 	"encoding/binary"
 	"fmt"
+	"slices"
 
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -33,6 +34,8 @@ const (
 	codeLastCompleteBlockHeight = 25 // the height of the last block for which all collections were received
 	codeEpochFirstHeight        = 26 // the height of the first block in a given epoch
 	codeSealedRootHeight        = 27 // the height of the highest sealed block contained in the root snapshot
+	codeEVMLatestHeight         = 28 // the height of the latest indexed EVM block
+	codeEVMLatestFlowHeight     = 29 // the latest Flow height whose EVM events are indexed
 
 	// codes for single entity storage
 	codeHeader               = 30
@@ -102,6 +105,11 @@ const (
 	codeLightTransactionResultIndex        = 109
 	codeTransactionResultErrorMessage      = 110
 	codeTransactionResultErrorMessageIndex = 111
+	codeAccountTransaction                 = 112 // index mapping account address and height to transactions, only listed in Prefixes so the migration copies it
+	codeEVMBlock                           = 113 // index mapping EVM block height to EVM block index entry
+	codeEVMBlockHeightByHash               = 114 // index mapping EVM block hash to EVM block height
+	codeEVMTransactionBlockHeight          = 115 // index mapping EVM transaction hash to EVM block height
+	codeAccountTransactionFirstHeight      = 116 // the first height indexed by the account-to-transaction index, only listed in Prefixes so the migration copies it
 	codeIndexCollection                    = 200
 	codeIndexExecutionResultByBlock        = 202
 	codeIndexCollectionByTransaction       = 203
@@ -115,6 +123,94 @@ const (
 	codeEpochEmergencyFallbackTriggered = 255
 )
 
+// Prefixes returns the codes of all prefixes currently in use, in ascending order.
+// Deprecated codes are not included. Any code added above must also be added here,
+// otherwise tools that process the database prefix by prefix, such as the
+// Badger to Pebble migration, would skip it.
+func Prefixes() []byte {
+	codes := []byte{
+		codeDBType,
+		codeSafetyData,
+		codeLivenessData,
+		codeSporkID,
+		codeSporkRootBlockHeight,
+		codeFinalizedHeight,
+		codeSealedHeight,
+		codeClusterHeight,
+		codeExecutedBlock,
+		codeFinalizedRootHeight,
+		codeLastCompleteBlockHeight,
+		codeEpochFirstHeight,
+		codeSealedRootHeight,
+		codeEVMLatestHeight,
+		codeEVMLatestFlowHeight,
+		codeHeader,
+		codeGuarantee,
+		codeSeal,
+		codeTransaction,
+		codeCollection,
+		codeExecutionResult,
+		codeResultApproval,
+		codeChunk,
+		codeExecutionReceiptMeta,
+		codeHeightToBlock,
+		codeBlockIDToLatestSealID,
+		codeClusterBlockToRefBlock,
+		codeRefHeightToClusterBlock,
+		codeBlockIDToFinalizedSeal,
+		codeBlockIDToQuorumCertificate,
+		codeEpochProtocolStateByBlockID,
+		codeProtocolKVStoreByBlockID,
+		codeBlockChildren,
+		codePayloadGuarantees,
+		codePayloadSeals,
+		codeCollectionBlock,
+		codeOwnBlockReceipt,
+		codePayloadReceipts,
+		codePayloadResults,
+		codeAllBlockReceipts,
+		codePayloadProtocolStateID,
+		codeEpochSetup,
+		codeEpochCommit,
+		codeBeaconPrivateKey,
+		codeDKGStarted,
+		codeDKGEnded,
+		codeVersionBeacon,
+		codeEpochProtocolState,
+		codeProtocolKVStore,
+		codeComputationResults,
+		codeJobConsumerProcessed,
+		codeJobQueue,
+		codeJobQueuePointer,
+		codeChunkDataPack,
+		codeCommit,
+		codeEvent,
+		codeExecutionStateInteractions,
+		codeTransactionResult,
+		codeFinalizedCluster,
+		codeServiceEvent,
+		codeTransactionResultIndex,
+		codeLightTransactionResult,
+		codeLightTransactionResultIndex,
+		codeTransactionResultErrorMessage,
+		codeTransactionResultErrorMessageIndex,
+		codeAccountTransaction,
+		codeEVMBlock,
+		codeEVMBlockHeightByHash,
+		codeEVMTransactionBlockHeight,
+		codeAccountTransactionFirstHeight,
+		codeIndexCollection,
+		codeIndexExecutionResultByBlock,
+		codeIndexCollectionByTransaction,
+		codeIndexResultApprovalByChunk,
+		blockedNodeIDs,
+		codeExecutionFork,
+		codeEpochEmergencyFallbackTriggered,
+	}
+	slices.Sort(codes)
+	return codes
+}
+
 func MakePrefix(code byte, keys ...interface{}) []byte {
 	prefix := make([]byte, 1)
 	prefix[0] = code
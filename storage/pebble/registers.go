@@ -1,9 +1,13 @@
 package pebble
 
 import (
+	"bytes"
+	"context"
 	"encoding/binary"
 	"fmt"
+	"iter"
 	"math"
+	"time"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/pkg/errors"
@@ -11,13 +15,14 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/pebble/registers"
 )
 
 // Registers library that implements pebble storage for registers
 // given a pebble instance with root block and root height populated
 type Registers struct {
 	db             *pebble.DB
-	firstHeight    uint64
+	firstHeight    *atomic.Uint64
 	latestHeight   *atomic.Uint64
 	pruneThreshold uint64
 }
@@ -25,7 +30,7 @@ type Registers struct {
 // PruningDisabled represents the absence of a pruning threshold.
 const PruningDisabled = math.MaxUint64
 
-var _ storage.RegisterIndex = (*Registers)(nil)
+var _ storage.PrunableRegisterIndex = (*Registers)(nil)
 
 // NewRegisters takes a populated pebble instance with LatestHeight and FirstHeight set.
 // return storage.ErrNotBootstrapped if they those two keys are unavailable as it implies a uninitialized state
@@ -46,7 +51,7 @@ func NewRegisters(db *pebble.DB, pruneThreshold uint64) (*Registers, error) {
 	// All registers between firstHeight and lastHeight have been indexed
 	return &Registers{
 		db:             db,
-		firstHeight:    firstHeight,
+		firstHeight:    atomic.NewUint64(firstHeight),
 		latestHeight:   atomic.NewUint64(latestHeight),
 		pruneThreshold: pruneThreshold,
 	}, nil
@@ -104,6 +109,155 @@ func (s *Registers) lookupRegister(key []byte) (flow.RegisterValue, error) {
 	return valueCopy, nil
 }
 
+// History iterates over the values written to the register within the height range
+// [fromHeight, toHeight], from the most recent to the oldest value.
+//
+// The iteration stops after the first error, which is yielded together with an empty change.
+// Expected errors:
+// - storage.ErrHeightNotIndexed if the height range is not within the indexed heights.
+func (s *Registers) History(reg flow.RegisterID, fromHeight, toHeight uint64) iter.Seq2[flow.RegisterChange, error] {
+	// values of the register are stored under adjacent keys, with the most recent value first.
+	// the keys between the lookup keys of toHeight and fromHeight hold the values written within the range.
+	lowerBound := newLookupKey(toHeight, reg).Bytes()
+	upperBound := append(newLookupKey(fromHeight, reg).Bytes(), 0)
+
+	return s.history(lowerBound, upperBound, fromHeight, toHeight, func(prefix []byte) (flow.RegisterID, bool) {
+		// skip values of other registers whose keys start with this register's key
+		return reg, len(prefix) == len(lowerBound)-registers.HeightSuffixLen
+	})
+}
+
+// OwnerHistory iterates over the values written to all registers of the owner within the height
+// range [fromHeight, toHeight]. Changes are grouped by register, in the order of the encoded register keys,
+// and ordered from the most recent to the oldest value within each register.
+// If cursor is not nil, the iteration starts at the change the cursor points to.
+//
+// Values written outside the height range are skipped by seeking to the next value within the range,
+// so the cost of the iteration depends on the number of registers of the owner and the number of
+// values within the range, not on the number of values outside of it.
+//
+// The iteration stops after the first error, which is yielded together with an empty change.
+// Expected errors:
+// - storage.ErrHeightNotIndexed if the height range is not within the indexed heights.
+func (s *Registers) OwnerHistory(
+	owner string,
+	fromHeight uint64,
+	toHeight uint64,
+	cursor *flow.RegisterHistoryCursor,
+) iter.Seq2[flow.RegisterChange, error] {
+	ownerPrefix := make([]byte, 0, len(owner)+2)
+	ownerPrefix = append(ownerPrefix, codeRegister)
+	ownerPrefix = append(ownerPrefix, owner...)
+
+	lowerBound := append(ownerPrefix, '/')
+	upperBound := append(ownerPrefix[:len(ownerPrefix):len(ownerPrefix)], '/'+1)
+	ownerPrefixLen := len(lowerBound)
+
+	if cursor != nil {
+		lowerBound = newLookupKey(cursor.Height, flow.RegisterID{Owner: owner, Key: cursor.Key}).Bytes()
+	}
+
+	return s.history(lowerBound, upperBound, fromHeight, toHeight, func(prefix []byte) (flow.RegisterID, bool) {
+		// the prefix is "<code><owner>/<key>/"
+		if len(prefix) < ownerPrefixLen+1 {
+			return flow.RegisterID{}, false
+		}
+		key := prefix[ownerPrefixLen : len(prefix)-1]
+		return flow.RegisterID{Owner: owner, Key: string(key)}, true
+	})
+}
+
+// history iterates over the register values stored under keys in [lowerBound, upperBound), and yields
+// the values written within the height range [fromHeight, toHeight].
+// decodeRegister returns the register of a key prefix (the key without the height), or false if values
+// stored under the prefix should be skipped.
+func (s *Registers) history(
+	lowerBound []byte,
+	upperBound []byte,
+	fromHeight uint64,
+	toHeight uint64,
+	decodeRegister func(prefix []byte) (flow.RegisterID, bool),
+) iter.Seq2[flow.RegisterChange, error] {
+	return func(yield func(flow.RegisterChange, error) bool) {
+		if fromHeight > toHeight {
+			yield(flow.RegisterChange{}, fmt.Errorf("invalid height range: from height %d is above to height %d", fromHeight, toHeight))
+			return
+		}
+
+		latestHeight := s.LatestHeight()
+		firstHeight := s.calculateFirstHeight(latestHeight)
+		if fromHeight < firstHeight || toHeight > latestHeight {
+			yield(flow.RegisterChange{}, fmt.Errorf("height range [%d-%d] not indexed, indexed range: [%d-%d], %w",
+				fromHeight, toHeight, firstHeight, latestHeight, storage.ErrHeightNotIndexed))
+			return
+		}
+
+		it, err := s.db.NewIter(&pebble.IterOptions{
+			LowerBound: lowerBound,
+			UpperBound: upperBound,
+		})
+		if err != nil {
+			yield(flow.RegisterChange{}, fmt.Errorf("failed to create iterator: %w", err))
+			return
+		}
+		defer it.Close()
+
+		// values of a register are stored under adjacent keys, ordered from the most recent to the oldest
+		// height, since the height is stored as its one's complement.
+		seek := make([]byte, 0, len(upperBound)+registers.HeightSuffixLen+1)
+		for valid := it.First(); valid; {
+			key := it.Key()
+			if len(key) < MinLookupKeyLen {
+				yield(flow.RegisterChange{}, fmt.Errorf("invalid register key: %x", key))
+				return
+			}
+
+			prefix := key[:len(key)-registers.HeightSuffixLen]
+			height := ^binary.BigEndian.Uint64(key[len(key)-registers.HeightSuffixLen:])
+
+			if height > toHeight {
+				// skip to the most recent value of the register at or below toHeight
+				seek = binary.BigEndian.AppendUint64(append(seek[:0], prefix...), ^toHeight)
+				valid = it.SeekGE(seek)
+				continue
+			}
+
+			if height < fromHeight {
+				// skip the remaining older values of the register
+				seek = append(binary.BigEndian.AppendUint64(append(seek[:0], prefix...), ^uint64(0)), 0)
+				valid = it.SeekGE(seek)
+				continue
+			}
+
+			reg, ok := decodeRegister(prefix)
+			if !ok {
+				valid = it.Next()
+				continue
+			}
+
+			value, err := it.ValueAndErr()
+			if err != nil {
+				yield(flow.RegisterChange{}, fmt.Errorf("failed to get value: %w", err))
+				return
+			}
+
+			// preventing caller from modifying the iterator's value slices
+			valueCopy := make([]byte, len(value))
+			copy(valueCopy, value)
+
+			if !yield(flow.RegisterChange{Key: reg, Height: height, Value: valueCopy}, nil) {
+				return
+			}
+
+			valid = it.Next()
+		}
+
+		if err := it.Error(); err != nil {
+			yield(flow.RegisterChange{}, fmt.Errorf("failed to iterate registers: %w", err))
+		}
+	}
+}
+
 // Store sets the given entries in a batch.
 // This function is expected to be called at one batch per height, sequentially. Under normal conditions,
 // it should be called wth the value of height set to LatestHeight + 1
@@ -172,18 +326,149 @@ func (s *Registers) FirstHeight() uint64 {
 // Returns:
 // - The first indexed height, either as the initialized height or adjusted for pruning.
 func (s *Registers) calculateFirstHeight(latestHeight uint64) uint64 {
+	firstHeight := s.firstHeight.Load()
 	if latestHeight < s.pruneThreshold {
-		return s.firstHeight
+		return firstHeight
 	}
 
 	pruneHeight := latestHeight - s.pruneThreshold
-	if pruneHeight < s.firstHeight {
-		return s.firstHeight
+	if pruneHeight < firstHeight {
+		return firstHeight
 	}
 
 	return pruneHeight
 }
 
+// PruneUpToHeight removes the register values which are no longer needed to serve reads at or above
+// pruneHeight. For each register, the most recent value at or below pruneHeight is kept, and all older
+// values are removed.
+//
+// The first height is advanced to pruneHeight before any value is removed, so reads below pruneHeight
+// fail with storage.ErrHeightNotIndexed instead of returning incomplete data. If pruneHeight is below
+// the first height, this is a no-op. If it equals the first height, the values left over by an interrupted
+// run are removed.
+//
+// Values are removed in batches of batchSize, sleeping for sleepAfterEachBatch after each batch commit
+// to limit the load on the database. Returns the number of removed values.
+//
+// CAUTION: This function is not safe for concurrent use with itself.
+//
+// Expected errors:
+// - context.Canceled or context.DeadlineExceeded if ctx is done before all values are removed. The
+// first height has already been advanced, the remaining values are removed by the next call.
+func (s *Registers) PruneUpToHeight(
+	ctx context.Context,
+	pruneHeight uint64,
+	batchSize uint,
+	sleepAfterEachBatch time.Duration,
+) (uint64, error) {
+	latestHeight := s.LatestHeight()
+	if pruneHeight > latestHeight {
+		return 0, fmt.Errorf("cannot prune above the latest height %d: %d", latestHeight, pruneHeight)
+	}
+
+	firstHeight := s.firstHeight.Load()
+	if pruneHeight < firstHeight {
+		return 0, nil
+	}
+
+	if pruneHeight > firstHeight {
+		err := s.db.Set(firstHeightKey, encodedUint64(pruneHeight), pebble.Sync)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update first height %d: %w", pruneHeight, err)
+		}
+		s.firstHeight.Store(pruneHeight)
+	}
+
+	if batchSize == 0 {
+		batchSize = 1
+	}
+
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte{codeRegister},
+		UpperBound: []byte{codeRegister + 1},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	batch := s.db.NewBatch()
+	defer func() {
+		batch.Close()
+	}()
+
+	var pruned uint64
+	commit := func() error {
+		err := batch.Commit(pebble.Sync)
+		if err != nil {
+			return fmt.Errorf("failed to commit batch: %w", err)
+		}
+		pruned += uint64(batch.Count())
+
+		batch.Close()
+		batch = s.db.NewBatch()
+		return nil
+	}
+
+	// keys of the same register are adjacent, ordered from the most recent to the oldest height,
+	// since the height is stored as its one's complement.
+	var register []byte
+	var kept bool
+	for iter.First(); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if len(key) < MinLookupKeyLen {
+			return pruned, fmt.Errorf("invalid register key: %x", key)
+		}
+
+		prefix := key[:len(key)-registers.HeightSuffixLen]
+		if !bytes.Equal(prefix, register) {
+			register = append(register[:0], prefix...)
+			kept = false
+		}
+
+		if !kept {
+			height := ^binary.BigEndian.Uint64(key[len(key)-registers.HeightSuffixLen:])
+			// keep all values above the prune height, and the most recent value at or below it
+			kept = height <= pruneHeight
+			continue
+		}
+
+		err := batch.Delete(key, nil)
+		if err != nil {
+			return pruned, fmt.Errorf("failed to delete key: %w", err)
+		}
+
+		if uint(batch.Count()) < batchSize {
+			continue
+		}
+
+		err = commit()
+		if err != nil {
+			return pruned, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return pruned, ctx.Err()
+		case <-time.After(sleepAfterEachBatch):
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return pruned, fmt.Errorf("failed to iterate registers: %w", err)
+	}
+
+	if batch.Count() > 0 {
+		err = commit()
+		if err != nil {
+			return pruned, err
+		}
+	}
+
+	return pruned, nil
+}
+
 func firstStoredHeight(db *pebble.DB) (uint64, error) {
 	return heightLookup(db, firstHeightKey)
 }
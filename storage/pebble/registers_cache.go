@@ -19,7 +19,7 @@ type RegistersCache struct {
 	cache *ReadCache
 }
 
-var _ storage.RegisterIndex = (*RegistersCache)(nil)
+var _ storage.PrunableRegisterIndex = (*RegistersCache)(nil)
 
 // NewRegistersCache wraps a read cache around Get requests to a underlying Registers object.
 func NewRegistersCache(registers *Registers, cacheType CacheType, size uint, metrics module.CacheMetrics) (*RegistersCache, error) {
@@ -53,10 +53,17 @@ func NewRegistersCache(registers *Registers, cacheType CacheType, size uint, met
 // GetPayload(13, A) would return the value at height 11.
 //
 // - storage.ErrNotFound if no register values are found
-// - storage.ErrHeightNotIndexed if the requested height is out of the range of stored heights
+// - storage.ErrHeightNotIndexed if the requested height is below the first stored height
 func (c *RegistersCache) Get(
 	reg flow.RegisterID,
 	height uint64,
 ) (flow.RegisterValue, error) {
+	// PruneUpToHeight advances the stored first height before removing values. Below it, the lookup
+	// would miss the removed values and report registers as not set.
+	firstHeight := c.firstHeight.Load()
+	if height < firstHeight {
+		return nil, fmt.Errorf("height %d not indexed, first height: %d, %w", height, firstHeight, storage.ErrHeightNotIndexed)
+	}
+
 	return c.cache.Get(newLookupKey(height, reg).String())
 }
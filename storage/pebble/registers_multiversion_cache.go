@@ -0,0 +1,267 @@
+package pebble
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	multiVersionRegisterResourceName = "registers_multiversion"
+)
+
+// registerVersion is the value of a register from the given height on, until the next version.
+// A nil value means the register does not exist.
+type registerVersion struct {
+	height uint64
+	value  flow.RegisterValue
+}
+
+// registerVersions are the versions of a cached register, ordered by height.
+// The versions are complete from the height of the first version up to the height of the cache,
+// so any height within that range can be served from the versions.
+type registerVersions []registerVersion
+
+// valueAt returns the value of the register at the given height, and false if the height is below the
+// first version.
+func (v registerVersions) valueAt(height uint64) (flow.RegisterValue, bool) {
+	for i := len(v) - 1; i >= 0; i-- {
+		if v[i].height <= height {
+			return v[i].value, true
+		}
+	}
+	return nil, false
+}
+
+// versionsBackend is the subset of the lru caches used by MultiVersionRegistersCache.
+type versionsBackend interface {
+	Get(key flow.RegisterID) (*registerVersions, bool)
+	Peek(key flow.RegisterID) (*registerVersions, bool)
+	Add(key flow.RegisterID, value *registerVersions)
+	Contains(key flow.RegisterID) bool
+	Len() int
+}
+
+// wrappedVersions is a wrapper around lru.Cache to implement versionsBackend, see wrapped.
+type wrappedVersions struct {
+	*lru.Cache[flow.RegisterID, *registerVersions]
+}
+
+func (c *wrappedVersions) Add(key flow.RegisterID, value *registerVersions) {
+	_ = c.Cache.Add(key, value)
+}
+
+func getVersionsCache(cacheType CacheType, size int) (versionsBackend, error) {
+	switch cacheType {
+	case CacheTypeLRU:
+		cache, err := lru.New[flow.RegisterID, *registerVersions](size)
+		if err != nil {
+			return nil, err
+		}
+		return &wrappedVersions{Cache: cache}, nil
+	case CacheTypeTwoQueue:
+		return lru.New2Q[flow.RegisterID, *registerVersions](size)
+	default:
+		return nil, fmt.Errorf("unknown cache type: %d", cacheType)
+	}
+}
+
+// MultiVersionRegistersCache caches the values of registers for the most recent heights in memory.
+//
+// Unlike RegistersCache, which caches values per requested height, the cache holds all the versions
+// of a register written within the recent heights, and serves Get requests for any of those heights.
+// The cache is kept up to date by Store: the values of cached registers written at a new height are
+// added as new versions, so the registers read by every script (e.g. the FungibleToken and
+// NonFungibleToken contracts) are read from the database only once, and not again at each new height.
+//
+// Registers are added to the cache when they are read at the latest height of the cache, and are evicted
+// by the backing LRU or 2Q cache. Reads outside the recent heights are served from the database.
+type MultiVersionRegistersCache struct {
+	*Registers
+	metrics module.CacheMetrics
+	heights uint64
+
+	mu sync.RWMutex
+	// height is the latest height the cached versions are complete for. It is updated after the
+	// registers of a height are stored, so it can lag behind the latest height of the database.
+	height uint64
+	cache  versionsBackend
+}
+
+var _ storage.PrunableRegisterIndex = (*MultiVersionRegistersCache)(nil)
+
+// NewMultiVersionRegistersCache creates a cache of the values of at most size registers, for the
+// given number of most recent heights, around the given Registers.
+// All registers must be stored through the returned cache to keep it up to date.
+func NewMultiVersionRegistersCache(
+	registers *Registers,
+	cacheType CacheType,
+	size uint,
+	heights uint64,
+	metrics module.CacheMetrics,
+) (*MultiVersionRegistersCache, error) {
+	if size == 0 {
+		return nil, errors.New("cache size cannot be 0")
+	}
+	if heights == 0 {
+		return nil, errors.New("number of cached heights cannot be 0")
+	}
+
+	cache, err := getVersionsCache(cacheType, int(size))
+	if err != nil {
+		return nil, fmt.Errorf("could not create cache: %w", err)
+	}
+
+	c := &MultiVersionRegistersCache{
+		Registers: registers,
+		metrics:   metrics,
+		heights:   heights,
+		height:    registers.LatestHeight(),
+		cache:     cache,
+	}
+	c.metrics.CacheEntries(multiVersionRegisterResourceName, uint(c.cache.Len()))
+
+	return c, nil
+}
+
+// Get returns the most recent updated payload for the given RegisterID.
+// "most recent" means the updates happens most recent up the given height.
+//
+// For example, if there are 2 values stored for register A at height 6 and 11, then
+// GetPayload(13, A) would return the value at height 11.
+//
+// - storage.ErrNotFound if no register values are found
+// - storage.ErrHeightNotIndexed if the requested height is out of the range of stored heights
+func (c *MultiVersionRegistersCache) Get(
+	reg flow.RegisterID,
+	height uint64,
+) (flow.RegisterValue, error) {
+	latestHeight := c.LatestHeight()
+	if height > latestHeight {
+		return nil, fmt.Errorf("height %d not indexed, latestHeight: %d, %w", height, latestHeight, storage.ErrHeightNotIndexed)
+	}
+
+	// values below the first height may have been pruned
+	firstHeight := c.calculateFirstHeight(latestHeight)
+	if height < firstHeight {
+		return nil, fmt.Errorf("height %d not indexed, indexed range: [%d-%d], %w", height, firstHeight, latestHeight, storage.ErrHeightNotIndexed)
+	}
+
+	value, cached := c.cachedValue(reg, height)
+	if cached {
+		c.metrics.CacheHit(multiVersionRegisterResourceName)
+		if value == nil {
+			return nil, storage.ErrNotFound
+		}
+		return value, nil
+	}
+
+	value, err := c.lookupRegister(newLookupKey(height, reg).Bytes())
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.insert(reg, height, nil)
+			c.metrics.CacheNotFound(multiVersionRegisterResourceName)
+		}
+		return nil, fmt.Errorf("could not retrieve register: %w", err)
+	}
+
+	c.metrics.CacheMiss(multiVersionRegisterResourceName)
+	c.insert(reg, height, value)
+
+	return value, nil
+}
+
+// Store stores the given entries at the given height, see Registers.Store, and adds the values of
+// the cached registers as new versions.
+// CAUTION: This function is not safe for concurrent use.
+func (c *MultiVersionRegistersCache) Store(
+	entries flow.RegisterEntries,
+	height uint64,
+) error {
+	err := c.Registers.Store(entries, height)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if height <= c.height {
+		// already stored
+		return nil
+	}
+
+	c.height = height
+	minHeight := c.minHeight()
+
+	for _, entry := range entries {
+		// peek to not count writes as recent use of the register
+		versions, ok := c.cache.Peek(entry.Key)
+		if !ok {
+			continue
+		}
+
+		value := entry.Value
+		if value == nil {
+			// a nil value stored in the database is read back as empty value, nil is reserved for
+			// registers which do not exist.
+			value = flow.RegisterValue{}
+		}
+
+		// drop the versions which are no longer needed to serve the heights of the cache
+		current := *versions
+		for len(current) > 1 && current[1].height <= minHeight {
+			current = current[1:]
+		}
+		*versions = append(current, registerVersion{height: height, value: value})
+	}
+
+	return nil
+}
+
+// cachedValue returns the value of the register at the given height from the cache, and false if it
+// is not cached for the height.
+func (c *MultiVersionRegistersCache) cachedValue(reg flow.RegisterID, height uint64) (flow.RegisterValue, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if height > c.height || height < c.minHeight() {
+		return nil, false
+	}
+
+	versions, ok := c.cache.Get(reg)
+	if !ok {
+		return nil, false
+	}
+	return versions.valueAt(height)
+}
+
+// insert adds the value of the register read from the database at the given height to the cache.
+// The value is only added if the height is the latest height of the cache, as the versions written
+// between an older height and the latest height are not known.
+func (c *MultiVersionRegistersCache) insert(reg flow.RegisterID, height uint64, value flow.RegisterValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if height != c.height || c.cache.Contains(reg) {
+		return
+	}
+
+	c.cache.Add(reg, &registerVersions{{height: height, value: value}})
+	c.metrics.CacheEntries(multiVersionRegisterResourceName, uint(c.cache.Len()))
+}
+
+// minHeight returns the lowest height served by the cache.
+// CAUTION: must be called while holding the lock.
+func (c *MultiVersionRegistersCache) minHeight() uint64 {
+	if c.height < c.heights {
+		return 0
+	}
+	return c.height - c.heights + 1
+}
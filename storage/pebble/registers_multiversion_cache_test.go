@@ -0,0 +1,200 @@
+package pebble
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	modulemock "github.com/onflow/flow-go/module/mock"
+	"github.com/onflow/flow-go/storage"
+)
+
+// TestMultiVersionRegistersCache_Get tests that registers read at the latest height are served from
+// the cache for all the recent heights, and kept up to date as new heights are stored.
+func TestMultiVersionRegistersCache_Get(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+		key2 := flow.RegisterID{Owner: "owner", Key: "key2"}
+		missing := flow.RegisterID{Owner: "owner", Key: "missing"}
+
+		collector := modulemock.NewCacheMetrics(t)
+		collector.On("CacheEntries", multiVersionRegisterResourceName, mock.Anything)
+
+		cache, err := NewMultiVersionRegistersCache(r, CacheTypeLRU, 10, 3, collector)
+		require.NoError(t, err)
+
+		// height 2: key1 = a, key2 = x
+		require.NoError(t, cache.Store(flow.RegisterEntries{
+			{Key: key1, Value: []byte("a")},
+			{Key: key2, Value: []byte("x")},
+		}, 2))
+
+		// the first reads at the latest height are misses
+		collector.On("CacheMiss", multiVersionRegisterResourceName).Times(1)
+		collector.On("CacheNotFound", multiVersionRegisterResourceName).Times(1)
+		value, err := cache.Get(key1, 2)
+		require.NoError(t, err)
+		require.Equal(t, flow.RegisterValue("a"), value)
+		_, err = cache.Get(missing, 2)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		collector.AssertExpectations(t)
+
+		// height 3: key1 = b, key2 = y
+		// height 4: key2 = z
+		// height 5: key1 = c, missing = m
+		require.NoError(t, cache.Store(flow.RegisterEntries{
+			{Key: key1, Value: []byte("b")},
+			{Key: key2, Value: []byte("y")},
+		}, 3))
+		require.NoError(t, cache.Store(flow.RegisterEntries{{Key: key2, Value: []byte("z")}}, 4))
+		require.NoError(t, cache.Store(flow.RegisterEntries{
+			{Key: key1, Value: []byte("c")},
+			{Key: missing, Value: []byte("m")},
+		}, 5))
+
+		// the cached registers are served for the recent heights [3, 5] without reading the database
+		collector.On("CacheHit", multiVersionRegisterResourceName).Times(6)
+		for height, expected := range map[uint64]string{3: "b", 4: "b", 5: "c"} {
+			value, err := cache.Get(key1, height)
+			require.NoError(t, err)
+			require.Equal(t, flow.RegisterValue(expected), value, "height %d", height)
+		}
+		for height, expected := range map[uint64]string{4: "", 5: "m"} {
+			value, err := cache.Get(missing, height)
+			if expected == "" {
+				require.ErrorIs(t, err, storage.ErrNotFound)
+				continue
+			}
+			require.NoError(t, err)
+			require.Equal(t, flow.RegisterValue(expected), value, "height %d", height)
+		}
+		_, err = cache.Get(missing, 3)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		collector.AssertExpectations(t)
+
+		// older heights and registers which are not cached are read from the database
+		collector.On("CacheMiss", multiVersionRegisterResourceName).Times(3)
+		value, err = cache.Get(key1, 2)
+		require.NoError(t, err)
+		require.Equal(t, flow.RegisterValue("a"), value)
+		value, err = cache.Get(key2, 4)
+		require.NoError(t, err)
+		require.Equal(t, flow.RegisterValue("z"), value)
+		value, err = cache.Get(key2, 5)
+		require.NoError(t, err)
+		require.Equal(t, flow.RegisterValue("z"), value)
+		collector.AssertExpectations(t)
+
+		// heights above the latest height are not indexed
+		_, err = cache.Get(key1, 6)
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+	})
+}
+
+// TestMultiVersionRegistersCache_Store tests that storing a height is idempotent, and that the cache is
+// not updated if storing fails.
+func TestMultiVersionRegistersCache_Store(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+
+		cache, err := NewMultiVersionRegistersCache(r, CacheTypeTwoQueue, 10, 10, metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		require.NoError(t, cache.Store(flow.RegisterEntries{{Key: key1, Value: []byte("a")}}, 2))
+		value, err := cache.Get(key1, 2)
+		require.NoError(t, err)
+		require.Equal(t, flow.RegisterValue("a"), value)
+
+		// idempotent at same height
+		require.NoError(t, cache.Store(flow.RegisterEntries{{Key: key1, Value: []byte("a")}}, 2))
+		versions, ok := cache.cache.Peek(key1)
+		require.True(t, ok)
+		require.Len(t, *versions, 1)
+
+		// out of range
+		require.Error(t, cache.Store(flow.RegisterEntries{{Key: key1, Value: []byte("b")}}, 4))
+		value, err = cache.Get(key1, 2)
+		require.NoError(t, err)
+		require.Equal(t, flow.RegisterValue("a"), value)
+
+		// a nil value is read back as empty value, as from the database
+		require.NoError(t, cache.Store(flow.RegisterEntries{{Key: key1, Value: nil}}, 3))
+		value, err = cache.Get(key1, 3)
+		require.NoError(t, err)
+		require.Equal(t, flow.RegisterValue{}, value)
+		dbValue, err := r.Get(key1, 3)
+		require.NoError(t, err)
+		require.Equal(t, dbValue, value)
+	})
+}
+
+// TestMultiVersionRegistersCache_Concurrent tests that concurrent reads always return the values stored
+// in the database while new heights are stored.
+func TestMultiVersionRegistersCache_Concurrent(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		keys := make([]flow.RegisterID, 10)
+		for i := range keys {
+			keys[i] = flow.RegisterID{Owner: "owner", Key: fmt.Sprintf("key%d", i)}
+		}
+		// the value of a register at a height is the height it was last written at
+		valueAt := func(i int, height uint64) flow.RegisterValue {
+			written := height - height%uint64(i+1)
+			if written < 2 {
+				return nil
+			}
+			return []byte(fmt.Sprintf("%d", written))
+		}
+
+		cache, err := NewMultiVersionRegistersCache(r, CacheTypeLRU, 5, 4, metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		const latestHeight = 100
+		done := make(chan struct{})
+		wg := sync.WaitGroup{}
+		for w := 0; w < 4; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					height := cache.LatestHeight()
+					for i, key := range keys {
+						expected := valueAt(i, height)
+						value, err := cache.Get(key, height)
+						if expected == nil {
+							assert.ErrorIs(t, err, storage.ErrNotFound)
+							continue
+						}
+						assert.NoError(t, err)
+						assert.Equal(t, expected, value, "key %d at height %d", i, height)
+					}
+				}
+			}()
+		}
+
+		for height := uint64(2); height <= latestHeight; height++ {
+			var entries flow.RegisterEntries
+			for i, key := range keys {
+				if height%uint64(i+1) == 0 {
+					entries = append(entries, flow.RegisterEntry{Key: key, Value: valueAt(i, height)})
+				}
+			}
+			require.NoError(t, cache.Store(entries, height))
+		}
+		close(done)
+		wg.Wait()
+	})
+}
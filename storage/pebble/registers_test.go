@@ -2,12 +2,15 @@ package pebble
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"iter"
 	"math/rand"
 	"os"
 	"path"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/pkg/errors"
@@ -15,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/storage/pebble/registers"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -210,6 +214,291 @@ func TestRegisters_Store_Versioning(t *testing.T) {
 	})
 }
 
+// TestRegisters_History tests that the history of a register and of an owner contains the values written
+// within the height range, from the most recent to the oldest value.
+func TestRegisters_History(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		// key11 has key1 as a prefix, and must not be part of the history of key1
+		key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+		key11 := flow.RegisterID{Owner: "owner", Key: "key11"}
+		key2 := flow.RegisterID{Owner: "owner", Key: "key2"}
+		other := flow.RegisterID{Owner: "owner2", Key: "key1"}
+
+		for height := uint64(2); height <= 6; height++ {
+			entries := flow.RegisterEntries{
+				{Key: other, Value: []byte(fmt.Sprintf("other-%d", height))},
+			}
+			if height%2 == 0 {
+				entries = append(entries, flow.RegisterEntry{Key: key1, Value: []byte(fmt.Sprintf("value1-%d", height))})
+			}
+			if height == 3 {
+				entries = append(entries,
+					flow.RegisterEntry{Key: key11, Value: []byte("value11-3")},
+					flow.RegisterEntry{Key: key2, Value: []byte("value2-3")},
+				)
+			}
+			require.NoError(t, r.Store(entries, height))
+		}
+
+		collect := func(history iter.Seq2[flow.RegisterChange, error]) ([]flow.RegisterChange, error) {
+			var changes []flow.RegisterChange
+			for change, err := range history {
+				if err != nil {
+					return nil, err
+				}
+				changes = append(changes, change)
+			}
+			return changes, nil
+		}
+
+		changes, err := collect(r.History(key1, 1, 6))
+		require.NoError(t, err)
+		assert.Equal(t, []flow.RegisterChange{
+			{Key: key1, Height: 6, Value: []byte("value1-6")},
+			{Key: key1, Height: 4, Value: []byte("value1-4")},
+			{Key: key1, Height: 2, Value: []byte("value1-2")},
+		}, changes)
+
+		// bounds are inclusive
+		changes, err = collect(r.History(key1, 4, 5))
+		require.NoError(t, err)
+		assert.Equal(t, []flow.RegisterChange{
+			{Key: key1, Height: 4, Value: []byte("value1-4")},
+		}, changes)
+
+		changes, err = collect(r.History(key2, 4, 6))
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+
+		changes, err = collect(r.OwnerHistory("owner", 3, 4, nil))
+		require.NoError(t, err)
+		assert.Equal(t, []flow.RegisterChange{
+			{Key: key1, Height: 4, Value: []byte("value1-4")},
+			{Key: key11, Height: 3, Value: []byte("value11-3")},
+			{Key: key2, Height: 3, Value: []byte("value2-3")},
+		}, changes)
+
+		// stopping the iteration early
+		for change, err := range r.OwnerHistory("owner", 1, 6, nil) {
+			require.NoError(t, err)
+			assert.Equal(t, key1, change.Key)
+			assert.Equal(t, uint64(6), change.Height)
+			break
+		}
+
+		// the iteration starts at the cursor, which may point into the middle of the values of a register
+		changes, err = collect(r.OwnerHistory("owner", 2, 6, &flow.RegisterHistoryCursor{Key: key1.Key, Height: 4}))
+		require.NoError(t, err)
+		assert.Equal(t, []flow.RegisterChange{
+			{Key: key1, Height: 4, Value: []byte("value1-4")},
+			{Key: key1, Height: 2, Value: []byte("value1-2")},
+			{Key: key11, Height: 3, Value: []byte("value11-3")},
+			{Key: key2, Height: 3, Value: []byte("value2-3")},
+		}, changes)
+
+		changes, err = collect(r.OwnerHistory("owner", 2, 6, &flow.RegisterHistoryCursor{Key: key2.Key, Height: 3}))
+		require.NoError(t, err)
+		assert.Equal(t, []flow.RegisterChange{
+			{Key: key2, Height: 3, Value: []byte("value2-3")},
+		}, changes)
+
+		_, err = collect(r.History(key1, 1, 7))
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+		_, err = collect(r.OwnerHistory("owner", 0, 6, nil))
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+		_, err = collect(r.History(key1, 5, 4))
+		require.Error(t, err)
+	})
+}
+
+// TestRegisters_OwnerHistory_HeightRange tests that the owner history skips the values written outside
+// of the height range, for registers with values above, within and below the range.
+func TestRegisters_OwnerHistory_HeightRange(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		registerIDs := []flow.RegisterID{
+			{Owner: "owner", Key: "a"},
+			{Owner: "owner", Key: "b"},
+			{Owner: "owner", Key: "c"},
+		}
+
+		// every register is written at every height
+		for height := uint64(2); height <= 100; height++ {
+			entries := make(flow.RegisterEntries, len(registerIDs))
+			for i, registerID := range registerIDs {
+				entries[i] = flow.RegisterEntry{Key: registerID, Value: []byte(fmt.Sprintf("%s-%d", registerID.Key, height))}
+			}
+			require.NoError(t, r.Store(entries, height))
+		}
+
+		var changes []flow.RegisterChange
+		for change, err := range r.OwnerHistory("owner", 50, 51, nil) {
+			require.NoError(t, err)
+			changes = append(changes, change)
+		}
+
+		var expected []flow.RegisterChange
+		for _, registerID := range registerIDs {
+			for _, height := range []uint64{51, 50} {
+				expected = append(expected, flow.RegisterChange{
+					Key:    registerID,
+					Height: height,
+					Value:  []byte(fmt.Sprintf("%s-%d", registerID.Key, height)),
+				})
+			}
+		}
+		assert.Equal(t, expected, changes)
+	})
+}
+
+// TestRegisters_PruneUpToHeight tests that pruning removes the values which are not needed to serve
+// reads at or above the prune height, and advances the first height.
+func TestRegisters_PruneUpToHeight(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key1 := flow.RegisterID{Owner: "owner", Key: "key1"}
+		key11 := flow.RegisterID{Owner: "owner", Key: "key11"}
+		key2 := flow.RegisterID{Owner: "owner", Key: "key2"}
+
+		// key1 is updated at every height, key11 only at height 2, and key2 at heights 2 and 9
+		for height := uint64(2); height <= 10; height++ {
+			entries := flow.RegisterEntries{
+				{Key: key1, Value: []byte(fmt.Sprintf("value1-%d", height))},
+			}
+			if height == 2 {
+				entries = append(entries, flow.RegisterEntry{Key: key11, Value: []byte("value11")})
+			}
+			if height == 2 || height == 9 {
+				entries = append(entries, flow.RegisterEntry{Key: key2, Value: []byte(fmt.Sprintf("value2-%d", height))})
+			}
+			require.NoError(t, r.Store(entries, height))
+		}
+
+		// pruning above the latest height fails
+		_, err := r.PruneUpToHeight(context.Background(), 11, 2, 0)
+		require.Error(t, err)
+
+		// key1 values at heights 2-5 are removed, the key11 and key2 values at height 2 are
+		// the most recent values at height 6, so they are retained
+		pruned, err := r.PruneUpToHeight(context.Background(), 6, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), pruned)
+		assert.Equal(t, uint64(6), r.FirstHeight())
+
+		_, err = r.Get(key1, 5)
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+		for height := uint64(6); height <= 10; height++ {
+			value, err := r.Get(key1, height)
+			require.NoError(t, err)
+			assert.Equal(t, []byte(fmt.Sprintf("value1-%d", height)), value)
+
+			value, err = r.Get(key11, height)
+			require.NoError(t, err)
+			assert.Equal(t, []byte("value11"), value)
+		}
+
+		value, err := r.Get(key2, 8)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value2-2"), value)
+
+		value, err = r.Get(key2, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value2-9"), value)
+
+		// pruning below the first height is a no-op
+		pruned, err = r.PruneUpToHeight(context.Background(), 5, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), pruned)
+
+		// key1 values at heights 6-9 and the key2 value at height 2 are removed
+		pruned, err = r.PruneUpToHeight(context.Background(), 10, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), pruned)
+
+		value, err = r.Get(key2, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value2-9"), value)
+
+		// the first height is persisted
+		reopened, err := NewRegisters(r.db, PruningDisabled)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), reopened.FirstHeight())
+	})
+}
+
+// TestRegistersCache_PruneUpToHeight tests that the register cache fails reads below the pruned height,
+// instead of reporting registers whose values were removed as not set.
+func TestRegistersCache_PruneUpToHeight(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key := flow.RegisterID{Owner: "owner", Key: "key"}
+		for height := uint64(2); height <= 10; height++ {
+			var entries flow.RegisterEntries
+			if height == 2 || height == 4 {
+				entries = flow.RegisterEntries{{Key: key, Value: []byte(fmt.Sprintf("value-%d", height))}}
+			}
+			require.NoError(t, r.Store(entries, height))
+		}
+
+		cache, err := NewRegistersCache(r, CacheTypeLRU, 10, metrics.NewNoopCollector())
+		require.NoError(t, err)
+
+		value, err := cache.Get(key, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value-2"), value)
+
+		// the value at height 2 is removed, the value at height 4 is retained
+		_, err = r.PruneUpToHeight(context.Background(), 6, 2, 0)
+		require.NoError(t, err)
+
+		// cached and uncached reads below the first height both fail
+		_, err = cache.Get(key, 2)
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+		_, err = cache.Get(key, 3)
+		require.ErrorIs(t, err, storage.ErrHeightNotIndexed)
+
+		value, err = cache.Get(key, 6)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value-4"), value)
+	})
+}
+
+// TestRegisters_PruneUpToHeight_Canceled tests that pruning stops when the context is canceled,
+// and that the remaining values are removed by the next call.
+func TestRegisters_PruneUpToHeight_Canceled(t *testing.T) {
+	t.Parallel()
+	RunWithRegistersStorageAtHeight1(t, func(r *Registers) {
+		key := flow.RegisterID{Owner: "owner", Key: "key"}
+		for height := uint64(2); height <= 10; height++ {
+			entries := flow.RegisterEntries{
+				{Key: key, Value: []byte(fmt.Sprintf("value-%d", height))},
+			}
+			require.NoError(t, r.Store(entries, height))
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		pruned, err := r.PruneUpToHeight(ctx, 10, 2, time.Hour)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, uint64(2), pruned)
+		assert.Equal(t, uint64(10), r.FirstHeight())
+
+		// the remaining values are removed by the next call
+		pruned, err = r.PruneUpToHeight(context.Background(), 10, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(6), pruned)
+
+		value, err := r.Get(key, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value-10"), value)
+	})
+}
+
 // TestRegisters_GetAndStoreEmptyOwner tests behavior of storing and retrieving registers with
 // an empty owner value, which is used for global state variables.
 func TestRegisters_GetAndStoreEmptyOwner(t *testing.T) {
@@ -1,6 +1,10 @@
 package storage
 
 import (
+	"context"
+	"iter"
+	"time"
+
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -21,6 +25,25 @@ type RegisterIndex interface {
 	// FirstHeight at which we started to index. Returns the first indexed height found in the store.
 	FirstHeight() uint64
 
+	// History iterates over the values written to the register within the height range
+	// [fromHeight, toHeight], from the most recent to the oldest value. The value in effect at fromHeight
+	// is only included if it was written at fromHeight.
+	//
+	// The iteration stops after the first error, which is yielded together with an empty change.
+	// Expected errors:
+	// - storage.ErrHeightNotIndexed if the height range is not within the indexed heights.
+	History(ID flow.RegisterID, fromHeight, toHeight uint64) iter.Seq2[flow.RegisterChange, error]
+
+	// OwnerHistory iterates over the values written to all registers of the owner within the height
+	// range [fromHeight, toHeight]. Changes are grouped by register, in the order of the register keys,
+	// and ordered from the most recent to the oldest value within each register.
+	// If cursor is not nil, the iteration starts at the change the cursor points to.
+	//
+	// The iteration stops after the first error, which is yielded together with an empty change.
+	// Expected errors:
+	// - storage.ErrHeightNotIndexed if the height range is not within the indexed heights.
+	OwnerHistory(owner string, fromHeight, toHeight uint64, cursor *flow.RegisterHistoryCursor) iter.Seq2[flow.RegisterChange, error]
+
 	// Store batch of register entries at the provided block height.
 	//
 	// The provided height must either be one higher than the current height or the same to ensure idempotency,
@@ -30,3 +53,22 @@ type RegisterIndex interface {
 	// No errors are expected during normal operation.
 	Store(entries flow.RegisterEntries, height uint64) error
 }
+
+// PrunableRegisterIndex is a register index whose register values below a height can be removed.
+type PrunableRegisterIndex interface {
+	RegisterIndex
+
+	// PruneUpToHeight removes the register values which are no longer needed to serve reads at or above
+	// pruneHeight, and advances the first height to pruneHeight. For each register, the most recent value
+	// at or below pruneHeight is kept.
+	//
+	// Values are removed in batches of batchSize, sleeping for sleepAfterEachBatch after each batch.
+	// Returns the number of removed values.
+	//
+	// Pruning below the first height is a no-op. Pruning at the first height removes the values
+	// left over by an interrupted call.
+	//
+	// Expected errors:
+	// - context.Canceled or context.DeadlineExceeded if ctx is done before all values are removed.
+	PruneUpToHeight(ctx context.Context, pruneHeight uint64, batchSize uint, sleepAfterEachBatch time.Duration) (uint64, error)
+}
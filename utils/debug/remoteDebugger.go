@@ -39,10 +39,12 @@ func NewRemoteDebugger(
 }
 
 // RunTransaction runs the transaction using the given storage snapshot.
+// The options are applied on top of the context of the debugger.
 func (d *RemoteDebugger) RunTransaction(
 	txBody *flow.TransactionBody,
 	snapshot StorageSnapshot,
 	blockHeader *flow.Header,
+	options ...fvm.Option,
 ) (
 	resultSnapshot *snapshot.ExecutionSnapshot,
 	txErr error,
@@ -50,7 +52,7 @@ func (d *RemoteDebugger) RunTransaction(
 ) {
 	blockCtx := fvm.NewContextFromParent(
 		d.ctx,
-		fvm.WithBlockHeader(blockHeader))
+		append([]fvm.Option{fvm.WithBlockHeader(blockHeader)}, options...)...)
 
 	tx := fvm.Transaction(txBody, 0)
 